@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+)
+
+// operationCooldown is how long after Argo CD accepted a sync or rollback of
+// an app another one of the same kind is refused, so a hasty second
+// keypress doesn't fire a duplicate. It is measured with the wall clock, not
+// clockNow, so it still ends in deterministic mode.
+const operationCooldown = 10 * time.Second
+
+// operationKey identifies a kind of operation on an app. Apps picked by name
+// only, as in a multi-selection, are resolved to their namespace so they
+// match the same app picked from the cursor.
+func (m *Model) operationKey(kind, appName string, appNamespace *string) string {
+	if appNamespace == nil {
+		if app := m.findAppByNameAndNamespace(appName, ""); app != nil {
+			appNamespace = app.AppNamespace
+		}
+	}
+	return kind + ":" + appKey(appName, appNamespace)
+}
+
+// rememberRequestedOperation records that Argo CD accepted a sync or rollback
+func (m *Model) rememberRequestedOperation(kind, appName string, appNamespace *string) {
+	if m.requestedOps == nil {
+		m.requestedOps = make(map[string]time.Time)
+	}
+	m.requestedOps[m.operationKey(kind, appName, appNamespace)] = time.Now()
+}
+
+// recentlyRequested returns how long ago the same operation was requested
+// for the app, if that was within the cooldown
+func (m *Model) recentlyRequested(kind, appName string, appNamespace *string) (time.Duration, bool) {
+	at, ok := m.requestedOps[m.operationKey(kind, appName, appNamespace)]
+	if !ok {
+		return 0, false
+	}
+	ago := time.Since(at)
+	if ago >= operationCooldown {
+		return 0, false
+	}
+	return ago, true
+}
+
+// refuseRepeatedOperation says the operation was just requested, when it
+// was; the caller stops there instead of starting it again
+func (m *Model) refuseRepeatedOperation(kind, appName string, appNamespace *string) (tea.Cmd, bool) {
+	ago, ok := m.recentlyRequested(kind, appName, appNamespace)
+	if !ok {
+		return nil, false
+	}
+	cblog.With("component", kind).Info("Refusing repeated operation", "app", appName, "ago", ago)
+	text := repeatedOperationText(kind, appName, ago)
+	m.statusService.Set(text)
+	return m.showStatusNote(text), true
+}
+
+// repeatedOperationText says an operation was requested ago, e.g. "Sync of
+// web already requested 3s ago"
+func repeatedOperationText(kind, appName string, ago time.Duration) string {
+	secs := max(1, int(ago.Seconds()))
+	return fmt.Sprintf("%s of %s already requested %ds ago", strings.ToUpper(kind[:1])+kind[1:], appName, secs)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestOperationCooldown_RefusesRepeatedSync(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Success: true, SwitchEpoch: m.switchEpoch})
+
+	_, cmd := m.handleSyncModal()
+	if m.state.Mode == model.ModeConfirmSync || m.state.Modals.ConfirmTarget != nil {
+		t.Fatal("a sync just accepted should not be offered again")
+	}
+	if cmd == nil || !strings.Contains(m.state.UI.StatusNote, "Sync of test-app already requested 1s ago") {
+		t.Errorf("the refusal should say when the sync was requested, got %q", m.state.UI.StatusNote)
+	}
+}
+
+func TestOperationCooldown_ExpiresAndSkipsFailures(t *testing.T) {
+	// The frozen on-screen clock of e2e runs must not hold the cooldown open
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+	m.requestedOps = map[string]time.Time{
+		m.operationKey("sync", "test-app", nil): time.Now().Add(-operationCooldown),
+	}
+	m.handleSyncModal()
+	if m.state.Mode != model.ModeConfirmSync {
+		t.Fatal("after the cooldown the sync should be offered again")
+	}
+
+	m = buildDeleteTestModel(120, 30)
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", Success: false, SwitchEpoch: m.switchEpoch})
+	m.handleSyncModal()
+	if m.state.Mode != model.ModeConfirmSync {
+		t.Error("a sync that was not accepted should not start the cooldown")
+	}
+}
+
+func TestOperationCooldown_MultiSelectionMatchesByNamespace(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.rememberRequestedOperation("sync", "test-app", m.state.Apps[0].AppNamespace)
+	m.state.Selections.SelectedApps = model.NewStringSet()
+	m.state.Selections.SelectedApps["test-app"] = true
+	m.state.Selections.SelectedApps["zzz-other-app"] = true
+
+	m.handleSyncModal()
+	if m.state.Mode == model.ModeConfirmSync || m.state.Modals.ConfirmTarget != nil {
+		t.Error("a selection containing a just-synced app should be refused")
+	}
+}
+
+func TestOperationCooldown_RepeatedConfirmSendsOnce(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleSyncModal()
+	if _, cmd := m.handleConfirmSyncKeys(keyPress("y")); cmd == nil || !m.state.Modals.ConfirmSyncLoading {
+		t.Fatal("the first confirm should send the sync")
+	}
+	if _, cmd := m.handleConfirmSyncKeys(keyPress("enter")); cmd != nil {
+		t.Error("a second confirm while the sync is being sent should do nothing")
+	}
+}
+
+func TestOperationCooldown_RefusesRepeatedRollback(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(model.RollbackExecutedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Success: true})
+
+	m.handleRollback()
+	if m.state.Mode == model.ModeRollback {
+		t.Fatal("a rollback just accepted should not be offered again")
+	}
+	if !strings.Contains(m.state.UI.StatusNote, "Rollback of test-app already requested") {
+		t.Errorf("got %q", m.state.UI.StatusNote)
+	}
+	if _, ok := m.recentlyRequested("sync", "test-app", nil); ok {
+		t.Error("the cooldown is per kind of operation")
+	}
+}
@@ -55,7 +55,12 @@ func (m *Model) startLoadingApplications() tea.Cmd {
 					return model.AuthErrorMsg{Error: argErr, SwitchEpoch: epoch}
 				}
 				// Surface structured errors so error view can show details/context
-				return model.StructuredErrorMsg{Error: argErr, SwitchEpoch: epoch}
+				return model.StructuredErrorMsg{
+					Error:       argErr,
+					Context:     map[string]interface{}{"operation": retryLoadApps},
+					Retry:       true,
+					SwitchEpoch: epoch,
+				}
 			}
 			// Fallback string matching
 			if isAuthenticationError(err.Error()) {
@@ -83,14 +88,15 @@ type watchStartedMsg struct {
 }
 
 // watchScopeDebounce is the delay before a scoped watch restart fires after a
-// scope change. Overridable via ARGONAUT_WATCH_SCOPE_DEBOUNCE for tests.
+// scope change. Overridable via ARGONAUT_WATCH_SCOPE_DEBOUNCE for tests;
+// zero in deterministic mode unless overridden.
 var watchScopeDebounce = func() time.Duration {
 	if v := os.Getenv("ARGONAUT_WATCH_SCOPE_DEBOUNCE"); v != "" {
 		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
 			return d
 		}
 	}
-	return 500 * time.Millisecond
+	return debounceDelay(500 * time.Millisecond)
 }()
 
 // watchBatchDrain is the maximum time the watch-event consumer batches
@@ -154,6 +160,9 @@ func (m *Model) startWatchingApplicationsWithConfig(projects []string, generatio
 	if m.state.Server == nil {
 		return nil
 	}
+	if !m.config.IsStreamEnabled() {
+		return m.startAppsPolling()
+	}
 
 	m.watchStartSequence++
 	startSeq := m.watchStartSequence
@@ -192,7 +201,12 @@ func (m *Model) startWatchingApplicationsWithConfig(projects []string, generatio
 				if hasHTTPStatusCtx(argErr, 401, 403) || argErr.IsCategory(apperrors.ErrorAuth) || argErr.IsCode("UNAUTHORIZED") || argErr.IsCode("AUTHENTICATION_FAILED") {
 					return model.AuthErrorMsg{Error: err, SwitchEpoch: epoch}
 				}
-				return model.StructuredErrorMsg{Error: argErr, SwitchEpoch: epoch}
+				return model.StructuredErrorMsg{
+					Error:       argErr,
+					Context:     map[string]interface{}{"operation": retryWatch},
+					Retry:       true,
+					SwitchEpoch: epoch,
+				}
 			}
 			if isAuthenticationError(err.Error()) {
 				return model.AuthErrorMsg{Error: err, SwitchEpoch: epoch}
@@ -212,11 +226,14 @@ func (m *Model) startWatchingApplicationsWithConfig(projects []string, generatio
 	}
 }
 
-// fetchAPIVersion fetches the ArgoCD API version and updates state
+// fetchAPIVersion fetches the ArgoCD API version so it can be shown and
+// checked against the supported releases. Failures are logged and leave the
+// version unknown, which turns nothing off.
 func (m *Model) fetchAPIVersion() tea.Cmd {
 	if m.state.Server == nil {
 		return nil
 	}
+	epoch := m.switchEpoch   // capture at call time
 	server := m.state.Server // capture at call time
 	return func() tea.Msg {
 		ctx, cancel := appcontext.WithAPITimeout(context.Background())
@@ -224,9 +241,31 @@ func (m *Model) fetchAPIVersion() tea.Cmd {
 		apiService := services.NewArgoApiService(server)
 		v, err := apiService.GetAPIVersion(ctx, server)
 		if err != nil {
-			return model.StatusChangeMsg{Status: "Version: unknown"}
+			cblog.With("component", "compat").Warn("Could not read the server version", "err", err)
+			return nil
+		}
+		return model.SetAPIVersionMsg{Version: v, SwitchEpoch: epoch}
+	}
+}
+
+// fetchHealthCustomizations reads the server's resource overrides so the tree
+// view can tell custom Lua health checks apart from built-in ones. Failures are
+// logged and otherwise ignored; health sources then fall back to built-in.
+func (m *Model) fetchHealthCustomizations() tea.Cmd {
+	epoch := m.switchEpoch
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		settings, err := api.NewApplicationService(server).GetSettings(ctx)
+		if err != nil {
+			cblog.With("component", "settings").Debug("Could not load resource overrides", "err", err)
+			return nil
 		}
-		return model.SetAPIVersionMsg{Version: v}
+		return model.HealthCustomizationsLoadedMsg{Keys: settings.CustomHealthKeys(), SwitchEpoch: epoch}
 	}
 }
 
@@ -234,6 +273,7 @@ func (m *Model) fetchAPIVersion() tea.Cmd {
 type eventResult struct {
 	update     *model.AppUpdatedMsg // non-nil for app-updated events
 	deleteName string               // non-empty for app-deleted events
+	deleteSeq  uint64               // stream log entry of an app-deleted event
 	immediate  tea.Msg              // non-nil for non-batchable events (auth-error, api-error, etc.)
 }
 
@@ -246,8 +286,9 @@ func (r eventResult) toBatchOperation() (model.AppBatchOperation, bool) {
 	}
 	if r.deleteName != "" {
 		return model.AppBatchOperation{
-			Type:   model.AppBatchOperationDelete,
-			Delete: r.deleteName,
+			Type:            model.AppBatchOperationDelete,
+			Delete:          r.deleteName,
+			DeleteStreamSeq: r.deleteSeq,
 		}, true
 	}
 	return model.AppBatchOperation{}, false
@@ -265,11 +306,11 @@ func classifyWatchEvent(ev services.ArgoApiEvent, epoch int) eventResult {
 			if len(ev.Resources) > 0 {
 				resourcesData, _ = json.Marshal(ev.Resources)
 			}
-			return eventResult{update: &model.AppUpdatedMsg{App: *ev.App, ResourcesJSON: resourcesData}}
+			return eventResult{update: &model.AppUpdatedMsg{App: *ev.App, ResourcesJSON: resourcesData, StreamSeq: ev.StreamSeq}}
 		}
 	case "app-deleted":
 		if ev.AppName != "" {
-			return eventResult{deleteName: ev.AppName}
+			return eventResult{deleteName: ev.AppName, deleteSeq: ev.StreamSeq}
 		}
 	case "apps-loaded":
 		if ev.Apps != nil {
@@ -498,79 +539,150 @@ func (m *Model) startDiffSession(appName string, appNamespace *string) tea.Cmd {
 	}
 	epoch := m.switchEpoch   // capture at call time
 	server := m.state.Server // capture at call time
+	prefetch := m.prefetch
+	cache := m.diffCache
+	revisions := ""
+	if app := m.findAppByNameAndNamespace(appName, derefOr(appNamespace)); app != nil {
+		revisions = diffCacheRevisions(*app, m.config)
+	}
 	return func() tea.Msg {
 		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 45*time.Second)
 		defer cancel()
 
-		apiService := services.NewArgoApiService(server)
-		diffs, err := apiService.GetResourceDiffs(ctx, server, appName, appNamespace)
+		sections, err := loadAppDiff(ctx, server, prefetch, cache, appName, appNamespace, revisions)
+		if tooLarge := responseTooLarge(err); tooLarge != nil {
+			return deferredDiffOutline(ctx, services.NewArgoApiService(server), server, appName, appNamespace, tooLarge, epoch)
+		}
 		if err != nil {
 			return model.ApiErrorMsg{Message: "Failed to load diffs: " + err.Error(), SwitchEpoch: epoch}
 		}
+		return m.diffSectionsMsg(appName, appNamespace, sections, epoch)
+	}
+}
 
-		normalizedDocs := make([]string, 0)
-		predictedDocs := make([]string, 0)
-		for _, d := range diffs {
-			// Filter out hook resources (like ArgoCD UI does)
-			if d.Hook {
-				continue
-			}
-
-			// Use NormalizedLiveState and PredictedLiveState as per ArgoCD spec
-			normalizedYAML := ""
-			predictedYAML := ""
-
-			if d.NormalizedLiveState != "" {
-				normalizedYAML = cleanManifestToYAML(d.NormalizedLiveState)
-			}
-			if d.PredictedLiveState != "" {
-				predictedYAML = cleanManifestToYAML(d.PredictedLiveState)
-			}
+// loadAppDiff returns the app's diff sections, from the diff cache when it
+// holds them for the revision pair. Runs inside a tea.Cmd goroutine.
+func loadAppDiff(ctx context.Context, server *model.Server, prefetch *appPrefetcher, cache *diffCache, appName string, appNamespace *string, revisions string) ([]model.DiffSection, error) {
+	key := appKey(appName, appNamespace)
+	if sections, cached := cache.get(key, revisions); cached {
+		cblog.With("component", "diff").Debug("Reusing cached diff", "app", appName, "revisions", revisions)
+		return sections, nil
+	}
+	apiService := services.NewArgoApiService(server)
+	diffs, err := prefetched(ctx, prefetch, key, prefetchDiffs, func(ctx context.Context) ([]services.ResourceDiff, error) {
+		return apiService.GetResourceDiffs(ctx, server, appName, appNamespace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sections := buildDiffSections(diffs)
+	cache.put(key, revisions, sections)
+	return sections, nil
+}
 
-			// Filter out resources with identical states (like ArgoCD UI does)
-			if normalizedYAML == predictedYAML {
-				continue
-			}
+// diffSectionsMsg opens an app's diff: the outline when several resources
+// changed, otherwise the pager. Runs inside a tea.Cmd goroutine.
+func (m *Model) diffSectionsMsg(appName string, appNamespace *string, sections []model.DiffSection, epoch int) tea.Msg {
+	if len(sections) == 0 {
+		return model.SetModeMsg{Mode: model.ModeNoDiff}
+	}
 
-			if normalizedYAML != "" {
-				normalizedDocs = append(normalizedDocs, normalizedYAML)
-			}
-			if predictedYAML != "" {
-				predictedDocs = append(predictedDocs, predictedYAML)
-			}
+	// Several changed resources: let the user pick which one to look at
+	// rather than dropping them into one long concatenated diff.
+	if len(sections) > 1 {
+		return model.DiffOutlineLoadedMsg{
+			AppName:      appName,
+			AppNamespace: appNamespace,
+			Sections:     sections,
+			SwitchEpoch:  epoch,
 		}
+	}
 
-		if len(normalizedDocs) == 0 && len(predictedDocs) == 0 {
-			return model.SetModeMsg{Mode: model.ModeNoDiff}
+	return m.showDiffSections(appName, sections, epoch)
+}
+
+// buildDiffSections converts the server-side resource diffs into outline
+// sections, skipping hooks and resources whose states are identical.
+func buildDiffSections(diffs []services.ResourceDiff) []model.DiffSection {
+	sections := make([]model.DiffSection, 0, len(diffs))
+	for _, d := range diffs {
+		// Filter out hook resources (like ArgoCD UI does)
+		if d.Hook {
+			continue
 		}
 
-		leftFile, _ := writeTempYAML("current-", normalizedDocs)
-		rightFile, _ := writeTempYAML("predicted-", predictedDocs)
+		// Use NormalizedLiveState and PredictedLiveState as per ArgoCD spec
+		normalizedYAML := ""
+		predictedYAML := ""
 
-		// Build raw unified diff via git (no color so delta can format it)
-		cmd := exec.Command("git", "--no-pager", "diff", "--no-index", "--no-color", "--", leftFile, rightFile)
-		out, err := cmd.CombinedOutput()
-		if err != nil && cmd.ProcessState != nil && cmd.ProcessState.ExitCode() != 1 {
-			return model.ApiErrorMsg{Message: "Diff failed: " + err.Error(), SwitchEpoch: epoch}
+		if d.NormalizedLiveState != "" {
+			normalizedYAML = cleanManifestToYAML(d.NormalizedLiveState)
 		}
-		cleaned := stripDiffHeader(string(out))
-		if strings.TrimSpace(cleaned) == "" {
-			return model.SetModeMsg{Mode: model.ModeNoDiff}
+		if d.PredictedLiveState != "" {
+			predictedYAML = cleanManifestToYAML(d.PredictedLiveState)
 		}
 
-		// 1) Interactive diff viewer: replace the terminal (e.g., vimdiff, meld)
-		if viewer := m.config.GetDiffViewer(); viewer != "" {
-			return m.openInteractiveDiffViewer(leftFile, rightFile, viewer)
+		// Filter out resources with identical states (like ArgoCD UI does)
+		if normalizedYAML == predictedYAML {
+			continue
 		}
 
-		// 2) Non-interactive formatter: pipe to tool (e.g., delta) and then show via pager
-		formatted := cleaned
-		if formattedOut, ferr := m.runDiffFormatterWithTitle(cleaned, appName); ferr == nil && strings.TrimSpace(formattedOut) != "" {
-			formatted = formattedOut
+		sections = append(sections, model.DiffSection{
+			Group:     d.Group,
+			Kind:      d.Kind,
+			Namespace: d.Namespace,
+			Name:      d.Name,
+			Live:      normalizedYAML,
+			Desired:   predictedYAML,
+		})
+	}
+	return sections
+}
+
+// showDiffSections diffs the given sections and hands the result to the
+// configured viewer, formatter or pager. Runs inside a tea.Cmd goroutine.
+func (m *Model) showDiffSections(subject string, sections []model.DiffSection, epoch int) tea.Msg {
+	normalizedDocs := make([]string, 0, len(sections))
+	predictedDocs := make([]string, 0, len(sections))
+	for _, s := range sections {
+		if s.Live != "" {
+			normalizedDocs = append(normalizedDocs, s.Live)
 		}
-		title := fmt.Sprintf("%s - Live vs Desired", appName)
-		return m.openTextPager(title, formatted)()
+		if s.Desired != "" {
+			predictedDocs = append(predictedDocs, s.Desired)
+		}
+	}
+
+	if len(normalizedDocs) == 0 && len(predictedDocs) == 0 {
+		return model.SetModeMsg{Mode: model.ModeNoDiff}
+	}
+
+	leftFile, _ := writeTempYAML("current-", normalizedDocs)
+	rightFile, _ := writeTempYAML("predicted-", predictedDocs)
+
+	// Build raw unified diff via git (no color so delta can format it)
+	cmd := exec.Command("git", "--no-pager", "diff", "--no-index", "--no-color", "--", leftFile, rightFile)
+	out, err := cmd.CombinedOutput()
+	if err != nil && cmd.ProcessState != nil && cmd.ProcessState.ExitCode() != 1 {
+		return model.ApiErrorMsg{Message: "Diff failed: " + err.Error(), SwitchEpoch: epoch}
+	}
+	cleaned := stripDiffHeader(string(out))
+	if strings.TrimSpace(cleaned) == "" {
+		return model.SetModeMsg{Mode: model.ModeNoDiff}
 	}
+
+	// 1) Interactive diff viewer: replace the terminal (e.g., vimdiff, meld)
+	if viewer := m.config.GetDiffViewer(); viewer != "" {
+		return m.openInteractiveDiffViewer(leftFile, rightFile, viewer)
+	}
+
+	// 2) Non-interactive formatter: pipe to tool (e.g., delta) and then show via pager
+	formatted := cleaned
+	if formattedOut, ferr := m.runDiffFormatterWithTitle(cleaned, subject); ferr == nil && strings.TrimSpace(formattedOut) != "" {
+		formatted = formattedOut
+	}
+	title := fmt.Sprintf("%s - Live vs Desired", subject)
+	return m.openTextPager(title, formatted)()
 }
 
 // startResourceDiffSession loads the diff for a specific resource and opens the diff pager
@@ -587,20 +699,15 @@ func (m *Model) startResourceDiffSession(res ResourceIdentifier) tea.Cmd {
 		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 45*time.Second)
 		defer cancel()
 
+		// Ask for just this resource rather than every managed resource of
+		// the app; the API matches the key, so one drifted object in a large
+		// app stays a small request
 		apiService := services.NewArgoApiService(server)
-		diffs, err := apiService.GetResourceDiffs(ctx, server, res.AppName, res.AppNamespace)
+		targetDiff, err := apiService.GetResourceDiff(ctx, server, res.AppName, res.AppNamespace, api.ManagedResourceKey{
+			Group: res.Group, Kind: res.Kind, Namespace: res.Namespace, Name: res.Name,
+		})
 		if err != nil {
-			return model.ApiErrorMsg{Message: "Failed to load diffs: " + err.Error(), SwitchEpoch: epoch}
-		}
-
-		// Find the matching resource diff
-		var targetDiff *services.ResourceDiff
-		for i := range diffs {
-			d := &diffs[i]
-			if d.Group == res.Group && d.Kind == res.Kind && d.Name == res.Name && d.Namespace == res.Namespace {
-				targetDiff = d
-				break
-			}
+			return model.ApiErrorMsg{Message: "Failed to load diff: " + err.Error(), SwitchEpoch: epoch}
 		}
 
 		if targetDiff == nil || targetDiff.Hook {
@@ -697,6 +804,7 @@ func (m *Model) startLoadingResourceTree(app model.App) tea.Cmd {
 	}
 	epoch := m.switchEpoch   // capture at call time
 	server := m.state.Server // capture at call time
+	prefetch := m.prefetch
 	return func() tea.Msg {
 		ctx, cancel := appcontext.WithAPITimeout(context.Background())
 		defer cancel()
@@ -706,7 +814,10 @@ func (m *Model) startLoadingResourceTree(app model.App) tea.Cmd {
 		if app.AppNamespace != nil {
 			appNamespace = *app.AppNamespace
 		}
-		tree, err := argo.GetResourceTree(ctx, server, app.Name, appNamespace)
+		key := appKey(app.Name, app.AppNamespace)
+		tree, err := prefetched(ctx, prefetch, key, prefetchTree, func(ctx context.Context) (*api.ResourceTree, error) {
+			return argo.GetResourceTree(ctx, server, app.Name, appNamespace)
+		})
 		if err != nil {
 			return model.ApiErrorMsg{Message: err.Error(), SwitchEpoch: epoch}
 		}
@@ -718,7 +829,9 @@ func (m *Model) startLoadingResourceTree(app model.App) tea.Cmd {
 
 		// Also fetch app details to get status.resources for sync status
 		var resourcesData []byte
-		argoApp, appErr := argo.GetApplication(ctx, server, app.Name, app.AppNamespace)
+		argoApp, appErr := prefetched(ctx, prefetch, key, prefetchApplication, func(ctx context.Context) (*api.ArgoApplication, error) {
+			return argo.GetApplication(ctx, server, app.Name, app.AppNamespace)
+		})
 		if appErr == nil && argoApp != nil && len(argoApp.Status.Resources) > 0 {
 			resourcesData, _ = json.Marshal(argoApp.Status.Resources)
 		}
@@ -734,13 +847,28 @@ func (m *Model) startLoadingResourceTree(app model.App) tea.Cmd {
 	}
 }
 
-// startWatchingResourceTree starts a streaming watcher for resource tree updates
-type treeWatchStartedMsg struct{ cleanup func() }
+// treeWatchStartedMsg reports that the tree stream with the given sequence
+// number is open
+type treeWatchStartedMsg struct {
+	appName string
+	seq     int
+	cleanup func()
+}
 
+// treeWatchFailedMsg reports that the tree stream could not be opened
+type treeWatchFailedMsg struct {
+	appName string
+	seq     int
+	err     error
+}
+
+// startWatchingResourceTree starts a streaming watcher for resource tree updates
 func (m *Model) startWatchingResourceTree(app model.App) tea.Cmd {
-	if m.state.Server == nil {
+	// Without streaming the tree is reloaded with the app list
+	if m.state.Server == nil || !m.config.IsStreamEnabled() {
 		return nil
 	}
+	seq := m.beginTreeStream(app)
 	server := m.state.Server // capture at call time
 	done := m.treeStreamDone // capture at call time
 	return func() tea.Msg {
@@ -754,7 +882,7 @@ func (m *Model) startWatchingResourceTree(app model.App) tea.Cmd {
 		ch, cleanup, err := apiService.WatchResourceTree(ctx, server, app.Name, appNamespace)
 		if err != nil {
 			cblog.With("component", "ui").Error("Tree watch failed", "err", err, "app", app.Name)
-			return model.StatusChangeMsg{Status: "Tree watch failed: " + err.Error()}
+			return treeWatchFailedMsg{appName: app.Name, seq: seq, err: err}
 		}
 		go func() {
 			eventCount := 0
@@ -763,6 +891,11 @@ func (m *Model) startWatchingResourceTree(app model.App) tea.Cmd {
 				case t, ok := <-ch:
 					if !ok {
 						cblog.With("component", "ui").Info("Tree watch channel closed", "app", app.Name, "events", eventCount)
+						// Not dropped like updates: the end decides whether to reconnect
+						select {
+						case m.treeStream <- model.ResourceTreeStreamMsg{AppName: app.Name, Seq: seq, Ended: true}:
+						case <-done:
+						}
 						return
 					}
 					if t == nil {
@@ -771,14 +904,14 @@ func (m *Model) startWatchingResourceTree(app model.App) tea.Cmd {
 					eventCount++
 					cblog.With("component", "ui").Debug("Received tree event", "app", app.Name, "event", eventCount)
 					data, _ := json.Marshal(t)
-					m.watchTreeDeliver(model.ResourceTreeStreamMsg{AppName: app.Name, TreeJSON: data})
+					m.watchTreeDeliver(model.ResourceTreeStreamMsg{AppName: app.Name, Seq: seq, TreeJSON: data})
 				case <-done:
 					cblog.With("component", "ui").Info("Tree watch cancelled via done signal", "app", app.Name)
 					return
 				}
 			}
 		}()
-		return treeWatchStartedMsg{cleanup: cleanup}
+		return treeWatchStartedMsg{appName: app.Name, seq: seq, cleanup: cleanup}
 	}
 }
 
@@ -799,7 +932,7 @@ func stripDiffHeader(out string) string {
 }
 
 // syncSelectedApplications syncs the currently selected applications
-func (m *Model) syncSelectedApplications(prune bool) tea.Cmd {
+func (m *Model) syncSelectedApplications(opts services.SyncOptions) tea.Cmd {
 	if m.state.Server == nil {
 		return func() tea.Msg {
 			return model.ApiErrorMsg{Message: "No server configured"}
@@ -825,7 +958,7 @@ func (m *Model) syncSelectedApplications(prune bool) tea.Cmd {
 		for _, appName := range selectedApps {
 			ctx, cancel := appcontext.WithAPITimeout(context.Background())
 			// Multi-app sync doesn't track per-app namespaces; pass nil (uses Argo CD default)
-			err := apiService.SyncApplication(ctx, server, appName, nil, prune)
+			err := apiService.SyncApplication(ctx, server, appName, nil, opts)
 			cancel()
 			if err != nil {
 				// Convert to structured error and return via TUI error handling
@@ -913,7 +1046,7 @@ func (m *Model) deleteApplication(req model.AppDeleteRequestMsg) tea.Cmd {
 }
 
 // syncSingleApplication syncs a specific application
-func (m *Model) syncSingleApplication(appName string, appNamespace *string, prune bool) tea.Cmd {
+func (m *Model) syncSingleApplication(appName string, appNamespace *string, opts services.SyncOptions) tea.Cmd {
 	if m.state.Server == nil {
 		return func() tea.Msg {
 			return model.ApiErrorMsg{Message: "No server configured"}
@@ -929,14 +1062,17 @@ func (m *Model) syncSingleApplication(appName string, appNamespace *string, prun
 		apiService := services.NewEnhancedArgoApiService(server)
 
 		cblog.With("component", "api").Info("Starting sync", "app", appName)
-		err := apiService.SyncApplication(ctx, server, appName, appNamespace, prune)
+		err := apiService.SyncApplication(ctx, server, appName, appNamespace, opts)
 		if err != nil {
 			cblog.With("component", "api").Error("Sync failed", "app", appName, "err", err)
+			if isOperationConflict(err) {
+				return model.OperationConflictMsg{AppName: appName, AppNamespace: appNamespace, Operation: "sync", Message: extractUserFriendlyError(err), SwitchEpoch: epoch}
+			}
 			// Convert to structured error and return via TUI error handling
 			if argErr, ok := err.(*apperrors.ArgonautError); ok {
 				return model.StructuredErrorMsg{
 					Error:       argErr,
-					Context:     map[string]interface{}{"operation": "sync", "appName": appName},
+					Context:     map[string]interface{}{"operation": "sync", "appName": appName, "appNamespace": appNamespace},
 					Retry:       argErr.Recoverable,
 					SwitchEpoch: epoch,
 				}
@@ -948,7 +1084,7 @@ func (m *Model) syncSingleApplication(appName string, appNamespace *string, prun
 					WithSeverity(apperrors.SeverityHigh).
 					AsRecoverable().
 					WithUserAction("Check your connection to ArgoCD and try again"),
-				Context:     map[string]interface{}{"operation": "sync", "appName": appName},
+				Context:     map[string]interface{}{"operation": "sync", "appName": appName, "appNamespace": appNamespace},
 				Retry:       true,
 				SwitchEpoch: epoch,
 			}
@@ -959,6 +1095,59 @@ func (m *Model) syncSingleApplication(appName string, appNamespace *string, prun
 	}
 }
 
+// syncApplicationSource syncs a single source of a multi-source application.
+// position is the 1-based source position, revision its target revision.
+func (m *Model) syncApplicationSource(appName string, appNamespace *string, syncOpts services.SyncOptions, position int, revision string) tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg {
+			return model.ApiErrorMsg{Message: "No server configured"}
+		}
+	}
+
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+
+		ns := ""
+		if appNamespace != nil {
+			ns = *appNamespace
+		}
+		opts := &api.SyncOptions{
+			Prune:                  syncOpts.Prune,
+			AppNamespace:           ns,
+			SourcePositions:        []int64{int64(position)},
+			Revisions:              []string{revision},
+			PruneLast:              syncOpts.PruneLast,
+			PrunePropagationPolicy: syncOpts.PrunePropagationPolicy,
+			ServerSideApply:        syncOpts.ServerSideApply,
+			Resources:              syncOpts.Resources,
+		}
+
+		cblog.With("component", "api").Info("Starting source sync", "app", appName, "source", position)
+		if err := api.NewApplicationService(server).SyncApplication(ctx, appName, opts); err != nil {
+			cblog.With("component", "api").Error("Source sync failed", "app", appName, "source", position, "err", err)
+			if isOperationConflict(err) {
+				return model.OperationConflictMsg{AppName: appName, AppNamespace: appNamespace, Operation: "sync", Message: extractUserFriendlyError(err), SwitchEpoch: epoch}
+			}
+			return model.StructuredErrorMsg{
+				Error: apperrors.New(apperrors.ErrorAPI, "SYNC_FAILED",
+					fmt.Sprintf("Failed to sync source %d of %s: %s", position, appName, extractUserFriendlyError(err))).
+					WithSeverity(apperrors.SeverityHigh).
+					AsRecoverable().
+					WithUserAction("Check your connection to ArgoCD and try again"),
+				Context:     map[string]interface{}{"operation": "sync", "appName": appName, "appNamespace": appNamespace, "source": position},
+				Retry:       true,
+				SwitchEpoch: epoch,
+			}
+		}
+
+		cblog.With("component", "api").Info("Source sync completed", "app", appName, "source", position)
+		return model.SyncCompletedMsg{AppName: appName, AppNamespace: appNamespace, Success: true, SwitchEpoch: epoch}
+	}
+}
+
 // refreshSingleApplication refreshes a specific application
 func (m *Model) refreshSingleApplication(appName string, appNamespace *string, hard bool) tea.Cmd {
 	if m.state.Server == nil {
@@ -992,7 +1181,7 @@ func (m *Model) refreshSingleApplication(appName string, appNamespace *string, h
 			if argErr, ok := err.(*apperrors.ArgonautError); ok {
 				return model.StructuredErrorMsg{
 					Error:       argErr,
-					Context:     map[string]interface{}{"operation": "refresh", "appName": appName, "hard": hard},
+					Context:     map[string]interface{}{"operation": "refresh", "appName": appName, "appNamespace": appNamespace, "hard": hard},
 					Retry:       argErr.Recoverable,
 					SwitchEpoch: epoch,
 				}
@@ -1003,7 +1192,7 @@ func (m *Model) refreshSingleApplication(appName string, appNamespace *string, h
 					WithSeverity(apperrors.SeverityMedium).
 					AsRecoverable().
 					WithUserAction("Check your connection to ArgoCD and try again"),
-				Context:     map[string]interface{}{"operation": "refresh", "appName": appName, "hard": hard},
+				Context:     map[string]interface{}{"operation": "refresh", "appName": appName, "appNamespace": appNamespace, "hard": hard},
 				Retry:       true,
 				SwitchEpoch: epoch,
 			}
@@ -1121,6 +1310,7 @@ func (m *Model) startRollbackSession(appName string, appNamespace *string) tea.C
 	}
 	epoch := m.switchEpoch   // capture at call time
 	server := m.state.Server // capture at call time
+	prefetch := m.prefetch
 	return func() tea.Msg {
 		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -1128,7 +1318,9 @@ func (m *Model) startRollbackSession(appName string, appNamespace *string) tea.C
 		apiService := services.NewArgoApiService(server)
 
 		// Get application with history
-		app, err := apiService.GetApplication(ctx, server, appName, appNamespace)
+		app, err := prefetched(ctx, prefetch, appKey(appName, appNamespace), prefetchApplication, func(ctx context.Context) (*api.ArgoApplication, error) {
+			return apiService.GetApplication(ctx, server, appName, appNamespace)
+		})
 		if err != nil {
 			errMsg := err.Error()
 			cblog.With("component", "rollback").Error("Rollback session failed", "app", appName, "err", err)
@@ -1144,11 +1336,16 @@ func (m *Model) startRollbackSession(appName string, appNamespace *string) tea.C
 		rows := api.ConvertDeploymentHistoryToRollbackRows(app.Status.History)
 
 		// Get current revision from sync status
+		// (multi-source apps: the revision of the source the history rows show)
 		currentRevision := ""
 		if app.Status.Sync.Revision != "" {
 			currentRevision = app.Status.Sync.Revision
-		} else if len(app.Status.Sync.Revisions) > 0 {
-			currentRevision = app.Status.Sync.Revisions[0]
+		} else if revs := app.Status.Sync.Revisions; len(revs) > 0 {
+			idx := api.GitSourceIndex(app.Spec.Sources)
+			if idx >= len(revs) {
+				idx = 0
+			}
+			currentRevision = revs[idx]
 		}
 
 		cblog.With("component", "rollback").Debug("Rollback session loaded", "app", appName, "rows", len(rows), "currentRevision", currentRevision)
@@ -1163,7 +1360,7 @@ func (m *Model) startRollbackSession(appName string, appNamespace *string) tea.C
 }
 
 // loadRevisionMetadata loads git metadata for a specific rollback row
-func (m *Model) loadRevisionMetadata(appName string, rowIndex int, revision string, appNamespace *string) tea.Cmd {
+func (m *Model) loadRevisionMetadata(appName string, rowIndex int, row model.RollbackRow, appNamespace *string) tea.Cmd {
 	if m.state.Server == nil {
 		return func() tea.Msg {
 			return model.ApiErrorMsg{Message: "No server configured"}
@@ -1176,15 +1373,23 @@ func (m *Model) loadRevisionMetadata(appName string, rowIndex int, revision stri
 
 		apiService := services.NewArgoApiService(server)
 
-		metadata, err := apiService.GetRevisionMetadata(ctx, server, appName, revision, appNamespace)
+		var metadata *model.RevisionMetadata
+		var err error
+		if row.SourceIndex != nil {
+			metadata, err = apiService.GetRevisionMetadataForSource(ctx, server, appName, row.Revision, appNamespace, *row.SourceIndex, row.ID)
+		} else {
+			metadata, err = apiService.GetRevisionMetadata(ctx, server, appName, row.Revision, appNamespace)
+		}
 		if err != nil {
 			return model.RollbackMetadataErrorMsg{
+				AppName:  appName,
 				RowIndex: rowIndex,
 				Error:    err.Error(),
 			}
 		}
 
 		return model.RollbackMetadataLoadedMsg{
+			AppName:  appName,
 			RowIndex: rowIndex,
 			Metadata: *metadata,
 		}
@@ -1213,6 +1418,9 @@ func (m *Model) executeRollback(request model.RollbackRequest) tea.Cmd {
 			if isAuthenticationError(errMsg) {
 				return model.AuthErrorMsg{Error: err, SwitchEpoch: epoch}
 			}
+			if isOperationConflict(err) {
+				return model.OperationConflictMsg{AppName: request.Name, AppNamespace: request.AppNamespace, Operation: "rollback", Message: extractUserFriendlyError(err), SwitchEpoch: epoch}
+			}
 			return model.ApiErrorMsg{Message: "Failed to rollback application: " + err.Error(), SwitchEpoch: epoch}
 		}
 
@@ -1322,6 +1530,16 @@ func (m *Model) deleteSelectedApplications(cascade bool, propagationPolicy strin
 		}
 	}
 
+	// Resolve the before-delete hooks here, where reading the app list is safe
+	hooks := make(map[string][]localHookRun)
+	for _, app := range m.state.Apps {
+		if _, ok := m.state.Selections.SelectedApps[app.Name]; ok {
+			if runs := m.beforeDeleteHooks(app.Name, app.AppNamespace); len(runs) > 0 {
+				hooks[app.Name] = runs
+			}
+		}
+	}
+
 	server := m.state.Server // capture at call time
 	return func() tea.Msg {
 		cblog.With("component", "app-delete").Info("Starting sequential multi-delete", "count", len(selectedApps), "cascade", cascade, "policy", propagationPolicy)
@@ -1345,16 +1563,20 @@ func (m *Model) deleteSelectedApplications(cascade bool, propagationPolicy strin
 				}
 			}
 
-			ctx, cancel := appcontext.WithAPITimeout(context.Background())
-			err := m.deleteApplicationHelper(ctx, server, deleteService, AppDeleteParams{
-				AppName:   appName,
-				Namespace: appNamespace,
-				Options: DeleteOptions{
-					Cascade:           cascade,
-					PropagationPolicy: propagationPolicy,
-				},
-			})
-			cancel()
+			// Hooks run before the API timeout starts counting
+			err := runBeforeDeleteHooks(hooks[appName])
+			if err == nil {
+				ctx, cancel := appcontext.WithAPITimeout(context.Background())
+				err = m.deleteApplicationHelper(ctx, server, deleteService, AppDeleteParams{
+					AppName:   appName,
+					Namespace: appNamespace,
+					Options: DeleteOptions{
+						Cascade:           cascade,
+						PropagationPolicy: propagationPolicy,
+					},
+				})
+				cancel()
+			}
 			if err != nil {
 				cblog.With("component", "app-delete").Error("Failed to delete app", "app", appName, "err", err)
 				failedApps = append(failedApps, fmt.Sprintf("%s (%v)", appName, err))
@@ -1421,6 +1643,14 @@ func (m *Model) deleteSingleApplication(params AppDeleteParams) tea.Cmd {
 	epoch := m.switchEpoch   // capture at call time
 	server := m.state.Server // capture at call time
 	return func() tea.Msg {
+		// Hooks run before the API timeout starts counting
+		if err := runBeforeDeleteHooks(params.Hooks); err != nil {
+			return model.AppDeleteErrorMsg{
+				AppName: params.AppName,
+				Error:   fmt.Sprintf("Failed to delete application: %v", err),
+			}
+		}
+
 		ctx, cancel := appcontext.WithAPITimeout(context.Background())
 		defer cancel()
 
@@ -1717,7 +1947,12 @@ func (m *Model) executeResourceAction(target model.ResourceActionTarget, action
 			errMsg := extractUserFriendlyError(err)
 			cblog.With("component", "resource-action").Error("Failed to run action",
 				"app", target.AppName, "kind", target.Kind, "name", target.Name, "action", action, "err", err)
-			return model.ResourceActionExecuteErrorMsg{Target: target, Error: errMsg, SwitchEpoch: epoch}
+			return model.ResourceActionExecuteErrorMsg{
+				Target:          target,
+				Error:           errMsg,
+				EndpointMissing: api.IsEndpointMissing(err),
+				SwitchEpoch:     epoch,
+			}
 		}
 
 		cblog.With("component", "resource-action").Info("Action executed",
@@ -0,0 +1,46 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/appcache"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// loadCachedApps reads the on-disk app list for the current server.
+// Returns nil (no message) when the cache is disabled, empty or unreadable.
+func (m *Model) loadCachedApps() tea.Cmd {
+	if m.appCacheDir == "" || m.state.Server == nil {
+		return nil
+	}
+	epoch := m.switchEpoch // capture at call time
+	dir := m.appCacheDir
+	serverURL := m.state.Server.BaseURL
+	return func() tea.Msg {
+		snap, err := appcache.Load(dir, serverURL)
+		if err != nil {
+			cblog.With("component", "appcache").Debug("Could not load app cache", "err", err)
+			return nil
+		}
+		if snap == nil || len(snap.Apps) == 0 {
+			return nil
+		}
+		return model.CachedAppsLoadedMsg{Apps: snap.Apps, SavedAt: snap.SavedAt, SwitchEpoch: epoch}
+	}
+}
+
+// saveAppsCache writes the live app list to disk for the next startup
+func (m *Model) saveAppsCache(apps []model.App) tea.Cmd {
+	if m.appCacheDir == "" || m.state.Server == nil {
+		return nil
+	}
+	dir := m.appCacheDir
+	serverURL := m.state.Server.BaseURL
+	apps = append([]model.App(nil), apps...) // Update keeps mutating state.Apps in place
+	return func() tea.Msg {
+		if err := appcache.Save(dir, serverURL, apps); err != nil {
+			cblog.With("component", "appcache").Debug("Could not save app cache", "err", err)
+		}
+		return nil
+	}
+}
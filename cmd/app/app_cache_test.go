@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/appcache"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestCachedApps_ShownUntilLiveListArrives(t *testing.T) {
+	m := buildBaseModel(100, 30)
+	m.state.Apps = nil
+	m.state.Mode = model.ModeLoading
+	m.state.Navigation.View = model.ViewApps
+
+	m.Update(model.CachedAppsLoadedMsg{Apps: []model.App{{Name: "cached-app", Sync: "Synced", Health: "Healthy"}}})
+	if !m.appsFromCache || len(m.state.Apps) != 1 {
+		t.Fatalf("expected cached apps to be shown, apps=%d fromCache=%v", len(m.state.Apps), m.appsFromCache)
+	}
+	if spec := m.activeOverlay(); spec != nil && strings.Contains(stripANSI(spec.modal), "Loading") {
+		t.Error("loading modal should not cover the cached list")
+	}
+	if !strings.Contains(stripANSI(m.renderStatusLine()), "Cached") {
+		t.Error("status line should mark the list as cached")
+	}
+
+	m.Update(model.AppsLoadedMsg{Apps: []model.App{{Name: "live-app", Sync: "Synced", Health: "Healthy"}}})
+	if m.appsFromCache || m.state.Apps[0].Name != "live-app" {
+		t.Fatalf("live list should replace the cache, got %+v", m.state.Apps)
+	}
+
+	// A cache read finishing after the live load must not clobber it
+	m.Update(model.CachedAppsLoadedMsg{Apps: []model.App{{Name: "cached-app"}}})
+	if m.appsFromCache || m.state.Apps[0].Name != "live-app" {
+		t.Fatalf("late cache should be ignored, got %+v", m.state.Apps)
+	}
+}
+
+func TestCachedApps_StaleEpochIgnored(t *testing.T) {
+	m := buildBaseModel(100, 30)
+	m.state.Apps = nil
+	m.switchEpoch = 3
+
+	m.Update(model.CachedAppsLoadedMsg{Apps: []model.App{{Name: "old-context-app"}}, SwitchEpoch: 2})
+	if len(m.state.Apps) != 0 {
+		t.Fatal("cached apps from a previous context should be dropped")
+	}
+}
+
+func TestLoadCachedApps_ReadsSnapshotForServer(t *testing.T) {
+	m := buildBaseModel(100, 30)
+	m.appCacheDir = t.TempDir()
+	if err := appcache.Save(m.appCacheDir, m.state.Server.BaseURL, []model.App{{Name: "from-disk"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, ok := m.loadCachedApps()().(model.CachedAppsLoadedMsg)
+	if !ok || len(msg.Apps) != 1 || msg.Apps[0].Name != "from-disk" {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+
+	m.appCacheDir = ""
+	if m.loadCachedApps() != nil {
+		t.Error("cache should be disabled without a directory")
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// appSourceLabel renders a source as repo/path (or chart) @ targetRevision
+func appSourceLabel(src model.AppSource) string {
+	var label string
+	if src.Chart != "" {
+		label = fmt.Sprintf("%s (chart %s)", src.RepoURL, src.Chart)
+	} else {
+		label = src.RepoURL
+		if src.Path != "" {
+			label += " " + src.Path
+		}
+	}
+	if src.TargetRevision != "" {
+		label += " @ " + src.TargetRevision
+	}
+	return label
+}
+
+// shortRevision trims git SHAs to 8 characters; chart versions are left alone
+func shortRevision(rev string) string {
+	return rev[:min(8, len(rev))]
+}
+
+// derefOr returns the pointed-to string, or "" when nil
+func derefOr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// handleOpenAppDetails opens the details modal for the app under the cursor
+func (m *Model) handleOpenAppDetails() (tea.Model, tea.Cmd) {
+	items := m.getVisibleItemsForCurrentView()
+	if m.state.Navigation.SelectedIdx >= len(items) {
+		return m, nil
+	}
+	app, ok := items[m.state.Navigation.SelectedIdx].(model.App)
+	if !ok {
+		return m, nil
+	}
+	return m, m.openAppDetails(app.Name, app.AppNamespace)
+}
+
+// openAppDetails shows the details modal for the given app and loads its
+// notification subscriptions
+func (m *Model) openAppDetails(appName string, appNamespace *string) tea.Cmd {
+	m.state.Modals.AppDetails = &model.AppDetailsState{
+		AppName:              appName,
+		AppNamespace:         appNamespace,
+		SubscriptionsLoading: m.state.Server != nil,
+	}
+	m.state.Mode = model.ModeAppDetails
+	return m.loadNotificationSubscriptions(appName, appNamespace)
+}
+
+// handleAppDetailsKeys handles input while the app details modal is open
+func (m *Model) handleAppDetailsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.state.Modals.AppDetails == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	if cmd, handled := m.handleSubscriptionKeys(msg); handled {
+		return m, cmd
+	}
+	switch msg.String() {
+	case "q", "esc", "i", "enter", "ctrl+c":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.AppDetails = nil
+	}
+	return m, nil
+}
+
+// renderAppDetailsModal renders app identity, status, sources and hydrator settings
+func (m *Model) renderAppDetailsModal() string {
+	st := m.state.Modals.AppDetails
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("App " + st.AppName)
+	label := lipgloss.NewStyle().Foreground(dimColor)
+	var lines []string
+	lines = append(lines, title, "")
+
+	ns := ""
+	if st.AppNamespace != nil {
+		ns = *st.AppNamespace
+	}
+	app := m.findAppByNameAndNamespace(st.AppName, ns)
+	if app == nil {
+		lines = append(lines, "Application is no longer available")
+	} else {
+		lines = append(lines, m.appDetailsLines(*app, innerWidth)...)
+		lines = append(lines, m.subscriptionLines(st, innerWidth)...)
+	}
+
+	lines = append(lines, "", label.Render("a add subscription • d remove • Esc to close"))
+
+	content := strings.Join(lines, "\n")
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	return modalStyle.Render(content)
+}
+
+// appDetailsLines renders the identity, status, sources and hydrator
+// settings of an app, for the details modal and the details pane
+func (m *Model) appDetailsLines(app model.App, innerWidth int) []string {
+	label := lipgloss.NewStyle().Foreground(dimColor)
+	var lines []string
+	field := func(name, value string) {
+		if value == "" {
+			value = "—"
+		}
+		lines = append(lines, label.Render(fmt.Sprintf("%-12s", name))+value)
+	}
+	field("Project", derefOr(app.Project))
+	field("Namespace", derefOr(app.AppNamespace))
+	dest := derefOr(app.ClusterLabel)
+	if app.Namespace != nil {
+		dest += "/" + *app.Namespace
+	}
+	field("Destination", dest)
+	if reason, failed := m.clusterConnectionError(app); failed {
+		lines = append(lines, lipgloss.NewStyle().Foreground(outOfSyncColor).Render(
+			truncateWithEllipsis("  "+clusterConnectionMarker+" cluster unreachable: "+reason, innerWidth)))
+	}
+	field("Status", fmt.Sprintf("%s / %s", app.Sync, app.Health))
+	if app.LastOperationBy != nil {
+		lastOp := app.LastOperationBy.String()
+		if app.LastSyncAt != nil {
+			lastOp += " " + timefmt.At(*app.LastSyncAt)
+		}
+		field("Last op by", lastOp)
+	}
+
+	heading := "Source"
+	if app.MultiSource {
+		heading = fmt.Sprintf("Sources (%d)", len(app.Sources))
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render(heading))
+	if len(app.Sources) == 0 {
+		lines = append(lines, label.Render("  none"))
+	}
+	for i, src := range app.Sources {
+		line := fmt.Sprintf("  %d. %s", i+1, appSourceLabel(src))
+		if src.Ref != "" {
+			line += " ref:" + src.Ref
+		}
+		if src.Revision != "" {
+			line += " → " + shortRevision(src.Revision)
+		}
+		lines = append(lines, truncateWithEllipsis(line, innerWidth))
+	}
+	if c := m.syncedCommit(app); c != nil {
+		commit := commitAuthorName(c.Author)
+		if !c.Date.IsZero() {
+			commit += " " + timefmt.At(c.Date)
+		}
+		field("  Commit", commit)
+		subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		lines = append(lines, truncateWithEllipsis("    "+subject, innerWidth))
+	}
+	if m.isBehindBranchTip(app) {
+		_, target, _ := driftTarget(app)
+		lines = append(lines, lipgloss.NewStyle().Foreground(yellowBright).Render("  "+revisionDriftMarker+" behind the tip of "+target))
+	}
+
+	if h := app.Hydrator; h != nil {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render("Source hydrator"))
+		dry := appSourceLabel(model.AppSource{RepoURL: h.DryRepoURL, Path: h.DryPath, TargetRevision: h.DryRevision})
+		field("  Dry", dry)
+		field("  Sync", strings.TrimSpace(h.SyncBranch+" "+h.SyncPath))
+		if h.HydrateToBranch != "" {
+			field("  Hydrate to", h.HydrateToBranch)
+		}
+	}
+	lines = append(lines, m.deprecatedAPILines(app, innerWidth)...)
+
+	return lines
+}
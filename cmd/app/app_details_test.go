@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestAppDetails_OpenRenderClose(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].MultiSource = true
+	m.state.Apps[0].Sources = []model.AppSource{
+		{RepoURL: "https://charts.example.com", Chart: "web", TargetRevision: "1.4.0", Revision: "1.4.0"},
+		{RepoURL: "https://git.example.com/values.git", TargetRevision: "main", Ref: "values", Revision: "0123456789abcdef"},
+	}
+
+	m.handleKeyMsg(testKeyMsg("i"))
+	if m.state.Mode != model.ModeAppDetails || m.state.Modals.AppDetails == nil {
+		t.Fatalf("expected details modal, mode=%s", m.state.Mode)
+	}
+
+	out := stripANSI(m.renderAppDetailsModal())
+	for _, want := range []string{"Sources (2)", "(chart web) @ 1.4.0", "ref:values → 01234567"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("details missing %q:\n%s", want, out)
+		}
+	}
+
+	m.handleKeyMsg(testKeyMsg("esc"))
+	if m.state.Mode != model.ModeNormal || m.state.Modals.AppDetails != nil {
+		t.Fatalf("expected modal closed, mode=%s", m.state.Mode)
+	}
+}
+
+func TestAppDetails_ShowsLastOperationInitiator(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].LastOperationBy = &model.OperationInitiator{Automated: true}
+	m.openAppDetails(m.state.Apps[0].Name, m.state.Apps[0].AppNamespace)
+
+	if out := stripANSI(m.renderAppDetailsModal()); !strings.Contains(out, "Last op by  automation") {
+		t.Errorf("details should show who started the last operation:\n%s", out)
+	}
+}
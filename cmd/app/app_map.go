@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// Cells of the app map: one per app, filled when the app is synced
+const (
+	mapCellSynced    = "■"
+	mapCellOutOfSync = "□"
+)
+
+// mapRow is one line of the app map: a cluster heading, or up to a line's
+// worth of the apps of one namespace, labelled on the namespace's first line
+type mapRow struct {
+	heading string
+	label   string
+	apps    []model.App
+}
+
+// mapApps returns the apps the map plots: those in the current scope,
+// ignoring search and quick filters
+func (m *Model) mapApps() []model.App {
+	idx := m.state.Index
+	if idx == nil && len(m.state.Apps) > 0 {
+		idx = model.BuildAppIndex(m.state.Apps)
+		m.state.Index = idx
+	}
+	return idx.ScopedApps(m.state.Apps, &m.state.Selections)
+}
+
+// mapClusterName returns the cluster an app is plotted under
+func mapClusterName(app model.App) string {
+	switch {
+	case app.ClusterLabel != nil && *app.ClusterLabel != "":
+		return *app.ClusterLabel
+	case app.ClusterID != nil && *app.ClusterID != "":
+		return *app.ClusterID
+	}
+	return "(unknown cluster)"
+}
+
+// mapNamespaceName returns the namespace an app is plotted under
+func mapNamespaceName(app model.App) string {
+	if app.Namespace != nil && *app.Namespace != "" {
+		return *app.Namespace
+	}
+	return "(no namespace)"
+}
+
+// mapLabelWidth is the width of the namespace column
+func mapLabelWidth(apps []model.App) int {
+	width := 0
+	for _, app := range apps {
+		width = max(width, lipgloss.Width(mapNamespaceName(app)))
+	}
+	return min(width, 24)
+}
+
+// mapLayout groups the apps by cluster, then namespace, both sorted by
+// name, and wraps each namespace's cells to the width
+func mapLayout(apps []model.App, width int) []mapRow {
+	sorted := slices.Clone(apps)
+	slices.SortFunc(sorted, func(a, b model.App) int {
+		if c := strings.Compare(mapClusterName(a), mapClusterName(b)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(mapNamespaceName(a), mapNamespaceName(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// 2 columns of indent and one after the label; cells take 2 columns each
+	perLine := max(1, (width-mapLabelWidth(apps)-3)/2)
+	var rows []mapRow
+	for i := 0; i < len(sorted); {
+		cluster := mapClusterName(sorted[i])
+		rows = append(rows, mapRow{heading: cluster})
+		for i < len(sorted) && mapClusterName(sorted[i]) == cluster {
+			ns := mapNamespaceName(sorted[i])
+			j := i
+			for j < len(sorted) && mapClusterName(sorted[j]) == cluster && mapNamespaceName(sorted[j]) == ns {
+				j++
+			}
+			for k := i; k < j; k += perLine {
+				row := mapRow{apps: sorted[k:min(j, k+perLine)]}
+				if k == i {
+					row.label = ns
+				}
+				rows = append(rows, row)
+			}
+			i = j
+		}
+	}
+	return rows
+}
+
+// mapAppKey identifies an app on the map
+func mapAppKey(app model.App) (string, string) {
+	ns := ""
+	if app.AppNamespace != nil {
+		ns = *app.AppNamespace
+	}
+	return app.Name, ns
+}
+
+// mapCursor returns the row and cell of the app under the map cursor, or
+// of the first app when it is gone. ok is false on an empty map.
+func (m *Model) mapCursor(rows []mapRow) (row, cell int, ok bool) {
+	st := m.state.Modals.Map
+	first := -1
+	for r, mr := range rows {
+		for c, app := range mr.apps {
+			if first < 0 {
+				first = r
+			}
+			if name, ns := mapAppKey(app); st != nil && name == st.App && ns == st.AppNamespace {
+				return r, c, true
+			}
+		}
+	}
+	if first < 0 {
+		return 0, 0, false
+	}
+	return first, 0, true
+}
+
+// setMapCursor moves the map cursor to an app
+func (m *Model) setMapCursor(app model.App) {
+	m.state.Modals.Map.App, m.state.Modals.Map.AppNamespace = mapAppKey(app)
+}
+
+// mapInnerWidth returns the width inside the map's border and padding
+func (m *Model) mapInnerWidth() int {
+	return layout.Card.InnerWidth(layout.OverlayWidth(m.state.Terminal.Cols))
+}
+
+// mapBodyRows returns how many map rows fit on screen: the title, app
+// line, help and blank lines around the map take 7 inside the border
+func (m *Model) mapBodyRows() int {
+	return max(1, layout.Card.InnerHeight(m.state.Terminal.Rows)-7)
+}
+
+// scrollMapToCursor keeps the cursor's row, and its cluster heading where
+// possible, within the rows shown
+func (m *Model) scrollMapToCursor(rows []mapRow) {
+	st := m.state.Modals.Map
+	r, _, ok := m.mapCursor(rows)
+	if !ok {
+		st.Offset = 0
+		return
+	}
+	body := m.mapBodyRows()
+	top := r
+	for top > 0 && rows[top].heading == "" && r-top+1 < body {
+		top--
+	}
+	if top < st.Offset {
+		st.Offset = top
+	}
+	if r >= st.Offset+body {
+		st.Offset = r - body + 1
+	}
+	st.Offset = max(0, min(st.Offset, len(rows)-body))
+}
+
+// handleOpenMap shows the app map with the cursor on the app under the
+// list cursor, if any
+func (m *Model) handleOpenMap() (tea.Model, tea.Cmd) {
+	m.state.Modals.Map = &model.MapState{}
+	if app, ok := m.cursorApp(); ok {
+		m.setMapCursor(app)
+	}
+	m.state.Mode = model.ModeMap
+	m.scrollMapToCursor(mapLayout(m.mapApps(), m.mapInnerWidth()))
+	return m, nil
+}
+
+// closeMap leaves the app map
+func (m *Model) closeMap() {
+	m.state.Mode = model.ModeNormal
+	m.state.Modals.Map = nil
+}
+
+// handleMapKeys moves the cursor over the map's cells and opens the app
+// under it in the apps view
+func (m *Model) handleMapKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.state.Modals.Map == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	key := msg.String()
+	if key == "q" || key == "esc" {
+		m.closeMap()
+		return m, nil
+	}
+
+	rows := mapLayout(m.mapApps(), m.mapInnerWidth())
+	r, c, ok := m.mapCursor(rows)
+	if !ok {
+		return m, nil
+	}
+	var cells []model.App
+	for _, row := range rows {
+		cells = append(cells, row.apps...)
+	}
+	pos := slices.IndexFunc(cells, func(app model.App) bool { return sameMapApp(app, rows[r].apps[c]) })
+
+	switch key {
+	case "left", "h":
+		m.setMapCursor(cells[max(0, pos-1)])
+	case "right", "l":
+		m.setMapCursor(cells[min(len(cells)-1, pos+1)])
+	case "up", "k":
+		for i := r - 1; i >= 0; i-- {
+			if len(rows[i].apps) > 0 {
+				m.setMapCursor(rows[i].apps[min(c, len(rows[i].apps)-1)])
+				break
+			}
+		}
+	case "down", "j":
+		for i := r + 1; i < len(rows); i++ {
+			if len(rows[i].apps) > 0 {
+				m.setMapCursor(rows[i].apps[min(c, len(rows[i].apps)-1)])
+				break
+			}
+		}
+	case "g", "home":
+		m.setMapCursor(cells[0])
+	case "G", "end":
+		m.setMapCursor(cells[len(cells)-1])
+	case "enter":
+		app := rows[r].apps[c]
+		m.closeMap()
+		return m.showAppInList(app)
+	}
+	m.scrollMapToCursor(rows)
+	return m, nil
+}
+
+// sameMapApp reports whether two apps are the same app
+func sameMapApp(a, b model.App) bool {
+	an, ans := mapAppKey(a)
+	bn, bns := mapAppKey(b)
+	return an == bn && ans == bns
+}
+
+// showAppInList switches to the apps view with the cursor on the app.
+// Search and quick filters are cleared so the app is listed; the scope is
+// kept, the map only plots apps in it.
+func (m *Model) showAppInList(app model.App) (tea.Model, tea.Cmd) {
+	m.clearTreeApp()
+	m = m.safeChangeView(model.ViewApps)
+	m.state.UI.ActiveFilter = ""
+	m.state.UI.SearchQuery = ""
+	m.state.UI.StatusFilter = model.StatusFilterAll
+
+	items := m.getVisibleItemsForCurrentView()
+	m.listNav.SetItemCount(len(items))
+	m.listNav.SetViewportHeight(m.listViewportHeight())
+	for i, item := range items {
+		if a, ok := item.(model.App); ok && sameMapApp(a, app) {
+			m.listNav.SetCursor(i)
+			break
+		}
+	}
+	m.state.Navigation.SelectedIdx = m.listNav.Cursor()
+	return m, nil
+}
+
+// renderMapCell renders an app's cell, colored by health
+func (m *Model) renderMapCell(app model.App, cursor bool) string {
+	cell := mapCellSynced
+	if app.Sync != "Synced" {
+		cell = mapCellOutOfSync
+	}
+	if cursor {
+		return lipgloss.NewStyle().Background(cyanBright).Foreground(textOnAccent).Render(cell)
+	}
+	return m.getColorForStatus(app.Health).Render(cell)
+}
+
+// renderMapModal renders the app map over nearly the whole screen: a line
+// per namespace with a cell per app, grouped under cluster headings, and
+// the app under the cursor
+func (m *Model) renderMapModal() string {
+	st := m.state.Modals.Map
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := layout.OverlayWidth(m.state.Terminal.Cols)
+	innerWidth := m.mapInnerWidth()
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	apps := m.mapApps()
+	rows := mapLayout(apps, innerWidth)
+	namespaces := 0
+	for _, row := range rows {
+		if row.label != "" {
+			namespaces++
+		}
+	}
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("App map")
+	lines := []string{title + " " + dim.Render(fmt.Sprintf("%d apps in %d namespaces", len(apps), namespaces)), ""}
+
+	r, c, ok := m.mapCursor(rows)
+	if !ok {
+		lines = append(lines, dim.Render("No apps in scope"))
+	}
+	labelWidth := mapLabelWidth(apps)
+	body := m.mapBodyRows()
+	offset := max(0, min(st.Offset, len(rows)-body))
+	for i := offset; i < min(len(rows), offset+body); i++ {
+		row := rows[i]
+		if row.heading != "" {
+			lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render(truncateWithEllipsis(row.heading, innerWidth)))
+			continue
+		}
+		cells := make([]string, len(row.apps))
+		for j, app := range row.apps {
+			cells[j] = m.renderMapCell(app, ok && i == r && j == c)
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, truncateWithEllipsis(row.label, labelWidth))
+		lines = append(lines, "  "+dim.Render(label)+" "+strings.Join(cells, " "))
+	}
+
+	lines = append(lines, "")
+	if ok {
+		app := rows[r].apps[c]
+		detail := fmt.Sprintf("%s • %s • %s • %s/%s", app.Name, app.Health, app.Sync, mapClusterName(app), mapNamespaceName(app))
+		lines = append(lines, truncateWithEllipsis(detail, innerWidth))
+	}
+	legend := mapCellSynced + " synced  " + mapCellOutOfSync + " out of sync • ←↑↓→ move • Enter open in list • Esc close"
+	lines = append(lines, dim.Render(truncateWithEllipsis(legend, innerWidth)))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(0, 1).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
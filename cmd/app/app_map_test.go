@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func buildMapTestModel() *Model {
+	m := buildDeleteTestModel(100, 30)
+	str := func(s string) *string { return &s }
+	m.state.Apps = []model.App{
+		{Name: "web", Sync: "Synced", Health: "Healthy", ClusterLabel: str("prod"), Namespace: str("shop")},
+		{Name: "api", Sync: "OutOfSync", Health: "Degraded", ClusterLabel: str("prod"), Namespace: str("shop")},
+		{Name: "billing", Sync: "Synced", Health: "Healthy", ClusterLabel: str("prod"), Namespace: str("pay")},
+		{Name: "web-dev", Sync: "Synced", Health: "Progressing", ClusterLabel: str("dev"), Namespace: str("shop")},
+	}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	return m
+}
+
+func TestMapLayout_GroupsByClusterAndNamespace(t *testing.T) {
+	m := buildMapTestModel()
+	rows := mapLayout(m.mapApps(), 80)
+
+	var got []string
+	for _, row := range rows {
+		if row.heading != "" {
+			got = append(got, "# "+row.heading)
+			continue
+		}
+		names := make([]string, len(row.apps))
+		for i, app := range row.apps {
+			names[i] = app.Name
+		}
+		got = append(got, row.label+": "+strings.Join(names, ","))
+	}
+	want := []string{"# dev", "shop: web-dev", "# prod", "pay: billing", "shop: api,web"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("layout:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// 4 columns of label and 3 around it leave room for one 2-column cell
+	rows = mapLayout(m.mapApps(), 9)
+	if last := rows[len(rows)-1]; last.label != "" || len(last.apps) != 1 || last.apps[0].Name != "web" {
+		t.Fatalf("a namespace's cells should wrap onto unlabelled lines, got %+v", last)
+	}
+}
+
+func TestMap_NavigatesAndOpensAppInList(t *testing.T) {
+	m := buildMapTestModel()
+	m.state.Navigation.SelectedIdx = 0 // api, the list is sorted by name
+	m.state.UI.StatusFilter = model.StatusFilterProgressing
+	m.handleOpenMap()
+
+	if m.state.Modals.Map.App != "web-dev" {
+		// The quick filter shows only web-dev, so the list cursor is on it
+		t.Fatalf("map cursor should start on the list's app, got %q", m.state.Modals.Map.App)
+	}
+	for _, step := range []struct{ key, want string }{
+		{"down", "billing"},
+		{"j", "api"},
+		{"l", "web"},
+		{"k", "billing"},
+		{"h", "web-dev"},
+		{"G", "web"},
+	} {
+		m.Update(keyPress(step.key))
+		if got := m.state.Modals.Map.App; got != step.want {
+			t.Fatalf("after %s the cursor should be on %s, got %s", step.key, step.want, got)
+		}
+	}
+
+	out := stripANSI(m.renderMapModal())
+	for _, want := range []string{"App map 4 apps in 3 namespaces", "prod", "web • Healthy • Synced • prod/shop"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("map should show %q:\n%s", want, out)
+		}
+	}
+
+	m.Update(keyPress("enter"))
+	if m.state.Mode != model.ModeNormal || m.state.Navigation.View != model.ViewApps {
+		t.Fatalf("enter should open the apps view, mode %s view %s", m.state.Mode, m.state.Navigation.View)
+	}
+	if m.state.UI.StatusFilter != model.StatusFilterAll {
+		t.Error("the quick filter hiding the app should be cleared")
+	}
+	if app, ok := m.cursorApp(); !ok || app.Name != "web" {
+		t.Fatalf("list cursor should be on web, got %+v", app)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// appSearchField is a column of the apps list a search term can be limited
+// to by writing it as field:value
+type appSearchField struct {
+	names []string // the first is shown in hints
+	value func(app model.App) string
+}
+
+var appSearchFields = []appSearchField{
+	{[]string{"name"}, func(a model.App) string { return a.Name }},
+	{[]string{"ns", "namespace"}, func(a model.App) string { return derefOr(a.Namespace) }},
+	{[]string{"health"}, func(a model.App) string { return a.Health }},
+	{[]string{"sync"}, func(a model.App) string { return a.Sync }},
+	{[]string{"project", "proj"}, func(a model.App) string { return derefOr(a.Project) }},
+	{[]string{"cluster"}, func(a model.App) string {
+		if a.ClusterLabel != nil {
+			return *a.ClusterLabel
+		}
+		return derefOr(a.ClusterID)
+	}},
+	{[]string{"appset"}, func(a model.App) string { return derefOr(a.ApplicationSet) }},
+}
+
+// appSearchHint lists the fields for the line under the search bar
+var appSearchHint = func() string {
+	names := make([]string, len(appSearchFields))
+	for i, f := range appSearchFields {
+		names[i] = f.names[0] + ":"
+	}
+	return "fields: " + strings.Join(names, " ") + "  e.g. ns:prod health:degraded"
+}()
+
+// findAppSearchField looks a field up by its name or alias
+func findAppSearchField(name string) *appSearchField {
+	for i := range appSearchFields {
+		for _, n := range appSearchFields[i].names {
+			if n == name {
+				return &appSearchFields[i]
+			}
+		}
+	}
+	return nil
+}
+
+// appSearchTerm is one word of a search; field is nil for a bare word,
+// which may match any column
+type appSearchTerm struct {
+	field *appSearchField
+	value string
+}
+
+// appSearch is a parsed apps search. A query without field:value terms is
+// one plain term, matched as typed against the name, sync, health,
+// namespace and project, as searches always were.
+type appSearch struct {
+	terms []appSearchTerm
+}
+
+// isFieldTerm reports whether a word is written as field:value
+func isFieldTerm(word string) bool {
+	field, _, ok := strings.Cut(word, ":")
+	if !ok || field == "" {
+		return false
+	}
+	for _, r := range field {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAppSearch parses an apps search. Terms it cannot read are left out
+// of the result, so the list still narrows on the rest while typing, and
+// the first problem is returned as the error.
+func parseAppSearch(query string) (appSearch, error) {
+	query = strings.ToLower(query)
+	words := strings.Fields(query)
+	fielded := false
+	for _, w := range words {
+		fielded = fielded || isFieldTerm(w)
+	}
+	if !fielded {
+		if query == "" {
+			return appSearch{}, nil
+		}
+		return appSearch{terms: []appSearchTerm{{value: query}}}, nil
+	}
+
+	var s appSearch
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, w := range words {
+		if !isFieldTerm(w) {
+			s.terms = append(s.terms, appSearchTerm{value: w})
+			continue
+		}
+		name, value, _ := strings.Cut(w, ":")
+		field := findAppSearchField(name)
+		switch {
+		case field == nil:
+			fail(fmt.Errorf("unknown field %q", name))
+		case value == "":
+			fail(fmt.Errorf("%s: needs a value", name))
+		default:
+			s.terms = append(s.terms, appSearchTerm{field: field, value: value})
+		}
+	}
+	return s, firstErr
+}
+
+// matches reports whether the app matches every term of the search
+func (s appSearch) matches(app model.App) bool {
+	for _, t := range s.terms {
+		if t.field != nil {
+			if !strings.Contains(strings.ToLower(t.field.value(app)), t.value) {
+				return false
+			}
+			continue
+		}
+		found := false
+		for _, v := range []string{app.Name, app.Sync, app.Health, derefOr(app.Namespace), derefOr(app.Project)} {
+			if strings.Contains(strings.ToLower(v), t.value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestParseAppSearch(t *testing.T) {
+	prod, payments, cluster := "prod", "payments", "in-cluster"
+	apps := []model.App{
+		{Name: "payment-api", Sync: "Synced", Health: "Degraded", Namespace: &prod, Project: &payments, ClusterLabel: &cluster},
+		{Name: "payment-web", Sync: "OutOfSync", Health: "Healthy", Namespace: &prod, Project: &payments},
+		{Name: "prod-dashboard", Sync: "Synced", Health: "Healthy"},
+	}
+	tests := []struct {
+		query string
+		want  []string
+		err   string
+	}{
+		{query: "prod", want: []string{"payment-api", "payment-web", "prod-dashboard"}},
+		{query: "payment api", want: nil}, // a plain search is one term, spaces included
+		{query: "name:prod", want: []string{"prod-dashboard"}},
+		{query: "ns:prod", want: []string{"payment-api", "payment-web"}},
+		{query: "namespace:PROD health:degr", want: []string{"payment-api"}},
+		{query: "ns:prod web", want: []string{"payment-web"}},
+		{query: "cluster:in-cluster", want: []string{"payment-api"}},
+		{query: "color:red ns:prod", want: []string{"payment-api", "payment-web"}, err: `unknown field "color"`},
+		{query: "health: sync:synced", want: []string{"payment-api", "prod-dashboard"}, err: "health: needs a value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			s, err := parseAppSearch(tt.query)
+			if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+			var got []string
+			for _, app := range apps {
+				if s.matches(app) {
+					got = append(got, app.Name)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppSearch_BarShowsFieldsAndErrors(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+	m.Update(keyPress("/"))
+	if bar := stripANSI(m.renderEnhancedSearchBar()); !strings.Contains(bar, "fields: name: ns: health:") {
+		t.Fatalf("search bar should list the fields:\n%s", bar)
+	}
+
+	m.inputComponents.searchInput.SetValue("health:degraded")
+	m.state.UI.SearchQuery = "health:degraded"
+	items := m.getVisibleItems()
+	if len(items) != 1 || items[0].(model.App).Name != "zzz-other-app" {
+		t.Fatalf("health:degraded should keep only the degraded app, got %v", items)
+	}
+
+	m.inputComponents.searchInput.SetValue("heath:degraded")
+	m.state.UI.SearchQuery = "heath:degraded"
+	if bar := stripANSI(m.renderEnhancedSearchBar()); !strings.Contains(bar, `⚠ unknown field "heath"`) {
+		t.Errorf("search bar should report the unknown field:\n%s", bar)
+	}
+	m.Update(keyPress("enter"))
+	if m.state.Mode != model.ModeSearch || m.state.UI.ActiveFilter != "" {
+		t.Errorf("a search with errors should stay open, mode %v filter %q", m.state.Mode, m.state.UI.ActiveFilter)
+	}
+
+	m.inputComponents.searchInput.SetValue("health:degraded")
+	m.Update(keyPress("enter"))
+	if m.state.Mode != model.ModeNormal || m.state.UI.ActiveFilter != "health:degraded" {
+		t.Errorf("a valid search should apply, mode %v filter %q", m.state.Mode, m.state.UI.ActiveFilter)
+	}
+}
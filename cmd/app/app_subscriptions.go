@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// loadNotificationSubscriptions reads the app's subscribe annotations for
+// the details modal
+func (m *Model) loadNotificationSubscriptions(appName string, appNamespace *string) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		app, err := api.NewApplicationService(server).GetApplication(ctx, appName, appNamespace)
+		if err != nil {
+			cblog.With("component", "notifications").Error("Failed to load subscriptions", "app", appName, "err", err)
+			return model.NotificationSubscriptionsMsg{AppName: appName, AppNamespace: appNamespace, Err: err, SwitchEpoch: epoch}
+		}
+		return model.NotificationSubscriptionsMsg{AppName: appName, AppNamespace: appNamespace, Subscriptions: app.NotificationSubscriptions(), SwitchEpoch: epoch}
+	}
+}
+
+// saveNotificationSubscriptions patches the annotation of a trigger on a
+// service so it holds the recipients left for it in subs
+func (m *Model) saveNotificationSubscriptions(appName string, appNamespace *string, subs []model.NotificationSubscription, trigger, service string) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	patch := api.NotificationAnnotationPatch(subs, trigger, service)
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		cblog.With("component", "notifications").Info("Updating subscriptions", "app", appName, "trigger", trigger, "service", service)
+		app, err := api.NewApplicationService(server).PatchApplicationAnnotations(ctx, appName, appNamespace, patch)
+		if err != nil {
+			cblog.With("component", "notifications").Error("Failed to update subscriptions", "app", appName, "err", err)
+			return model.NotificationSubscriptionsMsg{AppName: appName, AppNamespace: appNamespace, Saved: true, Err: err, SwitchEpoch: epoch}
+		}
+		return model.NotificationSubscriptionsMsg{AppName: appName, AppNamespace: appNamespace, Subscriptions: app.NotificationSubscriptions(), Saved: true, SwitchEpoch: epoch}
+	}
+}
+
+// handleNotificationSubscriptions fills the details modal with loaded
+// subscriptions, or those left by a change
+func (m *Model) handleNotificationSubscriptions(msg model.NotificationSubscriptionsMsg) tea.Cmd {
+	if msg.Saved && msg.Err != nil {
+		m.recordError("notifications", fmt.Sprintf("Failed to update notification subscriptions of %s: %s", msg.AppName, extractUserFriendlyError(msg.Err)), "", nil)
+	}
+	st := m.state.Modals.AppDetails
+	if st == nil || st.AppName != msg.AppName || derefOr(st.AppNamespace) != derefOr(msg.AppNamespace) {
+		return nil
+	}
+	st.SubscriptionsLoading = false
+	st.Saving = false
+	if msg.Err != nil {
+		st.SubscriptionsError = extractUserFriendlyError(msg.Err)
+		return nil
+	}
+	st.SubscriptionsError = ""
+	st.Subscriptions = msg.Subscriptions
+	st.SubscriptionIdx = max(0, min(st.SubscriptionIdx, len(st.Subscriptions)-1))
+	if msg.Saved {
+		return m.showStatusNote("Notification subscriptions of " + msg.AppName + " updated")
+	}
+	return nil
+}
+
+// parseSubscriptionInput reads "[trigger] service recipient" as typed in
+// the details modal
+func parseSubscriptionInput(input string) (model.NotificationSubscription, error) {
+	fields := strings.Fields(input)
+	switch len(fields) {
+	case 2:
+		return model.NotificationSubscription{Service: fields[0], Recipient: fields[1]}, nil
+	case 3:
+		return model.NotificationSubscription{Trigger: fields[0], Service: fields[1], Recipient: fields[2]}, nil
+	}
+	return model.NotificationSubscription{}, fmt.Errorf("expected [trigger] service recipient, e.g. on-sync-failed slack alerts")
+}
+
+// handleSubscriptionKeys handles the details modal's subscription editor:
+// typing a new subscription, confirming a removal, or moving between
+// subscriptions. handled is false for keys the modal itself handles.
+func (m *Model) handleSubscriptionKeys(msg tea.KeyMsg) (cmd tea.Cmd, handled bool) {
+	st := m.state.Modals.AppDetails
+	key := msg.String()
+
+	if st.SubscriptionInput != nil {
+		switch key {
+		case "esc", "ctrl+c":
+			st.SubscriptionInput = nil
+			st.SubscriptionsError = ""
+		case "enter":
+			return m.addSubscription(), true
+		case "backspace":
+			if input := *st.SubscriptionInput; input != "" {
+				*st.SubscriptionInput = input[:len(input)-1]
+			}
+		case "space":
+			*st.SubscriptionInput += " "
+		default:
+			if len(key) == 1 {
+				*st.SubscriptionInput += key
+			}
+		}
+		return nil, true
+	}
+
+	if st.ConfirmRemove {
+		st.ConfirmRemove = false
+		if key == "y" {
+			return m.removeSubscription(), true
+		}
+		return nil, true
+	}
+
+	switch key {
+	case "up", "k":
+		if st.SubscriptionIdx > 0 {
+			st.SubscriptionIdx--
+		}
+	case "down", "j":
+		if st.SubscriptionIdx < len(st.Subscriptions)-1 {
+			st.SubscriptionIdx++
+		}
+	case "a":
+		if st.SubscriptionsLoading || st.Saving {
+			return nil, true
+		}
+		input := ""
+		st.SubscriptionInput = &input
+		st.SubscriptionsError = ""
+	case "d":
+		if len(st.Subscriptions) > 0 && !st.Saving {
+			st.ConfirmRemove = true
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// addSubscription saves the subscription typed in the details modal
+func (m *Model) addSubscription() tea.Cmd {
+	st := m.state.Modals.AppDetails
+	sub, err := parseSubscriptionInput(*st.SubscriptionInput)
+	if err != nil {
+		st.SubscriptionsError = err.Error()
+		return nil
+	}
+	st.SubscriptionInput = nil
+	st.SubscriptionsError = ""
+	if idx := slices.Index(st.Subscriptions, sub); idx >= 0 {
+		st.SubscriptionIdx = idx
+		return nil
+	}
+	cmd := m.saveNotificationSubscriptions(st.AppName, st.AppNamespace, append(slices.Clone(st.Subscriptions), sub), sub.Trigger, sub.Service)
+	st.Saving = cmd != nil
+	return cmd
+}
+
+// removeSubscription saves the subscriptions without the selected one
+func (m *Model) removeSubscription() tea.Cmd {
+	st := m.state.Modals.AppDetails
+	if st.SubscriptionIdx >= len(st.Subscriptions) {
+		return nil
+	}
+	sub := st.Subscriptions[st.SubscriptionIdx]
+	cmd := m.saveNotificationSubscriptions(st.AppName, st.AppNamespace, slices.Delete(slices.Clone(st.Subscriptions), st.SubscriptionIdx, st.SubscriptionIdx+1), sub.Trigger, sub.Service)
+	st.Saving = cmd != nil
+	return cmd
+}
+
+// subscriptionLines renders the details modal's notifications section
+func (m *Model) subscriptionLines(st *model.AppDetailsState, innerWidth int) []string {
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	lines := []string{"", lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render("Notifications")}
+
+	switch {
+	case st.SubscriptionsLoading:
+		lines = append(lines, dim.Render("  Loading subscriptions…"))
+	case len(st.Subscriptions) == 0:
+		lines = append(lines, dim.Render("  no subscriptions"))
+	}
+	for i, sub := range st.Subscriptions {
+		trigger := sub.Trigger
+		if trigger == "" {
+			trigger = "(default triggers)"
+		}
+		line := truncateWithEllipsis(fmt.Sprintf("  %-24s %-10s %s", trigger, sub.Service, sub.Recipient), innerWidth)
+		if i == st.SubscriptionIdx {
+			line = lipgloss.NewStyle().Background(cyanBright).Foreground(textOnAccent).Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	switch {
+	case st.SubscriptionInput != nil:
+		lines = append(lines, "", truncateWithEllipsis("Add: "+*st.SubscriptionInput+"█", innerWidth),
+			dim.Render(truncateWithEllipsis("[trigger] service recipient • Enter save • Esc cancel", innerWidth)))
+	case st.ConfirmRemove && st.SubscriptionIdx < len(st.Subscriptions):
+		sub := st.Subscriptions[st.SubscriptionIdx]
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(yellowBright).Render(
+			truncateWithEllipsis(fmt.Sprintf("Remove %s from %s? y to confirm", sub.Recipient, sub.Service), innerWidth)))
+	case st.Saving:
+		lines = append(lines, "", dim.Render("Saving…"))
+	}
+	if st.SubscriptionsError != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(outOfSyncColor).Render(truncateWithEllipsis(st.SubscriptionsError, innerWidth)))
+	}
+	return lines
+}
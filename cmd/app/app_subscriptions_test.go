@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestAppDetails_EditsNotificationSubscriptions(t *testing.T) {
+	annotations := map[string]any{"notifications.argoproj.io/subscribe.on-sync-failed.slack": "alerts"}
+	var patches []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			raw, _ := io.ReadAll(r.Body)
+			var body struct{ Patch string }
+			json.Unmarshal(raw, &body)
+			patches = append(patches, body.Patch)
+			var patch struct {
+				Metadata struct{ Annotations map[string]any }
+			}
+			json.Unmarshal([]byte(body.Patch), &patch)
+			for k, v := range patch.Metadata.Annotations {
+				if v == nil {
+					delete(annotations, k)
+				} else {
+					annotations[k] = v
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"metadata": map[string]any{"name": "test-app", "annotations": annotations}})
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+
+	_, cmd := m.handleKeyMsg(testKeyMsg("i"))
+	if cmd == nil || !m.state.Modals.AppDetails.SubscriptionsLoading {
+		t.Fatal("opening details should load the subscriptions")
+	}
+	m.Update(cmd())
+	st := m.state.Modals.AppDetails
+	if len(st.Subscriptions) != 1 || st.Subscriptions[0].Recipient != "alerts" {
+		t.Fatalf("expected the alerts subscription, got %+v", st.Subscriptions)
+	}
+
+	m.Update(keyPress("a"))
+	typeText(m, "slack")
+	m.Update(keyPress("enter"))
+	if st.SubscriptionInput == nil || !strings.Contains(st.SubscriptionsError, "expected [trigger] service recipient") {
+		t.Fatalf("a subscription without a recipient should be refused, got error %q", st.SubscriptionsError)
+	}
+	for range len("slack") {
+		m.Update(keyPress("backspace"))
+	}
+	typeText(m, "on-sync-failed slack ops")
+	if got := *st.SubscriptionInput; got != "on-sync-failed slack ops" {
+		t.Fatalf("input = %q", got)
+	}
+	_, cmd = m.Update(keyPress("enter"))
+	if cmd == nil || !st.Saving {
+		t.Fatal("enter should save the new subscription")
+	}
+	m.Update(cmd())
+	if want := `{"metadata":{"annotations":{"notifications.argoproj.io/subscribe.on-sync-failed.slack":"alerts;ops"}}}`; len(patches) != 1 || patches[0] != want {
+		t.Fatalf("patches = %q, want %q", patches, want)
+	}
+	if st.Saving || len(st.Subscriptions) != 2 {
+		t.Fatalf("the saved subscriptions should be shown, got %+v", st.Subscriptions)
+	}
+
+	out := stripANSI(m.renderAppDetailsModal())
+	if !strings.Contains(out, "Notifications") || !strings.Contains(out, "on-sync-failed") || !strings.Contains(out, "ops") {
+		t.Errorf("details should list the subscriptions:\n%s", out)
+	}
+
+	// Removing the last recipient of a trigger removes its annotation
+	m.Update(keyPress("d"))
+	m.Update(keyPress("n"))
+	if st.ConfirmRemove || len(patches) != 1 {
+		t.Fatal("any key but y should cancel the removal")
+	}
+	m.Update(keyPress("d"))
+	_, cmd = m.Update(keyPress("y"))
+	m.Update(cmd())
+	m.Update(keyPress("d"))
+	_, cmd = m.Update(keyPress("y"))
+	m.Update(cmd())
+	if want := `{"metadata":{"annotations":{"notifications.argoproj.io/subscribe.on-sync-failed.slack":null}}}`; patches[len(patches)-1] != want {
+		t.Fatalf("last patch = %q, want %q", patches[len(patches)-1], want)
+	}
+	if len(st.Subscriptions) != 0 || m.state.Mode != model.ModeAppDetails {
+		t.Fatalf("all subscriptions should be gone with the modal still open, got %+v", st.Subscriptions)
+	}
+}
+
+func TestAppDetails_SubscriptionSaveFailureIsRecorded(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.openAppDetails("test-app", m.state.Apps[0].AppNamespace)
+	m.state.Modals.AppDetails.Saving = true
+
+	m.Update(model.NotificationSubscriptionsMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Saved: true, Err: io.ErrUnexpectedEOF})
+	st := m.state.Modals.AppDetails
+	if st.Saving || st.SubscriptionsError == "" {
+		t.Fatalf("the failure should show in the modal, got %+v", st)
+	}
+	if len(m.state.RecentErrors) != 1 || m.state.RecentErrors[0].Source != "notifications" {
+		t.Fatalf("the failure should be recorded, got %+v", m.state.RecentErrors)
+	}
+}
+
+// typeText presses the keys that type text
+func typeText(m *Model, text string) {
+	for _, r := range text {
+		if r == ' ' {
+			m.Update(keyPress("space"))
+			continue
+		}
+		m.Update(keyPress(string(r)))
+	}
+}
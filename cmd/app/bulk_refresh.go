@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// bulkRefreshFailedShown is how many failed apps the progress modal lists
+const bulkRefreshFailedShown = 5
+
+// bulkRefreshStepMsg reports the refresh of one app of a bulk refresh
+type bulkRefreshStepMsg struct {
+	id    int
+	epoch int
+	app   string
+	err   error
+}
+
+// handleBulkRefreshCommand refreshes every app of the project or
+// ApplicationSet named by arg, or else the one under the cursor
+func (m *Model) handleBulkRefreshCommand(arg string, hard bool) (tea.Model, tea.Cmd) {
+	scope := "project"
+	if m.state.Navigation.View == model.ViewApplicationSets {
+		scope = "ApplicationSet"
+	}
+	name := arg
+	if name == "" {
+		items := m.getVisibleItemsForCurrentView()
+		if m.state.Navigation.SelectedIdx >= len(items) {
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: "No " + scope + " selected for refresh"} }
+		}
+		name = fmt.Sprintf("%v", items[m.state.Navigation.SelectedIdx])
+	}
+
+	var apps []model.App
+	for _, app := range m.state.Apps {
+		member := derefOr(app.Project) == name
+		if scope == "ApplicationSet" {
+			member = derefOr(app.ApplicationSet) == name
+		}
+		if member {
+			apps = append(apps, app)
+		}
+	}
+	if len(apps) == 0 {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: fmt.Sprintf("No apps in %s %s", scope, name)} }
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+	return m, m.startBulkRefresh(scope, name, apps, hard)
+}
+
+// startBulkRefresh opens the progress modal and refreshes the first app;
+// each completed refresh starts the next
+func (m *Model) startBulkRefresh(scope, name string, apps []model.App, hard bool) tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg { return model.ApiErrorMsg{Message: "No server configured"} }
+	}
+	m.bulkRefreshSeq++
+	m.state.Modals.BulkRefresh = &model.BulkRefreshState{
+		Scope: scope,
+		Name:  name,
+		Hard:  hard,
+		Apps:  apps,
+		ID:    m.bulkRefreshSeq,
+	}
+	m.state.Mode = model.ModeBulkRefresh
+	cblog.With("component", "refresh").Info("Refreshing all apps", "scope", scope, "name", name, "apps", len(apps), "hard", hard)
+	return m.bulkRefreshNext()
+}
+
+// bulkRefreshNext refreshes the next app of the running bulk refresh
+func (m *Model) bulkRefreshNext() tea.Cmd {
+	st := m.state.Modals.BulkRefresh
+	app := st.Apps[st.Done]
+	id := st.ID
+	hard := st.Hard
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		err := api.NewApplicationService(server).RefreshApplication(ctx, app.Name, &api.RefreshOptions{
+			Hard:         hard,
+			AppNamespace: app.AppNamespace,
+		})
+		if err != nil {
+			cblog.With("component", "refresh").Error("Refresh failed", "app", app.Name, "err", err)
+		}
+		return bulkRefreshStepMsg{id: id, epoch: epoch, app: app.Name, err: err}
+	}
+}
+
+// handleBulkRefreshStep counts a refreshed app and starts the next one, or
+// reports the outcome once every app was refreshed
+func (m *Model) handleBulkRefreshStep(msg bulkRefreshStepMsg) tea.Cmd {
+	st := m.state.Modals.BulkRefresh
+	if st == nil || st.ID != msg.id || st.Finished {
+		return nil
+	}
+	st.Done++
+	if msg.err != nil {
+		st.Failed = append(st.Failed, msg.app)
+	}
+	if st.Done < len(st.Apps) {
+		return m.bulkRefreshNext()
+	}
+
+	st.Finished = true
+	summary := fmt.Sprintf("%s initiated for %d app(s) of %s %s", bulkRefreshVerb(st.Hard), len(st.Apps)-len(st.Failed), st.Scope, st.Name)
+	if len(st.Failed) > 0 {
+		summary += fmt.Sprintf(", %d failed", len(st.Failed))
+		m.recordError("refresh", fmt.Sprintf("%s %s: %d of %d refreshes failed", st.Scope, st.Name, len(st.Failed), len(st.Apps)),
+			strings.Join(st.Failed, ", "), nil)
+	}
+	return func() tea.Msg { return model.StatusChangeMsg{Status: summary} }
+}
+
+func bulkRefreshVerb(hard bool) string {
+	if hard {
+		return "Hard refresh"
+	}
+	return "Refresh"
+}
+
+// handleBulkRefreshKeys handles input in the bulk refresh modal; closing it
+// while refreshing skips the apps not refreshed yet
+func (m *Model) handleBulkRefreshKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "enter":
+		st := m.state.Modals.BulkRefresh
+		m.state.Modals.BulkRefresh = nil
+		m.state.Mode = model.ModeNormal
+		if st != nil && !st.Finished {
+			status := fmt.Sprintf("Stopped refreshing %s %s after %d of %d apps", st.Scope, st.Name, st.Done, len(st.Apps))
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+		}
+	}
+	return m, nil
+}
+
+// renderBulkRefreshModal renders the progress of a project or
+// ApplicationSet refresh: a bar with the count, the app being refreshed
+// and the apps whose refresh failed
+func (m *Model) renderBulkRefreshModal() string {
+	st := m.state.Modals.BulkRefresh
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(50, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	bad := lipgloss.NewStyle().Foreground(outOfSyncColor)
+
+	verb := "Refreshing"
+	if st.Hard {
+		verb = "Hard refreshing"
+	}
+	border := cyanBright
+	if st.Finished {
+		verb = bulkRefreshVerb(st.Hard) + " done for"
+		border = syncedColor
+		if len(st.Failed) > 0 {
+			border = outOfSyncColor
+		}
+	}
+	title := fmt.Sprintf("%s %s %s", verb, st.Scope, st.Name)
+	lines := []string{lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render(truncateWithEllipsis(title, innerWidth)), ""}
+
+	total := len(st.Apps)
+	count := fmt.Sprintf(" %d/%d", st.Done, total)
+	barWidth := max(10, innerWidth-len(count))
+	filled := 0
+	if total > 0 {
+		filled = barWidth * st.Done / total
+	}
+	bar := lipgloss.NewStyle().Foreground(syncedColor).Render(strings.Repeat("█", filled)) +
+		dim.Render(strings.Repeat("░", barWidth-filled))
+	lines = append(lines, bar+count)
+
+	if !st.Finished && st.Done < total {
+		lines = append(lines, dim.Render(truncateWithEllipsis("refreshing "+st.Apps[st.Done].Name, innerWidth)))
+	}
+	if n := len(st.Failed); n > 0 {
+		lines = append(lines, "", bad.Render(fmt.Sprintf("%d failed:", n)))
+		for _, name := range st.Failed[:min(n, bulkRefreshFailedShown)] {
+			lines = append(lines, bad.Render(truncateWithEllipsis("  "+name, innerWidth)))
+		}
+		if n > bulkRefreshFailedShown {
+			lines = append(lines, dim.Render(fmt.Sprintf("  … %d more in :errors", n-bulkRefreshFailedShown)))
+		}
+	}
+
+	help := "Esc to stop"
+	if st.Finished {
+		help = "Esc to close"
+	}
+	lines = append(lines, "", dim.Render(help))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestBulkRefresh_RefreshesEveryAppOfTheProject(t *testing.T) {
+	var mu sync.Mutex
+	var refreshed []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/api/v1/applications/")
+		mu.Lock()
+		refreshed = append(refreshed, name+"?"+r.URL.Query().Get("refresh"))
+		mu.Unlock()
+		if name == "broken" {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL}
+	project := "test-project"
+	m.state.Apps = append(m.state.Apps,
+		model.App{Name: "broken", Project: &project},
+		model.App{Name: "another", Project: &project},
+	)
+	m.state.Navigation.View = model.ViewProjects
+	m.state.Navigation.SelectedIdx = 0
+
+	_, cmd := m.handleRefreshCommand("", true)
+	if m.state.Mode != model.ModeBulkRefresh {
+		t.Fatalf("expected the progress modal, got mode %s", m.state.Mode)
+	}
+	if view := stripANSI(m.renderBulkRefreshModal()); !strings.Contains(view, "0/3") || !strings.Contains(view, "refreshing another") {
+		t.Errorf("expected the progress before the first refresh, got:\n%s", view)
+	}
+	for cmd != nil {
+		msg := cmd()
+		if _, ok := msg.(bulkRefreshStepMsg); !ok {
+			break
+		}
+		_, cmd = m.Update(msg)
+	}
+
+	if got := strings.Join(refreshed, ","); got != "another?hard,broken?hard,test-app?hard" {
+		t.Errorf("expected a hard refresh of every project app, got %s", got)
+	}
+	st := m.state.Modals.BulkRefresh
+	if st == nil || !st.Finished || len(st.Failed) != 1 || st.Failed[0] != "broken" {
+		t.Fatalf("expected a finished run with one failure, got %+v", st)
+	}
+	view := stripANSI(m.renderBulkRefreshModal())
+	if !strings.Contains(view, "3/3") || !strings.Contains(view, "1 failed") {
+		t.Errorf("expected the outcome in the modal, got:\n%s", view)
+	}
+	if len(m.state.RecentErrors) != 1 {
+		t.Errorf("the failures should be kept in :errors, got %v", m.state.RecentErrors)
+	}
+}
+
+func TestBulkRefresh_StoppingIgnoresLaterSteps(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.state.Navigation.View = model.ViewProjects
+	m.handleRefreshCommand("test-project", false)
+	id := m.state.Modals.BulkRefresh.ID
+
+	m.handleBulkRefreshKeys(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal || m.state.Modals.BulkRefresh != nil {
+		t.Fatal("esc should stop and close the refresh")
+	}
+	if cmd := m.handleBulkRefreshStep(bulkRefreshStepMsg{id: id, app: "test-app"}); cmd != nil {
+		t.Error("a step of a stopped refresh should not start another")
+	}
+
+	_, cmd := m.handleRefreshCommand("nowhere", false)
+	if m.state.Mode == model.ModeBulkRefresh || cmd == nil {
+		t.Error("a project without apps should only report it")
+	}
+}
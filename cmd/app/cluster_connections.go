@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// clusterConnectionsRefreshInterval is how often cluster connection states
+// are re-read; Argo CD itself re-checks clusters every few minutes
+const clusterConnectionsRefreshInterval = 2 * time.Minute
+
+// clusterConnectionMarker is appended to the name of apps whose destination
+// cluster Argo CD cannot reach
+const clusterConnectionMarker = "⚠"
+
+// startClusterConnections does the first cluster read for the current
+// context; later reads are chained by the refresh timer
+func (m *Model) startClusterConnections() tea.Cmd {
+	if m.clusterPolling {
+		return nil
+	}
+	cmd := m.fetchClusterConnections()
+	m.clusterPolling = cmd != nil
+	return cmd
+}
+
+// fetchClusterConnections reads the connection state and Kubernetes version
+// of every cluster the user can see. Failures, such as no permission to
+// list clusters, are logged and leave the current result in place.
+func (m *Model) fetchClusterConnections() tea.Cmd {
+	epoch := m.switchEpoch
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		clusters, err := api.NewApplicationService(server).ListClusters(ctx)
+		if err != nil {
+			cblog.With("component", "clusters").Debug("Could not load clusters", "err", err)
+			return model.ClusterConnectionsLoadedMsg{Err: err, SwitchEpoch: epoch}
+		}
+		return model.ClusterConnectionsLoadedMsg{
+			Failed:      api.FailedClusterConnections(clusters),
+			Versions:    api.ClusterVersions(clusters),
+			SwitchEpoch: epoch,
+		}
+	}
+}
+
+// scheduleClusterConnectionsRefresh re-reads cluster states after the refresh interval
+func (m *Model) scheduleClusterConnectionsRefresh() tea.Cmd {
+	epoch := m.switchEpoch
+	return tea.Tick(clusterConnectionsRefreshInterval, func(time.Time) tea.Msg {
+		return model.ClusterConnectionsRefreshMsg{SwitchEpoch: epoch}
+	})
+}
+
+// clusterConnectionError returns why the app's destination cluster cannot
+// be reached, or false when the connection is fine or unknown
+func (m *Model) clusterConnectionError(app model.App) (string, bool) {
+	if app.ClusterID == nil {
+		return "", false
+	}
+	msg, ok := m.state.FailedClusters[*app.ClusterID]
+	return msg, ok
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestClusterConnections_MarkAppsOnFailedClusters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"server": "https://prod.example.com", "name": "prod", "info": {"connectionState": {"status": "Failed", "message": "token has expired"}}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+	prod, other := "prod", "staging"
+	m.state.Apps[0].ClusterID = &prod
+	m.state.Apps[1].ClusterID = &other
+
+	cmd := m.startClusterConnections()
+	if cmd == nil {
+		t.Fatal("expected a cluster fetch")
+	}
+	if m.startClusterConnections() != nil {
+		t.Error("polling should only be started once per context")
+	}
+	msg, ok := cmd().(model.ClusterConnectionsLoadedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if _, next := m.Update(msg); next == nil {
+		t.Error("a refresh should be scheduled after loading")
+	}
+
+	if row := stripANSI(m.renderAppRow(m.state.Apps[0], false)); !strings.Contains(row, "test-app "+clusterConnectionMarker) {
+		t.Errorf("row missing cluster marker: %q", row)
+	}
+	if row := stripANSI(m.renderAppRow(m.state.Apps[1], false)); strings.Contains(row, clusterConnectionMarker) {
+		t.Errorf("app on a healthy cluster should not be marked: %q", row)
+	}
+
+	m.handleOpenAppDetails()
+	if out := stripANSI(m.renderAppDetailsModal()); !strings.Contains(out, "cluster unreachable: token has expired") {
+		t.Errorf("details should explain the failed connection:\n%s", out)
+	}
+}
+
+func TestClusterConnections_KeptOnErrorAndGatedByEpoch(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	failed := map[string]string{"prod": "token has expired"}
+	m.Update(model.ClusterConnectionsLoadedMsg{Failed: failed, SwitchEpoch: m.switchEpoch})
+	m.Update(model.ClusterConnectionsLoadedMsg{Err: errors.New("forbidden"), SwitchEpoch: m.switchEpoch})
+	if len(m.state.FailedClusters) != 1 {
+		t.Error("a failed refresh should keep the current result")
+	}
+	m.Update(model.ClusterConnectionsLoadedMsg{SwitchEpoch: m.switchEpoch + 1})
+	if len(m.state.FailedClusters) != 1 {
+		t.Error("a result from another context must be ignored")
+	}
+	m.Update(model.ClusterConnectionsLoadedMsg{SwitchEpoch: m.switchEpoch})
+	if len(m.state.FailedClusters) != 0 {
+		t.Error("a recovered cluster should clear the marker")
+	}
+}
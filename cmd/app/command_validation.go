@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// commandMenuMax is how many fuzzy matches the command bar menu shows at once
+const commandMenuMax = 5
+
+// commandArgKinds names the argument types the command bar checks while
+// typing. Files and Argo CD contexts are only known when the command runs.
+var commandArgKinds = map[string]string{
+	"app":        "app",
+	"appset":     "appset",
+	"cluster":    "cluster",
+	"namespace":  "namespace",
+	"project":    "project",
+	"theme":      "theme",
+	"sort":       "sort field",
+	"help-topic": "help topic",
+	"profile":    "profile target",
+}
+
+// commandArgument splits the input into the canonical command and the first
+// argument still being typed; ok is false when no such argument is in progress
+func (m *Model) commandArgument(input string) (canonical, arg string, ok bool) {
+	parts := strings.Fields(input)
+	trailingSpace := strings.HasSuffix(input, " ")
+	switch {
+	case len(parts) == 1 && trailingSpace:
+	case len(parts) == 2 && !trailingSpace:
+		arg = parts[1]
+	default:
+		return "", "", false
+	}
+	canonical = m.autocompleteEngine.ResolveAlias(strings.ToLower(parts[0]))
+	info := m.autocompleteEngine.GetCommandInfo(canonical)
+	if info == nil || !info.TakesArg || commandArgKinds[info.ArgType] == "" {
+		return "", "", false
+	}
+	if canonical == "wait" && strings.HasPrefix(arg, "-") {
+		return "", "", false
+	}
+	return canonical, arg, true
+}
+
+// commandMenu returns the fuzzy matches for the argument being typed. It is
+// empty once the argument is the only match, so a finished name hides it.
+func (m *Model) commandMenu(input string) []string {
+	canonical, arg, ok := m.commandArgument(input)
+	if !ok {
+		return nil
+	}
+	matches := m.autocompleteEngine.GetFuzzyArgumentMatches(canonical, arg, m.state)
+	if len(matches) == 1 && strings.EqualFold(matches[0], arg) {
+		return nil
+	}
+	return matches
+}
+
+// commandMenuSelection returns the input with the highlighted menu match in
+// place of the argument being typed
+func (m *Model) commandMenuSelection(input string) (string, bool) {
+	matches := m.commandMenu(input)
+	if len(matches) == 0 {
+		return "", false
+	}
+	idx := m.state.UI.CommandMenuIdx
+	if idx < 0 || idx >= len(matches) {
+		idx = 0
+	}
+	return strings.Fields(input)[0] + " " + matches[idx], true
+}
+
+// moveCommandMenu moves the menu highlight by delta, wrapping at both ends
+func (m *Model) moveCommandMenu(delta int) bool {
+	matches := m.commandMenu(m.inputComponents.GetCommandValue())
+	if len(matches) == 0 {
+		return false
+	}
+	m.state.UI.CommandMenuIdx = (m.state.UI.CommandMenuIdx + delta + len(matches)) % len(matches)
+	return true
+}
+
+// commandDiagnosis explains what is wrong with the command bar input, or
+// returns "" when it is valid or could still become valid as typing goes on
+func (m *Model) commandDiagnosis(input string) string {
+	parts := strings.Fields(input)
+	if len(parts) == 0 {
+		return ""
+	}
+	trailingSpace := strings.HasSuffix(input, " ")
+
+	canonical := m.autocompleteEngine.ResolveAlias(strings.ToLower(parts[0]))
+	info := m.autocompleteEngine.GetCommandInfo(canonical)
+	if info == nil {
+		if len(parts) == 1 && !trailingSpace && len(m.autocompleteEngine.GetCommandAutocomplete(":"+parts[0], m.state)) > 0 {
+			return ""
+		}
+		return fmt.Sprintf("unknown command %q, see :help", parts[0])
+	}
+	if len(parts) < 2 {
+		return ""
+	}
+
+	kind := commandArgKinds[info.ArgType]
+	arg := parts[1]
+	if kind == "" || (canonical == "wait" && strings.HasPrefix(arg, "-")) {
+		return ""
+	}
+	if len(parts) == 2 && !trailingSpace {
+		if len(m.autocompleteEngine.GetFuzzyArgumentMatches(canonical, arg, m.state)) == 0 {
+			return fmt.Sprintf("no %s matches %q", kind, arg)
+		}
+		return ""
+	}
+
+	if canonical == "sort" {
+		if !model.IsValidSortField(strings.ToLower(arg)) {
+			return fmt.Sprintf("unknown %s %q", kind, arg)
+		}
+		if len(parts) == 2 {
+			return ""
+		}
+		if len(parts) > 3 {
+			return "usage: :sort <name|sync|health|operation|duration> <asc|desc>"
+		}
+		direction := strings.ToLower(parts[2])
+		if !trailingSpace && (strings.HasPrefix("asc", direction) || strings.HasPrefix("desc", direction)) {
+			return ""
+		}
+		if !model.IsValidSortDirection(direction) {
+			return fmt.Sprintf("unknown sort direction %q", parts[2])
+		}
+		return ""
+	}
+
+	if !m.validateCommand(parts[0] + " " + arg) {
+		return fmt.Sprintf("unknown %s %q", kind, arg)
+	}
+	return ""
+}
+
+// renderCommandMenu renders the fuzzy matches below the command input,
+// scrolled so the highlighted one is visible
+func (m *Model) renderCommandMenu(input string, width int) string {
+	matches := m.commandMenu(input)
+	if len(matches) == 0 {
+		return ""
+	}
+	idx := m.state.UI.CommandMenuIdx
+	if idx < 0 || idx >= len(matches) {
+		idx = 0
+	}
+	start := max(0, idx-commandMenuMax+1)
+	end := min(len(matches), start+commandMenuMax)
+
+	line := "  "
+	for i := start; i < end; i++ {
+		item := statusStyle.Render(" " + matches[i] + " ")
+		if i == idx {
+			item = selectedStyle.Render(" " + matches[i] + " ")
+		}
+		if lipgloss.Width(line)+lipgloss.Width(item) > width {
+			end = i
+			break
+		}
+		line += item
+	}
+	if hidden := len(matches) - (end - start); hidden > 0 {
+		more := statusStyle.Render(fmt.Sprintf(" +%d more", hidden))
+		if lipgloss.Width(line)+lipgloss.Width(more) <= width {
+			line += more
+		}
+	}
+	return padRight(line, width)
+}
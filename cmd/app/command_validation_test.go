@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func buildCommandTestModel() *Model {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps = append(m.state.Apps,
+		model.App{Name: "payment-api", Sync: "Synced", Health: "Healthy"},
+		model.App{Name: "payment-web", Sync: "Synced", Health: "Healthy"},
+	)
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	m.handleEnhancedEnterCommandMode()
+	return m
+}
+
+func TestCommandDiagnosis(t *testing.T) {
+	m := buildCommandTestModel()
+	for _, tc := range []struct{ input, want string }{
+		{"syn", ""},
+		{"bogus", `unknown command "bogus", see :help`},
+		{"sync pay", ""},
+		{"sync pmtw", ""},
+		{"sync xyz", `no app matches "xyz"`},
+		{"sync xyz ", `unknown app "xyz"`},
+		{"sync payment-api ", ""},
+		{"wait --for=healthy", ""},
+		{"sort name as", ""},
+		{"sort name back", `unknown sort direction "back"`},
+		{"sort size ", `unknown sort field "size"`},
+		{"context anything ", ""},
+	} {
+		if got := m.commandDiagnosis(tc.input); got != tc.want {
+			t.Errorf("commandDiagnosis(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestCommandBar_ShowsErrorBeforeEnter(t *testing.T) {
+	m := buildCommandTestModel()
+	typeText(m, "sync xyz")
+
+	out := stripANSI(m.renderEnhancedCommandBar())
+	if !strings.Contains(out, "⚠") || !strings.Contains(out, `(no app matches "xyz")`) {
+		t.Fatalf("the bar should flag the argument while typing:\n%s", out)
+	}
+
+	m.Update(keyPress("enter"))
+	if m.state.Mode != model.ModeCommand || !m.state.UI.CommandInvalid {
+		t.Fatalf("enter should keep the invalid command open, mode %s", m.state.Mode)
+	}
+	if out := stripANSI(m.renderEnhancedCommandBar()); !strings.Contains(out, `(unknown app "xyz")`) {
+		t.Fatalf("after enter the argument counts as finished:\n%s", out)
+	}
+}
+
+func TestCommandBar_FuzzyMenuCompletesArgument(t *testing.T) {
+	m := buildCommandTestModel()
+	typeText(m, "sync pmt")
+
+	out := stripANSI(m.renderEnhancedCommandBar())
+	if !strings.Contains(out, "payment-api") || !strings.Contains(out, "payment-web") {
+		t.Fatalf("the menu should list the fuzzy matches:\n%s", out)
+	}
+	if strings.Contains(out, "⚠") {
+		t.Fatalf("a partial argument with matches is not an error:\n%s", out)
+	}
+
+	m.Update(keyPress("down"))
+	m.Update(keyPress("down"))
+	m.Update(keyPress("up"))
+	if m.state.UI.CommandMenuIdx != 1 {
+		t.Fatalf("down, down, up should highlight the second match, got %d", m.state.UI.CommandMenuIdx)
+	}
+	m.Update(keyPress("tab"))
+	if got := m.inputComponents.GetCommandValue(); got != "sync payment-web" {
+		t.Fatalf("tab should complete the highlighted match, got %q", got)
+	}
+	if menu := m.commandMenu(m.inputComponents.GetCommandValue()); menu != nil {
+		t.Fatalf("a finished name should hide the menu, got %v", menu)
+	}
+}
+
+func TestCommandBar_EnterAcceptsHighlightedMatch(t *testing.T) {
+	m := buildCommandTestModel()
+	typeText(m, "resources pweb")
+	m.Update(keyPress("enter"))
+
+	if m.state.Mode == model.ModeCommand {
+		t.Fatalf("enter should run the command with the fuzzy match, bar shows %q", m.inputComponents.GetCommandValue())
+	}
+	if m.state.UI.CommandInvalid {
+		t.Fatal("the fuzzy match should not be flagged invalid")
+	}
+}
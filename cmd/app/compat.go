@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/compat"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// endpointsProbedMsg carries which optional endpoints the server serves
+type endpointsProbedMsg struct {
+	epoch  int
+	served map[compat.Feature]bool
+}
+
+// handleAPIVersion records the server version and checks it against the
+// supported releases
+func (m *Model) handleAPIVersion(version string) tea.Cmd {
+	m.state.APIVersion = version
+	if _, ok := compat.Check(version); !ok {
+		cblog.With("component", "compat").Debug("Server version is not a release, skipping checks", "version", version)
+	}
+	return m.refreshCompat()
+}
+
+// probeEndpoints asks the server which optional endpoints it serves
+func (m *Model) probeEndpoints() tea.Cmd {
+	if m.state.Server == nil {
+		return nil
+	}
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		served := api.NewApplicationService(server).ProbeEndpoints(ctx)
+		cblog.With("component", "compat").Debug("Probed endpoints", "served", served)
+		return endpointsProbedMsg{epoch: epoch, served: served}
+	}
+}
+
+// handleEndpointsProbed records the probe results
+func (m *Model) handleEndpointsProbed(msg endpointsProbedMsg) tea.Cmd {
+	for f, served := range msg.served {
+		m.setEndpointServed(f, served)
+	}
+	return m.refreshCompat()
+}
+
+// markEndpointMissing turns a feature off after its endpoint answered that
+// it has no such route, and returns why it is off
+func (m *Model) markEndpointMissing(f compat.Feature) (string, tea.Cmd) {
+	m.setEndpointServed(f, false)
+	cmd := m.refreshCompat()
+	return m.unavailable(f), cmd
+}
+
+// setEndpointServed records whether the server serves a feature's endpoint
+func (m *Model) setEndpointServed(f compat.Feature, served bool) {
+	if m.probedEndpoints == nil {
+		m.probedEndpoints = make(map[compat.Feature]bool)
+	}
+	m.probedEndpoints[f] = served
+}
+
+// refreshCompat rebuilds the compatibility report from the server version
+// and the endpoint probes. A server outside the supported releases, or
+// lacking some features, gets a warning once per context naming what is
+// turned off; when another modal is open the warning goes to the status
+// line and the :errors drawer instead of interrupting it.
+func (m *Model) refreshCompat() tea.Cmd {
+	report, known := compat.Check(m.state.APIVersion)
+	if !known && len(m.probedEndpoints) == 0 {
+		return nil
+	}
+	for f, served := range m.probedEndpoints {
+		report.Probed(f, served)
+	}
+	m.serverCompat = &report
+	if m.compatWarned || !report.Problems() {
+		return nil
+	}
+	m.compatWarned = true
+
+	summary := report.Summary()
+	cblog.With("component", "compat").Warn(summary, "disabled", report.Disabled())
+	m.recordError("compat", summary, strings.Join(report.Disabled(), "\n"), nil)
+	if m.state.Mode == model.ModeNormal {
+		m.state.Mode = model.ModeCompatWarning
+		return nil
+	}
+	return m.showStatusNote("⚠ " + summary + " • :errors for details")
+}
+
+// unavailable explains why a feature is turned off for the connected
+// server, or returns "" when it can be used (or the version is unknown)
+func (m *Model) unavailable(f compat.Feature) string {
+	if m.serverCompat == nil {
+		return ""
+	}
+	return m.serverCompat.Unavailable(f)
+}
+
+// handleCompatWarningKeys dismisses the compatibility warning
+func (m *Model) handleCompatWarningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc", "q":
+		m.state.Mode = model.ModeNormal
+	}
+	return m, nil
+}
+
+// renderCompatWarningModal renders the server version warning with the
+// features turned off for it
+func (m *Model) renderCompatWarningModal() string {
+	report := m.serverCompat
+	if report == nil {
+		return ""
+	}
+
+	modalWidth := layout.FitModal(m.state.Terminal.Cols, 64)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	text := lipgloss.NewStyle().Foreground(whiteBright)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	title := lipgloss.NewStyle().
+		Foreground(progressColor).
+		Bold(true).
+		Render("⚠ Argo CD compatibility")
+
+	lines := []string{title, "", text.Width(innerWidth).Render(report.Summary() + ".")}
+	switch {
+	case report.TooOld:
+		lines = append(lines, "", dim.Width(innerWidth).Render("Views may fail with errors the server can't explain. Upgrading Argo CD is the fix."))
+	case report.TooNew:
+		lines = append(lines, "", dim.Width(innerWidth).Render("Endpoints may have changed; if something fails, check for a newer argonaut."))
+	}
+	if disabled := report.Disabled(); len(disabled) > 0 {
+		lines = append(lines, "", text.Render("Turned off for this server:"))
+		for _, d := range disabled {
+			lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  • "+truncateWithEllipsis(d, innerWidth-4)))
+		}
+	}
+
+	keycapFG := ensureContrastingForeground(keycapBG, whiteBright)
+	keycap := func(s string) string {
+		return lipgloss.NewStyle().Background(keycapBG).Foreground(keycapFG).Padding(0, 1).Render(s)
+	}
+	lines = append(lines, "", fmt.Sprintf("%s %s %s %s %s",
+		dim.Render("Press"), keycap("Enter"), dim.Render("or"), keycap("Esc"), dim.Render("to close")))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(progressColor).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/compat"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+func TestAPIVersion_OldServerShowsWarningOnce(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+
+	m.Update(model.SetAPIVersionMsg{Version: "v2.9.3+abc1234", SwitchEpoch: m.switchEpoch})
+	if m.state.Mode != model.ModeCompatWarning {
+		t.Fatalf("mode = %s, want the compatibility warning", m.state.Mode)
+	}
+	if m.state.APIVersion != "v2.9.3+abc1234" {
+		t.Errorf("APIVersion = %q", m.state.APIVersion)
+	}
+	view := stripANSI(m.renderCompatWarningModal())
+	if !strings.Contains(view, "Resource actions (needs v3.1.0)") {
+		t.Errorf("the warning should list the disabled feature:\n%s", view)
+	}
+	if len(m.state.RecentErrors) != 1 || m.state.RecentErrors[0].Source != "compat" {
+		t.Errorf("the warning should be kept in the :errors drawer: %+v", m.state.RecentErrors)
+	}
+
+	m.Update(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal {
+		t.Fatalf("esc should close the warning, mode = %s", m.state.Mode)
+	}
+
+	m.Update(model.SetAPIVersionMsg{Version: "v2.9.3+abc1234", SwitchEpoch: m.switchEpoch})
+	if m.state.Mode != model.ModeNormal {
+		t.Error("the warning should only be shown once per context")
+	}
+}
+
+func TestAPIVersion_SupportedOrUnknownServerIsQuiet(t *testing.T) {
+	for _, v := range []string{"v3.1.4+abc", "e2e"} {
+		m := buildDeleteTestModel(120, 30)
+		m.Update(model.SetAPIVersionMsg{Version: v, SwitchEpoch: m.switchEpoch})
+		if m.state.Mode != model.ModeNormal {
+			t.Errorf("%s: mode = %s, want normal", v, m.state.Mode)
+		}
+		if note := m.unavailable(compat.ResourceActions); note != "" {
+			t.Errorf("%s: resource actions should stay available, got %q", v, note)
+		}
+	}
+}
+
+func TestAPIVersion_WarningWaitsForOtherModals(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Mode = model.ModeHelp
+
+	m.Update(model.SetAPIVersionMsg{Version: "v2.7.0", SwitchEpoch: m.switchEpoch})
+	if m.state.Mode != model.ModeHelp {
+		t.Fatalf("an open modal should not be replaced, mode = %s", m.state.Mode)
+	}
+	if !strings.Contains(m.state.UI.StatusNote, "older than the oldest supported release") {
+		t.Errorf("status note = %q", m.state.UI.StatusNote)
+	}
+}
+
+func TestAPIVersion_StaleEpochIgnored(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(model.SetAPIVersionMsg{Version: "v2.7.0", SwitchEpoch: m.switchEpoch - 1})
+	if m.state.APIVersion != "" || m.serverCompat != nil {
+		t.Error("a version from a previous context should be dropped")
+	}
+}
+
+func TestResourceAction_DisabledOnOldServer(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleAPIVersion("v3.0.2")
+	m.state.Mode = model.ModeNormal
+	m.state.Navigation.View = model.ViewTree
+	m.treeView = treeview.NewTreeView(0, 0)
+	ns := "default"
+	m.treeView.SetAppMeta("test-app", "Healthy", "Synced")
+	m.treeView.UpsertAppTree("test-app", &api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "r1", Kind: "Rollout", Group: "argoproj.io", Name: "web", Namespace: &ns},
+	}})
+	m.treeView.SetSelectedIndex(1)
+
+	_, cmd := m.handleResourceAction()
+	if m.state.Modals.ResourceAction != nil || m.state.Mode != model.ModeNormal {
+		t.Fatal("the actions modal should not open on a server without the v2 actions endpoint")
+	}
+	if cmd == nil {
+		t.Fatal("expected a status message")
+	}
+	msg, ok := cmd().(model.StatusChangeMsg)
+	if !ok || !strings.Contains(msg.Status, "need Argo CD v3.1.0 or newer (server runs v3.0.2)") {
+		t.Errorf("status = %+v", msg)
+	}
+}
+
+func TestEndpointProbe_MissingEndpointWarnsAndHidesHint(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(model.SetAPIVersionMsg{Version: "v3.2.0", SwitchEpoch: m.switchEpoch})
+	if m.state.Mode != model.ModeNormal {
+		t.Fatalf("a supported server should not warn, mode = %s", m.state.Mode)
+	}
+
+	m.Update(endpointsProbedMsg{epoch: m.switchEpoch, served: map[compat.Feature]bool{compat.ResourceActions: false}})
+	if m.state.Mode != model.ModeCompatWarning {
+		t.Fatalf("a missing endpoint should warn, mode = %s", m.state.Mode)
+	}
+	if view := stripANSI(m.renderCompatWarningModal()); !strings.Contains(view, "Resource actions (endpoint not found)") {
+		t.Errorf("the warning should name the missing endpoint:\n%s", view)
+	}
+
+	m.state.Navigation.View = model.ViewTree
+	for _, h := range m.currentKeyHints() {
+		if h.key == "a" {
+			t.Error("the actions hint should be hidden while actions are off")
+		}
+	}
+}
+
+func TestEndpointProbe_ServedEndpointOverridesVersion(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(endpointsProbedMsg{epoch: m.switchEpoch, served: map[compat.Feature]bool{compat.ResourceActions: true}})
+	m.Update(model.SetAPIVersionMsg{Version: "v3.0.2", SwitchEpoch: m.switchEpoch})
+	if m.state.Mode != model.ModeNormal {
+		t.Errorf("a backported endpoint should not be warned about, mode = %s", m.state.Mode)
+	}
+	if note := m.unavailable(compat.ResourceActions); note != "" {
+		t.Errorf("resource actions should stay on, got %q", note)
+	}
+}
+
+func TestResourceActionExecuteError_MissingEndpointTurnsActionsOff(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	target := model.ResourceActionTarget{AppName: "test-app", Kind: "Rollout", Name: "web"}
+	m.state.Mode = model.ModeResourceAction
+	m.state.Modals.ResourceAction = &model.ResourceActionState{Target: target, Executing: true}
+
+	m.Update(model.ResourceActionExecuteErrorMsg{
+		Target:          target,
+		Error:           "Not Found",
+		EndpointMissing: true,
+		SwitchEpoch:     m.switchEpoch,
+	})
+	want := "Resource actions are not served by this server (an unknown version)"
+	if got := m.state.Modals.ResourceAction.Error; got != want {
+		t.Errorf("modal error = %q, want %q", got, want)
+	}
+	if m.state.Mode != model.ModeResourceAction {
+		t.Errorf("the open modal should stay, mode = %s", m.state.Mode)
+	}
+	if m.unavailable(compat.ResourceActions) == "" {
+		t.Error("resource actions should be off for the rest of the session")
+	}
+}
+
+func TestEndpointProbe_GatesSourcePickerAndAppSets(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].MultiSource = true
+	m.state.Apps[0].Sources = []model.AppSource{
+		{RepoURL: "https://charts.example.com", Chart: "web"},
+		{RepoURL: "https://git.example.com/values.git", Ref: "values"},
+	}
+	m.Update(model.SetAPIVersionMsg{Version: "v3.2.0", SwitchEpoch: m.switchEpoch})
+	m.Update(endpointsProbedMsg{epoch: m.switchEpoch, served: map[compat.Feature]bool{
+		compat.SourceSync:      false,
+		compat.ApplicationSets: false,
+	}})
+	m.state.Mode = model.ModeNormal
+
+	m.handleSyncModal()
+	if m.confirmSyncSources() != nil {
+		t.Error("the source picker should be hidden when the server can't sync one source")
+	}
+
+	m.state.Navigation.View = model.ViewApps
+	m.state.Mode = model.ModeCommand
+	m.inputComponents.SetCommandValue("appsets")
+	m.state.UI.Command = "appsets"
+	_, cmd := m.handleEnhancedCommandModeKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.state.Navigation.View == model.ViewApplicationSets {
+		t.Error("the ApplicationSets view should stay closed")
+	}
+	if msg, ok := cmd().(model.StatusChangeMsg); !ok || !strings.Contains(msg.Status, "ApplicationSets are not served by this server") {
+		t.Errorf("status = %+v", msg)
+	}
+}
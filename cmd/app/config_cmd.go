@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/theme"
+)
+
+const configUsage = `Usage:
+  argonaut [--profile <name>] config export [file]   write portable settings to file or stdout
+  argonaut [--profile <name>] config import <file>   replace settings with those in file ("-" for stdin)
+`
+
+// runConfigCommand runs "argonaut config ..." and returns the exit code
+func runConfigCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+	switch args[0] {
+	case "export":
+		if len(args) > 2 {
+			break
+		}
+		data, err := config.ExportSettings()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(args) == 1 || args[1] == "-" {
+			_, _ = stdout.Write(data)
+			return 0
+		}
+		if err := os.WriteFile(args[1], data, 0644); err != nil {
+			fmt.Fprintf(stderr, "Error: failed to write %s: %v\n", args[1], err)
+			return 1
+		}
+		fmt.Fprintf(stderr, "Exported settings to %s\n", args[1])
+		return 0
+	case "import":
+		if len(args) != 2 {
+			break
+		}
+		var data []byte
+		var err error
+		if args[1] == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(args[1])
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to read settings: %v\n", err)
+			return 1
+		}
+		backup, err := config.ImportSettings(data)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stderr, "Imported settings into %s\n", config.GetArgonautConfigPath())
+		if backup != "" {
+			fmt.Fprintf(stderr, "Previous settings saved to %s\n", backup)
+		}
+		if cfg, err := config.LoadArgonautConfig(); err == nil {
+			if _, ok := theme.Get(cfg.Appearance.Theme); !ok {
+				fmt.Fprintf(stderr, "Warning: theme %q is not available in this version; the default will be used\n", cfg.Appearance.Theme)
+			}
+		}
+		return 0
+	}
+	fmt.Fprint(stderr, configUsage)
+	return 2
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+)
+
+func TestConfigCommand_ExportThenImport(t *testing.T) {
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(t.TempDir(), "config.toml"))
+	cfg := config.GetDefaultConfig()
+	cfg.Appearance.Theme = "no-such-theme"
+	if err := config.SaveArgonautConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runConfigCommand([]string{"export"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("export exited %d: %s", code, stderr.String())
+	}
+	exported := stdout.String()
+
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(t.TempDir(), "config.toml"))
+	stderr.Reset()
+	if code := runConfigCommand([]string{"import", "-"}, strings.NewReader(exported), &stdout, &stderr); code != 0 {
+		t.Fatalf("import exited %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `theme "no-such-theme" is not available`) {
+		t.Errorf("import should warn about unknown themes, got %q", stderr.String())
+	}
+
+	for _, args := range [][]string{nil, {"import"}, {"export", "a", "b"}, {"reset"}} {
+		stderr.Reset()
+		if code := runConfigCommand(args, nil, &stdout, &stderr); code != 2 || !strings.Contains(stderr.String(), "Usage:") {
+			t.Errorf("%v: expected usage, got %d %q", args, code, stderr.String())
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
 	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 )
@@ -14,6 +15,7 @@ import (
 func (m *Model) performContextSwitch(contextName string) tea.Cmd {
 	configPath := m.argoConfigPath
 	currentCtx := m.currentContextName
+	settings := m.config
 	return func() tea.Msg {
 		// Same-context no-op
 		if contextName == currentCtx {
@@ -43,6 +45,7 @@ func (m *Model) performContextSwitch(contextName string) tea.Cmd {
 		if err != nil {
 			return model.ContextSwitchResultMsg{Error: err}
 		}
+		settings.ApplyServerSettings(server)
 
 		return model.ContextSwitchResultMsg{
 			Server:       server,
@@ -58,6 +61,7 @@ func (m *Model) performContextSwitch(contextName string) tea.Cmd {
 func (m *Model) handleContextSwitchResult(msg model.ContextSwitchResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Error != nil {
 		m.statusService.Error("Context switch failed: " + msg.Error.Error())
+		m.recordError("context", "Context switch failed: "+msg.Error.Error(), "", nil)
 		return m, nil
 	}
 
@@ -71,6 +75,10 @@ func (m *Model) handleContextSwitchResult(msg model.ContextSwitchResultMsg) (tea
 	m.cleanupAppWatcher()
 	//    b. Stop tree watchers
 	_ = m.cleanupTreeWatchers()
+	//    c. Cancel any background prefetch
+	m.prefetch.stop()
+	//    d. Forget the old server's response times
+	api.ResetLatency()
 
 	// 2. Create fresh model with same config (re-applies preferences)
 	newM := NewModel(m.config)
@@ -87,11 +95,14 @@ func (m *Model) handleContextSwitchResult(msg model.ContextSwitchResultMsg) (tea
 	newM.state.Server = msg.Server             // New server config
 	newM.state.ContextNames = msg.ContextNames // From result (no 2nd config read)
 	newM.switchEpoch = m.switchEpoch + 1       // Increment epoch
+	newM.appCacheDir = m.appCacheDir           // On-disk app list snapshots
+	newM.profileName = m.profileName           // Config profile shown in the banner
 
 	// 5. Start fresh load cycle
 	return newM, tea.Batch(
-		newM.spinner.Tick,
+		newM.spinnerTick(),
 		func() tea.Msg { return model.SetInitialLoadingMsg{Loading: true} },
+		newM.loadCachedApps(),
 		newM.validateAuthentication(),
 	)
 }
@@ -161,6 +161,7 @@ func TestContextSwitchPreservedFields(t *testing.T) {
 	m.ready = true
 	m.argoConfigPath = "/path/to/config"
 	m.currentContextName = "old-context"
+	m.profileName = "homelab"
 	m.switchEpoch = 3
 
 	newServer := &model.Server{BaseURL: "https://new.example.com", Token: "new-token"}
@@ -186,6 +187,9 @@ func TestContextSwitchPreservedFields(t *testing.T) {
 	if newM.currentContextName != "new-context" {
 		t.Errorf("currentContextName not set: %q", newM.currentContextName)
 	}
+	if newM.profileName != "homelab" {
+		t.Errorf("profileName not preserved: %q", newM.profileName)
+	}
 	if newM.state.Server != newServer {
 		t.Error("server not set to new server")
 	}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 )
@@ -276,3 +277,61 @@ func TestDefaultViewWarning_EmptyConfig(t *testing.T) {
 		t.Errorf("expected clusters view for empty config, got %s", m.state.Navigation.View)
 	}
 }
+
+func TestDefaultView_TreeOpensAppAfterLoad(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DefaultView = "tree:app-1"
+
+	m := NewModel(cfg)
+	if m.state.Navigation.View != model.ViewApps {
+		t.Fatalf("expected apps view until apps load, got %s", m.state.Navigation.View)
+	}
+
+	m.state.Apps = buildTestApps()
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	if cmd := m.validateDefaultViewScope(); cmd == nil {
+		t.Error("expected commands to load the resource tree")
+	}
+
+	if m.state.Modals.DefaultViewWarning != nil {
+		t.Errorf("unexpected warning: %s", *m.state.Modals.DefaultViewWarning)
+	}
+	if m.state.Navigation.View != model.ViewTree {
+		t.Errorf("expected tree view, got %s", m.state.Navigation.View)
+	}
+}
+
+func TestDefaultView_TreeAppNotFound(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DefaultView = "tree missing-app"
+
+	m := NewModel(cfg)
+	m.state.Apps = buildTestApps()
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	m.validateDefaultViewScope()
+
+	if m.state.Modals.DefaultViewWarning == nil || !strings.Contains(*m.state.Modals.DefaultViewWarning, "missing-app") {
+		t.Fatalf("expected warning naming the missing app, got %v", m.state.Modals.DefaultViewWarning)
+	}
+	if m.state.Navigation.View != model.ViewClusters {
+		t.Errorf("expected fallback to clusters view, got %s", m.state.Navigation.View)
+	}
+}
+
+func TestHelpViewsTopic(t *testing.T) {
+	m := buildBaseModel(100, 40)
+	m.state.Mode = model.ModeCommand
+	m.inputComponents.SetCommandValue("help views")
+	m.state.UI.Command = "help views"
+	m.handleEnhancedCommandModeKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if m.state.Mode != model.ModeHelp || m.state.Modals.HelpTopic != "views" {
+		t.Fatalf("expected views help, mode=%s topic=%q", m.state.Mode, m.state.Modals.HelpTopic)
+	}
+	out := stripANSI(m.renderHelpModal())
+	for _, want := range []string{"DEFAULT_VIEW", "tree:<app>", "cluster <name>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("views help should mention %q", want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/kubeapi"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// deprecatedAPIMarker is appended to the name of apps that deploy API
+// versions their destination cluster deprecated or no longer serves
+const deprecatedAPIMarker = "⚑"
+
+// clusterKubeVersion returns the Kubernetes version of the app's
+// destination cluster, or false when it is not known
+func (m *Model) clusterKubeVersion(app model.App) (kubeapi.Version, bool) {
+	if app.ClusterID == nil {
+		return kubeapi.Version{}, false
+	}
+	return kubeapi.ParseVersion(m.state.ClusterVersions[*app.ClusterID])
+}
+
+// deprecatedAPIStatus returns the worst standing of the app's deprecated
+// API versions on its destination cluster; Supported when the cluster's
+// version is not known
+func (m *Model) deprecatedAPIStatus(app model.App) kubeapi.Status {
+	cluster, ok := m.clusterKubeVersion(app)
+	if !ok {
+		return kubeapi.Supported
+	}
+	worst := kubeapi.Supported
+	for _, a := range app.DeprecatedAPIs {
+		if d, ok := kubeapi.Lookup(a.Group, a.Version, a.Kind); ok {
+			worst = max(worst, d.StatusOn(cluster))
+		}
+	}
+	return worst
+}
+
+// deprecatedAPIColor is the color of the marker and details lines for a
+// standing: red when syncs fail, yellow when they will after an upgrade
+func deprecatedAPIColor(status kubeapi.Status) lipgloss.Style {
+	switch status {
+	case kubeapi.Removed:
+		return lipgloss.NewStyle().Foreground(outOfSyncColor)
+	case kubeapi.Deprecated:
+		return lipgloss.NewStyle().Foreground(yellowBright)
+	}
+	return lipgloss.NewStyle().Foreground(dimColor)
+}
+
+// deprecatedAPILines lists the app's deprecated API versions for app
+// details, each with the release that removes it and what to use instead
+func (m *Model) deprecatedAPILines(app model.App, innerWidth int) []string {
+	if len(app.DeprecatedAPIs) == 0 {
+		return nil
+	}
+	heading := "Deprecated APIs"
+	cluster, known := m.clusterKubeVersion(app)
+	if known {
+		heading += " (cluster " + cluster.String() + ")"
+	}
+	lines := []string{"", lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render(heading)}
+	for _, a := range app.DeprecatedAPIs {
+		d, ok := kubeapi.Lookup(a.Group, a.Version, a.Kind)
+		if !ok {
+			continue
+		}
+		status := kubeapi.Supported
+		if known {
+			status = d.StatusOn(cluster)
+		}
+		line := fmt.Sprintf("  %s %s", d.APIVersion(), a.Kind)
+		if a.Count > 1 {
+			line += fmt.Sprintf(" ×%d", a.Count)
+		}
+		if status == kubeapi.Removed {
+			line += fmt.Sprintf(": removed in %s, use %s", d.RemovedIn, d.Replacement)
+		} else {
+			line += fmt.Sprintf(": deprecated in %s, removed in %s, use %s", d.DeprecatedIn, d.RemovedIn, d.Replacement)
+		}
+		lines = append(lines, deprecatedAPIColor(status).Render(truncateWithEllipsis(line, innerWidth)))
+	}
+	return lines
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestDeprecatedAPIs_MarkedForTheClusterVersion(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+	prod := "prod"
+	m.state.Apps[0].ClusterID = &prod
+	m.state.Apps[0].DeprecatedAPIs = []model.ResourceAPI{
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob", Count: 2},
+		{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema", Count: 1},
+	}
+
+	if row := stripANSI(m.renderAppRow(m.state.Apps[0], false)); strings.Contains(row, deprecatedAPIMarker) {
+		t.Errorf("without the cluster's version there is nothing to compare to: %q", row)
+	}
+
+	m.Update(model.ClusterConnectionsLoadedMsg{Versions: map[string]string{"prod": "1.24"}, SwitchEpoch: m.switchEpoch})
+	if row := stripANSI(m.renderAppRow(m.state.Apps[0], false)); !strings.Contains(row, "test-app "+deprecatedAPIMarker) {
+		t.Errorf("an app using APIs its cluster deprecated should be marked: %q", row)
+	}
+	if row := stripANSI(m.renderAppRow(m.state.Apps[1], false)); strings.Contains(row, deprecatedAPIMarker) {
+		t.Errorf("an app without deprecated APIs should not be marked: %q", row)
+	}
+
+	m.handleOpenAppDetails()
+	out := stripANSI(m.renderAppDetailsModal())
+	for _, want := range []string{
+		"Deprecated APIs (cluster 1.24)",
+		"batch/v1beta1 CronJob ×2: deprecated in 1.21, removed in 1.25, use batch/v1",
+		"flowcontrol.apiserver.k8s.io/v1beta3 FlowSchema: deprecated in 1.29",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("details should list %q:\n%s", want, out)
+		}
+	}
+
+	m.state.ClusterVersions["prod"] = "v1.25.3-eks-1"
+	if out := stripANSI(m.renderAppDetailsModal()); !strings.Contains(out, "batch/v1beta1 CronJob ×2: removed in 1.25, use batch/v1") {
+		t.Errorf("details should say the cluster no longer serves the version:\n%s", out)
+	}
+}
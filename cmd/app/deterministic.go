@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// deterministicMode makes the screen reproducible for end-to-end tests.
+// Set by ARGONAUT_E2E=1: the spinner stops on its first frame, on-screen
+// elapsed times are measured against a frozen clock and debounces fire
+// right away, so tests can wait for output instead of sleeping.
+var deterministicMode = os.Getenv("ARGONAUT_E2E") == "1"
+
+// frozenClock is the time on screen in deterministic mode
+var frozenClock = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// clockNow returns the time that on-screen elapsed times are measured with
+func clockNow() time.Time {
+	if deterministicMode {
+		return frozenClock
+	}
+	return time.Now()
+}
+
+// debounceDelay returns d, or no delay in deterministic mode
+func debounceDelay(d time.Duration) time.Duration {
+	if deterministicMode {
+		return 0
+	}
+	return d
+}
+
+// reducedMotion reports whether animations and transient effects are off
+// (appearance.reduced_motion, implied by appearance.low_bandwidth)
+func (m *Model) reducedMotion() bool {
+	return m.config != nil && (m.config.Appearance.ReducedMotion || m.config.Appearance.LowBandwidth)
+}
+
+// spinnerTick starts the spinner animation. Returns nil in deterministic
+// mode and with reduced motion so the spinner renders a single frame.
+func (m *Model) spinnerTick() tea.Cmd {
+	if deterministicMode || m.reducedMotion() {
+		return nil
+	}
+	return m.spinner.Tick
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func withDeterministicMode(t *testing.T) {
+	t.Helper()
+	prev := deterministicMode
+	deterministicMode = true
+	t.Cleanup(func() { deterministicMode = prev })
+}
+
+func TestDeterministicMode_FreezesTimeDrivenUI(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+
+	if m.spinnerTick() != nil {
+		t.Error("spinner should not animate")
+	}
+	if d := debounceDelay(prefetchDebounce); d != 0 {
+		t.Errorf("debounce should be skipped, got %s", d)
+	}
+	if !clockNow().Equal(frozenClock) {
+		t.Error("clock should be frozen")
+	}
+
+	shown := clockNow().Add(-time.Hour)
+	m.state.UI.WhatsNewShownAt = &shown
+	if m.shouldShowWhatsNewNotification() {
+		t.Error("elapsed times should be measured against the frozen clock")
+	}
+	shown = clockNow()
+	if !m.shouldShowWhatsNewNotification() {
+		t.Error("notification shown at the frozen time should stay visible")
+	}
+}
+
+func TestDeterministicMode_EscNotDebounced(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+
+	for i := range 2 {
+		m.state.Mode = model.ModeHelp
+		m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEscape})
+		if m.state.Mode != model.ModeNormal {
+			t.Fatalf("esc %d should close help right away", i+1)
+		}
+	}
+}
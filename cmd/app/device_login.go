@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/config"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// defaultDeviceLoginInterval is how often to poll when the provider does
+// not say, as RFC 8628 prescribes
+const defaultDeviceLoginInterval = 5 * time.Second
+
+// deviceLoginState is a sign-in with a device code: the user approves the
+// code in any browser while argonaut polls the SSO provider for a session
+type deviceLoginState struct {
+	seq      int
+	server   *model.Server
+	login    *api.DeviceLogin
+	code     *api.DeviceCode
+	interval time.Duration
+	err      string
+	// When the code expires, on the wall clock so it still does in
+	// deterministic mode; the countdown on screen runs from shownAt on
+	// clockNow instead
+	expires  time.Time
+	lifetime time.Duration
+	shownAt  time.Time
+}
+
+// deviceLoginStartedMsg carries the code to show, or why there is none
+type deviceLoginStartedMsg struct {
+	seq   int
+	login *api.DeviceLogin
+	code  *api.DeviceCode
+	err   error
+}
+
+// deviceLoginPolledMsg is the answer to one poll of the token endpoint
+type deviceLoginPolledMsg struct {
+	seq   int
+	token *api.DeviceToken
+	err   error
+}
+
+// deviceLoginSavedMsg reports whether the session was written to the
+// ArgoCD CLI config
+type deviceLoginSavedMsg struct {
+	path string
+	err  error
+}
+
+// deviceLoginServer is the server to sign in to: the one that turned the
+// token down, or else the current context of the ArgoCD CLI config
+func (m *Model) deviceLoginServer() (*model.Server, error) {
+	if m.state.Server != nil {
+		server := *m.state.Server
+		server.Token = ""
+		return &server, nil
+	}
+	cfg, err := config.ReadCLIConfigFromPath(m.argoConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := cfg.ResolveContext(m.currentContextName)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.ServerWithoutToken()
+}
+
+// startDeviceLogin opens the device login modal and asks for a code
+func (m *Model) startDeviceLogin() (tea.Model, tea.Cmd) {
+	m.deviceLoginSeq++
+	d := &deviceLoginState{seq: m.deviceLoginSeq}
+	m.deviceLogin = d
+	m.state.Mode = model.ModeDeviceLogin
+
+	server, err := m.deviceLoginServer()
+	if err != nil {
+		cblog.With("component", "auth").Warn("No server to sign in to", "err", err)
+		d.err = "No Argo CD server is configured. Run argocd login <server> once, then sign in here."
+		return m, nil
+	}
+	d.server = server
+	seq := d.seq
+	return m, func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		login, err := api.NewDeviceLogin(ctx, server)
+		if err != nil {
+			return deviceLoginStartedMsg{seq: seq, err: err}
+		}
+		code, err := login.Start(ctx)
+		return deviceLoginStartedMsg{seq: seq, login: login, code: code, err: err}
+	}
+}
+
+// handleDeviceLoginStarted shows the code and starts polling for approval
+func (m *Model) handleDeviceLoginStarted(msg deviceLoginStartedMsg) tea.Cmd {
+	d := m.deviceLogin
+	if d == nil || d.seq != msg.seq {
+		return nil
+	}
+	if msg.err != nil {
+		cblog.With("component", "auth").Error("Device login failed to start", "err", msg.err)
+		d.err = msg.err.Error()
+		return nil
+	}
+	d.login, d.code = msg.login, msg.code
+	d.interval = time.Duration(msg.code.Interval) * time.Second
+	if d.interval <= 0 {
+		d.interval = defaultDeviceLoginInterval
+	}
+	if msg.code.ExpiresIn > 0 {
+		d.lifetime = time.Duration(msg.code.ExpiresIn) * time.Second
+		d.expires, d.shownAt = time.Now().Add(d.lifetime), clockNow()
+	}
+	return m.scheduleDeviceLoginPoll()
+}
+
+// scheduleDeviceLoginPoll polls once after the provider's interval
+func (m *Model) scheduleDeviceLoginPoll() tea.Cmd {
+	d := m.deviceLogin
+	seq, login, code := d.seq, d.login, d.code
+	return tea.Tick(d.interval, func(time.Time) tea.Msg {
+		return pollDeviceLogin(seq, login, code)
+	})
+}
+
+// pollDeviceLogin asks the provider whether the code was approved
+func pollDeviceLogin(seq int, login *api.DeviceLogin, code *api.DeviceCode) tea.Msg {
+	ctx, cancel := appcontext.WithAPITimeout(context.Background())
+	defer cancel()
+	token, err := login.Poll(ctx, code)
+	return deviceLoginPolledMsg{seq: seq, token: token, err: err}
+}
+
+// handleDeviceLoginPolled keeps polling until the code is approved, then
+// connects with the new session and saves it like argocd login does
+func (m *Model) handleDeviceLoginPolled(msg deviceLoginPolledMsg) tea.Cmd {
+	d := m.deviceLogin
+	if d == nil || d.seq != msg.seq || d.err != "" {
+		return nil
+	}
+	switch {
+	case errors.Is(msg.err, api.ErrDeviceLoginPending):
+	case errors.Is(msg.err, api.ErrDeviceLoginSlowDown):
+		d.interval += defaultDeviceLoginInterval
+	case msg.err != nil:
+		cblog.With("component", "auth").Error("Device login failed", "err", msg.err)
+		d.err = msg.err.Error()
+		return nil
+	default:
+		return m.finishDeviceLogin(msg.token)
+	}
+	if !d.expires.IsZero() && time.Now().After(d.expires) {
+		d.err = api.ErrDeviceLoginExpired.Error()
+		return nil
+	}
+	return m.scheduleDeviceLoginPoll()
+}
+
+// finishDeviceLogin connects again the way startup does, with the token
+func (m *Model) finishDeviceLogin(token *api.DeviceToken) tea.Cmd {
+	server := *m.deviceLogin.server
+	server.Token = token.IDToken
+	m.deviceLogin = nil
+	cblog.With("component", "auth").Info("Signed in with a device code", "server", server.BaseURL)
+
+	m.state.Server = &server
+	m.state.Mode = model.ModeNormal
+	m.state.Modals.InitialLoading = true
+	if config.SafeMode() || (m.config != nil && m.config.NoConfigWrites) {
+		// Writes are off, so the ArgoCD CLI config is left alone too
+		return tea.Batch(m.validateAuthentication(), m.showStatusNote("Signed in for this session only, config writes are off"))
+	}
+	return tea.Batch(m.validateAuthentication(), saveDeviceLoginToken(m.argoConfigPath, m.currentContextName, token))
+}
+
+// saveDeviceLoginToken writes the session to the context's user in the
+// ArgoCD CLI config, so the next run and the argocd CLI start signed in
+func saveDeviceLoginToken(path, contextName string, token *api.DeviceToken) tea.Cmd {
+	return func() tea.Msg {
+		return deviceLoginSavedMsg{path: path, err: config.SaveContextToken(path, contextName, token.IDToken, token.RefreshToken)}
+	}
+}
+
+// handleDeviceLoginSaved reports whether the session outlives this run
+func (m *Model) handleDeviceLoginSaved(msg deviceLoginSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "auth").Error("Failed to save the device login session", "path", msg.path, "err", msg.err)
+		text := "Signed in for this session only, not saved: " + msg.err.Error()
+		m.statusService.Error(text)
+		m.recordError("auth", text, msg.err.Error(), nil)
+		return nil
+	}
+	return m.showStatusNote("Signed in, session saved to " + msg.path)
+}
+
+// handleDeviceLoginKeys handles the device login modal
+func (m *Model) handleDeviceLoginKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.deviceLogin
+	switch msg.String() {
+	case "ctrl+c":
+		return m, func() tea.Msg { return model.QuitMsg{} }
+	case "esc", "q":
+		m.deviceLogin = nil
+		m.state.Mode = model.ModeAuthRequired
+		return m, nil
+	case "r":
+		return m.startDeviceLogin()
+	case "y":
+		if d != nil && d.code != nil && d.err == "" {
+			return m, tea.Batch(clipboard.CopyCmd(deviceLoginURL(d.code)), m.showStatusNote("Copied the sign-in link"))
+		}
+	}
+	return m, nil
+}
+
+// deviceLoginURL is the page to approve the code on, with the code filled
+// in when the provider offers that
+func deviceLoginURL(code *api.DeviceCode) string {
+	if code.VerificationURIComplete != "" {
+		return code.VerificationURIComplete
+	}
+	return code.VerificationURI
+}
+
+// renderDeviceLoginView shows the device login modal over the dimmed
+// authentication screen
+func (m *Model) renderDeviceLoginView() string {
+	return m.renderWithOverlay(m.renderAuthRequiredView())
+}
+
+// renderDeviceLoginModal shows the link and code to approve, or what went
+// wrong. The link is never truncated: it has to be typed or copied whole.
+func (m *Model) renderDeviceLoginModal() string {
+	d := m.deviceLogin
+	if d == nil {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	keycapFG := ensureContrastingForeground(keycapBG, whiteBright)
+	keycap := lipgloss.NewStyle().Background(keycapBG).Foreground(keycapFG).Bold(true).Padding(0, 1)
+	width := m.modalWidth(56, 1, 2)
+
+	lines := []string{lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render("Sign in with SSO"), ""}
+	footer := "r try again • Esc cancel"
+	switch {
+	case d.err != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(outOfSyncColor).Render(d.err))
+	case d.code == nil:
+		host := "the server"
+		if d.server != nil {
+			host = hostFromURL(d.server.BaseURL)
+		}
+		lines = append(lines, m.spinner.View()+" "+statusStyle.Render("Contacting the SSO provider of "+host+"…"))
+	default:
+		url := deviceLoginURL(d.code)
+		width = layout.FitModal(m.state.Terminal.Cols, max(width, layout.Dialog.OuterWidth(lipgloss.Width(url))))
+		waiting := m.spinner.View() + " Waiting for approval"
+		if !d.expires.IsZero() {
+			waiting += ", the code expires in " + formatDeviceLoginRemaining(d.lifetime-clockNow().Sub(d.shownAt))
+		}
+		lines = append(lines,
+			"On any device with a browser, open",
+			lipgloss.NewStyle().Foreground(cyanBright).Underline(true).Render(url),
+			"",
+			"and enter the code "+keycap.Render(d.code.UserCode),
+			"",
+			dim.Render(waiting),
+		)
+		footer = "y copy link • r new code • Esc cancel"
+	}
+	lines = append(lines, "", dim.Render(footer))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(layout.Dialog.PadY, layout.Dialog.PadX).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// formatDeviceLoginRemaining renders the time left on a code as 4m05s
+func formatDeviceLoginRemaining(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
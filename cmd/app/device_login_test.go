@@ -0,0 +1,211 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// newDeviceLoginServer serves an Argo CD whose Dex approves the device code
+// on the second poll, or answers every poll with answer when it is set
+func newDeviceLoginServer(t *testing.T, answer string) *httptest.Server {
+	t.Helper()
+	polls := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/settings":
+			w.Write([]byte(`{"dexConfig": {"connectors": [{"name": "GitHub", "type": "github"}]}}`))
+		case "/api/dex/.well-known/openid-configuration":
+			w.Write([]byte(`{"device_authorization_endpoint": "` + srv.URL + `/api/dex/device/code", "token_endpoint": "` + srv.URL + `/api/dex/token"}`))
+		case "/api/dex/device/code":
+			w.Write([]byte(`{"device_code": "dev-1", "user_code": "ABCD-EFGH", "verification_uri": "` + srv.URL + `/api/dex/device", "expires_in": 300, "interval": 5}`))
+		case "/api/dex/token":
+			polls++
+			switch {
+			case answer != "":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "` + answer + `"}`))
+			case polls == 1:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "authorization_pending"}`))
+			default:
+				w.Write([]byte(`{"id_token": "id-tok", "refresh_token": "refresh-tok"}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// buildDeviceLoginModel is the authentication screen of a context whose
+// token the server turned down
+func buildDeviceLoginModel(t *testing.T, srv *httptest.Server) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "expired"}
+	m.state.Mode = model.ModeAuthRequired
+	m.argoConfigPath = filepath.Join(t.TempDir(), "config")
+	cfg := "contexts:\n- name: prod\n  server: " + strings.TrimPrefix(srv.URL, "http://") + "\n  user: prod\ncurrent-context: prod\n" +
+		"servers:\n- server: " + strings.TrimPrefix(srv.URL, "http://") + "\n  plain-text: true\nusers:\n- name: prod\n  auth-token: expired\n"
+	if err := os.WriteFile(m.argoConfigPath, []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// startedDeviceLogin presses d and delivers the code
+func startedDeviceLogin(t *testing.T, m *Model) {
+	t.Helper()
+	_, cmd := m.handleKeyMsg(keyPress("d"))
+	if m.state.Mode != model.ModeDeviceLogin || cmd == nil {
+		t.Fatalf("d should open the device login, mode = %s", m.state.Mode)
+	}
+	if view := stripANSI(m.renderDeviceLoginModal()); !strings.Contains(view, "Contacting") {
+		t.Errorf("the modal should say it is asking for a code:\n%s", view)
+	}
+	m.handleDeviceLoginStarted(cmd().(deviceLoginStartedMsg))
+	if d := m.deviceLogin; d == nil || d.code == nil || d.err != "" {
+		t.Fatalf("the code should have arrived: %+v", d)
+	}
+}
+
+func TestDeviceLogin_SignsInAndSavesTheSession(t *testing.T) {
+	srv := newDeviceLoginServer(t, "")
+	m := buildDeviceLoginModel(t, srv)
+	startedDeviceLogin(t, m)
+
+	view := stripANSI(m.renderDeviceLoginView())
+	if !strings.Contains(view, "ABCD-EFGH") || !strings.Contains(view, srv.URL+"/api/dex/device") {
+		t.Errorf("the modal should show the code and the whole link:\n%s", view)
+	}
+
+	d := m.deviceLogin
+	if cmd := m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg)); cmd == nil || m.state.Mode != model.ModeDeviceLogin {
+		t.Fatalf("a pending code should be polled again, mode = %s", m.state.Mode)
+	}
+	cmd := m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg))
+	if m.state.Mode != model.ModeNormal || !m.state.Modals.InitialLoading || m.deviceLogin != nil {
+		t.Fatalf("an approved code should connect again, mode = %s", m.state.Mode)
+	}
+	if m.state.Server.Token != "id-tok" || m.state.Server.BaseURL != srv.URL {
+		t.Errorf("the server should carry the new session: %+v", m.state.Server)
+	}
+
+	var saved *deviceLoginSavedMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(deviceLoginSavedMsg); ok {
+			saved = &msg
+		}
+	}
+	if saved == nil || saved.err != nil {
+		t.Fatalf("the session should be saved, got %+v", saved)
+	}
+	m.handleDeviceLoginSaved(*saved)
+	cfg, err := config.ReadCLIConfigFromPath(m.argoConfigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := cfg.ResolveContext("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.User.AuthToken != "id-tok" || ctx.User.RefreshToken != "refresh-tok" {
+		t.Errorf("the context's user should have the new session: %+v", ctx.User)
+	}
+}
+
+func TestDeviceLogin_DeniedOffersANewCode(t *testing.T) {
+	srv := newDeviceLoginServer(t, "access_denied")
+	m := buildDeviceLoginModel(t, srv)
+	startedDeviceLogin(t, m)
+
+	d := m.deviceLogin
+	if cmd := m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg)); cmd != nil {
+		t.Error("a denied code should not be polled again")
+	}
+	if view := stripANSI(m.renderDeviceLoginModal()); !strings.Contains(view, api.ErrDeviceLoginDenied.Error()) || !strings.Contains(view, "r try again") {
+		t.Errorf("the modal should say why and offer a new code:\n%s", view)
+	}
+
+	stale := deviceLoginPolledMsg{seq: d.seq, token: &api.DeviceToken{IDToken: "late"}}
+	_, cmd := m.handleKeyMsg(keyPress("r"))
+	if cmd == nil || m.deviceLogin.seq == d.seq || m.deviceLogin.err != "" {
+		t.Fatalf("r should ask for a new code: %+v", m.deviceLogin)
+	}
+	m.handleDeviceLoginPolled(stale)
+	if m.state.Mode != model.ModeDeviceLogin || m.state.Server.Token != "expired" {
+		t.Error("answers to the old code should be ignored")
+	}
+
+	m.handleKeyMsg(keyPress("esc"))
+	if m.state.Mode != model.ModeAuthRequired || m.deviceLogin != nil {
+		t.Errorf("esc should go back to the authentication screen, mode = %s", m.state.Mode)
+	}
+}
+
+func TestDeviceLogin_WithoutAConfiguredServer(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = nil
+	m.state.Mode = model.ModeAuthRequired
+	m.argoConfigPath = filepath.Join(t.TempDir(), "missing")
+
+	if _, cmd := m.handleKeyMsg(keyPress("d")); cmd != nil {
+		t.Error("without a server there is nothing to ask for a code")
+	}
+	if view := stripANSI(m.renderDeviceLoginModal()); !strings.Contains(view, "argocd login") {
+		t.Errorf("the modal should say how to add a server:\n%s", view)
+	}
+}
+
+func TestDeviceLogin_NoConfigWritesKeepsTheSessionInMemory(t *testing.T) {
+	srv := newDeviceLoginServer(t, "")
+	m := buildDeviceLoginModel(t, srv)
+	m.config = &config.ArgonautConfig{NoConfigWrites: true}
+	before, _ := os.ReadFile(m.argoConfigPath)
+	startedDeviceLogin(t, m)
+
+	d := m.deviceLogin
+	m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg))
+	m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg))
+	if m.state.Server.Token != "id-tok" {
+		t.Fatalf("the session should still be used, token = %q", m.state.Server.Token)
+	}
+	if !strings.Contains(m.state.UI.StatusNote, "this session only") {
+		t.Errorf("the status should say the session is not saved, got %q", m.state.UI.StatusNote)
+	}
+	if after, _ := os.ReadFile(m.argoConfigPath); string(after) != string(before) {
+		t.Errorf("the ArgoCD config should be unchanged:\n%s", after)
+	}
+}
+
+func TestDeviceLogin_CodeExpiresInDeterministicMode(t *testing.T) {
+	withDeterministicMode(t)
+	srv := newDeviceLoginServer(t, "authorization_pending")
+	m := buildDeviceLoginModel(t, srv)
+	startedDeviceLogin(t, m)
+	if view := stripANSI(m.renderDeviceLoginModal()); !strings.Contains(view, "the code expires in 5m00s") {
+		t.Errorf("the countdown should run on the on-screen clock:\n%s", view)
+	}
+
+	d := m.deviceLogin
+	d.expires = time.Now().Add(-time.Second)
+	if cmd := m.handleDeviceLoginPolled(pollDeviceLogin(d.seq, d.login, d.code).(deviceLoginPolledMsg)); cmd != nil {
+		t.Error("an expired code should not be polled again")
+	}
+	if d.err != api.ErrDeviceLoginExpired.Error() {
+		t.Errorf("the frozen on-screen clock must not keep the code alive, err = %q", d.err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// diffCache keeps the diff sections of the apps opened this session so
+// reopening an unchanged diff is instant. An entry is only served for the
+// revision pair it was computed for. Written from tea.Cmd goroutines, so
+// access goes through the mutex. A nil cache serves nothing.
+type diffCache struct {
+	mu      sync.Mutex
+	entries map[string]diffCacheEntry // appKey
+}
+
+// diffCacheEntry is one app's diff and the revision pair it was computed for
+type diffCacheEntry struct {
+	revisions string
+	sections  []model.DiffSection
+}
+
+func newDiffCache() *diffCache {
+	return &diffCache{entries: make(map[string]diffCacheEntry)}
+}
+
+// get returns the cached diff of the app for the revision pair
+func (c *diffCache) get(key, revisions string) ([]model.DiffSection, bool) {
+	if c == nil || revisions == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || e.revisions != revisions {
+		return nil, false
+	}
+	return e.sections, true
+}
+
+// put stores the app's diff for the revision pair
+func (c *diffCache) put(key, revisions string, sections []model.DiffSection) {
+	if c == nil || revisions == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = diffCacheEntry{revisions: revisions, sections: sections}
+}
+
+// invalidate drops the app's cached diff
+func (c *diffCache) invalidate(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// keys returns the appKeys with a cached diff
+func (c *diffCache) keys() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// diffRevisionPair identifies what an app's diff compares: the revision
+// each source is synced to and the revision it targets. "" when a synced
+// revision is not known yet, so the diff is not cached.
+func diffRevisionPair(app model.App) string {
+	if len(app.Sources) == 0 {
+		return ""
+	}
+	live := make([]string, 0, len(app.Sources))
+	target := make([]string, 0, len(app.Sources))
+	for _, s := range app.Sources {
+		if s.Revision == "" {
+			return ""
+		}
+		live = append(live, s.Revision)
+		target = append(target, s.TargetRevision)
+	}
+	return strings.Join(live, ",") + ".." + strings.Join(target, ",")
+}
+
+// diffCacheRevisions is what an app's cached diff is served for: the
+// revision pair plus the diff viewer and formatter settings, so changing
+// either in the config does not reuse a diff computed under the old ones.
+// The settings keep their secret references unexpanded. "" when the diff
+// is not cacheable.
+func diffCacheRevisions(app model.App, cfg *config.ArgonautConfig) string {
+	revisions := diffRevisionPair(app)
+	if revisions == "" || cfg == nil {
+		return revisions
+	}
+	return revisions + "|viewer=" + cfg.Diff.Viewer + "|formatter=" + cfg.Diff.Formatter
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestDiffRevisionPair(t *testing.T) {
+	app := model.App{Sources: []model.AppSource{
+		{Revision: "abc123", TargetRevision: "main"},
+		{Revision: "1.2.0", TargetRevision: "1.2.*"},
+	}}
+	if got := diffRevisionPair(app); got != "abc123,1.2.0..main,1.2.*" {
+		t.Errorf("diffRevisionPair() = %q", got)
+	}
+	app.Sources[1].Revision = ""
+	if got := diffRevisionPair(app); got != "" {
+		t.Errorf("an unknown synced revision is not cacheable, got %q", got)
+	}
+}
+
+func TestDiffCacheRevisions_IncludeDiffSettings(t *testing.T) {
+	app := model.App{Sources: []model.AppSource{{Revision: "abc123", TargetRevision: "main"}}}
+	cfg := &config.ArgonautConfig{Diff: config.DiffConfig{Formatter: "delta"}}
+	before := diffCacheRevisions(app, cfg)
+
+	cfg.Diff.Formatter = "delta --side-by-side"
+	if diffCacheRevisions(app, cfg) == before {
+		t.Error("a new formatter must not be served the old diff")
+	}
+	formatted := diffCacheRevisions(app, cfg)
+	cfg.Diff.Viewer = "code --diff {left} {right}"
+	if diffCacheRevisions(app, cfg) == formatted {
+		t.Error("a new viewer must not be served the old diff")
+	}
+	if got := diffCacheRevisions(model.App{}, cfg); got != "" {
+		t.Errorf("an app without revisions is not cacheable, got %q", got)
+	}
+}
+
+func TestDiffCache_ServedOnlyForSameRevisions(t *testing.T) {
+	c := newDiffCache()
+	sections := []model.DiffSection{{Kind: "Deployment", Name: "web"}}
+	c.put("/app", "a..main", sections)
+
+	if got, ok := c.get("/app", "a..main"); !ok || len(got) != 1 {
+		t.Fatalf("expected a hit, got %v %v", got, ok)
+	}
+	if _, ok := c.get("/app", "b..main"); ok {
+		t.Error("a new synced revision must not be served the old diff")
+	}
+	c.invalidate("/app")
+	if _, ok := c.get("/app", "a..main"); ok {
+		t.Error("expected a miss after invalidate")
+	}
+
+	var none *diffCache
+	none.put("/app", "a..main", sections)
+	if _, ok := none.get("/app", "a..main"); ok {
+		t.Error("a nil cache serves nothing")
+	}
+}
+
+func TestStartDiffSession_ReusesCachedDiff(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	// Unreachable: a request would fail the test with an ApiErrorMsg
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	app := &m.state.Apps[0]
+	app.Sources = []model.AppSource{{Revision: "abc123", TargetRevision: "main"}}
+	key := appKey(app.Name, app.AppNamespace)
+	m.diffCache.put(key, diffCacheRevisions(*app, m.config), []model.DiffSection{
+		{Kind: "Deployment", Name: "web", Live: "a: 1", Desired: "a: 2"},
+		{Kind: "Service", Name: "web", Live: "b: 1", Desired: "b: 2"},
+	})
+
+	msg := m.startDiffSession(app.Name, app.AppNamespace)()
+	outline, ok := msg.(model.DiffOutlineLoadedMsg)
+	if !ok {
+		t.Fatalf("expected the cached outline, got %T %+v", msg, msg)
+	}
+	if len(outline.Sections) != 2 {
+		t.Errorf("expected 2 cached sections, got %d", len(outline.Sections))
+	}
+}
+
+func TestApplyBatchAppUpdate_InvalidatesDiffOnEveryUpdate(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	app := m.state.Apps[0]
+	app.Sources = []model.AppSource{{Revision: "abc123", TargetRevision: "main"}}
+	m.state.Apps[0] = app
+	key := appKey(app.Name, app.AppNamespace)
+	other := appKey(m.state.Apps[1].Name, m.state.Apps[1].AppNamespace)
+	m.diffCache.put(key, diffRevisionPair(app), []model.DiffSection{{Kind: "Deployment", Name: "web"}})
+	m.diffCache.put(other, diffRevisionPair(app), []model.DiffSection{{Kind: "Deployment", Name: "api"}})
+
+	// Live objects can change under the same revisions and sync status
+	changed := app
+	changed.Health = "Degraded"
+	m.applyBatchAppUpdate(model.AppUpdatedMsg{App: changed})
+	if _, ok := m.diffCache.get(key, diffRevisionPair(app)); ok {
+		t.Error("a watch update should drop the app's cached diff")
+	}
+	if _, ok := m.diffCache.get(other, diffRevisionPair(app)); !ok {
+		t.Error("a watch update should keep the cached diffs of other apps")
+	}
+}
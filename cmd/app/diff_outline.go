@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// diffOutlineMaxVisible caps the number of outline rows shown at once
+const diffOutlineMaxVisible = 12
+
+// diffSectionLabel renders a section as Kind namespace/name
+func diffSectionLabel(s model.DiffSection) string {
+	if s.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// diffSectionMarker returns a one-character change marker: + created, - pruned, ~ modified
+func diffSectionMarker(s model.DiffSection) string {
+	switch {
+	case s.Deferred:
+		return "~" // not loaded yet; out of sync is all that is known
+	case s.Live == "":
+		return "+"
+	case s.Desired == "":
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// diffOutlineRowCount is the number of picker rows including the "all resources" row
+func diffOutlineRowCount(st *model.DiffOutlineState) int {
+	return len(st.Sections) + 1
+}
+
+// handleDiffOutlineKeys handles input in the diff outline picker
+func (m *Model) handleDiffOutlineKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.DiffOutline
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+
+	rows := diffOutlineRowCount(st)
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		m.state.Mode = model.ModeNormal
+		if m.state.Modals.DiffSummary != nil {
+			m.state.Mode = model.ModeDiffSummary
+		}
+		m.state.Modals.DiffOutline = nil
+		return m, nil
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < rows-1 {
+			st.SelectedIdx++
+		}
+	case "pgup":
+		st.SelectedIdx = max(0, st.SelectedIdx-diffOutlineMaxVisible)
+	case "pgdown":
+		st.SelectedIdx = min(rows-1, st.SelectedIdx+diffOutlineMaxVisible)
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = rows - 1
+	case "enter":
+		return m, m.openDiffOutlineSelection()
+	case "o":
+		return m.handleOpenDiffSource()
+	case "ctrl+r":
+		return m.handleReloadView()
+	}
+	return m, nil
+}
+
+// openDiffOutlineSelection opens the pager for the selected outline row.
+// The outline stays in Modals so the picker reappears once the pager closes.
+func (m *Model) openDiffOutlineSelection() tea.Cmd {
+	st := m.state.Modals.DiffOutline
+	epoch := m.switchEpoch
+	if st == nil {
+		return nil
+	}
+
+	subject := st.AppName
+	sections := st.Sections
+	if st.SelectedIdx > 0 && st.SelectedIdx <= len(st.Sections) {
+		sec := st.Sections[st.SelectedIdx-1]
+		sections = []model.DiffSection{sec}
+		subject = fmt.Sprintf("%s/%s", sec.Kind, sec.Name)
+		if sec.Namespace != "" {
+			subject = fmt.Sprintf("%s/%s/%s", sec.Namespace, sec.Kind, sec.Name)
+		}
+		if sec.Deferred {
+			return m.openDeferredDiffSection(st.AppName, st.AppNamespace, subject, sec)
+		}
+	} else if st.Notice != "" {
+		return func() tea.Msg {
+			return model.StatusChangeMsg{Status: "The full diff is too large to open; pick a resource"}
+		}
+	}
+
+	return func() tea.Msg {
+		return m.showDiffSections(subject, sections, epoch)
+	}
+}
+
+// responseTooLarge returns the size-limit error in err's chain, if any
+func responseTooLarge(err error) *api.ResponseTooLargeError {
+	var tooLarge *api.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return tooLarge
+	}
+	return nil
+}
+
+// deferredDiffOutline builds an outline of the app's out-of-sync resources
+// whose diffs are loaded one at a time, for apps whose full diff exceeded the
+// response size limit. Runs inside a tea.Cmd goroutine.
+func deferredDiffOutline(ctx context.Context, argo services.ArgoApiService, server *model.Server, appName string, appNamespace *string, tooLarge *api.ResponseTooLargeError, epoch int) tea.Msg {
+	app, err := argo.GetApplication(ctx, server, appName, appNamespace)
+	if err != nil {
+		return model.ApiErrorMsg{Message: "Failed to load diffs: " + tooLarge.Error(), SwitchEpoch: epoch}
+	}
+	var sections []model.DiffSection
+	for _, r := range app.Status.Resources {
+		if r.Status != "OutOfSync" {
+			continue
+		}
+		sections = append(sections, model.DiffSection{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name, Deferred: true})
+	}
+	if len(sections) == 0 {
+		return model.SetModeMsg{Mode: model.ModeNoDiff}
+	}
+	notice := fmt.Sprintf("Full diff is %s (limit %s); resources load one at a time",
+		api.FormatByteSize(tooLarge.Size), api.FormatByteSize(tooLarge.Limit))
+	if tooLarge.Path != "" {
+		notice += ". Saved to " + tooLarge.Path
+	}
+	return model.DiffOutlineLoadedMsg{
+		AppName:      appName,
+		AppNamespace: appNamespace,
+		Sections:     sections,
+		Notice:       notice,
+		SwitchEpoch:  epoch,
+	}
+}
+
+// openDeferredDiffSection loads one resource's diff and opens it
+func (m *Model) openDeferredDiffSection(appName string, appNamespace *string, subject string, sec model.DiffSection) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 45*time.Second)
+		defer cancel()
+
+		argo := services.NewArgoApiService(server)
+		d, err := argo.GetResourceDiff(ctx, server, appName, appNamespace, api.ManagedResourceKey{
+			Group: sec.Group, Kind: sec.Kind, Namespace: sec.Namespace, Name: sec.Name,
+		})
+		if err != nil {
+			return model.ApiErrorMsg{Message: "Failed to load diff: " + err.Error(), SwitchEpoch: epoch}
+		}
+		if d == nil {
+			return model.SetModeMsg{Mode: model.ModeNoDiff}
+		}
+		sections := buildDiffSections([]services.ResourceDiff{*d})
+		if len(sections) == 0 {
+			return model.SetModeMsg{Mode: model.ModeNoDiff}
+		}
+		return m.showDiffSections(subject, sections, epoch)
+	}
+}
+
+// renderDiffOutlineModal renders the resource outline for a multi-resource diff
+func (m *Model) renderDiffOutlineModal() string {
+	st := m.state.Modals.DiffOutline
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(50, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+
+	title := lipgloss.NewStyle().
+		Foreground(yellowBright).
+		Bold(true).
+		Render("Diff " + st.AppName)
+	subtitle := lipgloss.NewStyle().
+		Foreground(dimColor).
+		Render(fmt.Sprintf("%d changed resources", len(st.Sections)))
+
+	var lines []string
+	lines = append(lines, title+" "+subtitle, "")
+	if st.Notice != "" {
+		notice := lipgloss.NewStyle().Foreground(yellowBright).Width(innerWidth).Render("⚠ " + st.Notice)
+		lines = append(lines, notice, "")
+	}
+
+	rows := diffOutlineRowCount(st)
+	startIdx := 0
+	if st.SelectedIdx >= diffOutlineMaxVisible {
+		startIdx = st.SelectedIdx - diffOutlineMaxVisible + 1
+	}
+	endIdx := min(rows, startIdx+diffOutlineMaxVisible)
+
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		var label string
+		var marker string
+		if i == 0 {
+			marker = "*"
+			label = "All resources"
+			if st.Notice != "" {
+				label += " (too large)"
+			}
+		} else {
+			sec := st.Sections[i-1]
+			marker = diffSectionMarker(sec)
+			label = diffSectionLabel(sec)
+		}
+		text := truncateWithEllipsis(marker+" "+label, max(1, innerWidth-4))
+		if i == st.SelectedIdx {
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Padding(0, 1).
+				Render("► "+text))
+			continue
+		}
+		var styled string
+		switch marker {
+		case "+":
+			styled = lipgloss.NewStyle().Foreground(syncedColor).Render(text)
+		case "-":
+			styled = lipgloss.NewStyle().Foreground(redColor).Render(text)
+		default:
+			styled = text
+		}
+		lines = append(lines, "  "+styled)
+	}
+
+	if endIdx < rows {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	help := "Enter to open • Esc to close"
+	if link, ok := m.selectedDiffSource(); ok {
+		source := "Source: " + link.label
+		if !link.exact {
+			source += " (file not known)"
+		}
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(dimColor).Render(truncateWithEllipsis(source, innerWidth)))
+		help = "Enter to open • o open source • Esc to close"
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(dimColor).Render(help))
+
+	content := strings.Join(lines, "\n")
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(syncedColor).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	return modalStyle.Render(content)
+}
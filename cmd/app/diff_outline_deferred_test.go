@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestDiffSession_TooLargeFallsBackToDeferredOutline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/managed-resources") {
+			w.Write([]byte(`{"items": [` + strings.Repeat(`{"kind": "ConfigMap"},`, 200) + `{}]}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"name": "test-app"}, "status": {"resources": [
+			{"kind": "Deployment", "namespace": "prod", "name": "web", "group": "apps", "status": "OutOfSync"},
+			{"kind": "Service", "namespace": "prod", "name": "web", "status": "Synced"}
+		]}}`))
+	}))
+	defer srv.Close()
+	defer api.SetMaxResponseSize(api.DefaultMaxResponseSize)
+	api.SetMaxResponseSize(1024)
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+
+	msg, ok := m.startDiffSession("test-app", nil)().(model.DiffOutlineLoadedMsg)
+	if !ok {
+		t.Fatalf("expected a deferred outline, got %#v", msg)
+	}
+	if len(msg.Sections) != 1 || !msg.Sections[0].Deferred || msg.Sections[0].Kind != "Deployment" {
+		t.Fatalf("only out-of-sync resources should be listed: %+v", msg.Sections)
+	}
+	if !strings.Contains(msg.Notice, "limit 1.0 KB") || !strings.Contains(msg.Notice, "Saved to ") {
+		t.Errorf("notice = %q", msg.Notice)
+	}
+	os.Remove(msg.Notice[strings.Index(msg.Notice, "Saved to ")+len("Saved to "):])
+
+	m.Update(msg)
+	if m.state.Mode != model.ModeDiffOutline {
+		t.Fatalf("mode = %s", m.state.Mode)
+	}
+	if out := stripANSI(m.renderDiffOutlineModal()); !strings.Contains(out, "All resources (too large)") || !strings.Contains(out, "~ Deployment prod/web") {
+		t.Errorf("outline:\n%s", out)
+	}
+	if status, ok := m.openDiffOutlineSelection()().(model.StatusChangeMsg); !ok || !strings.Contains(status.Status, "too large") {
+		t.Errorf("opening all resources should be refused, got %#v", status)
+	}
+}
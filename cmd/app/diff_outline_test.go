@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+func buildDiffOutlineTestModel(t *testing.T) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(100, 30)
+	m.state.Mode = model.ModeDiffOutline
+	m.state.Modals.DiffOutline = &model.DiffOutlineState{
+		AppName: "test-app",
+		Sections: []model.DiffSection{
+			{Kind: "Deployment", Namespace: "web", Name: "api", Live: "a: 1\n", Desired: "a: 2\n"},
+			{Kind: "ConfigMap", Namespace: "web", Name: "settings", Desired: "b: 1\n"},
+			{Kind: "ClusterRole", Name: "reader", Live: "c: 1\n"},
+		},
+	}
+	return m
+}
+
+func TestBuildDiffSections_SkipsHooksAndIdenticalStates(t *testing.T) {
+	diffs := []services.ResourceDiff{
+		{Kind: "Deployment", Namespace: "web", Name: "api", NormalizedLiveState: `{"a":1}`, PredictedLiveState: `{"a":2}`},
+		{Kind: "Job", Namespace: "web", Name: "migrate", Hook: true, NormalizedLiveState: `{"a":1}`, PredictedLiveState: `{"a":2}`},
+		{Kind: "Service", Namespace: "web", Name: "api", NormalizedLiveState: `{"a":1}`, PredictedLiveState: `{"a":1}`},
+		{Kind: "ConfigMap", Namespace: "web", Name: "new", PredictedLiveState: `{"b":1}`},
+	}
+
+	sections := buildDiffSections(diffs)
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d: %+v", len(sections), sections)
+	}
+	if sections[0].Kind != "Deployment" || sections[1].Kind != "ConfigMap" {
+		t.Fatalf("unexpected section order: %+v", sections)
+	}
+	if sections[1].Live != "" || sections[1].Desired == "" {
+		t.Fatalf("new resource should have only a desired side: %+v", sections[1])
+	}
+}
+
+func TestDiffSectionMarker(t *testing.T) {
+	cases := []struct {
+		sec  model.DiffSection
+		want string
+	}{
+		{model.DiffSection{Live: "x", Desired: "y"}, "~"},
+		{model.DiffSection{Desired: "y"}, "+"},
+		{model.DiffSection{Live: "x"}, "-"},
+	}
+	for _, c := range cases {
+		if got := diffSectionMarker(c.sec); got != c.want {
+			t.Errorf("diffSectionMarker(%+v) = %q, want %q", c.sec, got, c.want)
+		}
+	}
+}
+
+func TestDiffOutlineKeys_NavigationClamps(t *testing.T) {
+	m := buildDiffOutlineTestModel(t)
+
+	step := func(msg tea.KeyMsg, want int, label string) {
+		t.Helper()
+		m.handleDiffOutlineKeys(msg)
+		if got := m.state.Modals.DiffOutline.SelectedIdx; got != want {
+			t.Fatalf("%s: expected idx %d, got %d", label, want, got)
+		}
+	}
+
+	step(tea.KeyPressMsg{Code: tea.KeyUp}, 0, "up clamps at all-resources row")
+	step(testKeyMsg("j"), 1, "j")
+	step(tea.KeyPressMsg{Code: tea.KeyDown}, 2, "down")
+	step(testKeyMsg("G"), 3, "G jumps to last resource")
+	step(testKeyMsg("j"), 3, "j clamps at last")
+	step(testKeyMsg("g"), 0, "g jumps to top")
+}
+
+func TestDiffOutlineKeys_EscClosesOutline(t *testing.T) {
+	m := buildDiffOutlineTestModel(t)
+	m.handleDiffOutlineKeys(testKeyMsg("esc"))
+	if m.state.Mode != model.ModeNormal {
+		t.Fatalf("expected normal mode, got %s", m.state.Mode)
+	}
+	if m.state.Modals.DiffOutline != nil {
+		t.Fatal("expected outline state to be cleared")
+	}
+}
+
+func TestDiffOutline_PagerReturnsToOutline(t *testing.T) {
+	m := buildDiffOutlineTestModel(t)
+	m.state.Mode = model.ModeExternal
+
+	next, _ := m.Update(pagerDoneMsg{})
+	if got := next.(*Model).state.Mode; got != model.ModeDiffOutline {
+		t.Fatalf("expected diff outline mode after pager closes, got %s", got)
+	}
+}
+
+func TestDiffOutlineLoadedMsg_StaleEpochIgnored(t *testing.T) {
+	m := buildDeleteTestModel(100, 30)
+	m.switchEpoch = 2
+
+	next, _ := m.Update(model.DiffOutlineLoadedMsg{AppName: "test-app", Sections: []model.DiffSection{{Kind: "A"}, {Kind: "B"}}, SwitchEpoch: 1})
+	if next.(*Model).state.Modals.DiffOutline != nil {
+		t.Fatal("stale outline message should be dropped")
+	}
+
+	next, _ = m.Update(model.DiffOutlineLoadedMsg{AppName: "test-app", Sections: []model.DiffSection{{Kind: "A"}, {Kind: "B"}}, SwitchEpoch: 2})
+	nm := next.(*Model)
+	if nm.state.Mode != model.ModeDiffOutline || nm.state.Modals.DiffOutline == nil {
+		t.Fatalf("expected outline to open, mode=%s", nm.state.Mode)
+	}
+}
+
+func TestRenderDiffOutlineModal_ListsResources(t *testing.T) {
+	m := buildDiffOutlineTestModel(t)
+	out := stripANSI(m.renderDiffOutlineModal())
+	for _, want := range []string{"All resources", "Deployment web/api", "ConfigMap web/settings", "ClusterRole reader", "3 changed resources"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("outline missing %q:\n%s", want, out)
+		}
+	}
+}
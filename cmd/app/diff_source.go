@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/gitweb"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/browser"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// originAnnotation is where kustomize records the file a resource was read
+// from, with buildMetadata: [originAnnotations] in the kustomization
+const originAnnotation = "config.kubernetes.io/origin"
+
+// resourceOrigin is the value of the origin annotation. Repo and Ref are
+// set for resources from a remote base.
+type resourceOrigin struct {
+	Path string `yaml:"path"`
+	Repo string `yaml:"repo"`
+	Ref  string `yaml:"ref"`
+}
+
+// sourceLink is where a changed resource is defined in git
+type sourceLink struct {
+	url   string
+	label string // e.g. "apps/web/deployment.yaml @ 1a2b3c4d"
+	exact bool   // url points at the resource's file, not its source directory
+}
+
+// manifestOrigin reads the origin annotation of a manifest, if any
+func manifestOrigin(manifest string) (resourceOrigin, bool) {
+	var obj struct {
+		Metadata struct {
+			Annotations map[string]string `yaml:"annotations"`
+		} `yaml:"metadata"`
+	}
+	if manifest == "" || yaml.Unmarshal([]byte(manifest), &obj) != nil {
+		return resourceOrigin{}, false
+	}
+	raw, ok := obj.Metadata.Annotations[originAnnotation]
+	if !ok {
+		return resourceOrigin{}, false
+	}
+	var origin resourceOrigin
+	if yaml.Unmarshal([]byte(raw), &origin) != nil || origin.Path == "" {
+		return resourceOrigin{}, false
+	}
+	return origin, true
+}
+
+// gitSource returns the app's first source read from a git repository
+func gitSource(app model.App) (model.AppSource, bool) {
+	for _, src := range app.Sources {
+		if src.RepoURL != "" && src.Chart == "" {
+			return src, true
+		}
+	}
+	return model.AppSource{}, false
+}
+
+// diffSourceLink resolves where a changed resource is defined, at the
+// revision the app is synced to. The file is known when kustomize recorded
+// its origin; otherwise the link is to the app's source directory. A nil
+// section stands for the whole app.
+func diffSourceLink(app model.App, sec *model.DiffSection) (sourceLink, bool) {
+	src, ok := gitSource(app)
+	if !ok {
+		return sourceLink{}, false
+	}
+	revision := src.Revision
+	if revision == "" {
+		revision = src.TargetRevision
+	}
+
+	if sec != nil {
+		manifest := sec.Desired
+		if manifest == "" {
+			manifest = sec.Live // pruned: the file is gone from the target revision
+		}
+		if origin, found := manifestOrigin(manifest); found {
+			repo, rev, file := src.RepoURL, revision, path.Join(src.Path, origin.Path)
+			if origin.Repo != "" {
+				repo, rev, file = origin.Repo, origin.Ref, origin.Path
+			}
+			if link, ok := gitweb.FileURL(repo, rev, file); ok {
+				return sourceLink{url: link, label: sourceLinkLabel(path.Clean(file), rev), exact: true}, true
+			}
+		}
+	}
+
+	link, ok := gitweb.DirURL(src.RepoURL, revision, src.Path)
+	if !ok {
+		return sourceLink{}, false
+	}
+	dir := strings.Trim(path.Clean("/"+src.Path), "/")
+	return sourceLink{url: link, label: sourceLinkLabel(dir+"/", revision)}, true
+}
+
+// sourceLinkLabel renders a repository path and revision, e.g. "apps/web/ @ 1a2b3c4d"
+func sourceLinkLabel(p, revision string) string {
+	if p == "/" {
+		p = "repository root"
+	}
+	if revision == "" {
+		return p
+	}
+	return p + " @ " + shortRevision(revision)
+}
+
+// selectedDiffSource resolves the source link of the diff outline row
+// under the cursor
+func (m *Model) selectedDiffSource() (sourceLink, bool) {
+	st := m.state.Modals.DiffOutline
+	if st == nil {
+		return sourceLink{}, false
+	}
+	app := m.findAppByNameAndNamespace(st.AppName, derefOr(st.AppNamespace))
+	if app == nil {
+		return sourceLink{}, false
+	}
+	var sec *model.DiffSection
+	if st.SelectedIdx > 0 && st.SelectedIdx <= len(st.Sections) {
+		sec = &st.Sections[st.SelectedIdx-1]
+	}
+	return diffSourceLink(*app, sec)
+}
+
+// handleOpenDiffSource opens the git file the selected diff outline row was
+// rendered from in the browser, so drift can be traced to the commit
+func (m *Model) handleOpenDiffSource() (tea.Model, tea.Cmd) {
+	link, ok := m.selectedDiffSource()
+	if !ok {
+		status := "No git source to open for " + m.state.Modals.DiffOutline.AppName
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+	}
+	return m, tea.Batch(browser.OpenCmd(link.url), m.showStatusNote(fmt.Sprintf("Opening %s", link.label)))
+}
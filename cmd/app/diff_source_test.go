@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+const kustomizedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    config.kubernetes.io/origin: |
+      path: ../base/deployment.yaml
+`
+
+func TestDiffSourceLink(t *testing.T) {
+	app := model.App{Name: "web", Sources: []model.AppSource{
+		{RepoURL: "https://charts.example.com", Chart: "redis", TargetRevision: "1.0.0"},
+		{RepoURL: "git@github.com:org/deploy.git", Path: "apps/web/overlays/prod", TargetRevision: "main", Revision: "1a2b3c4d5e6f"},
+	}}
+
+	link, ok := diffSourceLink(app, &model.DiffSection{Kind: "Deployment", Name: "web", Desired: kustomizedDeployment})
+	if !ok || !link.exact || link.url != "https://github.com/org/deploy/blob/1a2b3c4d5e6f/apps/web/overlays/base/deployment.yaml" {
+		t.Fatalf("the origin annotation should give the file, got %+v", link)
+	}
+	if link.label != "apps/web/overlays/base/deployment.yaml @ 1a2b3c4d" {
+		t.Errorf("label: %q", link.label)
+	}
+
+	remote := strings.Replace(kustomizedDeployment, "path: ../base/deployment.yaml",
+		"path: base/deployment.yaml\n      repo: https://gitlab.com/platform/bases\n      ref: v2", 1)
+	link, _ = diffSourceLink(app, &model.DiffSection{Kind: "Deployment", Name: "web", Live: remote})
+	if link.url != "https://gitlab.com/platform/bases/-/blob/v2/base/deployment.yaml" {
+		t.Errorf("a remote base should link to its own repo, got %q", link.url)
+	}
+
+	link, _ = diffSourceLink(app, &model.DiffSection{Kind: "Service", Name: "web", Desired: "kind: Service\n"})
+	if link.exact || link.url != "https://github.com/org/deploy/tree/1a2b3c4d5e6f/apps/web/overlays/prod" {
+		t.Errorf("without an origin the source directory should open, got %+v", link)
+	}
+
+	if _, ok := diffSourceLink(model.App{Sources: app.Sources[:1]}, nil); ok {
+		t.Error("a chart-only app has no git source to open")
+	}
+}
+
+func TestDiffOutline_ShowsAndOpensSource(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].Sources = []model.AppSource{{RepoURL: "https://github.com/org/deploy", Path: "apps/web", Revision: "1a2b3c4d5e6f"}}
+	m.state.Mode = model.ModeDiffOutline
+	m.state.Modals.DiffOutline = &model.DiffOutlineState{
+		AppName:      "test-app",
+		AppNamespace: m.state.Apps[0].AppNamespace,
+		Sections:     []model.DiffSection{{Kind: "Deployment", Name: "web", Desired: kustomizedDeployment}},
+		SelectedIdx:  1,
+	}
+
+	out := stripANSI(m.renderDiffOutlineModal())
+	if !strings.Contains(out, "Source: apps/base/deployment.yaml @ 1a2b3c4d") || !strings.Contains(out, "o open source") {
+		t.Fatalf("the outline should show the selected resource's file:\n%s", out)
+	}
+
+	_, cmd := m.handleDiffOutlineKeys(keyPress("o"))
+	if cmd == nil || m.state.UI.StatusNote != "Opening apps/base/deployment.yaml @ 1a2b3c4d" {
+		t.Fatalf("o should open the file, note %q", m.state.UI.StatusNote)
+	}
+
+	m.state.Modals.DiffOutline.SelectedIdx = 0
+	if out := stripANSI(m.renderDiffOutlineModal()); !strings.Contains(out, "Source: apps/web/ @ 1a2b3c4d (file not known)") {
+		t.Fatalf("the all resources row should point at the source directory:\n%s", out)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// diffSummaryWorkers is how many app diffs the summary loads at once
+const diffSummaryWorkers = 4
+
+// diffSummaryMaxVisible is how many apps the summary lists before scrolling
+const diffSummaryMaxVisible = 12
+
+// diffSummaryStepMsg carries the diff of one app of the summary
+type diffSummaryStepMsg struct {
+	id       int
+	epoch    int
+	idx      int // into DiffSummaryState.Apps
+	sections []model.DiffSection
+	err      error
+}
+
+// startDiffSummary opens the diff summary of the named apps and starts
+// loading their diffs; each loaded diff starts the next
+func (m *Model) startDiffSummary(names []string) tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg { return model.ApiErrorMsg{Message: "No server configured"} }
+	}
+	sort.Strings(names)
+	apps := make([]model.DiffSummaryApp, 0, len(names))
+	for _, name := range names {
+		row := model.DiffSummaryApp{Name: name}
+		if app := m.findAppByNameAndNamespace(name, ""); app != nil {
+			row.AppNamespace = app.AppNamespace
+		}
+		apps = append(apps, row)
+	}
+
+	m.diffSummarySeq++
+	m.state.Modals.DiffSummary = &model.DiffSummaryState{Apps: apps, ID: m.diffSummarySeq}
+	m.state.Mode = model.ModeDiffSummary
+	cblog.With("component", "diff").Info("Loading diff summary", "apps", len(apps))
+
+	var cmds []tea.Cmd
+	for range min(diffSummaryWorkers, len(apps)) {
+		cmds = append(cmds, m.diffSummaryNext())
+	}
+	return tea.Batch(cmds...)
+}
+
+// diffSummaryNext loads the diff of the next app not requested yet
+func (m *Model) diffSummaryNext() tea.Cmd {
+	st := m.state.Modals.DiffSummary
+	if st == nil || st.Started >= len(st.Apps) {
+		return nil
+	}
+	idx := st.Started
+	st.Started++
+	row := st.Apps[idx]
+	id := st.ID
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	prefetch := m.prefetch
+	cache := m.diffCache
+	revisions := ""
+	if app := m.findAppByNameAndNamespace(row.Name, derefOr(row.AppNamespace)); app != nil {
+		revisions = diffCacheRevisions(*app, m.config)
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 45*time.Second)
+		defer cancel()
+		sections, err := loadAppDiff(ctx, server, prefetch, cache, row.Name, row.AppNamespace, revisions)
+		if err != nil {
+			cblog.With("component", "diff").Error("Diff failed", "app", row.Name, "err", err)
+		}
+		return diffSummaryStepMsg{id: id, epoch: epoch, idx: idx, sections: sections, err: err}
+	}
+}
+
+// handleDiffSummaryStep counts the changed resources of a loaded diff and
+// starts loading the next app's
+func (m *Model) handleDiffSummaryStep(msg diffSummaryStepMsg) tea.Cmd {
+	st := m.state.Modals.DiffSummary
+	if st == nil || st.ID != msg.id || msg.idx >= len(st.Apps) {
+		return nil
+	}
+	row := &st.Apps[msg.idx]
+	row.Loaded = true
+	switch {
+	case responseTooLarge(msg.err) != nil:
+		row.TooLarge = true
+	case msg.err != nil:
+		row.Err = msg.err.Error()
+	default:
+		row.Sections = msg.sections
+		for _, sec := range msg.sections {
+			switch {
+			case sec.Live == "":
+				row.Created++
+			case sec.Desired == "":
+				row.Pruned++
+			default:
+				row.Changed++
+			}
+		}
+	}
+	return m.diffSummaryNext()
+}
+
+// handleDiffSummaryKeys handles input in the diff summary; enter opens the
+// selected app's full diff, which returns to the summary once closed
+func (m *Model) handleDiffSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.DiffSummary
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.DiffSummary = nil
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < len(st.Apps)-1 {
+			st.SelectedIdx++
+		}
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = max(0, len(st.Apps)-1)
+	case "enter":
+		return m, m.openDiffSummarySelection()
+	}
+	return m, nil
+}
+
+// openDiffSummarySelection opens the full diff of the app under the cursor,
+// reusing the diff the summary loaded
+func (m *Model) openDiffSummarySelection() tea.Cmd {
+	st := m.state.Modals.DiffSummary
+	if st == nil || st.SelectedIdx >= len(st.Apps) {
+		return nil
+	}
+	row := st.Apps[st.SelectedIdx]
+	if !row.Loaded {
+		status := "Still loading the diff of " + row.Name
+		return func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+	}
+	if row.TooLarge || row.Err != "" {
+		return m.startDiffSession(row.Name, row.AppNamespace)
+	}
+	epoch := m.switchEpoch
+	return func() tea.Msg {
+		return m.diffSectionsMsg(row.Name, row.AppNamespace, row.Sections, epoch)
+	}
+}
+
+// renderDiffSummaryModal renders the diff summary: per app, how many of its
+// resources a sync would change, create and prune
+func (m *Model) renderDiffSummaryModal() string {
+	st := m.state.Modals.DiffSummary
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	bad := lipgloss.NewStyle().Foreground(outOfSyncColor)
+
+	loaded, differ, resources := 0, 0, 0
+	for _, row := range st.Apps {
+		if row.Loaded {
+			loaded++
+		}
+		if n := row.Changed + row.Created + row.Pruned; n > 0 || row.TooLarge {
+			differ++
+			resources += n
+		}
+	}
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render(fmt.Sprintf("Diff of %d apps", len(st.Apps)))
+	if loaded < len(st.Apps) {
+		title += " " + dim.Render(fmt.Sprintf("%s loading %d/%d", m.spinner.View(), loaded, len(st.Apps)))
+	}
+	lines := []string{title, ""}
+
+	nameWidth := len("APP")
+	for _, row := range st.Apps {
+		nameWidth = max(nameWidth, len(row.Name))
+	}
+	nameWidth = min(nameWidth, innerWidth/2)
+	header := fmt.Sprintf("%-*s %7s %7s %7s", nameWidth, "APP", "CHANGED", "NEW", "PRUNED")
+	lines = append(lines, headerStyle.Render("  "+header))
+
+	st.SelectedIdx = min(st.SelectedIdx, max(0, len(st.Apps)-1))
+	startIdx := 0
+	if st.SelectedIdx >= diffSummaryMaxVisible {
+		startIdx = st.SelectedIdx - diffSummaryMaxVisible + 1
+	}
+	endIdx := min(len(st.Apps), startIdx+diffSummaryMaxVisible)
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		row := st.Apps[i]
+		name := fmt.Sprintf("%-*s", nameWidth, truncateWithEllipsis(row.Name, nameWidth))
+		var detail string
+		style := lipgloss.NewStyle()
+		switch {
+		case !row.Loaded && i < st.Started:
+			detail, style = "loading…", dim
+		case !row.Loaded:
+			detail, style = "waiting", dim
+		case row.TooLarge:
+			detail, style = "too large, enter to pick resources", lipgloss.NewStyle().Foreground(yellowBright)
+		case row.Err != "":
+			detail, style = row.Err, bad
+		case row.Changed+row.Created+row.Pruned == 0:
+			detail, style = "no changes", lipgloss.NewStyle().Foreground(syncedColor)
+		default:
+			detail = fmt.Sprintf("%7d %7d %7d", row.Changed, row.Created, row.Pruned)
+		}
+		text := truncateWithEllipsis(name+" "+detail, innerWidth-2)
+		if i == st.SelectedIdx {
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Render("► "+text))
+		} else {
+			lines = append(lines, "  "+style.Render(text))
+		}
+	}
+	if endIdx < len(st.Apps) {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	lines = append(lines, "", dim.Render(fmt.Sprintf("%d of %d apps differ, %d resources", differ, loaded, resources)))
+	help := "Enter to open the app's diff • Esc to close"
+	lines = append(lines, dim.Render(truncateWithEllipsis(help, innerWidth)))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
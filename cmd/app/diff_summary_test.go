@@ -0,0 +1,108 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// runDiffSummary feeds the summary the results of its loads until every
+// app's diff is in
+func runDiffSummary(m *Model, cmd tea.Cmd) {
+	pending := []tea.Cmd{cmd}
+	for len(pending) > 0 {
+		c := pending[0]
+		pending = pending[1:]
+		if c == nil {
+			continue
+		}
+		switch msg := c().(type) {
+		case tea.BatchMsg:
+			pending = append(pending, msg...)
+		case diffSummaryStepMsg:
+			_, next := m.Update(msg)
+			pending = append(pending, next)
+		}
+	}
+}
+
+func TestDiffSummary_CountsChangesPerApp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "zzz-other-app") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"items": [
+			{"kind": "Deployment", "name": "web", "normalizedLiveState": "{\"spec\":{\"replicas\":1}}", "predictedLiveState": "{\"spec\":{\"replicas\":2}}"},
+			{"kind": "ConfigMap", "name": "new", "predictedLiveState": "{\"data\":{\"a\":\"b\"}}"},
+			{"kind": "Service", "name": "same", "normalizedLiveState": "{\"a\":1}", "predictedLiveState": "{\"a\":1}"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+	m.state.Selections.AddSelectedApp("zzz-other-app")
+	m.state.Selections.AddSelectedApp("test-app")
+
+	_, cmd := m.handleOpenDiffForSelection()
+	if m.state.Mode != model.ModeDiffSummary {
+		t.Fatalf("several selected apps should open the summary, mode %s", m.state.Mode)
+	}
+	runDiffSummary(m, cmd)
+
+	apps := m.state.Modals.DiffSummary.Apps
+	if apps[0].Name != "test-app" || apps[0].Changed != 1 || apps[0].Created != 1 || apps[0].Pruned != 0 {
+		t.Errorf("test-app row = %+v", apps[0])
+	}
+	if apps[1].Name != "zzz-other-app" || !apps[1].Loaded || apps[1].Err == "" {
+		t.Errorf("a failed diff should be reported on its row: %+v", apps[1])
+	}
+	out := stripANSI(m.renderDiffSummaryModal())
+	if !strings.Contains(out, "1 of 2 apps differ, 2 resources") || strings.Contains(out, "loading") {
+		t.Errorf("summary:\n%s", out)
+	}
+}
+
+func TestDiffSummary_DrillsIntoAppAndBack(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.state.Mode = model.ModeDiffSummary
+	m.state.Modals.DiffSummary = &model.DiffSummaryState{
+		Apps: []model.DiffSummaryApp{
+			{Name: "test-app", Loaded: true, Changed: 2, Sections: []model.DiffSection{
+				{Kind: "Deployment", Name: "a", Live: "a: 1", Desired: "a: 2"},
+				{Kind: "Service", Name: "b", Live: "b: 1", Desired: "b: 2"},
+			}},
+			{Name: "zzz-other-app"},
+		},
+		Started: 2,
+	}
+
+	m.Update(keyPress("down"))
+	if _, cmd := m.Update(keyPress("enter")); cmd == nil {
+		t.Fatal("an app still loading should say so")
+	} else if status, ok := cmd().(model.StatusChangeMsg); !ok || !strings.Contains(status.Status, "Still loading") {
+		t.Fatalf("got %#v", status)
+	}
+
+	m.Update(keyPress("up"))
+	_, cmd := m.Update(keyPress("enter"))
+	msg, ok := cmd().(model.DiffOutlineLoadedMsg)
+	if !ok {
+		t.Fatalf("the loaded diff should open without a request, got %#v", msg)
+	}
+	m.Update(msg)
+	if m.state.Mode != model.ModeDiffOutline {
+		t.Fatalf("mode = %s", m.state.Mode)
+	}
+
+	m.Update(keyPress("esc"))
+	if m.state.Mode != model.ModeDiffSummary || m.state.Modals.DiffSummary == nil {
+		t.Fatalf("closing the app's diff should return to the summary, mode %s", m.state.Mode)
+	}
+}
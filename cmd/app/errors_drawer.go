@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// errorsMaxVisible is how many errors the drawer lists before scrolling
+const errorsMaxVisible = 10
+
+// Operations a recorded error can be retried with, see handleRetryOperation
+const (
+	retryLoadApps = "load-apps"
+	retryWatch    = "watch"
+	retryRefresh  = "refresh"
+)
+
+// recordError keeps an error for the :errors drawer so it outlives the
+// status line message
+func (m *Model) recordError(source, message, details string, retry *model.RetryOperationMsg) {
+	m.state.RecordError(model.RecentError{
+		At:      clockNow(),
+		Source:  source,
+		Message: message,
+		Details: details,
+		Retry:   retry,
+	})
+}
+
+// recordStructuredError records a structured error under the operation that
+// failed, with a retry when the operation can be re-run from the drawer
+func (m *Model) recordStructuredError(msg model.StructuredErrorMsg) {
+	e := msg.Error
+	op, _ := msg.Context["operation"].(string)
+	source := op
+	if source == "" {
+		source = string(e.Category)
+	}
+	message := e.Message
+	if app, _ := msg.Context["appName"].(string); app != "" && !strings.Contains(message, app) {
+		message = app + ": " + message
+	}
+	var details []string
+	for _, d := range []string{e.Details, e.UserAction} {
+		if d != "" {
+			details = append(details, d)
+		}
+	}
+	var retry *model.RetryOperationMsg
+	switch op {
+	case retryLoadApps, retryWatch, retryRefresh:
+		if msg.Retry {
+			retry = &model.RetryOperationMsg{Operation: op, Context: msg.Context}
+		}
+	}
+	m.recordError(source, message, strings.Join(details, "\n"), retry)
+}
+
+// handleOpenErrors shows the recent errors drawer, newest first
+func (m *Model) handleOpenErrors() (tea.Model, tea.Cmd) {
+	m.state.Modals.Errors = &model.ErrorsState{}
+	m.state.Mode = model.ModeErrors
+	return m, nil
+}
+
+// selectedRecentError returns the error under the drawer's cursor
+func (m *Model) selectedRecentError() (model.RecentError, bool) {
+	st := m.state.Modals.Errors
+	n := len(m.state.RecentErrors)
+	if st == nil || st.SelectedIdx >= n {
+		return model.RecentError{}, false
+	}
+	// The drawer lists newest first
+	return m.state.RecentErrors[n-1-st.SelectedIdx], true
+}
+
+// handleErrorsKeys handles input in the recent errors drawer
+func (m *Model) handleErrorsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.Errors
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+
+	rows := len(m.state.RecentErrors)
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.Errors = nil
+		return m, nil
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < rows-1 {
+			st.SelectedIdx++
+		}
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = max(0, rows-1)
+	case "c":
+		m.state.RecentErrors = nil
+		st.SelectedIdx = 0
+	case "r":
+		e, ok := m.selectedRecentError()
+		if !ok || e.Retry == nil {
+			return m, nil
+		}
+		retry := *e.Retry
+		retry.Attempt++
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.Errors = nil
+		return m, func() tea.Msg { return retry }
+	}
+	return m, nil
+}
+
+// handleRetryOperation re-runs an operation retried from the :errors drawer
+func (m *Model) handleRetryOperation(msg model.RetryOperationMsg) tea.Cmd {
+	switch msg.Operation {
+	case retryLoadApps:
+		m.statusService.Set("Retrying: loading applications")
+		return m.startLoadingApplications()
+	case retryWatch:
+		m.statusService.Set("Retrying: watching applications")
+		return m.startWatchingApplications()
+	case retryRefresh:
+		appName, _ := msg.Context["appName"].(string)
+		appNamespace, _ := msg.Context["appNamespace"].(*string)
+		hard, _ := msg.Context["hard"].(bool)
+		m.statusService.Set("Retrying: refreshing " + appName)
+		return m.refreshSingleApplication(appName, appNamespace, hard)
+	}
+	return nil
+}
+
+// renderErrorsModal renders the recent errors drawer, newest first, with
+// the details of the selected error
+func (m *Model) renderErrorsModal() string {
+	st := m.state.Modals.Errors
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	n := len(m.state.RecentErrors)
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Recent errors")
+	lines := []string{title + " " + dim.Render(fmt.Sprintf("%d, newest first", n)), ""}
+	if n == 0 {
+		lines = append(lines, "No errors so far")
+	}
+
+	sourceWidth, atWidth := 0, 0
+	for _, e := range m.state.RecentErrors {
+		sourceWidth = max(sourceWidth, len(e.Source))
+		atWidth = max(atWidth, len(timefmt.TimeOfDay(e.At)))
+	}
+
+	startIdx := 0
+	if st.SelectedIdx >= errorsMaxVisible {
+		startIdx = st.SelectedIdx - errorsMaxVisible + 1
+	}
+	endIdx := min(n, startIdx+errorsMaxVisible)
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		e := m.state.RecentErrors[n-1-i]
+		at := fmt.Sprintf("%-*s", atWidth, timefmt.TimeOfDay(e.At)) // relative times vary in width
+		message := truncateWithEllipsis(strings.SplitN(e.Message, "\n", 2)[0], max(1, innerWidth-sourceWidth-len(at)-6))
+		if i == st.SelectedIdx {
+			text := fmt.Sprintf("► %s %-*s %s", at, sourceWidth, e.Source, message)
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Padding(0, 1).
+				Render(text))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s",
+			dim.Render(at), lipgloss.NewStyle().Foreground(redColor).Render(fmt.Sprintf("%-*s", sourceWidth, e.Source)), message))
+	}
+	if endIdx < n {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	help := "Esc to close"
+	if e, ok := m.selectedRecentError(); ok {
+		lines = append(lines, "", lipgloss.NewStyle().Width(innerWidth).Render(e.Message))
+		if e.Details != "" {
+			lines = append(lines, dim.Width(innerWidth).Render(e.Details))
+		}
+		help = "c clear • Esc to close"
+		if e.Retry != nil {
+			help = "r retry • " + help
+		}
+	}
+	lines = append(lines, "", dim.Render(help))
+
+	content := strings.Join(lines, "\n")
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(redColor).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	return modalStyle.Render(content)
+}
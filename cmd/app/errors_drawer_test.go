@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apperrors "github.com/darksworm/argonaut/pkg/errors"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestErrorsDrawer_RetriesFailedRefresh(t *testing.T) {
+	refreshes := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("refresh") != "" {
+			refreshes++
+		}
+		w.Write([]byte(`{"metadata": {"name": "test-app"}}`))
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+
+	m.Update(model.StructuredErrorMsg{
+		Error:   apperrors.New(apperrors.ErrorNetwork, "REFRESH_FAILED", "connection reset").WithUserAction("Check your connection"),
+		Context: map[string]interface{}{"operation": "refresh", "appName": "test-app", "hard": false},
+		Retry:   true,
+	})
+	m.Update(model.AppDeleteErrorMsg{AppName: "zzz-other-app", Error: "permission denied"})
+
+	m.state.Mode = model.ModeNormal
+	m.handleOpenErrors()
+	out := stripANSI(m.renderErrorsModal())
+	for _, want := range []string{"Recent errors", "delete  zzz-other-app: permission denied", "refresh test-app: connection reset", "c clear • Esc to close"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("drawer should contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "r retry") {
+		t.Error("a failed delete should not offer a retry")
+	}
+
+	m.handleErrorsKeys(testKeyMsg("j"))
+	if out := stripANSI(m.renderErrorsModal()); !strings.Contains(out, "Check your connection") || !strings.Contains(out, "r retry") {
+		t.Errorf("the refresh error should show its details and offer a retry, got:\n%s", out)
+	}
+
+	_, cmd := m.handleErrorsKeys(testKeyMsg("r"))
+	if cmd == nil || m.state.Mode != model.ModeNormal {
+		t.Fatal("r should close the drawer and retry the refresh")
+	}
+	retry, ok := cmd().(model.RetryOperationMsg)
+	if !ok || retry.Operation != "refresh" || retry.Attempt != 1 {
+		t.Fatalf("unexpected retry message %#v", retry)
+	}
+	_, refresh := m.Update(retry)
+	if refresh == nil {
+		t.Fatal("retrying should refresh the app again")
+	}
+	refresh()
+	if refreshes != 1 {
+		t.Errorf("expected one refresh request, got %d", refreshes)
+	}
+}
+
+func TestErrorsDrawer_RecordsStreamDrops(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(watchEndedMsg{startSequenceNum: m.watchStartSequence, switchEpoch: m.switchEpoch})
+
+	if n := len(m.state.RecentErrors); n != 1 {
+		t.Fatalf("expected the dropped stream to be recorded, got %d errors", n)
+	}
+	if e := m.state.RecentErrors[0]; e.Source != "watch" || e.Retry != nil {
+		t.Errorf("stream drops reconnect on their own and should not offer a retry, got %+v", e)
+	}
+}
+
+func TestErrorsDrawer_KeepsLatest(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	for i := range model.MaxRecentErrors + 5 {
+		m.recordError("api", fmt.Sprintf("error %d", i), "", nil)
+	}
+	if n := len(m.state.RecentErrors); n != model.MaxRecentErrors {
+		t.Fatalf("expected %d errors kept, got %d", model.MaxRecentErrors, n)
+	}
+	if first := m.state.RecentErrors[0].Message; first != "error 5" {
+		t.Errorf("the oldest errors should be dropped first, got %q", first)
+	}
+
+	m.handleOpenErrors()
+	m.handleErrorsKeys(testKeyMsg("c"))
+	if len(m.state.RecentErrors) != 0 || !strings.Contains(stripANSI(m.renderErrorsModal()), "No errors so far") {
+		t.Error("c should clear the drawer")
+	}
+}
+
+func TestErrorsDrawer_RetriedRefreshDisambiguatesAppByNamespace(t *testing.T) {
+	var refreshed []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("refresh") != "" {
+			refreshed = append(refreshed, r.URL.Query().Get("appNamespace"))
+		}
+		w.Write([]byte(`{"metadata": {"name": "test-app"}}`))
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.state.Apps = append([]model.App{{Name: "test-app", AppNamespace: strp("team-b")}}, m.state.Apps...)
+
+	_, refresh := m.Update(model.RetryOperationMsg{
+		Operation: retryRefresh,
+		Context:   map[string]interface{}{"operation": "refresh", "appName": "test-app", "appNamespace": strp("test-namespace"), "hard": false},
+		Attempt:   1,
+	})
+	if refresh == nil {
+		t.Fatal("retrying should refresh the app again")
+	}
+	refresh()
+	if len(refreshed) != 1 || refreshed[0] != "test-namespace" {
+		t.Errorf("the retry should refresh the app in the namespace that failed, got %v", refreshed)
+	}
+}
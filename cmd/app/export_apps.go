@@ -0,0 +1,379 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// exportWorkers is how many apps :export fetches at once
+const exportWorkers = 4
+
+// exportKind is what :export writes for each app
+type exportKind string
+
+const (
+	// exportManifests writes the manifests Argo CD renders for the app, one
+	// file per resource
+	exportManifests exportKind = "manifests"
+	// exportSpecs writes the Application resource itself
+	exportSpecs exportKind = "specs"
+)
+
+// appsExportedMsg reports the outcome of :export
+type appsExportedMsg struct {
+	path   string
+	kind   exportKind
+	apps   int
+	failed []string
+	err    error
+}
+
+// exportFile is one file of an export, by its slash-separated path under
+// the export's root
+type exportFile struct {
+	name string
+	data []byte
+}
+
+// exportApps returns the apps :export writes: those in the current scope,
+// ignoring search and quick filters
+func (m *Model) exportApps() []model.App {
+	idx := m.state.Index
+	if idx == nil && len(m.state.Apps) > 0 {
+		idx = model.BuildAppIndex(m.state.Apps)
+		m.state.Index = idx
+	}
+	apps := append([]model.App(nil), idx.ScopedApps(m.state.Apps, &m.state.Selections)...)
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+	return apps
+}
+
+// handleExportCommand runs ":export [manifests|specs] [dir|file.zip]":
+// writes the rendered manifests or the Application specs of every app in
+// scope to a new directory with a folder per app, or to a zip of one.
+// Existing files are kept.
+func (m *Model) handleExportCommand(args []string) (tea.Model, tea.Cmd) {
+	kind := exportManifests
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case string(exportManifests):
+			args = args[1:]
+		case string(exportSpecs), "spec", "apps":
+			kind, args = exportSpecs, args[1:]
+		}
+	}
+	apps := m.exportApps()
+	if len(apps) == 0 {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "No apps in scope to export"} }
+	}
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return m, func() tea.Msg { return model.ApiErrorMsg{Message: "No server configured"} }
+	}
+
+	dest := strings.Join(args, " ")
+	if dest == "" {
+		dest = "argonaut-export-" + clockNow().Format("20060102-150405")
+	}
+	dest = expandHomePath(dest)
+
+	cblog.With("component", "export").Info("Exporting apps", "kind", kind, "apps", len(apps), "path", dest)
+	m.statusService.Set(fmt.Sprintf("Exporting %s of %d apps…", kind, len(apps)))
+	return m, func() tea.Msg {
+		files, failed := fetchExport(server, apps, kind)
+		msg := appsExportedMsg{path: dest, kind: kind, apps: len(apps) - len(failed), failed: failed}
+		if strings.EqualFold(filepath.Ext(dest), ".zip") {
+			root := strings.TrimSuffix(filepath.Base(dest), filepath.Ext(dest))
+			var data []byte
+			if data, msg.err = zipExport(root, files); msg.err == nil {
+				msg.err = writeNewFile(dest, data)
+			}
+		} else {
+			msg.err = writeExportDir(dest, files)
+		}
+		return msg
+	}
+}
+
+// fetchExport fetches every app's files, a few apps at a time. Apps that
+// fail are named in failed and in export-errors.txt, so a partial backup
+// says what it lacks.
+func fetchExport(server *model.Server, apps []model.App, kind exportKind) (files []exportFile, failed []string) {
+	folders := exportFolders(apps)
+	perApp := make([][]exportFile, len(apps))
+	errs := make([]error, len(apps))
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(exportWorkers, len(apps)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc := api.NewApplicationService(server)
+			for i := range next {
+				perApp[i], errs[i] = fetchAppExport(svc, apps[i], folders[i], kind)
+			}
+		}()
+	}
+	for i := range apps {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var report strings.Builder
+	for i, app := range apps {
+		if errs[i] != nil {
+			cblog.With("component", "export").Error("Failed to export app", "app", app.Name, "err", errs[i])
+			failed = append(failed, app.Name)
+			fmt.Fprintf(&report, "%s: %s\n", folders[i], errs[i])
+			continue
+		}
+		files = append(files, perApp[i]...)
+	}
+	if report.Len() > 0 {
+		files = append(files, exportFile{"export-errors.txt", []byte(report.String())})
+	}
+	return files, failed
+}
+
+// fetchAppExport fetches one app's files under its folder
+func fetchAppExport(svc *api.ApplicationService, app model.App, folder string, kind exportKind) ([]exportFile, error) {
+	ctx, cancel := appcontext.WithAPITimeout(context.Background())
+	defer cancel()
+
+	if kind == exportSpecs {
+		raw, err := svc.GetApplicationJSON(ctx, app.Name, app.AppNamespace)
+		if err != nil {
+			return nil, err
+		}
+		data, err := applicationSpecYAML(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []exportFile{{folder + "/application.yaml", data}}, nil
+	}
+
+	manifests, err := svc.GetManifests(ctx, app.Name, app.AppNamespace)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]exportFile, 0, len(manifests))
+	used := make(map[string]int)
+	for _, manifest := range manifests {
+		doc, err := manifestNode([]byte(manifest))
+		if err != nil {
+			return nil, err
+		}
+		data, err := encodeYAML(doc)
+		if err != nil {
+			return nil, err
+		}
+		name := manifestFileName(doc)
+		if used[name]++; used[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, used[name])
+		}
+		files = append(files, exportFile{folder + "/" + name + ".yaml", data})
+	}
+	return files, nil
+}
+
+// exportFolders names each app's folder after the app, adding the app
+// namespace for apps that share a name
+func exportFolders(apps []model.App) []string {
+	count := make(map[string]int)
+	for _, app := range apps {
+		count[app.Name]++
+	}
+	folders := make([]string, len(apps))
+	for i, app := range apps {
+		folders[i] = exportSafeName(app.Name)
+		if count[app.Name] > 1 && derefOr(app.AppNamespace) != "" {
+			folders[i] = exportSafeName(*app.AppNamespace + "_" + app.Name)
+		}
+	}
+	return folders
+}
+
+// exportUnsafe matches what does not belong in a file name
+var exportUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func exportSafeName(s string) string {
+	return strings.Trim(exportUnsafe.ReplaceAllString(s, "_"), ".")
+}
+
+// manifestFileName names a resource's file after its kind, namespace and
+// name, e.g. "deployment-shop-web"
+func manifestFileName(doc *yaml.Node) string {
+	var kind, namespace, name string
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i].Value, root.Content[i+1]
+		switch {
+		case key == "kind":
+			kind = val.Value
+		case key == "metadata" && val.Kind == yaml.MappingNode:
+			for j := 0; j+1 < len(val.Content); j += 2 {
+				switch val.Content[j].Value {
+				case "name":
+					name = val.Content[j+1].Value
+				case "namespace":
+					namespace = val.Content[j+1].Value
+				}
+			}
+		}
+	}
+	parts := []string{strings.ToLower(kind)}
+	if namespace != "" {
+		parts = append(parts, namespace)
+	}
+	parts = append(parts, name)
+	if out := exportSafeName(strings.Join(parts, "-")); out != "" {
+		return out
+	}
+	return "manifest"
+}
+
+// applicationSpecKeep lists the metadata an exported Application keeps;
+// the rest is set by the server
+var applicationSpecKeep = map[string]bool{"name": true, "namespace": true, "labels": true, "annotations": true, "finalizers": true}
+
+// applicationSpecYAML turns an application as the server sends it into a
+// manifest that recreates it: apiVersion, kind, the metadata a user sets
+// and the spec, without status
+func applicationSpecYAML(raw []byte) ([]byte, error) {
+	doc, err := manifestNode(raw)
+	if err != nil {
+		return nil, err
+	}
+	str := func(v string) *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v} }
+	out := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		str("apiVersion"), str("argoproj.io/v1alpha1"),
+		str("kind"), str("Application"),
+	}}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "metadata":
+			meta := &yaml.Node{Kind: yaml.MappingNode}
+			for j := 0; j+1 < len(val.Content); j += 2 {
+				if applicationSpecKeep[val.Content[j].Value] {
+					meta.Content = append(meta.Content, val.Content[j], val.Content[j+1])
+				}
+			}
+			out.Content = append(out.Content, key, meta)
+		case "spec":
+			out.Content = append(out.Content, key, val)
+		}
+	}
+	return encodeYAML(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{out}})
+}
+
+// yaml11Bools are the strings YAML 1.1 tools such as kubectl read as
+// booleans; they stay quoted
+var yaml11Bools = map[string]bool{"y": true, "n": true, "yes": true, "no": true, "on": true, "off": true}
+
+// blockStyle drops the JSON flow style and quotes a manifest parsed from
+// JSON comes with, so it reads like kubectl get -o yaml
+func blockStyle(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" || !yaml11Bools[strings.ToLower(n.Value)] {
+		n.Style = 0
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// encodeYAML writes a document in block style with the two-space indent
+// kubectl uses
+func encodeYAML(doc *yaml.Node) ([]byte, error) {
+	blockStyle(doc)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to convert manifest to YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeExportDir writes the files under dir, which must not exist yet
+func writeExportDir(dir string, files []exportFile) error {
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists", dir)
+		}
+		return err
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f.name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := writeNewFile(p, f.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zipExport packs the files into a zip under one root folder
+func zipExport(root string, files []exportFile) ([]byte, error) {
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: path.Join(root, f.name), Method: zip.Deflate, Modified: clockNow()})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// handleAppsExported reports where the export went and which apps it lacks
+func (m *Model) handleAppsExported(msg appsExportedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "export").Error("Failed to export apps", "path", msg.path, "err", msg.err)
+		text := "Could not export apps: " + msg.err.Error()
+		m.statusService.Error(text)
+		m.recordError("export", text, msg.err.Error(), nil)
+		return nil
+	}
+	cblog.With("component", "export").Info("Exported apps", "kind", msg.kind, "apps", msg.apps, "failed", len(msg.failed), "path", msg.path)
+	text := fmt.Sprintf("Exported %s of %d apps to %s", msg.kind, msg.apps, msg.path)
+	if len(msg.failed) > 0 {
+		text += fmt.Sprintf(", %d failed (see export-errors.txt)", len(msg.failed))
+		m.recordError("export", fmt.Sprintf("%d of %d apps could not be exported", len(msg.failed), msg.apps+len(msg.failed)),
+			strings.Join(msg.failed, ", "), nil)
+		m.statusService.Error(text)
+		return nil
+	}
+	m.statusService.Set(text)
+	return m.showStatusNote("Exported " + filepath.Base(msg.path))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"archive/zip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// exportTestModel serves test-app's manifests and spec; zzz-other-app's
+// manifests are denied
+func exportTestModel(t *testing.T) *Model {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/applications/test-app/manifests":
+			w.Write([]byte(`{"manifests": [
+				"{\"apiVersion\":\"v1\",\"kind\":\"Service\",\"metadata\":{\"name\":\"web\",\"namespace\":\"shop\"},\"spec\":{\"ports\":[{\"port\":80}]}}",
+				"{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"web\",\"namespace\":\"shop\",\"labels\":{\"enabled\":\"yes\"}},\"spec\":{\"replicas\":2}}"
+			]}`))
+		case "/api/v1/applications/test-app":
+			w.Write([]byte(`{"metadata":{"name":"test-app","namespace":"argocd","uid":"123","resourceVersion":"9","labels":{"team":"shop"}},` +
+				`"spec":{"project":"shop","destination":{"namespace":"shop"}},"status":{"sync":{"status":"Synced"}}}`))
+		default:
+			http.Error(w, `{"message":"permission denied"}`, http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	return m
+}
+
+func TestExport_WritesAFolderPerApp(t *testing.T) {
+	m := exportTestModel(t)
+	dir := filepath.Join(t.TempDir(), "backup")
+
+	_, cmd := m.handleExportCommand([]string{dir})
+	msg := cmd().(appsExportedMsg)
+	if msg.err != nil || msg.apps != 1 || len(msg.failed) != 1 || msg.failed[0] != "zzz-other-app" {
+		t.Fatalf("expected test-app exported and zzz-other-app failed, got %+v", msg)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "test-app", "deployment-shop-web.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: shop\n  labels:\n    enabled: \"yes\"\nspec:\n  replicas: 2\n"
+	if string(got) != want {
+		t.Errorf("manifest should be block YAML in the server's order:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-app", "service-shop-web.yaml")); err != nil {
+		t.Errorf("each resource should get its own file: %v", err)
+	}
+	if report, _ := os.ReadFile(filepath.Join(dir, "export-errors.txt")); !strings.Contains(string(report), "zzz-other-app: ") {
+		t.Errorf("failed apps should be listed in export-errors.txt, got %q", report)
+	}
+
+	m.handleAppsExported(msg)
+	if len(m.state.RecentErrors) == 0 || !strings.Contains(m.state.RecentErrors[0].Details, "zzz-other-app") {
+		t.Errorf("failed apps should be recorded in :errors, got %+v", m.state.RecentErrors)
+	}
+
+	// An existing directory is never written into
+	_, cmd = m.handleExportCommand([]string{dir})
+	if msg := cmd().(appsExportedMsg); msg.err == nil || !strings.Contains(msg.err.Error(), "already exists") {
+		t.Errorf("expected an existing directory to be refused, got %v", msg.err)
+	}
+}
+
+func TestExport_SpecsZipped(t *testing.T) {
+	m := exportTestModel(t)
+	m.state.Apps = m.state.Apps[:1]
+	path := filepath.Join(t.TempDir(), "freeze.zip")
+
+	_, cmd := m.handleExportCommand([]string{"specs", path})
+	if msg := cmd().(appsExportedMsg); msg.err != nil || msg.kind != exportSpecs || msg.apps != 1 {
+		t.Fatalf("unexpected result %+v", msg)
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	if len(zr.File) != 1 || zr.File[0].Name != "freeze/test-app/application.yaml" {
+		t.Fatalf("expected one spec under the archive's folder, got %v", zr.File)
+	}
+	f, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "apiVersion: argoproj.io/v1alpha1\nkind: Application\nmetadata:\n  name: test-app\n  namespace: argocd\n  labels:\n    team: shop\n" +
+		"spec:\n  project: shop\n  destination:\n    namespace: shop\n"
+	if string(got) != want {
+		t.Errorf("spec should keep what recreates the app, without status or server fields:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExportFolders_DisambiguateSharedNames(t *testing.T) {
+	a, b := "team-a", "team-b"
+	got := exportFolders([]model.App{{Name: "web", AppNamespace: &a}, {Name: "web", AppNamespace: &b}, {Name: "db/main"}})
+	if want := []string{"team-a_web", "team-b_web", "db_main"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("exportFolders() = %v, want %v", got, want)
+	}
+}
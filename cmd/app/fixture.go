@@ -0,0 +1,27 @@
+package main
+
+import (
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/fixture"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// startFixture serves the applications in a fixture file locally and points
+// the model at them. Contexts and the app cache are left alone so a demo
+// never touches a real server's state.
+func startFixture(m *Model, path string) (*model.Server, error) {
+	fx, err := fixture.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := fixture.Serve(fx)
+	if err != nil {
+		return nil, err
+	}
+	cblog.With("component", "app").Info("Serving fixture", "path", path, "apps", len(fx.Applications), "trees", len(fx.Trees))
+
+	m.appCacheDir = ""
+	m.state.ContextNames = nil
+	m.currentContextName = "fixture"
+	return &model.Server{BaseURL: baseURL, Token: "fixture"}, nil
+}
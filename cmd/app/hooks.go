@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// hooksMaxVisible caps the number of hook rows shown at once
+const hooksMaxVisible = 12
+
+// hookLogTailLines is how many log lines are read per hook
+const hookLogTailLines = 1000
+
+// hookLabel renders a hook as Kind namespace/name
+func hookLabel(h model.HookRun) string {
+	if h.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", h.Kind, h.Namespace, h.Name)
+	}
+	return fmt.Sprintf("%s %s", h.Kind, h.Name)
+}
+
+// sameHook reports whether two hook runs are the same resource, whatever
+// phase each was read in
+func sameHook(a, b model.HookRun) bool {
+	return a.Group == b.Group && a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// hookPhaseStyle colours a hook phase like the sync and health columns
+func hookPhaseStyle(phase string) lipgloss.Style {
+	switch phase {
+	case "Succeeded":
+		return lipgloss.NewStyle().Foreground(syncedColor)
+	case "Failed", "Error":
+		return lipgloss.NewStyle().Foreground(redColor)
+	case "Running", "Terminating":
+		return lipgloss.NewStyle().Foreground(yellowBright)
+	default:
+		return lipgloss.NewStyle().Foreground(dimColor)
+	}
+}
+
+// handleOpenHooks opens the hooks modal for the app under the cursor, or for
+// the app shown in the tree
+func (m *Model) handleOpenHooks() (tea.Model, tea.Cmd) {
+	switch m.state.Navigation.View {
+	case model.ViewApps:
+		items := m.getVisibleItemsForCurrentView()
+		if m.state.Navigation.SelectedIdx >= len(items) {
+			return m, nil
+		}
+		app, ok := items[m.state.Navigation.SelectedIdx].(model.App)
+		if !ok {
+			return m, nil
+		}
+		return m, m.openHooks(app.Name, app.AppNamespace)
+	case model.ViewTree:
+		if treeApp := m.state.UI.TreeApp; treeApp != nil {
+			return m, m.openHooks(treeApp.Name, treeApp.AppNamespace)
+		}
+	}
+	return m, func() tea.Msg {
+		return model.StatusChangeMsg{Status: "Navigate to apps view first to select an app for hooks"}
+	}
+}
+
+// openHooks shows the hooks modal for the given app and loads its last sync
+func (m *Model) openHooks(appName string, appNamespace *string) tea.Cmd {
+	m.state.Modals.Hooks = &model.HooksState{AppName: appName, AppNamespace: appNamespace, Loading: true}
+	m.state.Mode = model.ModeHooks
+	return m.loadHooks(appName, appNamespace)
+}
+
+// loadHooks reads the hook resources of the app's last sync operation
+func (m *Model) loadHooks(appName string, appNamespace *string) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		app, err := api.NewApplicationService(server).GetApplication(ctx, appName, appNamespace)
+		if err != nil {
+			cblog.With("component", "hooks").Error("Failed to load hooks", "app", appName, "err", err)
+			return model.HooksLoadedMsg{AppName: appName, AppNamespace: appNamespace, Err: err, SwitchEpoch: epoch}
+		}
+		return model.HooksLoadedMsg{AppName: appName, AppNamespace: appNamespace, Hooks: app.HookRuns(), SwitchEpoch: epoch}
+	}
+}
+
+// handleHooksLoaded fills the hooks modal if it still shows the app
+func (m *Model) handleHooksLoaded(msg model.HooksLoadedMsg) {
+	st := m.state.Modals.Hooks
+	if st == nil || st.AppName != msg.AppName || derefOr(st.AppNamespace) != derefOr(msg.AppNamespace) {
+		return
+	}
+	st.Loading = false
+	if msg.Err != nil {
+		st.Error = extractUserFriendlyError(msg.Err)
+		return
+	}
+	st.Hooks = msg.Hooks
+	st.SelectedIdx = 0
+	// Start on the first failed hook; it is usually why the sync is stuck
+	for i, h := range st.Hooks {
+		if h.Phase == "Failed" || h.Phase == "Error" {
+			st.SelectedIdx = i
+			break
+		}
+	}
+}
+
+// handleHooksKeys handles input in the hooks modal
+func (m *Model) handleHooksKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.Hooks
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+
+	rows := len(st.Hooks)
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.Hooks = nil
+		return m, nil
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < rows-1 {
+			st.SelectedIdx++
+		}
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = max(0, rows-1)
+	case "l", "enter":
+		return m, m.openHookLogs()
+	}
+	return m, nil
+}
+
+// openHookLogs reads the logs of the selected hook and opens them in the pager
+func (m *Model) openHookLogs() tea.Cmd {
+	st := m.state.Modals.Hooks
+	if st == nil || st.Loading || st.SelectedIdx >= len(st.Hooks) {
+		return nil
+	}
+	hook := st.Hooks[st.SelectedIdx]
+	if !hook.HasLogs() {
+		st.Error = fmt.Sprintf("%s hooks have no logs", hook.Kind)
+		return nil
+	}
+	st.Error = ""
+
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	appName, appNamespace := st.AppName, st.AppNamespace
+	params := api.LiveResourceParams{
+		AppName:      st.AppName,
+		AppNamespace: st.AppNamespace,
+		ResourceName: hook.Name,
+		Namespace:    hook.Namespace,
+		Kind:         hook.Kind,
+		Group:        hook.Group,
+		Version:      hook.Version,
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		logs, err := api.NewApplicationService(server).GetResourceLogs(ctx, params, hookLogTailLines)
+		if err != nil {
+			cblog.With("component", "hooks").Error("Failed to load hook logs", "hook", hook.Name, "err", err)
+		}
+		return model.HookLogsLoadedMsg{AppName: appName, AppNamespace: appNamespace, Hook: hook, Logs: logs, Err: err, SwitchEpoch: epoch}
+	}
+}
+
+// handleHookLogsLoaded opens hook logs in the pager if the modal still
+// shows the app with the hook selected. The modal stays in Modals so it
+// reappears once the pager closes.
+func (m *Model) handleHookLogsLoaded(msg model.HookLogsLoadedMsg) tea.Cmd {
+	st := m.state.Modals.Hooks
+	if st == nil || m.state.Mode != model.ModeHooks {
+		return nil
+	}
+	if st.AppName != msg.AppName || derefOr(st.AppNamespace) != derefOr(msg.AppNamespace) {
+		return nil
+	}
+	if st.SelectedIdx >= len(st.Hooks) || !sameHook(st.Hooks[st.SelectedIdx], msg.Hook) {
+		return nil
+	}
+	if msg.Err != nil {
+		// Hooks deleted by their hook-delete-policy no longer have pods
+		st.Error = "No logs: " + extractUserFriendlyError(msg.Err)
+		return nil
+	}
+	if strings.TrimSpace(msg.Logs) == "" {
+		st.Error = fmt.Sprintf("%s %s has no log output", msg.Hook.Kind, msg.Hook.Name)
+		return nil
+	}
+	return m.openTextPager(fmt.Sprintf("Logs %s", hookLabel(msg.Hook)), msg.Logs)
+}
+
+// renderHooksModal renders the hook resources of the app's last sync with
+// the phase each one reached
+func (m *Model) renderHooksModal() string {
+	st := m.state.Modals.Hooks
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Hooks " + st.AppName)
+	var lines []string
+	switch {
+	case st.Loading:
+		lines = append(lines, title, "", dim.Render("Loading last sync…"))
+	case len(st.Hooks) == 0 && st.Error == "":
+		lines = append(lines, title, "", "The last sync ran no hooks")
+	default:
+		lines = append(lines, title+" "+dim.Render(fmt.Sprintf("%d from the last sync", len(st.Hooks))), "")
+	}
+
+	typeWidth := 0
+	for _, h := range st.Hooks {
+		typeWidth = max(typeWidth, len(h.HookType))
+	}
+
+	startIdx := 0
+	if st.SelectedIdx >= hooksMaxVisible {
+		startIdx = st.SelectedIdx - hooksMaxVisible + 1
+	}
+	endIdx := min(len(st.Hooks), startIdx+hooksMaxVisible)
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		h := st.Hooks[i]
+		phase := h.Phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		label := truncateWithEllipsis(hookLabel(h), max(1, innerWidth-typeWidth-len(phase)-6))
+		if i == st.SelectedIdx {
+			text := fmt.Sprintf("► %-*s %s %s", typeWidth, h.HookType, label, phase)
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Padding(0, 1).
+				Render(text))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s %s",
+			dim.Render(fmt.Sprintf("%-*s", typeWidth, h.HookType)), label, hookPhaseStyle(phase).Render(phase)))
+	}
+	if endIdx < len(st.Hooks) {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	if st.SelectedIdx < len(st.Hooks) {
+		if msg := st.Hooks[st.SelectedIdx].Message; msg != "" {
+			lines = append(lines, "", lipgloss.NewStyle().Width(innerWidth).Render(msg))
+		}
+	}
+	if st.Error != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(redColor).Width(innerWidth).Render(st.Error))
+	}
+
+	help := "Esc to close"
+	if len(st.Hooks) > 0 {
+		help = "Enter/l logs • Esc to close"
+	}
+	lines = append(lines, "", dim.Render(help))
+
+	content := strings.Join(lines, "\n")
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	return modalStyle.Render(content)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+const hooksAppJSON = `{"metadata": {"name": "test-app", "namespace": "test-namespace"}, "status": {"operationState": {"phase": "Failed", "syncResult": {"resources": [
+	{"group": "batch", "kind": "Job", "namespace": "shop", "name": "schema-check", "hookType": "PreSync", "hookPhase": "Succeeded"},
+	{"group": "batch", "kind": "Job", "namespace": "shop", "name": "migrate", "hookType": "PreSync", "hookPhase": "Failed", "message": "Job has reached the specified backoff limit"},
+	{"kind": "ConfigMap", "namespace": "shop", "name": "marker", "hookType": "PostSync", "hookPhase": "Running"}
+]}}}}`
+
+func TestHooks_OpensOnFailedHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(hooksAppJSON))
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+
+	_, cmd := m.handleKeyMsg(testKeyMsg("H"))
+	if m.state.Mode != model.ModeHooks || cmd == nil {
+		t.Fatalf("H should open the hooks modal and load hooks, mode=%s", m.state.Mode)
+	}
+	m.Update(cmd())
+
+	st := m.state.Modals.Hooks
+	if st.Loading || len(st.Hooks) != 3 {
+		t.Fatalf("expected 3 loaded hooks, got loading=%v hooks=%d", st.Loading, len(st.Hooks))
+	}
+	if st.SelectedIdx != 1 {
+		t.Errorf("cursor should start on the failed hook, got %d", st.SelectedIdx)
+	}
+
+	out := stripANSI(m.renderHooksModal())
+	for _, want := range []string{"Hooks test-app", "PreSync  Job shop/migrate Failed", "PostSync ConfigMap shop/marker Running", "backoff limit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("hooks modal should contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// ConfigMap hooks run no pods
+	m.handleHooksKeys(testKeyMsg("G"))
+	if cmd := m.openHookLogs(); cmd != nil || !strings.Contains(st.Error, "no logs") {
+		t.Errorf("hooks without pods should explain there are no logs, got %q", st.Error)
+	}
+
+	m.handleHooksKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.state.Mode != model.ModeNormal || m.state.Modals.Hooks != nil {
+		t.Error("esc should close the hooks modal")
+	}
+}
+
+func TestHooks_LogsErrorStaysInModal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/logs") {
+			if got := r.URL.Query().Get("resourceName"); got != "migrate" {
+				t.Errorf("logs requested for %q, want migrate", got)
+			}
+			w.Write([]byte(`{"error":{"message":"no pods found for Job migrate"}}`))
+			return
+		}
+		w.Write([]byte(hooksAppJSON))
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.Update(m.openHooks("test-app", m.state.Apps[0].AppNamespace)())
+
+	_, cmd := m.handleHooksKeys(testKeyMsg("l"))
+	if cmd == nil {
+		t.Fatal("l should load the selected hook's logs")
+	}
+	_, next := m.Update(cmd())
+	if next != nil {
+		t.Error("a failed log read should not open the pager")
+	}
+	if m.state.Mode != model.ModeHooks || !strings.Contains(m.state.Modals.Hooks.Error, "no pods found") {
+		t.Errorf("the error should show in the modal, got mode=%s error=%q", m.state.Mode, m.state.Modals.Hooks.Error)
+	}
+}
+
+func TestHooks_IgnoresStaleLoad(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.openHooks("test-app", m.state.Apps[0].AppNamespace)
+
+	m.Update(model.HooksLoadedMsg{AppName: "zzz-other-app", Hooks: []model.HookRun{{Kind: "Job", Name: "x"}}})
+	if st := m.state.Modals.Hooks; !st.Loading || len(st.Hooks) != 0 {
+		t.Error("hooks of another app should be ignored")
+	}
+}
+
+func TestHooks_IgnoresLogsOfAnotherAppOrHook(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.openHooks("test-app", m.state.Apps[0].AppNamespace)
+	migrate := model.HookRun{Group: "batch", Kind: "Job", Namespace: "shop", Name: "migrate"}
+	m.Update(model.HooksLoadedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Hooks: []model.HookRun{migrate}})
+
+	stale := []model.HookLogsLoadedMsg{
+		{AppName: "test-app", AppNamespace: strp("team-b"), Hook: migrate, Err: errors.New("no pods")},
+		{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Hook: model.HookRun{Group: "batch", Kind: "Job", Namespace: "shop", Name: "seed"}, Err: errors.New("no pods")},
+	}
+	for _, msg := range stale {
+		m.Update(msg)
+		if st := m.state.Modals.Hooks; st.Error != "" {
+			t.Errorf("logs of %s/%s %s should be ignored, got error %q", derefOr(msg.AppNamespace), msg.AppName, msg.Hook.Name, st.Error)
+		}
+	}
+}
@@ -8,9 +8,11 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/compat"
 	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 	"github.com/darksworm/argonaut/pkg/theme"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 	"github.com/darksworm/argonaut/pkg/tui/treeview"
 )
 
@@ -119,11 +121,9 @@ func (m *Model) renderEnhancedSearchBar() string {
 	searchLabel := lipgloss.NewStyle().Bold(true).Foreground(cyanBright).Render("Search")
 
 	// Compute widths to make input fill the full row (no trailing help text)
-	totalWidth := m.state.Terminal.Cols
-	// Make the OUTER width match the main bordered box outer width (cols-2)
-	// Inner content width is then outer - borders(2) - padding(2) = cols-6
-	styleWidth := maxInt(0, totalWidth-2)
-	innerWidth := maxInt(0, styleWidth-4)
+	// Make the OUTER width match the main bordered box
+	styleWidth := m.screenWidth()
+	innerWidth := layout.Card.InnerWidth(styleWidth)
 
 	// Allocate remaining width to the input field
 	baseUsed := lipgloss.Width(searchLabel) + 1 /*space*/
@@ -137,6 +137,16 @@ func (m *Model) renderEnhancedSearchBar() string {
 	searchInputView := m.inputComponents.searchInput.View()
 	content := fmt.Sprintf("%s %s", searchLabel, searchInputView)
 
+	// In the apps list, name the fields a term can be limited to, or what is
+	// wrong with the ones typed
+	if m.state.Navigation.View == model.ViewApps && m.state.Diff == nil {
+		hint := lipgloss.NewStyle().Foreground(dimColor).Render(truncateWithEllipsis(appSearchHint, innerWidth))
+		if _, err := parseAppSearch(m.inputComponents.GetSearchValue()); err != nil {
+			hint = lipgloss.NewStyle().Foreground(outOfSyncColor).Render(truncateWithEllipsis("⚠ "+err.Error()+"; "+appSearchHint, innerWidth))
+		}
+		content += "\n" + hint
+	}
+
 	return searchBarStyle.Width(styleWidth).Render(content)
 }
 
@@ -154,18 +164,21 @@ func (m *Model) renderEnhancedCommandBar() string {
 		PaddingRight(1)
 
 	// Compute widths for full-row input (no label, fill full width)
-	totalWidth := m.state.Terminal.Cols
-	// Match OUTER width of main content border (cols-2); inner width = cols-6
-	styleWidth := maxInt(0, totalWidth-2)
-	innerWidth := maxInt(0, styleWidth-4)
+	// Make the OUTER width match the main bordered box
+	styleWidth := m.screenWidth()
+	innerWidth := layout.Card.InnerWidth(styleWidth)
 	minInput := 5
 	inputWidth := maxInt(minInput, innerWidth)
 	if inputWidth != m.inputComponents.commandInput.Width() {
 		m.inputComponents.commandInput.SetWidth(inputWidth)
 	}
 
-	// Render with autocomplete suggestions
+	// Render with autocomplete suggestions, and the fuzzy matches for the
+	// argument being typed on a second row
 	commandInputView := m.renderCommandInputWithAutocomplete(inputWidth)
+	if menu := m.renderCommandMenu(m.inputComponents.GetCommandValue(), inputWidth); menu != "" {
+		commandInputView += "\n" + menu
+	}
 	return commandBarStyle.Width(styleWidth).Render(commandInputView)
 }
 
@@ -240,7 +253,18 @@ func (m *Model) validateCommand(input string) bool {
 				}
 			}
 			return false
-		case "app", "delete", "sync", "diff", "rollback", "resources":
+		case "wait":
+			// Flags are checked when the command runs; only an app name is validated here
+			if strings.HasPrefix(arg, "-") {
+				return true
+			}
+			for _, a := range m.state.Apps {
+				if strings.EqualFold(a.Name, arg) {
+					return true
+				}
+			}
+			return false
+		case "app", "delete", "sync", "diff", "rollback", "resources", "details", "hooks":
 			for _, a := range m.state.Apps {
 				if strings.EqualFold(a.Name, arg) {
 					return true
@@ -270,6 +294,12 @@ func (m *Model) validateCommand(input string) bool {
 				return false
 			}
 			return true
+		case "help":
+			return strings.EqualFold(arg, "views") || strings.EqualFold(arg, "keys")
+		case "keys":
+			return strings.EqualFold(arg, "export")
+		case "profile":
+			return strings.EqualFold(arg, "render")
 		case "context":
 			// Context names are validated at execution time (re-reads config from disk)
 			// so any non-empty arg is syntactically valid here
@@ -356,9 +386,19 @@ func (m *Model) renderCommandInputWithAutocomplete(maxWidth int) string {
 		}
 	}
 
+	// Explain what is wrong while typing. After Enter every word counts as
+	// finished, so a partial argument is reported too.
+	diagnosis := m.commandDiagnosis(currentInput)
+	if m.state.UI.CommandInvalid {
+		diagnosis = m.commandDiagnosis(currentInput + " ")
+		if diagnosis == "" {
+			diagnosis = "invalid command, see :help"
+		}
+	}
+
 	// Determine prompt symbol and style based on command validity
 	var prompt string
-	if m.state.UI.CommandInvalid {
+	if diagnosis != "" {
 		// Red warning triangle for invalid commands
 		promptStyle := lipgloss.NewStyle().Foreground(outOfSyncColor) // red
 		prompt = promptStyle.Render("⚠ ")
@@ -368,13 +408,13 @@ func (m *Model) renderCommandInputWithAutocomplete(maxWidth int) string {
 		prompt = promptStyle.Render("> ")
 	}
 
-	// Add invalid command message if needed
+	// Add the diagnosis if needed, shortened to the room left on the line
 	invalidMessage := ""
-	if m.state.UI.CommandInvalid && currentInput != "" {
+	if diagnosis != "" {
 		availableWidth := maxWidth - lipgloss.Width(prompt) - lipgloss.Width(inputText) - lipgloss.Width(dimSuggestion)
 		if availableWidth > 20 { // Only show if there's enough space
 			messageStyle := lipgloss.NewStyle().Foreground(dimColor) // dim gray
-			invalidMessage = messageStyle.Render(" (unknown command, see :help)")
+			invalidMessage = messageStyle.Render(truncateWithEllipsis(" ("+diagnosis+")", availableWidth))
 		}
 	}
 
@@ -461,6 +501,10 @@ func (m *Model) handleEnhancedSearchModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			}
 			return m, nil
 		} else if m.state.Navigation.View == model.ViewApps {
+			// A search with errors stays open; the bar says what is wrong
+			if _, err := parseAppSearch(searchValue); err != nil {
+				return m, nil
+			}
 			// Keep filter applied in apps view
 			m.inputComponents.BlurInputs()
 			m.state.Mode = model.ModeNormal
@@ -512,6 +556,7 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		m.state.UI.Command = m.inputComponents.GetCommandValue()
 		// Clear invalid flag when user pastes (any change resets the warning)
 		m.state.UI.CommandInvalid = false
+		m.state.UI.CommandMenuIdx = 0
 		return m, cmd
 	case "ctrl+c":
 		// Treat Ctrl+C as closing the input (do not quit app)
@@ -528,9 +573,27 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		m.state.UI.Command = ""
 		m.state.UI.CommandInvalid = false
 		return m, nil
+	case "up", "ctrl+p", "down", "ctrl+n":
+		// Move through the fuzzy matches for the argument being typed
+		delta := 1
+		if msg.String() == "up" || msg.String() == "ctrl+p" {
+			delta = -1
+		}
+		if m.moveCommandMenu(delta) {
+			return m, nil
+		}
+		return m, m.inputComponents.UpdateCommandInput(msg)
 	case "tab":
-		// Tab completion - accept the first autocomplete suggestion
+		// Tab completion - accept the highlighted fuzzy match, or else the
+		// first autocomplete suggestion
 		currentInput := m.inputComponents.GetCommandValue()
+		if applied, ok := m.commandMenuSelection(currentInput); ok {
+			m.inputComponents.SetCommandValue(applied)
+			m.state.UI.Command = applied
+			m.state.UI.CommandMenuIdx = 0
+			m.inputComponents.commandInput.CursorEnd()
+			return m, nil
+		}
 		// Build query with ':' prefix for the engine
 		query := currentInput
 		if !strings.HasPrefix(query, ":") {
@@ -559,7 +622,10 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		}
 		sugg := m.autocompleteEngine.GetCommandAutocomplete(q, m.state)
 		raw := typed
-		if len(sugg) > 0 {
+		if applied, ok := m.commandMenuSelection(typed); ok {
+			// A highlighted fuzzy match stands in for a partial argument
+			raw = applied
+		} else if len(sugg) > 0 {
 			applied := strings.TrimPrefix(sugg[0], ":")
 			// Only accept if it continues what was typed (prefix match)
 			if strings.HasPrefix(strings.ToLower(applied), strings.ToLower(typed)) {
@@ -796,6 +862,61 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 
 			// Start loading rollback history using the same function as R key
 			return m, m.startRollbackSession(target, targetNamespace)
+		case "details", "info":
+			// :details [app]
+			if arg == "" {
+				if m.state.Navigation.View != model.ViewApps {
+					return m, func() tea.Msg {
+						return model.StatusChangeMsg{Status: "Navigate to apps view first to select an app for details"}
+					}
+				}
+				return m.handleOpenAppDetails()
+			}
+			found := m.findAppByNameAndNamespace(arg, "")
+			if found == nil {
+				return m, func() tea.Msg { return model.StatusChangeMsg{Status: "App not found: " + arg} }
+			}
+			return m, m.openAppDetails(found.Name, found.AppNamespace)
+		case "hooks", "hook":
+			// :hooks [app]
+			if arg == "" {
+				return m.handleOpenHooks()
+			}
+			found := m.findAppByNameAndNamespace(arg, "")
+			if found == nil {
+				return m, func() tea.Msg { return model.StatusChangeMsg{Status: "App not found: " + arg} }
+			}
+			return m, m.openHooks(found.Name, found.AppNamespace)
+		case "wait":
+			// :wait [[ns/]app] [--for synced|healthy|operation] [--timeout 5m]
+			return m.handleWaitCommand(parts[1:])
+		case "param":
+			// :param [source] name=value... overrides Helm parameters
+			return m.handleParamCommand(parts[1:])
+		case "save":
+			// :save [file] writes the selected tree resource's live manifest
+			return m.handleSaveManifestCommand(allArgs)
+		case "stage", "train":
+			// :stage opens a release train of the selected apps
+			return m.handleStageCommand()
+		case "export":
+			// :export [manifests|specs] [dir|file.zip] backs up the apps in
+			// scope, a folder per app
+			return m.handleExportCommand(parts[1:])
+		case "termcheck":
+			// :termcheck probes what the terminal supports, for "looks
+			// broken in tmux" reports
+			return m, m.startTermCheck()
+		case "support-bundle", "support":
+			// :support-bundle [file] packs the log, redacted config, recent
+			// errors and API requests into a tar.gz for bug reports
+			return m.handleSupportBundleCommand(allArgs)
+		case "manifest", "yaml":
+			// :manifest shows the selected tree resource's live manifest
+			return m.handleViewManifest()
+		case "wide":
+			// :wide toggles the apps table's name column taking the whole width
+			return m.handleToggleWide()
 		case "resources", "res", "r":
 			target := arg
 			var selectedApp *model.App
@@ -815,7 +936,7 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 					m.cleanupTreeWatchers()
 					// Multiple apps selected - open multi tree view with live updates
 					m.treeView = treeview.NewTreeView(0, 0)
-					m.treeView.ApplyTheme(currentPalette)
+					m.treeView.ApplyStyles(currentStyles)
 					m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 					m.treeNav.Reset() // Reset scroll position
 					m.state.SaveNavigationState()
@@ -866,7 +987,7 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 			}
 			// Single app: open tree view with watch (reset tree view)
 			m.treeView = treeview.NewTreeView(0, 0)
-			m.treeView.ApplyTheme(currentPalette)
+			m.treeView.ApplyStyles(currentStyles)
 			m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 			m.treeNav.Reset() // Reset scroll position
 			m.state.SaveNavigationState()
@@ -1050,6 +1171,9 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 			}
 			return m, nil
 		case "appset", "appsets", "applicationset", "applicationsets", "as":
+			if note := m.unavailable(compat.ApplicationSets); note != "" {
+				return m, func() tea.Msg { return model.StatusChangeMsg{Status: note} }
+			}
 			m.clearTreeApp()
 			m.treeLoading = false
 			m.state.Navigation.SelectedIdx = 0
@@ -1082,16 +1206,44 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 			}
 			return m, nil
 		case "help":
-			// Show help modal
+			// Show help modal, optionally on a specific topic
+			topic := strings.ToLower(arg)
+			if topic != "" && topic != "views" && topic != "keys" {
+				return m, func() tea.Msg {
+					return model.StatusChangeMsg{Status: fmt.Sprintf("Unknown help topic %q. Try :help views or :help keys", arg)}
+				}
+			}
+			m.state.Modals.HelpTopic = topic
 			m.state.Mode = model.ModeHelp
 			return m, nil
+		case "errors", "errs":
+			// Show errors kept since the status line moved on
+			return m.handleOpenErrors()
+		case "stream":
+			// Show the watch stream events and what each changed
+			return m.handleOpenStream()
+		case "profile":
+			// Record render times, or stop and show them
+			return m.handleProfileCommand(arg)
+		case "history", "timeline":
+			// Step back through the app statuses seen this session
+			return m.handleOpenHistory()
+		case "map", "topology":
+			// Show the apps as cells by cluster and namespace
+			return m.handleOpenMap()
+		case "keys", "keymap", "bindings":
+			// Show every key binding, generated from the registry, or export
+			// them as a cheat sheet with :keys export [file]
+			return m.handleKeysCommand(parts[1:])
 		case "theme":
 			return m.handleThemeCommand(arg)
 		case "sort":
 			return m.handleSortCommand(allArgs)
 		case "quit", "q", "q!", "wq", "wq!", "exit":
-			// Exit the application
-			return m, func() tea.Msg { return model.QuitMsg{} }
+			// Exit the application; the ! forms don't ask about watched
+			// operations still running
+			force := strings.HasSuffix(cmd, "!")
+			return m, func() tea.Msg { return model.QuitMsg{Force: force} }
 		case "upgrade", "update":
 			// Trigger upgrade process
 			return m, func() tea.Msg { return model.UpgradeRequestedMsg{} }
@@ -1119,6 +1271,7 @@ func (m *Model) handleEnhancedCommandModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		m.state.UI.Command = m.inputComponents.GetCommandValue()
 		// Clear invalid flag when user types (any change resets the warning)
 		m.state.UI.CommandInvalid = false
+		m.state.UI.CommandMenuIdx = 0
 		return m, cmd
 	}
 }
@@ -1139,6 +1292,7 @@ func (m *Model) handleEnhancedEnterCommandMode() (tea.Model, tea.Cmd) {
 	m.state.Mode = model.ModeCommand
 	m.state.UI.Command = ""
 	m.state.UI.CommandInvalid = false
+	m.state.UI.CommandMenuIdx = 0
 	m.inputComponents.ClearCommandInput()
 	m.inputComponents.FocusCommandInput()
 	return m, nil
@@ -1247,7 +1401,7 @@ func (m *Model) handleSortCommand(arg string) (*Model, tea.Cmd) {
 
 	if !model.IsValidSortField(field) {
 		return m, func() tea.Msg {
-			return model.StatusChangeMsg{Status: "Invalid field. Use: name, sync, or health"}
+			return model.StatusChangeMsg{Status: "Invalid field. Use: name, sync, health, operation or duration"}
 		}
 	}
 
@@ -8,10 +8,13 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/compat"
 	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/kubeconfig"
 	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
 	"github.com/darksworm/argonaut/pkg/theme"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 	"github.com/darksworm/argonaut/pkg/tui/treeview"
 )
 
@@ -46,52 +49,15 @@ func (m *Model) handleNavigationDown() (tea.Model, tea.Cmd) {
 }
 
 // treeViewportHeight computes the number of rows available to render the
-// tree panel body, mirroring the layout math in renderMainLayout/renderTreePanel.
+// tree panel body, from the same layout renderMainLayout draws.
 func (m *Model) treeViewportHeight() int {
-	const (
-		BORDER_LINES       = 2
-		TABLE_HEADER_LINES = 0
-		TAG_LINE           = 0
-		STATUS_LINES       = 1
-	)
-	header := m.renderBanner()
-	headerLines := countLines(header)
-	searchLines := 0
-	if m.state.Mode == model.ModeSearch {
-		searchLines = 1 // search bar is single-line
-	}
-	commandLines := 0
-	if m.state.Mode == model.ModeCommand {
-		commandLines = 1 // command bar is single-line
-	}
-	overhead := BORDER_LINES + headerLines + searchLines + commandLines + TABLE_HEADER_LINES + TAG_LINE + STATUS_LINES
-	availableRows := max(0, m.state.Terminal.Rows-overhead)
-	return max(0, availableRows)
+	return max(1, layout.Card.InnerHeight(m.bodyHeight()))
 }
 
 // listViewportHeight computes the number of visible rows in the list view,
-// matching the calculation in renderListView: availableRows - 2 (for table header and border)
+// matching renderListView: the inside of the box less its header row.
 func (m *Model) listViewportHeight() int {
-	const (
-		BORDER_LINES       = 2
-		TABLE_HEADER_LINES = 0
-		TAG_LINE           = 0
-		STATUS_LINES       = 1
-	)
-	header := m.renderBanner()
-	headerLines := countLines(header)
-	searchLines := 0
-	if m.state.Mode == model.ModeSearch {
-		searchLines = 1
-	}
-	commandLines := 0
-	if m.state.Mode == model.ModeCommand {
-		commandLines = 1
-	}
-	overhead := BORDER_LINES + headerLines + searchLines + commandLines + TABLE_HEADER_LINES + TAG_LINE + STATUS_LINES
-	availableRows := max(0, m.state.Terminal.Rows-overhead)
-	// Match renderListView: tableHeight = availableRows - 1, visibleRows = tableHeight - 1
-	return max(1, availableRows-2)
+	return max(1, layout.Card.InnerHeight(m.bodyHeight())-1)
 }
 
 // handleToggleSelection toggles selection of current item (space key)
@@ -233,6 +199,7 @@ func (m *Model) handleEnterCommandMode() (tea.Model, tea.Cmd) {
 
 // handleShowHelp shows the help modal
 func (m *Model) handleShowHelp() (tea.Model, tea.Cmd) {
+	m.state.Modals.HelpTopic = ""
 	m.state.Mode = model.ModeHelp
 	return m, nil
 }
@@ -304,12 +271,71 @@ func (m *Model) handleSyncModal() (tea.Model, tea.Cmd) {
 		m.state.Modals.ConfirmTargetNamespace = nil
 	}
 
-	if m.state.Modals.ConfirmTarget != nil {
-		m.state.Modals.ConfirmSyncSelected = 0 // default to Yes
+	if target := m.state.Modals.ConfirmTarget; target != nil {
+		if *target == "__MULTI__" {
+			for name, selected := range m.state.Selections.SelectedApps {
+				if !selected {
+					continue
+				}
+				if cmd, refused := m.refuseRepeatedOperation("sync", name, nil); refused {
+					m.state.Modals.ConfirmTarget = nil
+					return m, cmd
+				}
+			}
+		} else if cmd, refused := m.refuseRepeatedOperation("sync", *target, m.state.Modals.ConfirmTargetNamespace); refused {
+			m.state.Modals.ConfirmTarget = nil
+			m.state.Modals.ConfirmTargetNamespace = nil
+			return m, cmd
+		}
+		m.resetConfirmSyncOptions()
 		m.state.Mode = model.ModeConfirmSync
 	}
 
-	return m, nil
+	return m, m.prunePreviewCmd()
+}
+
+// resetConfirmSyncOptions puts the sync modal options back to their defaults,
+// then applies the config sync profile matching a single target app, if any.
+// Options never carry over from a previous sync, so a profile's prune setting
+// cannot leak into the sync of an unrelated app.
+func (m *Model) resetConfirmSyncOptions() {
+	modals := &m.state.Modals
+	modals.ConfirmSyncSelected = 0 // default to Yes
+	modals.ConfirmSyncSource = 0   // default to all sources
+	modals.ConfirmSyncPrune = false
+	modals.ConfirmSyncWatch = true
+	modals.ConfirmSyncPrunePolicy = ""
+	modals.ConfirmSyncPruneLast = false
+	modals.ConfirmSyncServerSideApply = false
+	modals.ConfirmSyncProfile = ""
+	modals.ConfirmSyncPrunePreview = nil
+
+	// Profiles are per app; a multi-app sync keeps the defaults
+	if modals.ConfirmTarget == nil || *modals.ConfirmTarget == "__MULTI__" {
+		return
+	}
+	appNamespace := ""
+	if modals.ConfirmTargetNamespace != nil {
+		appNamespace = *modals.ConfirmTargetNamespace
+	}
+	project := ""
+	if app := m.findAppByNameAndNamespace(*modals.ConfirmTarget, appNamespace); app != nil && app.Project != nil {
+		project = *app.Project
+	}
+	profile := m.config.SyncProfileFor(*modals.ConfirmTarget, project)
+	if profile == nil {
+		return
+	}
+	if profile.Prune != nil {
+		modals.ConfirmSyncPrune = *profile.Prune
+	}
+	if profile.Watch != nil {
+		modals.ConfirmSyncWatch = *profile.Watch
+	}
+	if profile.ServerSideApply != nil {
+		modals.ConfirmSyncServerSideApply = *profile.ServerSideApply
+	}
+	modals.ConfirmSyncProfile = profile.Label()
 }
 
 // handleRollback initiates rollback for selected or current app
@@ -348,6 +374,9 @@ func (m *Model) handleRollback() (tea.Model, tea.Cmd) {
 		m.statusService.Set("No app selected for rollback")
 		return m, nil
 	}
+	if cmd, refused := m.refuseRepeatedOperation("rollback", appName, appNamespace); refused {
+		return m, cmd
+	}
 
 	// Set rollback app name and switch to rollback mode
 	m.state.Modals.RollbackAppName = &appName
@@ -385,9 +414,10 @@ func (m *Model) handleEscape() (tea.Model, tea.Cmd) {
 	default:
 		curr := m.state.Navigation.View
 		// Edge case: in apps view with an applied filter, first Esc only clears the filter
-		if curr == model.ViewApps && (m.state.UI.ActiveFilter != "" || m.state.UI.SearchQuery != "") {
+		if curr == model.ViewApps && (m.state.UI.ActiveFilter != "" || m.state.UI.SearchQuery != "" || m.state.UI.StatusFilter != model.StatusFilterAll) {
 			m.state.UI.SearchQuery = ""
 			m.state.UI.ActiveFilter = ""
+			m.state.UI.StatusFilter = model.StatusFilterAll
 			return m, nil
 		}
 
@@ -419,7 +449,7 @@ func (m *Model) handleEscape() (tea.Model, tea.Cmd) {
 					if parentApp != nil {
 						m = m.cleanupTreeWatchers()
 						m.treeView = treeview.NewTreeView(0, 0)
-						m.treeView.ApplyTheme(currentPalette)
+						m.treeView.ApplyStyles(currentStyles)
 						m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 						m.treeNav.Reset()
 						m.state.Navigation.View = model.ViewTree
@@ -553,13 +583,7 @@ func (m *Model) applyThemePreview(themeName string) {
 
 // themePageSize returns the number of visible theme rows for page scrolling
 func (m *Model) themePageSize() int {
-	headerLines := 2 // title + blank line
-	borderLines := 4 // top + bottom border + padding
-	footerLines := 2 // potential warning message + blank line
-	statusLine := 1  // status line at bottom
-	maxAvailableHeight := m.state.Terminal.Rows - headerLines - borderLines - footerLines - statusLine
-	maxThemeLines := max(5, maxAvailableHeight)
-	return max(1, maxThemeLines-2) // Reserve 2 lines for scroll indicators
+	return max(1, m.themeListLines()-2) // Reserve 2 lines for scroll indicators
 }
 
 // handleHelpModeKeys handles input when in help mode
@@ -574,8 +598,13 @@ func (m *Model) handleHelpModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleNoDiffModeKeys handles input when in no-diff modal mode
 func (m *Model) handleNoDiffModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Any key press closes the modal
+	// Any key press closes the modal, returning to the diff outline if one is open
 	m.state.Mode = model.ModeNormal
+	if m.state.Modals.DiffOutline != nil {
+		m.state.Mode = model.ModeDiffOutline
+	} else if m.state.Modals.DiffSummary != nil {
+		m.state.Mode = model.ModeDiffSummary
+	}
 	return m, nil
 }
 
@@ -627,13 +656,33 @@ func (m *Model) handleDiffModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // diffPageSize returns the number of visible rows for page scrolling in diff mode
 func (m *Model) diffPageSize() int {
-	// Diff view uses most of the terminal height minus borders and header
-	overhead := 6 // header, footer, borders
-	return max(1, m.state.Terminal.Rows-overhead)
+	return m.diffViewportHeight()
+}
+
+// confirmSyncSources returns the sources offered by the sync source picker:
+// the target app's sources when it is a single multi-source app and the
+// server can sync one source, else nil.
+func (m *Model) confirmSyncSources() []model.AppSource {
+	target := m.state.Modals.ConfirmTarget
+	if target == nil || *target == "__MULTI__" {
+		return nil
+	}
+	ns := ""
+	if m.state.Modals.ConfirmTargetNamespace != nil {
+		ns = *m.state.Modals.ConfirmTargetNamespace
+	}
+	app := m.findAppByNameAndNamespace(*target, ns)
+	if app == nil || !app.MultiSource || len(app.Sources) < 2 || m.unavailable(compat.SourceSync) != "" {
+		return nil
+	}
+	return app.Sources
 }
 
 // handleConfirmSyncKeys handles input when in sync confirmation mode
 func (m *Model) handleConfirmSyncKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if p := m.state.Modals.ConfirmSyncPrunePreview; p != nil && p.Picking {
+		return m.handlePruneExcludeKeys(msg)
+	}
 	switch msg.String() {
 	case "esc", "q":
 		m.state.Mode = model.ModeNormal
@@ -650,6 +699,17 @@ func (m *Model) handleConfirmSyncKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.state.Modals.ConfirmSyncSelected = 1
 		}
 		return m, nil
+	case "up", "k":
+		// Move the source picker (multi-source apps only)
+		if len(m.confirmSyncSources()) > 0 && m.state.Modals.ConfirmSyncSource > 0 {
+			m.state.Modals.ConfirmSyncSource--
+		}
+		return m, nil
+	case "down", "j":
+		if n := len(m.confirmSyncSources()); n > 0 && m.state.Modals.ConfirmSyncSource < n {
+			m.state.Modals.ConfirmSyncSource++
+		}
+		return m, nil
 	case "enter":
 		if m.state.Modals.ConfirmSyncSelected == 1 {
 			// Cancel
@@ -660,10 +720,20 @@ func (m *Model) handleConfirmSyncKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		fallthrough
 	case "y":
+		if m.state.Modals.ConfirmSyncLoading {
+			// The sync was already sent; a repeated confirm would send it again
+			return m, nil
+		}
+		// Excluding resources from prune makes a selective sync, which runs
+		// no hooks and records no history; say so before the first confirm
+		if p := m.state.Modals.ConfirmSyncPrunePreview; p != nil && !p.SelectiveWarned && m.pruneExclusionResources() != nil {
+			p.SelectiveWarned = true
+			return m, nil
+		}
 		// Confirm sync - keep modal open and show loading overlay
 		target := m.state.Modals.ConfirmTarget
 		targetNamespace := m.state.Modals.ConfirmTargetNamespace
-		prune := m.state.Modals.ConfirmSyncPrune
+		opts := m.confirmSyncOptions()
 		m.state.Modals.ConfirmSyncLoading = true
 		m.state.Mode = model.ModeConfirmSync
 
@@ -671,36 +741,101 @@ func (m *Model) handleConfirmSyncKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			cblog.With("component", "sync").Info("Executing sync confirmation",
 				"target", *target,
 				"isMulti", *target == "__MULTI__")
+			if m.state.Modals.ConfirmSyncWatch {
+				if *target == "__MULTI__" {
+					for name, selected := range m.state.Selections.SelectedApps {
+						if !selected {
+							continue
+						}
+						// Selections hold names only; key by the listed app so
+						// the watch matches the one the cursor path tracks
+						var ns *string
+						if app := m.findAppByNameAndNamespace(name, ""); app != nil {
+							ns = app.AppNamespace
+						}
+						m.trackWatchedSync(name, ns)
+					}
+				} else {
+					m.trackWatchedSync(*target, targetNamespace)
+				}
+			}
 			if *target == "__MULTI__" {
-				return m, m.syncSelectedApplications(prune)
-			} else {
-				return m, m.syncSingleApplication(*target, targetNamespace, prune)
+				return m, m.syncSelectedApplications(opts)
 			}
+			if sources := m.confirmSyncSources(); m.state.Modals.ConfirmSyncSource > 0 && m.state.Modals.ConfirmSyncSource <= len(sources) {
+				pos := m.state.Modals.ConfirmSyncSource
+				return m, m.syncApplicationSource(*target, targetNamespace, opts, pos, sources[pos-1].TargetRevision)
+			}
+			return m, m.syncSingleApplication(*target, targetNamespace, opts)
 		}
 		return m, nil
 	case "p":
-		// Toggle prune option
+		// Toggle prune option, checking what it would delete the first time
 		m.state.Modals.ConfirmSyncPrune = !m.state.Modals.ConfirmSyncPrune
-		return m, nil
+		return m, m.prunePreviewCmd()
+	case "x":
+		// Pick resources prune should leave alone
+		return m, m.handleStartPruneExclude()
 	case "w":
 		// Toggle watch option (single or multi)
 		m.state.Modals.ConfirmSyncWatch = !m.state.Modals.ConfirmSyncWatch
 		return m, nil
+	case "o":
+		// Cycle prune propagation policy: app default -> foreground -> background -> orphan
+		switch m.state.Modals.ConfirmSyncPrunePolicy {
+		case "":
+			m.state.Modals.ConfirmSyncPrunePolicy = "foreground"
+		case "foreground":
+			m.state.Modals.ConfirmSyncPrunePolicy = "background"
+		case "background":
+			m.state.Modals.ConfirmSyncPrunePolicy = "orphan"
+		default:
+			m.state.Modals.ConfirmSyncPrunePolicy = ""
+		}
+		return m, nil
+	case "L":
+		// Toggle prune-last option
+		m.state.Modals.ConfirmSyncPruneLast = !m.state.Modals.ConfirmSyncPruneLast
+		return m, nil
+	case "a":
+		// Toggle server-side apply
+		m.state.Modals.ConfirmSyncServerSideApply = !m.state.Modals.ConfirmSyncServerSideApply
+		return m, nil
 	}
 	return m, nil
 }
 
+// confirmSyncOptions collects the options chosen in the sync confirmation modal
+func (m *Model) confirmSyncOptions() services.SyncOptions {
+	return services.SyncOptions{
+		Prune:                  m.state.Modals.ConfirmSyncPrune,
+		PruneLast:              m.state.Modals.ConfirmSyncPruneLast,
+		PrunePropagationPolicy: m.state.Modals.ConfirmSyncPrunePolicy,
+		ServerSideApply:        m.state.Modals.ConfirmSyncServerSideApply,
+		Resources:              m.pruneExclusionResources(),
+	}
+}
+
 // rollbackPageSize returns the number of visible rows for page scrolling in rollback mode
 func (m *Model) rollbackPageSize() int {
-	// Approximate: modal takes ~60% of terminal height, minus header/footer
-	modalHeight := m.state.Terminal.Rows * 60 / 100
-	overhead := 6 // header, footer, borders
-	return max(1, modalHeight-overhead)
+	if m.state.Rollback == nil {
+		return 1
+	}
+	return m.rollbackHistoryRows(m.state.Rollback)
 }
 
 // handleRollbackModeKeys handles input when in rollback mode.
 // Navigation keys (up/k, down/j, pgup, pgdown, g, G) are handled by the centralized router.
 func (m *Model) handleRollbackModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if rb := m.state.Rollback; rb != nil && rb.Filtering {
+		return m.handleRollbackFilterKeys(msg)
+	}
+	// The first Esc drops a history filter, the next one closes the modal
+	if rb := m.state.Rollback; rb != nil && rb.Mode == "list" && rb.Filter != "" && msg.String() == "esc" {
+		rb.Filter = ""
+		return m, m.loadRollbackMetadata()
+	}
+
 	switch msg.String() {
 	case "esc", "q", "ctrl+c":
 		// Allow exit even during loading
@@ -716,6 +851,12 @@ func (m *Model) handleRollbackModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg.String() {
+	case "/":
+		// Filter the deployment history by revision, author, message or date
+		if m.state.Rollback.Mode == "list" {
+			m.state.Rollback.Filtering = true
+		}
+		return m, nil
 	case "p":
 		// Toggle prune option in confirmation view
 		if m.state.Rollback.Mode == "confirm" {
@@ -741,6 +882,9 @@ func (m *Model) handleRollbackModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		// Confirm rollback or execute rollback
 		if m.state.Rollback.Mode == "list" {
+			if len(rollbackVisibleRows(m.state.Rollback)) == 0 {
+				return m, nil
+			}
 			// Switch to confirmation mode
 			m.state.Rollback.Mode = "confirm"
 			m.state.Rollback.ConfirmSelected = 0
@@ -762,6 +906,9 @@ func (m *Model) handleRollbackModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					Prune:        m.state.Rollback.Prune,
 					DryRun:       m.state.Rollback.DryRun,
 				}
+				if m.state.Rollback.Watch {
+					m.trackWatchedOperation("rollback", request.Name, request.AppNamespace)
+				}
 				// Set loading state
 				m.state.Rollback.Loading = true
 				m.state.Rollback.Error = ""
@@ -769,6 +916,9 @@ func (m *Model) handleRollbackModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case "ctrl+r":
+		// Reload the deployment history, keeping the selected row
+		return m.handleReloadView()
 	case "d":
 		// Show diff for selected revision (if we want to implement this later)
 		if m.state.Rollback.Mode == "list" && len(m.state.Rollback.Rows) > 0 && m.state.Rollback.SelectedIdx < len(m.state.Rollback.Rows) {
@@ -900,6 +1050,7 @@ func (m *Model) executeAppDeletion() (tea.Model, tea.Cmd) {
 				Cascade:           m.state.Modals.DeleteCascade,
 				PropagationPolicy: m.state.Modals.DeletePropagationPolicy,
 			},
+			Hooks: m.beforeDeleteHooks(appName, m.state.Modals.DeleteAppNamespace),
 		})
 	}
 }
@@ -1071,9 +1222,9 @@ func (m *Model) handleResourceSync() (tea.Model, tea.Cmd) {
 			if m.state.UI.TreeApp != nil {
 				m.state.Modals.ConfirmTargetNamespace = m.state.UI.TreeApp.AppNamespace
 			}
-			m.state.Modals.ConfirmSyncSelected = 0 // default to Yes
+			m.resetConfirmSyncOptions()
 			m.state.Mode = model.ModeConfirmSync
-			return m, nil
+			return m, m.prunePreviewCmd()
 		}
 		return m, nil
 	}
@@ -1147,6 +1298,11 @@ func (m *Model) handleRefreshCommand(arg string, hard bool) (tea.Model, tea.Cmd)
 		return m, m.refreshSingleApplication(appName, appNamespace, hard)
 	}
 
+	// In the projects and ApplicationSets views, refresh every member app
+	if m.state.Navigation.View == model.ViewProjects || m.state.Navigation.View == model.ViewApplicationSets {
+		return m.handleBulkRefreshCommand(arg, hard)
+	}
+
 	// In apps view
 	target := arg
 
@@ -1260,6 +1416,10 @@ func (m *Model) executeResourceSync() (tea.Model, tea.Cmd) {
 	if m.state.Modals.ResourceSyncAppName == nil || len(m.state.Modals.ResourceSyncTargets) == 0 {
 		return m, nil
 	}
+	if m.state.Modals.ResourceSyncLoading {
+		// Already sent; a repeated confirm would send it again
+		return m, nil
+	}
 
 	m.state.Modals.ResourceSyncLoading = true
 
@@ -1270,6 +1430,22 @@ func (m *Model) executeResourceSync() (tea.Model, tea.Cmd) {
 	)
 }
 
+// treeAppNamespace resolves the AppNamespace of an app shown in the tree,
+// using the tree-scoped app first — Argo CD apps are not unique by name
+// across ArgoCD namespaces, so a first-name-match in m.state.Apps can pick
+// the wrong app.
+func (m *Model) treeAppNamespace(appName string) *string {
+	if treeApp := m.state.UI.TreeApp; treeApp != nil && treeApp.Name == appName && treeApp.AppNamespace != nil {
+		return treeApp.AppNamespace
+	}
+	for i := range m.state.Apps {
+		if m.state.Apps[i].Name == appName {
+			return m.state.Apps[i].AppNamespace
+		}
+	}
+	return nil
+}
+
 // handleResourceAction opens the resource actions modal for the selected resource
 func (m *Model) handleResourceAction() (tea.Model, tea.Cmd) {
 	if m.state.Navigation.View != model.ViewTree || m.treeView == nil {
@@ -1288,27 +1464,15 @@ func (m *Model) handleResourceAction() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	sel := selections[0]
-
-	// Resolve AppNamespace using the tree-scoped app first — Argo CD apps are
-	// not unique by name across ArgoCD namespaces, so a first-name-match in
-	// m.state.Apps can pick the wrong app.
-	var appNamespace *string
-	if treeApp := m.state.UI.TreeApp; treeApp != nil && treeApp.Name == sel.AppName {
-		appNamespace = treeApp.AppNamespace
-	}
-	if appNamespace == nil {
-		for i := range m.state.Apps {
-			if m.state.Apps[i].Name == sel.AppName {
-				appNamespace = m.state.Apps[i].AppNamespace
-				break
-			}
-		}
+	if note := m.unavailable(compat.ResourceActions); note != "" {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: note} }
 	}
 
+	sel := selections[0]
+
 	target := model.ResourceActionTarget{
 		AppName:      sel.AppName,
-		AppNamespace: appNamespace,
+		AppNamespace: m.treeAppNamespace(sel.AppName),
 		Group:        sel.Group,
 		Version:      sel.Version,
 		Kind:         sel.Kind,
@@ -1483,6 +1647,8 @@ func (m *Model) handleAuthRequiredModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, func() tea.Msg { return model.QuitMsg{} }
+	case "d":
+		return m.startDeviceLogin()
 	case "l":
 		// Open logs pager with syntax highlighting
 		logFile := os.Getenv("ARGONAUT_LOG_FILE")
@@ -1559,6 +1725,9 @@ func (m *Model) handleConnectionErrorModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		// Return to normal mode from connection error (for retry attempts)
 		m.state.Mode = model.ModeNormal
 		return m, nil
+	case "i", "I":
+		// Continue past a certificate that failed verification; I remembers it
+		return m.continueInsecurely(msg.String() == "I")
 	case "l":
 		// Open system logs view to help debug connection issues
 		// Open logs in ov pager with syntax highlighting
@@ -1594,13 +1763,15 @@ func (m *Model) getVisibleItemsForCurrentView() []interface{} {
 
 // handleKeyMsg centralizes keyboard handling and delegates to mode/view handlers
 func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Global kill: always quit on Ctrl+C
+	// Global kill: always quit on Ctrl+C (asking first while watched
+	// operations run; a second Ctrl+C quits)
 	if msg.String() == "ctrl+c" {
 		return m, func() tea.Msg { return model.QuitMsg{} }
 	}
 
-	// Global escape debounce to prevent rapid consecutive escape key presses
-	if msg.String() == "esc" {
+	// Global escape debounce to prevent rapid consecutive escape key presses.
+	// Off in deterministic mode so tests need not wait it out.
+	if msg.String() == "esc" && !deterministicMode {
 		now := time.Now().UnixMilli()
 		const GLOBAL_ESCAPE_DEBOUNCE_MS = 100 // 100ms debounce
 
@@ -1613,12 +1784,22 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state.Navigation.LastEscPressed = now
 	}
 
+	// Tab focus and Enter on the focused toggle work the same in every modal
+	if next, cmd, handled := m.handleModalFocusKeys(msg); handled {
+		return next, cmd
+	}
+
 	// Centralized navigation interception
 	// Navigation keys (up/k, down/j, pgup, pgdown, g, G) are handled here for all modes
 	// that support list navigation. Mode-specific handlers only handle non-navigation keys.
 	if isNavigationKey(msg) {
 		ctx := m.getNavigatorContext()
 		if ctx.SupportsNavigation {
+			if m.state.Mode == model.ModeRollback {
+				// Rows scrolled into view get their metadata
+				m.executeNavigation(ctx, msg)
+				return m, m.loadRollbackMetadata()
+			}
 			return m.executeNavigation(ctx, msg)
 		}
 		// If navigation not supported, fall through to mode-specific handler
@@ -1650,8 +1831,40 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleResourceActionKeys(msg)
 	case model.ModeDiff:
 		return m.handleDiffModeKeys(msg)
+	case model.ModeDiffOutline:
+		return m.handleDiffOutlineKeys(msg)
+	case model.ModeAppDetails:
+		return m.handleAppDetailsKeys(msg)
+	case model.ModeHooks:
+		return m.handleHooksKeys(msg)
+	case model.ModeErrors:
+		return m.handleErrorsKeys(msg)
+	case model.ModeProfile:
+		return m.handleProfileKeys(msg)
+	case model.ModeStream:
+		return m.handleStreamKeys(msg)
+	case model.ModeHistory:
+		return m.handleHistoryKeys(msg)
+	case model.ModeDiffSummary:
+		return m.handleDiffSummaryKeys(msg)
+	case model.ModeWait:
+		return m.handleWaitKeys(msg)
+	case model.ModeBulkRefresh:
+		return m.handleBulkRefreshKeys(msg)
+	case model.ModeStaging:
+		return m.handleStagingKeys(msg)
+	case model.ModeTermCheck:
+		return m.handleTermCheckKeys(msg)
+	case model.ModeManifest:
+		return m.handleManifestKeys(msg)
+	case model.ModeMap:
+		return m.handleMapKeys(msg)
+	case model.ModeOperationConflict:
+		return m.handleOperationConflictKeys(msg)
 	case model.ModeAuthRequired:
 		return m.handleAuthRequiredModeKeys(msg)
+	case model.ModeDeviceLogin:
+		return m.handleDeviceLoginKeys(msg)
 	case model.ModeError:
 		return m.handleErrorModeKeys(msg)
 	case model.ModeConnectionError:
@@ -1670,6 +1883,10 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleK9sErrorModeKeys(msg)
 	case model.ModeDefaultViewWarning:
 		return m.handleDefaultViewWarningModeKeys(msg)
+	case model.ModeCompatWarning:
+		return m.handleCompatWarningKeys(msg)
+	case model.ModeConfirmQuit:
+		return m.handleConfirmQuitKeys(msg)
 	}
 
 	// Tree view keys when in normal mode.
@@ -1700,6 +1917,7 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.treeView != nil && m.treeView.MatchCount() > 0 {
 				m.treeView.NextMatch()
 				m.treeNav.SetCursor(m.treeView.SelectedIndex())
+				m.treeFollow = false
 			}
 			return m, nil
 		case "N":
@@ -1707,6 +1925,7 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if m.treeView != nil && m.treeView.MatchCount() > 0 {
 				m.treeView.PrevMatch()
 				m.treeNav.SetCursor(m.treeView.SelectedIndex())
+				m.treeFollow = false
 			}
 			return m, nil
 		case "left", "h", "right", "l", "enter":
@@ -1718,6 +1937,7 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						return m.handleNavigateToChildApp(childName, childNamespace)
 					}
 				}
+				m.treeFollow = false
 				// Expand/collapse handled by tree view, then sync treeNav
 				updatedModel, _ := m.treeView.Update(msg)
 				m.treeView = updatedModel.(*treeview.TreeView)
@@ -1734,6 +1954,9 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "K":
 			// Open k9s for the selected resource
 			return m.handleOpenK9s()
+		case "L":
+			// Show logs of the selected Pod, or the latest pod of a Job/CronJob
+			return m.handleOpenPodLogs()
 		case "d":
 			// Show diff for the selected resource
 			return m.handleResourceDiff()
@@ -1756,6 +1979,24 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "a":
 			// Open resource actions modal (Rollouts promote/abort/restart/etc.)
 			return m.handleResourceAction()
+		case "y":
+			// Copy the web UI link of the selected resource
+			return m.handleCopyResourceLink()
+		case "w":
+			// Save the live manifest of the selected resource
+			return m.handleSaveManifestPrompt()
+		case "v":
+			// View the live manifest of the selected resource
+			return m.handleViewManifest()
+		case "ctrl+r":
+			// Reload the resource trees, keeping the cursor
+			return m.handleReloadView()
+		case "W":
+			// Reopen the live updates of the app under the cursor
+			return m.handleTreeResubscribeKey()
+		case "f":
+			// Keep the cursor on the resources of a rollout
+			return m.handleToggleTreeFollow()
 		case ":":
 			// Enter command mode
 			return m.handleEnterCommandMode()
@@ -1786,6 +2027,9 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleEnterCommandMode()
 	case "?":
 		return m.handleShowHelp()
+	case "M":
+		// Show the app map (apps grouped by cluster and namespace)
+		return m.handleOpenMap()
 	case "s":
 		if m.state.Navigation.View == model.ViewApps {
 			return m.handleSyncModal()
@@ -1807,6 +2051,46 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.state.Navigation.View == model.ViewApps {
 			return m.handleOpenAppK9s()
 		}
+	case "i":
+		// Show details (sources, revisions, hydrator) for selected app (apps view)
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handleOpenAppDetails()
+		}
+		return m, nil
+	case "|":
+		// Show or hide the details pane beside the list (apps view)
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handleToggleDetailsPane()
+		}
+		return m, nil
+	case "ctrl+left", "<":
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handlePaneResize(-paneResizeStep)
+		}
+		return m, nil
+	case "ctrl+right", ">":
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handlePaneResize(paneResizeStep)
+		}
+		return m, nil
+	case "H":
+		// Show the hooks run by the last sync of the selected app (apps view)
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handleOpenHooks()
+		}
+		return m, nil
+	case "P":
+		// Scope the apps view to the selected app's project (apps view)
+		return m.handleScopeToAppProject()
+	case "C":
+		// Scope the apps view to the selected app's destination cluster (apps view)
+		return m.handleScopeToAppCluster()
+	case "1", "2", "3", "4":
+		// Quick filter the apps view by sync or health status
+		if m.state.Navigation.View == model.ViewApps {
+			return m.handleStatusFilterKey(msg.String())
+		}
+		return m, nil
 	case "R":
 		cblog.With("component", "tui").Debug("R key pressed", "view", m.state.Navigation.View)
 		if m.state.Navigation.View == model.ViewApps {
@@ -1824,6 +2108,9 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.handleResourceDelete()
 		}
 		return m, nil
+	case "ctrl+r":
+		// Reload the list behind the current view, keeping the cursor
+		return m.handleReloadView()
 	case "esc":
 		return m.handleEscape()
 	case "Z":
@@ -1838,9 +2125,10 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Check if this is ZQ (quit without saving)
 		now := time.Now().UnixMilli()
 		if m.state.Navigation.LastZPressed > 0 && now-m.state.Navigation.LastZPressed < 500 {
-			// ZQ: quit without saving (like vim)
+			// ZQ: quit without saving (like vim), without asking about
+			// watched operations
 			m.state.Navigation.LastZPressed = 0 // Reset Z state
-			return m, func() tea.Msg { return model.QuitMsg{} }
+			return m, func() tea.Msg { return model.QuitMsg{Force: true} }
 		}
 		return m, nil
 	}
@@ -1862,7 +2150,7 @@ func (m *Model) handleOpenResourcesForSelection() (tea.Model, tea.Cmd) {
 		m.cleanupTreeWatchers()
 		// Reset tree view to a fresh multi-app instance
 		m.treeView = treeview.NewTreeView(0, 0)
-		m.treeView.ApplyTheme(currentPalette)
+		m.treeView.ApplyStyles(currentStyles)
 		m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 		m.treeNav.Reset() // Reset scroll position
 		m.state.SaveNavigationState()
@@ -1905,7 +2193,7 @@ func (m *Model) handleOpenResourcesForSelection() (tea.Model, tea.Cmd) {
 	m.cleanupTreeWatchers()
 	// Reset tree view to a fresh single-app instance
 	m.treeView = treeview.NewTreeView(0, 0)
-	m.treeView.ApplyTheme(currentPalette)
+	m.treeView.ApplyStyles(currentStyles)
 	m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 	m.treeNav.Reset() // Reset scroll position
 	m.state.SaveNavigationState()
@@ -1930,7 +2218,7 @@ func (m *Model) handleNavigateToChildApp(appName string, appNamespace string) (t
 	m.state.SaveNavigationState()
 	m = m.cleanupTreeWatchers()
 	m.treeView = treeview.NewTreeView(0, 0)
-	m.treeView.ApplyTheme(currentPalette)
+	m.treeView.ApplyStyles(currentStyles)
 	m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 	m.treeNav.Reset()
 	m.state.Navigation.View = model.ViewTree
@@ -2009,6 +2297,11 @@ func (m *Model) handleResourceDiff() (*Model, tea.Cmd) {
 		})
 	}
 
+	// Argo CD found nothing to change in a Synced resource; skip the request
+	if _, sync := m.treeView.SelectedStatus(); sync == "Synced" {
+		return m, func() tea.Msg { return model.SetModeMsg{Mode: model.ModeNoDiff} }
+	}
+
 	// Get the app name for resource-level diff
 	appName := ""
 	if m.state.UI.TreeApp != nil {
@@ -2289,8 +2582,8 @@ func (m *Model) handleOpenDiffForSelection() (tea.Model, tea.Cmd) {
 		}
 		cblog.With("component", "diff").Debug("Using single selected app", "app", appName)
 	} else if len(selected) > 1 {
-		// Multiple apps selected - cannot show diff for multiple apps
-		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Cannot show diff for multiple apps"} }
+		// Multiple apps selected: summarize their diffs side by side
+		return m, m.startDiffSummary(selected)
 	} else {
 		// No apps selected via checkbox, use cursor position
 		items := m.getVisibleItemsForCurrentView()
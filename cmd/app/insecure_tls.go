@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// serverInsecureSavedMsg reports whether remembering to skip certificate
+// verification for a server was written to the config
+type serverInsecureSavedMsg struct {
+	host string
+	err  error
+}
+
+// continueInsecurely connects again without verifying the server's TLS
+// certificate, like a browser's "proceed anyway". The override lasts for
+// this session; with remember, the server's [servers] entry keeps it.
+func (m *Model) continueInsecurely(remember bool) (tea.Model, tea.Cmd) {
+	if m.state.Server == nil || m.tlsFailure == "" {
+		return m, nil
+	}
+	cblog.With("component", "tls").Warn("Continuing without verifying the server certificate",
+		"server", m.state.Server.BaseURL, "problem", m.tlsFailure, "remember", remember)
+
+	// Safe mode saves nothing, so there is nothing to remember it in
+	remember = remember && !config.SafeMode()
+
+	server := *m.state.Server
+	server.Insecure = true
+	m.state.Server = &server
+	m.insecureOverride = true
+	m.tlsFailure = ""
+
+	// Connect again the way startup does
+	m.state.Mode = model.ModeNormal
+	m.state.Modals.InitialLoading = true
+	cmds := []tea.Cmd{m.validateAuthentication()}
+	if remember {
+		cmds = append(cmds, saveServerInsecure(server.BaseURL))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// saveServerInsecure writes the server's insecure setting to the config
+func saveServerInsecure(baseURL string) tea.Cmd {
+	host := hostFromURL(baseURL)
+	return func() tea.Msg {
+		cfg, err := config.LoadArgonautConfig()
+		if err != nil {
+			return serverInsecureSavedMsg{host: host, err: err}
+		}
+		if cfg.NoConfigWrites {
+			return serverInsecureSavedMsg{host: host, err: fmt.Errorf("the config sets no_config_writes")}
+		}
+		cfg.SetServerInsecure(baseURL)
+		return serverInsecureSavedMsg{host: host, err: config.SaveArgonautConfig(cfg)}
+	}
+}
+
+// handleServerInsecureSaved reports whether the override was remembered
+func (m *Model) handleServerInsecureSaved(msg serverInsecureSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "tls").Error("Failed to remember insecure server", "server", msg.host, "err", msg.err)
+		text := "Not remembered for " + msg.host + ", this session only: " + msg.err.Error()
+		m.statusService.Error(text)
+		m.recordError("tls", text, msg.err.Error(), nil)
+		return nil
+	}
+	m.statusService.Set("Certificate checks stay off for " + msg.host + " ([servers] insecure in config)")
+	return nil
+}
+
+// renderCertificateErrorContent explains a certificate that failed
+// verification and offers to continue without verifying it
+func (m *Model) renderCertificateErrorContent() string {
+	host := "the server"
+	if m.state.Server != nil {
+		host = hostFromURL(m.state.Server.BaseURL)
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(outOfSyncColor).Bold(true)
+	messageStyle := lipgloss.NewStyle().Foreground(whiteBright)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	warn := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
+	tipStyle := lipgloss.NewStyle().Foreground(cyanBright)
+
+	lines := []string{
+		titleStyle.Render("Certificate Error"),
+		"",
+		messageStyle.Render("The TLS certificate of " + host + " could not be verified:"),
+		dim.Render(m.tlsFailure),
+		"",
+		messageStyle.Render("This happens with expired or self-signed internal certificates, but also when someone intercepts the connection."),
+		"",
+		tipStyle.Render("Tip: trust an internal CA with --ca-cert or --ca-path instead of turning verification off."),
+		"",
+		warn.Render("Press 'i' to continue insecurely for this session (certificate not verified)"),
+	}
+	if !config.SafeMode() {
+		lines = append(lines, warn.Render("Press 'I' to continue insecurely and remember it for "+host))
+	}
+	lines = append(lines,
+		"",
+		tipStyle.Render(strings.Join([]string{"Press 'q' to exit", "Press 'l' to view system logs", "Press Esc to retry"}, " | ")),
+	)
+	return strings.Join(lines, "\n")
+}
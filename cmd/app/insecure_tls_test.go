@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestCertificateError_ContinueInsecurelyForSession(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: ts.URL, Token: "t"}
+
+	msg, ok := m.validateAuthentication()().(model.AuthValidationResultMsg)
+	if !ok || msg.Mode != model.ModeConnectionError || !strings.Contains(msg.CertError, "x509") {
+		t.Fatalf("a self-signed server should fail with a certificate error, got %+v", msg)
+	}
+	_, cmd := m.Update(msg)
+	m.Update(cmd())
+	if m.state.Mode != model.ModeConnectionError || m.tlsFailure == "" {
+		t.Fatalf("mode = %s, tlsFailure = %q", m.state.Mode, m.tlsFailure)
+	}
+	view := stripANSI(m.renderConnectionErrorView())
+	if !strings.Contains(view, "Certificate Error") || !strings.Contains(view, "continue insecurely for this session") {
+		t.Errorf("the error view should offer to continue insecurely:\n%s", view)
+	}
+
+	_, cmd = m.Update(keyPress("i"))
+	if !m.state.Server.Insecure || !m.insecureOverride || m.tlsFailure != "" {
+		t.Fatalf("i should turn verification off for the session: %+v", m.state.Server)
+	}
+	if m.state.Mode != model.ModeNormal || !m.state.Modals.InitialLoading || cmd == nil {
+		t.Fatalf("i should connect again, mode = %s", m.state.Mode)
+	}
+	if msg, ok := m.validateAuthentication()().(model.AuthValidationResultMsg); !ok || msg.Mode != model.ModeLoading {
+		t.Errorf("the insecure connection should authenticate, got %+v", msg)
+	}
+	if banner := stripANSI(m.renderContextBlock(false)); !strings.Contains(banner, "TLS: not verified") {
+		t.Errorf("the header should mark the unverified connection:\n%s", banner)
+	}
+}
+
+func TestCertificateError_OnlyOfferedForCertificateFailures(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argocd.example.com", Token: "t"}
+	m.state.Mode = model.ModeConnectionError
+
+	m.Update(keyPress("i"))
+	if m.state.Server.Insecure || m.state.Mode != model.ModeConnectionError {
+		t.Error("without a certificate error, i should do nothing")
+	}
+	if view := stripANSI(m.renderConnectionErrorView()); strings.Contains(view, "insecurely") {
+		t.Errorf("a plain connection error should not offer to skip verification:\n%s", view)
+	}
+}
+
+func TestCertificateError_SafeModeOnlyContinuesForTheSession(t *testing.T) {
+	config.SetSafeMode(true)
+	t.Cleanup(func() { config.SetSafeMode(false) })
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "t"}
+	m.state.Mode = model.ModeConnectionError
+	m.tlsFailure = "x509: certificate signed by unknown authority"
+
+	if view := stripANSI(m.renderConnectionErrorView()); strings.Contains(view, "remember") {
+		t.Errorf("safe mode saves nothing and should not offer to remember:\n%s", view)
+	}
+	_, cmd := m.Update(keyPress("I"))
+	if !m.state.Server.Insecure || cmd == nil {
+		t.Fatalf("I should still continue for the session: %+v", m.state.Server)
+	}
+}
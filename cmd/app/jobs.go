@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// podLogTailLines is how many log lines are read per pod
+const podLogTailLines = 1000
+
+// jobDetail describes the last run of the Job or CronJob under the cursor:
+// its phase, failed pods and why it failed
+func (m *Model) jobDetail() string {
+	if m.treeView == nil {
+		return ""
+	}
+	runs, ok := m.treeView.SelectedJobRuns()
+	if !ok {
+		return ""
+	}
+	if runs.Phase == "" {
+		return runs.Kind + " has not run yet"
+	}
+
+	parts := []string{fmt.Sprintf("%s last run %s", runs.Kind, runs.Phase)}
+	if runs.Kind == "CronJob" && runs.LastRun != nil {
+		parts[0] += " " + timefmt.At(*runs.LastRun)
+	}
+	if runs.FailedPods > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", runs.FailedPods))
+	}
+	if runs.Message != "" {
+		parts = append(parts, runs.Message)
+	}
+	if runs.LatestPod != nil {
+		parts = append(parts, "L: logs")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// handleOpenPodLogs opens the logs of the Pod under the cursor, or of the
+// latest pod of the Job or CronJob under the cursor
+func (m *Model) handleOpenPodLogs() (tea.Model, tea.Cmd) {
+	if m.treeView == nil {
+		return m, nil
+	}
+	var pod *treeview.ResourceSelection
+	if runs, ok := m.treeView.SelectedJobRuns(); ok {
+		pod = runs.LatestPod
+		if pod == nil {
+			return m, func() tea.Msg {
+				return model.StatusChangeMsg{Status: runs.Kind + " has no pods to show logs for"}
+			}
+		}
+	} else if sel, ok := m.treeView.CurrentResource(); ok && sel.Group == "" && sel.Kind == "Pod" {
+		pod = &sel
+	}
+	if pod == nil {
+		return m, func() tea.Msg {
+			return model.StatusChangeMsg{Status: "Logs are available for Pods, Jobs and CronJobs"}
+		}
+	}
+	return m, m.fetchPodLogs(*pod)
+}
+
+// fetchPodLogs reads a pod's logs through Argo CD
+func (m *Model) fetchPodLogs(pod treeview.ResourceSelection) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	params := api.LiveResourceParams{
+		AppName:      pod.AppName,
+		AppNamespace: m.treeAppNamespace(pod.AppName),
+		ResourceName: pod.Name,
+		Namespace:    pod.Namespace,
+		Kind:         pod.Kind,
+		Version:      pod.Version,
+	}
+	label := pod.Namespace + "/" + pod.Name
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		logs, err := api.NewApplicationService(server).GetResourceLogs(ctx, params, podLogTailLines)
+		if err != nil {
+			cblog.With("component", "logs").Error("Failed to load pod logs", "pod", label, "err", err)
+		}
+		return model.PodLogsLoadedMsg{Pod: label, Logs: logs, Err: err, SwitchEpoch: epoch}
+	}
+}
+
+// handlePodLogsLoaded opens pod logs in the pager, or says why it cannot
+func (m *Model) handlePodLogsLoaded(msg model.PodLogsLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.statusService.Set("No logs for " + msg.Pod + ": " + extractUserFriendlyError(msg.Err))
+		return nil
+	}
+	if strings.TrimSpace(msg.Logs) == "" {
+		m.statusService.Set(msg.Pod + " has no log output")
+		return nil
+	}
+	return m.openTextPager("Logs "+msg.Pod, msg.Logs)
+}
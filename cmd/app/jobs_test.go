@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// buildJobTreeModel shows a tree with a failed Job and its pod, served by srv
+func buildJobTreeModel(t *testing.T, srv *httptest.Server) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(140, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.state.Navigation.View = model.ViewTree
+	m.state.UI.TreeApp = &model.TreeAppInfo{Name: "test-app", AppNamespace: m.state.Apps[0].AppNamespace}
+
+	m.treeView = treeview.NewTreeView(0, 0)
+	ns := "ops"
+	degraded, backoff := "Degraded", "Job has reached the specified backoff limit"
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "j1", Group: "batch", Version: "v1", Kind: "Job", Name: "migrate", Namespace: &ns,
+			Health: &api.ResourceHealth{Status: &degraded, Message: &backoff}},
+		{UID: "p1", Version: "v1", Kind: "Pod", Name: "migrate-x1", Namespace: &ns,
+			ParentRefs: []api.ResourceRef{{Group: "batch", Kind: "Job", UID: "j1"}}, Health: &api.ResourceHealth{Status: &degraded}},
+	}}
+	m.treeView.UpsertAppTree("test-app", &tree)
+	m.treeView.SetSelectedIndex(1) // Job node (0 is synthetic Application root)
+	return m
+}
+
+func TestJobDetail_DescribesLastRun(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	want := "Job last run Failed • 1 failed • Job has reached the specified backoff limit • L: logs"
+	if got := m.jobDetail(); got != want {
+		t.Errorf("jobDetail() = %q, want %q", got, want)
+	}
+	if line := stripANSI(m.renderStatusLine()); !strings.Contains(line, "Job last run Failed") {
+		t.Errorf("status line should describe the job, got %q", line)
+	}
+}
+
+func TestPodLogs_OpensLatestPodOfJob(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("kind") != "Pod" || q.Get("resourceName") != "migrate-x1" || q.Get("appNamespace") != "test-namespace" {
+			t.Errorf("unexpected logs query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"result":{"content":"","podName":"migrate-x1","last":true}}`))
+	}))
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	_, cmd := m.handleKeyMsg(testKeyMsg("L"))
+	if cmd == nil {
+		t.Fatal("L on a Job should load its latest pod's logs")
+	}
+	msg := cmd()
+	if got, ok := msg.(model.PodLogsLoadedMsg); !ok || got.Pod != "ops/migrate-x1" {
+		t.Fatalf("unexpected message %#v", msg)
+	}
+	if _, next := m.Update(msg); next != nil {
+		t.Error("empty logs should not open the pager")
+	}
+}
+
+func TestPodLogs_OtherKinds(t *testing.T) {
+	m := buildDeleteTestModel(100, 30)
+	m.state.Navigation.View = model.ViewTree
+	m.treeView = treeview.NewTreeView(0, 0)
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{{UID: "d1", Group: "apps", Kind: "Deployment", Name: "web"}}}
+	m.treeView.UpsertAppTree("test-app", &tree)
+	m.treeView.SetSelectedIndex(1)
+
+	_, cmd := m.handleOpenPodLogs()
+	if st, ok := cmd().(model.StatusChangeMsg); !ok || !strings.Contains(st.Status, "Pods, Jobs and CronJobs") {
+		t.Errorf("expected a hint about which kinds have logs, got %#v", cmd())
+	}
+}
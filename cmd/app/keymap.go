@@ -0,0 +1,489 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// keyScope is where a set of key bindings is active: a view in normal mode
+// or a modal mode that takes all keys
+type keyScope string
+
+const (
+	scopeAnywhere       keyScope = "anywhere"
+	scopeNavigation     keyScope = "navigation"
+	scopeGeneral        keyScope = "general"
+	scopeApps           keyScope = "apps"
+	scopeTree           keyScope = "tree"
+	scopeDiff           keyScope = "diff"
+	scopeDiffOutline    keyScope = "diff-outline"
+	scopeDiffSummary    keyScope = "diff-summary"
+	scopeSync           keyScope = "sync"
+	scopePruneExclude   keyScope = "prune-exclude"
+	scopeRollback       keyScope = "rollback"
+	scopeDetails        keyScope = "details"
+	scopeHooks          keyScope = "hooks"
+	scopeErrors         keyScope = "errors"
+	scopeStream         keyScope = "stream"
+	scopeProfile        keyScope = "profile"
+	scopeHistory        keyScope = "history"
+	scopeWait           keyScope = "wait"
+	scopeBulkRefresh    keyScope = "bulk-refresh"
+	scopeStaging        keyScope = "staging"
+	scopeTermCheck      keyScope = "termcheck"
+	scopeManifest       keyScope = "manifest"
+	scopeMap            keyScope = "map"
+	scopeConflict       keyScope = "conflict"
+	scopeDeviceLogin    keyScope = "device-login"
+	scopeQuit           keyScope = "quit"
+	scopeAppDelete      keyScope = "app-delete"
+	scopeResourceSync   keyScope = "resource-sync"
+	scopeResourceDelete keyScope = "resource-delete"
+	scopeResourceAction keyScope = "resource-action"
+	scopeTheme          keyScope = "theme"
+)
+
+// keyScopeInfo describes a scope. Keys of the parent scopes are active in
+// the scope too, so the scope must not bind them again.
+type keyScopeInfo struct {
+	scope   keyScope
+	title   string
+	parents []keyScope
+}
+
+// keyScopes lists the scopes in the order the :keys view shows them
+var keyScopes = []keyScopeInfo{
+	{scope: scopeAnywhere, title: "ANYWHERE"},
+	{scope: scopeNavigation, title: "NAVIGATION", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeGeneral, title: "GENERAL", parents: []keyScope{scopeNavigation}},
+	{scope: scopeApps, title: "APPS VIEW", parents: []keyScope{scopeGeneral}},
+	{scope: scopeTree, title: "TREE VIEW", parents: []keyScope{scopeNavigation}},
+	{scope: scopeDiff, title: "DIFF", parents: []keyScope{scopeNavigation}},
+	{scope: scopeDiffOutline, title: "DIFF OUTLINE", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeDiffSummary, title: "DIFF SUMMARY", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeSync, title: "SYNC", parents: []keyScope{scopeAnywhere}},
+	{scope: scopePruneExclude, title: "PRUNE EXCLUDE", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeRollback, title: "ROLLBACK", parents: []keyScope{scopeNavigation}},
+	{scope: scopeDetails, title: "DETAILS", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeHooks, title: "HOOKS", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeErrors, title: "ERRORS", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeStream, title: "STREAM", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeProfile, title: "RENDER PROFILE", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeHistory, title: "HISTORY", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeWait, title: "WAIT", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeBulkRefresh, title: "REFRESH ALL", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeStaging, title: "RELEASE TRAIN", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeTermCheck, title: "TERMINAL CHECK", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeManifest, title: "MANIFEST", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeMap, title: "APP MAP", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeConflict, title: "CONFLICT", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeDeviceLogin, title: "SSO SIGN-IN", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeQuit, title: "QUIT", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeAppDelete, title: "DELETE APP", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeResourceSync, title: "SYNC RES.", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeResourceDelete, title: "DELETE RES.", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeResourceAction, title: "ACTIONS", parents: []keyScope{scopeAnywhere}},
+	{scope: scopeTheme, title: "THEME", parents: []keyScope{scopeNavigation}},
+}
+
+// keyBinding is one action and the keys that trigger it in a scope
+type keyBinding struct {
+	scope keyScope
+	keys  []string // as reported by tea.KeyMsg.String()
+	help  string
+}
+
+// keyBindings is the registry of every key the input handlers respond to.
+// A key added to a handler belongs here too; TestKeyBindings_* check that
+// the two agree and that no binding shadows another by accident.
+var keyBindings = []keyBinding{
+	{scope: scopeAnywhere, keys: []string{"ctrl+c"}, help: "quit"},
+
+	{scope: scopeNavigation, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeNavigation, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeNavigation, keys: []string{"pgup"}, help: "page up"},
+	{scope: scopeNavigation, keys: []string{"pgdown"}, help: "page down"},
+	{scope: scopeNavigation, keys: []string{"g"}, help: "top"},
+	{scope: scopeNavigation, keys: []string{"G"}, help: "bottom"},
+
+	{scope: scopeGeneral, keys: []string{"/"}, help: "search"},
+	{scope: scopeGeneral, keys: []string{":"}, help: "command"},
+	{scope: scopeGeneral, keys: []string{"?"}, help: "help"},
+	{scope: scopeGeneral, keys: []string{"space"}, help: "select"},
+	{scope: scopeGeneral, keys: []string{"enter"}, help: "drill down"},
+	{scope: scopeGeneral, keys: []string{"esc"}, help: "clear/up"},
+	{scope: scopeGeneral, keys: []string{"M"}, help: "app map"},
+	{scope: scopeGeneral, keys: []string{"Z"}, help: "ZZ/ZQ quit"},
+	{scope: scopeGeneral, keys: []string{"Q"}, help: "ZQ quit"},
+	{scope: scopeGeneral, keys: []string{"ctrl+r"}, help: "reload"},
+
+	{scope: scopeApps, keys: []string{"s"}, help: "sync"},
+	{scope: scopeApps, keys: []string{"d"}, help: "diff"},
+	{scope: scopeApps, keys: []string{"r"}, help: "resources"},
+	{scope: scopeApps, keys: []string{"R"}, help: "rollback"},
+	{scope: scopeApps, keys: []string{"i"}, help: "details"},
+	{scope: scopeApps, keys: []string{"H"}, help: "sync hooks"},
+	{scope: scopeApps, keys: []string{"P"}, help: "scope to app's project"},
+	{scope: scopeApps, keys: []string{"C"}, help: "scope to app's cluster"},
+	{scope: scopeApps, keys: []string{"1"}, help: "all apps"},
+	{scope: scopeApps, keys: []string{"2"}, help: "OutOfSync only"},
+	{scope: scopeApps, keys: []string{"3"}, help: "Degraded only"},
+	{scope: scopeApps, keys: []string{"4"}, help: "Progressing only"},
+	{scope: scopeApps, keys: []string{"K"}, help: "open in k9s"},
+	{scope: scopeApps, keys: []string{"ctrl+d"}, help: "delete"},
+	{scope: scopeApps, keys: []string{"|"}, help: "details pane"},
+	{scope: scopeApps, keys: []string{"ctrl+left", "<"}, help: "narrower list"},
+	{scope: scopeApps, keys: []string{"ctrl+right", ">"}, help: "wider list"},
+
+	{scope: scopeTree, keys: []string{"/"}, help: "filter"},
+	{scope: scopeTree, keys: []string{"n"}, help: "next match"},
+	{scope: scopeTree, keys: []string{"N"}, help: "prev match"},
+	{scope: scopeTree, keys: []string{"left", "h", "right", "l"}, help: "collapse/expand"},
+	{scope: scopeTree, keys: []string{"enter"}, help: "expand/open child app"},
+	{scope: scopeTree, keys: []string{"space"}, help: "select"},
+	{scope: scopeTree, keys: []string{"d"}, help: "diff"},
+	{scope: scopeTree, keys: []string{"s"}, help: "sync"},
+	{scope: scopeTree, keys: []string{"a"}, help: "actions"},
+	{scope: scopeTree, keys: []string{"y"}, help: "copy web UI link"},
+	{scope: scopeTree, keys: []string{"w"}, help: "save manifest"},
+	{scope: scopeTree, keys: []string{"v"}, help: "view manifest"},
+	{scope: scopeTree, keys: []string{"K"}, help: "open in k9s"},
+	{scope: scopeTree, keys: []string{"L"}, help: "pod logs"},
+	{scope: scopeTree, keys: []string{"ctrl+d"}, help: "delete"},
+	{scope: scopeTree, keys: []string{"ctrl+r"}, help: "reload"},
+	{scope: scopeTree, keys: []string{"W"}, help: "resubscribe live updates"},
+	{scope: scopeTree, keys: []string{"f"}, help: "follow progressing resources"},
+	{scope: scopeTree, keys: []string{"esc"}, help: "back"},
+	{scope: scopeTree, keys: []string{"q"}, help: "apps"},
+	{scope: scopeTree, keys: []string{":"}, help: "command"},
+	{scope: scopeTree, keys: []string{"?"}, help: "help"},
+
+	{scope: scopeDiff, keys: []string{"/"}, help: "search"},
+	{scope: scopeDiff, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeDiffOutline, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeDiffOutline, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeDiffOutline, keys: []string{"pgup"}, help: "page up"},
+	{scope: scopeDiffOutline, keys: []string{"pgdown"}, help: "page down"},
+	{scope: scopeDiffOutline, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeDiffOutline, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeDiffOutline, keys: []string{"enter"}, help: "open"},
+	{scope: scopeDiffOutline, keys: []string{"o"}, help: "open source in browser"},
+	{scope: scopeDiffOutline, keys: []string{"ctrl+r"}, help: "reload"},
+	{scope: scopeDiffOutline, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeDiffSummary, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeDiffSummary, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeDiffSummary, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeDiffSummary, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeDiffSummary, keys: []string{"enter"}, help: "open app diff"},
+	{scope: scopeDiffSummary, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeSync, keys: []string{"y"}, help: "sync"},
+	{scope: scopeSync, keys: []string{"left", "h", "right", "l"}, help: "choose button"},
+	{scope: scopeSync, keys: []string{"up", "k", "down", "j"}, help: "choose source"},
+	{scope: scopeSync, keys: []string{"enter"}, help: "confirm"},
+	{scope: scopeSync, keys: []string{"p"}, help: "prune"},
+	{scope: scopeSync, keys: []string{"o"}, help: "prune propagation"},
+	{scope: scopeSync, keys: []string{"L"}, help: "prune last"},
+	{scope: scopeSync, keys: []string{"a"}, help: "server-side apply"},
+	{scope: scopeSync, keys: []string{"w"}, help: "watch"},
+	{scope: scopeSync, keys: []string{"x"}, help: "exclude from prune"},
+	{scope: scopeSync, keys: []string{"tab", "shift+tab"}, help: "move focus"},
+	{scope: scopeSync, keys: []string{"q", "esc"}, help: "cancel"},
+
+	{scope: scopePruneExclude, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopePruneExclude, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopePruneExclude, keys: []string{"space"}, help: "exclude/include"},
+	{scope: scopePruneExclude, keys: []string{"x", "enter", "esc"}, help: "done"},
+
+	{scope: scopeRollback, keys: []string{"enter"}, help: "choose/confirm"},
+	{scope: scopeRollback, keys: []string{"/"}, help: "filter history"},
+	{scope: scopeRollback, keys: []string{"left", "h", "right", "l"}, help: "choose button"},
+	{scope: scopeRollback, keys: []string{"p"}, help: "prune"},
+	{scope: scopeRollback, keys: []string{"w"}, help: "watch"},
+	{scope: scopeRollback, keys: []string{"ctrl+r"}, help: "reload"},
+	{scope: scopeRollback, keys: []string{"tab", "shift+tab"}, help: "move focus"},
+	{scope: scopeRollback, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeDetails, keys: []string{"up", "k"}, help: "previous subscription"},
+	{scope: scopeDetails, keys: []string{"down", "j"}, help: "next subscription"},
+	{scope: scopeDetails, keys: []string{"a"}, help: "add subscription"},
+	{scope: scopeDetails, keys: []string{"d"}, help: "remove subscription"},
+	{scope: scopeDetails, keys: []string{"y"}, help: "confirm removal"},
+	{scope: scopeDetails, keys: []string{"q", "esc", "i", "enter"}, help: "close"},
+
+	{scope: scopeHooks, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeHooks, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeHooks, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeHooks, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeHooks, keys: []string{"enter", "l"}, help: "logs"},
+	{scope: scopeHooks, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeErrors, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeErrors, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeErrors, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeErrors, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeErrors, keys: []string{"r"}, help: "retry"},
+	{scope: scopeErrors, keys: []string{"c"}, help: "clear"},
+	{scope: scopeErrors, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeStream, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeStream, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeStream, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeStream, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeStream, keys: []string{"c"}, help: "clear"},
+	{scope: scopeStream, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeProfile, keys: []string{"r"}, help: "record again"},
+	{scope: scopeProfile, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeHistory, keys: []string{"left", "h"}, help: "previous change"},
+	{scope: scopeHistory, keys: []string{"right", "l"}, help: "next change"},
+	{scope: scopeHistory, keys: []string{"["}, help: "minute back"},
+	{scope: scopeHistory, keys: []string{"]"}, help: "minute forward"},
+	{scope: scopeHistory, keys: []string{"g", "home"}, help: "oldest"},
+	{scope: scopeHistory, keys: []string{"G", "end"}, help: "now"},
+	{scope: scopeHistory, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeHistory, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeHistory, keys: []string{"a"}, help: "all apps"},
+	{scope: scopeHistory, keys: []string{"y"}, help: "copy apps"},
+	{scope: scopeHistory, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeWait, keys: []string{"q", "esc", "enter"}, help: "stop waiting / close"},
+
+	{scope: scopeBulkRefresh, keys: []string{"q", "esc", "enter"}, help: "stop / close"},
+
+	{scope: scopeStaging, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeStaging, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeStaging, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeStaging, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeStaging, keys: []string{"s"}, help: "sync"},
+	{scope: scopeStaging, keys: []string{"r"}, help: "refresh"},
+	{scope: scopeStaging, keys: []string{"b"}, help: "roll back to previous deployment"},
+	{scope: scopeStaging, keys: []string{"x"}, help: "skip"},
+	{scope: scopeStaging, keys: []string{"space"}, help: "next action"},
+	{scope: scopeStaging, keys: []string{"J", "K"}, help: "move step down/up"},
+	{scope: scopeStaging, keys: []string{"enter"}, help: "run / continue"},
+	{scope: scopeStaging, keys: []string{"q", "esc"}, help: "close / stop after step"},
+
+	{scope: scopeTermCheck, keys: []string{"r"}, help: "probe again"},
+	{scope: scopeTermCheck, keys: []string{"y"}, help: "copy report"},
+	{scope: scopeTermCheck, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeManifest, keys: []string{"up", "k"}, help: "up"},
+	{scope: scopeManifest, keys: []string{"down", "j"}, help: "down"},
+	{scope: scopeManifest, keys: []string{"pgup"}, help: "page up"},
+	{scope: scopeManifest, keys: []string{"pgdown"}, help: "page down"},
+	{scope: scopeManifest, keys: []string{"g", "home"}, help: "top"},
+	{scope: scopeManifest, keys: []string{"G", "end"}, help: "bottom"},
+	{scope: scopeManifest, keys: []string{"left", "h"}, help: "fold / parent"},
+	{scope: scopeManifest, keys: []string{"right", "l"}, help: "unfold"},
+	{scope: scopeManifest, keys: []string{"space", "enter"}, help: "toggle fold"},
+	{scope: scopeManifest, keys: []string{"z"}, help: "fold all"},
+	{scope: scopeManifest, keys: []string{"Z"}, help: "unfold all"},
+	{scope: scopeManifest, keys: []string{"y"}, help: "copy path"},
+	{scope: scopeManifest, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeMap, keys: []string{"left", "h"}, help: "previous app"},
+	{scope: scopeMap, keys: []string{"right", "l"}, help: "next app"},
+	{scope: scopeMap, keys: []string{"up", "k"}, help: "line up"},
+	{scope: scopeMap, keys: []string{"down", "j"}, help: "line down"},
+	{scope: scopeMap, keys: []string{"g", "home"}, help: "first app"},
+	{scope: scopeMap, keys: []string{"G", "end"}, help: "last app"},
+	{scope: scopeMap, keys: []string{"enter"}, help: "open in list"},
+	{scope: scopeMap, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeConflict, keys: []string{"v"}, help: "view operation"},
+	{scope: scopeConflict, keys: []string{"w"}, help: "wait for operation"},
+	{scope: scopeConflict, keys: []string{"t"}, help: "terminate operation"},
+	{scope: scopeConflict, keys: []string{"q", "esc"}, help: "close"},
+
+	{scope: scopeDeviceLogin, keys: []string{"y"}, help: "copy sign-in link"},
+	{scope: scopeDeviceLogin, keys: []string{"r"}, help: "new code"},
+	{scope: scopeDeviceLogin, keys: []string{"q", "esc"}, help: "cancel"},
+
+	{scope: scopeQuit, keys: []string{"q", "y"}, help: "quit anyway"},
+	{scope: scopeQuit, keys: []string{"d"}, help: "detach"},
+	{scope: scopeQuit, keys: []string{"n", "esc"}, help: "keep watching"},
+
+	{scope: scopeAppDelete, keys: []string{"y"}, help: "delete"},
+	{scope: scopeAppDelete, keys: []string{"c"}, help: "cascade"},
+	{scope: scopeAppDelete, keys: []string{"p"}, help: "propagation policy"},
+	{scope: scopeAppDelete, keys: []string{"backspace"}, help: "clear"},
+	{scope: scopeAppDelete, keys: []string{"tab", "shift+tab"}, help: "move focus"},
+	{scope: scopeAppDelete, keys: []string{"q", "esc"}, help: "cancel"},
+
+	{scope: scopeResourceSync, keys: []string{"y"}, help: "sync"},
+	{scope: scopeResourceSync, keys: []string{"left", "h", "right", "l"}, help: "choose button"},
+	{scope: scopeResourceSync, keys: []string{"enter"}, help: "confirm"},
+	{scope: scopeResourceSync, keys: []string{"p"}, help: "prune"},
+	{scope: scopeResourceSync, keys: []string{"f"}, help: "force"},
+	{scope: scopeResourceSync, keys: []string{"tab", "shift+tab"}, help: "move focus"},
+	{scope: scopeResourceSync, keys: []string{"q", "esc"}, help: "cancel"},
+
+	{scope: scopeResourceDelete, keys: []string{"y"}, help: "delete"},
+	{scope: scopeResourceDelete, keys: []string{"c"}, help: "cascade"},
+	{scope: scopeResourceDelete, keys: []string{"p"}, help: "propagation policy"},
+	{scope: scopeResourceDelete, keys: []string{"f"}, help: "force"},
+	{scope: scopeResourceDelete, keys: []string{"backspace"}, help: "clear"},
+	{scope: scopeResourceDelete, keys: []string{"tab", "shift+tab"}, help: "move focus"},
+	{scope: scopeResourceDelete, keys: []string{"q", "esc"}, help: "cancel"},
+
+	{scope: scopeResourceAction, keys: []string{"left", "up"}, help: "previous"},
+	{scope: scopeResourceAction, keys: []string{"right", "down"}, help: "next"},
+	{scope: scopeResourceAction, keys: []string{"enter"}, help: "run"},
+	{scope: scopeResourceAction, keys: []string{"backspace"}, help: "clear filter"},
+	{scope: scopeResourceAction, keys: []string{"esc"}, help: "clear filter/close"},
+
+	{scope: scopeTheme, keys: []string{"enter"}, help: "apply"},
+	{scope: scopeTheme, keys: []string{"q", "esc"}, help: "cancel"},
+}
+
+// keyScopeByName returns the description of a scope
+func keyScopeByName(scope keyScope) (keyScopeInfo, bool) {
+	for _, s := range keyScopes {
+		if s.scope == scope {
+			return s, true
+		}
+	}
+	return keyScopeInfo{}, false
+}
+
+// inheritedKeys returns the keys active in a scope through its parents,
+// mapped to the binding that provides them
+func inheritedKeys(scope keyScope) map[string]keyBinding {
+	keys := make(map[string]keyBinding)
+	info, _ := keyScopeByName(scope)
+	for _, parent := range info.parents {
+		for k, b := range inheritedKeys(parent) {
+			keys[k] = b
+		}
+		for _, b := range keyBindings {
+			if b.scope != parent {
+				continue
+			}
+			for _, k := range b.keys {
+				keys[k] = b
+			}
+		}
+	}
+	return keys
+}
+
+// keyBindingConflicts returns a description of every key bound twice in one
+// scope, and of every key that shadows a binding of a parent scope
+func keyBindingConflicts() []string {
+	var conflicts []string
+	for _, info := range keyScopes {
+		inherited := inheritedKeys(info.scope)
+		own := make(map[string]keyBinding)
+		for _, b := range keyBindings {
+			if b.scope != info.scope {
+				continue
+			}
+			for _, k := range b.keys {
+				if prev, dup := own[k]; dup {
+					conflicts = append(conflicts, fmt.Sprintf("%s: %q is bound to both %q and %q", info.scope, k, prev.help, b.help))
+					continue
+				}
+				own[k] = b
+				if parent, shadows := inherited[k]; shadows {
+					conflicts = append(conflicts, fmt.Sprintf("%s: %q (%s) shadows %s %q", info.scope, k, b.help, parent.scope, parent.help))
+				}
+			}
+		}
+	}
+	for _, b := range keyBindings {
+		if _, ok := keyScopeByName(b.scope); !ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s: unknown scope for %q", b.scope, b.help))
+		}
+	}
+	return conflicts
+}
+
+// logKeyBindingConflicts warns about conflicting bindings at startup
+func logKeyBindingConflicts() {
+	for _, c := range keyBindingConflicts() {
+		cblog.With("component", "keys").Warn("Key binding conflict", "conflict", c)
+	}
+}
+
+// displayKey is the form a key takes in the :keys view
+func displayKey(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	return k
+}
+
+// renderKeysHelp renders the `:keys` page generated from the registry
+func (m *Model) renderKeysHelp() string {
+	isWide := m.state.Terminal.Cols >= 60
+	mono := func(s string) string { return lipgloss.NewStyle().Foreground(cyanBright).Render(s) }
+	sep := " " + lipgloss.NewStyle().Foreground(dimColor).Render("•") + " "
+
+	// The help box and the title column take the rest of the width
+	available := layout.Card.InnerWidth(m.screenWidth())
+	if isWide {
+		available -= 13
+	}
+	available = max(20, available)
+
+	var sections []string
+	for _, info := range keyScopes {
+		var items []string
+		for _, b := range keyBindings {
+			if b.scope != info.scope {
+				continue
+			}
+			keys := make([]string, len(b.keys))
+			for i, k := range b.keys {
+				keys[i] = displayKey(k)
+			}
+			items = append(items, mono(strings.Join(keys, "/"))+" "+b.help)
+		}
+		if len(items) == 0 {
+			continue
+		}
+		sections = append(sections, m.renderHelpSection(info.title, wrapJoined(items, sep, available), isWide))
+	}
+	sections = append(sections, "", statusStyle.Render("Press ?, q or Esc to close"))
+
+	body := "\n" + strings.Join(sections, "\n") + "\n"
+	return m.renderFullScreenViewWithOptions("", body, m.renderStatusLine(), FullScreenViewOptions{ContentBordered: true, BorderColor: magentaBright})
+}
+
+// wrapJoined joins items with sep, starting a new line before an item that
+// would not fit in width
+func wrapJoined(items []string, sep string, width int) string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		if line == "" {
+			line = item
+			continue
+		}
+		if lipgloss.Width(line+sep+item) > width {
+			lines = append(lines, line)
+			line = item
+			continue
+		}
+		line += sep + item
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
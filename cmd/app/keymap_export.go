@@ -0,0 +1,105 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// defaultKeysExportFile is where :keys export writes without a file name
+const defaultKeysExportFile = "argonaut-keys.md"
+
+// keysExportedMsg reports the outcome of :keys export
+type keysExportedMsg struct {
+	path string
+	err  error
+}
+
+// keymapMarkdown renders the key binding registry as a markdown cheat
+// sheet: one table per scope in the order the :keys view shows them.
+// Tables keep it printable and let wikis render it as is.
+func keymapMarkdown() string {
+	var b strings.Builder
+	b.WriteString("# argonaut key bindings\n\n")
+	b.WriteString("Generated by argonaut " + appVersion + " with `:keys export`.\n")
+
+	for _, info := range keyScopes {
+		var rows []string
+		for _, kb := range keyBindings {
+			if kb.scope != info.scope {
+				continue
+			}
+			keys := make([]string, len(kb.keys))
+			for i, k := range kb.keys {
+				keys[i] = "`" + strings.ReplaceAll(displayKey(k), "|", `\|`) + "`"
+			}
+			rows = append(rows, "| "+strings.Join(keys, " ")+" | "+kb.help+" |")
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		b.WriteString("\n## " + sentenceCase(info.title) + "\n\n")
+		if len(info.parents) > 0 {
+			parents := make([]string, len(info.parents))
+			for i, p := range info.parents {
+				parent, _ := keyScopeByName(p)
+				parents[i] = sentenceCase(parent.title)
+			}
+			b.WriteString("Keys from " + strings.Join(parents, ", ") + " work here too.\n\n")
+		}
+		b.WriteString("| Keys | Action |\n|------|--------|\n")
+		b.WriteString(strings.Join(rows, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// sentenceCase turns a :keys section title such as "APPS VIEW" into
+// "Apps view" for the cheat sheet headings
+func sentenceCase(title string) string {
+	lower := strings.ToLower(title)
+	if lower == "" {
+		return lower
+	}
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
+
+// handleKeysCommand runs ":keys [export [file]]": shows the key bindings,
+// or writes them to a markdown cheat sheet. Existing files are kept.
+func (m *Model) handleKeysCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.state.Modals.HelpTopic = "keys"
+		m.state.Mode = model.ModeHelp
+		return m, nil
+	}
+	if !strings.EqualFold(args[0], "export") {
+		status := "Unknown :keys argument " + args[0] + ". Try :keys export [file]"
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+	}
+
+	path := strings.Join(args[1:], " ")
+	if path == "" {
+		path = defaultKeysExportFile
+	}
+	path = expandHomePath(path)
+	sheet := []byte(keymapMarkdown())
+	return m, func() tea.Msg {
+		return keysExportedMsg{path: path, err: writeNewFile(path, sheet)}
+	}
+}
+
+// handleKeysExported reports where the cheat sheet went, or why it did not
+func (m *Model) handleKeysExported(msg keysExportedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "keys").Error("Failed to export key bindings", "path", msg.path, "err", msg.err)
+		text := "Could not export key bindings: " + msg.err.Error()
+		m.statusService.Error(text)
+		m.recordError("keys", text, msg.err.Error(), nil)
+		return nil
+	}
+	m.statusService.Set("Exported key bindings to " + msg.path)
+	return m.showStatusNote("Exported " + filepath.Base(msg.path))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestKeymapMarkdown_ListsEveryBinding(t *testing.T) {
+	sheet := keymapMarkdown()
+	for _, info := range keyScopes {
+		if !strings.Contains(sheet, "\n## "+sentenceCase(info.title)+"\n") {
+			t.Errorf("missing section %q", info.title)
+		}
+	}
+	for _, b := range keyBindings {
+		if !strings.Contains(sheet, " | "+b.help+" |") {
+			t.Errorf("missing binding %q (%s)", b.help, b.scope)
+		}
+	}
+	if !strings.Contains(sheet, "Keys from Navigation work here too.") {
+		t.Error("scopes should name the parents whose keys they inherit")
+	}
+	if !strings.Contains(sheet, "| `\\|` | details pane |") {
+		t.Error("a | key must be escaped so the table keeps its columns")
+	}
+}
+
+func TestKeysExport_WritesCheatSheet(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	path := filepath.Join(t.TempDir(), "keys.md")
+
+	_, cmd := m.handleKeysCommand([]string{"export", path})
+	if cmd == nil {
+		t.Fatal("export should return a command")
+	}
+	m.Update(cmd())
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cheat sheet not written: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# argonaut key bindings") {
+		t.Errorf("unexpected sheet:\n%s", data)
+	}
+	if m.state.UI.StatusNote != "Exported keys.md" {
+		t.Errorf("status note = %q", m.state.UI.StatusNote)
+	}
+
+	// Existing files are kept
+	_, cmd = m.handleKeysCommand([]string{"export", path})
+	m.Update(cmd())
+	if len(m.state.RecentErrors) != 1 || !strings.Contains(m.state.RecentErrors[0].Message, "already exists") {
+		t.Errorf("exporting over a file should fail: %+v", m.state.RecentErrors)
+	}
+}
+
+func TestKeysCommand_WithoutArgsShowsKeys(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleKeysCommand(nil)
+	if m.state.Mode != model.ModeHelp || m.state.Modals.HelpTopic != "keys" {
+		t.Errorf("mode = %s, topic = %q", m.state.Mode, m.state.Modals.HelpTopic)
+	}
+
+	_, cmd := m.handleKeysCommand([]string{"print"})
+	if msg, ok := cmd().(model.StatusChangeMsg); !ok || !strings.Contains(msg.Status, ":keys export") {
+		t.Errorf("an unknown argument should point at export, got %+v", msg)
+	}
+}
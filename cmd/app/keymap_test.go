@@ -0,0 +1,409 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/yamlview"
+)
+
+// keyPress builds the key message a registry key stands for
+func keyPress(k string) tea.KeyMsg {
+	switch k {
+	case "space":
+		return tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	case "enter":
+		return tea.KeyPressMsg{Code: tea.KeyEnter}
+	case "esc":
+		return tea.KeyPressMsg{Code: tea.KeyEscape}
+	case "backspace":
+		return tea.KeyPressMsg{Code: tea.KeyBackspace}
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}
+	case "up":
+		return tea.KeyPressMsg{Code: tea.KeyUp}
+	case "down":
+		return tea.KeyPressMsg{Code: tea.KeyDown}
+	case "left":
+		return tea.KeyPressMsg{Code: tea.KeyLeft}
+	case "right":
+		return tea.KeyPressMsg{Code: tea.KeyRight}
+	case "pgup":
+		return tea.KeyPressMsg{Code: tea.KeyPgUp}
+	case "pgdown":
+		return tea.KeyPressMsg{Code: tea.KeyPgDown}
+	case "home":
+		return tea.KeyPressMsg{Code: tea.KeyHome}
+	case "end":
+		return tea.KeyPressMsg{Code: tea.KeyEnd}
+	}
+	if ctrl, ok := strings.CutPrefix(k, "ctrl+"); ok {
+		if len(ctrl) > 1 {
+			named := keyPress(ctrl).(tea.KeyPressMsg)
+			return tea.KeyPressMsg{Code: named.Code, Mod: tea.ModCtrl}
+		}
+		return tea.KeyPressMsg{Code: rune(ctrl[0]), Mod: tea.ModCtrl}
+	}
+	return tea.KeyPressMsg{Code: rune(k[0]), Text: k}
+}
+
+// keyHarness builds a model in a scope so each of its keys can be pressed
+// on a fresh copy. prime lists keys that must be pressed first for a key to
+// do anything, such as Z before Q.
+type keyHarness struct {
+	setup func(t *testing.T) *Model
+	prime map[string]string
+}
+
+var keyHarnesses = map[keyScope]keyHarness{
+	scopeGeneral: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.UI.SearchQuery = "app"                                // gives esc something to clear
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"} // and ctrl+r a server to reload from
+			return m
+		},
+		prime: map[string]string{"Q": "Z"},
+	},
+	scopeApps: {
+		setup: func(t *testing.T) *Model { return buildDeleteTestModel(120, 30) },
+		prime: map[string]string{"1": "2"},
+	},
+	scopeSync: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Apps[0].MultiSource = true
+			m.state.Apps[0].Sources = []model.AppSource{{RepoURL: "a"}, {RepoURL: "b"}}
+			m.handleSyncModal()
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "left": "right", "h": "right"},
+	},
+	scopePruneExclude: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.handleSyncModal()
+			m.state.Modals.ConfirmSyncPrune = true
+			m.state.Modals.ConfirmSyncPrunePreview = &model.PrunePreview{
+				Target:    "test-app",
+				Resources: []model.PruneCandidate{{Kind: "ConfigMap", Name: "old"}, {Kind: "Secret", Name: "stale"}},
+				Picking:   true,
+			}
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down"},
+	},
+	scopeAppDelete: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.handleAppDelete()
+			m.state.Modals.DeleteConfirmationKey = "x"
+			return m
+		},
+	},
+	scopeDetails: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.handleOpenAppDetails()
+			m.state.Modals.AppDetails.Subscriptions = []model.NotificationSubscription{
+				{Trigger: "on-sync-failed", Service: "slack", Recipient: "alerts"},
+				{Service: "email", Recipient: "team@example.com"},
+			}
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "y": "d"},
+	},
+	scopeHooks: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.openHooks("test-app", m.state.Apps[0].AppNamespace)
+			m.handleHooksLoaded(model.HooksLoadedMsg{
+				AppName:      "test-app",
+				AppNamespace: m.state.Apps[0].AppNamespace,
+				Hooks:        []model.HookRun{{Group: "batch", Kind: "Job", Name: "migrate"}, {Group: "batch", Kind: "Job", Name: "smoke"}},
+			})
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "g": "G", "home": "G"},
+	},
+	scopeErrors: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.recordError("sync", "first", "", nil)
+			m.recordError("refresh", "second", "", &model.RetryOperationMsg{Operation: retryRefresh})
+			m.handleOpenErrors()
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "g": "G", "home": "G"},
+	},
+	scopeStream: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			captureWatchEvents(t,
+				`{"result":{"type":"MODIFIED","application":{"metadata":{"name":"test-app"}}}}`,
+				`{"result":{"type":"MODIFIED","application":{"metadata":{"name":"zzz-other-app"}}}}`,
+			)
+			m.handleOpenStream()
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "g": "G", "home": "G", "c": "down"},
+	},
+	scopeProfile: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.handleProfileCommand("render")
+			m.View()
+			m.handleProfileCommand("render")
+			return m
+		},
+	},
+	scopeHistory: {
+		setup: func(t *testing.T) *Model {
+			m := buildHistoryTestModel()
+			m.Update(keyPress("a"))
+			return m
+		},
+		prime: map[string]string{"right": "left", "l": "left", "]": "[", "G": "g", "end": "g", "up": "down", "k": "down"},
+	},
+	scopeManifest: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			doc, err := manifestNode([]byte(`{"metadata":{"name":"web","labels":{"a":"b"}},"spec":{"replicas":1},"data":{"x":"1","y":"2"}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.state.Modals.Manifest = &model.ManifestState{Title: "ConfigMap/web"}
+			m.state.Mode = model.ModeManifest
+			m.manifestView = yamlview.New(doc)
+			m.manifestView.SetSize(80, 3)
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "pgup": "pgdown", "g": "G", "home": "G",
+			"right": "h", "l": "h", "Z": "z"},
+	},
+	scopeMap: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.handleOpenMap()
+			return m
+		},
+		// The cursor starts on the last cell: test-app's namespace sorts last
+		prime: map[string]string{"right": "g", "l": "g", "down": "g", "j": "g", "G": "g", "end": "g"},
+	},
+	scopeWait: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.startWait("test-app", nil, []string{model.WaitHealthy}, time.Minute)
+			return m
+		},
+	},
+	scopeBulkRefresh: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.startBulkRefresh("project", "test-project", m.state.Apps[:1], false)
+			return m
+		},
+	},
+	scopeStaging: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.state.Selections.SelectedApps = map[string]bool{"test-app": true, "zzz-other-app": true}
+			m.handleStageCommand()
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "g": "G", "home": "G", "K": "j", "s": "x"},
+	},
+	scopeTermCheck: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.startTermCheck()
+			m.handleTermCheckReply(termCheckTimeoutMsg{id: m.termCheckSeq})
+			return m
+		},
+	},
+	scopeConflict: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.handleOperationConflict(model.OperationConflictMsg{AppName: "test-app", Operation: "sync"})
+			return m
+		},
+	},
+	scopeDeviceLogin: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.startDeviceLogin()
+			m.handleDeviceLoginStarted(deviceLoginStartedMsg{seq: m.deviceLoginSeq, code: &api.DeviceCode{
+				DeviceCode: "dev-1", UserCode: "ABCD-EFGH", VerificationURI: "https://argo.example.com/api/dex/device",
+			}})
+			return m
+		},
+	},
+	scopeQuit: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.trackWatchedOperation("sync", "test-app", m.state.Apps[0].AppNamespace)
+			m.confirmWatchedOperations("sync")
+			m.handleQuit(model.QuitMsg{})
+			return m
+		},
+	},
+	scopeDiffOutline: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.state.Mode = model.ModeDiffOutline
+			m.state.Modals.DiffOutline = &model.DiffOutlineState{
+				AppName:     "test-app",
+				Sections:    []model.DiffSection{{Kind: "Deployment", Name: "a"}, {Kind: "Service", Name: "b"}},
+				SelectedIdx: 1,
+			}
+			return m
+		},
+	},
+	scopeDiffSummary: {
+		setup: func(t *testing.T) *Model {
+			m := buildDeleteTestModel(120, 30)
+			m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+			m.state.Mode = model.ModeDiffSummary
+			m.state.Modals.DiffSummary = &model.DiffSummaryState{
+				Apps: []model.DiffSummaryApp{
+					{Name: "test-app", Loaded: true, Changed: 1},
+					{Name: "zzz-other-app", Loaded: true},
+				},
+				Started: 2,
+			}
+			return m
+		},
+		prime: map[string]string{"up": "down", "k": "down", "g": "G", "home": "G"},
+	},
+	scopeResourceAction: {
+		setup: buildResourceActionTestModel,
+		prime: map[string]string{"left": "right", "up": "right", "backspace": "p"},
+	},
+}
+
+// stateFingerprint captures everything a key press can change besides
+// returning a command
+func stateFingerprint(t *testing.T, m *Model) string {
+	t.Helper()
+	b, err := json.Marshal(m.state)
+	if err != nil {
+		t.Fatalf("marshal state: %v", err)
+	}
+	if v := m.manifestView; v != nil {
+		// The manifest viewer keeps its cursor and folds outside the state
+		return string(b) + v.Path() + v.Render()
+	}
+	return string(b)
+}
+
+func TestKeyBindings_NoConflicts(t *testing.T) {
+	for _, c := range keyBindingConflicts() {
+		t.Error(c)
+	}
+}
+
+func TestKeyBindingConflicts_DetectsDuplicatesAndShadowing(t *testing.T) {
+	saved := keyBindings
+	defer func() { keyBindings = saved }()
+	keyBindings = append(append([]keyBinding{}, saved...),
+		keyBinding{scope: scopeApps, keys: []string{"g"}, help: "graph"},
+		keyBinding{scope: scopeApps, keys: []string{"s"}, help: "suspend"},
+		keyBinding{scope: scopeSync, keys: []string{"ctrl+c"}, help: "cancel"},
+	)
+
+	got := strings.Join(keyBindingConflicts(), "\n")
+	for _, want := range []string{
+		`apps: "g" (graph) shadows navigation "top"`,
+		`apps: "s" is bound to both "sync" and "suspend"`,
+		`sync: "ctrl+c" (cancel) shadows anywhere "quit"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("conflicts should include %q, got:\n%s", want, got)
+		}
+	}
+}
+
+// Every key the registry lists for a scope must do something there: change
+// the state or return a command. Catches keys that are documented but no
+// longer reach their handler.
+func TestKeyBindings_HandlersRespond(t *testing.T) {
+	for scope, h := range keyHarnesses {
+		for _, b := range keyBindings {
+			if b.scope != scope {
+				continue
+			}
+			for _, k := range b.keys {
+				if got := keyPress(k).String(); got != k {
+					t.Fatalf("keyPress(%q) reports %q", k, got)
+				}
+				m := h.setup(t)
+				if p, ok := h.prime[k]; ok {
+					m.handleKeyMsg(keyPress(p))
+				}
+				before := stateFingerprint(t, m)
+				next, cmd := m.handleKeyMsg(keyPress(k))
+				if cmd == nil && stateFingerprint(t, next.(*Model)) == before {
+					t.Errorf("%s: %q (%s) did nothing", scope, k, b.help)
+				}
+			}
+		}
+	}
+}
+
+// The hint bar only advertises registered keys
+func TestKeyBindings_HintsAreRegistered(t *testing.T) {
+	for view, scope := range map[model.View]keyScope{model.ViewApps: scopeApps, model.ViewTree: scopeTree} {
+		active := inheritedKeys(scope)
+		for _, b := range keyBindings {
+			if b.scope == scope {
+				for _, k := range b.keys {
+					active[k] = b
+				}
+			}
+		}
+		m := buildDeleteTestModel(120, 30)
+		m.state.Navigation.View = view
+		for _, hint := range m.currentKeyHints() {
+			keys := []string{hint.key}
+			if hint.key != "/" {
+				keys = strings.Split(hint.key, "/")
+			}
+			for _, k := range keys {
+				if _, ok := active[k]; !ok {
+					t.Errorf("%s hint %q is not a registered key", scope, k)
+				}
+			}
+		}
+	}
+}
+
+func TestKeysCommand_ListsRegistry(t *testing.T) {
+	m := buildBaseModel(120, 60)
+	m.state.Mode = model.ModeCommand
+	m.inputComponents.SetCommandValue("keys")
+	m.state.UI.Command = "keys"
+	m.handleEnhancedCommandModeKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if m.state.Mode != model.ModeHelp || m.state.Modals.HelpTopic != "keys" {
+		t.Fatalf("expected keys help, mode=%s topic=%q", m.state.Mode, m.state.Modals.HelpTopic)
+	}
+	out := stripANSI(m.renderHelpModal())
+	for _, want := range []string{"APPS VIEW", "TREE VIEW", "SYNC", "server-side apply", "ctrl+d delete"} {
+		if !strings.Contains(out, want) {
+			t.Errorf(":keys should list %q", want)
+		}
+	}
+}
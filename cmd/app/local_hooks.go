@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/localhook"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// hookSeen is what the last app update showed of an app, to tell which
+// events the next update brings
+type hookSeen struct {
+	health    string
+	phase     string
+	startedAt *time.Time
+}
+
+// localHookRun is a configured hook and the event it runs on. The hook's
+// command keeps its secret references, which are only expanded to run it,
+// so logs and errors never show the values.
+type localHookRun struct {
+	hook   config.LocalHook
+	event  localhook.Event
+	config *config.ArgonautConfig
+}
+
+func (r localHookRun) run() (string, error) {
+	command := r.config.ExpandSecretsForShell(r.hook.Command)
+	return localhook.Run(context.Background(), command, r.hook.GetTimeout(), r.event)
+}
+
+// localHookDoneMsg reports a hook that ran on an app event
+type localHookDoneMsg struct {
+	run    localHookRun
+	output string
+	err    error
+}
+
+// checkLocalHooks runs the hooks of the events the latest app update
+// brought: finished operations and apps turning Degraded. An app's first
+// update only sets what later ones are compared with, and cached apps shown
+// before the first live update are not compared at all. Returns nil when
+// nothing is to run.
+func (m *Model) checkLocalHooks() tea.Cmd {
+	if m.config == nil || len(m.config.Hooks) == 0 || m.appsFromCache {
+		return nil
+	}
+	if m.hookSeen == nil {
+		m.hookSeen = make(map[string]hookSeen)
+	}
+	var cmds []tea.Cmd
+	for _, app := range m.state.Apps {
+		key := appKey(app.Name, app.AppNamespace)
+		prev, known := m.hookSeen[key]
+		now := hookSeen{health: app.Health, phase: app.OperationPhase, startedAt: app.OperationStartedAt}
+		m.hookSeen[key] = now
+		if !known {
+			continue
+		}
+		if now.startedAt != nil && isFinishedOperation(now.phase) &&
+			(prev.startedAt == nil || !prev.startedAt.Equal(*now.startedAt) || !isFinishedOperation(prev.phase)) {
+			cmds = append(cmds, m.runLocalHooks(config.HookSyncCompleted, app)...)
+		}
+		if now.health == "Degraded" && prev.health != "Degraded" {
+			cmds = append(cmds, m.runLocalHooks(config.HookAppDegraded, app)...)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+// localHookRuns returns the hooks configured for the event on the app
+func (m *Model) localHookRuns(event string, app model.App) []localHookRun {
+	hooks := m.config.HooksFor(event, app.Name, derefOr(app.Project))
+	if len(hooks) == 0 {
+		return nil
+	}
+	ev := localhook.Event{
+		Name:         event,
+		App:          app.Name,
+		AppNamespace: derefOr(app.AppNamespace),
+		Project:      derefOr(app.Project),
+		Cluster:      derefOr(app.ClusterLabel),
+		Namespace:    derefOr(app.Namespace),
+		Context:      m.currentContextName,
+		Sync:         app.Sync,
+		Health:       app.Health,
+	}
+	if m.state.Server != nil {
+		ev.Server = m.state.Server.BaseURL
+	}
+	if event == config.HookSyncCompleted {
+		ev.Result, ev.Message = app.OperationPhase, app.OperationMessage
+	}
+	for _, src := range app.Sources {
+		if src.Revision != "" {
+			ev.Revision = src.Revision
+			break
+		}
+	}
+	runs := make([]localHookRun, 0, len(hooks))
+	for _, h := range hooks {
+		runs = append(runs, localHookRun{hook: h, event: ev, config: m.config})
+	}
+	return runs
+}
+
+// runLocalHooks starts the hooks of an event in the background
+func (m *Model) runLocalHooks(event string, app model.App) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, r := range m.localHookRuns(event, app) {
+		cmds = append(cmds, func() tea.Msg {
+			cblog.With("component", "hooks").Info("Running local hook", "event", r.event.Name, "app", r.event.App, "command", r.hook.Command)
+			out, err := r.run()
+			return localHookDoneMsg{run: r, output: out, err: err}
+		})
+	}
+	return cmds
+}
+
+// beforeDeleteHooks returns the before-delete hooks of an app, to run
+// ahead of its delete request
+func (m *Model) beforeDeleteHooks(name string, appNamespace *string) []localHookRun {
+	app := model.App{Name: name, AppNamespace: appNamespace}
+	if found := m.findAppByNameAndNamespace(name, derefOr(appNamespace)); found != nil {
+		app = *found
+	}
+	return m.localHookRuns(config.HookBeforeDelete, app)
+}
+
+// runBeforeDeleteHooks runs an app's before-delete hooks in order and stops
+// at the first that fails, which cancels the delete
+func runBeforeDeleteHooks(runs []localHookRun) error {
+	for _, r := range runs {
+		cblog.With("component", "hooks").Info("Running local hook", "event", r.event.Name, "app", r.event.App, "command", r.hook.Command)
+		if out, err := r.run(); err != nil {
+			if out != "" {
+				err = fmt.Errorf("%w: %s", err, out)
+			}
+			return fmt.Errorf("before-delete hook %q failed, not deleting: %w", r.hook.Command, err)
+		}
+	}
+	return nil
+}
+
+// handleLocalHookDone reports a hook that failed in :errors
+func (m *Model) handleLocalHookDone(msg localHookDoneMsg) tea.Cmd {
+	ev := msg.run.event
+	if msg.err == nil {
+		cblog.With("component", "hooks").Info("Local hook finished", "event", ev.Name, "app", ev.App)
+		return nil
+	}
+	cblog.With("component", "hooks").Warn("Local hook failed", "event", ev.Name, "app", ev.App, "err", msg.err)
+	details := "command: " + msg.run.hook.Command
+	if msg.output != "" {
+		details += "\n\n" + msg.output
+	}
+	status := fmt.Sprintf("%s hook for %s failed: %v", ev.Name, ev.App, msg.err)
+	m.recordError("hooks", status, details, nil)
+	return func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+}
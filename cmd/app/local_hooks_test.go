@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// runHookCmds runs the hooks a check started and returns their reports
+func runHookCmds(t *testing.T, cmd tea.Cmd) []localHookDoneMsg {
+	t.Helper()
+	if cmd == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		cmds = msg
+	case localHookDoneMsg:
+		return []localHookDoneMsg{msg}
+	default:
+		t.Fatalf("unexpected msg %T", msg)
+	}
+	var done []localHookDoneMsg
+	for _, c := range cmds {
+		done = append(done, c().(localHookDoneMsg))
+	}
+	return done
+}
+
+func TestLocalHooks_FireOnSyncCompletedAndDegraded(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.currentContextName = "prod"
+	m.config = &config.ArgonautConfig{Hooks: []config.LocalHook{
+		{Event: config.HookSyncCompleted, App: "test-*", Command: `echo "$ARGONAUT_EVENT $ARGONAUT_APP $ARGONAUT_RESULT $ARGONAUT_CONTEXT"`},
+		{Event: config.HookAppDegraded, Command: `echo "$ARGONAUT_EVENT $ARGONAUT_APP $ARGONAUT_HEALTH"`},
+	}}
+
+	if cmd := m.checkLocalHooks(); cmd != nil {
+		t.Fatal("the first update should only record the apps")
+	}
+
+	started := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	m.state.Apps[0].OperationPhase = "Running"
+	m.state.Apps[0].OperationStartedAt = &started
+	if cmd := m.checkLocalHooks(); cmd != nil {
+		t.Fatal("a running operation should not fire sync-completed")
+	}
+
+	m.state.Apps[0].OperationPhase = "Failed"
+	m.state.Apps[0].Health = "Degraded"
+	done := runHookCmds(t, m.checkLocalHooks())
+	var outputs []string
+	for _, d := range done {
+		if d.err != nil {
+			t.Fatalf("hook failed: %v", d.err)
+		}
+		outputs = append(outputs, d.output)
+	}
+	want := []string{"sync-completed test-app Failed prod", "app-degraded test-app Degraded"}
+	if strings.Join(outputs, "|") != strings.Join(want, "|") {
+		t.Errorf("expected %q, got %q", want, outputs)
+	}
+
+	if cmd := m.checkLocalHooks(); cmd != nil {
+		t.Error("an unchanged app should not fire again")
+	}
+
+	failed := done[1]
+	failed.output, failed.err = "pager unreachable", errors.New("exit status 1")
+	if cmd := m.handleLocalHookDone(failed); cmd == nil {
+		t.Error("a failing hook should show in the status line")
+	}
+	if n := len(m.state.RecentErrors); n != 1 || !strings.Contains(m.state.RecentErrors[0].Details, "pager unreachable") {
+		t.Errorf("expected the failure in :errors with its output, got %+v", m.state.RecentErrors)
+	}
+}
+
+func TestLocalHooks_FailingBeforeDeleteCancelsTheDelete(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+	m.config = &config.ArgonautConfig{Hooks: []config.LocalHook{
+		{Event: config.HookBeforeDelete, Project: "test-project", Command: `echo "no backup of $ARGONAUT_APP"; exit 1`},
+	}}
+
+	if hooks := m.beforeDeleteHooks("zzz-other-app", nil); len(hooks) != 0 {
+		t.Fatalf("the hook is for another project, got %+v", hooks)
+	}
+	ns := "test-namespace"
+	msg := m.deleteSingleApplication(AppDeleteParams{AppName: "test-app", Namespace: &ns, Hooks: m.beforeDeleteHooks("test-app", &ns)})()
+	errMsg, ok := msg.(model.AppDeleteErrorMsg)
+	if !ok || !strings.Contains(errMsg.Error, "not deleting") || !strings.Contains(errMsg.Error, "no backup of test-app") {
+		t.Fatalf("expected the delete to be cancelled with the hook's output, got %+v", msg)
+	}
+	if calls.Load() != 0 {
+		t.Error("the delete request should not be sent")
+	}
+}
+
+func TestLocalHooks_SecretsOnlyExpandedToRun(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", configPath)
+	if err := os.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte("token: s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := `[secrets]
+file = "secrets.yaml"
+decrypt_command = "cat {file}"
+
+[[hooks]]
+event = "before-delete"
+command = "test -n ${secret:token} && exit 3"
+`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadArgonautConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := buildDeleteTestModel(120, 30)
+	m.config = cfg
+	err = runBeforeDeleteHooks(m.beforeDeleteHooks("test-app", nil))
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Fatalf("the hook should run with the secret, got %v", err)
+	}
+	if strings.Contains(err.Error(), "s3cr3t") || !strings.Contains(err.Error(), "${secret:token}") {
+		t.Errorf("the error should show the command's reference, not the secret: %v", err)
+	}
+}
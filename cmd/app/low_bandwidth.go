@@ -0,0 +1,26 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/darksworm/argonaut/pkg/config"
+)
+
+// lowBandwidthFPS caps redraws in low bandwidth mode. Bursts of watch
+// events land in one frame instead of one repaint each (the default is 60).
+const lowBandwidthFPS = 10
+
+// lowBandwidthOptions returns the program options of low bandwidth mode
+// (appearance.low_bandwidth or --low-bandwidth): fewer frames, and the 16
+// color palette, whose escape codes are a fraction of the size of true
+// color ones. The renderer only ever writes the cells that changed; reduced
+// motion, which the mode implies, keeps cells from changing without data.
+func lowBandwidthOptions(cfg *config.ArgonautConfig) []tea.ProgramOption {
+	if cfg == nil || !cfg.Appearance.LowBandwidth {
+		return nil
+	}
+	return []tea.ProgramOption{
+		tea.WithFPS(lowBandwidthFPS),
+		tea.WithColorProfile(colorprofile.ANSI),
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -15,12 +16,14 @@ import (
 	"charm.land/lipgloss/v2"
 	cblog "github.com/charmbracelet/log"
 	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/appcache"
 	"github.com/darksworm/argonaut/pkg/config"
 	appcontext "github.com/darksworm/argonaut/pkg/context"
 	"github.com/darksworm/argonaut/pkg/model"
 	"github.com/darksworm/argonaut/pkg/portforward"
 	"github.com/darksworm/argonaut/pkg/services"
 	"github.com/darksworm/argonaut/pkg/theme"
+	"github.com/darksworm/argonaut/pkg/timefmt"
 	"github.com/darksworm/argonaut/pkg/trust"
 )
 
@@ -69,6 +72,12 @@ func renderColorfulHelp(fs *flag.FlagSet) string {
 	help.WriteString("\n  ")
 	help.WriteString(lipgloss.NewStyle().Foreground(helpTextColor).Render("argonaut"))
 	help.WriteString(lipgloss.NewStyle().Foreground(helpDimColor).Render(" [options]"))
+	help.WriteString("\n  ")
+	help.WriteString(lipgloss.NewStyle().Foreground(helpTextColor).Render("argonaut config export|import"))
+	help.WriteString(lipgloss.NewStyle().Foreground(helpDimColor).Render(" [file]"))
+	help.WriteString("\n  ")
+	help.WriteString(lipgloss.NewStyle().Foreground(helpTextColor).Render("argonaut report"))
+	help.WriteString(lipgloss.NewStyle().Foreground(helpDimColor).Render(" [--project name] [--since 24h] [-o file]"))
 	help.WriteString("\n\n")
 
 	// Options section
@@ -147,6 +156,12 @@ func main() {
 		clientCertFlag string
 		clientKeyFlag  string
 		themeFlag      string
+		profileFlag    string
+		contextFlag    string
+		fixtureFlag    string
+		lowBandwidth   bool
+		noStream       bool
+		safeMode       bool
 		showVersion    bool
 		showHelp       bool
 	)
@@ -168,6 +183,18 @@ func main() {
 	fs.StringVar(&clientKeyFlag, "client-cert-key", "", "Path to client certificate private key file (PEM format)")
 	// Theme selection flag
 	fs.StringVar(&themeFlag, "theme", "", fmt.Sprintf("UI theme preset (%s)", strings.Join(theme.Names(), ", ")))
+	// Config profile flag
+	fs.StringVar(&profileFlag, "profile", "", "Config profile to use (profiles/<name>.toml next to config.toml)")
+	// Context selection, like the argocd CLI's --argocd-context
+	fs.StringVar(&contextFlag, "context", "", "Argo CD context to use instead of the CLI config's current context")
+	// Offline mode
+	fs.StringVar(&fixtureFlag, "fixture", "", "Serve applications from a JSON file instead of an Argo CD server")
+	// Slow SSH sessions
+	fs.BoolVar(&lowBandwidth, "low-bandwidth", false, "Keep redraws small for slow SSH sessions (fewer frames, 16 colors, no animations)")
+	// Networks that block the streaming endpoint
+	fs.BoolVar(&noStream, "no-stream", false, "Do not watch for changes; reload the app list every stream.refresh_interval instead")
+	// Ruling out the user's customizations
+	fs.BoolVar(&safeMode, "safe-mode", false, "Ignore the argonaut config, profiles and saved state and use the built-in defaults; nothing is saved")
 
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		if err == flag.ErrHelp {
@@ -190,6 +217,25 @@ func main() {
 		return
 	}
 
+	// Flags for the argocd CLI in ARGOCD_OPTS apply too, below argonaut's own
+	argocdOpts, err := config.ArgocdOptsFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(argocdOpts.Ignored) > 0 {
+		cblog.With("component", "app").Info("Ignoring argocd CLI flags argonaut has no use for", "flags", argocdOpts.Ignored)
+	}
+	if contextFlag != "" {
+		argocdOpts.Context = contextFlag
+	}
+	if caCertFlag == "" {
+		caCertFlag = argocdOpts.ServerCert
+	}
+	if clientCertFlag == "" && clientKeyFlag == "" {
+		clientCertFlag, clientKeyFlag = argocdOpts.ClientCert, argocdOpts.ClientCertKey
+	}
+
 	// Set up TLS trust configuration
 	setupTLSTrust(TLSConfig{
 		CACertFile:     caCertFlag,
@@ -198,20 +244,71 @@ func main() {
 		ClientKeyFile:  clientKeyFlag,
 	})
 
+	// Select the config profile before anything reads the config path
+	if profileFlag != "" {
+		if err := config.SetProfile(profileFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cblog.With("component", "app").Info("Using config profile", "profile", profileFlag)
+	}
+
+	if safeMode {
+		config.SetSafeMode(true)
+		cblog.With("component", "app").Info("Safe mode: ignoring the config file and saved state", "config", config.GetArgonautConfigPath())
+	}
+
+	// Subcommands run against the selected profile and exit
+	if fs.NArg() > 0 {
+		switch fs.Arg(0) {
+		case "config":
+			os.Exit(runConfigCommand(fs.Args()[1:], os.Stdin, os.Stdout, os.Stderr))
+		case "report":
+			loadServer := func() (*model.Server, error) { return reportServer(cfgPathFlag, argocdOpts) }
+			os.Exit(runReportCommand(fs.Args()[1:], loadServer, os.Stdout, os.Stderr))
+		}
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", fs.Arg(0))
+		os.Exit(2)
+	}
+
 	// Check if config file exists before loading (for "what's new" logic)
 	configExisted := config.ConfigFileExists()
 
 	// Load and apply theme
 	argonautConfig, err := config.LoadArgonautConfig()
 	if err != nil {
-		cblog.With("component", "app").Warn("Could not load config, using defaults", "err", err)
+		// Saves are refused for the rest of the run so the defaults never
+		// replace the file that failed to load
+		cblog.With("component", "app").Warn("Could not load config, using defaults; changes will not be saved", "err", err)
 		argonautConfig = config.GetDefaultConfig()
 	}
 
+	// Flags and ARGOCD_OPTS below only apply to this run: saves reload the
+	// config file instead of writing this one back
+
 	// Override theme from CLI flag if provided
 	if themeFlag != "" {
 		argonautConfig.Appearance.Theme = themeFlag
 	}
+	if lowBandwidth {
+		argonautConfig.Appearance.LowBandwidth = true
+	}
+	if noStream {
+		enabled := false
+		argonautConfig.Stream.Enabled = &enabled
+	}
+
+	// The config (usually a profile) may point at its own Argo CD CLI config;
+	// an explicit --argocd-config still wins
+	if cfgPathFlag == "" {
+		cfgPathFlag = argonautConfig.GetArgocdConfigPath()
+	}
+	if cfgPathFlag == "" {
+		cfgPathFlag = argocdOpts.Config
+	}
+	if argocdOpts.PortForwardNamespace != "" {
+		argonautConfig.PortForward.Namespace = argocdOpts.PortForwardNamespace
+	}
 
 	// Apply theme colors
 	palette := theme.FromConfig(argonautConfig)
@@ -221,38 +318,39 @@ func main() {
 	requestTimeout := argonautConfig.GetRequestTimeout()
 	appcontext.SetRequestTimeout(requestTimeout)
 	cblog.With("component", "app").Debug("Applied request timeout", "timeout", requestTimeout.String())
+	api.SetMaxResponseSize(argonautConfig.GetMaxResponseSize())
+	timeFormat, timeClock, timeZone := argonautConfig.GetTimeDisplay()
+	timefmt.Set(timefmt.Options{
+		Relative: timeFormat == config.TimeRelative,
+		Hour12:   timeClock == config.Clock12h,
+		UTC:      timeZone == config.ZoneUTC,
+		Now:      clockNow,
+	})
+	logKeyBindingConflicts()
 
 	// Create the initial model
 	m := NewModel(argonautConfig)
 
-	// Check if this is a new version (for "what's new" notification)
-	if appVersion != "dev" {
+	// Check if this is a new version (for "what's new" notification); safe
+	// mode has no last seen version to compare with
+	if appVersion != "dev" && !safeMode {
 		lastSeen := argonautConfig.LastSeenVersion
 		if !configExisted {
 			// Fresh install - no config file existed, save version, no notification
-			argonautConfig.LastSeenVersion = appVersion
-			if err := config.SaveArgonautConfig(argonautConfig); err != nil {
-				cblog.With("component", "app").Warn("Could not save last seen version", "err", err)
-			}
+			saveLastSeenVersion(appVersion)
 		} else if lastSeen == "" {
 			// Config exists but no last_seen_version - existing user upgrading to version with this feature
 			// Show notification!
 			m.state.UI.ShowWhatsNew = true
-			now := time.Now()
+			now := clockNow()
 			m.state.UI.WhatsNewShownAt = &now
-			argonautConfig.LastSeenVersion = appVersion
-			if err := config.SaveArgonautConfig(argonautConfig); err != nil {
-				cblog.With("component", "app").Warn("Could not save last seen version", "err", err)
-			}
+			saveLastSeenVersion(appVersion)
 		} else if lastSeen != appVersion {
 			// User upgraded to a new version - show notification
 			m.state.UI.ShowWhatsNew = true
-			now := time.Now()
+			now := clockNow()
 			m.state.UI.WhatsNewShownAt = &now
-			argonautConfig.LastSeenVersion = appVersion
-			if err := config.SaveArgonautConfig(argonautConfig); err != nil {
-				cblog.With("component", "app").Warn("Could not save last seen version", "err", err)
-			}
+			saveLastSeenVersion(appVersion)
 		}
 	}
 
@@ -273,18 +371,39 @@ func main() {
 		effectiveConfigPath = config.GetConfigPath()
 	}
 	m.argoConfigPath = effectiveConfigPath
+	if !safeMode {
+		// Safe mode writes nothing, app list snapshots included
+		m.appCacheDir = appcache.DefaultDir()
+		m.profileName = config.ActiveProfile()
+	}
 
 	// Read the CLI config to populate context names
 	if cliCfg, cfgErr := config.ReadCLIConfigFromPath(effectiveConfigPath); cfgErr == nil {
 		m.state.ContextNames = cliCfg.GetContextNames()
+		if contextFlag != "" {
+			if _, err := cliCfg.ResolveContext(contextFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v. Available contexts: %s\n", err, strings.Join(m.state.ContextNames, ", "))
+				os.Exit(1)
+			}
+		}
+		cliCfg.Apply(argocdOpts)
 		m.currentContextName = cliCfg.CurrentContext
 	}
 
 	// Port-forward manager (if used)
 	var pfManager *portforward.Manager
 
-	// Try to read the ArgoCD CLI config file
-	server, err := loadArgoConfig(cfgPathFlag)
+	// Try to read the ArgoCD CLI config file, unless running against a fixture
+	var server *model.Server
+	if fixtureFlag != "" {
+		server, err = startFixture(m, fixtureFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		server, err = loadArgoConfig(cfgPathFlag, argocdOpts)
+	}
 	if err != nil {
 		// Check if it's a port-forward mode error
 		if pfErr, isPortForward := err.(*PortForwardModeError); isPortForward {
@@ -349,6 +468,9 @@ func main() {
 			m.state.Server = nil
 		}
 	} else {
+		if fixtureFlag == "" {
+			argonautConfig.ApplyServerSettings(server)
+		}
 		cblog.With("component", "app").Info("Loaded Argo CD config", "server", server.BaseURL)
 		m.state.Server = server
 		// Server is configured - the Init() method will handle showing loading screen
@@ -365,16 +487,36 @@ func main() {
 	// Create the Bubbletea program
 	p := tea.NewProgram(
 		m,
+		lowBandwidthOptions(argonautConfig)...,
 	)
 
 	// Store program pointer for terminal hand-off (pager integration)
 	m.SetProgram(p)
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	// The paths of saved oversized responses were only shown in the TUI
+	api.RemoveSavedResponses()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
+	if fm, ok := final.(*Model); ok && fm.detachNote != "" {
+		fmt.Print(fm.detachNote)
+	}
+}
+
+// saveLastSeenVersion records the version for the "what's new" notice. The
+// config is read again so the flags applied to this run are not saved.
+func saveLastSeenVersion(version string) {
+	cfg, err := config.LoadArgonautConfig()
+	if err == nil {
+		cfg.LastSeenVersion = version
+		err = config.SaveArgonautConfig(cfg)
+	}
+	if err != nil {
+		cblog.With("component", "app").Warn("Could not save last seen version", "err", err)
+	}
 }
 
 // setupLogging configures logging to write to a file instead of stdout
@@ -411,7 +553,7 @@ func setupLogging() {
 }
 
 // loadArgoConfig loads ArgoCD CLI configuration (matches TypeScript app-orchestrator.ts)
-func loadArgoConfig(overridePath string) (*model.Server, error) {
+func loadArgoConfig(overridePath string, opts config.ArgocdOpts) (*model.Server, error) {
 	// Read CLI config file (override path if specified)
 	var (
 		cfg *config.ArgoCLIConfig
@@ -423,9 +565,14 @@ func loadArgoConfig(overridePath string) (*model.Server, error) {
 		// Still respect ARGOCD_CONFIG environment variable via ReadCLIConfig()
 		cfg, err = config.ReadCLIConfig()
 	}
+	if err != nil && errors.Is(err, os.ErrNotExist) && (opts.Server != "" || opts.PortForward) {
+		// Like the CLI, a server given by flag or environment needs no config
+		cfg, err = &config.ArgoCLIConfig{}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CLI config: %w", err)
 	}
+	cfg.Apply(opts)
 
 	// Check if port-forward mode is configured
 	if isPortForward, pfErr := cfg.IsPortForwardMode(); pfErr == nil && isPortForward {
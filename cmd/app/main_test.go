@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLastSeenVersion_KeepsRunOverridesOut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", path)
+	if err := os.WriteFile(path, []byte("[appearance]\ntheme = \"nord\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	saveLastSeenVersion("v9.9.9")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	if !strings.Contains(saved, "v9.9.9") || !strings.Contains(saved, "nord") {
+		t.Errorf("the version should be saved with the file's settings:\n%s", saved)
+	}
+	for _, override := range []string{"low_bandwidth", "[stream]", "port_forward"} {
+		if strings.Contains(saved, override) {
+			t.Errorf("%s is only set by flags and should not be saved:\n%s", override, saved)
+		}
+	}
+}
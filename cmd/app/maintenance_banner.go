@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// maintenanceBannerRefreshInterval is how often project annotations are
+// re-read so a freeze announced mid-session still shows up
+const maintenanceBannerRefreshInterval = 5 * time.Minute
+
+// startMaintenanceBanner does the first banner read for the current context;
+// later reads are chained by the refresh timer
+func (m *Model) startMaintenanceBanner() tea.Cmd {
+	if m.bannerPolling {
+		return nil
+	}
+	cmd := m.fetchMaintenanceBanner()
+	m.bannerPolling = cmd != nil
+	return cmd
+}
+
+// fetchMaintenanceBanner reads the banner annotation from the projects the
+// user can see. Failures are logged and leave the current banner in place.
+func (m *Model) fetchMaintenanceBanner() tea.Cmd {
+	epoch := m.switchEpoch
+	server := m.state.Server // capture at call time
+	if server == nil || !m.config.IsMaintenanceBannerEnabled() {
+		return nil
+	}
+	annotation := m.config.GetBannerAnnotation()
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		projects, err := api.NewApplicationService(server).ListProjects(ctx)
+		if err != nil {
+			cblog.With("component", "banner").Debug("Could not load projects", "err", err)
+			return model.MaintenanceBannerLoadedMsg{Err: err, SwitchEpoch: epoch}
+		}
+		return model.MaintenanceBannerLoadedMsg{
+			Messages:    api.ProjectAnnotationMessages(projects, annotation),
+			SwitchEpoch: epoch,
+		}
+	}
+}
+
+// scheduleMaintenanceBannerRefresh re-reads the banner after the refresh interval
+func (m *Model) scheduleMaintenanceBannerRefresh() tea.Cmd {
+	epoch := m.switchEpoch
+	return tea.Tick(maintenanceBannerRefreshInterval, func(time.Time) tea.Msg {
+		return model.MaintenanceBannerRefreshMsg{SwitchEpoch: epoch}
+	})
+}
+
+// renderMaintenanceBanner renders the org-wide notices as full-width lines
+// above the header, or "" when there are none
+func (m *Model) renderMaintenanceBanner() string {
+	if len(m.state.MaintenanceBanner) == 0 {
+		return ""
+	}
+	width := m.screenWidth()
+	st := lipgloss.NewStyle().
+		Bold(true).
+		Background(yellowBright).
+		Foreground(ensureContrastingForeground(yellowBright, whiteBright)).
+		Width(width)
+	lines := make([]string, 0, len(m.state.MaintenanceBanner))
+	for _, msg := range m.state.MaintenanceBanner {
+		// Annotations may be multi-line; one row per notice keeps the header stable
+		msg = strings.Join(strings.Fields(msg), " ")
+		lines = append(lines, st.Render(" ⚠ "+truncateWithEllipsis(msg, width-4)))
+	}
+	return strings.Join(lines, "\n")
+}
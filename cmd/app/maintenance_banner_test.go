@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestMaintenanceBanner_FetchedFromProjectAnnotations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "default", "annotations": {"example.com/notice": "Change freeze until Monday"}}},
+			{"metadata": {"name": "web", "annotations": {"argonaut.io/banner": "ignored"}}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{MaintenanceBanner: config.MaintenanceBannerConfig{Enabled: true, Annotation: "example.com/notice"}}
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+
+	cmd := m.startMaintenanceBanner()
+	if cmd == nil {
+		t.Fatal("expected a banner fetch")
+	}
+	if m.startMaintenanceBanner() != nil {
+		t.Error("polling should only be started once per context")
+	}
+	msg, ok := cmd().(model.MaintenanceBannerLoadedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	if _, next := m.Update(msg); next == nil {
+		t.Error("a refresh should be scheduled after loading")
+	}
+	if len(m.state.MaintenanceBanner) != 1 || m.state.MaintenanceBanner[0] != "Change freeze until Monday" {
+		t.Errorf("MaintenanceBanner = %v", m.state.MaintenanceBanner)
+	}
+}
+
+func TestMaintenanceBanner_OffUnlessEnabled(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{MaintenanceBanner: config.MaintenanceBannerConfig{Annotation: "example.com/notice"}}
+	if m.startMaintenanceBanner() != nil {
+		t.Error("the banner must not query projects unless enabled")
+	}
+}
+
+func TestMaintenanceBanner_KeptOnErrorAndGatedByEpoch(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.Update(model.MaintenanceBannerLoadedMsg{Messages: []string{"Freeze"}, SwitchEpoch: m.switchEpoch})
+	m.Update(model.MaintenanceBannerLoadedMsg{Err: errors.New("forbidden"), SwitchEpoch: m.switchEpoch})
+	if len(m.state.MaintenanceBanner) != 1 {
+		t.Error("a failed refresh should keep the current banner")
+	}
+	m.Update(model.MaintenanceBannerLoadedMsg{SwitchEpoch: m.switchEpoch + 1})
+	if len(m.state.MaintenanceBanner) != 1 {
+		t.Error("a result from another context must be ignored")
+	}
+	m.Update(model.MaintenanceBannerLoadedMsg{SwitchEpoch: m.switchEpoch})
+	if len(m.state.MaintenanceBanner) != 0 {
+		t.Error("removing the annotation should clear the banner")
+	}
+}
+
+func TestMaintenanceBanner_RenderedAboveHeader(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	before := m.listViewportHeight()
+	m.state.MaintenanceBanner = []string{"Change freeze\nuntil Monday"}
+
+	lines := strings.Split(stripANSI(m.renderBanner()), "\n")
+	if !strings.Contains(lines[0], "⚠ Change freeze until Monday") {
+		t.Errorf("first header line = %q", lines[0])
+	}
+	if got := m.listViewportHeight(); got != before-1 {
+		t.Errorf("list viewport height = %d, want %d", got, before-1)
+	}
+}
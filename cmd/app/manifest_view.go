@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+	"github.com/darksworm/argonaut/pkg/tui/yamlview"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestLoadedMsg carries the live manifest of a tree resource
+type manifestLoadedMsg struct {
+	resource string // manifestKey of the resource
+	doc      *yaml.Node
+	err      error
+	epoch    int
+}
+
+// manifestKey identifies a resource by its app and its own identity
+func manifestKey(p api.LiveResourceParams) string {
+	return strings.Join([]string{appKey(p.AppName, p.AppNamespace), p.Group, p.Kind, p.Namespace, p.ResourceName}, "/")
+}
+
+// handleViewManifest loads the live manifest of the resource under the tree
+// cursor into the YAML viewer
+func (m *Model) handleViewManifest() (tea.Model, tea.Cmd) {
+	if m.state.Navigation.View != model.ViewTree || m.treeView == nil {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Open an app's resources to view a manifest"} }
+	}
+	sel, ok := m.treeView.CurrentResource()
+	if !ok {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Select a resource to view its manifest"} }
+	}
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return m, nil
+	}
+	title := sel.Kind + "/" + sel.Name
+	if sel.Namespace != "" {
+		title = sel.Namespace + "/" + title
+	}
+	params := m.liveResourceParams(sel)
+	resource := manifestKey(params)
+	m.state.Modals.Manifest = &model.ManifestState{Title: title, Resource: resource, Loading: true}
+	m.state.Mode = model.ModeManifest
+	m.manifestView = nil
+
+	epoch := m.switchEpoch // capture at call time
+	return m, func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		manifest, err := api.NewApplicationService(server).GetResourceManifest(ctx, params)
+		if err != nil {
+			return manifestLoadedMsg{resource: resource, err: err, epoch: epoch}
+		}
+		doc, err := manifestNode(manifest)
+		return manifestLoadedMsg{resource: resource, doc: doc, err: err, epoch: epoch}
+	}
+}
+
+// manifestNode parses a live JSON manifest keeping the server's field order.
+// managedFields are dropped like kubectl get -o yaml does.
+func manifestNode(manifest []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(manifest, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse manifest: not an object")
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "metadata" || root.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		meta := root.Content[i+1]
+		for j := 0; j+1 < len(meta.Content); j += 2 {
+			if meta.Content[j].Value == "managedFields" {
+				meta.Content = append(meta.Content[:j], meta.Content[j+2:]...)
+				break
+			}
+		}
+	}
+	return &doc, nil
+}
+
+// handleManifestLoaded shows the loaded manifest, or reports why it
+// failed, if the viewer still waits for that resource
+func (m *Model) handleManifestLoaded(msg manifestLoadedMsg) tea.Cmd {
+	st := m.state.Modals.Manifest
+	if st == nil || m.state.Mode != model.ModeManifest || st.Resource != msg.resource {
+		return nil
+	}
+	if msg.err != nil {
+		cblog.With("component", "manifest").Error("Failed to load manifest", "resource", st.Title, "err", msg.err)
+		m.closeManifest()
+		text := "Could not load manifest: " + extractUserFriendlyError(msg.err)
+		m.recordError("manifest", text, st.Title, nil)
+		return m.showStatusNote(text)
+	}
+	st.Loading = false
+	m.manifestView = yamlview.New(msg.doc)
+	m.manifestView.ApplyStyles(currentStyles)
+	return nil
+}
+
+func (m *Model) closeManifest() {
+	m.state.Modals.Manifest = nil
+	m.manifestView = nil
+	m.state.Mode = model.ModeNormal
+}
+
+// handleManifestKeys handles input in the manifest viewer
+func (m *Model) handleManifestKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.Manifest
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	key := msg.String()
+	if key == "q" || key == "esc" {
+		m.closeManifest()
+		return m, nil
+	}
+	v := m.manifestView
+	if v == nil {
+		return m, nil // still loading
+	}
+	st.Copied = ""
+	switch key {
+	case "up", "k":
+		v.Move(-1)
+	case "down", "j":
+		v.Move(1)
+	case "pgup":
+		v.Move(-v.PageSize())
+	case "pgdown":
+		v.Move(v.PageSize())
+	case "g", "home":
+		v.Top()
+	case "G", "end":
+		v.Bottom()
+	case "left", "h":
+		v.Fold()
+	case "right", "l":
+		v.Unfold()
+	case "space", "enter":
+		v.Toggle()
+	case "z":
+		v.FoldAll()
+	case "Z":
+		v.UnfoldAll()
+	case "y":
+		path := v.Path()
+		if path == "" {
+			return m, nil
+		}
+		st.Copied = path
+		return m, clipboard.CopyCmd(path)
+	}
+	return m, nil
+}
+
+// renderManifestModal renders the manifest viewer over nearly the whole
+// screen: the resource, the path under the cursor and the folded YAML
+func (m *Model) renderManifestModal() string {
+	st := m.state.Modals.Manifest
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := layout.OverlayWidth(m.state.Terminal.Cols)
+	innerWidth := layout.Card.InnerWidth(modalWidth)
+	// title, breadcrumb, blank line above and below the body, help
+	bodyRows := max(1, layout.Card.InnerHeight(m.state.Terminal.Rows)-7)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render(truncateWithEllipsis(st.Title, innerWidth))
+	lines := []string{title}
+	if m.manifestView == nil {
+		lines = append(lines, "", dim.Render("Loading manifest…"), "", dim.Render("Esc to close"))
+	} else {
+		v := m.manifestView
+		v.SetSize(innerWidth, bodyRows)
+		crumb := strings.Join(v.Breadcrumb(), " › ")
+		if crumb == "" {
+			crumb = " "
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render(truncateWithEllipsis(crumb, innerWidth)), "", v.Render(), "")
+		help := "h/l fold/unfold • z/Z fold/unfold all • y copy path • Esc to close"
+		if st.Copied != "" {
+			help = "Copied " + st.Copied
+		}
+		lines = append(lines, dim.Render(truncateWithEllipsis(help, innerWidth)))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(0, 1).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
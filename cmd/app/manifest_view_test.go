@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestManifestView_ShowsLiveManifestWithoutManagedFields(t *testing.T) {
+	manifest := `{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"migrate","namespace":"ops",` +
+		`"managedFields":[{"manager":"kubectl"}]},"spec":{"backoffLimit":4,"template":{"spec":{"restartPolicy":"Never"}}}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("kind") != "Job" || q.Get("resourceName") != "migrate" || q.Get("group") != "batch" {
+			t.Errorf("unexpected manifest query %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"manifest": manifest})
+	}))
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	_, cmd := m.Update(keyPress("v"))
+	if m.state.Mode != model.ModeManifest || cmd == nil {
+		t.Fatalf("expected v to open the manifest viewer, got mode %s", m.state.Mode)
+	}
+	if view := stripANSI(m.renderManifestModal()); !strings.Contains(view, "ops/Job/migrate") || !strings.Contains(view, "Loading") {
+		t.Errorf("expected a loading viewer for the job:\n%s", view)
+	}
+	m.Update(cmd())
+
+	view := stripANSI(m.renderManifestModal())
+	if !strings.Contains(view, "backoffLimit: 4") || !strings.Contains(view, "restartPolicy: Never") {
+		t.Errorf("expected the manifest as YAML:\n%s", view)
+	}
+	if strings.Contains(view, "managedFields") {
+		t.Errorf("managedFields should be dropped:\n%s", view)
+	}
+
+	// Move to spec.backoffLimit and copy its path
+	for range 6 {
+		m.handleManifestKeys(keyPress("down"))
+	}
+	if _, cmd := m.handleManifestKeys(keyPress("y")); cmd == nil || m.state.Modals.Manifest.Copied != "spec.backoffLimit" {
+		t.Errorf("expected y to copy spec.backoffLimit, got %q", m.state.Modals.Manifest.Copied)
+	}
+	if view := stripANSI(m.renderManifestModal()); !strings.Contains(view, "spec › backoffLimit") {
+		t.Errorf("expected the breadcrumb of the cursor:\n%s", view)
+	}
+
+	m.handleManifestKeys(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal || m.manifestView != nil {
+		t.Error("esc should close the viewer")
+	}
+}
+
+func TestManifestView_ReportsLoadErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"forbidden"}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	_, cmd := m.handleViewManifest()
+	m.Update(cmd())
+	if m.state.Mode != model.ModeNormal || m.state.Modals.Manifest != nil {
+		t.Errorf("a failed load should close the viewer, got mode %s", m.state.Mode)
+	}
+	if errs := m.state.RecentErrors; len(errs) == 0 || errs[len(errs)-1].Source != "manifest" {
+		t.Error("a failed load should be recorded in :errors")
+	}
+}
+
+func TestManifestView_IgnoresLoadOfAnotherResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"manifest": `{"kind":"Job"}`})
+	}))
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	_, cmd := m.handleViewManifest()
+	msg := cmd().(manifestLoadedMsg)
+	msg.resource = "team-b/other-app/batch/Job/ops/migrate"
+	m.Update(msg)
+	if st := m.state.Modals.Manifest; st == nil || !st.Loading || m.manifestView != nil {
+		t.Error("a manifest loaded for another resource should not fill the viewer")
+	}
+}
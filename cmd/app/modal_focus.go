@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// Toggles of modals that Tab can focus
+const (
+	focusPrune      = "prune"
+	focusWatch      = "watch"
+	focusPolicy     = "policy"
+	focusPruneLast  = "prune-last"
+	focusServerSide = "server-side"
+	focusForce      = "force"
+	focusCascade    = "cascade"
+)
+
+// focusElement is a button or toggle of a modal that Tab moves focus to
+type focusElement struct {
+	name string
+	// key selects a button, or is the key Enter stands for on a toggle
+	key string
+	// selected reports whether a button is the modal's selected one; nil
+	// for toggles
+	selected func() bool
+}
+
+// modalFocusOrder lists the buttons and toggles of the open modal in the
+// order they appear on screen, or nil when the modal takes no Tab focus
+func (m *Model) modalFocusOrder() []focusElement {
+	mo := &m.state.Modals
+	button := func(name, key string, selected func() bool) focusElement {
+		return focusElement{name: name, key: key, selected: selected}
+	}
+	toggle := func(name, key string) focusElement {
+		return focusElement{name: name, key: key}
+	}
+
+	switch m.state.Mode {
+	case model.ModeConfirmSync:
+		if mo.ConfirmSyncLoading || (mo.ConfirmSyncPrunePreview != nil && mo.ConfirmSyncPrunePreview.Picking) {
+			return nil
+		}
+		return []focusElement{
+			button("yes", "left", func() bool { return mo.ConfirmSyncSelected == 0 }),
+			button("cancel", "right", func() bool { return mo.ConfirmSyncSelected == 1 }),
+			toggle(focusPrune, "p"),
+			toggle(focusWatch, "w"),
+			toggle(focusPolicy, "o"),
+			toggle(focusPruneLast, "L"),
+			toggle(focusServerSide, "a"),
+		}
+	case model.ModeConfirmResourceSync:
+		if mo.ResourceSyncLoading {
+			return nil
+		}
+		return []focusElement{
+			button("sync", "left", func() bool { return mo.ResourceSyncConfirmSelected == 0 }),
+			button("cancel", "right", func() bool { return mo.ResourceSyncConfirmSelected == 1 }),
+			toggle(focusPrune, "p"),
+			toggle(focusForce, "f"),
+		}
+	case model.ModeRollback:
+		rb := m.state.Rollback
+		if rb == nil || rb.Mode != "confirm" || rb.Loading {
+			return nil
+		}
+		return []focusElement{
+			toggle(focusPrune, "p"),
+			toggle(focusWatch, "w"),
+			button("yes", "left", func() bool { return rb.ConfirmSelected == 0 }),
+			button("no", "right", func() bool { return rb.ConfirmSelected == 1 }),
+		}
+	case model.ModeUpgrade:
+		if mo.UpgradeLoading {
+			return nil
+		}
+		return []focusElement{
+			button("upgrade", "left", func() bool { return mo.UpgradeSelected == 0 }),
+			button("cancel", "right", func() bool { return mo.UpgradeSelected == 1 }),
+		}
+	case model.ModeConfirmAppDelete:
+		if mo.DeleteLoading {
+			return nil
+		}
+		return []focusElement{toggle(focusCascade, "c"), toggle(focusPolicy, "p")}
+	case model.ModeConfirmResourceDelete:
+		if mo.ResourceDeleteLoading {
+			return nil
+		}
+		return []focusElement{toggle(focusCascade, "c"), toggle(focusPolicy, "p"), toggle(focusForce, "f")}
+	}
+	return nil
+}
+
+// focusedElement returns the position in order of the element with focus:
+// the focused toggle, else the modal's selected button. It is -1 when
+// nothing has focus, as in modals without buttons.
+func (m *Model) focusedElement(order []focusElement) int {
+	for i, el := range order {
+		if m.state.Modals.Focus != "" {
+			if el.selected == nil && el.name == m.state.Modals.Focus {
+				return i
+			}
+		} else if el.selected != nil && el.selected() {
+			return i
+		}
+	}
+	return -1
+}
+
+// focusKey builds the key press a focus element's key stands for
+func focusKey(k string) tea.KeyPressMsg {
+	switch k {
+	case "left":
+		return tea.KeyPressMsg{Code: tea.KeyLeft}
+	case "right":
+		return tea.KeyPressMsg{Code: tea.KeyRight}
+	}
+	return tea.KeyPressMsg{Code: rune(k[0]), Text: k}
+}
+
+// handleModalFocusKeys moves focus between a modal's buttons and toggles
+// with Tab and Shift+Tab, and makes Enter flip the focused toggle. Buttons
+// keep the modal's own Enter handling. handled is false for other keys.
+func (m *Model) handleModalFocusKeys(msg tea.KeyMsg) (next tea.Model, cmd tea.Cmd, handled bool) {
+	order := m.modalFocusOrder()
+	if len(order) == 0 {
+		return m, nil, false
+	}
+	cur := m.focusedElement(order)
+
+	switch key := msg.String(); key {
+	case "tab", "shift+tab":
+		step := 1
+		if key == "shift+tab" {
+			step = -1
+		}
+		i := 0
+		switch {
+		case cur >= 0:
+			i = (cur + step + len(order)) % len(order)
+		case step < 0:
+			i = len(order) - 1
+		}
+		el := order[i]
+		if el.selected != nil {
+			m.state.Modals.Focus = ""
+			next, cmd = m.handleKeyMsg(focusKey(el.key))
+			return next, cmd, true
+		}
+		m.state.Modals.Focus = el.name
+		return m, nil, true
+	case "enter":
+		if cur < 0 || order[cur].selected != nil {
+			return m, nil, false
+		}
+		next, cmd = m.handleKeyMsg(focusKey(order[cur].key))
+		return next, cmd, true
+	case "left", "h", "right", "l":
+		// Choosing a button takes focus back from the toggles
+		m.state.Modals.Focus = ""
+	}
+	return m, nil, false
+}
+
+// focusMarker marks the toggle of a modal that has focus
+func (m *Model) focusMarker(name string) string {
+	if m.state.Modals.Focus != name {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render("▸ ")
+}
+
+// buttonFocused reports whether the modal's selected button has focus;
+// false while Tab has moved it to one of the toggles
+func (m *Model) buttonFocused() bool {
+	return m.state.Modals.Focus == ""
+}
+
+// listFocus is the list row under the cursor when a modal opened
+type listFocus struct {
+	view model.View
+	idx  int
+	key  string
+}
+
+// listItemKey identifies a row of a list view
+func listItemKey(item interface{}) string {
+	if app, ok := item.(model.App); ok {
+		return appKey(app.Name, app.AppNamespace)
+	}
+	return fmt.Sprint(item)
+}
+
+// returnsFocus reports whether a mode is a modal over a view, which hands
+// focus back to the view's list when it closes
+func returnsFocus(mode model.Mode) bool {
+	switch mode {
+	case model.ModeNormal, model.ModeLoading, model.ModeSearch, model.ModeCommand, model.ModeExternal,
+		model.ModeAuthRequired, model.ModeDeviceLogin, model.ModeError, model.ModeConnectionError, model.ModeCoreDetected:
+		return false
+	}
+	return true
+}
+
+// trackModalFocus runs after each message. A modal that opens starts with
+// focus on its default element and remembers the list row under the
+// cursor; when it closes, the cursor goes back to that row if live updates
+// moved the list under it meanwhile.
+func (m *Model) trackModalFocus(prev model.Mode) {
+	mode := m.state.Mode
+	if mode == prev {
+		return
+	}
+	m.state.Modals.Focus = ""
+	switch {
+	case prev == model.ModeNormal && returnsFocus(mode):
+		m.focusReturn = m.currentListFocus()
+	case mode == model.ModeNormal:
+		if returnsFocus(prev) {
+			m.restoreListFocus()
+		}
+		m.focusReturn = nil
+	}
+}
+
+// currentListFocus returns the list row under the cursor, or nil in the
+// tree view, which keeps its own cursor
+func (m *Model) currentListFocus() *listFocus {
+	if m.state.Navigation.View == model.ViewTree {
+		return nil
+	}
+	items := m.getVisibleItems()
+	idx := m.state.Navigation.SelectedIdx
+	if idx < 0 || idx >= len(items) {
+		return nil
+	}
+	return &listFocus{view: m.state.Navigation.View, idx: idx, key: listItemKey(items[idx])}
+}
+
+// restoreListFocus puts the cursor back on the row it was on when the
+// modal opened. A cursor the modal moved itself, such as the app map
+// opening an app, is left alone.
+func (m *Model) restoreListFocus() {
+	f := m.focusReturn
+	if f == nil || m.state.Navigation.View != f.view || m.state.Navigation.SelectedIdx != f.idx {
+		return
+	}
+	items := m.getVisibleItems()
+	if f.idx < len(items) && listItemKey(items[f.idx]) == f.key {
+		return
+	}
+	for i, item := range items {
+		if listItemKey(item) == f.key {
+			m.listNav.SetItemCount(len(items))
+			m.listNav.SetViewportHeight(m.listViewportHeight())
+			m.listNav.SetCursor(i)
+			m.state.Navigation.SelectedIdx = m.listNav.Cursor()
+			return
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestModalFocus_TabCyclesSyncModal(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleSyncModal()
+	mo := &m.state.Modals
+
+	m.Update(keyPress("tab"))
+	if mo.ConfirmSyncSelected != 1 || mo.Focus != "" {
+		t.Fatalf("tab should move from Yes to Cancel, selected=%d focus=%q", mo.ConfirmSyncSelected, mo.Focus)
+	}
+	m.Update(keyPress("tab"))
+	if mo.Focus != focusPrune {
+		t.Fatalf("tab should move on to the prune toggle, focus=%q", mo.Focus)
+	}
+	if out := stripANSI(m.renderConfirmSyncModal()); !strings.Contains(out, "▸ p: Prune Off") {
+		t.Errorf("the focused toggle should be marked:\n%s", out)
+	}
+
+	m.Update(keyPress("enter"))
+	if !mo.ConfirmSyncPrune || m.state.Mode != model.ModeConfirmSync {
+		t.Fatal("enter should flip the focused toggle, not confirm the sync")
+	}
+
+	// Shift+Tab wraps from the first button to the last toggle
+	m.Update(keyPress("shift+tab"))
+	m.Update(keyPress("shift+tab"))
+	if mo.Focus != "" || mo.ConfirmSyncSelected != 0 {
+		t.Fatalf("shift+tab should go back to Yes, selected=%d focus=%q", mo.ConfirmSyncSelected, mo.Focus)
+	}
+	m.Update(keyPress("shift+tab"))
+	if mo.Focus != focusServerSide {
+		t.Fatalf("shift+tab from the first element should wrap to the last, focus=%q", mo.Focus)
+	}
+	m.Update(keyPress("right"))
+	if mo.Focus != "" || mo.ConfirmSyncSelected != 1 {
+		t.Fatal("choosing a button should take focus back from the toggles")
+	}
+
+	m.Update(keyPress("esc"))
+	m.handleSyncModal()
+	if mo.Focus != "" {
+		t.Fatal("a modal should open with focus on its default button")
+	}
+}
+
+func TestModalFocus_TogglesWithoutButtons(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleAppDelete()
+	cascade := m.state.Modals.DeleteCascade
+
+	m.Update(keyPress("enter"))
+	if m.state.Modals.DeleteCascade != cascade || m.state.Mode != model.ModeConfirmAppDelete {
+		t.Fatal("enter should do nothing before a toggle has focus")
+	}
+	m.Update(keyPress("tab"))
+	m.Update(keyPress("enter"))
+	if m.state.Modals.DeleteCascade == cascade {
+		t.Fatal("enter should flip the focused cascade toggle")
+	}
+	m.Update(keyPress("tab"))
+	m.Update(keyPress("tab"))
+	if m.state.Modals.Focus != focusCascade {
+		t.Fatalf("tab should cycle back to the first toggle, focus=%q", m.state.Modals.Focus)
+	}
+}
+
+func TestModalFocus_RestoresListCursorOnClose(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Navigation.SelectedIdx = 1 // zzz-other-app
+	m.listNav.SetCursor(1)
+
+	m.Update(keyPress("i"))
+	if m.state.Modals.AppDetails == nil || m.state.Modals.AppDetails.AppName != "zzz-other-app" {
+		t.Fatal("details should open for the app under the cursor")
+	}
+	// An app appearing above it while the modal is open shifts the list
+	m.state.Apps = append(m.state.Apps, model.App{Name: "new-app", Sync: "Synced", Health: "Healthy"})
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+
+	m.Update(keyPress("esc"))
+	if app, ok := m.cursorApp(); !ok || app.Name != "zzz-other-app" {
+		t.Fatalf("closing the modal should put the cursor back on zzz-other-app, got %+v", app)
+	}
+}
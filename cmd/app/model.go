@@ -12,18 +12,22 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/table"
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
 	cblog "github.com/charmbracelet/log"
 	"github.com/darksworm/argonaut/pkg/api"
 	"github.com/darksworm/argonaut/pkg/autocomplete"
+	"github.com/darksworm/argonaut/pkg/compat"
 	"github.com/darksworm/argonaut/pkg/config"
 	apperrors "github.com/darksworm/argonaut/pkg/errors"
 	"github.com/darksworm/argonaut/pkg/model"
 	"github.com/darksworm/argonaut/pkg/services"
 	"github.com/darksworm/argonaut/pkg/tui"
+	"github.com/darksworm/argonaut/pkg/tui/browser"
 	"github.com/darksworm/argonaut/pkg/tui/clipboard"
 	"github.com/darksworm/argonaut/pkg/tui/listnav"
 	"github.com/darksworm/argonaut/pkg/tui/selection"
 	"github.com/darksworm/argonaut/pkg/tui/treeview"
+	"github.com/darksworm/argonaut/pkg/tui/yamlview"
 )
 
 // Model represents the main Bubbletea model containing all application state
@@ -103,6 +107,12 @@ type Model struct {
 	// Tree loading overlay state
 	treeLoading bool
 
+	// Keeps the tree cursor on a Progressing resource (f in the tree view)
+	treeFollow bool
+
+	// Frame timings recorded by :profile render
+	renderProfile *renderProfile
+
 	// List navigators for all scrollable lists
 	listNav     *listnav.ListNavigator // Main list (apps, clusters, namespaces, projects)
 	treeNav     *listnav.ListNavigator // Tree view
@@ -112,6 +122,11 @@ type Model struct {
 	// Cleanup callbacks for active tree watchers
 	treeWatchCleanups []func()
 
+	// State of the tree view's watch streams, keyed by app name (see
+	// tree_streams.go)
+	treeStreams   map[string]*treeStream
+	treeStreamSeq int
+
 	// Debug: render counter
 	renderCount int
 
@@ -137,10 +152,129 @@ type Model struct {
 	// Context switching state
 	argoConfigPath     string // Path to ArgoCD CLI config (for re-reads on switch)
 	currentContextName string // Active ArgoCD context name
+	profileName        string // Active argonaut config profile ("" = default config)
 	switchEpoch        int    // Incremented on each context switch; captured by async closures
+
+	// On-disk app list snapshot (instant startup)
+	appCacheDir   string // Snapshot directory; empty disables the cache
+	appsFromCache bool   // state.Apps holds the cached list until live data arrives
+	viewReload    string // what ctrl+r is reloading ("" when idle)
+
+	// List row under the cursor when the open modal was opened
+	focusReturn *listFocus
+
+	// Git lookups for on-screen apps' synced revisions, keyed by appKey
+	revisionInfo map[string]revisionInfo
+
+	// Syncs and rollbacks followed until they finish, keyed by appKey;
+	// quitting while one runs asks first and syncs started with Watch post
+	// their result (see watched_ops.go)
+	watchedOps map[string]watchedOperation
+
+	// When Argo CD accepted each sync and rollback, keyed by operationKey;
+	// repeating one within the cooldown is refused (see action_cooldown.go)
+	requestedOps map[string]time.Time
+
+	// Printed after the program exits, set when quitting with detach
+	detachNote string
+
+	// Counts :wait commands so ticks of a finished wait are ignored
+	waitSeq int
+	// Clock :wait measures elapsed time and timeouts with; time.Now when nil
+	waitClock func() time.Time
+
+	// Counts project and ApplicationSet refreshes so steps of a stopped one are ignored
+	bulkRefreshSeq int
+
+	// Counts diff summaries so results for a closed one are ignored
+	diffSummarySeq int
+
+	// Counts release trains so steps of a closed one are ignored
+	stagingSeq int
+
+	// Counts :termcheck runs so a timeout of an earlier one is ignored
+	termCheckSeq int
+
+	// Last seen health and operation of each app, for the local hooks
+	hookSeen map[string]hookSeen
+
+	// Color profile Bubble Tea detected for the terminal, for :termcheck
+	colorProfile colorprofile.Profile
+
+	// Maintenance banner polling has started for this context
+	bannerPolling bool
+
+	// Cluster connection polling has started for this context
+	clusterPolling bool
+
+	// App list polling has started for this context, in place of the
+	// watch stream when streaming is off (see polling.go)
+	appsPolling bool
+
+	// Version check of the connected server, nil until /api/version has
+	// answered with a release version or the endpoint probes are back
+	// (see compat.go)
+	serverCompat    *compat.Report
+	probedEndpoints map[compat.Feature]bool
+	compatWarned    bool // the compatibility warning was shown for this context
+
+	// Background re-reads of the server version (see server_version.go):
+	// a changed version waits in versionCandidate until a second read
+	// confirms it
+	versionPolling    bool
+	versionCandidate  string
+	versionSkewWarned bool // replicas with different versions were reported
+
+	// Status of Argo Rollouts selected in the tree, keyed by rolloutKey
+	rolloutInfo map[string]rolloutInfo
+
+	// Background loads of the app under the cursor (see prefetch.go)
+	prefetch       *appPrefetcher
+	prefetchTarget string // appKey of the app being prefetched
+	prefetchSeq    int    // bumped per cursor move; stale debounce ticks are dropped
+
+	// Bumped per pane layout change; only the last one's save runs (see panes.go)
+	layoutSaveSeq int
+
+	// Diffs opened this session, by app and revision pair (see diff_cache.go)
+	diffCache *diffCache
+
+	// When apps with cached data left the filtered scope, keyed by appKey (see prune.go)
+	outOfScopeSince map[string]time.Time
+
+	// Live manifest shown in the YAML viewer (see manifest_view.go)
+	manifestView *yamlview.Viewer
+
+	// What each captured watch stream event changed, keyed by its stream log
+	// sequence number (see stream_log.go)
+	streamDeltas map[uint64]string
+
+	// Why the server's TLS certificate failed verification on the last
+	// connection attempt, and whether it was continued past for this
+	// session (see insecure_tls.go)
+	tlsFailure       string
+	insecureOverride bool
+
+	// Sign-in with a device code in progress, and a counter that tells its
+	// answers apart from those of a login started over (see device_login.go)
+	deviceLogin    *deviceLoginState
+	deviceLoginSeq int
 }
 
+// Update applies a message, then moves focus if it opened or closed a modal
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prevMode := m.state.Mode
+	next, cmd := m.update(msg)
+	m.trackModalFocus(prevMode)
+	return next, cmd
+}
+
+// update applies a message to the model
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.viewReload != "" && reloadFinished(msg) {
+		m.viewReload = ""
+	}
+
 	switch msg := msg.(type) {
 
 	// Terminal/System messages
@@ -159,7 +293,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		return m.handleKeyMsg(msg)
+		next, cmd := m.handleKeyMsg(msg)
+		// Scrolling or re-scoping may bring unchecked apps on screen
+		if nm, ok := next.(*Model); ok {
+			cmd = tea.Batch(cmd, nm.checkRevisions(), nm.schedulePrefetch(), nm.checkSelectedRollout())
+		}
+		return next, cmd
 
 	case tea.PasteMsg:
 		// Handle clipboard paste events
@@ -184,12 +323,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.inputComponents.SetCommandValue(newValue)
 			m.state.UI.Command = newValue
 			m.state.UI.CommandInvalid = false
+			m.state.UI.CommandMenuIdx = 0
 			return m, nil
 		}
 		return m, nil
 
 	case tea.MouseClickMsg:
-		return m.handleMouseClickMsg(msg)
+		next, cmd := m.handleMouseClickMsg(msg)
+		if nm, ok := next.(*Model); ok {
+			cmd = tea.Batch(cmd, nm.schedulePrefetch())
+		}
+		return next, cmd
 
 	case tea.MouseMotionMsg:
 		return m.handleMouseMotionMsg(msg)
@@ -201,25 +345,65 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.UI.SelectionCopied = false
 		return m, nil
 
+	case clearStatusNoteMsg:
+		m.state.UI.StatusNote = ""
+		return m, nil
+
+	case manifestSavedMsg:
+		return m, m.handleManifestSaved(msg)
+
+	case keysExportedMsg:
+		return m, m.handleKeysExported(msg)
+
+	case supportBundleWrittenMsg:
+		return m, m.handleSupportBundleWritten(msg)
+
+	case appsExportedMsg:
+		return m, m.handleAppsExported(msg)
+
+	case serverInsecureSavedMsg:
+		return m, m.handleServerInsecureSaved(msg)
+
+	case deviceLoginStartedMsg:
+		return m, m.handleDeviceLoginStarted(msg)
+
+	case deviceLoginPolledMsg:
+		return m, m.handleDeviceLoginPolled(msg)
+
+	case deviceLoginSavedMsg:
+		return m, m.handleDeviceLoginSaved(msg)
+
 	case clipboard.CopyMsg:
 		// Clipboard copy completed (success or failure logged elsewhere)
 		return m, nil
 
+	case browser.OpenMsg:
+		if msg.Err != nil {
+			// No browser to start, e.g. over ssh: hand over the link instead
+			return m, tea.Batch(clipboard.CopyCmd(msg.URL), m.showStatusNote("Could not open a browser, copied the link"))
+		}
+		return m, nil
+
 	// Tree stream messages from watcher goroutine
 	case model.ResourceTreeStreamMsg:
 		cblog.With("component", "ui").Debug("Processing tree stream message", "app", msg.AppName, "hasData", len(msg.TreeJSON) > 0)
+		if msg.Ended {
+			return m, tea.Batch(m.consumeTreeEvent(), m.handleTreeStreamEnded(msg.AppName, msg.Seq, "the stream closed"))
+		}
+		m.handleTreeStreamUpdate(msg)
 		if len(msg.TreeJSON) > 0 && m.treeView != nil && m.state.Navigation.View == model.ViewTree {
 			var tree api.ResourceTree
 			if err := json.Unmarshal(msg.TreeJSON, &tree); err == nil {
 				cblog.With("component", "ui").Debug("Updating tree view", "app", msg.AppName, "nodes", len(tree.Nodes))
 				m.treeView.UpsertAppTree(msg.AppName, &tree)
+				m.followProgressing()
 			} else {
 				cblog.With("component", "ui").Error("Failed to unmarshal tree", "err", err, "app", msg.AppName)
 			}
 		}
 		// Any tree stream activity implies data is arriving; clear loading overlay
 		m.treeLoading = false
-		return m, m.consumeTreeEvent()
+		return m, tea.Batch(m.consumeTreeEvent(), m.checkSelectedRollout())
 
 	// Tree watch started (store cleanup)
 	case treeWatchStartedMsg:
@@ -227,11 +411,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.treeWatchCleanups = append(m.treeWatchCleanups, msg.cleanup)
 			m.statusService.Set("Watching tree…")
 		}
+		m.handleTreeStreamStarted(msg)
 		return m, nil
 
+	case treeWatchFailedMsg:
+		m.statusService.Set("Tree watch failed: " + msg.err.Error())
+		return m, m.handleTreeStreamEnded(msg.appName, msg.seq, msg.err.Error())
+
+	case treeResubscribeMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleTreeResubscribe(msg)
+
 		// Spinner messages
 	case spinner.TickMsg:
-		if m.inPager {
+		if m.inPager || m.reducedMotion() {
 			// Suspend spinner updates while pager owns the terminal
 			return m, nil
 		}
@@ -288,7 +483,118 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case model.SetAPIVersionMsg:
-		m.state.APIVersion = msg.Version
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, tea.Batch(m.handleAPIVersion(msg.Version), m.startServerVersionChecks())
+
+	case serverVersionCheckMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.pollServerVersion()
+
+	case serverVersionPolledMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleServerVersionPolled(msg)
+
+	case endpointsProbedMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleEndpointsProbed(msg)
+
+	case model.HealthCustomizationsLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		m.state.HealthCustomizations = model.HealthCustomizations(msg.Keys)
+		return m, nil
+
+	case prefetchTickMsg:
+		return m, m.handlePrefetchTick(msg)
+
+	case layoutSaveTickMsg:
+		return m, m.handleLayoutSaveTick(msg)
+
+	case model.MaintenanceBannerLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		if msg.Err == nil {
+			m.state.MaintenanceBanner = msg.Messages
+		}
+		return m, m.scheduleMaintenanceBannerRefresh()
+
+	case operationTickMsg:
+		// Not epoch-gated, like the status clock: one chain for the session
+		return m, m.operationTick()
+
+	case statusClockTickMsg:
+		// Not epoch-gated: the chain started by Init carries over context
+		// switches, so starting another one would double the ticks
+		return m, m.statusClockTick()
+
+	case pruneTickMsg:
+		// Not epoch-gated, like the status clock: one chain for the session.
+		// Measured with the wall clock, which runs on in e2e runs too.
+		m.pruneOutOfScope(time.Now())
+		return m, m.pruneTick()
+
+	case resumeCheckMsg:
+		return m, m.handleResumeCheck(msg)
+
+	case resumeReconnectMsg:
+		return m, m.reconnectAfterResume(msg)
+
+	case appsRefreshTickMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleAppsRefreshTick()
+
+	case appsRefreshedMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleAppsRefreshed(msg)
+
+	case model.MaintenanceBannerRefreshMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.fetchMaintenanceBanner()
+
+	case model.ClusterConnectionsLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		if msg.Err == nil {
+			m.state.FailedClusters = msg.Failed
+			m.state.ClusterVersions = msg.Versions
+		}
+		return m, m.scheduleClusterConnectionsRefresh()
+
+	case model.ClusterConnectionsRefreshMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.fetchClusterConnections()
+
+	case model.RolloutStatusLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		m.handleRolloutStatusLoaded(msg)
+		return m, nil
+
+	case model.RevisionInfoLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		m.handleRevisionInfoLoaded(msg)
 		return m, nil
 
 		// Mode messages
@@ -433,6 +739,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		cblog.With("component", "watch").Info("watch stream ended, scheduling reconnect",
 			"delay", watchReconnectDelay)
+		m.recordError(retryWatch, "Application stream dropped, reconnecting", "", nil)
 		return m, tea.Tick(watchReconnectDelay, func(time.Time) tea.Msg {
 			return watchReconnectMsg(msg)
 		})
@@ -457,6 +764,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"resourceVersion", msg.ResourceVersion)
 		m.state.Apps = msg.Apps
 		m.state.Index = model.BuildAppIndex(m.state.Apps)
+		m.recordStatusHistory()
+		// A reload may have removed apps below the cursor
+		m.clampListCursor()
+		m.constrainScopeToToken()
+		// Live data replaces any cached list; refresh the snapshot for next start
+		m.appsFromCache = false
+		saveCache := m.saveAppsCache(msg.Apps)
 		// Store resource version for watch coordination
 		if msg.ResourceVersion != "" {
 			m.lastResourceVersion = msg.ResourceVersion
@@ -465,7 +779,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.Modals.InitialLoading = false
 
 		// Validate pending default_view scope against loaded data
-		m.validateDefaultViewScope()
+		openTree := m.validateDefaultViewScope()
 
 		// Determine which mode to transition to
 		targetMode := model.ModeNormal
@@ -481,11 +795,31 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(
 				func() tea.Msg { return model.SetModeMsg{Mode: targetMode} },
 				m.startWatchingApplications(),
+				m.fetchHealthCustomizations(),
+				m.fetchAPIVersion(),
+				m.probeEndpoints(),
+				m.startMaintenanceBanner(),
+				m.startClusterConnections(),
+				saveCache,
+				openTree,
+				m.checkRevisions(),
 			)
 		}
 		// Watch is already running — the batch handler maintains the chain.
 		// Do NOT call consumeWatchEvents() here to avoid duplicate consumers.
-		return m, func() tea.Msg { return model.SetModeMsg{Mode: targetMode} }
+		return m, tea.Batch(func() tea.Msg { return model.SetModeMsg{Mode: targetMode} }, saveCache, openTree, m.checkRevisions())
+
+	case model.CachedAppsLoadedMsg:
+		// Only fill an empty list: the live load may already have won the race
+		if msg.SwitchEpoch != m.switchEpoch || len(m.state.Apps) > 0 {
+			return m, nil
+		}
+		cblog.With("component", "model").Info("Showing cached apps while loading",
+			"apps_count", len(msg.Apps), "savedAt", msg.SavedAt)
+		m.state.Apps = msg.Apps
+		m.state.Index = model.BuildAppIndex(m.state.Apps)
+		m.appsFromCache = true
+		return m, nil
 
 	case model.AppsBatchUpdateMsg:
 		// Gate by switch epoch — discard entire batch from a previous context
@@ -506,6 +840,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case model.AppBatchOperationDelete:
 					if op.Delete != "" && m.applyBatchAppDelete(op.Delete) {
 						deletesApplied++
+						m.recordStreamDelta(op.DeleteStreamSeq, "removed")
+					} else {
+						m.recordStreamDelta(op.DeleteStreamSeq, "unknown app, ignored")
 					}
 				}
 			}
@@ -521,6 +858,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.state.Index = model.BuildAppIndex(m.state.Apps)
+		m.recordStatusHistory()
 		// Adjust selection bounds after deletes
 		if deletesApplied > 0 {
 			visibleItems := m.getVisibleItemsForCurrentView()
@@ -541,12 +879,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Generation == m.watchGeneration {
 			cmds = append(cmds, m.consumeWatchEvents())
 		}
+		// A sync moves the synced revision; recheck apps that changed
+		cmds = append(cmds, m.checkRevisions(), m.checkWatchedOperations(), m.checkLocalHooks(), m.checkWait())
 		if msg.Immediate != nil {
 			imm := msg.Immediate
 			cmds = append(cmds, func() tea.Msg { return imm })
 		}
 		return m, tea.Batch(cmds...)
 
+	case model.RetryOperationMsg:
+		return m, m.handleRetryOperation(msg)
+
 	case model.StatusChangeMsg:
 		// Now safe to log since we're using file logging
 		m.statusService.Set(msg.Status)
@@ -580,6 +923,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Apply current sort config to the newly loaded tree
 				m.treeView.SetSort(m.state.UI.Sort)
+				m.followProgressing()
 			}
 			// Reset cursor for tree view
 			m.state.Navigation.SelectedIdx = 0
@@ -587,7 +931,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Clear loading overlay once initial tree is loaded
 		m.treeLoading = false
-		return m, nil
+		return m, m.checkSelectedRollout()
 
 		// removed: resources list loader
 
@@ -612,6 +956,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Update error state so the error view can show full details
 			tui.UpdateAppErrorState(m.state, msg.Error)
+			m.recordStructuredError(msg)
+		}
+
+		// A refused sync never started; don't report the app's next operation
+		if name, ok := msg.Context["appName"].(string); ok && msg.Context["operation"] == "sync" {
+			ns, _ := msg.Context["appNamespace"].(*string)
+			m.forgetUnconfirmedOperation("sync", name, ns)
 		}
 
 		// Clear any loading states that might be active
@@ -661,6 +1012,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fullErrorMsg = fmt.Sprintf("API Error (%d): %s", msg.StatusCode, msg.Message)
 		}
 		m.statusService.Error(fullErrorMsg)
+		recorded := msg.Message
+		if msg.StatusCode > 0 {
+			recorded = fmt.Sprintf("HTTP %d: %s", msg.StatusCode, msg.Message)
+		}
+		m.recordError("api", recorded, msg.Details, nil)
 
 		// Clear any loading states that might be active
 		if m.state.Diff != nil {
@@ -752,6 +1108,110 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state.Diff != nil {
 			m.state.Diff.Loading = false
 		}
+		// A pager opened from the diff outline returns to the outline so the
+		// user can jump to the next resource.
+		if m.state.Modals.DiffOutline != nil {
+			m.state.Mode = model.ModeDiffOutline
+		} else if m.state.Modals.DiffSummary != nil {
+			m.state.Mode = model.ModeDiffSummary
+		}
+		// Likewise a hook's logs return to the hooks modal
+		if m.state.Modals.Hooks != nil {
+			m.state.Mode = model.ModeHooks
+		}
+		return m, nil
+
+	case waitTickMsg:
+		return m, m.handleWaitTick(msg)
+
+	case manifestLoadedMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleManifestLoaded(msg)
+
+	case bulkRefreshStepMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleBulkRefreshStep(msg)
+
+	case diffSummaryStepMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleDiffSummaryStep(msg)
+
+	case stagingStepMsg:
+		if msg.epoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleStagingStep(msg)
+
+	case localHookDoneMsg:
+		return m, m.handleLocalHookDone(msg)
+
+	case stagingTickMsg:
+		return m, m.handleStagingTick(msg)
+
+	case tea.ColorProfileMsg:
+		m.colorProfile = msg.Profile
+		return m, nil
+
+	case tea.CursorPositionMsg, tea.ModeReportMsg, tea.TerminalVersionMsg, termCheckTimeoutMsg:
+		return m, m.handleTermCheckReply(msg)
+
+	case model.PrunePreviewLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		m.handlePrunePreviewLoaded(msg)
+		return m, nil
+
+	case model.HooksLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		m.handleHooksLoaded(msg)
+		return m, nil
+
+	case model.NotificationSubscriptionsMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleNotificationSubscriptions(msg)
+
+	case model.PodLogsLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handlePodLogsLoaded(msg)
+
+	case model.HookLogsLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleHookLogsLoaded(msg)
+
+	case model.DiffOutlineLoadedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		if m.state.Diff != nil {
+			m.state.Diff.Loading = false
+		}
+		prevOutline := m.state.Modals.DiffOutline
+		m.state.Modals.DiffOutline = &model.DiffOutlineState{
+			AppName:      msg.AppName,
+			AppNamespace: msg.AppNamespace,
+			Sections:     msg.Sections,
+			Notice:       msg.Notice,
+		}
+		// A reload (ctrl+r) stays on the row it was on; row 0 is "all resources"
+		if prevOutline != nil && prevOutline.AppName == msg.AppName && m.state.Mode == model.ModeDiffOutline {
+			m.state.Modals.DiffOutline.SelectedIdx = min(prevOutline.SelectedIdx, len(msg.Sections))
+		}
+		m.state.Mode = model.ModeDiffOutline
 		return m, nil
 
 	case k9sDoneMsg:
@@ -835,6 +1295,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.restartWatchWithScope()
 
+	case model.OperationConflictMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleOperationConflict(msg)
+
+	case model.OperationTerminatedMsg:
+		if msg.SwitchEpoch != m.switchEpoch {
+			return m, nil
+		}
+		return m, m.handleOperationTerminated(msg)
+
 	case model.SyncCompletedMsg:
 		// Gate by switch epoch
 		if msg.SwitchEpoch != m.switchEpoch {
@@ -843,6 +1315,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle single app sync completion
 		if msg.Success {
 			m.statusService.Set(fmt.Sprintf("Sync initiated for %s", msg.AppName))
+			m.confirmWatchedOperations("sync", appKey(msg.AppName, msg.AppNamespace))
+			m.rememberRequestedOperation("sync", msg.AppName, msg.AppNamespace)
 
 			// Show tree view if watch is enabled
 			if m.state.Modals.ConfirmSyncWatch {
@@ -857,7 +1331,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cleanupTreeWatchers()
 				// Reset tree view for fresh single-app session
 				m.treeView = treeview.NewTreeView(0, 0)
-				m.treeView.ApplyTheme(currentPalette)
+				m.treeView.ApplyStyles(currentStyles)
 				m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 				m.treeNav.Reset() // Reset scroll position
 				// Use namespace from message to avoid ambiguity when multiple apps share a name
@@ -876,6 +1350,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			m.statusService.Set("Sync cancelled")
+			m.forgetWatchedOperation(msg.AppName, msg.AppNamespace)
 		}
 		// Close confirm modal/loading state if open (non-watch path)
 		m.state.Modals.ConfirmTarget = nil
@@ -941,6 +1416,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case model.AppDeleteErrorMsg:
 		// Handle application deletion error
 		m.statusService.Set(fmt.Sprintf("Failed to delete %s: %s", msg.AppName, msg.Error))
+		m.recordError("delete", msg.AppName+": "+msg.Error, "", nil)
 		m.state.Modals.DeleteError = &msg.Error
 		m.state.Modals.DeleteLoading = false
 		// Keep modal open to show error
@@ -988,6 +1464,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case model.ResourceDeleteErrorMsg:
 		// Handle resource deletion error
 		m.statusService.Set(fmt.Sprintf("Resource deletion failed: %s", msg.Error))
+		m.recordError("delete", msg.Error, "", nil)
 		m.state.Modals.ResourceDeleteError = &msg.Error
 		m.state.Modals.ResourceDeleteLoading = false
 		// Keep modal open to show error
@@ -1038,6 +1515,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case model.ResourceSyncErrorMsg:
 		// Handle resource sync error
 		m.statusService.Set(fmt.Sprintf("Resource sync failed: %s", msg.Error))
+		m.recordError("sync", msg.Error, "", nil)
 		m.state.Modals.ResourceSyncError = &msg.Error
 		m.state.Modals.ResourceSyncLoading = false
 		// Keep modal open to show error
@@ -1089,6 +1567,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.SwitchEpoch != m.switchEpoch {
 			return m, nil
 		}
+		m.recordError("action", msg.Target.Kind+"/"+msg.Target.Name+": "+msg.Error, "", nil)
 		st := m.state.Modals.ResourceAction
 		if st == nil || m.state.Mode != model.ModeResourceAction {
 			return m, nil
@@ -1105,6 +1584,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.statusService.Set(fmt.Sprintf("Ran %s on %s/%s", msg.Action, msg.Target.Kind, msg.Target.Name))
+		m.forgetRollout(msg.Target)
 		// Only tear down the modal if it still targets the same resource —
 		// the user may have closed the original modal and opened another one
 		// for a different resource before this completion arrived.
@@ -1123,16 +1603,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.SwitchEpoch != m.switchEpoch {
 			return m, nil
 		}
+		var compatCmd tea.Cmd
+		if msg.EndpointMissing {
+			msg.Error, compatCmd = m.markEndpointMissing(compat.ResourceActions)
+		}
+		m.recordError("action", msg.Target.Kind+"/"+msg.Target.Name+": "+msg.Error, "", nil)
 		st := m.state.Modals.ResourceAction
 		if st == nil || m.state.Mode != model.ModeResourceAction {
-			return m, nil
+			return m, compatCmd
 		}
 		if st.Target != msg.Target {
-			return m, nil
+			return m, compatCmd
 		}
 		st.Executing = false
 		st.Error = msg.Error
-		return m, nil
+		return m, compatCmd
 
 	case model.MultiSyncCompletedMsg:
 		// Gate by switch epoch
@@ -1142,6 +1627,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle multiple app sync completion
 		if msg.Success {
 			m.statusService.Set(fmt.Sprintf("Sync initiated for %d app(s)", msg.AppCount))
+			m.confirmWatchedOperations("sync")
+			for name, ok := range m.state.Selections.SelectedApps {
+				if ok {
+					m.rememberRequestedOperation("sync", name, nil)
+				}
+			}
 			if m.state.Modals.ConfirmSyncWatch && len(m.state.Selections.SelectedApps) > 1 {
 				// Snapshot selected names before clearing
 				sel := m.state.Selections.SelectedApps
@@ -1157,7 +1648,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cleanupTreeWatchers()
 					// Reset tree view for multi-app session
 					m.treeView = treeview.NewTreeView(0, 0)
-					m.treeView.ApplyTheme(currentPalette)
+					m.treeView.ApplyStyles(currentStyles)
 					m.treeNav.Reset() // Reset scroll position
 					m.state.SaveNavigationState()
 					m.state.Navigation.View = model.ViewTree
@@ -1210,6 +1701,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				refreshType = "Hard refresh"
 			}
 			m.statusService.Set(fmt.Sprintf("%s initiated for %s", refreshType, msg.AppName))
+			if m.reducedMotion() {
+				return m, nil
+			}
 
 			// Set flash state based on current view
 			if m.state.Navigation.View == model.ViewTree {
@@ -1238,6 +1732,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				refreshType = "Hard refresh"
 			}
 			m.statusService.Set(fmt.Sprintf("%s initiated for %d app(s)", refreshType, msg.AppCount))
+			if m.reducedMotion() {
+				return m, nil
+			}
 
 			// Set flash for all selected apps
 			if m.state.UI.RefreshFlashApps == nil {
@@ -1291,6 +1788,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Rollback Messages
 	case model.RollbackHistoryLoadedMsg:
 		// Initialize rollback state with deployment history
+		prev := m.state.Rollback
 		m.state.Rollback = &model.RollbackState{
 			AppName:         msg.AppName,
 			AppNamespace:    msg.AppNamespace,
@@ -1303,38 +1801,56 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Watch:           true,
 			DryRun:          false,
 		}
-
-		// Start loading metadata for the first visible chunk (up to 10)
-		var cmds []tea.Cmd
-		preload := min(10, len(msg.Rows))
-		for i := 0; i < preload; i++ {
-			cmds = append(cmds, m.loadRevisionMetadata(msg.AppName, i, msg.Rows[i].Revision, msg.AppNamespace))
+		// A reload (ctrl+r) keeps the row, filter and options that were picked
+		if prev != nil && prev.AppName == msg.AppName && !prev.Loading && prev.Mode == "list" {
+			rb := m.state.Rollback
+			rb.SelectedIdx = min(prev.SelectedIdx, max(0, len(msg.Rows)-1))
+			rb.Filter, rb.Filtering = prev.Filter, prev.Filtering
+			rb.Prune, rb.Watch, rb.DryRun = prev.Prune, prev.Watch, prev.DryRun
 		}
 
-		return m, tea.Batch(cmds...)
+		return m, m.loadRollbackMetadata()
 
 	case model.RollbackMetadataLoadedMsg:
 		// Update rollback row with loaded metadata
-		if m.state.Rollback != nil && msg.RowIndex < len(m.state.Rollback.Rows) {
-			row := &m.state.Rollback.Rows[msg.RowIndex]
+		rb := m.state.Rollback
+		if rb == nil || rb.AppName != msg.AppName {
+			return m, nil
+		}
+		rb.MetaPending = max(0, rb.MetaPending-1)
+		if msg.RowIndex < len(rb.Rows) {
+			row := &rb.Rows[msg.RowIndex]
 			row.Author = &msg.Metadata.Author
 			row.Date = &msg.Metadata.Date
 			row.Message = &msg.Metadata.Message
 		}
-		return m, nil
+		if rb.Filter != "" {
+			m.applyRollbackFilter()
+		}
+		return m, m.loadRollbackMetadata()
 
 	case model.RollbackMetadataErrorMsg:
 		// Handle metadata loading error
-		if m.state.Rollback != nil && msg.RowIndex < len(m.state.Rollback.Rows) {
-			row := &m.state.Rollback.Rows[msg.RowIndex]
+		rb := m.state.Rollback
+		if rb == nil || rb.AppName != msg.AppName {
+			return m, nil
+		}
+		rb.MetaPending = max(0, rb.MetaPending-1)
+		if msg.RowIndex < len(rb.Rows) {
+			row := &rb.Rows[msg.RowIndex]
 			row.MetaError = &msg.Error
 		}
-		return m, nil
+		if rb.Filter != "" {
+			m.applyRollbackFilter()
+		}
+		return m, m.loadRollbackMetadata()
 
 	case model.RollbackExecutedMsg:
 		// Handle rollback completion
 		if msg.Success {
 			m.statusService.Set(fmt.Sprintf("Rollback initiated for %s", msg.AppName))
+			m.confirmWatchedOperations("rollback", appKey(msg.AppName, msg.AppNamespace))
+			m.rememberRequestedOperation("rollback", msg.AppName, msg.AppNamespace)
 
 			// Clear rollback state and return to normal mode
 			m.state.Rollback = nil
@@ -1347,7 +1863,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cleanupTreeWatchers()
 				// Reset tree view for fresh single-app session
 				m.treeView = treeview.NewTreeView(0, 0)
-				m.treeView.ApplyTheme(currentPalette)
+				m.treeView.ApplyStyles(currentStyles)
 				m.treeView.SetSize(m.contentInnerWidth(), m.state.Terminal.Rows)
 				m.treeNav.Reset() // Reset scroll position
 				// Use namespace from message to avoid ambiguity when multiple apps share a name
@@ -1366,6 +1882,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			m.statusService.Error(fmt.Sprintf("Rollback failed for %s", msg.AppName))
+			m.forgetWatchedOperation(msg.AppName, msg.AppNamespace)
 		}
 		return m, nil
 
@@ -1390,7 +1907,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Load metadata for newly selected row if not loaded
 					row := m.state.Rollback.Rows[m.state.Rollback.SelectedIdx]
 					if row.Author == nil && row.MetaError == nil {
-						return m, m.loadRevisionMetadata(m.state.Rollback.AppName, m.state.Rollback.SelectedIdx, row.Revision, m.state.Rollback.AppNamespace)
+						return m, m.loadRevisionMetadata(m.state.Rollback.AppName, m.state.Rollback.SelectedIdx, row, m.state.Rollback.AppNamespace)
 					}
 				}
 			case "down":
@@ -1400,7 +1917,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					row := m.state.Rollback.Rows[m.state.Rollback.SelectedIdx]
 					var cmds []tea.Cmd
 					if row.Author == nil && row.MetaError == nil {
-						cmds = append(cmds, m.loadRevisionMetadata(m.state.Rollback.AppName, m.state.Rollback.SelectedIdx, row.Revision, m.state.Rollback.AppNamespace))
+						cmds = append(cmds, m.loadRevisionMetadata(m.state.Rollback.AppName, m.state.Rollback.SelectedIdx, row, m.state.Rollback.AppNamespace))
 					}
 					// Opportunistically preload the next two rows' metadata to reduce "loading" gaps
 					for j := 1; j <= 2; j++ {
@@ -1408,7 +1925,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if idx < len(m.state.Rollback.Rows) {
 							r := m.state.Rollback.Rows[idx]
 							if r.Author == nil && r.MetaError == nil {
-								cmds = append(cmds, m.loadRevisionMetadata(m.state.Rollback.AppName, idx, r.Revision, m.state.Rollback.AppNamespace))
+								cmds = append(cmds, m.loadRevisionMetadata(m.state.Rollback.AppName, idx, r, m.state.Rollback.AppNamespace))
 							}
 						}
 					}
@@ -1467,13 +1984,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				"msg_epoch", msg.SwitchEpoch, "current_epoch", m.switchEpoch)
 			return m, nil
 		}
+		m.tlsFailure = msg.CertError
 		return m, func() tea.Msg { return model.SetModeMsg{Mode: msg.Mode} }
 
 	case model.ContextSwitchResultMsg:
 		return m.handleContextSwitchResult(msg)
 
 	case model.QuitMsg:
-		return m, tea.Quit
+		return m, m.handleQuit(msg)
 
 	case model.SetInitialLoadingMsg:
 		cblog.With("component", "model").Info("SetInitialLoadingMsg received", "loading", msg.Loading)
@@ -1501,7 +2019,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if msg.UpdateInfo.Available {
 				// Set notification timestamp for new notifications
 				if isNewNotification && msg.UpdateInfo.NotificationShownAt == nil {
-					now := time.Now()
+					now := clockNow()
 					msg.UpdateInfo.NotificationShownAt = &now
 					m.state.UI.UpdateInfo = msg.UpdateInfo
 				}
@@ -1555,16 +2073,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m *Model) applyBatchAppUpdate(upd model.AppUpdatedMsg) {
 	found := false
+	var prev model.App
+	key := appKey(upd.App.Name, upd.App.AppNamespace)
 	if idx := m.state.Index; idx != nil {
-		if i, ok := idx.NameToIndex[upd.App.Name]; ok && i < len(m.state.Apps) && m.state.Apps[i].Name == upd.App.Name {
+		if i, ok := idx.NameToIndex[upd.App.Name]; ok && i < len(m.state.Apps) && appKey(m.state.Apps[i].Name, m.state.Apps[i].AppNamespace) == key {
+			prev = m.state.Apps[i]
 			m.state.Apps[i] = upd.App
 			found = true
 		}
 	}
 	if !found {
-		// Fallback to linear scan (index may be stale during in-batch mutations)
+		// Fallback to linear scan (index may be stale during in-batch mutations,
+		// and names repeat across app namespaces)
 		for i, a := range m.state.Apps {
-			if a.Name == upd.App.Name {
+			if appKey(a.Name, a.AppNamespace) == key {
+				prev = a
 				m.state.Apps[i] = upd.App
 				found = true
 				break
@@ -1573,7 +2096,14 @@ func (m *Model) applyBatchAppUpdate(upd model.AppUpdatedMsg) {
 	}
 	if !found {
 		m.state.Apps = append(m.state.Apps, upd.App)
+		m.recordStreamDelta(upd.StreamSeq, "added")
+	} else if upd.StreamSeq != 0 {
+		m.recordStreamDelta(upd.StreamSeq, appDelta(prev, upd.App))
 	}
+	// Any change to the app can change its live objects, and with them
+	// the diff
+	m.prefetch.invalidate(key)
+	m.diffCache.invalidate(key)
 	// Update tree view sync statuses
 	if m.treeView != nil && m.state.Navigation.View == model.ViewTree && len(upd.ResourcesJSON) > 0 {
 		var resources []api.ResourceStatus
@@ -1626,6 +2156,17 @@ func (m *Model) resolveActionRefreshApp(msg model.ResourceActionExecutedMsg) *mo
 	return &model.App{Name: msg.AppName, AppNamespace: msg.Target.AppNamespace}
 }
 
+// applyBatchAppRemove removes the app with the given appKey
+func (m *Model) applyBatchAppRemove(key string) bool {
+	for i, a := range m.state.Apps {
+		if appKey(a.Name, a.AppNamespace) == key {
+			m.state.Apps = append(m.state.Apps[:i], m.state.Apps[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) applyBatchAppDelete(name string) bool {
 	if name == "" {
 		return false
@@ -47,6 +47,7 @@ func NewModel(cfg *config.ArgonautConfig) *Model {
 	})
 
 	state := model.NewAppState()
+	state.UI.DetailsPane = cfg.Layout.DetailsPane
 
 	// Apply default view from config
 	var pendingDefaultViewScope *defaultViewScope
@@ -57,6 +58,9 @@ func NewModel(cfg *config.ArgonautConfig) *Model {
 		state.Navigation.View = model.View(view)
 		if scopeType != "" && scopeValue != "" {
 			switch scopeType {
+			case "app":
+				// The tree opens once apps are loaded; start from the apps list
+				state.Navigation.View = model.ViewApps
 			case "cluster":
 				state.Selections.ScopeClusters = model.StringSetFromSlice([]string{scopeValue})
 			case "namespace":
@@ -97,25 +101,28 @@ func NewModel(cfg *config.ArgonautConfig) *Model {
 		rollbackNav:             listnav.New(),
 		selection:               selection.New(),
 		pendingDefaultViewScope: pendingDefaultViewScope,
+		prefetch:                newAppPrefetcher(),
+		diffCache:               newDiffCache(),
 	}
 }
 
 // defaultViewScope holds pending scope validation info from default_view config.
 // Validated after apps are loaded to check if the scoped entity actually exists.
 type defaultViewScope struct {
-	scopeType  string // "cluster", "namespace", "project", or "appset"
+	scopeType  string // "cluster", "namespace", "project", "appset", or "app" (tree)
 	scopeValue string
 }
 
 // validateDefaultViewScope checks if the scoped entity from default_view exists
 // in the loaded app data. If not, sets a warning and resets navigation to defaults.
-func (m *Model) validateDefaultViewScope() {
+// For a tree default_view it opens the app's resource tree and returns the load command.
+func (m *Model) validateDefaultViewScope() tea.Cmd {
 	if m.pendingDefaultViewScope == nil {
-		return
+		return nil
 	}
 
 	if m.state.Index == nil {
-		return // Index not yet built; keep pendingDefaultViewScope for next call
+		return nil // Index not yet built; keep pendingDefaultViewScope for next call
 	}
 
 	scope := m.pendingDefaultViewScope
@@ -156,6 +163,17 @@ func (m *Model) validateDefaultViewScope() {
 				break
 			}
 		}
+	case "app":
+		label = "Application"
+		// Accept namespace/app to pick between same-named apps
+		appNamespace, appName, found := strings.Cut(scope.scopeValue, "/")
+		if !found {
+			appName, appNamespace = scope.scopeValue, ""
+		}
+		if m.findAppByNameAndNamespace(appName, appNamespace) != nil {
+			_, cmd := m.handleNavigateToChildApp(appName, appNamespace)
+			return cmd
+		}
 	}
 
 	if !exists {
@@ -166,6 +184,7 @@ func (m *Model) validateDefaultViewScope() {
 		m.state.Navigation.SelectedIdx = 0
 		m.state.Selections = *model.NewSelectionState()
 	}
+	return nil
 }
 
 // preserve imports used by other files in this package
@@ -176,7 +195,7 @@ var _ tea.Msg
 func (m *Model) Init() tea.Cmd {
 	// Initialize with terminal size request and startup commands
 	var cmds []tea.Cmd
-	cmds = append(cmds, m.spinner.Tick)
+	cmds = append(cmds, m.spinnerTick())
 
 	// Configure clipboard from config
 	if copyCmd := m.config.GetClipboardCopyCommand(); copyCmd != "" {
@@ -189,6 +208,8 @@ func (m *Model) Init() tea.Cmd {
 	// Show initial loading modal immediately if server is configured
 	if m.state.Server != nil {
 		cmds = append(cmds, func() tea.Msg { return model.SetInitialLoadingMsg{Loading: true} })
+		// Render the last-known app list while the live one loads
+		cmds = append(cmds, m.loadCachedApps())
 	}
 
 	cmds = append(cmds,
@@ -196,6 +217,12 @@ func (m *Model) Init() tea.Cmd {
 		m.validateAuthentication(),
 		// Start periodic update check (delayed)
 		m.scheduleInitialUpdateCheck(),
+		m.statusClockTick(),
+		m.operationTick(),
+		// Notice when the machine wakes from sleep with dead streams
+		m.scheduleResumeCheck(),
+		// Release the cached data of apps long out of scope
+		m.pruneTick(),
 	)
 
 	_ = context.TODO() // keep import stable if unused on some builds
@@ -226,6 +253,11 @@ func (m *Model) validateAuthentication() tea.Cmd {
 		if err := appService.GetUserInfo(ctx); err != nil {
 			cblog.With("component", "auth").Error("Authentication validation failed", "err", err)
 
+			// A certificate that fails verification can be continued past
+			if certErr, ok := api.CertificateError(err); ok {
+				return model.AuthValidationResultMsg{Mode: model.ModeConnectionError, SwitchEpoch: epoch, CertError: certErr}
+			}
+
 			// Check if this is a connection error rather than authentication error
 			errStr := err.Error()
 			if strings.Contains(errStr, "connection refused") ||
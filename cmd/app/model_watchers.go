@@ -21,6 +21,7 @@ func (m *Model) cleanupTreeWatchers() *Model {
 		}
 	}
 	m.treeWatchCleanups = nil
+	m.treeStreams = nil
 	if m.treeStreamDone != nil {
 		close(m.treeStreamDone)
 		m.treeStreamDone = make(chan struct{})
@@ -32,6 +33,7 @@ func (m *Model) cleanupTreeWatchers() *Model {
 func (m *Model) safeChangeView(newView model.View) *Model {
 	if m.state.Navigation.View == model.ViewTree && newView != model.ViewTree {
 		m = m.cleanupTreeWatchers()
+		m.treeFollow = false
 	}
 	m.state.Navigation.View = newView
 	// Reset list navigator when changing views
@@ -68,20 +68,11 @@ func (m *Model) getNavigatorContext() *NavigatorContext {
 		}
 
 	case model.ModeRollback:
-		if m.state.Rollback == nil || m.state.Rollback.Loading {
+		// While the filter has focus j, k, g and G are typed into it
+		if m.state.Rollback == nil || m.state.Rollback.Loading || m.state.Rollback.Filtering {
 			return &NavigatorContext{SupportsNavigation: false}
 		}
-		return &NavigatorContext{
-			Navigator:         m.rollbackNav,
-			GetItemCount:      func() int { return len(m.state.Rollback.Rows) },
-			GetViewportHeight: m.rollbackPageSize,
-			OnNavigate: func(changed bool) {
-				if changed {
-					m.state.Rollback.SelectedIdx = m.rollbackNav.Cursor()
-				}
-			},
-			SupportsNavigation: true,
-		}
+		return m.rollbackNavigatorContext()
 
 	case model.ModeDiff:
 		if m.state.Diff == nil {
@@ -106,6 +97,8 @@ func (m *Model) getNavigatorContext() *NavigatorContext {
 				OnNavigate: func(changed bool) {
 					if changed {
 						m.treeView.SetSelectedIndex(m.treeNav.Cursor())
+						// Moving the cursor by hand stops following
+						m.treeFollow = false
 					}
 				},
 				SupportsNavigation: true,
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// Widths of the OPERATION and DURATION columns; "Terminating" is the
+// longest phase, and the duration header takes the sort indicator too
+const (
+	operationPhaseWidth    = 11
+	operationDurationWidth = 9
+)
+
+// operationTickMsg redraws the durations of running operations
+type operationTickMsg struct{}
+
+// operationColumnsEnabled reports whether the apps list shows the phase and
+// duration of each app's last operation
+func (m *Model) operationColumnsEnabled() bool {
+	return m.config != nil && m.config.Appearance.OperationColumns
+}
+
+// operationTick schedules a redraw in a second so running operations count
+// up. Returns nil when the columns are off, with reduced motion (durations
+// then change with the data) and in deterministic mode.
+func (m *Model) operationTick() tea.Cmd {
+	if !m.operationColumnsEnabled() || m.reducedMotion() || deterministicMode {
+		return nil
+	}
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return operationTickMsg{} })
+}
+
+// splitOperationColumns carves the OPERATION and DURATION columns out of the
+// name column when they are enabled and the name column is wide enough to
+// share; opWidth is 0 otherwise
+func (m *Model) splitOperationColumns(nameWidth int) (rest, opWidth int) {
+	opWidth = operationPhaseWidth + 1 + operationDurationWidth
+	if !m.operationColumnsEnabled() || m.state.UI.WideNames || nameWidth < 30+opWidth {
+		return nameWidth, 0
+	}
+	return nameWidth - opWidth - 1, opWidth
+}
+
+// formatOperationDuration renders a duration in at most 8 cells, with the
+// two largest units: 45s, 12m03s, 3h05m, 2d04h
+func formatOperationDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+}
+
+// renderOperationHeader renders the OPERATION and DURATION headers, with
+// the sort indicator on the active one
+func (m *Model) renderOperationHeader(style lipgloss.Style) string {
+	phase, took := "OPERATION", "DURATION"
+	switch m.state.UI.Sort.Field {
+	case model.SortFieldOperation:
+		phase = m.state.UI.Sort.Direction.Indicator() + phase
+	case model.SortFieldDuration:
+		took = m.state.UI.Sort.Direction.Indicator() + took
+	}
+	return padRight(style.Render(phase), operationPhaseWidth) + " " +
+		padLeft(style.Render(took), operationDurationWidth)
+}
+
+// renderOperationCells renders an app's last operation phase and duration;
+// colors are left out on active rows so the highlight spans the row
+func (m *Model) renderOperationCells(app model.App, active bool) string {
+	phase, took := app.OperationPhase, ""
+	if d, ok := app.OperationDuration(clockNow()); ok {
+		took = formatOperationDuration(d)
+	}
+	if !active && phase != "" {
+		color := dimColor
+		switch phase {
+		case "Running", "Terminating":
+			color = progressColor
+		case "Failed", "Error":
+			color = outOfSyncColor
+		}
+		phase = lipgloss.NewStyle().Foreground(color).Render(phase)
+		took = lipgloss.NewStyle().Foreground(color).Render(took)
+	}
+	return padRight(phase, operationPhaseWidth) + " " + padLeft(took, operationDurationWidth)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestOperationColumns_ShowPhaseAndDuration(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(140, 30)
+	m.config = &config.ArgonautConfig{}
+	if header := stripANSI(m.renderListHeader()); strings.Contains(header, "OPERATION") {
+		t.Fatalf("columns should be off unless configured: %q", header)
+	}
+
+	m.config.Appearance.OperationColumns = true
+	m.state.UI.Sort = model.SortConfig{Field: model.SortFieldDuration, Direction: model.SortDesc}
+	header := stripANSI(m.renderListHeader())
+	if !strings.Contains(header, "OPERATION") || !strings.Contains(header, "▼DURATION") {
+		t.Fatalf("header should show the columns with the sort indicator: %q", header)
+	}
+
+	started := frozenClock.Add(-(12*time.Minute + 3*time.Second))
+	m.state.Apps[0].OperationPhase = "Running"
+	m.state.Apps[0].OperationStartedAt = &started
+	if row := strings.Join(strings.Fields(stripANSI(m.renderAppRow(m.state.Apps[0], false))), " "); !strings.Contains(row, "test-app Running 12m03s V Synced") {
+		t.Errorf("a running operation should count from its start: %q", row)
+	}
+
+	finished := started.Add(90 * time.Minute)
+	m.state.Apps[0].OperationPhase = "Failed"
+	m.state.Apps[0].LastSyncAt = &finished
+	if row := strings.Join(strings.Fields(stripANSI(m.renderAppRow(m.state.Apps[0], true))), " "); !strings.Contains(row, "test-app Failed 1h30m V Synced") {
+		t.Errorf("a finished operation should show how long it took: %q", row)
+	}
+	if row := stripANSI(m.renderAppRow(m.state.Apps[1], false)); strings.Contains(row, "0s") {
+		t.Errorf("an app without an operation should leave the columns empty: %q", row)
+	}
+
+	if header, row := stripANSI(m.renderListHeader()), stripANSI(m.renderAppRow(m.state.Apps[0], false)); len([]rune(header)) != len([]rune(row)) {
+		t.Errorf("header and rows should line up:\n%q\n%q", header, row)
+	}
+}
+
+func TestFormatOperationDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		45 * time.Second:                "45s",
+		12*time.Minute + 3*time.Second:  "12m03s",
+		3*time.Hour + 5*time.Minute:     "3h05m",
+		52*time.Hour + 20*time.Minute:   "2d04h",
+		59*time.Minute + 59*time.Second: "59m59s",
+	} {
+		if got := formatOperationDuration(d); got != want {
+			t.Errorf("formatOperationDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
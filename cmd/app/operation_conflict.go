@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// isOperationConflict reports whether Argo CD refused a sync or rollback
+// because another operation is already running on the app
+func isOperationConflict(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "another operation is already in progress")
+}
+
+// handleOperationConflict closes the sync or rollback modal that was
+// waiting for the refused request and offers what can be done about the
+// operation in the way instead
+func (m *Model) handleOperationConflict(msg model.OperationConflictMsg) tea.Cmd {
+	m.recordError(msg.Operation, fmt.Sprintf("%s: another operation is already in progress", msg.AppName), msg.Message, nil)
+	m.forgetWatchedOperation(msg.AppName, msg.AppNamespace)
+
+	m.state.Modals.ConfirmSyncLoading = false
+	m.state.Modals.ConfirmTarget = nil
+	m.state.Modals.ConfirmTargetNamespace = nil
+	m.state.Rollback = nil
+	m.state.Modals.RollbackAppName = nil
+
+	m.state.Modals.OperationConflict = &model.OperationConflictState{
+		AppName:      msg.AppName,
+		AppNamespace: msg.AppNamespace,
+		Operation:    msg.Operation,
+		Message:      msg.Message,
+	}
+	m.state.Mode = model.ModeOperationConflict
+	return nil
+}
+
+// handleOperationConflictKeys handles input in the conflict dialog
+func (m *Model) handleOperationConflictKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.OperationConflict
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	if st.Terminating {
+		return m, nil
+	}
+	if st.ConfirmTerminate {
+		return m.handleConfirmTerminateKeys(msg)
+	}
+	switch msg.String() {
+	case "v":
+		m.closeOperationConflict()
+		return m, m.openAppDetails(st.AppName, st.AppNamespace)
+	case "w":
+		m.closeOperationConflict()
+		return m, m.startWait(st.AppName, st.AppNamespace, []string{model.WaitOperation}, waitDefaultTimeout)
+	case "t":
+		st.ConfirmTerminate = true
+		st.ConfirmSelected = 0
+	case "q", "esc":
+		m.closeOperationConflict()
+	}
+	return m, nil
+}
+
+// handleConfirmTerminateKeys asks before terminating the operation, with
+// the same keys as the other confirmations; cancelling goes back to the
+// conflict dialog
+func (m *Model) handleConfirmTerminateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.OperationConflict
+	switch msg.String() {
+	case "left", "h":
+		st.ConfirmSelected = 0
+		return m, nil
+	case "right", "l":
+		st.ConfirmSelected = 1
+		return m, nil
+	case "enter":
+		if st.ConfirmSelected == 1 {
+			st.ConfirmTerminate = false
+			return m, nil
+		}
+	case "y":
+	case "n", "q", "esc":
+		st.ConfirmTerminate = false
+		return m, nil
+	default:
+		return m, nil
+	}
+	st.ConfirmTerminate = false
+	st.Terminating = true
+	st.Error = ""
+	return m, m.terminateOperation(st.AppName, st.AppNamespace)
+}
+
+func (m *Model) closeOperationConflict() {
+	m.state.Modals.OperationConflict = nil
+	m.state.Mode = model.ModeNormal
+}
+
+// terminateOperation stops the operation running on the app
+func (m *Model) terminateOperation(appName string, appNamespace *string) tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg {
+			return model.ApiErrorMsg{Message: "No server configured"}
+		}
+	}
+
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+
+		cblog.With("component", "api").Info("Terminating operation", "app", appName)
+		if err := api.NewApplicationService(server).TerminateOperation(ctx, appName, appNamespace); err != nil {
+			cblog.With("component", "api").Error("Terminate failed", "app", appName, "err", err)
+			return model.OperationTerminatedMsg{AppName: appName, AppNamespace: appNamespace, Error: extractUserFriendlyError(err), SwitchEpoch: epoch}
+		}
+		return model.OperationTerminatedMsg{AppName: appName, AppNamespace: appNamespace, SwitchEpoch: epoch}
+	}
+}
+
+// handleOperationTerminated closes the conflict dialog once the operation
+// is terminated, or keeps it open with the error so it can be retried
+func (m *Model) handleOperationTerminated(msg model.OperationTerminatedMsg) tea.Cmd {
+	st := m.state.Modals.OperationConflict
+	if msg.Error != "" {
+		m.recordError("terminate", fmt.Sprintf("Failed to terminate operation of %s: %s", msg.AppName, msg.Error), "", nil)
+	}
+	if st == nil || st.AppName != msg.AppName || derefOr(st.AppNamespace) != derefOr(msg.AppNamespace) {
+		return nil
+	}
+	st.Terminating = false
+	if msg.Error != "" {
+		st.Error = msg.Error
+		return nil
+	}
+	m.closeOperationConflict()
+	return m.showStatusNote(fmt.Sprintf("Terminated the running operation of %s", msg.AppName))
+}
+
+// renderOperationConflictModal renders the conflict dialog: the operation
+// in the way, as far as the watch stream knows it, and what to do about it
+func (m *Model) renderOperationConflictModal() string {
+	st := m.state.Modals.OperationConflict
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(50, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	bad := lipgloss.NewStyle().Foreground(outOfSyncColor)
+	titleStyle := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
+
+	title := fmt.Sprintf("Cannot %s %s", st.Operation, st.AppName)
+	lines := []string{
+		titleStyle.Render(truncateWithEllipsis(title, innerWidth)),
+		"",
+		lipgloss.NewStyle().Width(innerWidth).Render("Another operation is already in progress on this app."),
+	}
+
+	if app := m.findAppByNameAndNamespace(st.AppName, derefOr(st.AppNamespace)); app != nil && app.OperationPhase != "" {
+		running := "Operation: " + app.OperationPhase
+		if app.OperationStartedAt != nil {
+			running += fmt.Sprintf(", started %s ago", clockNow().Sub(*app.OperationStartedAt).Round(time.Second))
+		}
+		if app.LastOperationBy != nil {
+			if by := app.LastOperationBy.String(); by != "" {
+				running += " by " + by
+			}
+		}
+		lines = append(lines, "", truncateWithEllipsis(running, innerWidth))
+		if app.OperationMessage != "" {
+			lines = append(lines, dim.Width(innerWidth).Render(app.OperationMessage))
+		}
+	}
+
+	if st.Error != "" {
+		lines = append(lines, "", bad.Width(innerWidth).Render("Terminate failed: "+st.Error))
+	}
+
+	switch {
+	case st.ConfirmTerminate:
+		lines = append(lines, "", m.renderConfirmTerminate(st, innerWidth))
+	case st.Terminating:
+		lines = append(lines, "", dim.Render("Terminating…"))
+	default:
+		lines = append(lines, "", dim.Width(innerWidth).Render("v view operation • w wait for it • t terminate it • Esc close"))
+	}
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(outOfSyncColor).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
+
+// renderConfirmTerminate renders the question and buttons that confirm
+// terminating the operation, styled like the delete confirmations
+func (m *Model) renderConfirmTerminate(st *model.OperationConflictState, innerWidth int) string {
+	inactiveFG := ensureContrastingForeground(inactiveBG, whiteBright)
+	active := lipgloss.NewStyle().Background(outOfSyncColor).Foreground(textOnDanger).Bold(true).Padding(0, 2)
+	inactive := lipgloss.NewStyle().Background(inactiveBG).Foreground(inactiveFG).Padding(0, 2)
+
+	terminateBtn, cancelBtn := active.Render("Terminate"), inactive.Render("Cancel")
+	if st.ConfirmSelected == 1 {
+		terminateBtn, cancelBtn = inactive.Render("Terminate"), active.Render("Cancel")
+	}
+	center := lipgloss.NewStyle().Width(innerWidth).Align(lipgloss.Center)
+	question := lipgloss.NewStyle().Foreground(whiteBright).Render("Terminate the running operation of ") +
+		lipgloss.NewStyle().Foreground(whiteBright).Bold(true).Render(st.AppName) +
+		lipgloss.NewStyle().Foreground(whiteBright).Render("?")
+	hint := lipgloss.NewStyle().Foreground(dimColor).Render("y terminate • Esc back")
+	return strings.Join([]string{
+		center.Render(question),
+		"",
+		center.Render(terminateBtn + "  " + cancelBtn),
+		center.Render(hint),
+	}, "\n")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestIsOperationConflict(t *testing.T) {
+	if !isOperationConflict(errors.New("rpc error: code = FailedPrecondition desc = another operation is already in progress")) {
+		t.Error("expected the Argo CD conflict message to be recognised")
+	}
+	if isOperationConflict(errors.New("permission denied")) || isOperationConflict(nil) {
+		t.Error("other errors are not conflicts")
+	}
+}
+
+func TestOperationConflict_ReplacesSyncModal(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].OperationPhase = "Running"
+	m.state.Apps[0].LastOperationBy = &model.OperationInitiator{Username: "alice"}
+	m.state.Mode = model.ModeConfirmSync
+	m.state.Modals.ConfirmSyncLoading = true
+	m.state.Modals.ConfirmTarget = strp("test-app")
+
+	m.Update(model.OperationConflictMsg{AppName: "test-app", AppNamespace: strp("test-namespace"), Operation: "sync"})
+
+	if m.state.Mode != model.ModeOperationConflict || m.state.Modals.ConfirmSyncLoading {
+		t.Fatalf("expected the conflict dialog instead of the sync modal, mode %s", m.state.Mode)
+	}
+	modal := stripANSI(m.renderOperationConflictModal())
+	for _, want := range []string{"Cannot sync test-app", "Running", "alice", "terminate"} {
+		if !strings.Contains(modal, want) {
+			t.Errorf("dialog should show %q, got:\n%s", want, modal)
+		}
+	}
+	if len(m.state.RecentErrors) != 1 {
+		t.Errorf("the conflict should be kept in the errors drawer, got %d entries", len(m.state.RecentErrors))
+	}
+}
+
+func TestOperationConflict_Keys(t *testing.T) {
+	open := func() *Model {
+		m := buildDeleteTestModel(120, 30)
+		m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+		m.handleOperationConflict(model.OperationConflictMsg{AppName: "test-app", AppNamespace: strp("test-namespace"), Operation: "rollback"})
+		return m
+	}
+
+	m := open()
+	m.handleOperationConflictKeys(keyPress("w"))
+	if m.state.Mode != model.ModeWait || m.state.Modals.Wait == nil || m.state.Modals.Wait.Conditions[0] != model.WaitOperation {
+		t.Errorf("w should wait for the operation, mode %s", m.state.Mode)
+	}
+
+	m = open()
+	m.handleOperationConflictKeys(keyPress("v"))
+	if m.state.Mode != model.ModeAppDetails {
+		t.Errorf("v should open the app details, mode %s", m.state.Mode)
+	}
+
+	m = open()
+	if _, cmd := m.handleOperationConflictKeys(keyPress("t")); cmd != nil || !m.state.Modals.OperationConflict.ConfirmTerminate {
+		t.Fatal("t should ask before terminating the operation")
+	}
+	if modal := stripANSI(m.renderOperationConflictModal()); !strings.Contains(modal, "Terminate the running operation of test-app?") {
+		t.Errorf("the confirmation should name the app, got:\n%s", modal)
+	}
+	m.handleOperationConflictKeys(keyPress("esc"))
+	if st := m.state.Modals.OperationConflict; st == nil || st.ConfirmTerminate || m.state.Mode != model.ModeOperationConflict {
+		t.Fatal("esc should go back to the conflict dialog")
+	}
+	m.handleOperationConflictKeys(keyPress("t"))
+	m.handleOperationConflictKeys(keyPress("right"))
+	if _, cmd := m.handleOperationConflictKeys(keyPress("enter")); cmd != nil || m.state.Modals.OperationConflict.Terminating {
+		t.Fatal("enter on Cancel should not terminate")
+	}
+	m.handleOperationConflictKeys(keyPress("t"))
+	if _, cmd := m.handleOperationConflictKeys(keyPress("y")); cmd == nil || !m.state.Modals.OperationConflict.Terminating {
+		t.Fatal("y should start terminating the operation")
+	}
+	m.handleOperationTerminated(model.OperationTerminatedMsg{AppName: "test-app", AppNamespace: strp("test-namespace"), Error: "forbidden"})
+	if st := m.state.Modals.OperationConflict; st == nil || st.Terminating || st.Error != "forbidden" {
+		t.Fatalf("a failed terminate should stay open with the error, got %+v", st)
+	}
+	m.handleOperationTerminated(model.OperationTerminatedMsg{AppName: "test-app", AppNamespace: strp("test-namespace")})
+	if m.state.Mode != model.ModeNormal || m.state.Modals.OperationConflict != nil {
+		t.Error("a terminated operation should close the dialog")
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// paneResizeStep is how much of the width, in percent, one resize key
+// press moves from one pane to the other
+const paneResizeStep = 5
+
+// layoutSaveDebounce is how long the layout must stay unchanged before it
+// is written, so holding a resize key writes the config once
+const layoutSaveDebounce = 500 * time.Millisecond
+
+// layoutSaveTickMsg fires once the layout has stayed unchanged
+type layoutSaveTickMsg struct {
+	seq int
+}
+
+// splitPaneEnabled reports whether the current view has a secondary pane
+// switched on. The apps list with the details of the selected app is the
+// only split layout so far.
+func (m *Model) splitPaneEnabled() bool {
+	return m.state.Navigation.View == model.ViewApps && m.state.UI.DetailsPane
+}
+
+// splitPaneVisible reports whether the secondary pane is shown: it is
+// collapsed while the terminal is narrower than layout.collapse_below
+func (m *Model) splitPaneVisible() bool {
+	return m.splitPaneEnabled() && m.state.Terminal.Cols >= m.config.GetCollapseBelow()
+}
+
+// splitRatio returns the share of the width in percent the list keeps in
+// the current view
+func (m *Model) splitRatio() int {
+	if ratio, ok := m.state.UI.SplitRatios[m.state.Navigation.View]; ok {
+		return ratio
+	}
+	return m.config.GetSplitRatio(string(m.state.Navigation.View))
+}
+
+// primaryPaneWidth returns the outer width of the list's bordered box,
+// the whole width inside the main container unless a pane is beside it
+func (m *Model) primaryPaneWidth() int {
+	total := m.screenWidth()
+	if !m.splitPaneVisible() {
+		return total
+	}
+	return total * m.splitRatio() / 100
+}
+
+// handleToggleDetailsPane shows or hides the details pane beside the apps list
+func (m *Model) handleToggleDetailsPane() (tea.Model, tea.Cmd) {
+	m.state.UI.DetailsPane = !m.state.UI.DetailsPane
+	note := "Details pane off"
+	if m.state.UI.DetailsPane {
+		note = "Details pane on • ctrl+←/→ to resize"
+		if !m.splitPaneVisible() {
+			note = fmt.Sprintf("Details pane shows from %d columns wide", m.config.GetCollapseBelow())
+		}
+	}
+	return m, tea.Batch(m.showStatusNote(note), m.saveLayout())
+}
+
+// handlePaneResize moves the border between the list and the secondary pane
+// by delta percent of the width
+func (m *Model) handlePaneResize(delta int) (tea.Model, tea.Cmd) {
+	if !m.splitPaneEnabled() {
+		return m, m.showStatusNote("No pane to resize • | shows the details pane")
+	}
+	if !m.splitPaneVisible() {
+		return m, m.showStatusNote(fmt.Sprintf("Details pane hidden below %d columns", m.config.GetCollapseBelow()))
+	}
+	ratio := min(max(m.splitRatio()+delta, config.MinSplitRatio), config.MaxSplitRatio)
+	if m.state.UI.SplitRatios == nil {
+		m.state.UI.SplitRatios = make(map[model.View]int)
+	}
+	m.state.UI.SplitRatios[m.state.Navigation.View] = ratio
+	return m, tea.Batch(m.showStatusNote(fmt.Sprintf("List %d%% • pane %d%%", ratio, 100-ratio)), m.saveLayout())
+}
+
+// saveLayout keeps the pane layout in the config, so it is restored on the
+// next start and after a context switch. The file is written once the
+// layout settles; each change restarts the wait.
+func (m *Model) saveLayout() tea.Cmd {
+	if m.config != nil {
+		m.config.Layout = m.layoutConfig()
+	}
+	m.layoutSaveSeq++
+	seq := m.layoutSaveSeq
+	return tea.Tick(debounceDelay(layoutSaveDebounce), func(time.Time) tea.Msg {
+		return layoutSaveTickMsg{seq: seq}
+	})
+}
+
+// handleLayoutSaveTick writes the layout if it has not changed since the
+// tick was scheduled
+func (m *Model) handleLayoutSaveTick(msg layoutSaveTickMsg) tea.Cmd {
+	if msg.seq != m.layoutSaveSeq {
+		return nil // changed again since
+	}
+	layout := m.layoutConfig()
+	return func() tea.Msg {
+		cfg, err := config.LoadArgonautConfig()
+		if err != nil {
+			cblog.With("component", "layout").Warn("Not saving pane layout", "err", err)
+			return nil
+		}
+		cfg.Layout = layout
+		if err := config.SaveArgonautConfig(cfg); err != nil {
+			cblog.With("component", "layout").Warn("Failed to save pane layout", "err", err)
+		}
+		return nil
+	}
+}
+
+// layoutConfig returns the pane layout as it is kept in the config
+func (m *Model) layoutConfig() config.LayoutConfig {
+	layout := config.LayoutConfig{DetailsPane: m.state.UI.DetailsPane}
+	if m.config != nil {
+		layout.CollapseBelow = m.config.Layout.CollapseBelow
+		layout.SplitRatios = maps.Clone(m.config.Layout.SplitRatios)
+	}
+	for view, ratio := range m.state.UI.SplitRatios {
+		if layout.SplitRatios == nil {
+			layout.SplitRatios = make(map[string]int)
+		}
+		layout.SplitRatios[string(view)] = ratio
+	}
+	return layout
+}
+
+// renderDetailsPane renders the details of the app under the cursor in a
+// bordered box as tall as the list beside it
+func (m *Model) renderDetailsPane(width, height int) string {
+	innerWidth := layout.Card.InnerWidth(width)
+	innerRows := layout.Card.InnerHeight(height)
+
+	var lines []string
+	items := m.getVisibleItems()
+	if idx := m.state.Navigation.SelectedIdx; idx < len(items) {
+		if app, ok := items[idx].(model.App); ok {
+			title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render(truncateWithEllipsis(app.Name, innerWidth))
+			lines = append(lines, title, "")
+			lines = append(lines, m.appDetailsLines(app, innerWidth)...)
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(dimColor).Render("No app selected"))
+	}
+	if len(lines) > innerRows {
+		lines = lines[:innerRows]
+	}
+	content := normalizeLinesToWidth(strings.Join(lines, "\n"), innerWidth)
+	return contentBorderStyle.Width(width).Height(height).AlignVertical(lipgloss.Top).Render(content)
+}
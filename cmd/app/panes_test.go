@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestDetailsPane_SplitsWidth(t *testing.T) {
+	m := buildDeleteTestModel(160, 30)
+	if got := m.contentInnerWidth(); got != 154 {
+		t.Fatalf("without the pane the list should use the full width, got %d", got)
+	}
+
+	m.Update(keyPress("|"))
+	if !m.state.UI.DetailsPane {
+		t.Fatal("| should switch the details pane on")
+	}
+	// 60% of the 158 columns inside the main container
+	if got := m.primaryPaneWidth(); got != 94 {
+		t.Fatalf("list width = %d, want 94", got)
+	}
+	if got := m.contentInnerWidth(); got != 90 {
+		t.Fatalf("list inner width = %d, want 90", got)
+	}
+
+	pane := stripANSI(m.renderDetailsPane(64, 20))
+	for _, want := range []string{"test-app", "test-project"} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("details pane should show %q:\n%s", want, pane)
+		}
+	}
+	for i, line := range strings.Split(stripANSI(m.renderMainLayout()), "\n") {
+		if w := lipgloss.Width(line); w > 160 {
+			t.Errorf("line %d is %d columns wide, wider than the terminal", i, w)
+		}
+	}
+}
+
+func TestDetailsPane_CollapsesWhenNarrow(t *testing.T) {
+	m := buildDeleteTestModel(100, 30)
+	m.state.UI.DetailsPane = true
+
+	if m.splitPaneVisible() {
+		t.Fatalf("pane should be hidden below %d columns", config.DefaultCollapseBelow)
+	}
+	if got := m.contentInnerWidth(); got != 94 {
+		t.Fatalf("list should keep the full width while the pane is hidden, got %d", got)
+	}
+	_, cmd := m.handlePaneResize(paneResizeStep)
+	if cmd == nil {
+		t.Fatal("resizing a hidden pane should explain why nothing happens")
+	}
+	if _, ok := m.state.UI.SplitRatios[model.ViewApps]; ok {
+		t.Fatal("resizing a hidden pane should not change the ratio")
+	}
+}
+
+func TestDetailsPane_ResizeClamps(t *testing.T) {
+	t.Setenv("ARGONAUT_CONFIG", t.TempDir()+"/config.toml")
+	m := buildDeleteTestModel(160, 30)
+	m.state.UI.DetailsPane = true
+
+	m.Update(keyPress("ctrl+left"))
+	if got := m.splitRatio(); got != config.DefaultSplitRatio-paneResizeStep {
+		t.Fatalf("ctrl+left should narrow the list, ratio = %d", got)
+	}
+	for range 20 {
+		m.Update(keyPress(">"))
+	}
+	if got := m.splitRatio(); got != config.MaxSplitRatio {
+		t.Fatalf("ratio should stop at %d, got %d", config.MaxSplitRatio, got)
+	}
+	for range 20 {
+		m.Update(keyPress("<"))
+	}
+	if got := m.splitRatio(); got != config.MinSplitRatio {
+		t.Fatalf("ratio should stop at %d, got %d", config.MinSplitRatio, got)
+	}
+}
+
+func TestDetailsPane_SavesLayout(t *testing.T) {
+	t.Setenv("ARGONAUT_CONFIG", t.TempDir()+"/config.toml")
+	m := buildDeleteTestModel(160, 30)
+	m.state.UI.DetailsPane = true
+	m.state.UI.SplitRatios = map[model.View]int{model.ViewApps: 45}
+
+	m.saveLayout()
+	stale := layoutSaveTickMsg{seq: m.layoutSaveSeq}
+	m.saveLayout()
+	if cmd := m.handleLayoutSaveTick(stale); cmd != nil {
+		t.Fatal("a layout changed again since should not be written yet")
+	}
+	if msg := m.handleLayoutSaveTick(layoutSaveTickMsg{seq: m.layoutSaveSeq})(); msg != nil {
+		t.Fatalf("saving the layout should not produce a message, got %T", msg)
+	}
+	cfg, err := config.LoadArgonautConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Layout.DetailsPane || cfg.GetSplitRatio("apps") != 45 {
+		t.Fatalf("layout not saved: %+v", cfg.Layout)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	apperrors "github.com/darksworm/argonaut/pkg/errors"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// parseParamArgs parses the arguments of ":param [source] name=value...".
+// source is the 1-based source position, 0 when not given.
+func parseParamArgs(args []string) (source int, params []api.HelmParameter, err error) {
+	if len(args) > 0 && !strings.Contains(args[0], "=") {
+		n, convErr := strconv.Atoi(args[0])
+		if convErr != nil || n < 1 {
+			return 0, nil, fmt.Errorf("invalid source %q (use its number from app details)", args[0])
+		}
+		source, args = n, args[1:]
+	}
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return 0, nil, fmt.Errorf("expected name=value, got %q", arg)
+		}
+		params = append(params, api.HelmParameter{Name: name, Value: value})
+	}
+	if len(params) == 0 {
+		return 0, nil, fmt.Errorf("no parameters given (use name=value)")
+	}
+	return source, params, nil
+}
+
+// handleParamCommand runs ":param" on the app open in the tree view, or the
+// app under the cursor. Multi-source apps need the source to override, as
+// each source has its own Helm parameters.
+func (m *Model) handleParamCommand(args []string) (tea.Model, tea.Cmd) {
+	source, params, err := parseParamArgs(args)
+	if err != nil {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "param: " + err.Error()} }
+	}
+
+	var app model.App
+	if tree := m.state.UI.TreeApp; m.state.Navigation.View == model.ViewTree && tree != nil {
+		app = model.App{Name: tree.Name, AppNamespace: tree.AppNamespace}
+		if found := m.findAppByNameAndNamespace(tree.Name, derefOr(tree.AppNamespace)); found != nil {
+			app = *found
+		}
+	} else if cur, ok := m.cursorApp(); ok {
+		app = cur
+	} else {
+		return m, func() tea.Msg {
+			return model.StatusChangeMsg{Status: "Navigate to apps view first to select an app to set parameters on"}
+		}
+	}
+
+	if app.MultiSource {
+		if source == 0 {
+			labels := make([]string, 0, len(app.Sources))
+			for i, src := range app.Sources {
+				labels = append(labels, fmt.Sprintf("%d. %s", i+1, appSourceLabel(src)))
+			}
+			status := fmt.Sprintf("%s has %d sources, pick one: :param <1-%d> name=value (%s)",
+				app.Name, len(app.Sources), len(app.Sources), strings.Join(labels, ", "))
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+		}
+		if source > len(app.Sources) {
+			status := fmt.Sprintf("param: %s has no source %d (it has %d)", app.Name, source, len(app.Sources))
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+		}
+	} else if source > 1 {
+		status := fmt.Sprintf("param: %s has a single source", app.Name)
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+	} else {
+		source = 0
+	}
+	return m, m.setHelmParameters(app.Name, app.AppNamespace, source, params)
+}
+
+// setHelmParameters overrides Helm parameters of one source of an app;
+// position is 0 for single-source apps
+func (m *Model) setHelmParameters(appName string, appNamespace *string, position int, params []api.HelmParameter) tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg {
+			return model.ApiErrorMsg{Message: "No server configured"}
+		}
+	}
+
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+
+		cblog.With("component", "api").Info("Setting Helm parameters", "app", appName, "source", position, "count", len(params))
+		if err := api.NewApplicationService(server).SetHelmParameters(ctx, appName, appNamespace, position, params); err != nil {
+			cblog.With("component", "api").Error("Setting Helm parameters failed", "app", appName, "source", position, "err", err)
+			return model.StructuredErrorMsg{
+				Error: apperrors.New(apperrors.ErrorAPI, "PARAM_OVERRIDE_FAILED",
+					fmt.Sprintf("Failed to set parameters on %s: %s", appName, extractUserFriendlyError(err))).
+					WithSeverity(apperrors.SeverityHigh).
+					AsRecoverable().
+					WithUserAction("Check your permissions on the application and try again"),
+				Context:     map[string]interface{}{"operation": "param", "appName": appName, "source": position},
+				SwitchEpoch: epoch,
+			}
+		}
+
+		status := fmt.Sprintf("Set %d parameter(s) on %s", len(params), appName)
+		if position > 0 {
+			status += fmt.Sprintf(" source %d", position)
+		}
+		return model.StatusChangeMsg{Status: status}
+	}
+}
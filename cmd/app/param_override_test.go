@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestParseParamArgs(t *testing.T) {
+	source, params, err := parseParamArgs([]string{"2", "image.tag=v2", "args={a,b}"})
+	if err != nil || source != 2 || len(params) != 2 || params[1].Value != "{a,b}" {
+		t.Fatalf("got source %d, params %v, err %v", source, params, err)
+	}
+	if source, _, err := parseParamArgs([]string{"replicas=3"}); err != nil || source != 0 {
+		t.Errorf("source should be optional, got %d, %v", source, err)
+	}
+	for _, args := range [][]string{nil, {"2"}, {"0", "a=b"}, {"web", "a=b"}, {"=b"}} {
+		if _, _, err := parseParamArgs(args); err == nil {
+			t.Errorf("parseParamArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestParamCommand_MultiSourceNeedsSource(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].MultiSource = true
+	m.state.Apps[0].Sources = []model.AppSource{
+		{RepoURL: "https://charts.example.com", Chart: "web", TargetRevision: "1.4.0"},
+		{RepoURL: "https://git.example.com/values.git", TargetRevision: "main", Ref: "values"},
+	}
+
+	status := func(args ...string) string {
+		_, cmd := m.handleParamCommand(args)
+		msg, _ := cmd().(model.StatusChangeMsg)
+		return msg.Status
+	}
+	got := status("image.tag=v2")
+	if !strings.Contains(got, "pick one: :param <1-2>") || !strings.Contains(got, "2. https://git.example.com/values.git @ main") {
+		t.Errorf("expected the sources to pick from, got %q", got)
+	}
+	if got := status("3", "image.tag=v2"); !strings.Contains(got, "has no source 3") {
+		t.Errorf("expected out-of-range error, got %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+// appsRefreshTickMsg reloads the app list while streaming is off
+type appsRefreshTickMsg struct{ epoch int }
+
+// appsRefreshedMsg carries the app list reloaded while streaming is off
+type appsRefreshedMsg struct {
+	epoch int
+	apps  []model.App
+	err   error
+}
+
+// startAppsPolling takes the place of the watch stream when streaming is
+// off (--no-stream or stream.enabled = false): the app list loaded at
+// startup is reloaded every stream.refresh_interval
+func (m *Model) startAppsPolling() tea.Cmd {
+	if m.appsPolling || m.state.Server == nil {
+		return nil
+	}
+	m.appsPolling = true
+	cblog.With("component", "watch").Info("Streaming is off, polling the app list",
+		"interval", m.config.GetStreamRefreshInterval())
+	return m.scheduleAppsRefresh()
+}
+
+// scheduleAppsRefresh reloads the app list after the refresh interval
+func (m *Model) scheduleAppsRefresh() tea.Cmd {
+	interval := m.config.GetStreamRefreshInterval()
+	if interval <= 0 {
+		return nil
+	}
+	epoch := m.switchEpoch
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return appsRefreshTickMsg{epoch: epoch}
+	})
+}
+
+// handleAppsRefreshTick reloads the app list, and the resource tree when
+// one is open
+func (m *Model) handleAppsRefreshTick() tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	cmds := []tea.Cmd{func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		result, err := services.NewArgoApiService(server).ListApplicationsWithMeta(ctx, server)
+		if err != nil {
+			return appsRefreshedMsg{epoch: epoch, err: err}
+		}
+		return appsRefreshedMsg{epoch: epoch, apps: result.Apps}
+	}}
+	if m.treeView != nil && m.state.Navigation.View == model.ViewTree && m.state.Mode == model.ModeNormal {
+		for _, name := range m.treeView.AppNames() {
+			app := model.App{Name: name, AppNamespace: m.treeAppNamespace(name)}
+			if found := m.findAppByNameAndNamespace(name, derefOr(app.AppNamespace)); found != nil {
+				app = *found
+			}
+			cmds = append(cmds, m.startLoadingResourceTree(app))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleAppsRefreshed applies a reloaded app list like a batch of watch
+// events, so synced revisions, watched syncs and :wait are checked as they
+// would be while streaming. A failed reload keeps the list and tries again
+// at the next interval.
+func (m *Model) handleAppsRefreshed(msg appsRefreshedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "watch").Warn("Could not reload the app list", "err", msg.err)
+		return m.scheduleAppsRefresh()
+	}
+
+	prev := make(map[string]model.App, len(m.state.Apps))
+	for _, app := range m.state.Apps {
+		prev[appKey(app.Name, app.AppNamespace)] = app
+	}
+	seen := make(map[string]bool, len(msg.apps))
+	for _, app := range msg.apps {
+		key := appKey(app.Name, app.AppNamespace)
+		seen[key] = true
+		if old, ok := prev[key]; ok && reflect.DeepEqual(old, app) {
+			continue
+		}
+		m.applyBatchAppUpdate(model.AppUpdatedMsg{App: app})
+	}
+	for key := range prev {
+		if !seen[key] {
+			m.applyBatchAppRemove(key)
+		}
+	}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	m.recordStatusHistory()
+	m.clampListCursor()
+	m.appsFromCache = false
+
+	return tea.Batch(
+		m.saveAppsCache(m.state.Apps),
+		m.checkRevisions(),
+		m.checkWatchedOperations(),
+		m.checkLocalHooks(),
+		m.checkWait(),
+		m.scheduleAppsRefresh(),
+	)
+}
+
+// refreshIntervalText renders the refresh interval for the status line,
+// e.g. "30s" or "2m" rather than "2m0s"
+func refreshIntervalText(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func buildNoStreamTestModel(interval string) *Model {
+	m := buildDeleteTestModel(120, 30)
+	off := false
+	m.config = &config.ArgonautConfig{Stream: config.StreamConfig{Enabled: &off, RefreshInterval: interval}}
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	return m
+}
+
+func TestNoStream_PollsInsteadOfWatching(t *testing.T) {
+	m := buildNoStreamTestModel("")
+	seq := m.watchStartSequence
+
+	if cmd := m.startWatchingApplications(); cmd == nil {
+		t.Fatal("the first start should schedule a reload")
+	}
+	if m.watchStartSequence != seq {
+		t.Error("no watch stream should be started")
+	}
+	if cmd := m.startWatchingApplications(); cmd != nil {
+		t.Error("polling is already running; a second start must not double the reloads")
+	}
+	if cmd := m.startWatchingResourceTree(m.state.Apps[0]); cmd != nil {
+		t.Error("the resource tree should not be watched either")
+	}
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, "reloads every 30s") {
+		t.Errorf("the status line should say the list is polled: %q", status)
+	}
+}
+
+func TestNoStream_ZeroIntervalLeavesReloadToUser(t *testing.T) {
+	m := buildNoStreamTestModel("0")
+	if cmd := m.startWatchingApplications(); cmd != nil {
+		t.Error("refresh_interval = 0 should not schedule reloads")
+	}
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, "ctrl+r reloads") {
+		t.Errorf("status line = %q", status)
+	}
+}
+
+func TestNoStream_RefreshAppliesChangesInPlace(t *testing.T) {
+	m := buildNoStreamTestModel("")
+	m.state.Mode = model.ModeHistory
+	m.state.Modals.History = &model.HistoryState{}
+	m.recordStatusHistory()
+	ns := "test-namespace"
+
+	m.Update(appsRefreshedMsg{epoch: m.switchEpoch, apps: []model.App{
+		{Name: "test-app", Sync: "OutOfSync", Health: "Healthy", AppNamespace: &ns},
+		{Name: "new-app", Sync: "Synced", Health: "Healthy"},
+	}})
+
+	if m.state.Mode != model.ModeHistory {
+		t.Errorf("a background reload must not leave the open view, mode %s", m.state.Mode)
+	}
+	if len(m.state.Apps) != 2 || m.findAppByNameAndNamespace("zzz-other-app", "") != nil {
+		t.Fatalf("removed apps should be dropped: %+v", m.state.Apps)
+	}
+	if app := m.findAppByNameAndNamespace("test-app", ns); app == nil || app.Sync != "OutOfSync" {
+		t.Errorf("test-app should be updated: %+v", app)
+	}
+	if m.findAppByNameAndNamespace("new-app", "") == nil {
+		t.Error("new apps should be added")
+	}
+	if len(m.state.History.Changes()) == 0 {
+		t.Error("polled changes should reach the status history")
+	}
+}
+
+func TestNoStream_RefreshDisambiguatesAppByNamespace(t *testing.T) {
+	m := buildNoStreamTestModel("")
+	ns := "test-namespace"
+	m.state.Apps = append(m.state.Apps, model.App{Name: "test-app", Sync: "Synced", Health: "Healthy", AppNamespace: strp("team-b")})
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+
+	m.Update(appsRefreshedMsg{epoch: m.switchEpoch, apps: []model.App{
+		{Name: "test-app", Sync: "OutOfSync", Health: "Healthy", AppNamespace: &ns},
+	}})
+
+	if len(m.state.Apps) != 1 {
+		t.Fatalf("the same-named app gone from team-b should be dropped: %+v", m.state.Apps)
+	}
+	if app := m.findAppByNameAndNamespace("test-app", ns); app == nil || app.Sync != "OutOfSync" {
+		t.Errorf("test-app in %s should be updated: %+v", ns, m.state.Apps)
+	}
+}
+
+func TestRefreshIntervalText(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		30 * time.Second: "30s",
+		2 * time.Minute:  "2m",
+		time.Hour:        "1h",
+		90 * time.Second: "1m30s",
+	} {
+		if got := refreshIntervalText(d); got != want {
+			t.Errorf("refreshIntervalText(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
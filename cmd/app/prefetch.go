@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+// prefetchDebounce is how long the cursor must rest on an app before it is
+// prefetched, so scrolling through the list does not fire requests per row
+const prefetchDebounce = 300 * time.Millisecond
+
+// prefetchTTL bounds how long a finished prefetch is served
+const prefetchTTL = 30 * time.Second
+
+// Kinds of data prefetched for the app under the cursor
+const (
+	prefetchApplication = "application" // details and history (R, tree statuses)
+	prefetchTree        = "tree"        // resource tree (r)
+	prefetchDiffs       = "diffs"       // managed resource diffs (d)
+)
+
+// prefetchTickMsg fires once the cursor has rested on an app
+type prefetchTickMsg struct {
+	seq         int
+	switchEpoch int
+}
+
+// prefetchEntry is one in-flight or finished background load
+type prefetchEntry struct {
+	done      chan struct{} // closed once value/err are set
+	value     any
+	err       error
+	fetchedAt time.Time
+}
+
+// appPrefetcher holds background loads of the app under the cursor so the
+// diff, resources and rollback commands can reuse them. Entries are written
+// from tea.Cmd goroutines, so access goes through the mutex. A nil
+// prefetcher serves nothing.
+type appPrefetcher struct {
+	mu      sync.Mutex
+	entries map[string]*prefetchEntry // appKey + "|" + kind
+	cancel  context.CancelFunc        // cancels the current prefetch
+}
+
+func newAppPrefetcher() *appPrefetcher {
+	return &appPrefetcher{entries: make(map[string]*prefetchEntry)}
+}
+
+// begin registers in-flight entries for the app, cancelling any prefetch it
+// supersedes, and returns the context the loads run under
+func (p *appPrefetcher) begin(key string, kinds ...string) (context.Context, map[string]*prefetchEntry) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.cancel = cancel
+	for k, e := range p.entries {
+		select {
+		case <-e.done:
+			if time.Since(e.fetchedAt) > prefetchTTL {
+				delete(p.entries, k)
+			}
+		default:
+		}
+	}
+	entries := make(map[string]*prefetchEntry, len(kinds))
+	for _, kind := range kinds {
+		e := &prefetchEntry{done: make(chan struct{})}
+		p.entries[key+"|"+kind] = e
+		entries[kind] = e
+	}
+	return ctx, entries
+}
+
+// stop cancels the current prefetch
+func (p *appPrefetcher) stop() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// lookup returns the in-flight or fresh entry for the app and kind. A
+// failed load is kept until the TTL too, so an app whose loads fail is not
+// prefetched again each time the cursor rests on it; a cancelled one is
+// dropped.
+func (p *appPrefetcher) lookup(key, kind string) *prefetchEntry {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.entries[key+"|"+kind]
+	if !ok {
+		return nil
+	}
+	select {
+	case <-e.done:
+		if errors.Is(e.err, context.Canceled) || time.Since(e.fetchedAt) > prefetchTTL {
+			delete(p.entries, key+"|"+kind)
+			return nil
+		}
+	default: // still loading
+	}
+	return e
+}
+
+// has reports whether the app has an in-flight or fresh entry of the kind
+func (p *appPrefetcher) has(key, kind string) bool {
+	return p.lookup(key, kind) != nil
+}
+
+// invalidate drops everything prefetched for the app; called when the app
+// changes so stale trees, diffs or history are never served
+func (p *appPrefetcher) invalidate(key string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k := range p.entries {
+		if strings.HasPrefix(k, key+"|") {
+			delete(p.entries, k)
+		}
+	}
+}
+
+// keys returns the appKeys with prefetched entries
+func (p *appPrefetcher) keys() []string {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	seen := make(map[string]bool)
+	var keys []string
+	for k := range p.entries {
+		key, _, _ := strings.Cut(k, "|")
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// prefetched returns the prefetched value for the app and kind, waiting for
+// an in-flight load, or calls fetch when there is none or it failed. A
+// command the user asked for always tries again.
+func prefetched[T any](ctx context.Context, p *appPrefetcher, key, kind string, fetch func(context.Context) (T, error)) (T, error) {
+	if e := p.lookup(key, kind); e != nil {
+		select {
+		case <-e.done:
+			if v, ok := e.value.(T); ok && e.err == nil {
+				return v, nil
+			}
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+	return fetch(ctx)
+}
+
+// cursorApp returns the app under the cursor in the apps list
+func (m *Model) cursorApp() (model.App, bool) {
+	if m.state.Navigation.View != model.ViewApps {
+		return model.App{}, false
+	}
+	items := m.getVisibleItemsForCurrentView()
+	idx := m.state.Navigation.SelectedIdx
+	if idx < 0 || idx >= len(items) {
+		return model.App{}, false
+	}
+	app, ok := items[idx].(model.App)
+	return app, ok
+}
+
+// schedulePrefetch starts the debounce for the app under the cursor when the
+// cursor has moved to another app. The superseded prefetch is cancelled
+// right away. Returns nil when there is nothing to do.
+func (m *Model) schedulePrefetch() tea.Cmd {
+	if !m.config.IsPrefetchEnabled() || m.state.Server == nil || m.prefetch == nil {
+		return nil
+	}
+	app, ok := m.cursorApp()
+	if !ok {
+		return nil
+	}
+	key := appKey(app.Name, app.AppNamespace)
+	if key == m.prefetchTarget {
+		return nil
+	}
+	m.prefetchTarget = key
+	m.prefetchSeq++
+	m.prefetch.stop()
+	seq, epoch := m.prefetchSeq, m.switchEpoch
+	return tea.Tick(debounceDelay(prefetchDebounce), func(time.Time) tea.Msg {
+		return prefetchTickMsg{seq: seq, switchEpoch: epoch}
+	})
+}
+
+// handlePrefetchTick prefetches the app the cursor came to rest on
+func (m *Model) handlePrefetchTick(msg prefetchTickMsg) tea.Cmd {
+	if msg.switchEpoch != m.switchEpoch || msg.seq != m.prefetchSeq {
+		return nil // cursor moved on
+	}
+	app, ok := m.cursorApp()
+	if !ok || appKey(app.Name, app.AppNamespace) != m.prefetchTarget {
+		return nil
+	}
+	return m.prefetchApp(app)
+}
+
+// prefetchApp loads the app's details, resource tree and diffs concurrently
+// under a context that the next prefetch cancels. Kinds that are already
+// loaded or loading are skipped.
+func (m *Model) prefetchApp(app model.App) tea.Cmd {
+	server := m.state.Server // capture at call time
+	key := appKey(app.Name, app.AppNamespace)
+	var kinds []string
+	for _, kind := range []string{prefetchApplication, prefetchTree, prefetchDiffs} {
+		if !m.prefetch.has(key, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	ctx, entries := m.prefetch.begin(key, kinds...)
+	name, appNamespace := app.Name, app.AppNamespace
+	return func() tea.Msg {
+		argo := services.NewArgoApiService(server)
+		var wg sync.WaitGroup
+		for kind, e := range entries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(e.done)
+				var reqCtx context.Context
+				var cancel context.CancelFunc
+				if kind == prefetchDiffs {
+					// Diffs get the floor the diff command has
+					reqCtx, cancel = appcontext.WithMinAPITimeout(ctx, 45*time.Second)
+				} else {
+					reqCtx, cancel = appcontext.WithAPITimeout(ctx)
+				}
+				defer cancel()
+				switch kind {
+				case prefetchApplication:
+					e.value, e.err = argo.GetApplication(reqCtx, server, name, appNamespace)
+				case prefetchTree:
+					e.value, e.err = argo.GetResourceTree(reqCtx, server, name, derefOr(appNamespace))
+				case prefetchDiffs:
+					e.value, e.err = argo.GetResourceDiffs(reqCtx, server, name, appNamespace)
+				}
+				e.fetchedAt = time.Now()
+				if e.err != nil && ctx.Err() == nil {
+					cblog.With("component", "prefetch").Debug("Prefetch failed", "app", name, "kind", kind, "err", e.err)
+				}
+			}()
+		}
+		wg.Wait()
+		return nil
+	}
+}
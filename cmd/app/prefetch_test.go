@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestPrefetch_LoadsCursorAppOnceAndServesCommands(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/resource-tree"):
+			w.Write([]byte(`{"nodes": []}`))
+		case strings.HasSuffix(r.URL.Path, "/managed-resources"):
+			w.Write([]byte(`{"items": []}`))
+		default:
+			w.Write([]byte(`{"metadata": {"name": "test-app"}, "status": {"history": [{"id": 1, "revision": "abc"}]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "t"}
+
+	if m.schedulePrefetch() == nil {
+		t.Fatal("expected a debounce for the app under the cursor")
+	}
+	if m.schedulePrefetch() != nil {
+		t.Fatal("cursor did not move; nothing new to schedule")
+	}
+	if m.handlePrefetchTick(prefetchTickMsg{seq: m.prefetchSeq - 1, switchEpoch: m.switchEpoch}) != nil {
+		t.Fatal("a superseded tick must not prefetch")
+	}
+	cmd := m.handlePrefetchTick(prefetchTickMsg{seq: m.prefetchSeq, switchEpoch: m.switchEpoch})
+	if cmd == nil {
+		t.Fatal("expected the prefetch to start")
+	}
+	cmd()
+
+	ns := m.state.Apps[0].AppNamespace
+	if msg, ok := m.startRollbackSession("test-app", ns)().(model.RollbackHistoryLoadedMsg); !ok || len(msg.Rows) != 1 {
+		t.Fatalf("unexpected rollback message: %#v", msg)
+	}
+	if _, ok := m.startLoadingResourceTree(m.state.Apps[0])().(model.ResourceTreeLoadedMsg); !ok {
+		t.Fatal("expected the tree to load from the prefetch")
+	}
+	if m.startDiffSession("test-app", ns)() != (model.SetModeMsg{Mode: model.ModeNoDiff}) {
+		t.Fatal("expected an empty diff from the prefetch")
+	}
+	for path, n := range hits {
+		if n != 1 {
+			t.Errorf("%s requested %d times, want 1", path, n)
+		}
+	}
+	if len(hits) != 3 {
+		t.Errorf("expected application, tree and diff requests, got %v", hits)
+	}
+
+	// A change to the app drops what was prefetched
+	m.applyBatchAppUpdate(model.AppUpdatedMsg{App: m.state.Apps[0]})
+	m.startRollbackSession("test-app", ns)()
+	if hits["/api/v1/applications/test-app"] != 2 {
+		t.Errorf("updated app should be fetched again, got %v", hits)
+	}
+}
+
+func TestPrefetch_SupersededPrefetchIsCancelled(t *testing.T) {
+	p := newAppPrefetcher()
+	first, entries := p.begin("ns/a", prefetchTree)
+	p.begin("ns/b", prefetchTree)
+	if first.Err() == nil {
+		t.Fatal("starting a new prefetch should cancel the previous one")
+	}
+
+	// A cancelled load falls back to a direct fetch
+	e := entries[prefetchTree]
+	e.err = first.Err()
+	close(e.done)
+	got, err := prefetched(context.Background(), p, "ns/a", prefetchTree, func(context.Context) (string, error) { return "direct", nil })
+	if err != nil || got != "direct" {
+		t.Errorf("prefetched() = %q, %v", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := prefetched(ctx, p, "ns/b", prefetchTree, func(context.Context) (string, error) { return "", nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("waiting on an in-flight load should honour the caller's context, got %v", err)
+	}
+}
+
+func TestPrefetch_DisabledInConfig(t *testing.T) {
+	off := false
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:1"}
+	m.config = &config.ArgonautConfig{Prefetch: config.PrefetchConfig{Enabled: &off}}
+	if m.schedulePrefetch() != nil {
+		t.Error("disabled prefetch must not schedule loads")
+	}
+}
+
+func TestPrefetch_FailedLoadIsKeptUntilTTL(t *testing.T) {
+	p := newAppPrefetcher()
+	_, entries := p.begin("ns/a", prefetchDiffs)
+	e := entries[prefetchDiffs]
+	e.err = errors.New("permission denied")
+	e.fetchedAt = time.Now()
+	close(e.done)
+
+	if !p.has("ns/a", prefetchDiffs) {
+		t.Fatal("a failed prefetch should not be started again before the TTL")
+	}
+	// The user asking for the diff still tries again
+	got, err := prefetched(context.Background(), p, "ns/a", prefetchDiffs, func(context.Context) (string, error) { return "direct", nil })
+	if err != nil || got != "direct" {
+		t.Errorf("prefetched() = %q, %v", got, err)
+	}
+
+	e.fetchedAt = time.Now().Add(-prefetchTTL - time.Second)
+	if p.has("ns/a", prefetchDiffs) {
+		t.Error("a failed prefetch should be dropped after the TTL")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+)
+
+// pruneInterval is how often apps out of the filtered scope are checked
+const pruneInterval = time.Minute
+
+// pruneTickMsg checks which apps stayed out of scope long enough to release
+type pruneTickMsg struct{}
+
+// pruneTick schedules the next check. Returns nil when pruning is off
+// (memory.prune_after = "0").
+func (m *Model) pruneTick() tea.Cmd {
+	if m.config.GetPruneAfter() <= 0 {
+		return nil
+	}
+	return tea.Tick(pruneInterval, func(time.Time) tea.Msg { return pruneTickMsg{} })
+}
+
+// pinnedAppKeys returns the apps whose data is in use: those in the
+// filtered scope and the one shown in the resource tree
+func (m *Model) pinnedAppKeys() map[string]bool {
+	pinned := make(map[string]bool)
+	apps := m.state.Apps
+	if m.state.Index != nil {
+		apps = m.state.Index.ScopedApps(m.state.Apps, &m.state.Selections)
+	}
+	for _, app := range apps {
+		pinned[appKey(app.Name, app.AppNamespace)] = true
+	}
+	if tree := m.state.UI.TreeApp; tree != nil {
+		pinned[appKey(tree.Name, tree.AppNamespace)] = true
+	}
+	return pinned
+}
+
+// trackedAppKeys returns the apps that have per-app bookkeeping. Watched
+// operations are not: each waits for an operation still in progress, and is
+// dropped once it finishes or after watchedOperationTTL.
+func (m *Model) trackedAppKeys() map[string]bool {
+	tracked := make(map[string]bool)
+	for key := range m.revisionInfo {
+		tracked[key] = true
+	}
+	for _, key := range m.diffCache.keys() {
+		tracked[key] = true
+	}
+	for _, key := range m.prefetch.keys() {
+		tracked[key] = true
+	}
+	for key := range m.rolloutInfo {
+		// rolloutKey starts with the app's appKey, "appNamespace/name"
+		parts := strings.SplitN(key, "/", 3)
+		if len(parts) == 3 {
+			tracked[parts[0]+"/"+parts[1]] = true
+		}
+	}
+	return tracked
+}
+
+// pruneOutOfScope notes when apps with cached data left the filtered scope
+// and releases those that stayed out for memory.prune_after
+func (m *Model) pruneOutOfScope(now time.Time) {
+	after := m.config.GetPruneAfter()
+	if after <= 0 {
+		return
+	}
+	pinned := m.pinnedAppKeys()
+	tracked := m.trackedAppKeys()
+	if m.outOfScopeSince == nil {
+		m.outOfScopeSince = make(map[string]time.Time)
+	}
+	for key := range m.outOfScopeSince {
+		if pinned[key] || !tracked[key] {
+			delete(m.outOfScopeSince, key)
+		}
+	}
+	released := 0
+	for key := range tracked {
+		if pinned[key] {
+			continue
+		}
+		since, ok := m.outOfScopeSince[key]
+		if !ok {
+			m.outOfScopeSince[key] = now
+			continue
+		}
+		if now.Sub(since) >= after {
+			m.releaseApp(key)
+			delete(m.outOfScopeSince, key)
+			released++
+		}
+	}
+	if released > 0 {
+		cblog.With("component", "prune").Debug("Released apps out of scope", "apps", released, "after", after)
+	}
+}
+
+// releaseApp drops the cached data of the app: its revision lookups,
+// cached diff, prefetched data and Rollout statuses, and cancels its
+// prefetch if one is running
+func (m *Model) releaseApp(key string) {
+	delete(m.revisionInfo, key)
+	m.diffCache.invalidate(key)
+	m.prefetch.invalidate(key)
+	if m.prefetchTarget == key {
+		m.prefetch.stop()
+		m.prefetchTarget = ""
+	}
+	for k := range m.rolloutInfo {
+		if strings.HasPrefix(k, key+"/") {
+			delete(m.rolloutInfo, k)
+		}
+	}
+}
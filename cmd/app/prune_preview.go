@@ -0,0 +1,436 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+// prunePreviewMaxListed is how many resources the sync modal lists before
+// summarizing the rest
+const prunePreviewMaxListed = 8
+
+// prunePreviewMaxDependents is how many owned resources are listed under
+// each resource prune would delete
+const prunePreviewMaxDependents = 4
+
+// pruneCandidates returns the resources a sync with prune would delete:
+// live in the cluster but no longer in git. Hooks are not pruned, and
+// resources with the Prune=false sync option are counted as kept.
+func pruneCandidates(appName string, diffs []services.ResourceDiff) ([]model.PruneCandidate, int) {
+	var out []model.PruneCandidate
+	kept := 0
+	for _, d := range diffs {
+		if d.Hook || emptyManifest(d.LiveState) || !emptyManifest(d.TargetState) {
+			continue
+		}
+		if pruneDisabled(d.LiveState) {
+			kept++
+			continue
+		}
+		out = append(out, model.PruneCandidate{
+			AppName:       appName,
+			Group:         d.Group,
+			Kind:          d.Kind,
+			Namespace:     d.Namespace,
+			Name:          d.Name,
+			ClaimsDeleted: d.Kind == "StatefulSet" && claimsDeletedWith(d.LiveState),
+		})
+	}
+	return out, kept
+}
+
+// appliedResources returns the resources in git a sync applies, so a sync
+// that excludes resources from prune can name them
+func appliedResources(appName string, diffs []services.ResourceDiff) []model.ResourceSyncTarget {
+	var out []model.ResourceSyncTarget
+	for _, d := range diffs {
+		if d.Hook || emptyManifest(d.TargetState) {
+			continue
+		}
+		out = append(out, model.ResourceSyncTarget{AppName: appName, Group: d.Group, Kind: d.Kind, Namespace: d.Namespace, Name: d.Name})
+	}
+	return out
+}
+
+// addPruneDependents fills in the resources each candidate owns, following
+// the parent references of the app's resource tree down from it. Argo CD
+// also infers a StatefulSet as the parent of its volume claims, which
+// Kubernetes only owns, and deletes, when the StatefulSet's
+// persistentVolumeClaimRetentionPolicy says so; otherwise they are kept
+// and not listed.
+func addPruneDependents(candidates []model.PruneCandidate, nodes []api.ResourceNode) {
+	children := make(map[string][]api.ResourceNode)
+	for _, n := range nodes {
+		for _, p := range n.ParentRefs {
+			if p.UID != "" {
+				children[p.UID] = append(children[p.UID], n)
+			}
+		}
+	}
+	for i := range candidates {
+		c := &candidates[i]
+		var root *api.ResourceNode
+		for j, n := range nodes {
+			if n.Group == c.Group && n.Kind == c.Kind && n.Name == c.Name && derefOr(n.Namespace) == c.Namespace {
+				root = &nodes[j]
+				break
+			}
+		}
+		if root == nil || root.UID == "" {
+			continue
+		}
+		seen := map[string]bool{root.UID: true}
+		var walk func(parent api.ResourceNode, depth int)
+		walk = func(parent api.ResourceNode, depth int) {
+			for _, child := range children[parent.UID] {
+				if seen[child.UID] {
+					continue
+				}
+				// Only the candidate's live state is known, so claims of a
+				// StatefulSet further down count as retained, the default
+				if parent.Kind == "StatefulSet" && child.Kind == "PersistentVolumeClaim" && (parent.UID != root.UID || !c.ClaimsDeleted) {
+					continue
+				}
+				seen[child.UID] = true
+				c.Dependents = append(c.Dependents, model.PruneDependent{
+					Kind:      child.Kind,
+					Namespace: derefOr(child.Namespace),
+					Name:      child.Name,
+					Depth:     depth,
+				})
+				walk(child, depth+1)
+			}
+		}
+		walk(*root, 1)
+	}
+}
+
+// claimsDeletedWith reports whether a StatefulSet's live manifest has its
+// volume claims deleted along with it; Kubernetes retains them by default
+func claimsDeletedWith(live string) bool {
+	var obj struct {
+		Spec struct {
+			PersistentVolumeClaimRetentionPolicy struct {
+				WhenDeleted string `json:"whenDeleted"`
+			} `json:"persistentVolumeClaimRetentionPolicy"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(live), &obj); err != nil {
+		return false
+	}
+	return obj.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted == "Delete"
+}
+
+// emptyManifest reports whether a managed resource state is absent
+func emptyManifest(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || s == "null"
+}
+
+// pruneDisabled reports whether a live manifest opts out of pruning with
+// the argocd.argoproj.io/sync-options: Prune=false annotation
+func pruneDisabled(live string) bool {
+	var obj struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(live), &obj); err != nil {
+		return false
+	}
+	for _, opt := range strings.Split(obj.Metadata.Annotations["argocd.argoproj.io/sync-options"], ",") {
+		if strings.TrimSpace(opt) == "Prune=false" {
+			return true
+		}
+	}
+	return false
+}
+
+// prunePreviewCmd loads what the sync in the confirm modal would prune. Does
+// nothing while prune is off or when the target was already checked.
+func (m *Model) prunePreviewCmd() tea.Cmd {
+	modals := &m.state.Modals
+	if !modals.ConfirmSyncPrune || modals.ConfirmTarget == nil || m.state.Server == nil {
+		return nil
+	}
+	target := *modals.ConfirmTarget
+	targetNamespace := modals.ConfirmTargetNamespace
+	if prunePreviewFor(modals.ConfirmSyncPrunePreview, target, targetNamespace) {
+		return nil
+	}
+
+	type appRef struct {
+		name      string
+		namespace *string
+	}
+	var apps []appRef
+	if target == "__MULTI__" {
+		for name, selected := range m.state.Selections.SelectedApps {
+			if !selected {
+				continue
+			}
+			ref := appRef{name: name}
+			if app := m.findAppByNameAndNamespace(name, ""); app != nil {
+				ref.namespace = app.AppNamespace
+			}
+			apps = append(apps, ref)
+		}
+		slices.SortFunc(apps, func(a, b appRef) int { return strings.Compare(a.name, b.name) })
+	} else {
+		apps = []appRef{{name: target, namespace: targetNamespace}}
+	}
+
+	modals.ConfirmSyncPrunePreview = &model.PrunePreview{Target: target, AppNamespace: targetNamespace, Loading: true}
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	prefetch := m.prefetch
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 45*time.Second)
+		defer cancel()
+
+		apiService := services.NewArgoApiService(server)
+		msg := model.PrunePreviewLoadedMsg{Target: target, AppNamespace: targetNamespace, SwitchEpoch: epoch}
+		for _, app := range apps {
+			diffs, err := prefetched(ctx, prefetch, appKey(app.name, app.namespace), prefetchDiffs, func(ctx context.Context) ([]services.ResourceDiff, error) {
+				return apiService.GetResourceDiffs(ctx, server, app.name, app.namespace)
+			})
+			if err != nil {
+				msg.Err = fmt.Errorf("%s: %w", app.name, err)
+				return msg
+			}
+			resources, kept := pruneCandidates(app.name, diffs)
+			if len(resources) > 0 {
+				// Owner references show what Kubernetes deletes along with them
+				tree, err := prefetched(ctx, prefetch, appKey(app.name, app.namespace), prefetchTree, func(ctx context.Context) (*api.ResourceTree, error) {
+					return apiService.GetResourceTree(ctx, server, app.name, derefOr(app.namespace))
+				})
+				if err != nil {
+					msg.Err = fmt.Errorf("%s: %w", app.name, err)
+					return msg
+				}
+				addPruneDependents(resources, tree.Nodes)
+			}
+			msg.Resources = append(msg.Resources, resources...)
+			msg.Kept += kept
+			msg.Applied = append(msg.Applied, appliedResources(app.name, diffs)...)
+		}
+		return msg
+	}
+}
+
+// prunePreviewFor reports whether p was computed for the app, or the
+// multi-selection, in the confirm modal
+func prunePreviewFor(p *model.PrunePreview, target string, appNamespace *string) bool {
+	return p != nil && appKey(p.Target, p.AppNamespace) == appKey(target, appNamespace)
+}
+
+// handlePrunePreviewLoaded shows the prune preview if the modal still
+// targets what it was computed for
+func (m *Model) handlePrunePreviewLoaded(msg model.PrunePreviewLoadedMsg) {
+	p := m.state.Modals.ConfirmSyncPrunePreview
+	if !prunePreviewFor(p, msg.Target, msg.AppNamespace) {
+		return
+	}
+	p.Loading = false
+	p.Resources = msg.Resources
+	p.Kept = msg.Kept
+	p.Applied = msg.Applied
+	if msg.Err != nil {
+		p.Error = msg.Err.Error()
+	}
+}
+
+// handleStartPruneExclude lets the resources prune would delete be picked
+// to leave out of the sync
+func (m *Model) handleStartPruneExclude() tea.Cmd {
+	modals := &m.state.Modals
+	p := modals.ConfirmSyncPrunePreview
+	switch {
+	case !modals.ConfirmSyncPrune:
+		return m.showStatusNote("Turn prune on (p) to pick resources it should leave")
+	case modals.ConfirmTarget != nil && *modals.ConfirmTarget == "__MULTI__":
+		return m.showStatusNote("Resources can be excluded from prune when syncing a single app")
+	case p == nil || p.Loading:
+		return m.showStatusNote("Still checking what prune would delete")
+	case p.Error != "" || len(p.Resources) == 0:
+		return m.showStatusNote("Nothing to exclude from prune")
+	}
+	p.Picking = true
+	p.SelectedIdx = min(p.SelectedIdx, len(p.Resources)-1)
+	return nil
+}
+
+// handlePruneExcludeKeys moves over the resources prune would delete and
+// excludes or includes the one under the cursor
+func (m *Model) handlePruneExcludeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.state.Modals.ConfirmSyncPrunePreview
+	switch msg.String() {
+	case "up", "k":
+		if p.SelectedIdx > 0 {
+			p.SelectedIdx--
+		}
+	case "down", "j":
+		if p.SelectedIdx < len(p.Resources)-1 {
+			p.SelectedIdx++
+		}
+	case "space", " ":
+		if p.SelectedIdx < len(p.Resources) {
+			p.Resources[p.SelectedIdx].Excluded = !p.Resources[p.SelectedIdx].Excluded
+			p.SelectiveWarned = false
+		}
+	case "x", "enter", "esc":
+		p.Picking = false
+	}
+	return m, nil
+}
+
+// pruneExclusionResources returns the resources to sync when some are
+// excluded from prune: everything in git and the candidates left in. nil
+// syncs the whole app.
+func (m *Model) pruneExclusionResources() []api.SyncResourceTarget {
+	modals := &m.state.Modals
+	p := modals.ConfirmSyncPrunePreview
+	if !modals.ConfirmSyncPrune || modals.ConfirmTarget == nil || !prunePreviewFor(p, *modals.ConfirmTarget, modals.ConfirmTargetNamespace) {
+		return nil
+	}
+	if !slices.ContainsFunc(p.Resources, func(c model.PruneCandidate) bool { return c.Excluded }) {
+		return nil
+	}
+	resources := make([]api.SyncResourceTarget, 0, len(p.Applied)+len(p.Resources))
+	for _, r := range p.Applied {
+		resources = append(resources, api.SyncResourceTarget{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name})
+	}
+	for _, c := range p.Resources {
+		if !c.Excluded {
+			resources = append(resources, api.SyncResourceTarget{Group: c.Group, Kind: c.Kind, Namespace: c.Namespace, Name: c.Name})
+		}
+	}
+	return resources
+}
+
+// pruneResourceName renders a resource as Kind namespace/name
+func pruneResourceName(kind, namespace, name string) string {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+	return kind + " " + name
+}
+
+// renderPrunePreview lists what the sync would prune and what Kubernetes
+// deletes along with it, for the sync modal
+func (m *Model) renderPrunePreview(width int) []string {
+	p := m.state.Modals.ConfirmSyncPrunePreview
+	if !m.state.Modals.ConfirmSyncPrune || p == nil {
+		return nil
+	}
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	warn := lipgloss.NewStyle().Foreground(outOfSyncColor).Bold(true)
+
+	var lines []string
+	switch {
+	case p.Loading:
+		lines = append(lines, dim.Render("Checking what prune would delete…"))
+	case p.Error != "":
+		lines = append(lines, warn.Render("Could not check what prune would delete"),
+			dim.Render(truncateWithEllipsis(p.Error, width)))
+	case len(p.Resources) == 0:
+		lines = append(lines, dim.Render("Prune deletes nothing"))
+	default:
+		excluded := 0
+		for _, r := range p.Resources {
+			if r.Excluded {
+				excluded++
+			}
+		}
+		header := fmt.Sprintf("Prune will delete %d resource(s):", len(p.Resources)-excluded)
+		if excluded > 0 {
+			header = fmt.Sprintf("Prune will delete %d resource(s), %d excluded:", len(p.Resources)-excluded, excluded)
+		}
+		lines = append(lines, warn.Render(header))
+
+		// Orphaned dependents outlive their owner; otherwise they go with it
+		fate := "deletes"
+		if m.state.Modals.ConfirmSyncPrunePolicy == "orphan" {
+			fate = "orphans"
+		}
+		multi := p.Target == "__MULTI__"
+		offset := 0
+		if p.Picking {
+			offset = max(0, p.SelectedIdx-prunePreviewMaxListed+1)
+		}
+		if offset > 0 {
+			lines = append(lines, dim.Render(fmt.Sprintf("… %d more above", offset)))
+		}
+		for i := offset; i < len(p.Resources); i++ {
+			r := p.Resources[i]
+			if i == offset+prunePreviewMaxListed {
+				lines = append(lines, dim.Render(fmt.Sprintf("… and %d more", len(p.Resources)-i)))
+				break
+			}
+			line := pruneResourceName(r.Kind, r.Namespace, r.Name)
+			if multi {
+				line = r.AppName + ": " + line
+			}
+			if r.Excluded {
+				line += " (excluded)"
+			}
+			if p.Picking {
+				if i == p.SelectedIdx {
+					line = "► " + line
+				} else {
+					line = "  " + line
+				}
+			}
+			line = truncateWithEllipsis(line, width)
+			if r.Excluded {
+				line = dim.Render(line)
+			}
+			lines = append(lines, line)
+			if r.Excluded {
+				continue
+			}
+			for j, d := range r.Dependents {
+				if j == prunePreviewMaxDependents {
+					lines = append(lines, dim.Render(fmt.Sprintf("    … and %d more it %s", len(r.Dependents)-j, fate)))
+					break
+				}
+				dep := truncateWithEllipsis(strings.Repeat("  ", d.Depth)+"└ "+pruneResourceName(d.Kind, d.Namespace, d.Name), width)
+				if d.Kind == "PersistentVolumeClaim" {
+					// Volume claims are where data lives
+					lines = append(lines, warn.Render(dep))
+				} else {
+					lines = append(lines, dim.Render(dep))
+				}
+			}
+		}
+		if fate == "orphans" && slices.ContainsFunc(p.Resources, func(r model.PruneCandidate) bool { return len(r.Dependents) > 0 }) {
+			lines = append(lines, dim.Render("└ owned resources are orphaned, not deleted"))
+		}
+		switch {
+		case p.Picking:
+			lines = append(lines, dim.Render("space: exclude/include • x: done"))
+		case excluded > 0:
+			lines = append(lines, warn.Render(truncateWithEllipsis("Selective sync: hooks do not run and no history is recorded", width)))
+			if p.SelectiveWarned {
+				lines = append(lines, warn.Render(truncateWithEllipsis("Confirm again to sync only the other resources", width)))
+			}
+		case !multi:
+			lines = append(lines, dim.Render("x: exclude resources from prune"))
+		}
+	}
+	if p.Kept > 0 && !p.Loading && p.Error == "" {
+		lines = append(lines, dim.Render(fmt.Sprintf("%d kept by Prune=false", p.Kept)))
+	}
+	return lines
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+func TestPruneCandidates(t *testing.T) {
+	diffs := []services.ResourceDiff{
+		{Kind: "ConfigMap", Namespace: "prod", Name: "old", LiveState: `{"kind":"ConfigMap"}`, TargetState: "null"},
+		{Kind: "Deployment", Namespace: "prod", Name: "api", LiveState: `{"kind":"Deployment"}`, TargetState: `{"kind":"Deployment"}`},
+		{Kind: "Service", Namespace: "prod", Name: "new", TargetState: `{"kind":"Service"}`},
+		{Kind: "Job", Namespace: "prod", Name: "migrate", LiveState: `{"kind":"Job"}`, Hook: true},
+		{Kind: "Secret", Namespace: "prod", Name: "keep", LiveState: `{"metadata":{"annotations":{"argocd.argoproj.io/sync-options":"ServerSideApply=true, Prune=false"}}}`},
+	}
+	got, kept := pruneCandidates("api", diffs)
+	if len(got) != 1 || got[0].Name != "old" || got[0].AppName != "api" {
+		t.Errorf("only the live resource missing from git should be pruned, got %+v", got)
+	}
+	if kept != 1 {
+		t.Errorf("Prune=false resources should be counted as kept, got %d", kept)
+	}
+}
+
+func TestConfirmSync_PrunePreview(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/test-app/resource-tree") {
+			w.Write([]byte(`{"nodes": [{"kind": "ConfigMap", "namespace": "prod", "name": "legacy-config", "uid": "cm"}]}`))
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/test-app/managed-resources") {
+			http.NotFound(w, r)
+			return
+		}
+		requests++
+		w.Write([]byte(`{"items": [
+			{"kind": "ConfigMap", "namespace": "prod", "name": "legacy-config", "liveState": "{\"kind\":\"ConfigMap\"}", "targetState": "null"},
+			{"kind": "Deployment", "namespace": "prod", "name": "api", "liveState": "{}", "targetState": "{}"}
+		]}`))
+	}))
+	defer srv.Close()
+	m := buildDeleteTestModel(120, 40)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+
+	if _, cmd := m.handleSyncModal(); cmd != nil {
+		t.Fatal("nothing should load while prune is off")
+	}
+	_, cmd := m.handleConfirmSyncKeys(testKeyMsg("p"))
+	if cmd == nil {
+		t.Fatal("turning prune on should check what it would delete")
+	}
+	if out := stripANSI(m.renderConfirmSyncModal()); !strings.Contains(out, "Checking what prune would delete") {
+		t.Errorf("modal should show the check in progress:\n%s", out)
+	}
+	m.Update(cmd())
+
+	out := stripANSI(m.renderConfirmSyncModal())
+	for _, want := range []string{"Prune will delete 1 resource(s):", "ConfigMap prod/legacy-config"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "prod/api") {
+		t.Errorf("resources still in git should not be listed:\n%s", out)
+	}
+
+	// Toggling prune off and on reuses the preview
+	m.handleConfirmSyncKeys(testKeyMsg("p"))
+	if strings.Contains(stripANSI(m.renderConfirmSyncModal()), "legacy-config") {
+		t.Error("the preview should hide while prune is off")
+	}
+	if _, cmd := m.handleConfirmSyncKeys(testKeyMsg("p")); cmd != nil || requests != 1 {
+		t.Errorf("the preview should be loaded once per modal, got %d requests", requests)
+	}
+}
+
+func TestAddPruneDependents_FollowsOwnerChains(t *testing.T) {
+	ns := func(s string) *string { return &s }
+	nodes := []api.ResourceNode{
+		{Kind: "StatefulSet", Group: "apps", Namespace: ns("prod"), Name: "db", UID: "sts"},
+		{Kind: "Pod", Namespace: ns("prod"), Name: "db-0", UID: "pod", ParentRefs: []api.ResourceRef{{Kind: "StatefulSet", UID: "sts"}}},
+		{Kind: "PersistentVolumeClaim", Namespace: ns("prod"), Name: "data-db-0", UID: "pvc", ParentRefs: []api.ResourceRef{{Kind: "StatefulSet", UID: "sts"}}},
+		{Kind: "Deployment", Group: "apps", Namespace: ns("prod"), Name: "web", UID: "deploy"},
+		{Kind: "ReplicaSet", Group: "apps", Namespace: ns("prod"), Name: "web-1", UID: "rs", ParentRefs: []api.ResourceRef{{Kind: "Deployment", UID: "deploy"}}},
+		{Kind: "Pod", Namespace: ns("prod"), Name: "web-1-a", UID: "web-pod", ParentRefs: []api.ResourceRef{{Kind: "ReplicaSet", UID: "rs"}}},
+	}
+	candidates := []model.PruneCandidate{
+		{Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "db"},
+		{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web"},
+		{Kind: "ConfigMap", Namespace: "prod", Name: "not-in-tree"},
+	}
+	addPruneDependents(candidates, nodes)
+
+	want := [][]model.PruneDependent{
+		{{Kind: "Pod", Namespace: "prod", Name: "db-0", Depth: 1}},
+		{{Kind: "ReplicaSet", Namespace: "prod", Name: "web-1", Depth: 1}, {Kind: "Pod", Namespace: "prod", Name: "web-1-a", Depth: 2}},
+		nil,
+	}
+	for i, c := range candidates {
+		if !reflect.DeepEqual(c.Dependents, want[i]) {
+			t.Errorf("%s dependents = %+v, want %+v", c.Name, c.Dependents, want[i])
+		}
+	}
+
+	// Claims are only garbage-collected under whenDeleted: Delete
+	deleting := []model.PruneCandidate{{Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "db", ClaimsDeleted: true}}
+	addPruneDependents(deleting, nodes)
+	if got := deleting[0].Dependents; len(got) != 2 || got[1].Kind != "PersistentVolumeClaim" {
+		t.Errorf("claims deleted with the StatefulSet should be listed, got %+v", got)
+	}
+}
+
+func TestPruneCandidates_ReadsClaimRetentionPolicy(t *testing.T) {
+	diffs := []services.ResourceDiff{
+		{Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "keeps", LiveState: `{"spec":{"replicas":1}}`},
+		{Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "retains", LiveState: `{"spec":{"persistentVolumeClaimRetentionPolicy":{"whenDeleted":"Retain","whenScaled":"Delete"}}}`},
+		{Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "deletes", LiveState: `{"spec":{"persistentVolumeClaimRetentionPolicy":{"whenDeleted":"Delete"}}}`},
+	}
+	candidates, _ := pruneCandidates("test-app", diffs)
+	for _, c := range candidates {
+		if c.ClaimsDeleted != (c.Name == "deletes") {
+			t.Errorf("%s ClaimsDeleted = %v", c.Name, c.ClaimsDeleted)
+		}
+	}
+}
+
+func TestConfirmSync_ExcludeFromPrune(t *testing.T) {
+	m := buildDeleteTestModel(120, 40)
+	m.handleSyncModal()
+	m.state.Modals.ConfirmSyncPrune = true
+	m.state.Modals.ConfirmSyncPrunePreview = &model.PrunePreview{
+		Target:       "test-app",
+		AppNamespace: strp("test-namespace"),
+		Resources: []model.PruneCandidate{
+			{AppName: "test-app", Group: "apps", Kind: "StatefulSet", Namespace: "prod", Name: "db", Dependents: []model.PruneDependent{
+				{Kind: "PersistentVolumeClaim", Namespace: "prod", Name: "data-db-0", Depth: 1},
+			}},
+			{AppName: "test-app", Kind: "ConfigMap", Namespace: "prod", Name: "old"},
+		},
+		Applied: []model.ResourceSyncTarget{{AppName: "test-app", Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web"}},
+	}
+
+	out := stripANSI(m.renderConfirmSyncModal())
+	for _, want := range []string{"Prune will delete 2 resource(s):", "└ PersistentVolumeClaim prod/data-db-0", "x: exclude resources from prune"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+	if opts := m.confirmSyncOptions(); opts.Resources != nil {
+		t.Fatalf("without exclusions the whole app should sync, got %+v", opts.Resources)
+	}
+
+	m.handleConfirmSyncKeys(testKeyMsg("x"))
+	m.handleConfirmSyncKeys(keyPress("space"))
+	m.handleConfirmSyncKeys(testKeyMsg("x"))
+	if p := m.state.Modals.ConfirmSyncPrunePreview; p.Picking || !p.Resources[0].Excluded {
+		t.Fatalf("space should exclude the StatefulSet, got %+v", p)
+	}
+
+	out = stripANSI(m.renderConfirmSyncModal())
+	for _, want := range []string{"Prune will delete 1 resource(s), 1 excluded:", "StatefulSet prod/db (excluded)", "hooks do not run"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "data-db-0") {
+		t.Errorf("an excluded resource's dependents are not deleted:\n%s", out)
+	}
+	want := []api.SyncResourceTarget{
+		{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web"},
+		{Kind: "ConfigMap", Namespace: "prod", Name: "old"},
+	}
+	if got := m.confirmSyncOptions().Resources; !reflect.DeepEqual(got, want) {
+		t.Fatalf("sync should name everything but the excluded resource, got %+v", got)
+	}
+
+	// The first confirm only warns that hooks and history are skipped
+	if _, cmd := m.handleConfirmSyncKeys(testKeyMsg("y")); cmd != nil || m.state.Modals.ConfirmSyncLoading {
+		t.Fatal("the first confirm of a selective sync should not sync")
+	}
+	if out := stripANSI(m.renderConfirmSyncModal()); !strings.Contains(out, "Confirm again") || !strings.Contains(out, "no history") {
+		t.Errorf("the modal should ask to confirm the selective sync:\n%s", out)
+	}
+	if _, cmd := m.handleConfirmSyncKeys(testKeyMsg("y")); cmd == nil || !m.state.Modals.ConfirmSyncLoading {
+		t.Error("the second confirm should sync")
+	}
+}
+
+func TestPrunePreview_DisambiguatesAppByNamespace(t *testing.T) {
+	m := buildDeleteTestModel(120, 40)
+	m.state.Server = &model.Server{BaseURL: "http://argocd.invalid", Token: "tok"}
+	m.handleSyncModal()
+	m.state.Modals.ConfirmSyncPrune = true
+	m.state.Modals.ConfirmSyncPrunePreview = &model.PrunePreview{Target: "test-app", AppNamespace: strp("team-b"), Loading: true}
+
+	// A preview of the same-named app in another namespace is not reused
+	if cmd := m.prunePreviewCmd(); cmd == nil {
+		t.Fatal("the preview should be loaded for the app in the modal")
+	}
+	m.handlePrunePreviewLoaded(model.PrunePreviewLoadedMsg{
+		Target:       "test-app",
+		AppNamespace: strp("team-b"),
+		Resources:    []model.PruneCandidate{{AppName: "test-app", Kind: "ConfigMap", Namespace: "prod", Name: "other-team"}},
+	})
+	if p := m.state.Modals.ConfirmSyncPrunePreview; !p.Loading || len(p.Resources) != 0 {
+		t.Fatalf("a preview for the same-named app in another namespace should be dropped, got %+v", p)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+func TestPruneOutOfScope_ReleasesAppsAfterThreshold(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Memory: config.MemoryConfig{PruneAfter: "10m"}}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	inScope := appKey("test-app", strp("test-namespace"))
+	other := appKey(m.state.Apps[1].Name, m.state.Apps[1].AppNamespace)
+	sections := []model.DiffSection{{Kind: "Deployment", Name: "web"}}
+	for _, key := range []string{inScope, other} {
+		m.diffCache.put(key, "a..main", sections)
+	}
+	m.revisionInfo = map[string]revisionInfo{other: {}}
+	m.watchedOps = map[string]watchedOperation{other: {kind: "sync", appName: m.state.Apps[1].Name, registeredAt: time.Now()}}
+
+	// Scope to the first app's project so the other one leaves the scope
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{derefOr(m.state.Apps[0].Project)})
+	if !m.pinnedAppKeys()[inScope] || m.pinnedAppKeys()[other] {
+		t.Fatalf("test setup: expected only %s in scope, got %v", inScope, m.pinnedAppKeys())
+	}
+
+	start := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	m.pruneOutOfScope(start)
+	m.pruneOutOfScope(start.Add(9 * time.Minute))
+	if _, ok := m.diffCache.get(other, "a..main"); !ok {
+		t.Fatal("data of an app out of scope for less than prune_after should be kept")
+	}
+
+	m.pruneOutOfScope(start.Add(10 * time.Minute))
+	if _, ok := m.diffCache.get(other, "a..main"); ok {
+		t.Error("the cached diff of an app out of scope for prune_after should be released")
+	}
+	if _, ok := m.revisionInfo[other]; ok {
+		t.Error("revision lookups of the released app should be dropped")
+	}
+	if _, ok := m.watchedOps[other]; !ok {
+		t.Error("a watched operation waits for its running operation and should not be released")
+	}
+	if _, ok := m.diffCache.get(inScope, "a..main"); !ok {
+		t.Error("apps in scope keep their data")
+	}
+	if len(m.outOfScopeSince) != 0 {
+		t.Errorf("nothing is left to track, got %v", m.outOfScopeSince)
+	}
+}
+
+func TestPruneOutOfScope_ComingBackResetsTheClock(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Memory: config.MemoryConfig{PruneAfter: "10m"}}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	key := appKey("test-app", strp("test-namespace"))
+	m.diffCache.put(key, "a..main", []model.DiffSection{{Kind: "Deployment", Name: "web"}})
+
+	start := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{"nowhere"})
+	m.pruneOutOfScope(start)
+	m.state.Selections.ScopeProjects = nil
+	m.pruneOutOfScope(start.Add(5 * time.Minute))
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{"nowhere"})
+	m.pruneOutOfScope(start.Add(6 * time.Minute))
+	m.pruneOutOfScope(start.Add(12 * time.Minute))
+	if _, ok := m.diffCache.get(key, "a..main"); !ok {
+		t.Error("an app back in scope starts its out-of-scope time over")
+	}
+
+	m.config.Memory.PruneAfter = "0"
+	m.pruneOutOfScope(start.Add(time.Hour))
+	if _, ok := m.diffCache.get(key, "a..main"); !ok {
+		t.Error(`prune_after = "0" never releases anything`)
+	}
+}
+
+func TestReleaseApp_KeepsRolloutsOfSameNamedAppInOtherNamespace(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	rollout := treeview.ResourceSelection{AppName: "web", Namespace: "default", Name: "web"}
+	released, kept := rolloutKey(strp("team-a"), rollout), rolloutKey(strp("team-b"), rollout)
+	m.rolloutInfo = map[string]rolloutInfo{released: {}, kept: {}}
+
+	m.releaseApp(appKey("web", strp("team-a")))
+	if _, ok := m.rolloutInfo[released]; ok {
+		t.Error("the released app's Rollout status should be dropped")
+	}
+	if _, ok := m.rolloutInfo[kept]; !ok {
+		t.Error("an app of the same name in another namespace keeps its Rollout status")
+	}
+}
+
+func TestPruneOutOfScope_ReleasesRolloutStatuses(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Memory: config.MemoryConfig{PruneAfter: "10m"}}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	app := m.state.Apps[1]
+	key := rolloutKey(app.AppNamespace, treeview.ResourceSelection{AppName: app.Name, Namespace: "default", Name: "web"})
+	m.rolloutInfo = map[string]rolloutInfo{key: {}}
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{derefOr(m.state.Apps[0].Project)})
+
+	start := time.Now()
+	m.pruneOutOfScope(start)
+	m.pruneOutOfScope(start.Add(10 * time.Minute))
+	if _, ok := m.rolloutInfo[key]; ok {
+		t.Error("the Rollout statuses of an app out of scope for prune_after should be released")
+	}
+}
+
+func TestPruneTick_RunsInDeterministicMode(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Memory: config.MemoryConfig{PruneAfter: "10m"}}
+	if m.pruneTick() == nil {
+		t.Error("pruning runs on the wall clock, so it should not stop in e2e runs")
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// operationsInFlight returns the watched operations still running, by app
+// name. An operation the watch stream has not shown yet counts as running,
+// up to watchedOperationTTL.
+func (m *Model) operationsInFlight() []model.QuitOperation {
+	var ops []model.QuitOperation
+	for _, op := range m.watchedOps {
+		if !op.confirmed || op.expired() {
+			continue
+		}
+		phase := "Pending"
+		if app := m.startedOperation(op); app != nil {
+			if isFinishedOperation(app.OperationPhase) {
+				continue
+			}
+			phase = app.OperationPhase
+		}
+		q := model.QuitOperation{Kind: op.kind, AppName: op.appName, Phase: phase}
+		if op.appNamespace != "" {
+			ns := op.appNamespace
+			q.AppNamespace = &ns
+		}
+		ops = append(ops, q)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].AppName < ops[j].AppName })
+	return ops
+}
+
+// handleQuit quits, unless syncs or rollbacks started with Watch are still
+// running: then it asks whether to quit anyway, keep watching or detach.
+// A forced quit (:q!, ZQ) and a second quit from the dialog don't ask.
+func (m *Model) handleQuit(msg model.QuitMsg) tea.Cmd {
+	if msg.Force || m.state.Mode == model.ModeConfirmQuit {
+		return tea.Quit
+	}
+	ops := m.operationsInFlight()
+	if len(ops) == 0 {
+		return tea.Quit
+	}
+	cblog.With("component", "quit").Info("Quit with operations still watched", "operations", len(ops))
+	m.state.Modals.QuitConfirm = &model.QuitConfirmState{Operations: ops, PrevMode: m.state.Mode}
+	m.state.Mode = model.ModeConfirmQuit
+	return nil
+}
+
+// handleConfirmQuitKeys handles input in the quit dialog
+func (m *Model) handleConfirmQuitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.QuitConfirm
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	switch msg.String() {
+	case "q", "y":
+		return m, func() tea.Msg { return model.QuitMsg{Force: true} }
+	case "d":
+		m.detachNote = detachInstructions(st.Operations, m.currentContextName)
+		return m, func() tea.Msg { return model.QuitMsg{Force: true} }
+	case "n", "esc":
+		m.state.Modals.QuitConfirm = nil
+		m.state.Mode = st.PrevMode
+		return m, m.showStatusNote(fmt.Sprintf("Still watching %d operation(s)", len(st.Operations)))
+	}
+	return m, nil
+}
+
+// detachInstructions is printed after quitting with detach: the operations
+// keep running on the server, so it lists them with the argocd commands
+// that pick up the wait in the shell
+func detachInstructions(ops []model.QuitOperation, contextName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "argonaut quit with %d operation(s) still running on the server. To keep watching:\n\n", len(ops))
+	for _, op := range ops {
+		cmd := "argocd app wait " + op.AppName
+		if op.AppNamespace != nil {
+			cmd += " --app-namespace " + *op.AppNamespace
+		}
+		if contextName != "" {
+			cmd += " --argocd-context " + contextName
+		}
+		fmt.Fprintf(&b, "  %s --operation   # %s, %s\n", cmd, op.Kind, op.Phase)
+	}
+	return b.String()
+}
+
+// renderConfirmQuitModal renders the quit dialog with the operations that
+// are still running
+func (m *Model) renderConfirmQuitModal() string {
+	st := m.state.Modals.QuitConfirm
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(50, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	titleStyle := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
+
+	title := fmt.Sprintf("%d operation(s) still being watched", len(st.Operations))
+	lines := []string{titleStyle.Render(truncateWithEllipsis(title, innerWidth)), ""}
+
+	const maxListed = 8
+	for i, op := range st.Operations {
+		if i == maxListed {
+			lines = append(lines, dim.Render(fmt.Sprintf("  … and %d more", len(st.Operations)-maxListed)))
+			break
+		}
+		name := op.AppName
+		if op.AppNamespace != nil {
+			name = *op.AppNamespace + "/" + name
+		}
+		row := fmt.Sprintf("  %s %s", op.Kind, name)
+		lines = append(lines, truncateWithEllipsis(row, innerWidth-len(op.Phase)-1)+" "+lipgloss.NewStyle().Foreground(progressColor).Render(op.Phase))
+	}
+
+	lines = append(lines, "", lipgloss.NewStyle().Width(innerWidth).Render(
+		"They keep running on the server either way; quitting only stops watching them here."))
+	help := "q quit anyway • Esc keep watching • d detach (quit, print argocd app wait commands)"
+	lines = append(lines, "", dim.Width(innerWidth).Render(help))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(yellowBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// isQuit reports whether cmd quits the program
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func buildWatchedSyncModel(t *testing.T) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(120, 30)
+	before := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	m.state.Apps[0].OperationStartedAt = &before
+	m.state.Apps[0].OperationPhase = "Succeeded"
+	m.trackWatchedOperation("sync", "test-app", m.state.Apps[0].AppNamespace)
+	m.confirmWatchedOperations("sync", appKey("test-app", m.state.Apps[0].AppNamespace))
+
+	started := before.Add(time.Hour)
+	m.state.Apps[0].OperationStartedAt = &started
+	m.state.Apps[0].OperationPhase = "Running"
+	return m
+}
+
+func TestQuit_WithoutWatchedOperationsQuits(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	if !isQuit(m.handleQuit(model.QuitMsg{})) {
+		t.Error("nothing is watched, so quit should not ask")
+	}
+}
+
+func TestQuit_AsksWhileWatchedOperationRuns(t *testing.T) {
+	m := buildWatchedSyncModel(t)
+
+	if cmd := m.handleQuit(model.QuitMsg{}); cmd != nil {
+		t.Fatal("quit should wait for an answer")
+	}
+	if m.state.Mode != model.ModeConfirmQuit {
+		t.Fatalf("mode = %s, want the quit dialog", m.state.Mode)
+	}
+	ops := m.state.Modals.QuitConfirm.Operations
+	if len(ops) != 1 || ops[0].AppName != "test-app" || ops[0].Phase != "Running" {
+		t.Errorf("operations = %+v", ops)
+	}
+	view := stripANSI(m.renderConfirmQuitModal())
+	if !strings.Contains(view, "1 operation(s) still being watched") || !strings.Contains(view, "test-namespace/test-app") {
+		t.Errorf("dialog should list the running sync:\n%s", view)
+	}
+
+	// Keep watching returns to where the quit came from
+	m.Update(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal || m.state.Modals.QuitConfirm != nil {
+		t.Fatalf("esc should keep watching, mode = %s", m.state.Mode)
+	}
+
+	// Quitting again from the dialog does not ask twice
+	m.handleQuit(model.QuitMsg{})
+	if !isQuit(m.handleQuit(model.QuitMsg{})) {
+		t.Error("a second quit from the dialog should quit")
+	}
+}
+
+func TestQuit_QuitAnywayAndForce(t *testing.T) {
+	m := buildWatchedSyncModel(t)
+	m.handleQuit(model.QuitMsg{})
+	_, cmd := m.handleConfirmQuitKeys(keyPress("q"))
+	if msg, ok := cmd().(model.QuitMsg); !ok || !msg.Force {
+		t.Errorf("q should force the quit, got %#v", msg)
+	}
+
+	m = buildWatchedSyncModel(t)
+	if !isQuit(m.handleQuit(model.QuitMsg{Force: true})) {
+		t.Error(":q! should quit without asking")
+	}
+}
+
+func TestQuit_DetachPrintsWaitCommands(t *testing.T) {
+	m := buildWatchedSyncModel(t)
+	m.currentContextName = "prod"
+	m.handleQuit(model.QuitMsg{})
+	_, cmd := m.handleConfirmQuitKeys(keyPress("d"))
+	if msg, ok := cmd().(model.QuitMsg); !ok || !msg.Force {
+		t.Fatalf("detach should quit, got %#v", msg)
+	}
+	want := "argocd app wait test-app --app-namespace test-namespace --argocd-context prod --operation"
+	if !strings.Contains(m.detachNote, want) {
+		t.Errorf("detach note should say how to keep waiting:\n%s", m.detachNote)
+	}
+}
+
+func TestQuit_FinishedAndUnconfirmedOperationsDoNotAsk(t *testing.T) {
+	m := buildWatchedSyncModel(t)
+	m.state.Apps[0].OperationPhase = "Succeeded"
+	if !isQuit(m.handleQuit(model.QuitMsg{})) {
+		t.Error("a finished operation should not hold the quit")
+	}
+	if m.checkWatchedOperations(); len(m.watchedOps) != 0 {
+		t.Error("the finished operation should be forgotten")
+	}
+
+	// A request that failed was never confirmed by the server
+	m = buildDeleteTestModel(120, 30)
+	m.trackWatchedOperation("rollback", "test-app", m.state.Apps[0].AppNamespace)
+	if !isQuit(m.handleQuit(model.QuitMsg{})) {
+		t.Error("an operation the server never accepted should not hold the quit")
+	}
+}
+
+func TestQuit_ConfirmedBySyncCompleted(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Modals.ConfirmSyncWatch = false
+	m.trackWatchedOperation("sync", "test-app", m.state.Apps[0].AppNamespace)
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Success: true, SwitchEpoch: m.switchEpoch})
+	if len(m.operationsInFlight()) != 1 {
+		t.Fatal("an accepted sync should count as running until the watch shows it finished")
+	}
+
+	m.trackWatchedOperation("sync", "zzz-other-app", nil)
+	m.Update(model.SyncCompletedMsg{AppName: "zzz-other-app", Success: false, SwitchEpoch: m.switchEpoch})
+	if _, ok := m.watchedOps[appKey("zzz-other-app", nil)]; ok {
+		t.Error("a cancelled sync should be forgotten")
+	}
+}
+
+func TestQuit_WatchedOperationsExpireInDeterministicMode(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildWatchedSyncModel(t)
+	key := appKey("test-app", m.state.Apps[0].AppNamespace)
+	op := m.watchedOps[key]
+	op.registeredAt = time.Now().Add(-watchedOperationTTL - time.Minute)
+	m.watchedOps[key] = op
+
+	if !isQuit(m.handleQuit(model.QuitMsg{})) {
+		t.Error("an operation watched for longer than the TTL should not hold the quit")
+	}
+	if m.checkWatchedOperations(); len(m.watchedOps) != 0 {
+		t.Error("the expired operation should be forgotten")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestReducedMotion_NoAnimationsOrTransientEffects(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{ReducedMotion: true}}
+
+	if m.spinnerTick() != nil {
+		t.Error("the spinner should not animate")
+	}
+
+	_, cmd := m.Update(model.RefreshCompletedMsg{AppName: "test-app", Success: true})
+	if cmd != nil || m.state.UI.RefreshFlashApps["test-app"] {
+		t.Error("a refresh should not flash the row")
+	}
+
+	m.handleOpenErrors()
+	if m.willDesaturateBase() {
+		t.Error("the view behind a modal should not be dimmed")
+	}
+}
+
+func TestReducedMotion_WaitShowsNoLiveCounter(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].Health = "Progressing"
+	m.startWait("test-app", strp("test-namespace"), []string{model.WaitHealthy}, time.Minute)
+	if modal := stripANSI(m.renderWaitModal()); !strings.Contains(modal, "0s of 1m0s") {
+		t.Fatalf("expected the elapsed time by default, got:\n%s", modal)
+	}
+
+	m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{ReducedMotion: true}}
+	modal := stripANSI(m.renderWaitModal())
+	if strings.Contains(modal, "0s of") || !strings.Contains(modal, "timeout 1m0s") {
+		t.Errorf("expected only the timeout while waiting, got:\n%s", modal)
+	}
+}
+
+func TestLowBandwidth_ImpliesReducedMotion(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	if len(lowBandwidthOptions(m.config)) != 0 {
+		t.Error("the default program options are kept when the mode is off")
+	}
+
+	m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{LowBandwidth: true}}
+	if !m.reducedMotion() {
+		t.Error("low bandwidth mode should turn off animations")
+	}
+	if len(lowBandwidthOptions(m.config)) != 2 {
+		t.Error("low bandwidth mode should cap the frame rate and the color palette")
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// renderProfileFrames is how many of the latest frames :profile render keeps
+const renderProfileFrames = 300
+
+// renderFrame is one measured call of View
+type renderFrame struct {
+	view   string
+	took   time.Duration
+	allocs uint64
+	bytes  uint64
+}
+
+// renderProfile records how long frames take to render while :profile
+// render runs. Allocations are counted process-wide, so work done by
+// background streams during a frame is counted too.
+type renderProfile struct {
+	recording bool
+	frames    []renderFrame
+}
+
+// renderViewStats sums up the recorded frames of one view
+type renderViewStats struct {
+	view   string
+	frames int
+	total  time.Duration
+	p95    time.Duration
+	max    time.Duration
+	allocs uint64
+	bytes  uint64
+}
+
+// measure starts timing a frame of the view; the returned func ends it.
+// Reading the memory stats stops the world briefly, which is why frames are
+// only measured while recording.
+func (p *renderProfile) measure(view string) func() {
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	return func() {
+		took := time.Since(start)
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		p.frames = append(p.frames, renderFrame{
+			view:   view,
+			took:   took,
+			allocs: after.Mallocs - before.Mallocs,
+			bytes:  after.TotalAlloc - before.TotalAlloc,
+		})
+		if len(p.frames) > renderProfileFrames {
+			p.frames = append(p.frames[:0], p.frames[len(p.frames)-renderProfileFrames:]...)
+		}
+	}
+}
+
+// stats sums up the frames by view, slowest in total first
+func (p *renderProfile) stats() []renderViewStats {
+	byView := map[string][]renderFrame{}
+	for _, f := range p.frames {
+		byView[f.view] = append(byView[f.view], f)
+	}
+	out := make([]renderViewStats, 0, len(byView))
+	for view, frames := range byView {
+		s := renderViewStats{view: view, frames: len(frames)}
+		took := make([]time.Duration, len(frames))
+		for i, f := range frames {
+			took[i] = f.took
+			s.total += f.took
+			s.allocs += f.allocs
+			s.bytes += f.bytes
+		}
+		slices.Sort(took)
+		s.max = took[len(took)-1]
+		s.p95 = took[(len(took)*95+99)/100-1]
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].total != out[j].total {
+			return out[i].total > out[j].total
+		}
+		return out[i].view < out[j].view
+	})
+	return out
+}
+
+// profileViewName names what View draws, for grouping frames: the view for
+// the main layout, or else the mode, like diff or help
+func (m *Model) profileViewName() string {
+	switch m.state.Mode {
+	case model.ModeNormal, model.ModeSearch, model.ModeCommand, model.ModeLoading:
+		return string(m.state.Navigation.View)
+	}
+	return string(m.state.Mode)
+}
+
+// handleProfileCommand runs :profile render. The first run starts recording;
+// the next one stops and shows where the time went.
+func (m *Model) handleProfileCommand(arg string) (tea.Model, tea.Cmd) {
+	if !strings.EqualFold(arg, "render") {
+		status := "Unknown :profile target. Try :profile render"
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+	}
+	if m.renderProfile == nil || !m.renderProfile.recording {
+		return m, m.startRenderProfile()
+	}
+	m.renderProfile.recording = false
+	cblog.With("component", "profile").Info("Render profile stopped", "frames", len(m.renderProfile.frames))
+	m.state.Mode = model.ModeProfile
+	return m, nil
+}
+
+// startRenderProfile starts recording frames, dropping earlier ones
+func (m *Model) startRenderProfile() tea.Cmd {
+	m.renderProfile = &renderProfile{recording: true}
+	cblog.With("component", "profile").Info("Render profile started")
+	return m.showStatusNote("Profiling renders; use the slow view, then :profile render again")
+}
+
+// handleProfileKeys handles input in the render profile modal
+func (m *Model) handleProfileKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+	case "r":
+		m.state.Mode = model.ModeNormal
+		return m, m.startRenderProfile()
+	}
+	return m, nil
+}
+
+// renderProfileModal shows the recorded frames by view
+func (m *Model) renderProfileModal() string {
+	p := m.renderProfile
+	if p == nil {
+		return ""
+	}
+	modalWidth := m.modalWidth(60, 2, 3)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Render profile")
+	lines := []string{title + " " + dim.Render(fmt.Sprintf("last %d frames, up to %d", len(p.frames), renderProfileFrames)), ""}
+
+	stats := p.stats()
+	if len(stats) == 0 {
+		lines = append(lines, "No frames were drawn while recording")
+	} else {
+		viewWidth := len("VIEW")
+		for _, s := range stats {
+			viewWidth = max(viewWidth, len(s.view))
+		}
+		ms := func(d time.Duration) string { return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000) }
+		row := func(view, frames, avg, p95, mx, allocs, kb string) string {
+			return fmt.Sprintf("%-*s %6s %8s %8s %8s %9s %9s", viewWidth, view, frames, avg, p95, mx, allocs, kb)
+		}
+		lines = append(lines, headerStyle.Render(row("VIEW", "FRAMES", "AVG", "P95", "MAX", "ALLOCS/F", "KB/F")))
+		for _, s := range stats {
+			n := uint64(s.frames)
+			lines = append(lines, row(s.view, fmt.Sprint(s.frames), ms(s.total/time.Duration(s.frames)), ms(s.p95), ms(s.max),
+				fmt.Sprint(s.allocs/n), fmt.Sprint(s.bytes/n/1024)))
+		}
+	}
+	lines = append(lines, "", dim.Render("r record again • Esc to close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(magentaBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestRenderProfile_RecordsFramesByView(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+
+	if _, cmd := m.handleProfileCommand("render"); cmd == nil || !m.renderProfile.recording {
+		t.Fatal(":profile render should start recording")
+	}
+	if line := stripANSI(m.renderStatusLine()); !strings.Contains(line, "[profiling]") {
+		t.Errorf("status line should show the profile is recording, got %q", line)
+	}
+	m.View()
+	m.View()
+	m.state.Mode = model.ModeHelp
+	m.View()
+	m.state.Mode = model.ModeNormal
+
+	m.handleProfileCommand("render")
+	if m.renderProfile.recording || m.state.Mode != model.ModeProfile {
+		t.Fatal("a second :profile render should stop and show the profile")
+	}
+	stats := m.renderProfile.stats()
+	got := map[string]int{}
+	for _, s := range stats {
+		got[s.view] = s.frames
+	}
+	if got["apps"] != 2 || got["help"] != 1 || len(got) != 2 {
+		t.Errorf("frames by view = %v, want 2 apps and 1 help", got)
+	}
+
+	m.View() // frames drawn after stopping are not recorded
+	if n := len(m.renderProfile.frames); n != 3 {
+		t.Errorf("recorded %d frames after stopping, want 3", n)
+	}
+	out := stripANSI(m.renderProfileModal())
+	for _, want := range []string{"Render profile", "last 3 frames", "VIEW", "ALLOCS/F", "apps", "help"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("profile modal should contain %q:\n%s", want, out)
+		}
+	}
+
+	m.Update(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal {
+		t.Errorf("esc should close the profile, mode %v", m.state.Mode)
+	}
+}
+
+func TestRenderProfile_KeepsLastFramesAndStats(t *testing.T) {
+	p := &renderProfile{recording: true}
+	for i := 0; i < renderProfileFrames+20; i++ {
+		p.measure("tree")()
+	}
+	if len(p.frames) != renderProfileFrames {
+		t.Fatalf("kept %d frames, want %d", len(p.frames), renderProfileFrames)
+	}
+
+	p.frames = nil
+	for i := 1; i <= 20; i++ {
+		p.frames = append(p.frames, renderFrame{view: "tree", took: time.Duration(i) * time.Millisecond, allocs: 10})
+	}
+	p.frames = append(p.frames, renderFrame{view: "apps", took: time.Millisecond})
+	stats := p.stats()
+	if len(stats) != 2 || stats[0].view != "tree" {
+		t.Fatalf("the slowest view should come first, got %+v", stats)
+	}
+	tree := stats[0]
+	if tree.p95 != 19*time.Millisecond || tree.max != 20*time.Millisecond || tree.allocs != 200 {
+		t.Errorf("tree stats = %+v", tree)
+	}
+}
+
+func TestProfileCommand_RejectsUnknownTarget(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+	_, cmd := m.handleProfileCommand("api")
+	if cmd == nil || m.renderProfile != nil {
+		t.Fatal("an unknown target should only be reported")
+	}
+	if msg, ok := cmd().(model.StatusChangeMsg); !ok || !strings.Contains(msg.Status, ":profile render") {
+		t.Errorf("status = %+v", msg)
+	}
+	if m.commandDiagnosis("profile api ") == "" || m.commandDiagnosis("profile render") != "" {
+		t.Error("the command bar should flag unknown profile targets only")
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/config"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+const reportUsage = `Usage:
+  argonaut [--context <name>] report [--project <name>]... [--since 24h] [-o file]
+
+Writes a markdown summary of the deploys and rollbacks in the period and the
+apps that are unhealthy now, for stand-up notes.
+
+  --project   only apps of this project; repeat or separate with commas
+  --since     how far back to look, e.g. 90m, 24h or 7d (default 24h)
+  -o          write to file instead of stdout
+`
+
+// reportEventWindow is how long before a deployment an "initiated rollback"
+// event still counts as the reason for it
+const reportEventWindow = 10 * time.Minute
+
+// stringList is a flag that can be repeated and takes comma-separated values
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+// parseSince reads a --since period: a Go duration, or whole days as "7d"
+func parseSince(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid --since %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since %q", s)
+	}
+	return d, nil
+}
+
+// runReportCommand runs "argonaut report ..." and returns the exit code
+func runReportCommand(args []string, loadServer func() (*model.Server, error), stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var projects stringList
+	fs.Var(&projects, "project", "")
+	since := fs.String("since", "24h", "")
+	output := fs.String("o", "", "")
+	fs.StringVar(output, "output", "", "")
+	if err := fs.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+		}
+		fmt.Fprint(stderr, reportUsage)
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprint(stderr, reportUsage)
+		return 2
+	}
+	period, err := parseSince(*since)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	server, err := loadServer()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	now := time.Now()
+	r, err := collectReport(api.NewApplicationService(server), projects, now.Add(-period))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	r.server = server.BaseURL
+	r.period = *since
+	r.generated = now
+	text := r.markdown()
+
+	if *output == "" || *output == "-" {
+		_, _ = io.WriteString(stdout, text)
+		return 0
+	}
+	if err := os.WriteFile(expandHomePath(*output), []byte(text), 0644); err != nil {
+		fmt.Fprintf(stderr, "Error: failed to write %s: %v\n", *output, err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "Wrote report to %s\n", *output)
+	return 0
+}
+
+// reportServer finds the Argo CD server the way the TUI does. Port-forward
+// and core contexts are refused: a one-shot command has no port-forward
+// to ride on.
+func reportServer(cfgPath string, opts config.ArgocdOpts) (*model.Server, error) {
+	cfg, err := config.LoadArgonautConfig()
+	if err != nil {
+		cfg = config.GetDefaultConfig()
+	}
+	if cfgPath == "" {
+		cfgPath = cfg.GetArgocdConfigPath()
+	}
+	if cfgPath == "" {
+		cfgPath = opts.Config
+	}
+	appcontext.SetRequestTimeout(cfg.GetRequestTimeout())
+	api.SetMaxResponseSize(cfg.GetMaxResponseSize())
+
+	server, err := loadArgoConfig(cfgPath, opts)
+	var pfErr *PortForwardModeError
+	var coreErr *CoreModeError
+	switch {
+	case errors.As(err, &pfErr):
+		return nil, fmt.Errorf("report does not support port-forward contexts; use a context with a server address")
+	case errors.As(err, &coreErr):
+		return nil, fmt.Errorf("report needs the Argo CD API server, which core installations don't run")
+	case err != nil:
+		return nil, err
+	}
+	cfg.ApplyServerSettings(server)
+	return server, nil
+}
+
+// reportDeploy is a deployment from an app's history
+type reportDeploy struct {
+	app      string
+	at       time.Time
+	revision string
+	by       string
+	// rollback marks a redeploy of the earlier deployment rollbackTo;
+	// history IDs start at 0, so the ID alone cannot tell
+	rollback   bool
+	rollbackTo int
+}
+
+// reportApp is an app that is unhealthy now
+type reportApp struct {
+	name, health, sync, message string
+}
+
+// standupReport is what "argonaut report" writes
+type standupReport struct {
+	server    string
+	projects  []string
+	period    string
+	generated time.Time
+	deploys   []reportDeploy
+	rollbacks []reportDeploy
+	unhealthy []reportApp
+}
+
+// collectReport reads the deployments since the given time and the apps
+// that are unhealthy now. Events are read only for apps deployed in the
+// period, to tell rollbacks apart. Each request gets the configured
+// timeout of its own.
+func collectReport(svc *api.ApplicationService, projects []string, since time.Time) (*standupReport, error) {
+	ctx, cancel := appcontext.WithAPITimeout(context.Background())
+	apps, err := svc.ListApplicationsWithHistory(ctx, projects)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	r := &standupReport{projects: projects}
+	eventsServed := true
+	for _, app := range apps {
+		name := app.Metadata.Name
+		if h := app.Status.Health.Status; h != "" && h != "Healthy" && h != "Suspended" {
+			r.unhealthy = append(r.unhealthy, reportApp{name, h, app.Status.Sync.Status, app.Status.Health.Message})
+		}
+
+		var recent bool
+		for _, h := range app.Status.History {
+			recent = recent || !h.DeployedAt.Before(since)
+		}
+		if !recent {
+			continue
+		}
+		var ns *string
+		if app.Metadata.Namespace != "" {
+			ns = &app.Metadata.Namespace
+		}
+		var events []api.ApplicationEvent
+		if eventsServed {
+			ctx, cancel := appcontext.WithAPITimeout(context.Background())
+			events, err = svc.ListApplicationEvents(ctx, name, ns)
+			cancel()
+			if err != nil {
+				// Rollbacks are then told apart by their revision alone
+				cblog.With("component", "report").Warn("Could not read app events", "app", name, "err", err)
+				// A server without the events endpoint isn't asked for the other apps
+				eventsServed = !api.IsEndpointMissing(err)
+			}
+		}
+		for _, d := range classifyDeploys(name, app.Status.History, events, since) {
+			if d.rollback {
+				r.rollbacks = append(r.rollbacks, d)
+			} else {
+				r.deploys = append(r.deploys, d)
+			}
+		}
+	}
+	byTime := func(ds []reportDeploy) {
+		sort.SliceStable(ds, func(i, j int) bool { return ds[i].at.Before(ds[j].at) })
+	}
+	byTime(r.deploys)
+	byTime(r.rollbacks)
+	sort.Slice(r.unhealthy, func(i, j int) bool { return r.unhealthy[i].name < r.unhealthy[j].name })
+	return r, nil
+}
+
+// classifyDeploys returns the app's deployments since the given time. A
+// deployment is a rollback when an "initiated rollback" event came just
+// before it, or, since events expire after about an hour, when it
+// redeployed the revision of an earlier deployment other than the one
+// right before it.
+func classifyDeploys(app string, history []api.DeploymentHistory, events []api.ApplicationEvent, since time.Time) []reportDeploy {
+	revisionOf := func(h api.DeploymentHistory) string {
+		if len(h.Revisions) > 0 {
+			return strings.Join(h.Revisions, ",")
+		}
+		return h.Revision
+	}
+	var out []reportDeploy
+	for i, h := range history {
+		if h.DeployedAt.Before(since) {
+			continue
+		}
+		d := reportDeploy{app: app, at: h.DeployedAt, revision: revisionOf(h)}
+		if by := h.InitiatedBy; by != nil {
+			d.by = by.Username
+			if by.Automated {
+				d.by = "auto-sync"
+			}
+		}
+		for _, e := range events {
+			if id, ok := e.RollbackTarget(); ok && !e.At().After(h.DeployedAt) && h.DeployedAt.Sub(e.At()) <= reportEventWindow {
+				d.rollback, d.rollbackTo = true, id
+			}
+		}
+		if !d.rollback && i > 0 && revisionOf(history[i-1]) != d.revision {
+			for j := i - 2; j >= 0; j-- {
+				if revisionOf(history[j]) == d.revision {
+					d.rollback, d.rollbackTo = true, history[j].ID
+					break
+				}
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// markdown renders the report for pasting into stand-up notes
+func (r *standupReport) markdown() string {
+	var s strings.Builder
+	scope := "all projects"
+	if len(r.projects) > 0 {
+		scope = strings.Join(r.projects, ", ")
+	}
+	fmt.Fprintf(&s, "# Argo CD: %s, last %s\n\n", scope, r.period)
+	fmt.Fprintf(&s, "_%s, %s_\n", r.server, r.generated.Format("Jan 2 15:04 MST"))
+
+	deployLine := func(d reportDeploy) string {
+		line := fmt.Sprintf("- %s **%s**", d.at.In(r.generated.Location()).Format("Jan 2 15:04"), d.app)
+		if d.revision != "" {
+			line += " `" + shortRevision(d.revision) + "`"
+		}
+		if d.rollback {
+			line += fmt.Sprintf(", back to deployment %d", d.rollbackTo)
+		}
+		if d.by != "" {
+			line += " by " + d.by
+		}
+		return line + "\n"
+	}
+
+	fmt.Fprintf(&s, "\n## Deploys (%d)\n\n", len(r.deploys))
+	if len(r.deploys) == 0 {
+		s.WriteString("None.\n")
+	}
+	for _, d := range r.deploys {
+		s.WriteString(deployLine(d))
+	}
+
+	fmt.Fprintf(&s, "\n## Rollbacks (%d)\n\n", len(r.rollbacks))
+	if len(r.rollbacks) == 0 {
+		s.WriteString("None.\n")
+	}
+	for _, d := range r.rollbacks {
+		s.WriteString(deployLine(d))
+	}
+
+	fmt.Fprintf(&s, "\n## Unhealthy now (%d)\n\n", len(r.unhealthy))
+	if len(r.unhealthy) == 0 {
+		s.WriteString("None.\n")
+	}
+	for _, a := range r.unhealthy {
+		line := fmt.Sprintf("- **%s**: %s", a.name, a.health)
+		if a.sync != "" {
+			line += ", " + a.sync
+		}
+		if msg := strings.TrimSpace(strings.SplitN(a.message, "\n", 2)[0]); msg != "" {
+			line += " — " + msg
+		}
+		s.WriteString(line + "\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestParseSince(t *testing.T) {
+	for in, want := range map[string]time.Duration{"24h": 24 * time.Hour, "90m": 90 * time.Minute, "7d": 7 * 24 * time.Hour} {
+		if got, err := parseSince(in); err != nil || got != want {
+			t.Errorf("parseSince(%q) = %v, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{"", "yesterday", "0d", "-1h"} {
+		if _, err := parseSince(in); err == nil {
+			t.Errorf("parseSince(%q) should fail", in)
+		}
+	}
+}
+
+func TestClassifyDeploys(t *testing.T) {
+	base := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	history := []api.DeploymentHistory{
+		{ID: 1, Revision: "aaa", DeployedAt: base.Add(-48 * time.Hour)},
+		{ID: 2, Revision: "bbb", DeployedAt: base.Add(time.Hour), InitiatedBy: &api.OperationInitiator{Automated: true}},
+		{ID: 3, Revision: "aaa", DeployedAt: base.Add(2 * time.Hour), InitiatedBy: &api.OperationInitiator{Username: "bob"}},
+		{ID: 4, Revision: "ccc", DeployedAt: base.Add(3 * time.Hour)},
+		{ID: 5, Revision: "ccc", DeployedAt: base.Add(4 * time.Hour)},
+	}
+	events := []api.ApplicationEvent{
+		{Message: "carol initiated rollback to 4", LastTimestamp: base.Add(4*time.Hour - time.Minute)},
+	}
+	got := classifyDeploys("checkout", history, events, base)
+	if len(got) != 4 {
+		t.Fatalf("deployments before the period should be left out, got %+v", got)
+	}
+	if got[0].rollback || got[0].by != "auto-sync" {
+		t.Errorf("a new revision is a deploy: %+v", got[0])
+	}
+	if !got[1].rollback || got[1].rollbackTo != 1 || got[1].by != "bob" {
+		t.Errorf("going back to an earlier revision is a rollback: %+v", got[1])
+	}
+	if got[2].rollback {
+		t.Errorf("got %+v", got[2])
+	}
+	if !got[3].rollback || got[3].rollbackTo != 4 {
+		t.Errorf("a rollback event should mark the deployment after it: %+v", got[3])
+	}
+}
+
+func TestClassifyDeploys_RollbackToTheFirstDeployment(t *testing.T) {
+	base := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	history := []api.DeploymentHistory{
+		{ID: 0, Revision: "aaa", DeployedAt: base.Add(time.Hour)},
+		{ID: 1, Revision: "bbb", DeployedAt: base.Add(2 * time.Hour)},
+		{ID: 2, Revision: "aaa", DeployedAt: base.Add(3 * time.Hour)},
+		{ID: 3, Revision: "ccc", DeployedAt: base.Add(4 * time.Hour)},
+		{ID: 4, Revision: "ddd", DeployedAt: base.Add(5 * time.Hour)},
+	}
+	events := []api.ApplicationEvent{
+		{Message: "carol initiated rollback to 0", LastTimestamp: base.Add(5*time.Hour - time.Minute)},
+	}
+	got := classifyDeploys("checkout", history, events, base)
+	if len(got) != 5 {
+		t.Fatalf("got %+v", got)
+	}
+	if got[0].rollback || got[1].rollback || got[3].rollback {
+		t.Errorf("new revisions are deploys: %+v", got)
+	}
+	if !got[2].rollback || got[2].rollbackTo != 0 {
+		t.Errorf("redeploying deployment 0 is a rollback: %+v", got[2])
+	}
+	if !got[4].rollback || got[4].rollbackTo != 0 {
+		t.Errorf("a rollback event to deployment 0 is a rollback: %+v", got[4])
+	}
+	r := &standupReport{generated: base, rollbacks: got[4:]}
+	if md := r.markdown(); !strings.Contains(md, "back to deployment 0") {
+		t.Errorf("the report should name deployment 0:\n%s", md)
+	}
+}
+
+func TestRunReportCommand(t *testing.T) {
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-72 * time.Hour).UTC().Format(time.RFC3339)
+	var eventRequests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			eventRequests = append(eventRequests, r.URL.Path)
+			w.Write([]byte(`{"items": []}`))
+			return
+		}
+		if got := r.URL.Query()["projects"]; len(got) != 2 {
+			t.Errorf("projects = %v", got)
+		}
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "checkout"}, "status": {"health": {"status": "Healthy"}, "history": [
+				{"id": 7, "revision": "0123456789abcdef", "deployedAt": "` + recent + `", "initiatedBy": {"username": "alice"}}
+			]}},
+			{"metadata": {"name": "cart"}, "status": {"health": {"status": "Degraded", "message": "Back-off restarting failed container\nmore"}, "sync": {"status": "Synced"}, "history": [
+				{"id": 2, "revision": "fedcba", "deployedAt": "` + old + `"}
+			]}}
+		]}`))
+	}))
+	defer ts.Close()
+
+	var out, errOut bytes.Buffer
+	load := func() (*model.Server, error) { return &model.Server{BaseURL: ts.URL, Token: "t"}, nil }
+	code := runReportCommand([]string{"--project", "ecommerce,payments", "--since", "24h"}, load, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, errOut.String())
+	}
+	report := out.String()
+	for _, want := range []string{
+		"# Argo CD: ecommerce, payments, last 24h",
+		"## Deploys (1)", "**checkout** `01234567` by alice",
+		"## Rollbacks (0)",
+		"## Unhealthy now (1)", "- **cart**: Degraded, Synced — Back-off restarting failed container\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+	if len(eventRequests) != 1 || eventRequests[0] != "/api/v1/applications/checkout/events" {
+		t.Errorf("events should only be read for apps deployed in the period, got %v", eventRequests)
+	}
+}
+
+func TestRunReportCommand_Usage(t *testing.T) {
+	load := func() (*model.Server, error) {
+		t.Fatal("the server should not be loaded for bad arguments")
+		return nil, nil
+	}
+	var out, errOut bytes.Buffer
+	if code := runReportCommand([]string{"--since", "soon"}, load, &out, &errOut); code != 2 {
+		t.Errorf("exit code %d", code)
+	}
+	if code := runReportCommand([]string{"extra"}, load, &out, &errOut); code != 2 || !strings.Contains(errOut.String(), "Usage:") {
+		t.Errorf("exit code %d: %s", code, errOut.String())
+	}
+}
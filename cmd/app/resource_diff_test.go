@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+func buildResourceDiffTreeModel(srv *httptest.Server, sync string) *Model {
+	m := buildDeleteTestModel(140, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.state.Navigation.View = model.ViewTree
+	m.state.UI.TreeApp = &model.TreeAppInfo{Name: "test-app", AppNamespace: m.state.Apps[0].AppNamespace}
+
+	m.treeView = treeview.NewTreeView(0, 0)
+	ns := "prod"
+	m.treeView.UpsertAppTree("test-app", &api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "d1", Group: "apps", Version: "v1", Kind: "Deployment", Name: "web", Namespace: &ns},
+	}})
+	m.treeView.SetResourceStatuses("test-app", []api.ResourceStatus{
+		{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web", Status: sync},
+	})
+	m.treeView.SetSelectedIndex(1) // 0 is the synthetic Application root
+	return m
+}
+
+func TestResourceDiff_FetchesOnlyTheSelectedResource(t *testing.T) {
+	var full atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("kind") == "" {
+			full.Add(1)
+		} else if q.Get("group") != "apps" || q.Get("kind") != "Deployment" || q.Get("namespace") != "prod" ||
+			q.Get("name") != "web" || q.Get("appNamespace") != "test-namespace" {
+			t.Errorf("unexpected managed-resources query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer srv.Close()
+	m := buildResourceDiffTreeModel(srv, "OutOfSync")
+
+	_, cmd := m.handleResourceDiff()
+	if cmd == nil || m.state.Diff == nil || !m.state.Diff.Loading {
+		t.Fatal("expected the diff to load")
+	}
+	if msg, ok := cmd().(model.SetModeMsg); !ok || msg.Mode != model.ModeNoDiff {
+		t.Errorf("a resource the app does not report should show no diff, got %#v", msg)
+	}
+	if full.Load() != 0 {
+		t.Error("the whole app's diff should not be requested")
+	}
+}
+
+func TestResourceDiff_SyncedResourceSkipsTheRequest(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	m := buildResourceDiffTreeModel(srv, "Synced")
+
+	_, cmd := m.handleResourceDiff()
+	if msg, ok := cmd().(model.SetModeMsg); !ok || msg.Mode != model.ModeNoDiff {
+		t.Errorf("expected no diff, got %#v", msg)
+	}
+	if calls.Load() != 0 {
+		t.Error("a Synced resource should not be fetched")
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// manifestSavedMsg reports the outcome of :save
+type manifestSavedMsg struct {
+	path string
+	err  error
+}
+
+// clearStatusNoteMsg hides the status line note after a short while
+type clearStatusNoteMsg struct{}
+
+// argoWebURL returns the Argo CD web UI link to an application, or to one of
+// its resources when res is not nil. The UI selects the resource from the
+// node parameter: group/Kind/namespace/name/0.
+func argoWebURL(server *model.Server, appName string, appNamespace *string, res *treeview.ResourceSelection) string {
+	base := strings.TrimRight(server.BaseURL, "/")
+	if root := strings.Trim(server.GrpcWebRootPath, "/"); root != "" {
+		base += "/" + root
+	}
+	link := base + "/applications/"
+	if ns := derefOr(appNamespace); ns != "" {
+		link += url.PathEscape(ns) + "/"
+	}
+	link += url.PathEscape(appName)
+	if res != nil {
+		node := strings.Join([]string{res.Group, res.Kind, res.Namespace, res.Name, "0"}, "/")
+		link += "?" + url.Values{"resource": {""}, "node": {node}}.Encode()
+	}
+	return link
+}
+
+// handleCopyResourceLink copies the web UI link of the resource under the
+// tree cursor, or of the application when the cursor is on an app node
+func (m *Model) handleCopyResourceLink() (tea.Model, tea.Cmd) {
+	if m.treeView == nil || m.state.Server == nil || m.state.UI.TreeApp == nil {
+		return m, nil
+	}
+	var link string
+	if sel, ok := m.treeView.CurrentResource(); ok {
+		link = argoWebURL(m.state.Server, sel.AppName, m.treeAppNamespace(sel.AppName), &sel)
+	} else {
+		app := m.state.UI.TreeApp
+		link = argoWebURL(m.state.Server, app.Name, app.AppNamespace, nil)
+	}
+	return m, tea.Batch(clipboard.CopyCmd(link), m.showCopiedStatus())
+}
+
+// defaultManifestFilename names a saved manifest after the resource, e.g.
+// deployment-guestbook-ui.yaml
+func defaultManifestFilename(sel treeview.ResourceSelection) string {
+	name := strings.ToLower(sel.Kind + "-" + sel.Name)
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '-'
+		}
+		return r
+	}, name)
+	return name + ".yaml"
+}
+
+// handleSaveManifestPrompt opens the command line with ":save <file>" for
+// the resource under the cursor so the file name can be edited first
+func (m *Model) handleSaveManifestPrompt() (tea.Model, tea.Cmd) {
+	if m.treeView == nil {
+		return m, nil
+	}
+	sel, ok := m.treeView.CurrentResource()
+	if !ok {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Select a resource to save its manifest"} }
+	}
+	next, cmd := m.handleEnterCommandMode()
+	m.state.UI.Command = "save " + defaultManifestFilename(sel)
+	m.inputComponents.SetCommandValue(m.state.UI.Command)
+	m.inputComponents.commandInput.CursorEnd()
+	return next, cmd
+}
+
+// handleSaveManifestCommand runs ":save [file]": writes the live manifest of
+// the resource under the tree cursor as YAML. Existing files are kept.
+func (m *Model) handleSaveManifestCommand(path string) (tea.Model, tea.Cmd) {
+	if m.state.Navigation.View != model.ViewTree || m.treeView == nil {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Open an app's resources to save a manifest"} }
+	}
+	sel, ok := m.treeView.CurrentResource()
+	if !ok {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Select a resource to save its manifest"} }
+	}
+	if path == "" {
+		path = defaultManifestFilename(sel)
+	}
+	path = expandHomePath(path)
+
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return m, nil
+	}
+	params := m.liveResourceParams(sel)
+	return m, func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		manifest, err := api.NewApplicationService(server).GetResourceManifest(ctx, params)
+		if err != nil {
+			return manifestSavedMsg{path: path, err: err}
+		}
+		return manifestSavedMsg{path: path, err: writeManifestYAML(path, manifest)}
+	}
+}
+
+// liveResourceParams identifies a tree resource for the live manifest API
+func (m *Model) liveResourceParams(sel treeview.ResourceSelection) api.LiveResourceParams {
+	return api.LiveResourceParams{
+		AppName:      sel.AppName,
+		AppNamespace: m.treeAppNamespace(sel.AppName),
+		ResourceName: sel.Name,
+		Namespace:    sel.Namespace,
+		Kind:         sel.Kind,
+		Group:        sel.Group,
+		Version:      sel.Version,
+	}
+}
+
+// writeManifestYAML converts a live JSON manifest to YAML and writes it to a
+// new file. managedFields are dropped like kubectl get -o yaml does.
+func writeManifestYAML(path string, manifest []byte) error {
+	var obj map[string]any
+	if err := json.Unmarshal(manifest, &obj); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if meta, ok := obj["metadata"].(map[string]any); ok {
+		delete(meta, "managedFields")
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to convert manifest to YAML: %w", err)
+	}
+	return writeNewFile(path, out)
+}
+
+// expandHomePath expands a leading ~/ to the home directory
+func expandHomePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// writeNewFile writes data to a file that must not exist yet, so an export
+// never overwrites something the user keeps
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists", path)
+		}
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// handleManifestSaved reports where the manifest went, or why it did not
+func (m *Model) handleManifestSaved(msg manifestSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "save").Error("Failed to save manifest", "path", msg.path, "err", msg.err)
+		text := "Could not save manifest: " + extractUserFriendlyError(msg.err)
+		m.statusService.Error(text)
+		m.recordError("save", text, msg.err.Error(), nil)
+		return nil
+	}
+	m.statusService.Set("Saved manifest to " + msg.path)
+	return m.showStatusNote("Saved " + filepath.Base(msg.path))
+}
+
+// showStatusNote shows a short note in the status line for a moment
+func (m *Model) showStatusNote(note string) tea.Cmd {
+	m.state.UI.StatusNote = note
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusNoteMsg{} })
+}
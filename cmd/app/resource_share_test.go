@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+func TestArgoWebURL(t *testing.T) {
+	server := &model.Server{BaseURL: "https://argo.example.com/"}
+	res := &treeview.ResourceSelection{Group: "apps", Kind: "Deployment", Namespace: "web", Name: "guestbook-ui"}
+
+	if got, want := argoWebURL(server, "guestbook", strp("argocd"), nil), "https://argo.example.com/applications/argocd/guestbook"; got != want {
+		t.Errorf("app link = %q, want %q", got, want)
+	}
+	want := "https://argo.example.com/applications/guestbook?node=apps%2FDeployment%2Fweb%2Fguestbook-ui%2F0&resource="
+	if got := argoWebURL(server, "guestbook", nil, res); got != want {
+		t.Errorf("resource link = %q, want %q", got, want)
+	}
+
+	server.GrpcWebRootPath = "/argo-cd/"
+	if got := argoWebURL(server, "guestbook", nil, nil); got != "https://argo.example.com/argo-cd/applications/guestbook" {
+		t.Errorf("root path should prefix the link, got %q", got)
+	}
+}
+
+func TestDefaultManifestFilename(t *testing.T) {
+	sel := treeview.ResourceSelection{Kind: "ConfigMap", Name: "app config/v2"}
+	if got := defaultManifestFilename(sel); got != "configmap-app-config-v2.yaml" {
+		t.Errorf("defaultManifestFilename() = %q", got)
+	}
+}
+
+func TestSaveManifestPrompt_PrefillsCommand(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	m.handleKeyMsg(testKeyMsg("w"))
+	if m.state.Mode != model.ModeCommand {
+		t.Fatalf("expected command mode, got %s", m.state.Mode)
+	}
+	if got := m.inputComponents.GetCommandValue(); got != "save job-migrate.yaml" {
+		t.Errorf("command = %q, want the default file name", got)
+	}
+}
+
+func TestSaveManifest_WritesYAMLWithoutManagedFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("kind") != "Job" || q.Get("group") != "batch" || q.Get("resourceName") != "migrate" {
+			t.Errorf("unexpected manifest query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"manifest":"{\"apiVersion\":\"batch/v1\",\"kind\":\"Job\",\"metadata\":{\"name\":\"migrate\",\"managedFields\":[{\"manager\":\"argocd\"}]}}"}`))
+	}))
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+	path := filepath.Join(t.TempDir(), "job.yaml")
+
+	_, cmd := m.handleSaveManifestCommand(path)
+	msg, ok := cmd().(manifestSavedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected a saved manifest, got %#v", msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "kind: Job") || strings.Contains(string(data), "managedFields") {
+		t.Errorf("unexpected saved manifest:\n%s", data)
+	}
+	m.handleManifestSaved(msg)
+	if m.state.UI.StatusNote != "Saved job.yaml" {
+		t.Errorf("status note = %q", m.state.UI.StatusNote)
+	}
+
+	// A second save must not overwrite the file
+	_, cmd = m.handleSaveManifestCommand(path)
+	if msg := cmd().(manifestSavedMsg); msg.err == nil || !strings.Contains(msg.err.Error(), "already exists") {
+		t.Errorf("expected the existing file to be kept, got %v", msg.err)
+	}
+}
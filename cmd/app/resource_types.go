@@ -30,4 +30,5 @@ type AppDeleteParams struct {
 	AppName   string  // Application name
 	Namespace *string // Application namespace (optional)
 	Options   DeleteOptions
+	Hooks     []localHookRun // before-delete hooks; a failing one cancels the delete
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+const (
+	// resumeCheckInterval is how often the wall clock is compared with the
+	// timer. Timers run on the monotonic clock, which stops while the
+	// machine sleeps, so a check firing late by wall time means it slept.
+	resumeCheckInterval = 10 * time.Second
+	// resumeGapThreshold is how far the wall clock may run ahead of the
+	// timer before a resume is assumed
+	resumeGapThreshold = 30 * time.Second
+	// resumeSettleDelay gives the network time to come back after waking
+	// before reconnecting
+	resumeSettleDelay = 3 * time.Second
+)
+
+// resumeCheckMsg carries when a resume check was scheduled and when it fired
+type resumeCheckMsg struct {
+	scheduled time.Time
+	fired     time.Time
+}
+
+// resumeReconnectMsg reconnects once the network had time to settle
+type resumeReconnectMsg struct {
+	switchEpoch int
+}
+
+// scheduleResumeCheck schedules the next wall clock check. Returns nil in
+// deterministic mode, where the clock is frozen.
+func (m *Model) scheduleResumeCheck() tea.Cmd {
+	if deterministicMode {
+		return nil
+	}
+	scheduled := time.Now()
+	return tea.Tick(resumeCheckInterval, func(t time.Time) tea.Msg {
+		return resumeCheckMsg{scheduled: scheduled, fired: t}
+	})
+}
+
+// wallClockGap returns how much longer than resumeCheckInterval passed by
+// the wall clock between scheduling a check and it firing
+func wallClockGap(scheduled, fired time.Time) time.Duration {
+	// Round(0) strips the monotonic reading so the wall clocks are compared
+	return fired.Round(0).Sub(scheduled.Round(0)) - resumeCheckInterval
+}
+
+// handleResumeCheck keeps the checks going and, when the wall clock jumped,
+// schedules a reconnect. Not epoch-gated: the chain started by Init carries
+// over context switches.
+func (m *Model) handleResumeCheck(msg resumeCheckMsg) tea.Cmd {
+	gap := wallClockGap(msg.scheduled, msg.fired)
+	if gap < resumeGapThreshold || m.state.Server == nil {
+		return m.scheduleResumeCheck()
+	}
+	cblog.With("component", "resume").Info("Wall clock jumped, assuming resume from sleep", "gap", gap)
+	m.statusService.Set(fmt.Sprintf("Resumed after %s asleep, reconnecting", gap.Round(time.Second)))
+	epoch := m.switchEpoch
+	return tea.Batch(
+		m.scheduleResumeCheck(),
+		m.showStatusNote("Reconnecting after sleep"),
+		tea.Tick(resumeSettleDelay, func(time.Time) tea.Msg { return resumeReconnectMsg{switchEpoch: epoch} }),
+	)
+}
+
+// reconnectAfterResume re-validates the session, which reloads the app
+// list, and restarts the streams that likely died while asleep
+func (m *Model) reconnectAfterResume(msg resumeReconnectMsg) tea.Cmd {
+	if msg.switchEpoch != m.switchEpoch || m.state.Server == nil {
+		return nil
+	}
+	// The stream position is hours old; watch from the current state
+	m.lastResourceVersion = ""
+	cmds := []tea.Cmd{m.validateAuthentication(), m.startWatchingApplications()}
+
+	if m.state.Navigation.View == model.ViewTree && m.treeView != nil {
+		cmds = append(cmds, m.reloadTree())
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestWallClockGap(t *testing.T) {
+	scheduled := time.Now()
+	if gap := wallClockGap(scheduled, scheduled.Add(resumeCheckInterval)); gap != 0 {
+		t.Errorf("a check firing on time has no gap, got %v", gap)
+	}
+
+	// After a sleep the timer fires once the monotonic interval passed, but
+	// the wall clock moved on by the time spent asleep
+	asleep := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	woke := asleep.Add(time.Hour + resumeCheckInterval)
+	if gap := wallClockGap(asleep, woke); gap != time.Hour {
+		t.Errorf("gap = %v, want 1h", gap)
+	}
+}
+
+func TestResumeCheck_ReconnectsAfterSleep(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "t"}
+	now := time.Now()
+
+	if cmd := m.handleResumeCheck(resumeCheckMsg{scheduled: now, fired: now.Add(resumeCheckInterval)}); cmd == nil {
+		t.Fatal("checks should keep going")
+	}
+	if m.state.UI.StatusNote != "" {
+		t.Fatalf("no sleep, no note; got %q", m.state.UI.StatusNote)
+	}
+
+	m.handleResumeCheck(resumeCheckMsg{scheduled: now.Round(0), fired: now.Round(0).Add(2 * time.Hour)})
+	if m.state.UI.StatusNote != "Reconnecting after sleep" {
+		t.Fatalf("status note = %q", m.state.UI.StatusNote)
+	}
+
+	m.lastResourceVersion = "1234"
+	if cmd := m.reconnectAfterResume(resumeReconnectMsg{switchEpoch: m.switchEpoch + 1}); cmd != nil {
+		t.Error("a reconnect from another context should be ignored")
+	}
+	seq := m.watchStartSequence
+	if cmd := m.reconnectAfterResume(resumeReconnectMsg{switchEpoch: m.switchEpoch}); cmd == nil {
+		t.Fatal("expected reconnect commands")
+	}
+	if m.lastResourceVersion != "" {
+		t.Errorf("the watch should restart from the current state, resourceVersion = %q", m.lastResourceVersion)
+	}
+	if m.watchStartSequence != seq+1 {
+		t.Error("the app watch should be restarted")
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+// revisionDriftMarker is appended to the name of apps behind their branch tip
+const revisionDriftMarker = "↓"
+
+// commitSHAPattern matches a full git commit SHA; apps pinned to one cannot drift
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// revisionInfo is the cached git lookup for one app's synced revision
+type revisionInfo struct {
+	revision string                  // synced revision the result belongs to
+	pending  bool                    // lookup in flight
+	commit   *model.RevisionMetadata // synced commit; nil when unknown
+	behind   bool                    // synced revision is behind the branch tip
+}
+
+// revisionDriftEnabled reports whether drift checks are switched on in config
+func (m *Model) revisionDriftEnabled() bool {
+	return m.config != nil && m.config.Revisions.Drift
+}
+
+// commitInfoEnabled reports whether synced commit metadata should be looked up
+func (m *Model) commitInfoEnabled() bool {
+	return m.config != nil && (m.config.Revisions.CommitInfo || m.config.Revisions.CommitColumn)
+}
+
+// commitColumnEnabled reports whether the apps list shows the COMMIT column
+func (m *Model) commitColumnEnabled() bool {
+	return m.config != nil && m.config.Revisions.CommitColumn
+}
+
+// appKey identifies an app in per-app caches such as the revision cache
+func appKey(name string, appNamespace *string) string {
+	if appNamespace == nil {
+		return "/" + name
+	}
+	return *appNamespace + "/" + name
+}
+
+// gitRevision returns the synced revision of a single-source git app. The
+// metadata endpoint cannot look up one source of a multi-source app without
+// a history ID, so those are skipped.
+func gitRevision(app model.App) (string, bool) {
+	if app.MultiSource || len(app.Sources) != 1 {
+		return "", false
+	}
+	src := app.Sources[0]
+	if src.Chart != "" || src.Revision == "" {
+		return "", false
+	}
+	return src.Revision, true
+}
+
+// driftTarget returns the synced revision and the branch to compare it with.
+// Apps pinned to a commit SHA have nothing to drift from.
+func driftTarget(app model.App) (revision, target string, ok bool) {
+	revision, ok = gitRevision(app)
+	if !ok {
+		return "", "", false
+	}
+	target = app.Sources[0].TargetRevision
+	if target == "" {
+		target = "HEAD"
+	}
+	if commitSHAPattern.MatchString(target) || target == revision {
+		return "", "", false
+	}
+	return revision, target, true
+}
+
+// cachedRevisionInfo returns the finished lookup for the app's current synced revision
+func (m *Model) cachedRevisionInfo(app model.App) (revisionInfo, bool) {
+	info, ok := m.revisionInfo[appKey(app.Name, app.AppNamespace)]
+	if !ok || info.pending {
+		return revisionInfo{}, false
+	}
+	revision, ok := gitRevision(app)
+	if !ok || revision != info.revision {
+		return revisionInfo{}, false
+	}
+	return info, true
+}
+
+// isBehindBranchTip reports whether a drift check found the app behind
+func (m *Model) isBehindBranchTip(app model.App) bool {
+	info, ok := m.cachedRevisionInfo(app)
+	return ok && info.behind
+}
+
+// syncedCommit returns the metadata of the app's synced commit, when looked up
+func (m *Model) syncedCommit(app model.App) *model.RevisionMetadata {
+	if info, ok := m.cachedRevisionInfo(app); ok {
+		return info.commit
+	}
+	return nil
+}
+
+// commitSummary is the one-line "author: subject" form of a commit
+func commitSummary(c *model.RevisionMetadata) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	if c.Author == "" {
+		return subject
+	}
+	return commitAuthorName(c.Author) + ": " + subject
+}
+
+// commitAuthorName drops the email from a "Name <email>" author
+func commitAuthorName(author string) string {
+	if name, _, found := strings.Cut(author, " <"); found {
+		return name
+	}
+	return author
+}
+
+// onScreenApps returns the apps rows currently shown in the list
+func (m *Model) onScreenApps() []model.App {
+	if m.state.Navigation.View != model.ViewApps {
+		return nil
+	}
+	items := m.getVisibleItems()
+	start := max(0, m.listNav.ScrollOffset())
+	end := min(len(items), start+m.listViewportHeight())
+	var apps []model.App
+	for i := start; i < end; i++ {
+		if app, ok := items[i].(model.App); ok {
+			apps = append(apps, app)
+		}
+	}
+	return apps
+}
+
+// checkRevisions starts git lookups for on-screen apps that have not been
+// looked up at their current synced revision. Returns nil when there is
+// nothing to do.
+func (m *Model) checkRevisions() tea.Cmd {
+	drift, commits := m.revisionDriftEnabled(), m.commitInfoEnabled()
+	if (!drift && !commits) || m.state.Server == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, app := range m.onScreenApps() {
+		revision, ok := gitRevision(app)
+		if !ok {
+			continue
+		}
+		target := ""
+		if drift {
+			if _, t, ok := driftTarget(app); ok {
+				target = t
+			}
+		}
+		if !commits && target == "" {
+			continue
+		}
+		key := appKey(app.Name, app.AppNamespace)
+		if info, seen := m.revisionInfo[key]; seen && info.revision == revision {
+			continue
+		}
+		if m.revisionInfo == nil {
+			m.revisionInfo = make(map[string]revisionInfo)
+		}
+		m.revisionInfo[key] = revisionInfo{revision: revision, pending: true}
+		cmds = append(cmds, m.fetchRevisionInfo(app.Name, app.AppNamespace, revision, target))
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchRevisionInfo loads the metadata of the synced revision and, when a
+// target branch is given, resolves the branch tip to its SHA; a tip other
+// than the synced commit means the app is behind
+func (m *Model) fetchRevisionInfo(appName string, appNamespace *string, revision, target string) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+
+		apiService := services.NewArgoApiService(server)
+		msg := model.RevisionInfoLoadedMsg{AppName: appName, AppNamespace: appNamespace, Revision: revision, SwitchEpoch: epoch}
+
+		synced, err := apiService.GetRevisionMetadata(ctx, server, appName, revision, appNamespace)
+		if err != nil {
+			cblog.With("component", "revisions").Debug("Could not load synced revision", "app", appName, "err", err)
+		} else {
+			msg.Commit = synced
+		}
+		if target == "" {
+			return msg
+		}
+		tip, err := apiService.ResolveRevision(ctx, server, appName, target, appNamespace)
+		if err != nil {
+			cblog.With("component", "revisions").Debug("Could not resolve target revision", "app", appName, "target", target, "err", err)
+			return msg
+		}
+		msg.Behind = !strings.EqualFold(tip, revision)
+		return msg
+	}
+}
+
+// handleRevisionInfoLoaded stores a finished lookup
+func (m *Model) handleRevisionInfoLoaded(msg model.RevisionInfoLoadedMsg) {
+	key := appKey(msg.AppName, msg.AppNamespace)
+	info, ok := m.revisionInfo[key]
+	if !ok || info.revision != msg.Revision {
+		return // app moved to another revision while the lookup ran
+	}
+	m.revisionInfo[key] = revisionInfo{revision: msg.Revision, commit: msg.Commit, behind: msg.Behind}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+const syncedSHA = "0123456789abcdef0123456789abcdef01234567"
+
+func buildDriftTestModel(t *testing.T, serverURL string) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(100, 30)
+	m.config = &config.ArgonautConfig{Revisions: config.RevisionsConfig{Drift: true}}
+	m.state.Server = &model.Server{BaseURL: serverURL, Token: "tok"}
+	m.state.Apps[0].Sources = []model.AppSource{{RepoURL: "https://git.example.com/repo.git", TargetRevision: "main", Revision: syncedSHA}}
+	return m
+}
+
+func TestDriftTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		app    model.App
+		target string
+		ok     bool
+	}{
+		{"branch", model.App{Sources: []model.AppSource{{TargetRevision: "main", Revision: syncedSHA}}}, "main", true},
+		{"empty target means HEAD", model.App{Sources: []model.AppSource{{Revision: syncedSHA}}}, "HEAD", true},
+		{"pinned sha", model.App{Sources: []model.AppSource{{TargetRevision: syncedSHA, Revision: syncedSHA}}}, "", false},
+		{"chart", model.App{Sources: []model.AppSource{{Chart: "web", TargetRevision: "1.x", Revision: "1.4.0"}}}, "", false},
+		{"never synced", model.App{Sources: []model.AppSource{{TargetRevision: "main"}}}, "", false},
+		{"multi-source", model.App{MultiSource: true, Sources: []model.AppSource{{TargetRevision: "main", Revision: syncedSHA}}}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, target, ok := driftTarget(tt.app)
+			if ok != tt.ok || target != tt.target {
+				t.Errorf("driftTarget = (%q, %v), want (%q, %v)", target, ok, tt.target, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRevisionDrift_MarksAppBehindBranchTip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/manifests") && r.URL.Query().Get("revision") == "main" {
+			w.Write([]byte(`{"revision": "` + strings.Repeat("a", 40) + `"}`))
+			return
+		}
+		w.Write([]byte(`{"author": "alice", "date": "2026-10-01T10:00:00Z", "message": "deployed"}`))
+	}))
+	defer srv.Close()
+	m := buildDriftTestModel(t, srv.URL)
+
+	cmd := m.checkRevisions()
+	if cmd == nil {
+		t.Fatal("expected a drift check for the on-screen git app")
+	}
+	if m.checkRevisions() != nil {
+		t.Error("an app already being checked should not be checked again")
+	}
+
+	msg, ok := cmd().(model.RevisionInfoLoadedMsg)
+	if !ok || msg.Commit == nil || !msg.Behind {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	m.Update(msg)
+	if !m.isBehindBranchTip(m.state.Apps[0]) {
+		t.Fatal("app should be marked behind")
+	}
+	if row := stripANSI(m.renderAppRow(m.state.Apps[0], false)); !strings.Contains(row, "test-app "+revisionDriftMarker) {
+		t.Errorf("row missing drift marker: %q", row)
+	}
+
+	// A sync to a new revision clears the marker until it is rechecked
+	m.state.Apps[0].Sources[0].Revision = strings.Repeat("f", 40)
+	if m.isBehindBranchTip(m.state.Apps[0]) {
+		t.Error("marker should not carry over to a new synced revision")
+	}
+	if m.checkRevisions() == nil {
+		t.Error("new synced revision should be rechecked")
+	}
+}
+
+func TestRevisionDrift_SameCommitOrFailedLookupIsNotBehind(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/manifests") {
+			w.Write([]byte(`{"revision": "` + syncedSHA + `"}`))
+			return
+		}
+		// A newer commit that happens to share author, date and message
+		w.Write([]byte(`{"author": "alice", "date": "2026-10-01T10:00:00Z", "message": "deployed"}`))
+	}))
+	defer srv.Close()
+	m := buildDriftTestModel(t, srv.URL)
+	msg := m.checkRevisions()().(model.RevisionInfoLoadedMsg)
+	if msg.Commit == nil || msg.Behind {
+		t.Fatalf("same commit should not be behind: %#v", msg)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "app not found"}`, http.StatusNotFound)
+	}))
+	defer failing.Close()
+	m = buildDriftTestModel(t, failing.URL)
+	msg = m.checkRevisions()().(model.RevisionInfoLoadedMsg)
+	if msg.Commit != nil || msg.Behind {
+		t.Fatalf("failed lookup should leave drift unknown: %#v", msg)
+	}
+}
+
+func TestRevisions_DisabledOrStaleResultIgnored(t *testing.T) {
+	m := buildDriftTestModel(t, "http://127.0.0.1:1")
+	m.config.Revisions.Drift = false
+	if m.checkRevisions() != nil {
+		t.Fatal("revision lookups should be off unless enabled")
+	}
+
+	m.config.Revisions.Drift = true
+	m.switchEpoch = 2
+	m.checkRevisions()
+	app := m.state.Apps[0]
+	m.Update(model.RevisionInfoLoadedMsg{AppName: app.Name, AppNamespace: app.AppNamespace, Revision: syncedSHA, Behind: true, SwitchEpoch: 1})
+	if m.isBehindBranchTip(app) {
+		t.Error("result from a previous context should be dropped")
+	}
+	m.Update(model.RevisionInfoLoadedMsg{AppName: app.Name, AppNamespace: app.AppNamespace, Revision: "old", Behind: true, SwitchEpoch: 2})
+	if m.isBehindBranchTip(app) {
+		t.Error("result for an older synced revision should be dropped")
+	}
+}
+
+func TestCommitInfo_ColumnAndDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/revisions/main/") {
+			t.Error("branch tip should not be looked up without drift enabled")
+		}
+		w.Write([]byte(`{"author": "Alice Doe <alice@example.com>", "date": "2026-10-01T10:00:00Z", "message": "Fix login redirect\n\nLonger body"}`))
+	}))
+	defer srv.Close()
+	m := buildDriftTestModel(t, srv.URL)
+	m.config.Revisions = config.RevisionsConfig{CommitColumn: true}
+	m.state.Terminal.Cols = 140
+
+	if header := stripANSI(m.renderListHeader()); !strings.Contains(header, "COMMIT") {
+		t.Fatalf("header missing COMMIT column: %q", header)
+	}
+	m.Update(m.checkRevisions()())
+
+	row := stripANSI(m.renderAppRow(m.state.Apps[0], false))
+	if !strings.Contains(row, "Alice Doe: Fix login redirect") {
+		t.Errorf("row missing commit summary: %q", row)
+	}
+	if strings.Contains(row, revisionDriftMarker) {
+		t.Errorf("drift marker shown without drift enabled: %q", row)
+	}
+
+	m.openAppDetails(m.state.Apps[0].Name, m.state.Apps[0].AppNamespace)
+	out := stripANSI(m.renderAppDetailsModal())
+	for _, want := range []string{"Commit", "Alice Doe at", "Fix login redirect"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("details missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Longer body") {
+		t.Error("details should only show the commit subject")
+	}
+}
+
+func TestCommitColumn_HiddenWhenNarrowOrDisabled(t *testing.T) {
+	m := buildDriftTestModel(t, "http://127.0.0.1:1")
+	if nameOnly, commitWidth := m.splitCommitColumn(80); commitWidth != 0 || nameOnly != 80 {
+		t.Errorf("column should be off unless configured, got %d/%d", nameOnly, commitWidth)
+	}
+	m.config.Revisions.CommitColumn = true
+	if _, commitWidth := m.splitCommitColumn(30); commitWidth != 0 {
+		t.Error("column should be hidden when the name column is narrow")
+	}
+	if nameOnly, commitWidth := m.splitCommitColumn(80); nameOnly+1+commitWidth != 80 || commitWidth == 0 {
+		t.Errorf("columns should share the name width, got %d/%d", nameOnly, commitWidth)
+	}
+}
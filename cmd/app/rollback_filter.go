@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// rollbackMetadataInFlight caps the metadata requests the rollback modal
+// has running at once while a filter walks the rest of the history
+const rollbackMetadataInFlight = 10
+
+// loadRollbackMetadata starts metadata requests for the history rows on
+// screen that have none yet; it runs again whenever the window moves, so
+// browsing a long history costs a request per row looked at. While a
+// filter is set, the other rows are walked too, newest first and at most
+// rollbackMetadataInFlight at a time, so the filter can match authors and
+// messages of deployments nobody scrolled to. Each reply calls it again.
+func (m *Model) loadRollbackMetadata() tea.Cmd {
+	rb := m.state.Rollback
+	if rb == nil || rb.Loading {
+		return nil
+	}
+	if rb.MetaRequested == nil {
+		rb.MetaRequested = make(map[int]bool)
+	}
+	var cmds []tea.Cmd
+	request := func(i int) {
+		if row := rb.Rows[i]; !rb.MetaRequested[i] && row.Author == nil && row.MetaError == nil {
+			rb.MetaRequested[i] = true
+			rb.MetaPending++
+			cmds = append(cmds, m.loadRevisionMetadata(rb.AppName, i, row, rb.AppNamespace))
+		}
+	}
+	visible, start, end := rollbackWindow(rb, m.rollbackHistoryRows(rb))
+	for _, i := range visible[start:end] {
+		request(i)
+	}
+	if strings.TrimSpace(rb.Filter) != "" {
+		for i := len(rb.Rows) - 1; i >= 0 && rb.MetaPending < rollbackMetadataInFlight; i-- {
+			request(i)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// rollbackWindow returns the history rows the filter keeps and the part of
+// them shown, rows long and centered on the selection where it can be
+func rollbackWindow(rb *model.RollbackState, rows int) (visible []int, start, end int) {
+	visible = rollbackVisibleRows(rb)
+	selected := 0
+	for pos, i := range visible {
+		if i == rb.SelectedIdx {
+			selected = pos
+		}
+	}
+	start = max(0, min(selected-rows/2, len(visible)-rows))
+	end = min(start+rows, len(visible))
+	return visible, start, end
+}
+
+// rollbackRowMatches reports whether every word of the filter appears,
+// ignoring case, in the row's ID, revisions, date, weekday, author,
+// message or initiator
+func rollbackRowMatches(row model.RollbackRow, filter string) bool {
+	fields := []string{fmt.Sprintf("#%d", row.ID), row.Revision}
+	fields = append(fields, row.Revisions...)
+	if row.DeployedAt != nil {
+		fields = append(fields, row.DeployedAt.Local().Format("Monday 2006-01-02 15:04"))
+	}
+	if row.Author != nil {
+		fields = append(fields, *row.Author)
+	}
+	if row.Message != nil {
+		fields = append(fields, *row.Message)
+	}
+	if row.InitiatedBy != nil {
+		fields = append(fields, row.InitiatedBy.String())
+	}
+	haystack := strings.ToLower(strings.Join(fields, "\n"))
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// rollbackVisibleRows returns the indices of the history rows the filter
+// keeps, in history order
+func rollbackVisibleRows(rb *model.RollbackState) []int {
+	visible := make([]int, 0, len(rb.Rows))
+	for i, row := range rb.Rows {
+		if rollbackRowMatches(row, rb.Filter) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// rollbackNavigatorContext navigates the filtered history: the navigator's
+// cursor is a position among the visible rows, mapped back to SelectedIdx
+func (m *Model) rollbackNavigatorContext() *NavigatorContext {
+	rb := m.state.Rollback
+	visible := rollbackVisibleRows(rb)
+	cursor := 0
+	for pos, i := range visible {
+		if i == rb.SelectedIdx {
+			cursor = pos
+		}
+	}
+	m.rollbackNav.SetItemCount(len(visible))
+	m.rollbackNav.SetCursor(cursor)
+	return &NavigatorContext{
+		Navigator:         m.rollbackNav,
+		GetItemCount:      func() int { return len(visible) },
+		GetViewportHeight: m.rollbackPageSize,
+		OnNavigate: func(changed bool) {
+			if changed && len(visible) > 0 {
+				rb.SelectedIdx = visible[m.rollbackNav.Cursor()]
+			}
+		},
+		SupportsNavigation: true,
+	}
+}
+
+// applyRollbackFilter keeps the selection on a matching row after the
+// filter or the rows' metadata changed, moving it to the newest match
+// when it no longer matches
+func (m *Model) applyRollbackFilter() {
+	rb := m.state.Rollback
+	visible := rollbackVisibleRows(rb)
+	for _, i := range visible {
+		if i == rb.SelectedIdx {
+			return
+		}
+	}
+	if len(visible) > 0 {
+		rb.SelectedIdx = visible[len(visible)-1]
+	}
+}
+
+// handleRollbackFilterKeys edits the history filter while it has focus.
+// Enter keeps the filter and hands the keys back to the list; Esc drops it.
+func (m *Model) handleRollbackFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rb := m.state.Rollback
+	switch key := msg.String(); key {
+	case "esc":
+		rb.Filter = ""
+		rb.Filtering = false
+	case "enter":
+		rb.Filtering = false
+	case "up", "down", "pgup", "pgdown":
+		return m.executeNavigation(m.rollbackNavigatorContext(), msg)
+	case "backspace":
+		if r := []rune(rb.Filter); len(r) > 0 {
+			rb.Filter = string(r[:len(r)-1])
+		}
+	case "space":
+		rb.Filter += " "
+	default:
+		if len([]rune(key)) == 1 {
+			rb.Filter += key
+		}
+	}
+	m.applyRollbackFilter()
+	return m, m.loadRollbackMetadata()
+}
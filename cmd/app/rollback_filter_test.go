@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func rollbackFilterTestModel(rows int) *Model {
+	m := buildDeleteTestModel(140, 30)
+	name := "test-app"
+	m.state.Mode = model.ModeRollback
+	m.state.Modals.RollbackAppName = &name
+	m.state.Rollback = &model.RollbackState{AppName: name, Mode: "list"}
+	deployed := time.Date(2025, 3, 3, 12, 0, 0, 0, time.Local) // a Monday
+	for i := range rows {
+		at := deployed.Add(time.Duration(i) * 24 * time.Hour)
+		author, message := "alice", fmt.Sprintf("release %d", i)
+		if i%10 == 7 {
+			author, message = "bob", fmt.Sprintf("hotfix %d", i)
+		}
+		m.state.Rollback.Rows = append(m.state.Rollback.Rows, model.RollbackRow{
+			ID: i, Revision: fmt.Sprintf("%08x", 0xabc000+i), DeployedAt: &at, Author: &author, Message: &message,
+		})
+	}
+	return m
+}
+
+func TestRollbackFilter_NarrowsHistoryAndKeepsSelectionOnMatches(t *testing.T) {
+	m := rollbackFilterTestModel(40)
+	rb := m.state.Rollback
+
+	m.handleKeyMsg(keyPress("/"))
+	for _, k := range []string{"b", "o", "b", "space", "h", "o", "t", "f", "i", "x"} {
+		m.handleKeyMsg(keyPress(k))
+	}
+	if rb.Filter != "bob hotfix" || !rb.Filtering {
+		t.Fatalf("typing should edit the filter, got %q (filtering %v)", rb.Filter, rb.Filtering)
+	}
+	if got := rollbackVisibleRows(rb); len(got) != 4 || rb.SelectedIdx != 37 {
+		t.Fatalf("expected the 4 hotfixes with the newest selected, got %v selected %d", got, rb.SelectedIdx)
+	}
+
+	out := stripANSI(m.renderRollbackModal())
+	for _, want := range []string{"1–4 of 4 matches (40 total)", "/bob hotfix", "#7 ", "#37 "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("filtered history should show %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "release 12") {
+		t.Errorf("rows the filter drops should not be shown:\n%s", out)
+	}
+
+	// Enter hands the keys back to the list, where k moves among matches
+	m.handleKeyMsg(keyPress("enter"))
+	m.handleKeyMsg(keyPress("k"))
+	if rb.Filtering || rb.SelectedIdx != 27 {
+		t.Fatalf("k should move to the previous match, got %d (filtering %v)", rb.SelectedIdx, rb.Filtering)
+	}
+
+	// The first Esc drops the filter and keeps the row, the next closes
+	m.handleKeyMsg(keyPress("esc"))
+	if rb.Filter != "" || rb.SelectedIdx != 27 || m.state.Mode != model.ModeRollback {
+		t.Fatalf("esc should clear the filter first, got %q selected %d mode %s", rb.Filter, rb.SelectedIdx, m.state.Mode)
+	}
+	m.state.Navigation.LastEscPressed = 0 // past the esc debounce
+	m.handleKeyMsg(keyPress("esc"))
+	if m.state.Mode != model.ModeNormal {
+		t.Fatalf("a second esc should close the modal, mode %s", m.state.Mode)
+	}
+}
+
+func TestRollbackFilter_MatchesDatesAndWeekdays(t *testing.T) {
+	m := rollbackFilterTestModel(14)
+	rb := m.state.Rollback
+	for filter, want := range map[string]int{"tuesday": 2, "2025-03-05": 1, "#1": 5, "abc00a": 1} {
+		rb.Filter = filter
+		if got := rollbackVisibleRows(rb); len(got) != want {
+			t.Errorf("%q: expected %d matches, got %v", filter, want, got)
+		}
+	}
+
+	rb.Filter = "nothing like this"
+	m.applyRollbackFilter()
+	if out := stripANSI(m.renderRollbackModal()); !strings.Contains(out, "no matches among 14") {
+		t.Errorf("an empty result should say so:\n%s", out)
+	}
+	m.handleKeyMsg(keyPress("enter"))
+	if rb.Mode != "list" {
+		t.Errorf("enter with nothing shown should not pick a hidden row")
+	}
+}
+
+func TestRollbackHistory_ShowsWhereTheWindowIs(t *testing.T) {
+	m := rollbackFilterTestModel(200)
+	m.state.Rollback.SelectedIdx = 100
+	rows := m.rollbackHistoryRows(m.state.Rollback)
+	start := 100 - rows/2
+
+	out := stripANSI(m.renderRollbackModal())
+	for _, want := range []string{
+		fmt.Sprintf("%d–%d of 200", start+1, start+rows),
+		fmt.Sprintf("… %d older entries above …", start),
+		fmt.Sprintf("… %d newer entries below …", 200-start-rows),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history should show %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRollbackMetadata_LoadsOnlyTheRowsOnScreen(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+	m.state.Mode = model.ModeRollback
+	rows := make([]model.RollbackRow, 200)
+	for i := range rows {
+		rows[i] = model.RollbackRow{ID: i, Revision: fmt.Sprintf("rev%03d", i)}
+	}
+	m.Update(model.RollbackHistoryLoadedMsg{AppName: "test-app", Rows: rows})
+	rb := m.state.Rollback
+	page := m.rollbackHistoryRows(rb)
+	if len(rb.MetaRequested) != page || !rb.MetaRequested[0] || rb.MetaRequested[page] {
+		t.Fatalf("expected requests for the first %d rows only, got %v", page, rb.MetaRequested)
+	}
+
+	m.Update(model.RollbackMetadataLoadedMsg{AppName: "test-app", RowIndex: 0, Metadata: model.RevisionMetadata{Author: "alice"}})
+	m.Update(model.RollbackMetadataErrorMsg{AppName: "test-app", RowIndex: 1, Error: "gone"})
+	if rb.Rows[0].Author == nil || rb.Rows[1].MetaError == nil || len(rb.MetaRequested) != page {
+		t.Fatal("replies should fill in their rows without asking for more")
+	}
+
+	// Jumping to the end asks for the rows scrolled into view
+	m.handleKeyMsg(keyPress("G"))
+	if !rb.MetaRequested[199] || rb.MetaRequested[100] {
+		t.Fatalf("expected requests for the last page only, got %d requested", len(rb.MetaRequested))
+	}
+
+	// Replies for another app's history are dropped
+	m.Update(model.RollbackMetadataLoadedMsg{AppName: "other-app", RowIndex: 2, Metadata: model.RevisionMetadata{Author: "mallory"}})
+	if rb.Rows[2].Author != nil {
+		t.Fatal("a stale reply should not touch the history")
+	}
+}
+
+func TestRollbackMetadata_FilterWalksRowsNotScrolledTo(t *testing.T) {
+	m := buildDeleteTestModel(140, 30)
+	m.state.Mode = model.ModeRollback
+	rows := make([]model.RollbackRow, 200)
+	for i := range rows {
+		rows[i] = model.RollbackRow{ID: i, Revision: fmt.Sprintf("rev%03d", i)}
+	}
+	m.Update(model.RollbackHistoryLoadedMsg{AppName: "test-app", Rows: rows})
+	rb := m.state.Rollback
+	answered := map[int]bool{}
+	reply := func() {
+		for i := range rb.MetaRequested {
+			if answered[i] {
+				continue
+			}
+			answered[i] = true
+			author := "alice"
+			if i == 120 {
+				author = "bob"
+			}
+			m.Update(model.RollbackMetadataLoadedMsg{AppName: "test-app", RowIndex: i, Metadata: model.RevisionMetadata{Author: author}})
+		}
+	}
+	reply()
+	if rb.MetaRequested[120] {
+		t.Fatal("rows off screen should not be fetched without a filter")
+	}
+
+	m.handleKeyMsg(keyPress("/"))
+	for _, k := range []string{"b", "o", "b"} {
+		m.handleKeyMsg(keyPress(k))
+	}
+	if rb.MetaPending > rollbackMetadataInFlight {
+		t.Fatalf("the walk should run at most %d requests at once, got %d", rollbackMetadataInFlight, rb.MetaPending)
+	}
+	for range 30 {
+		if rb.MetaPending == 0 {
+			break
+		}
+		reply()
+	}
+	if len(rb.MetaRequested) != len(rows) {
+		t.Fatalf("the filter should walk the whole history, got %d of %d", len(rb.MetaRequested), len(rows))
+	}
+	if got := rollbackVisibleRows(rb); len(got) != 1 || got[0] != 120 || rb.SelectedIdx != 120 {
+		t.Errorf("the off-screen deployment by bob should match and be selected, got %v selected %d", got, rb.SelectedIdx)
+	}
+}
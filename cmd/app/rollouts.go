@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// rolloutStatusTTL bounds how long a Rollout's status is shown before it is
+// re-read; a change in the node's health re-reads it right away
+const rolloutStatusTTL = 10 * time.Second
+
+// rolloutInfo is the cached status of one Rollout in the tree
+type rolloutInfo struct {
+	health    string // tree health the status was read at
+	pending   bool   // read in flight
+	status    *model.RolloutStatus
+	fetchedAt time.Time
+}
+
+// rolloutKey identifies a Rollout across the apps shown in the tree; it
+// starts with the app's appKey
+func rolloutKey(appNamespace *string, sel treeview.ResourceSelection) string {
+	return appKey(sel.AppName, appNamespace) + "/" + sel.Namespace + "/" + sel.Name
+}
+
+// selectedRollout returns the tree resource under the cursor when it is an
+// Argo Rollouts Rollout
+func (m *Model) selectedRollout() (treeview.ResourceSelection, bool) {
+	if m.treeView == nil || m.state.Navigation.View != model.ViewTree {
+		return treeview.ResourceSelection{}, false
+	}
+	sel, ok := m.treeView.CurrentResource()
+	if !ok || !api.IsRollout(sel.Group, sel.Kind) {
+		return treeview.ResourceSelection{}, false
+	}
+	return sel, true
+}
+
+// checkSelectedRollout reads the status of the Rollout under the cursor
+// unless a fresh one is cached or a read is in flight
+func (m *Model) checkSelectedRollout() tea.Cmd {
+	sel, ok := m.selectedRollout()
+	if !ok || m.state.Server == nil {
+		return nil
+	}
+	key := rolloutKey(m.treeAppNamespace(sel.AppName), sel)
+	info, seen := m.rolloutInfo[key]
+	if seen && (info.pending || (info.health == sel.Health && time.Since(info.fetchedAt) < rolloutStatusTTL)) {
+		return nil
+	}
+	if m.rolloutInfo == nil {
+		m.rolloutInfo = make(map[string]rolloutInfo)
+	}
+	info.pending = true
+	m.rolloutInfo[key] = info
+	return m.fetchRolloutStatus(key, sel)
+}
+
+// fetchRolloutStatus reads a Rollout's live manifest
+func (m *Model) fetchRolloutStatus(key string, sel treeview.ResourceSelection) tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	params := api.LiveResourceParams{
+		AppName:      sel.AppName,
+		AppNamespace: m.treeAppNamespace(sel.AppName),
+		ResourceName: sel.Name,
+		Namespace:    sel.Namespace,
+		Kind:         sel.Kind,
+		Group:        sel.Group,
+		Version:      sel.Version,
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		st, err := api.NewApplicationService(server).GetRolloutStatus(ctx, params)
+		if err != nil {
+			cblog.With("component", "rollouts").Debug("Could not read rollout", "rollout", key, "err", err)
+		}
+		return model.RolloutStatusLoadedMsg{Key: key, Health: sel.Health, Status: st, Err: err, SwitchEpoch: epoch}
+	}
+}
+
+// handleRolloutStatusLoaded caches a Rollout read, keeping the previous
+// status when the read failed
+func (m *Model) handleRolloutStatusLoaded(msg model.RolloutStatusLoadedMsg) {
+	if m.rolloutInfo == nil {
+		m.rolloutInfo = make(map[string]rolloutInfo)
+	}
+	info := m.rolloutInfo[msg.Key]
+	if msg.Err == nil {
+		info.status = msg.Status
+	}
+	info.health = msg.Health
+	info.pending = false
+	info.fetchedAt = time.Now()
+	m.rolloutInfo[msg.Key] = info
+}
+
+// forgetRollout drops a Rollout's cached status so the next check re-reads
+// it, e.g. after a promote or abort
+func (m *Model) forgetRollout(target model.ResourceActionTarget) {
+	if !api.IsRollout(target.Group, target.Kind) {
+		return
+	}
+	delete(m.rolloutInfo, rolloutKey(target.AppNamespace, treeview.ResourceSelection{
+		AppName: target.AppName, Namespace: target.Namespace, Name: target.Name,
+	}))
+}
+
+// rolloutDetail describes the progress of the Rollout under the cursor:
+// canary step and weight, pause state, and the actions that move it on.
+// Empty until the status has been read.
+func (m *Model) rolloutDetail() string {
+	sel, ok := m.selectedRollout()
+	if !ok {
+		return ""
+	}
+	st := m.rolloutInfo[rolloutKey(m.treeAppNamespace(sel.AppName), sel)].status
+	if st == nil || st.Strategy == "" {
+		return ""
+	}
+
+	var parts []string
+	switch {
+	case st.Strategy == "canary" && st.Steps > 0 && st.Step > st.Steps:
+		parts = append(parts, "canary complete")
+	case st.Strategy == "canary" && st.Step > 0:
+		parts = append(parts, fmt.Sprintf("canary step %d/%d", st.Step, st.Steps))
+	default:
+		parts = append(parts, st.Strategy)
+	}
+	if st.Strategy == "canary" {
+		parts = append(parts, fmt.Sprintf("weight %d%%", st.Weight))
+	}
+	switch {
+	case st.Aborted:
+		parts = append(parts, "aborted")
+	case st.Paused && len(st.PauseReasons) > 0:
+		parts = append(parts, fmt.Sprintf("paused (%s)", strings.Join(st.PauseReasons, ", ")))
+	case st.Paused:
+		parts = append(parts, "paused")
+	}
+	// Promote and abort are Rollout resource actions; hint at them while
+	// the rollout is waiting on someone
+	if st.Paused || st.Aborted {
+		parts = append(parts, "a: promote/abort")
+	}
+	return "Rollout " + strings.Join(parts, " • ")
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// buildRolloutTreeModel shows a tree with a Rollout selected, served by srv
+func buildRolloutTreeModel(t *testing.T, srv *httptest.Server) *Model {
+	t.Helper()
+	m := buildDeleteTestModel(140, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.state.Navigation.View = model.ViewTree
+	m.state.UI.TreeApp = &model.TreeAppInfo{Name: "test-app", AppNamespace: m.state.Apps[0].AppNamespace}
+
+	m.treeView = treeview.NewTreeView(0, 0)
+	ns := "shop"
+	paused := "Suspended"
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "r1", Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout", Name: "web", Namespace: &ns, Health: &api.ResourceHealth{Status: &paused}},
+	}}
+	m.treeView.SetAppMeta("test-app", "Suspended", "Synced")
+	m.treeView.UpsertAppTree("test-app", &tree)
+	m.treeView.SetSelectedIndex(1) // Rollout node (0 is synthetic Application root)
+	return m
+}
+
+func TestSelectedRollout_ShowsStepWeightAndPause(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if got := r.URL.Query().Get("appNamespace"); got != "test-namespace" {
+			t.Errorf("appNamespace = %q, want test-namespace", got)
+		}
+		w.Write([]byte(`{"manifest": "{\"spec\":{\"strategy\":{\"canary\":{\"steps\":[{\"setWeight\":20},{\"pause\":{}},{\"setWeight\":60}]}}},\"status\":{\"currentStepIndex\":1,\"pauseConditions\":[{\"reason\":\"CanaryPauseStep\"}]}}"}`))
+	}))
+	defer srv.Close()
+	m := buildRolloutTreeModel(t, srv)
+
+	cmd := m.checkSelectedRollout()
+	if cmd == nil {
+		t.Fatal("selecting a Rollout should read its status")
+	}
+	if m.checkSelectedRollout() != nil {
+		t.Error("no second read while one is in flight")
+	}
+	m.Update(cmd())
+
+	want := "Rollout canary step 2/3 • weight 20% • paused (CanaryPauseStep) • a: promote/abort"
+	if got := m.rolloutDetail(); got != want {
+		t.Errorf("rolloutDetail() = %q, want %q", got, want)
+	}
+	if line := stripANSI(m.renderStatusLine()); !strings.Contains(line, want) {
+		t.Errorf("status line should describe the rollout, got %q", line)
+	}
+	if m.checkSelectedRollout() != nil {
+		t.Error("a fresh status should not be re-read")
+	}
+
+	// A promote drops the cached status so the rollout is re-read
+	m.Update(model.ResourceActionExecutedMsg{
+		Target: model.ResourceActionTarget{AppName: "test-app", AppNamespace: strp("test-namespace"), Group: "argoproj.io", Kind: "Rollout", Namespace: "shop", Name: "web"},
+		Action: "promote-full",
+	})
+	if m.checkSelectedRollout() == nil {
+		t.Error("a rollout should be re-read after an action runs on it")
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 read to have run, got %d", hits)
+	}
+}
+
+func TestSelectedRollout_ReadFailureKeepsStatus(t *testing.T) {
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"manifest": "{\"spec\":{\"strategy\":{\"blueGreen\":{}}},\"status\":{\"phase\":\"Healthy\"}}"}`))
+	}))
+	defer srv.Close()
+	m := buildRolloutTreeModel(t, srv)
+
+	m.Update(m.checkSelectedRollout()())
+	if got := m.rolloutDetail(); got != "Rollout blueGreen" {
+		t.Fatalf("rolloutDetail() = %q", got)
+	}
+
+	fail = true
+	sel := mustCurrentResource(t, m)
+	key := rolloutKey(m.treeAppNamespace(sel.AppName), sel)
+	info := m.rolloutInfo[key]
+	info.fetchedAt = time.Time{} // expired
+	m.rolloutInfo[key] = info
+	m.Update(m.checkSelectedRollout()())
+	if got := m.rolloutDetail(); got != "Rollout blueGreen" {
+		t.Errorf("a failed read should keep the last status, got %q", got)
+	}
+}
+
+func TestSelectedRollout_IgnoresOtherKinds(t *testing.T) {
+	m := buildDeleteTestModel(100, 30)
+	m.state.Server = &model.Server{BaseURL: "http://127.0.0.1:0"}
+	m.state.Navigation.View = model.ViewTree
+	m.treeView = treeview.NewTreeView(0, 0)
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{{UID: "d1", Group: "apps", Kind: "Deployment", Name: "web"}}}
+	m.treeView.UpsertAppTree("test-app", &tree)
+	m.treeView.SetSelectedIndex(1)
+
+	if m.checkSelectedRollout() != nil {
+		t.Error("only Rollouts should be read")
+	}
+}
+
+func mustCurrentResource(t *testing.T, m *Model) treeview.ResourceSelection {
+	t.Helper()
+	sel, ok := m.treeView.CurrentResource()
+	if !ok {
+		t.Fatal("no resource under the cursor")
+	}
+	return sel
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenarios are regression tests for key-driven flows written as YAML files
+// in testdata/scenarios: the apps to start with, the Argo CD API responses to
+// serve, and steps of keys to press and what the model must look like after.
+// They run against the model directly, without a terminal; commands the
+// model returns are run and their messages fed back until it settles.
+type scenario struct {
+	Description string `yaml:"description"`
+	Terminal    struct {
+		Cols int `yaml:"cols"`
+		Rows int `yaml:"rows"`
+	} `yaml:"terminal"`
+	// View to start in, apps by default
+	View string `yaml:"view"`
+	// Config is argonaut config in TOML, applied over the defaults
+	Config string `yaml:"config"`
+	// Apps use the JSON field names of model.App
+	Apps []any          `yaml:"apps"`
+	API  []scenarioCall `yaml:"api"`
+	// Steps run in order; each presses its keys, types its text, then checks
+	// its expectations
+	Steps []scenarioStep `yaml:"steps"`
+}
+
+// scenarioCall is a canned API response. Requests nothing answers get a 404.
+type scenarioCall struct {
+	Method string `yaml:"method"`
+	Path   string `yaml:"path"`
+	Status int    `yaml:"status"`
+	Body   any    `yaml:"body"`
+}
+
+type scenarioStep struct {
+	Keys   []string        `yaml:"keys"`
+	Type   string          `yaml:"type"`
+	Expect *scenarioExpect `yaml:"expect"`
+}
+
+type scenarioExpect struct {
+	Mode     string `yaml:"mode"`
+	View     string `yaml:"view"`
+	Selected *int   `yaml:"selected"`
+	// Screen lists text the rendered screen must show, NotScreen text it
+	// must not
+	Screen    []string `yaml:"screen"`
+	NotScreen []string `yaml:"not_screen"`
+	// State maps dotted paths into the JSON of the model state, e.g.
+	// modals.confirmTarget or rollback.mode, to the value expected there
+	State map[string]any `yaml:"state"`
+	// Requests lists "METHOD /path" calls the API must have received by now;
+	// Bodies maps such a call to text its last request body must contain
+	Requests []string          `yaml:"requests"`
+	Bodies   map[string]string `yaml:"bodies"`
+}
+
+const (
+	// scenarioCmdTimeout is how long a command gets to return its message.
+	// Ticks and streams take longer and are dropped.
+	scenarioCmdTimeout = 250 * time.Millisecond
+	// scenarioMaxRounds bounds how many rounds of follow-up commands one
+	// step runs, in case commands keep returning more
+	scenarioMaxRounds = 20
+)
+
+func TestScenarios(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "scenarios", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no scenarios in testdata/scenarios")
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".yaml")
+		t.Run(name, func(t *testing.T) { runScenario(t, file) })
+	}
+}
+
+func runScenario(t *testing.T, file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sc scenario
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&sc); err != nil {
+		t.Fatalf("invalid scenario: %v", err)
+	}
+
+	withDeterministicMode(t)
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(t.TempDir(), "config.toml"))
+	api := newScenarioAPI(t, sc.API)
+	m := newScenarioModel(t, sc, api.srv.URL)
+
+	for i, step := range sc.Steps {
+		for _, k := range step.Keys {
+			_, cmd := m.Update(keyPress(k))
+			settleScenario(m, cmd)
+		}
+		for _, r := range step.Type {
+			_, cmd := m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+			settleScenario(m, cmd)
+		}
+		if step.Expect != nil {
+			checkScenarioStep(t, fmt.Sprintf("step %d", i+1), m, api, step.Expect)
+		}
+		if t.Failed() {
+			// Later steps build on this one
+			return
+		}
+	}
+}
+
+func newScenarioModel(t *testing.T, sc scenario, serverURL string) *Model {
+	t.Helper()
+	cfg := config.GetDefaultConfig()
+	if err := toml.Unmarshal([]byte(sc.Config), cfg); err != nil {
+		t.Fatalf("invalid scenario config: %v", err)
+	}
+	// Scenarios only see the requests their keys lead to
+	cfg.Prefetch.Enabled = new(bool)
+
+	cols, rows := sc.Terminal.Cols, sc.Terminal.Rows
+	if cols == 0 {
+		cols = 120
+	}
+	if rows == 0 {
+		rows = 30
+	}
+	m := NewModel(cfg)
+	m.ready = true
+	m.state.Terminal.Cols = cols
+	m.state.Terminal.Rows = rows
+	m.state.Mode = model.ModeNormal
+	m.state.Modals = model.ModalState{}
+	m.state.Server = &model.Server{BaseURL: serverURL, Token: "scenario-token"}
+	m.state.Navigation.View = model.ViewApps
+	if sc.View != "" {
+		m.state.Navigation.View = model.View(sc.View)
+	}
+
+	raw, err := json.Marshal(sc.Apps)
+	if err != nil {
+		t.Fatalf("invalid scenario apps: %v", err)
+	}
+	if err := json.Unmarshal(raw, &m.state.Apps); err != nil {
+		t.Fatalf("invalid scenario apps: %v", err)
+	}
+	return m
+}
+
+// settleScenario runs cmd and the commands its messages lead to, feeding
+// each message to the model. Commands of a round run concurrently and their
+// messages are handled in the order the commands were issued.
+func settleScenario(m *Model, cmd tea.Cmd) {
+	pending := []tea.Cmd{cmd}
+	for round := 0; round < scenarioMaxRounds && len(pending) > 0; round++ {
+		msgs := make([]tea.Msg, len(pending))
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		for i, c := range pending {
+			if c == nil {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				done := make(chan tea.Msg, 1)
+				go func() { done <- c() }()
+				select {
+				case msg := <-done:
+					mu.Lock()
+					msgs[i] = msg
+					mu.Unlock()
+				case <-time.After(scenarioCmdTimeout):
+				}
+			}()
+		}
+		wg.Wait()
+
+		pending = nil
+		for _, msg := range msgs {
+			if cmds, ok := batchedCmds(msg); ok {
+				pending = append(pending, cmds...)
+				continue
+			}
+			if msg == nil {
+				continue
+			}
+			_, next := m.Update(msg)
+			pending = append(pending, next)
+		}
+	}
+}
+
+// batchedCmds unpacks the messages tea.Batch and tea.Sequence return
+func batchedCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	if msg == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(msg)
+	cmdType := reflect.TypeOf((*tea.Cmd)(nil)).Elem()
+	if v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i], _ = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+func checkScenarioStep(t *testing.T, step string, m *Model, api *scenarioAPI, want *scenarioExpect) {
+	t.Helper()
+	if want.Mode != "" && string(m.state.Mode) != want.Mode {
+		t.Errorf("%s: mode = %q, want %q", step, m.state.Mode, want.Mode)
+	}
+	if want.View != "" && string(m.state.Navigation.View) != want.View {
+		t.Errorf("%s: view = %q, want %q", step, m.state.Navigation.View, want.View)
+	}
+	if want.Selected != nil && m.state.Navigation.SelectedIdx != *want.Selected {
+		t.Errorf("%s: selected = %d, want %d", step, m.state.Navigation.SelectedIdx, *want.Selected)
+	}
+
+	if len(want.Screen) > 0 || len(want.NotScreen) > 0 {
+		screen := stripANSI(m.View().Content)
+		for _, text := range want.Screen {
+			if !strings.Contains(screen, text) {
+				t.Errorf("%s: screen should show %q:\n%s", step, text, screen)
+			}
+		}
+		for _, text := range want.NotScreen {
+			if strings.Contains(screen, text) {
+				t.Errorf("%s: screen should not show %q:\n%s", step, text, screen)
+			}
+		}
+	}
+
+	if len(want.State) > 0 {
+		raw, err := json.Marshal(m.state)
+		if err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+		var state any
+		if err := json.Unmarshal(raw, &state); err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+		for path, expected := range want.State {
+			got, found := lookupJSONPath(state, path)
+			if !found {
+				got = nil
+			}
+			if !jsonEqual(got, expected) {
+				t.Errorf("%s: state %s = %v, want %v", step, path, got, expected)
+			}
+		}
+	}
+
+	for _, call := range want.Requests {
+		if _, ok := api.body(call); !ok {
+			t.Errorf("%s: expected request %s, got %v", step, call, api.calls())
+		}
+	}
+	for call, text := range want.Bodies {
+		body, ok := api.body(call)
+		if !ok {
+			t.Errorf("%s: expected request %s, got %v", step, call, api.calls())
+		} else if !strings.Contains(body, text) {
+			t.Errorf("%s: body of %s should contain %q, got %s", step, call, text, body)
+		}
+	}
+}
+
+// lookupJSONPath follows a dotted path of object keys and list indexes
+func lookupJSONPath(v any, path string) (any, bool) {
+	for _, part := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			next, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []any:
+			var i int
+			if _, err := fmt.Sscan(part, &i); err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// jsonEqual compares values after a JSON round trip, so YAML's ints match
+// JSON's floats
+func jsonEqual(a, b any) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	var na, nb any
+	_ = json.Unmarshal(ra, &na)
+	_ = json.Unmarshal(rb, &nb)
+	return reflect.DeepEqual(na, nb)
+}
+
+// scenarioAPI serves a scenario's canned responses and records the calls
+type scenarioAPI struct {
+	srv *httptest.Server
+
+	mu     sync.Mutex
+	order  []string
+	bodies map[string]string
+}
+
+func newScenarioAPI(t *testing.T, calls []scenarioCall) *scenarioAPI {
+	t.Helper()
+	a := &scenarioAPI{bodies: map[string]string{}}
+	a.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		call := r.Method + " " + r.URL.Path
+		a.mu.Lock()
+		a.order = append(a.order, call)
+		a.bodies[call] = string(body)
+		a.mu.Unlock()
+
+		for _, c := range calls {
+			method := c.Method
+			if method == "" {
+				method = http.MethodGet
+			}
+			if method != r.Method || c.Path != r.URL.Path {
+				continue
+			}
+			status := c.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(c.Body)
+			return
+		}
+		http.Error(w, `{"message":"not in scenario"}`, http.StatusNotFound)
+	}))
+	t.Cleanup(a.srv.Close)
+	return a
+}
+
+func (a *scenarioAPI) body(call string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	body, ok := a.bodies[call]
+	return body, ok
+}
+
+func (a *scenarioAPI) calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.order...)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// handleScopeToAppProject scopes the apps view to the project of the app
+// under the cursor (P)
+func (m *Model) handleScopeToAppProject() (tea.Model, tea.Cmd) {
+	return m.scopeToSelectedApp("project", func(app model.App) *string { return app.Project },
+		func(sel *model.SelectionState, v string) { sel.ScopeProjects = model.StringSetFromSlice([]string{v}) })
+}
+
+// handleScopeToAppCluster scopes the apps view to the destination cluster of
+// the app under the cursor (C)
+func (m *Model) handleScopeToAppCluster() (tea.Model, tea.Cmd) {
+	return m.scopeToSelectedApp("cluster", func(app model.App) *string { return app.ClusterLabel },
+		func(sel *model.SelectionState, v string) { sel.ScopeClusters = model.StringSetFromSlice([]string{v}) })
+}
+
+// scopeToSelectedApp replaces every scope with the one value of the app under
+// the cursor, so the apps view lists its neighbours. The cursor stays on the
+// app and search filters are cleared, as they would be after a drill-down.
+func (m *Model) scopeToSelectedApp(kind string, value func(model.App) *string, apply func(*model.SelectionState, string)) (tea.Model, tea.Cmd) {
+	if m.state.Navigation.View != model.ViewApps {
+		return m, nil
+	}
+	items := m.getVisibleItemsForCurrentView()
+	if m.state.Navigation.SelectedIdx >= len(items) {
+		return m, nil
+	}
+	app, ok := items[m.state.Navigation.SelectedIdx].(model.App)
+	if !ok {
+		return m, nil
+	}
+	v := value(app)
+	if v == nil || *v == "" {
+		return m, func() tea.Msg {
+			return model.StatusChangeMsg{Status: app.Name + " has no " + kind}
+		}
+	}
+
+	sel := &m.state.Selections
+	sel.ScopeClusters = model.NewStringSet()
+	sel.ScopeNamespaces = model.NewStringSet()
+	sel.ScopeProjects = model.NewStringSet()
+	sel.ScopeApplicationSets = model.NewStringSet()
+	sel.SelectedApps = model.NewStringSet()
+	apply(sel, *v)
+	m.state.UI.ActiveFilter = ""
+	m.state.UI.SearchQuery = ""
+
+	m.listNav.Reset()
+	scoped := m.getVisibleItemsForCurrentView()
+	m.listNav.SetItemCount(len(scoped))
+	m.listNav.SetViewportHeight(m.listViewportHeight())
+	for i, item := range scoped {
+		if a, ok := item.(model.App); ok && a.Name == app.Name {
+			m.listNav.SetCursor(i)
+			break
+		}
+	}
+	m.state.Navigation.SelectedIdx = m.listNav.Cursor()
+
+	m.statusService.Set("Scoped to " + kind + " " + *v)
+	return m, m.maybeRestartWatchForScope()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// buildScopeJumpTestModel lists apps across two projects and two clusters,
+// scoped to prod and filtered to "l", with the cursor on "billing" (prod cluster, payments project)
+func buildScopeJumpTestModel() *Model {
+	m := buildDeleteTestModel(120, 30)
+	str := func(s string) *string { return &s }
+	m.state.Apps = []model.App{
+		{Name: "api", Project: str("payments"), ClusterLabel: str("staging")},
+		{Name: "billing", Project: str("payments"), ClusterLabel: str("prod")},
+		{Name: "checkout", Project: str("shop"), ClusterLabel: str("prod")},
+		{Name: "ledger", Project: str("payments"), ClusterLabel: str("prod")},
+		{Name: "orphan"},
+	}
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+	m.state.Selections.ScopeClusters = model.StringSetFromSlice([]string{"prod"})
+	m.state.UI.ActiveFilter = "l" // billing, ledger
+	m.state.Navigation.SelectedIdx = 0
+	return m
+}
+
+func visibleAppNames(m *Model) []string {
+	var names []string
+	for _, item := range m.getVisibleItemsForCurrentView() {
+		names = append(names, item.(model.App).Name)
+	}
+	return names
+}
+
+func TestScopeJump_Project(t *testing.T) {
+	m := buildScopeJumpTestModel()
+
+	m.handleKeyMsg(testKeyMsg("P"))
+
+	if !model.HasInStringSet(m.state.Selections.ScopeProjects, "payments") || len(m.state.Selections.ScopeClusters) != 0 {
+		t.Fatalf("P should scope to the app's project only, got %+v", m.state.Selections)
+	}
+	if got := visibleAppNames(m); len(got) != 3 || got[0] != "api" || got[2] != "ledger" {
+		t.Errorf("expected the payments apps, got %v", got)
+	}
+	if m.state.Navigation.SelectedIdx != 1 {
+		t.Errorf("cursor should stay on billing, got index %d", m.state.Navigation.SelectedIdx)
+	}
+	if m.state.UI.ActiveFilter != "" {
+		t.Errorf("filter should be cleared, got %q", m.state.UI.ActiveFilter)
+	}
+}
+
+func TestScopeJump_Cluster(t *testing.T) {
+	m := buildScopeJumpTestModel()
+	m.handleKeyMsg(testKeyMsg("P"))
+	m.state.Navigation.SelectedIdx = 2 // ledger
+
+	m.handleKeyMsg(testKeyMsg("C"))
+
+	if len(m.state.Selections.ScopeProjects) != 0 || !model.HasInStringSet(m.state.Selections.ScopeClusters, "prod") {
+		t.Fatalf("C should replace the project scope with the app's cluster, got %+v", m.state.Selections)
+	}
+	got := visibleAppNames(m)
+	if len(got) != 3 || got[m.state.Navigation.SelectedIdx] != "ledger" {
+		t.Errorf("expected the prod apps with ledger selected, got %v at %d", got, m.state.Navigation.SelectedIdx)
+	}
+}
+
+func TestScopeJump_AppWithoutCluster(t *testing.T) {
+	m := buildScopeJumpTestModel()
+	m.state.Selections.ScopeClusters = model.NewStringSet()
+	m.state.UI.ActiveFilter = "orphan"
+
+	_, cmd := m.handleKeyMsg(testKeyMsg("C"))
+
+	if len(m.state.Selections.ScopeClusters) != 0 || m.state.UI.ActiveFilter != "orphan" {
+		t.Error("scopes should be left alone when the app has no cluster")
+	}
+	if cmd == nil {
+		t.Fatal("expected a status explaining the app has no cluster")
+	}
+	if st, ok := cmd().(model.StatusChangeMsg); !ok || st.Status != "orphan has no cluster" {
+		t.Errorf("expected a status explaining the app has no cluster, got %#v", st)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// Regions of the main screen, top to bottom
+const (
+	regionHeader  = "header"
+	regionGap     = "gap"
+	regionSearch  = "search"
+	regionCommand = "command"
+	regionBody    = "body"
+	regionHints   = "hints"
+	regionStatus  = "status"
+)
+
+// screenBox is the padding mainContainerStyle puts around every screen
+var screenBox = layout.Box{PadX: 1}
+
+// mainScreen is the main layout split into its regions, with the parts
+// already rendered that decide their heights
+type mainScreen struct {
+	header     string
+	gap        bool
+	searchBar  string
+	commandBar string
+	stack      layout.Stack
+}
+
+// layoutMainScreen splits the terminal for renderMainLayout. The body, the
+// bordered list or tree, takes whatever the bars above and below it leave.
+func (m *Model) layoutMainScreen() mainScreen {
+	s := mainScreen{header: m.renderBanner()}
+	// A subtle vertical gap only in the wide full layout. The narrow banner
+	// already includes spacing, and compact or hidden banners are chosen to
+	// save the line.
+	s.gap = m.state.Terminal.Cols > 100 && m.config.GetBannerMode() == config.BannerFull
+	if m.state.Mode == model.ModeSearch {
+		s.searchBar = m.renderEnhancedSearchBar()
+	}
+	if m.state.Mode == model.ModeCommand {
+		s.commandBar = m.renderEnhancedCommandBar()
+	}
+	gapLines := 0
+	if s.gap {
+		gapLines = 1
+	}
+	s.stack = layout.VStack(m.state.Terminal.Rows,
+		layout.Fixed(regionHeader, countLines(s.header)),
+		layout.Fixed(regionGap, gapLines),
+		layout.Fixed(regionSearch, countLines(s.searchBar)),
+		layout.Fixed(regionCommand, countLines(s.commandBar)),
+		layout.Fill(regionBody, layout.Card.OuterHeight(1)),
+		layout.Fixed(regionHints, m.keyHintLines()),
+		layout.Fixed(regionStatus, 1),
+	)
+	return s
+}
+
+// bodyHeight is the outer height of the bordered list or tree
+func (m *Model) bodyHeight() int {
+	return m.layoutMainScreen().stack.Height(regionBody)
+}
+
+// screenWidth is the width inside the padding of every screen, which the
+// bordered boxes and single-line bars fill
+func (m *Model) screenWidth() int {
+	return screenBox.InnerWidth(m.state.Terminal.Cols)
+}
+
+// modalWidth sizes a centered modal as num/den of the terminal width, at
+// least minWidth, leaving a margin on each side
+func (m *Model) modalWidth(minWidth, num, den int) int {
+	return layout.ModalWidth(m.state.Terminal.Cols, minWidth, num, den)
+}
+
+// diffViewportHeight is how many diff lines the diff pager shows: the
+// inside of its box, between the title and the status line
+func (m *Model) diffViewportHeight() int {
+	body := layout.VStack(m.state.Terminal.Rows,
+		layout.Fixed(regionHeader, 1),
+		layout.Fill(regionBody, layout.Card.OuterHeight(3)),
+		layout.Fixed(regionStatus, 1),
+	).Height(regionBody)
+	return layout.Card.InnerHeight(body)
+}
+
+// rollbackBoxHeight is the outer height of the rollback modal box, which
+// fills the screen between the header, a blank line and the status line
+func (m *Model) rollbackBoxHeight(header string) int {
+	return layout.VStack(m.state.Terminal.Rows,
+		layout.Fixed(regionHeader, countLines(header)),
+		layout.Fixed(regionGap, 1),
+		layout.Fill(regionBody, layout.Card.OuterHeight(1)),
+		layout.Fixed(regionStatus, 1),
+	).Height(regionBody)
+}
+
+// rollbackHistoryRows is how many deployments the rollback list shows at
+// once. Around them the modal draws 2 lines of title, 2 more for the
+// current revision, 2 for the section header, 2 for the options and 3 for
+// the instructions.
+func (m *Model) rollbackHistoryRows(rollback *model.RollbackState) int {
+	fixed := 2 + 2 + 2 + 3
+	if rollback.CurrentRevision != "" {
+		fixed += 2
+	}
+	inner := layout.Card.InnerHeight(m.rollbackBoxHeight(m.renderBanner()))
+	return max(1, inner-fixed)
+}
+
+// themeListLines is how many lines the theme picker gives its list: the
+// modal's inside, less the title and footer lines, above the status line
+func (m *Model) themeListLines() int {
+	const titleLines, footerLines = 2, 2 // title + blank, warning + blank
+	box := layout.VStack(m.state.Terminal.Rows,
+		layout.Fill(regionBody, 0),
+		layout.Fixed(regionStatus, 1),
+	).Height(regionBody)
+	return max(5, layout.Dialog.InnerHeight(box)-titleLines-footerLines)
+}
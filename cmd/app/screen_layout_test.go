@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// terminalSizes covers each size class the views switch on: the compact
+// banner at 22 rows and under, the narrow banner at 100 columns and under,
+// the wide help at 60 columns and the gap line of the wide full layout
+var terminalSizes = []struct {
+	class      string
+	cols, rows int
+}{
+	{"tiny", 30, 10},
+	{"compact", 60, 20},
+	{"standard", 80, 24},
+	{"narrow tall", 100, 40},
+	{"wide", 140, 40},
+	{"huge", 240, 70},
+}
+
+// buildLayoutTestModel has more apps than any terminal shows, with the
+// cursor on the last one
+func buildLayoutTestModel(cols, rows int) *Model {
+	m := buildDeleteTestModel(cols, rows)
+	m.state.Apps = nil
+	for i := range 80 {
+		m.state.Apps = append(m.state.Apps, model.App{Name: fmt.Sprintf("app-%02d", i), Sync: "Synced", Health: "Healthy"})
+	}
+	return m
+}
+
+// moveListCursorToEnd puts the cursor on the last app the way key
+// navigation does, sizing the list with listViewportHeight
+func moveListCursorToEnd(m *Model) {
+	m.listNav.SetItemCount(len(m.getVisibleItemsForCurrentView()))
+	m.listNav.SetViewportHeight(m.listViewportHeight())
+	m.listNav.SetCursor(len(m.state.Apps) - 1)
+	m.state.Navigation.SelectedIdx = m.listNav.Cursor()
+}
+
+// assertFillsTerminal checks a screen takes exactly the terminal's rows and
+// that no line is wider than the terminal, so nothing wraps or scrolls
+func assertFillsTerminal(t *testing.T, name string, m *Model, out string) {
+	t.Helper()
+	if h := lipgloss.Height(out); h != m.state.Terminal.Rows {
+		t.Errorf("%s: %d rows, want the terminal's %d", name, h, m.state.Terminal.Rows)
+	}
+	for i, line := range strings.Split(out, "\n") {
+		if w := lipgloss.Width(line); w > m.state.Terminal.Cols {
+			t.Errorf("%s: line %d is %d wide, past the terminal's %d", name, i, w, m.state.Terminal.Cols)
+			break
+		}
+	}
+}
+
+func TestScreenLayout_AppsFillTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		for _, mode := range []model.Mode{model.ModeNormal, model.ModeSearch, model.ModeCommand} {
+			name := fmt.Sprintf("%s %dx%d %s", size.class, size.cols, size.rows, mode)
+			m := buildLayoutTestModel(size.cols, size.rows)
+			m.state.Mode = mode
+			moveListCursorToEnd(m)
+
+			out := m.renderMainLayout()
+			assertFillsTerminal(t, name, m, out)
+			if !strings.Contains(stripANSI(out), "app-79") {
+				t.Errorf("%s: the cursor's app should be on screen:\n%s", name, stripANSI(out))
+			}
+		}
+	}
+}
+
+func TestScreenLayout_DetailsPaneFillsTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildLayoutTestModel(size.cols, size.rows)
+		m.state.UI.DetailsPane = true
+		assertFillsTerminal(t, size.class, m, m.renderMainLayout())
+	}
+}
+
+func TestScreenLayout_TreeFillsTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildDeleteTestModel(size.cols, size.rows)
+		m.state.Navigation.View = model.ViewTree
+		m.treeView = treeview.NewTreeView(0, 0)
+		tree := api.ResourceTree{Nodes: []api.ResourceNode{{UID: "d1", Group: "apps", Version: "v1", Kind: "Deployment", Name: "web"}}}
+		for i := range 80 {
+			tree.Nodes = append(tree.Nodes, api.ResourceNode{UID: fmt.Sprintf("p%d", i), Version: "v1", Kind: "Pod", Name: fmt.Sprintf("web-%02d", i),
+				ParentRefs: []api.ResourceRef{{Group: "apps", Kind: "Deployment", UID: "d1"}}})
+		}
+		m.treeView.UpsertAppTree("test-app", &tree)
+		m.treeView.SetSelectedIndex(m.treeView.VisibleCount() - 1)
+		m.treeNav.SetItemCount(m.treeView.VisibleCount())
+		m.treeNav.SetViewportHeight(m.treeViewportHeight())
+		m.treeNav.SetCursor(m.treeView.SelectedIndex())
+
+		out := m.renderMainLayout()
+		assertFillsTerminal(t, size.class, m, out)
+		if !strings.Contains(stripANSI(out), "web-79") {
+			t.Errorf("%s: the cursor's pod should be on screen:\n%s", size.class, stripANSI(out))
+		}
+	}
+}
+
+func TestScreenLayout_DiffPagesWhatItShows(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildDeleteTestModel(size.cols, size.rows)
+		m.state.Mode = model.ModeDiff
+		m.state.Diff = &model.DiffState{Title: strings.Repeat("a very long diff title ", 20)}
+		for i := range 200 {
+			m.state.Diff.Content = append(m.state.Diff.Content, fmt.Sprintf("line %d", i))
+		}
+
+		out := m.renderDiffView()
+		assertFillsTerminal(t, size.class, m, out)
+		want := fmt.Sprintf("1-%d/200", m.diffPageSize())
+		if !strings.Contains(stripANSI(out), want) {
+			t.Errorf("%s: a page should be the %d lines shown, status %q missing:\n%s", size.class, m.diffPageSize(), want, stripANSI(out))
+		}
+	}
+}
+
+func TestScreenLayout_RollbackFillsTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildDeleteTestModel(size.cols, size.rows)
+		name := "test-app"
+		m.state.Modals.RollbackAppName = &name
+		assertFillsTerminal(t, size.class+" loading", m, m.renderRollbackModal())
+
+		m.state.Rollback = &model.RollbackState{AppName: name, CurrentRevision: "abcdef1234"}
+		for i := range 40 {
+			m.state.Rollback.Rows = append(m.state.Rollback.Rows, model.RollbackRow{ID: i, Revision: fmt.Sprintf("rev%02d", i)})
+		}
+		assertFillsTerminal(t, size.class+" history", m, m.renderRollbackModal())
+	}
+}
+
+func TestScreenLayout_ModalsFitTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildDeleteTestModel(size.cols, size.rows)
+		for _, w := range []int{m.modalWidth(60, 2, 3), m.modalWidth(36, 1, 2)} {
+			if w > size.cols {
+				t.Errorf("%s: a modal %d wide does not fit %d columns", size.class, w, size.cols)
+			}
+		}
+	}
+}
+
+func TestScreenLayout_DeviceLoginFillsTheTerminal(t *testing.T) {
+	for _, size := range terminalSizes {
+		m := buildDeleteTestModel(size.cols, size.rows)
+		m.state.Server = &model.Server{BaseURL: "https://argo.example.com"}
+		m.state.Mode = model.ModeDeviceLogin
+		m.deviceLogin = &deviceLoginState{server: m.state.Server, code: &api.DeviceCode{
+			UserCode: "ABCD-EFGH", VerificationURIComplete: "https://argo.example.com/api/dex/device?user_code=ABCD-EFGH",
+		}}
+		assertFillsTerminal(t, size.class, m, m.renderDeviceLoginView())
+	}
+}
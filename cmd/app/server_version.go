@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+)
+
+const (
+	// serverVersionRefreshInterval is how often the server version is read
+	// again to notice upgrades during a session
+	serverVersionRefreshInterval = 2 * time.Minute
+	// serverVersionConfirmDelay is how soon a changed version is read again
+	// before acting on it; behind a load balancer, replicas of a rolling
+	// upgrade answer with different versions for a while
+	serverVersionConfirmDelay = 15 * time.Second
+)
+
+// serverVersionCheckMsg asks for the server version to be read again
+type serverVersionCheckMsg struct {
+	epoch int
+}
+
+// serverVersionPolledMsg carries the server version read in the
+// background, or "" when it could not be read
+type serverVersionPolledMsg struct {
+	epoch   int
+	version string
+}
+
+// startServerVersionChecks schedules the first background version read for
+// the current context; later reads are chained by the handler
+func (m *Model) startServerVersionChecks() tea.Cmd {
+	if m.versionPolling || m.state.Server == nil {
+		return nil
+	}
+	m.versionPolling = true
+	return m.scheduleServerVersionCheck(serverVersionRefreshInterval)
+}
+
+// scheduleServerVersionCheck reads the server version again after delay
+func (m *Model) scheduleServerVersionCheck(delay time.Duration) tea.Cmd {
+	epoch := m.switchEpoch
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return serverVersionCheckMsg{epoch: epoch}
+	})
+}
+
+// pollServerVersion reads the server version. Failures are logged and keep
+// the version known so far.
+func (m *Model) pollServerVersion() tea.Cmd {
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	if server == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		v, err := services.NewArgoApiService(server).GetAPIVersion(ctx, server)
+		if err != nil {
+			cblog.With("component", "compat").Debug("Could not read the server version", "err", err)
+			return serverVersionPolledMsg{epoch: epoch}
+		}
+		return serverVersionPolledMsg{epoch: epoch, version: v}
+	}
+}
+
+// handleServerVersionPolled compares a background read with the known
+// version. A different version is read once more before it counts, so a
+// single replica of a rolling upgrade doesn't restart everything; when the
+// next read has the old version again, the replicas disagree and that is
+// reported once.
+func (m *Model) handleServerVersionPolled(msg serverVersionPolledMsg) tea.Cmd {
+	current := m.state.APIVersion
+	switch {
+	case msg.version == "" || current == "":
+		return m.scheduleServerVersionCheck(serverVersionRefreshInterval)
+
+	case msg.version == current:
+		candidate := m.versionCandidate
+		m.versionCandidate = ""
+		if candidate == "" || m.versionSkewWarned {
+			return m.scheduleServerVersionCheck(serverVersionRefreshInterval)
+		}
+		m.versionSkewWarned = true
+		text := fmt.Sprintf("Argo CD replicas report different versions (%s and %s)", current, candidate)
+		cblog.With("component", "compat").Warn(text)
+		m.recordError("compat", text, "A rolling upgrade may be in progress; requests answered by either replica can fail until it finishes.", nil)
+		return tea.Batch(
+			m.showStatusNote("⚠ "+text),
+			m.scheduleServerVersionCheck(serverVersionRefreshInterval),
+		)
+
+	case msg.version != m.versionCandidate:
+		m.versionCandidate = msg.version
+		cblog.With("component", "compat").Info("Server version changed, confirming", "from", current, "to", msg.version)
+		return m.scheduleServerVersionCheck(serverVersionConfirmDelay)
+	}
+
+	m.versionCandidate = ""
+	return tea.Batch(
+		m.handleServerVersionChanged(current, msg.version),
+		m.scheduleServerVersionCheck(serverVersionRefreshInterval),
+	)
+}
+
+// handleServerVersionChanged adopts the upgraded server's version: features
+// are checked again since they depend on the release, and the streams are
+// reconnected since the old ones were cut or answered by old replicas
+func (m *Model) handleServerVersionChanged(prev, next string) tea.Cmd {
+	text := fmt.Sprintf("Argo CD changed from %s to %s", prev, next)
+	cblog.With("component", "compat").Warn(text + ", reconnecting")
+	m.statusService.Set(text + ", reconnected")
+	m.recordError("compat", text, "Errors from just before this may come from the upgrade. Features were checked again and the streams reconnected.", nil)
+
+	m.probedEndpoints = nil
+	m.serverCompat = nil
+	m.compatWarned = false
+	m.versionSkewWarned = false
+	// The stream position may not carry over to the new release
+	m.lastResourceVersion = ""
+
+	cmds := []tea.Cmd{
+		m.showStatusNote(text),
+		m.handleAPIVersion(next),
+		m.probeEndpoints(),
+		m.startWatchingApplications(),
+	}
+	if m.state.Navigation.View == model.ViewTree && m.treeView != nil {
+		cmds = append(cmds, m.reloadTree())
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/compat"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func buildServerVersionModel(version string) *Model {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argocd.example.com", Token: "t"}
+	m.state.APIVersion = version
+	return m
+}
+
+func TestServerVersion_ChangeIsConfirmedBeforeReconnecting(t *testing.T) {
+	m := buildServerVersionModel("v3.1.2")
+	m.probedEndpoints = map[compat.Feature]bool{}
+	m.compatWarned = true
+	m.lastResourceVersion = "42"
+
+	m.Update(serverVersionPolledMsg{epoch: m.switchEpoch, version: "v3.2.0"})
+	if m.state.APIVersion != "v3.1.2" || m.versionCandidate != "v3.2.0" {
+		t.Fatalf("a single read should only note the candidate, version = %s, candidate = %q", m.state.APIVersion, m.versionCandidate)
+	}
+	if m.lastResourceVersion != "42" {
+		t.Error("an unconfirmed change should not reconnect")
+	}
+
+	_, cmd := m.Update(serverVersionPolledMsg{epoch: m.switchEpoch, version: "v3.2.0"})
+	if cmd == nil {
+		t.Fatal("a confirmed change should reconnect and keep checking")
+	}
+	if m.state.APIVersion != "v3.2.0" || m.versionCandidate != "" {
+		t.Errorf("version = %s, candidate = %q", m.state.APIVersion, m.versionCandidate)
+	}
+	if m.lastResourceVersion != "" || m.probedEndpoints != nil || m.compatWarned {
+		t.Error("the stream position and feature checks should start over")
+	}
+	if len(m.state.RecentErrors) != 1 || !strings.Contains(m.state.RecentErrors[0].Message, "from v3.1.2 to v3.2.0") {
+		t.Errorf("the upgrade should be explained in the errors drawer, got %+v", m.state.RecentErrors)
+	}
+}
+
+func TestServerVersion_ReplicasWithDifferentVersionsWarnOnce(t *testing.T) {
+	m := buildServerVersionModel("v3.1.2")
+	for i := 0; i < 2; i++ {
+		m.Update(serverVersionPolledMsg{epoch: m.switchEpoch, version: "v3.2.0"})
+		m.Update(serverVersionPolledMsg{epoch: m.switchEpoch, version: "v3.1.2"})
+	}
+	if m.state.APIVersion != "v3.1.2" || m.versionCandidate != "" {
+		t.Errorf("version = %s, candidate = %q", m.state.APIVersion, m.versionCandidate)
+	}
+	if len(m.state.RecentErrors) != 1 || !strings.Contains(m.state.RecentErrors[0].Message, "replicas report different versions") {
+		t.Errorf("the skew should be reported once, got %+v", m.state.RecentErrors)
+	}
+}
+
+func TestServerVersion_FailedReadKeepsVersion(t *testing.T) {
+	m := buildServerVersionModel("v3.1.2")
+	m.versionCandidate = "v3.2.0"
+	_, cmd := m.Update(serverVersionPolledMsg{epoch: m.switchEpoch})
+	if cmd == nil || m.state.APIVersion != "v3.1.2" || m.versionCandidate != "v3.2.0" {
+		t.Errorf("a failed read should change nothing and keep checking, version = %s, candidate = %q", m.state.APIVersion, m.versionCandidate)
+	}
+	if len(m.state.RecentErrors) != 0 {
+		t.Errorf("a failed read should not be reported, got %+v", m.state.RecentErrors)
+	}
+}
+
+func TestServerVersion_StaleEpochIgnored(t *testing.T) {
+	m := buildServerVersionModel("v3.1.2")
+	if _, cmd := m.Update(serverVersionPolledMsg{epoch: m.switchEpoch + 1, version: "v3.2.0"}); cmd != nil || m.versionCandidate != "" {
+		t.Error("a read for another context should be dropped")
+	}
+	if _, cmd := m.Update(serverVersionCheckMsg{epoch: m.switchEpoch + 1}); cmd != nil {
+		t.Error("a check for another context should be dropped")
+	}
+}
+
+func TestServerVersion_PollReadsServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Version":"v3.2.0+abc"}`))
+	}))
+	defer ts.Close()
+
+	m := buildServerVersionModel("v3.1.2")
+	m.state.Server.BaseURL = ts.URL
+	_, cmd := m.Update(serverVersionCheckMsg{epoch: m.switchEpoch})
+	if cmd == nil {
+		t.Fatal("a check should read the version")
+	}
+	msg, ok := cmd().(serverVersionPolledMsg)
+	if !ok || msg.epoch != m.switchEpoch || !strings.HasPrefix(msg.version, "v3.2.0") {
+		t.Errorf("got %+v", msg)
+	}
+}
+
+func TestServerVersion_ChecksStartOnce(t *testing.T) {
+	m := buildServerVersionModel("")
+	m.Update(model.SetAPIVersionMsg{Version: "v3.1.2", SwitchEpoch: m.switchEpoch})
+	if !m.versionPolling {
+		t.Fatal("the first version should start the background checks")
+	}
+	if m.startServerVersionChecks() != nil {
+		t.Error("the checks should only be started once per context")
+	}
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/services"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// stagingMaxVisible is how many steps the release train lists before scrolling
+const stagingMaxVisible = 12
+
+// stagingActions lists the actions in the order space cycles through them
+var stagingActions = []string{model.StageSync, model.StageRefresh, model.StageRollback, model.StageSkip}
+
+// stagingOperations names the operation kind of the actions that start an
+// Argo CD operation, for the cooldown and the quit guard
+var stagingOperations = map[string]string{
+	model.StageSync:     "sync",
+	model.StageRollback: "rollback",
+}
+
+// stagingVerbs describes a running step by its action
+var stagingVerbs = map[string]string{
+	model.StageSync:     "syncing",
+	model.StageRefresh:  "refreshing",
+	model.StageRollback: "rolling back",
+}
+
+// stagingStepMsg reports the request of one step of a release train
+type stagingStepMsg struct {
+	id    int
+	epoch int
+	step  int
+	// message describes what was requested, such as the rollback target
+	message string
+	err     error
+}
+
+// stagingTickMsg re-checks the app of a step whose sync or rollback was
+// accepted, until its operation has finished
+type stagingTickMsg struct {
+	id   int
+	step int
+	at   time.Time
+}
+
+// handleStageCommand opens a release train of the selected apps, or of the
+// app under the cursor when none are selected, each staged for a sync
+func (m *Model) handleStageCommand() (tea.Model, tea.Cmd) {
+	var apps []model.App
+	for _, app := range m.state.Apps {
+		if m.state.Selections.SelectedApps[app.Name] {
+			apps = append(apps, app)
+		}
+	}
+	if len(apps) == 0 {
+		app, ok := m.cursorApp()
+		if !ok {
+			return m, func() tea.Msg {
+				return model.StatusChangeMsg{Status: "Select apps with space to stage actions for them"}
+			}
+		}
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+
+	steps := make([]model.StagedStep, 0, len(apps))
+	for _, app := range apps {
+		steps = append(steps, model.StagedStep{Name: app.Name, AppNamespace: app.AppNamespace, Action: model.StageSync})
+	}
+	m.stagingSeq++
+	m.state.Modals.Staging = &model.StagingState{Steps: steps, ID: m.stagingSeq}
+	m.state.Mode = model.ModeStaging
+	return m, nil
+}
+
+// stagingEditable reports whether a step can still be changed or moved:
+// it has not run, or it failed and runs again on resume
+func stagingEditable(st *model.StagingState, idx int) bool {
+	return !st.Running && idx >= st.Next && idx < len(st.Steps)
+}
+
+// startStaging runs the release train from its next step
+func (m *Model) startStaging() tea.Cmd {
+	if m.state.Server == nil {
+		return func() tea.Msg { return model.ApiErrorMsg{Message: "No server configured"} }
+	}
+	st := m.state.Modals.Staging
+	st.Running = true
+	cblog.With("component", "staging").Info("Running release train", "steps", len(st.Steps), "from", st.Next)
+	return m.stagingNext()
+}
+
+// stagingNext starts the next step of the running release train; skipped
+// steps are passed over and the train finishes after the last step
+func (m *Model) stagingNext() tea.Cmd {
+	st := m.state.Modals.Staging
+	for st.Next < len(st.Steps) && st.Steps[st.Next].Action == model.StageSkip {
+		st.Steps[st.Next].Status = model.StepSkipped
+		st.Next++
+	}
+	if st.Next >= len(st.Steps) {
+		return m.finishStaging()
+	}
+
+	idx := st.Next
+	step := &st.Steps[idx]
+	step.Status, step.Message, step.StartedAt = model.StepRunning, "", clockNow()
+	step.Deadline = time.Now().Add(waitDefaultTimeout)
+	step.PrevOperationAt = nil
+	if app := m.findAppByNameAndNamespace(step.Name, derefOr(step.AppNamespace)); app != nil {
+		step.PrevOperationAt = app.OperationStartedAt
+	}
+	st.SelectedIdx = idx
+
+	// Syncs and rollbacks go by the same rules as manual ones: a repeat
+	// within the cooldown is refused, and quitting asks while they run
+	if kind := stagingOperations[step.Action]; kind != "" {
+		if ago, ok := m.recentlyRequested(kind, step.Name, step.AppNamespace); ok {
+			return m.failStagingStep(repeatedOperationText(kind, step.Name, ago))
+		}
+		m.trackWatchedOperation(kind, step.Name, step.AppNamespace)
+	}
+
+	id := st.ID
+	name, appNamespace, action := step.Name, step.AppNamespace, step.Action
+	epoch := m.switchEpoch   // capture at call time
+	server := m.state.Server // capture at call time
+	return func() tea.Msg {
+		msg := stagingStepMsg{id: id, epoch: epoch, step: idx}
+		switch action {
+		case model.StageRefresh:
+			ctx, cancel := appcontext.WithAPITimeout(context.Background())
+			defer cancel()
+			msg.err = api.NewApplicationService(server).RefreshApplication(ctx, name, &api.RefreshOptions{AppNamespace: appNamespace})
+		case model.StageSync:
+			ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			msg.err = services.NewEnhancedArgoApiService(server).SyncApplication(ctx, server, name, appNamespace, services.SyncOptions{})
+		case model.StageRollback:
+			msg.message, msg.err = rollbackToPrevious(server, name, appNamespace)
+		}
+		if msg.err != nil {
+			cblog.With("component", "staging").Error("Step failed", "app", name, "action", action, "err", msg.err)
+		}
+		return msg
+	}
+}
+
+// rollbackToPrevious rolls an app back to the deployment before its
+// current one and describes the target. Loading the history and the
+// rollback each get their own timeout, with the floors of a manual rollback.
+func rollbackToPrevious(server *model.Server, name string, appNamespace *string) (string, error) {
+	svc := services.NewArgoApiService(server)
+	ctx, cancel := appcontext.WithMinAPITimeout(context.Background(), 30*time.Second)
+	app, err := svc.GetApplication(ctx, server, name, appNamespace)
+	cancel()
+	if err != nil {
+		return "", err
+	}
+	history := app.Status.History
+	if len(history) < 2 {
+		return "", errors.New("no earlier deployment to roll back to")
+	}
+	target := history[len(history)-2]
+	ctx, cancel = appcontext.WithMinAPITimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	err = svc.RollbackApplication(ctx, server, model.RollbackRequest{ID: target.ID, Name: name, AppNamespace: appNamespace})
+	return fmt.Sprintf("to #%d (%s)", target.ID, shortRevision(target.Revision)), err
+}
+
+// handleStagingStep records the outcome of a step's request. A refresh is
+// done once requested; a sync or rollback is followed until its operation
+// finishes.
+func (m *Model) handleStagingStep(msg stagingStepMsg) tea.Cmd {
+	st := m.state.Modals.Staging
+	if st == nil || st.ID != msg.id || !st.Running || st.Next != msg.step {
+		return nil
+	}
+	step := &st.Steps[msg.step]
+	if kind := stagingOperations[step.Action]; kind != "" {
+		if msg.err != nil {
+			m.forgetWatchedOperation(step.Name, step.AppNamespace)
+		} else {
+			m.confirmWatchedOperations(kind, appKey(step.Name, step.AppNamespace))
+			m.rememberRequestedOperation(kind, step.Name, step.AppNamespace)
+		}
+	}
+	if msg.err != nil {
+		return m.failStagingStep(extractUserFriendlyError(msg.err))
+	}
+	step.Message = msg.message
+	if step.Action == model.StageRefresh {
+		step.Status = model.StepDone
+		st.Next++
+		return m.stagingNext()
+	}
+	return m.stagingTick(msg.id, msg.step)
+}
+
+// stagingTick schedules the next check of a step's operation
+func (m *Model) stagingTick(id, step int) tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return stagingTickMsg{id: id, step: step, at: t} })
+}
+
+// handleStagingTick moves on once the step's app has finished an operation
+// started after the step, and stops the train if it failed or never came
+func (m *Model) handleStagingTick(msg stagingTickMsg) tea.Cmd {
+	st := m.state.Modals.Staging
+	if st == nil || st.ID != msg.id || !st.Running || st.Next != msg.step {
+		return nil
+	}
+	step := &st.Steps[msg.step]
+	app := m.findAppByNameAndNamespace(step.Name, derefOr(step.AppNamespace))
+	if app != nil && app.OperationStartedAt != nil && isFinishedOperation(app.OperationPhase) &&
+		(step.PrevOperationAt == nil || !app.OperationStartedAt.Equal(*step.PrevOperationAt)) {
+		if app.OperationPhase != "Succeeded" {
+			return m.failStagingStep(strings.TrimSpace(app.OperationPhase + ": " + app.OperationMessage))
+		}
+		step.Status = model.StepDone
+		st.Next++
+		return m.stagingNext()
+	}
+	if msg.at.After(step.Deadline) {
+		return m.failStagingStep(fmt.Sprintf("%s did not finish within %s", step.Action, waitDefaultTimeout))
+	}
+	return m.stagingTick(msg.id, msg.step)
+}
+
+// failStagingStep stops the train at its current step, which runs again
+// when the train is resumed
+func (m *Model) failStagingStep(reason string) tea.Cmd {
+	st := m.state.Modals.Staging
+	step := &st.Steps[st.Next]
+	step.Status, step.Message = model.StepFailed, reason
+	st.Running = false
+	m.recordError("staging", fmt.Sprintf("Release train stopped: %s of %s failed", step.Action, step.Name), reason, nil)
+	status := fmt.Sprintf("Release train stopped at %s: %s", step.Name, reason)
+	return func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+}
+
+// finishStaging reports the outcome once every step has run
+func (m *Model) finishStaging() tea.Cmd {
+	st := m.state.Modals.Staging
+	st.Running = false
+	ran, skipped := 0, 0
+	for _, step := range st.Steps {
+		if step.Status == model.StepSkipped {
+			skipped++
+		} else {
+			ran++
+		}
+	}
+	status := fmt.Sprintf("Release train done: %d step(s) run, %d skipped", ran, skipped)
+	return func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+}
+
+// handleStagingKeys handles input in the release train. Steps that have
+// not run can be given another action or moved; Enter runs the train, or
+// resumes it from a failed step. Closing a running train stops it after
+// the current step.
+func (m *Model) handleStagingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.Staging
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	idx := st.SelectedIdx
+	switch key := msg.String(); key {
+	case "q", "esc":
+		m.state.Modals.Staging = nil
+		m.state.Mode = model.ModeNormal
+		if st.Next > 0 && st.Next < len(st.Steps) {
+			status := fmt.Sprintf("Stopped the release train after %d of %d steps", st.Next, len(st.Steps))
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: status} }
+		}
+	case "up", "k":
+		if idx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if idx < len(st.Steps)-1 {
+			st.SelectedIdx++
+		}
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = max(0, len(st.Steps)-1)
+	case "s", "r", "b", "x", "space":
+		if !stagingEditable(st, idx) {
+			return m, nil
+		}
+		action := map[string]string{"s": model.StageSync, "r": model.StageRefresh, "b": model.StageRollback, "x": model.StageSkip}[key]
+		if key == "space" {
+			for i, a := range stagingActions {
+				if a == st.Steps[idx].Action {
+					action = stagingActions[(i+1)%len(stagingActions)]
+				}
+			}
+		}
+		st.Steps[idx].Action = action
+	case "J", "K":
+		to := idx + 1
+		if key == "K" {
+			to = idx - 1
+		}
+		if !stagingEditable(st, idx) || !stagingEditable(st, to) {
+			return m, nil
+		}
+		st.Steps[idx], st.Steps[to] = st.Steps[to], st.Steps[idx]
+		st.SelectedIdx = to
+	case "enter":
+		if st.Running {
+			return m, nil
+		}
+		if st.Next >= len(st.Steps) {
+			m.state.Modals.Staging = nil
+			m.state.Mode = model.ModeNormal
+			return m, nil
+		}
+		return m, m.startStaging()
+	}
+	return m, nil
+}
+
+// renderStagingModal renders the release train: each app with its action
+// and, once the train runs, how its step went
+func (m *Model) renderStagingModal() string {
+	st := m.state.Modals.Staging
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	bad := lipgloss.NewStyle().Foreground(outOfSyncColor)
+	good := lipgloss.NewStyle().Foreground(syncedColor)
+
+	finished := st.Next >= len(st.Steps)
+	failed := !st.Running && !finished && st.Steps[st.Next].Status == model.StepFailed
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render(fmt.Sprintf("Release train of %d apps", len(st.Steps)))
+	if st.Running {
+		title += " " + dim.Render(fmt.Sprintf("%s step %d/%d", m.spinner.View(), st.Next+1, len(st.Steps)))
+	}
+	lines := []string{title, ""}
+
+	nameWidth := 0
+	for _, step := range st.Steps {
+		nameWidth = max(nameWidth, len(step.Name))
+	}
+	nameWidth = min(nameWidth, innerWidth/2)
+	actionStyles := map[string]lipgloss.Style{
+		model.StageSync:     lipgloss.NewStyle().Foreground(cyanBright),
+		model.StageRefresh:  lipgloss.NewStyle(),
+		model.StageRollback: lipgloss.NewStyle().Foreground(yellowBright),
+		model.StageSkip:     dim,
+	}
+
+	st.SelectedIdx = min(st.SelectedIdx, max(0, len(st.Steps)-1))
+	startIdx := 0
+	if st.SelectedIdx >= stagingMaxVisible {
+		startIdx = st.SelectedIdx - stagingMaxVisible + 1
+	}
+	endIdx := min(len(st.Steps), startIdx+stagingMaxVisible)
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		step := st.Steps[i]
+		var detail string
+		style := dim
+		switch step.Status {
+		case model.StepRunning:
+			detail, style = m.spinner.View()+" "+stagingVerbs[step.Action], lipgloss.NewStyle()
+			if step.Message != "" {
+				detail += " " + step.Message
+			}
+		case model.StepDone:
+			detail, style = "✓ "+step.Message, good
+		case model.StepFailed:
+			detail, style = "✗ "+step.Message, bad
+		case model.StepSkipped:
+			detail = "skipped"
+		}
+		action := fmt.Sprintf("%-8s", step.Action)
+		name := fmt.Sprintf("%-*s", nameWidth, truncateWithEllipsis(step.Name, nameWidth))
+		text := truncateWithEllipsis(strings.TrimRight(action+" "+name+" "+detail, " "), innerWidth-2)
+		switch {
+		case i == st.SelectedIdx:
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Render("► "+text))
+		case step.Status == model.StepPending:
+			lines = append(lines, "  "+actionStyles[step.Action].Render(text))
+		default:
+			lines = append(lines, "  "+style.Render(text))
+		}
+	}
+	if endIdx < len(st.Steps) {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	var help string
+	switch {
+	case st.Running:
+		help = "Esc to stop after this step"
+	case finished:
+		help = "Enter or Esc to close"
+	case failed:
+		help = "s/r/b/x change the failed step • Enter to continue • Esc to close"
+	default:
+		help = "s sync • r refresh • b rollback • x skip • J/K move • Enter to run • Esc to close"
+	}
+	lines = append(lines, "", dim.Render(truncateWithEllipsis(help, innerWidth)))
+
+	border := cyanBright
+	if failed {
+		border = outOfSyncColor
+	} else if finished {
+		border = syncedColor
+	}
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
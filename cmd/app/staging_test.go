@@ -0,0 +1,196 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// stagingTestModel stages test-app and zzz-other-app against a server that
+// records each request and holds one deployment of test-app in its history
+func stagingTestModel(t *testing.T) (*Model, *[]string) {
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.Query().Get("refresh"))
+		mu.Unlock()
+		if r.Method == http.MethodGet && r.URL.Query().Get("refresh") == "" {
+			w.Write([]byte(`{"metadata":{"name":"test-app"},"status":{"history":[{"id":4,"revision":"abc1234def"}]}}`))
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	t.Cleanup(srv.Close)
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: srv.URL, Token: "tok"}
+	m.state.Selections.SelectedApps = map[string]bool{"test-app": true, "zzz-other-app": true}
+	m.handleStageCommand()
+	return m, &requests
+}
+
+func TestStaging_RunsTheEditedStepsInOrder(t *testing.T) {
+	m, requests := stagingTestModel(t)
+	st := m.state.Modals.Staging
+	if m.state.Mode != model.ModeStaging || len(st.Steps) != 2 || st.Steps[0].Action != model.StageSync {
+		t.Fatalf("expected both apps staged for a sync, got mode %s %+v", m.state.Mode, st)
+	}
+
+	// Refresh zzz-other-app first, then sync test-app
+	for _, k := range []string{"j", "r", "K"} {
+		m.handleKeyMsg(keyPress(k))
+	}
+	if st.Steps[0].Name != "zzz-other-app" || st.Steps[0].Action != model.StageRefresh || st.SelectedIdx != 0 {
+		t.Fatalf("expected the refresh moved to the top, got %+v", st.Steps)
+	}
+	out := stripANSI(m.renderStagingModal())
+	for _, want := range []string{"refresh  zzz-other-app", "sync     test-app", "J/K move"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the train should show %q:\n%s", want, out)
+		}
+	}
+
+	_, cmd := m.handleKeyMsg(keyPress("enter"))
+	cmd = m.handleStagingStep(cmd().(stagingStepMsg))
+	if st.Steps[0].Status != model.StepDone || st.Steps[1].Status != model.StepRunning {
+		t.Fatalf("a refresh should be done once requested, got %+v", st.Steps)
+	}
+	// The sync is accepted; the step waits for the app's next operation
+	cmd = m.handleStagingStep(cmd().(stagingStepMsg))
+	if cmd == nil || st.Next != 1 {
+		t.Fatalf("the sync should wait for its operation, next %d", st.Next)
+	}
+	m.handleKeyMsg(keyPress("x"))
+	if st.Steps[1].Action != model.StageSync {
+		t.Error("a running train should not be edited")
+	}
+
+	now := time.Now()
+	m.state.Apps[0].OperationPhase = "Succeeded"
+	m.state.Apps[0].OperationStartedAt = &now
+	cmd = m.handleStagingTick(stagingTickMsg{id: st.ID, step: 1, at: now})
+	if st.Running || st.Steps[1].Status != model.StepDone {
+		t.Fatalf("the train should finish with the sync, got %+v", st)
+	}
+	if status := cmd().(model.StatusChangeMsg).Status; status != "Release train done: 2 step(s) run, 0 skipped" {
+		t.Errorf("unexpected summary %q", status)
+	}
+
+	want := []string{"GET /api/v1/applications/zzz-other-app?true", "POST /api/v1/applications/test-app/sync?"}
+	if got := strings.Join(*requests, ","); got != strings.Join(want, ",") {
+		t.Errorf("expected the steps' requests in order, got %s", got)
+	}
+}
+
+func TestStaging_StopsAtAFailedStepAndResumes(t *testing.T) {
+	m, _ := stagingTestModel(t)
+	st := m.state.Modals.Staging
+	m.handleKeyMsg(keyPress("b"))
+	m.handleKeyMsg(keyPress("j"))
+	m.handleKeyMsg(keyPress("x"))
+
+	// test-app has no earlier deployment to roll back to
+	_, cmd := m.handleKeyMsg(keyPress("enter"))
+	m.handleStagingStep(cmd().(stagingStepMsg))
+	if st.Running || st.Next != 0 || st.Steps[0].Status != model.StepFailed || !strings.Contains(st.Steps[0].Message, "no earlier deployment") {
+		t.Fatalf("the train should stop at the failed rollback, got %+v", st)
+	}
+	if len(m.state.RecentErrors) == 0 || m.state.RecentErrors[0].Source != "staging" {
+		t.Errorf("the failure should be recorded in :errors, got %+v", m.state.RecentErrors)
+	}
+	if out := stripANSI(m.renderStagingModal()); !strings.Contains(out, "✗ no earlier deployment") || !strings.Contains(out, "Enter to continue") {
+		t.Errorf("the failed step should be shown with how to continue:\n%s", out)
+	}
+
+	// Skipping the failed step and continuing runs the rest
+	m.handleKeyMsg(keyPress("k"))
+	m.handleKeyMsg(keyPress("x"))
+	_, cmd = m.handleKeyMsg(keyPress("enter"))
+	if st.Running || st.Steps[0].Status != model.StepSkipped || st.Steps[1].Status != model.StepSkipped {
+		t.Fatalf("both steps should end skipped, got %+v", st.Steps)
+	}
+	if status := cmd().(model.StatusChangeMsg).Status; status != "Release train done: 0 step(s) run, 2 skipped" {
+		t.Errorf("unexpected summary %q", status)
+	}
+	m.handleKeyMsg(keyPress("enter"))
+	if m.state.Mode != model.ModeNormal || m.state.Modals.Staging != nil {
+		t.Error("enter should close a finished train")
+	}
+}
+
+func TestStaging_FollowsTheOperationOfASyncOrRollback(t *testing.T) {
+	m, _ := stagingTestModel(t)
+	st := m.state.Modals.Staging
+	before := time.Now().Add(-time.Hour)
+	m.state.Apps[0].OperationPhase = "Succeeded"
+	m.state.Apps[0].OperationStartedAt = &before
+
+	_, cmd := m.handleKeyMsg(keyPress("enter"))
+	m.handleStagingStep(cmd().(stagingStepMsg))
+
+	// The operation from before the step does not count
+	if cmd := m.handleStagingTick(stagingTickMsg{id: st.ID, step: 0, at: time.Now()}); cmd == nil || st.Next != 0 {
+		t.Fatal("an earlier operation should keep the step waiting")
+	}
+	now := time.Now()
+	m.state.Apps[0].OperationPhase = "Failed"
+	m.state.Apps[0].OperationMessage = "one or more objects failed to apply"
+	m.state.Apps[0].OperationStartedAt = &now
+	m.handleStagingTick(stagingTickMsg{id: st.ID, step: 0, at: time.Now()})
+	if st.Running || st.Steps[0].Message != "Failed: one or more objects failed to apply" {
+		t.Fatalf("a failed sync should stop the train, got %+v", st.Steps[0])
+	}
+
+	// A step whose operation never finishes times out; resumed past the
+	// cooldown of the first sync
+	for k, at := range m.requestedOps {
+		m.requestedOps[k] = at.Add(-operationCooldown)
+	}
+	_, cmd = m.handleKeyMsg(keyPress("enter"))
+	m.handleStagingStep(cmd().(stagingStepMsg))
+	m.handleStagingTick(stagingTickMsg{id: st.ID, step: 0, at: st.Steps[0].Deadline.Add(time.Second)})
+	if st.Running || !strings.Contains(st.Steps[0].Message, "did not finish within") {
+		t.Fatalf("the step should time out, got %+v", st.Steps[0])
+	}
+}
+
+func TestStaging_SyncsGoThroughTheCooldownAndQuitGuard(t *testing.T) {
+	m, _ := stagingTestModel(t)
+	st := m.state.Modals.Staging
+	st.Steps[1].Action = model.StageSkip
+
+	_, cmd := m.handleKeyMsg(keyPress("enter"))
+	m.handleStagingStep(cmd().(stagingStepMsg))
+	if ops := m.operationsInFlight(); len(ops) != 1 || ops[0].AppName != "test-app" {
+		t.Fatalf("a staged sync should keep quitting from dropping it, got %+v", ops)
+	}
+
+	// The sync was just requested: staging it again is refused like a manual one
+	m.state.Modals.Staging = nil
+	m.state.Mode = model.ModeNormal
+	m.handleStageCommand()
+	st = m.state.Modals.Staging
+	st.Steps[1].Action = model.StageSkip
+	_, cmd = m.handleKeyMsg(keyPress("enter"))
+	if _, sent := cmd().(stagingStepMsg); sent || st.Running || !strings.Contains(st.Steps[0].Message, "already requested") {
+		t.Fatalf("a repeated sync should be refused, got %+v", st.Steps[0])
+	}
+}
+
+func TestStaging_StepDeadlineIsOnTheWallClockInDeterministicMode(t *testing.T) {
+	withDeterministicMode(t)
+	m, _ := stagingTestModel(t)
+	st := m.state.Modals.Staging
+
+	_, cmd := m.handleKeyMsg(keyPress("enter"))
+	m.handleStagingStep(cmd().(stagingStepMsg))
+	if cmd := m.handleStagingTick(stagingTickMsg{id: st.ID, step: 0, at: time.Now()}); cmd == nil || !st.Running {
+		t.Fatalf("a step should not time out on its first tick, got %+v", st.Steps[0])
+	}
+}
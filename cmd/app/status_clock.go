@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+)
+
+// statusSlowLatency is the average API latency from which the status line
+// shows it as slow
+const statusSlowLatency = time.Second
+
+// statusClockTickMsg redraws the status line clock when the minute changes
+type statusClockTickMsg struct{}
+
+// statusClockEnabled reports whether the status line shows the clock and
+// API latency
+func (m *Model) statusClockEnabled() bool {
+	return m.config != nil && m.config.Appearance.StatusClock
+}
+
+// statusClockTick schedules a redraw at the start of the next minute. Returns
+// nil when the clock is off or the clock is frozen for end-to-end tests.
+func (m *Model) statusClockTick() tea.Cmd {
+	if !m.statusClockEnabled() || deterministicMode {
+		return nil
+	}
+	now := time.Now()
+	return tea.Tick(now.Truncate(time.Minute).Add(time.Minute).Sub(now), func(time.Time) tea.Msg {
+		return statusClockTickMsg{}
+	})
+}
+
+// renderStatusClock renders the local time and the average latency of the
+// recent API requests, or "" when the segment is off
+func (m *Model) renderStatusClock() string {
+	if !m.statusClockEnabled() {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	latency := dim.Render("api –")
+	if avg, ok := api.AverageLatency(); ok {
+		text := "api " + formatLatency(avg)
+		if avg >= statusSlowLatency {
+			latency = lipgloss.NewStyle().Foreground(outOfSyncColor).Render(text)
+		} else {
+			latency = dim.Render(text)
+		}
+	}
+	return timefmt.Clock(clockNow()) + " " + latency
+}
+
+// formatLatency renders a latency in milliseconds, or seconds from 1s up
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// statusFilterKeys are the apps view quick filter keys
+var statusFilterKeys = map[string]model.StatusFilter{
+	"1": model.StatusFilterAll,
+	"2": model.StatusFilterOutOfSync,
+	"3": model.StatusFilterDegraded,
+	"4": model.StatusFilterProgressing,
+}
+
+// handleStatusFilterKey applies a quick filter; pressing the key of the
+// active filter again shows all apps
+func (m *Model) handleStatusFilterKey(key string) (tea.Model, tea.Cmd) {
+	filter := statusFilterKeys[key]
+	if filter == m.state.UI.StatusFilter {
+		filter = model.StatusFilterAll
+	}
+	if filter == m.state.UI.StatusFilter {
+		return m, nil
+	}
+	m.state.UI.StatusFilter = filter
+	m.state.Navigation.SelectedIdx = 0
+	return m, nil
+}
+
+// filterAppsByStatus drops the apps the quick filter hides
+func (m *Model) filterAppsByStatus(apps []model.App) []model.App {
+	f := m.state.UI.StatusFilter
+	if f == model.StatusFilterAll {
+		return apps
+	}
+	kept := make([]model.App, 0, len(apps))
+	for _, app := range apps {
+		if f.Matches(app) {
+			kept = append(kept, app)
+		}
+	}
+	return kept
+}
+
+// statusFilterTag names the active quick filter for the status line, e.g.
+// " [OutOfSync]", or "" when all apps are shown
+func (m *Model) statusFilterTag() string {
+	if m.state.UI.StatusFilter == model.StatusFilterAll {
+		return ""
+	}
+	return " [" + string(m.state.UI.StatusFilter) + "]"
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestStatusFilterKeys_ToggleQuickFilters(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps = append(m.state.Apps, model.App{Name: "rolling", Sync: "Synced", Health: "Progressing"})
+	visibleNames := func() []string {
+		var names []string
+		for _, it := range m.getVisibleItems() {
+			names = append(names, it.(model.App).Name)
+		}
+		return names
+	}
+
+	m.handleKeyMsg(keyPress("2"))
+	if got := visibleNames(); len(got) != 1 || got[0] != "zzz-other-app" {
+		t.Fatalf("2 should show only OutOfSync apps, got %v", got)
+	}
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, "<apps [OutOfSync]>") {
+		t.Errorf("the status line should name the filter, got %q", status)
+	}
+
+	m.handleKeyMsg(keyPress("4"))
+	if got := visibleNames(); len(got) != 1 || got[0] != "rolling" {
+		t.Fatalf("4 should show only Progressing apps, got %v", got)
+	}
+	m.state.UI.ActiveFilter = "roll"
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, "<apps [Progressing]:roll>") {
+		t.Errorf("the quick filter combines with the text filter, got %q", status)
+	}
+	m.state.UI.ActiveFilter = ""
+
+	m.handleKeyMsg(keyPress("4"))
+	if got := visibleNames(); len(got) != 3 {
+		t.Errorf("pressing the active filter's key again should show all apps, got %v", got)
+	}
+
+	m.handleKeyMsg(keyPress("3"))
+	m.handleKeyMsg(keyPress("esc"))
+	if m.state.UI.StatusFilter != model.StatusFilterAll || m.state.Navigation.View != model.ViewApps {
+		t.Errorf("esc should clear the quick filter first, got %q in %s", m.state.UI.StatusFilter, m.state.Navigation.View)
+	}
+}
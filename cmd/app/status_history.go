@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// historyMaxVisible is how many apps the :history view lists before scrolling
+const historyMaxVisible = 12
+
+// recordStatusHistory notes the app list's statuses for the :history view
+func (m *Model) recordStatusHistory() {
+	m.state.History.Record(clockNow(), m.state.Apps)
+}
+
+// handleOpenHistory shows the live app statuses with a timeline of the
+// changes seen this session to step back through
+func (m *Model) handleOpenHistory() (tea.Model, tea.Cmd) {
+	m.state.Modals.History = &model.HistoryState{}
+	m.state.Mode = model.ModeHistory
+	return m, nil
+}
+
+// historyTime is the moment the :history view shows
+func (m *Model) historyTime() time.Time {
+	if st := m.state.Modals.History; st != nil && st.At != nil {
+		return *st.At
+	}
+	return clockNow()
+}
+
+// historyMoments lists the moments something changed, oldest first,
+// starting with the earliest one the history can show
+func (m *Model) historyMoments() []time.Time {
+	moments := []time.Time{m.state.History.Since()}
+	for _, c := range m.state.History.Changes() {
+		if !c.At.Equal(moments[len(moments)-1]) {
+			moments = append(moments, c.At)
+		}
+	}
+	return moments
+}
+
+// setHistoryTime moves the view to a moment, clamped to the history; at
+// or after the present it follows the live state again
+func (m *Model) setHistoryTime(t time.Time) {
+	st := m.state.Modals.History
+	st.Copied = false
+	if !t.Before(clockNow()) {
+		st.At = nil
+		return
+	}
+	if since := m.state.History.Since(); t.Before(since) {
+		t = since
+	}
+	st.At = &t
+}
+
+// historyApps returns the apps the view lists at its moment: those out of
+// sync or unhealthy, or every app when ShowAll is on
+func (m *Model) historyApps() []model.AppStatusAt {
+	all := m.state.History.At(m.historyTime())
+	if m.state.Modals.History.ShowAll {
+		return all
+	}
+	var apps []model.AppStatusAt
+	for _, s := range all {
+		if s.Sync != "Synced" || s.Health != "Healthy" {
+			apps = append(apps, s)
+		}
+	}
+	return apps
+}
+
+// handleHistoryKeys handles input in the :history view
+func (m *Model) handleHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.History
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	if m.state.History.Since().IsZero() {
+		if k := msg.String(); k == "q" || k == "esc" {
+			m.state.Mode = model.ModeNormal
+			m.state.Modals.History = nil
+		}
+		return m, nil
+	}
+
+	cur := m.historyTime()
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.History = nil
+	case "left", "h":
+		moments := m.historyMoments()
+		for i := len(moments) - 1; i >= 0; i-- {
+			if moments[i].Before(cur) || (st.At == nil && moments[i].Equal(cur)) {
+				m.setHistoryTime(moments[i])
+				break
+			}
+		}
+	case "right", "l":
+		if st.At == nil {
+			break
+		}
+		st.At, st.Copied = nil, false
+		for _, t := range m.historyMoments() {
+			if t.After(cur) {
+				m.setHistoryTime(t)
+				break
+			}
+		}
+	case "[":
+		m.setHistoryTime(cur.Add(-time.Minute))
+	case "]":
+		if st.At != nil {
+			m.setHistoryTime(cur.Add(time.Minute))
+		}
+	case "g", "home":
+		m.setHistoryTime(m.state.History.Since())
+	case "G", "end":
+		st.At, st.Copied = nil, false
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < len(m.historyApps())-1 {
+			st.SelectedIdx++
+		}
+	case "a":
+		st.ShowAll = !st.ShowAll
+		st.SelectedIdx = 0
+	case "y":
+		apps := m.historyApps()
+		if len(apps) == 0 {
+			return m, nil
+		}
+		lines := make([]string, 0, len(apps))
+		for _, s := range apps {
+			lines = append(lines, fmt.Sprintf("%s %s %s %s", timefmt.TimeOfDay(cur), s.Name, s.Sync, s.Health))
+		}
+		st.Copied = true
+		return m, clipboard.CopyCmd(strings.Join(lines, "\n"))
+	}
+	return m, nil
+}
+
+// statusChangeText summarizes a change, e.g. "sync Synced→OutOfSync"
+func statusChangeText(c model.StatusChange) string {
+	switch {
+	case c.Added:
+		return "added"
+	case c.Removed:
+		return "removed"
+	}
+	var changes []string
+	field := func(name, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s %s→%s", name, orDash(from), orDash(to)))
+		}
+	}
+	field("sync", c.Prev.Sync, c.Status.Sync)
+	field("health", c.Prev.Health, c.Status.Health)
+	field("operation", c.Prev.Operation, c.Status.Operation)
+	return strings.Join(changes, ", ")
+}
+
+// renderHistoryScrubber draws the history as a line from its start to now
+// with a dot where something changed and a marker at the moment shown
+func (m *Model) renderHistoryScrubber(width int) string {
+	since, now := m.state.History.Since(), clockNow()
+	left, right := timefmt.Clock(since)+" ", " now"
+	barWidth := max(1, width-lipgloss.Width(left)-lipgloss.Width(right))
+	span := now.Sub(since)
+	col := func(t time.Time) int {
+		if span <= 0 {
+			return barWidth - 1
+		}
+		return min(barWidth-1, max(0, int(int64(barWidth-1)*int64(t.Sub(since))/int64(span))))
+	}
+
+	bar := []rune(strings.Repeat("─", barWidth))
+	for _, c := range m.state.History.Changes() {
+		bar[col(c.At)] = '·'
+	}
+	marker := col(m.historyTime())
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	return dim.Render(left+string(bar[:marker])) +
+		lipgloss.NewStyle().Foreground(cyanBright).Bold(true).Render("▲") +
+		dim.Render(string(bar[marker+1:])+right)
+}
+
+// renderHistoryModal renders the :history view: the timeline, what changed
+// at the moment shown and the apps out of sync or unhealthy at that moment
+func (m *Model) renderHistoryModal() string {
+	st := m.state.Modals.History
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Status history")
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	if m.state.History.Since().IsZero() {
+		lines := []string{title, "", "No app list loaded yet", "", dim.Render("Esc to close")}
+		return modalStyle.Render(strings.Join(lines, "\n"))
+	}
+
+	at := m.historyTime()
+	when := "now"
+	if st.At != nil {
+		when = timefmt.TimeOfDay(at)
+	}
+	lines := []string{
+		title + " " + lipgloss.NewStyle().Foreground(cyanBright).Render(when) + " " +
+			dim.Render(fmt.Sprintf("%d changes in the last %d minutes", len(m.state.History.Changes()), int(model.StatusHistoryWindow/time.Minute))),
+		"",
+		m.renderHistoryScrubber(innerWidth),
+	}
+
+	// What changed at exactly this moment, when stepping through changes
+	var here []string
+	if st.At != nil {
+		for _, c := range m.state.History.Changes() {
+			if c.At.Equal(at) {
+				here = append(here, c.Status.Name+" "+statusChangeText(c))
+			}
+		}
+	}
+	if len(here) > 0 {
+		lines = append(lines, truncateWithEllipsis(strings.Join(here, "; "), innerWidth))
+	} else {
+		lines = append(lines, "")
+	}
+	lines = append(lines, "")
+
+	apps := m.historyApps()
+	st.SelectedIdx = min(st.SelectedIdx, max(0, len(apps)-1))
+	if len(apps) == 0 {
+		if st.ShowAll {
+			lines = append(lines, "No apps")
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Foreground(syncedColor).Render("Every app synced and healthy"))
+		}
+	} else {
+		nameWidth, syncWidth, healthWidth, opWidth := len("APP"), len("SYNC"), len("HEALTH"), len("OPERATION")
+		for _, s := range apps {
+			nameWidth = max(nameWidth, len(s.Name))
+			syncWidth = max(syncWidth, len(s.Sync))
+			healthWidth = max(healthWidth, len(s.Health))
+			opWidth = max(opWidth, len(orDash(s.Operation)))
+		}
+		nameWidth = min(nameWidth, innerWidth/3)
+		row := func(name, sync, health, op, since string) string {
+			return fmt.Sprintf("%-*s %-*s %-*s %-*s %s", nameWidth, truncateWithEllipsis(name, nameWidth),
+				syncWidth, sync, healthWidth, health, opWidth, op, since)
+		}
+		lines = append(lines, headerStyle.Render("  "+row("APP", "SYNC", "HEALTH", "OPERATION", "SINCE")))
+
+		startIdx := 0
+		if st.SelectedIdx >= historyMaxVisible {
+			startIdx = st.SelectedIdx - historyMaxVisible + 1
+		}
+		endIdx := min(len(apps), startIdx+historyMaxVisible)
+		if startIdx > 0 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+		}
+		for i := startIdx; i < endIdx; i++ {
+			s := apps[i]
+			since := "-"
+			if !s.Since.IsZero() {
+				since = timefmt.TimeOfDay(s.Since)
+			}
+			text := row(s.Name, s.Sync, s.Health, orDash(s.Operation), since)
+			switch {
+			case i == st.SelectedIdx:
+				lines = append(lines, lipgloss.NewStyle().
+					Background(cyanBright).
+					Foreground(textOnAccent).
+					Render("► "+text))
+			case st.At != nil && s.Since.Equal(at):
+				lines = append(lines, "  "+lipgloss.NewStyle().Foreground(yellowBright).Render(text))
+			default:
+				lines = append(lines, "  "+text)
+			}
+		}
+		if endIdx < len(apps) {
+			lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+		}
+	}
+
+	scope := "out of sync or unhealthy (a shows all)"
+	if st.ShowAll {
+		scope = "(a shows only those out of sync or unhealthy)"
+	}
+	lines = append(lines, "", dim.Render(fmt.Sprintf("%d apps %s", len(apps), scope)))
+	help := "←/→ change • [/] minute • g oldest • G now • y copy • Esc to close"
+	if st.Copied {
+		help = "Copied • " + help
+	}
+	lines = append(lines, dim.Render(truncateWithEllipsis(help, innerWidth)))
+
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// buildHistoryTestModel records test-app going OutOfSync five minutes ago
+// and zzz-other-app recovering a minute ago
+func buildHistoryTestModel() *Model {
+	m := buildDeleteTestModel(120, 40)
+	now := clockNow()
+	m.state.History.Record(now.Add(-10*time.Minute), m.state.Apps)
+	m.state.Apps[0].Sync = "OutOfSync"
+	m.state.History.Record(now.Add(-5*time.Minute), m.state.Apps)
+	m.state.Apps[1].Sync, m.state.Apps[1].Health = "Synced", "Healthy"
+	m.state.History.Record(now.Add(-time.Minute), m.state.Apps)
+	m.handleOpenHistory()
+	return m
+}
+
+func historyListed(m *Model) []string {
+	var names []string
+	for _, s := range m.historyApps() {
+		names = append(names, s.Name+" "+s.Sync+" "+s.Health)
+	}
+	return names
+}
+
+func TestHistory_StepsThroughChanges(t *testing.T) {
+	m := buildHistoryTestModel()
+	if got := strings.Join(historyListed(m), ", "); got != "test-app OutOfSync Healthy" {
+		t.Fatalf("live view should list only test-app, got %q", got)
+	}
+
+	m.Update(keyPress("left"))
+	out := stripANSI(m.renderHistoryModal())
+	if !strings.Contains(out, "zzz-other-app sync OutOfSync→Synced, health Degraded→Healthy") {
+		t.Fatalf("the latest change should be described:\n%s", out)
+	}
+
+	m.Update(keyPress("left"))
+	if got := strings.Join(historyListed(m), ", "); got != "test-app OutOfSync Healthy, zzz-other-app OutOfSync Degraded" {
+		t.Fatalf("five minutes ago both apps were out of sync, got %q", got)
+	}
+
+	m.Update(keyPress("g"))
+	if got := strings.Join(historyListed(m), ", "); got != "zzz-other-app OutOfSync Degraded" {
+		t.Fatalf("at the start only zzz-other-app was out of sync, got %q", got)
+	}
+	m.Update(keyPress("a"))
+	if len(m.historyApps()) != 2 {
+		t.Fatalf("a should list every app, got %v", historyListed(m))
+	}
+
+	m.Update(keyPress("right"))
+	m.Update(keyPress("right"))
+	m.Update(keyPress("right"))
+	if m.state.Modals.History.At != nil {
+		t.Fatalf("stepping past the last change should follow the live state, at %v", m.state.Modals.History.At)
+	}
+}
+
+func TestHistory_StepsByMinute(t *testing.T) {
+	m := buildHistoryTestModel()
+	for range 3 {
+		m.Update(keyPress("["))
+	}
+	// Three minutes back zzz-other-app had not recovered yet
+	if got := len(m.historyApps()); got != 2 {
+		t.Fatalf("three minutes back both apps should be listed, got %v", historyListed(m))
+	}
+	for range 20 {
+		m.Update(keyPress("["))
+	}
+	if at := m.state.Modals.History.At; at == nil || !at.Equal(m.state.History.Since()) {
+		t.Fatalf("stepping back should stop at the start of the history, at %v", at)
+	}
+	m.Update(keyPress("G"))
+	if m.state.Modals.History.At != nil {
+		t.Fatal("G should return to the live state")
+	}
+}
+
+func TestHistory_RecordsWatchUpdates(t *testing.T) {
+	m := buildDeleteTestModel(120, 40)
+	m.Update(model.AppsLoadedMsg{Apps: m.state.Apps, SwitchEpoch: m.switchEpoch})
+	app := m.state.Apps[0]
+	app.Health = "Degraded"
+	m.Update(model.AppsBatchUpdateMsg{Updates: []model.AppUpdatedMsg{{App: app}}, SwitchEpoch: m.switchEpoch})
+
+	changes := m.state.History.Changes()
+	if len(changes) != 1 || changes[0].Status.Name != "test-app" || statusChangeText(changes[0]) != "health Healthy→Degraded" {
+		t.Fatalf("the watch update should be recorded, got %+v", changes)
+	}
+}
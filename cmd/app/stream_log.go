@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// streamMaxVisible is how many events the :stream view lists before scrolling
+const streamMaxVisible = 10
+
+// streamRawMaxLines is how much of the selected event's JSON is shown
+const streamRawMaxLines = 12
+
+// recordStreamDelta notes what applying a watch stream event changed in the
+// app list, for the :stream view. Events not captured (seq 0) are skipped.
+func (m *Model) recordStreamDelta(seq uint64, change string) {
+	if seq == 0 {
+		return
+	}
+	if m.streamDeltas == nil {
+		m.streamDeltas = make(map[uint64]string)
+	}
+	m.streamDeltas[seq] = change
+	if len(m.streamDeltas) > api.StreamLogSize {
+		for k := range m.streamDeltas {
+			if k+api.StreamLogSize <= seq {
+				delete(m.streamDeltas, k)
+			}
+		}
+	}
+}
+
+// appDelta summarizes how an app changed, e.g. "sync OutOfSync→Synced"
+func appDelta(prev, next model.App) string {
+	var changes []string
+	field := func(name, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s %s→%s", name, orDash(from), orDash(to)))
+		}
+	}
+	field("sync", prev.Sync, next.Sync)
+	field("health", prev.Health, next.Health)
+	field("operation", prev.OperationPhase, next.OperationPhase)
+	field("revision", sourceRevisions(prev), sourceRevisions(next))
+	field("project", derefOr(prev.Project), derefOr(next.Project))
+	field("namespace", derefOr(prev.Namespace), derefOr(next.Namespace))
+	if len(changes) == 0 {
+		return "no change"
+	}
+	return strings.Join(changes, ", ")
+}
+
+// sourceRevisions lists the synced revision of every source of the app
+func sourceRevisions(app model.App) string {
+	revs := make([]string, 0, len(app.Sources))
+	for _, src := range app.Sources {
+		if src.Revision != "" {
+			revs = append(revs, shortRevision(src.Revision))
+		}
+	}
+	return strings.Join(revs, ",")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// handleOpenStream shows the watch stream events received since the view
+// was first opened, newest first. Events are only captured from then on.
+func (m *Model) handleOpenStream() (tea.Model, tea.Cmd) {
+	api.SetStreamCapture(true)
+	m.state.Modals.Stream = &model.StreamLogState{}
+	m.state.Mode = model.ModeStream
+	return m, nil
+}
+
+// handleStreamKeys handles input in the :stream view
+func (m *Model) handleStreamKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.Stream
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+
+	rows := len(api.StreamEvents())
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Mode = model.ModeNormal
+		m.state.Modals.Stream = nil
+		return m, nil
+	case "up", "k":
+		if st.SelectedIdx > 0 {
+			st.SelectedIdx--
+		}
+	case "down", "j":
+		if st.SelectedIdx < rows-1 {
+			st.SelectedIdx++
+		}
+	case "g", "home":
+		st.SelectedIdx = 0
+	case "G", "end":
+		st.SelectedIdx = max(0, rows-1)
+	case "c":
+		api.ClearStreamEvents()
+		m.streamDeltas = nil
+		st.SelectedIdx = 0
+	}
+	return m, nil
+}
+
+// renderStreamModal renders the :stream view: watch events newest first
+// with what each changed, and the redacted JSON of the selected one
+func (m *Model) renderStreamModal() string {
+	st := m.state.Modals.Stream
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 2, 3)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+
+	events := api.StreamEvents()
+	n := len(events)
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Watch stream")
+	lines := []string{title + " " + dim.Render(fmt.Sprintf("%d of the last %d events, newest first", n, api.StreamLogSize)), ""}
+	if n == 0 {
+		lines = append(lines, "No events since the view was first opened")
+	}
+	st.SelectedIdx = min(st.SelectedIdx, max(0, n-1))
+
+	atWidth, typeWidth, appWidth := 0, 0, 0
+	for _, e := range events {
+		atWidth = max(atWidth, len(timefmt.TimeOfDay(e.At)))
+		typeWidth = max(typeWidth, len(e.Type))
+		appWidth = max(appWidth, len(e.App))
+	}
+	appWidth = min(appWidth, innerWidth/3)
+
+	startIdx := 0
+	if st.SelectedIdx >= streamMaxVisible {
+		startIdx = st.SelectedIdx - streamMaxVisible + 1
+	}
+	endIdx := min(n, startIdx+streamMaxVisible)
+	if startIdx > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▲ more above"))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		e := events[n-1-i]
+		at := fmt.Sprintf("%-*s", atWidth, timefmt.TimeOfDay(e.At)) // relative times vary in width
+		app := fmt.Sprintf("%-*s", appWidth, truncateWithEllipsis(e.App, appWidth))
+		delta, applied := m.streamDeltas[e.Seq]
+		if !applied {
+			delta = "not applied"
+		}
+		delta = truncateWithEllipsis(delta, max(1, innerWidth-len(at)-typeWidth-appWidth-6))
+		if i == st.SelectedIdx {
+			text := fmt.Sprintf("► %s %-*s %s %s", at, typeWidth, e.Type, app, delta)
+			lines = append(lines, lipgloss.NewStyle().
+				Background(cyanBright).
+				Foreground(textOnAccent).
+				Padding(0, 1).
+				Render(text))
+			continue
+		}
+		if !applied {
+			delta = dim.Render(delta)
+		}
+		lines = append(lines, fmt.Sprintf("  %s %-*s %s %s", dim.Render(at), typeWidth, e.Type, app, delta))
+	}
+	if endIdx < n {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cyanBright).Render("  ▼ more below"))
+	}
+
+	help := "Esc to close"
+	if n > 0 {
+		raw := events[n-1-st.SelectedIdx].Raw
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, raw, "", "  ") != nil {
+			pretty.Reset()
+			pretty.Write(raw)
+		}
+		rawLines := strings.Split(pretty.String(), "\n")
+		if len(rawLines) > streamRawMaxLines {
+			more := len(rawLines) - streamRawMaxLines
+			rawLines = append(rawLines[:streamRawMaxLines], fmt.Sprintf("… %d more lines", more))
+		}
+		lines = append(lines, "")
+		for _, l := range rawLines {
+			lines = append(lines, dim.Render(truncateWithEllipsis(l, innerWidth)))
+		}
+		help = "c clear • Esc to close"
+	}
+	lines = append(lines, "", dim.Render(help))
+
+	content := strings.Join(lines, "\n")
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+
+	return modalStyle.Render(content)
+}
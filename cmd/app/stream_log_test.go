@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// captureWatchEvents turns the stream log on and feeds it the given watch
+// events through a real application stream, returning the sequence numbers
+// the events were logged under
+func captureWatchEvents(t *testing.T, events ...string) []uint64 {
+	t.Helper()
+	api.ClearStreamEvents()
+	api.SetStreamCapture(true)
+	t.Cleanup(func() {
+		api.SetStreamCapture(false)
+		api.ClearStreamEvents()
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprintf(w, "data: %s\n\n", e)
+		}
+	}))
+	defer server.Close()
+
+	ch := make(chan api.ApplicationWatchEvent, len(events))
+	svc := api.NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	if err := svc.WatchApplications(context.Background(), ch); err != nil {
+		t.Fatalf("WatchApplications: %v", err)
+	}
+	close(ch)
+	var seqs []uint64
+	for e := range ch {
+		seqs = append(seqs, e.StreamSeq)
+	}
+	return seqs
+}
+
+func TestStreamView_ShowsEventsWithTheirModelDeltas(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(140, 40)
+	seqs := captureWatchEvents(t,
+		`{"result":{"type":"MODIFIED","application":{"metadata":{"name":"test-app","namespace":"test-namespace"},"status":{"sync":{"status":"OutOfSync"}}}}}`,
+		`{"result":{"type":"DELETED","application":{"metadata":{"name":"gone-app"}}}}`,
+		`{"result":{"type":"MODIFIED","application":{"metadata":{"name":"late-app"}}}}`,
+	)
+
+	updated := m.state.Apps[0]
+	updated.Sync = "OutOfSync"
+	m.Update(model.AppsBatchUpdateMsg{Operations: []model.AppBatchOperation{
+		{Type: model.AppBatchOperationUpdate, Update: &model.AppUpdatedMsg{App: updated, StreamSeq: seqs[0]}},
+		{Type: model.AppBatchOperationDelete, Delete: "gone-app", DeleteStreamSeq: seqs[1]},
+	}})
+
+	m.handleOpenStream()
+	if m.state.Mode != model.ModeStream {
+		t.Fatalf("expected the stream view, got mode %s", m.state.Mode)
+	}
+	view := stripANSI(m.renderStreamModal())
+	for _, want := range []string{
+		"MODIFIED test-namespace/test-app sync Synced→OutOfSync",
+		"DELETED  gone-app",
+		"unknown app, ignored",
+		"late-app",
+		"not applied",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the stream view:\n%s", want, view)
+		}
+	}
+	// The newest event is selected and its JSON shown
+	if !strings.Contains(view, `"name": "late-app"`) {
+		t.Errorf("expected the selected event's JSON:\n%s", view)
+	}
+
+	m.handleStreamKeys(keyPress("c"))
+	if len(api.StreamEvents()) != 0 || len(m.streamDeltas) != 0 {
+		t.Error("c should clear the recorded events and their deltas")
+	}
+}
+
+func TestAppDelta(t *testing.T) {
+	prev := model.App{Name: "a", Sync: "Synced", Health: "Healthy"}
+	if got := appDelta(prev, prev); got != "no change" {
+		t.Errorf("expected no change, got %q", got)
+	}
+	next := prev
+	next.Health = "Degraded"
+	next.OperationPhase = "Running"
+	if got := appDelta(prev, next); got != "health Healthy→Degraded, operation -→Running" {
+		t.Errorf("unexpected delta %q", got)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// maxBundleLogBytes is how much of the end of the log a support bundle keeps
+const maxBundleLogBytes = 4 << 20
+
+// supportBundleWrittenMsg reports the outcome of :support-bundle
+type supportBundleWrittenMsg struct {
+	path string
+	err  error
+}
+
+// supportBundle is what :support-bundle collects, captured from the model
+// when the command runs; the files are read when the bundle is written
+type supportBundle struct {
+	created     time.Time
+	version     string
+	contextName string
+	server      string
+	apiVersion  string
+	compat      string
+	cols, rows  int
+	view        model.View
+	mode        model.Mode
+	apps        int
+	streaming   bool
+	errors      []model.RecentError
+	requests    []api.RequestTrace
+	logPath     string
+	// secrets are configured values redacted wherever they appear: the
+	// notification webhook URL and the values of the secrets file
+	secrets []string
+}
+
+// logSecretPattern matches bearer tokens and key=value pairs named like a
+// password, token or secret in log lines
+var logSecretPattern = regexp.MustCompile(`(?i)(bearer\s+|\w*(?:password|token|secret)\w*=)("[^"]*"|[^\s"]+)`)
+
+// logURLPattern matches URLs with a user or a path; webhooks carry their
+// token in the path, so only the scheme and host are kept
+var logURLPattern = regexp.MustCompile(`(?i)(https?://)(?:[^\s/@"'<>]*@)?([^\s/"'<>?#]+)[/?#][^\s"'<>]*`)
+
+// redactLog replaces what looks like a credential in log output: the given
+// secret values, URL paths, bearer tokens and password-like key=value pairs
+func redactLog(data []byte, secrets ...string) []byte {
+	for _, secret := range secrets {
+		if secret != "" {
+			data = bytes.ReplaceAll(data, []byte(secret), []byte("<redacted>"))
+		}
+	}
+	data = logURLPattern.ReplaceAll(data, []byte("${1}${2}/<redacted>"))
+	return logSecretPattern.ReplaceAll(data, []byte("${1}<redacted>"))
+}
+
+// bundleSecrets returns the configured values to redact, longest first so
+// a secret that contains another is replaced whole
+func bundleSecrets(cfg *config.ArgonautConfig) []string {
+	secrets := []string{cfg.GetNotificationWebhookURL(), cfg.Notifications.WebhookURL}
+	for _, name := range cfg.SecretNames() {
+		secrets = append(secrets, cfg.ExpandSecrets("${secret:"+name+"}"))
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+// handleSupportBundleCommand runs ":support-bundle [file]": writes the log,
+// the config with secrets redacted, the recent errors and API requests and a
+// summary of the session to a tar.gz to attach to a bug report
+func (m *Model) handleSupportBundleCommand(path string) (tea.Model, tea.Cmd) {
+	b := supportBundle{
+		created:     clockNow(),
+		version:     appVersion,
+		contextName: m.currentContextName,
+		apiVersion:  m.state.APIVersion,
+		cols:        m.state.Terminal.Cols,
+		rows:        m.state.Terminal.Rows,
+		view:        m.state.Navigation.View,
+		mode:        m.state.Mode,
+		apps:        len(m.state.Apps),
+		streaming:   m.config.IsStreamEnabled(),
+		errors:      append([]model.RecentError(nil), m.state.RecentErrors...),
+		requests:    api.RecentRequests(),
+		logPath:     os.Getenv("ARGONAUT_LOG_FILE"),
+	}
+	if m.config != nil {
+		b.secrets = bundleSecrets(m.config)
+	}
+	if m.state.Server != nil {
+		b.server = m.state.Server.BaseURL
+		if u, err := url.Parse(b.server); err == nil && u.User != nil {
+			u.User = nil
+			b.server = u.String()
+		}
+	}
+	if m.serverCompat != nil {
+		b.compat = m.serverCompat.Summary()
+	}
+
+	if path == "" {
+		path = "argonaut-support-" + b.created.Format("20060102-150405") + ".tar.gz"
+	}
+	path = expandHomePath(path)
+	return m, func() tea.Msg {
+		data, err := b.archive()
+		if err != nil {
+			return supportBundleWrittenMsg{path: path, err: err}
+		}
+		return supportBundleWrittenMsg{path: path, err: writeNewFile(path, data)}
+	}
+}
+
+// bundleFile is one file of a support bundle
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// archive packs the bundle's files into a tar.gz under one directory
+func (b supportBundle) archive() ([]byte, error) {
+	var files []bundleFile
+	var notes []string
+	if logData, err := tailFile(b.logPath, maxBundleLogBytes); err != nil {
+		notes = append(notes, "argonaut.log: "+err.Error())
+	} else {
+		files = append(files, bundleFile{"argonaut.log", redactLog(logData, b.secrets...)})
+	}
+	if cfg, err := config.RedactedConfig(); err != nil {
+		notes = append(notes, "config.toml: "+err.Error())
+	} else if cfg != nil {
+		files = append(files, bundleFile{"config.toml", cfg})
+	} else {
+		notes = append(notes, "config.toml: no config file")
+	}
+	files = append(files,
+		bundleFile{"errors.txt", redactLog([]byte(b.errorsText()), b.secrets...)},
+		bundleFile{"api-requests.txt", redactLog([]byte(b.requestsText()), b.secrets...)})
+
+	names := []string{"summary.md"}
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+	files = append([]bundleFile{{"summary.md", []byte(b.summary(names, notes))}}, files...)
+
+	var out bytes.Buffer
+	gz := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gz)
+	dir := "argonaut-support-" + b.created.Format("20060102-150405")
+	for _, f := range files {
+		hdr := &tar.Header{Name: dir + "/" + f.name, Mode: 0o644, Size: int64(len(f.data)), ModTime: b.created}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// orUnknown is s, or "unknown" when it is empty
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// summary describes the environment and session the bundle was taken in
+func (b supportBundle) summary(files, notes []string) string {
+	var s strings.Builder
+	s.WriteString("# argonaut support bundle\n\n")
+	fmt.Fprintf(&s, "- Created: %s\n", b.created.Format(time.RFC3339))
+	fmt.Fprintf(&s, "- argonaut: %s (%s, %s/%s)\n", b.version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&s, "- Terminal: %dx%d, TERM=%s, COLORTERM=%s, TERM_PROGRAM=%s\n",
+		b.cols, b.rows, orUnknown(os.Getenv("TERM")), orUnknown(os.Getenv("COLORTERM")), orUnknown(os.Getenv("TERM_PROGRAM")))
+	fmt.Fprintf(&s, "- Server: %s (context %s)\n", orUnknown(b.server), orUnknown(b.contextName))
+	fmt.Fprintf(&s, "- Argo CD: %s\n", orUnknown(b.apiVersion))
+	if b.compat != "" {
+		fmt.Fprintf(&s, "- Compatibility: %s\n", b.compat)
+	}
+	fmt.Fprintf(&s, "- Session: %s view, %s mode, %d apps, streaming %t\n", b.view, b.mode, b.apps, b.streaming)
+	fmt.Fprintf(&s, "- Recent errors: %d\n", len(b.errors))
+	fmt.Fprintf(&s, "- API requests traced: %d\n", len(b.requests))
+
+	s.WriteString("\n## Files\n\n")
+	for _, f := range files {
+		s.WriteString("- " + f + "\n")
+	}
+	if len(notes) > 0 {
+		s.WriteString("\n## Left out\n\n")
+		for _, n := range notes {
+			s.WriteString("- " + n + "\n")
+		}
+	}
+	s.WriteString("\nThe log, errors and API requests have passwords, tokens, values from the secrets file, the webhook URL and URL paths redacted, and the config its secrets and webhook URL. Look them over before sharing.\n")
+	return s.String()
+}
+
+// errorsText lists the errors of the :errors drawer, oldest first
+func (b supportBundle) errorsText() string {
+	if len(b.errors) == 0 {
+		return "No errors recorded.\n"
+	}
+	var s strings.Builder
+	for _, e := range b.errors {
+		fmt.Fprintf(&s, "%s [%s] %s\n", e.At.Format(time.RFC3339), e.Source, e.Message)
+		if e.Details != "" && e.Details != e.Message {
+			fmt.Fprintf(&s, "    %s\n", strings.ReplaceAll(e.Details, "\n", "\n    "))
+		}
+	}
+	return s.String()
+}
+
+// requestsText lists the traced API requests, oldest first
+func (b supportBundle) requestsText() string {
+	if len(b.requests) == 0 {
+		return "No API requests recorded.\n"
+	}
+	var s strings.Builder
+	for _, r := range b.requests {
+		status := "---"
+		if r.Status != 0 {
+			status = fmt.Sprint(r.Status)
+		}
+		fmt.Fprintf(&s, "%s %s %-6s %s %s", r.At.Format("15:04:05.000"), status, r.Method, r.Duration.Round(time.Millisecond), r.Path)
+		if r.Err != "" {
+			fmt.Fprintf(&s, "  (%s)", r.Err)
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// tailFile reads up to limit bytes from the end of a file, starting at a
+// whole line when it has to cut
+func tailFile(path string, limit int64) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no log file")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= limit {
+		return io.ReadAll(f)
+	}
+	if _, err := f.Seek(info.Size()-limit, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+	return data, nil
+}
+
+// handleSupportBundleWritten reports where the bundle went, or why it did not
+func (m *Model) handleSupportBundleWritten(msg supportBundleWrittenMsg) tea.Cmd {
+	if msg.err != nil {
+		cblog.With("component", "support").Error("Failed to write support bundle", "path", msg.path, "err", msg.err)
+		text := "Could not write support bundle: " + msg.err.Error()
+		m.statusService.Error(text)
+		m.recordError("support", text, msg.err.Error(), nil)
+		return nil
+	}
+	cblog.With("component", "support").Info("Wrote support bundle", "path", msg.path)
+	m.statusService.Set("Wrote support bundle to " + msg.path)
+	return m.showStatusNote("Wrote " + filepath.Base(msg.path))
+}
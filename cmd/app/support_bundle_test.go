@@ -0,0 +1,212 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/notify"
+)
+
+// readBundle returns the files of a support bundle by name, without their
+// directory
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("bundle not written: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(tr)
+		files[filepath.Base(hdr.Name)] = string(data)
+	}
+	return files
+}
+
+func TestSupportBundle_CollectsRedactedFiles(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "argonaut.log")
+	os.WriteFile(logPath, []byte("INFO request Authorization: Bearer eyJhbGciOi.abc\nINFO login auth_token=s3cr3t user=alice\n"), 0644)
+	t.Setenv("ARGONAUT_LOG_FILE", logPath)
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+	os.WriteFile(filepath.Join(dir, "config.toml"), []byte("[notifications]\nwebhook_url = \"https://hooks.example.com/T0/XXXX\"\n"), 0644)
+
+	m := buildDeleteTestModel(120, 30)
+	m.state.APIVersion = "v2.13.1"
+	m.recordError("sync", "Sync failed: permission denied", "rpc error: code = PermissionDenied", nil)
+
+	path := filepath.Join(dir, "bundle.tar.gz")
+	_, cmd := m.handleSupportBundleCommand(path)
+	m.Update(cmd())
+	if m.state.UI.StatusNote != "Wrote bundle.tar.gz" {
+		t.Errorf("status note = %q", m.state.UI.StatusNote)
+	}
+
+	files := readBundle(t, path)
+	for _, name := range []string{"summary.md", "argonaut.log", "config.toml", "errors.txt", "api-requests.txt"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("bundle is missing %s", name)
+		}
+	}
+	if log := files["argonaut.log"]; strings.Contains(log, "eyJhbGciOi") || strings.Contains(log, "s3cr3t") || !strings.Contains(log, "user=alice") {
+		t.Errorf("log should have credentials redacted and the rest kept:\n%s", log)
+	}
+	if strings.Contains(files["config.toml"], "hooks.example.com") {
+		t.Errorf("config should have the webhook redacted:\n%s", files["config.toml"])
+	}
+	if !strings.Contains(files["errors.txt"], "[sync] Sync failed: permission denied") {
+		t.Errorf("errors.txt:\n%s", files["errors.txt"])
+	}
+	summary := files["summary.md"]
+	for _, want := range []string{"Terminal: 120x30", "Argo CD: v2.13.1", "Recent errors: 1"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary should contain %q:\n%s", want, summary)
+		}
+	}
+
+	// Existing files are kept
+	_, cmd = m.handleSupportBundleCommand(path)
+	m.Update(cmd())
+	last := m.state.RecentErrors[len(m.state.RecentErrors)-1]
+	if last.Source != "support" || !strings.Contains(last.Message, "already exists") {
+		t.Errorf("writing over a file should fail: %+v", last)
+	}
+}
+
+func TestSupportBundle_RedactsFailingWebhook(t *testing.T) {
+	// Some receivers echo the URL they were called on in the error body
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no_service: http://"+r.Host+r.URL.Path, http.StatusNotFound)
+	}))
+	defer srv.Close()
+	hookURL := srv.URL + "/services/T0/B0/s3cr3thook"
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "argonaut.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+	prev := cblog.Default()
+	cblog.SetDefault(cblog.New(logFile))
+	defer cblog.SetDefault(prev)
+	t.Setenv("ARGONAUT_LOG_FILE", logPath)
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Notifications: config.NotificationsConfig{WebhookURL: hookURL}}
+	status, _ := m.sendSyncNotification(notify.Event{App: "web", Result: "Failed"})().(model.StatusChangeMsg)
+	if !strings.Contains(status.Status, "s3cr3thook") {
+		t.Fatalf("the webhook's reply should echo its URL for this test, got %q", status.Status)
+	}
+	m.recordError("notify", status.Status, "POST "+hookURL, nil)
+	m.recordError("api", "Request failed", "GET https://argocd.example.com/api/v1/applications/web?appNamespace=team-a", nil)
+
+	path := filepath.Join(dir, "bundle.tar.gz")
+	_, cmd := m.handleSupportBundleCommand(path)
+	m.Update(cmd())
+	files := readBundle(t, path)
+	for name, want := range map[string]string{"argonaut.log": "Sync notification failed", "errors.txt": "Notification for web failed"} {
+		if !strings.Contains(files[name], want) || strings.Contains(files[name], "s3cr3thook") {
+			t.Errorf("%s should keep the failure with the webhook URL redacted:\n%s", name, files[name])
+		}
+	}
+	if errs := files["errors.txt"]; strings.Contains(errs, "/api/v1/applications/web") || !strings.Contains(errs, "https://argocd.example.com/<redacted>") {
+		t.Errorf("URL paths should be redacted down to the host:\n%s", errs)
+	}
+}
+
+func TestSupportBundle_RedactsDecryptedSecrets(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte("diff:\n  token: tok-abc\n  long: tok-abc-and-more\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+	cfgData := "[secrets]\nfile = \"secrets.yaml\"\ndecrypt_command = \"cat {file}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(cfgData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+
+	logPath := filepath.Join(dir, "argonaut.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+	prev := cblog.Default()
+	cblog.SetDefault(cblog.New(logFile))
+	defer cblog.SetDefault(prev)
+	t.Setenv("ARGONAUT_LOG_FILE", logPath)
+	// Command output, like a diff formatter's, can echo what it was given
+	cblog.Info("Formatter failed", "output", "fmt: bad flag tok-abc-and-more")
+
+	m := buildDeleteTestModel(120, 30)
+	m.config = cfg
+	m.recordError("hook", "post-sync hook failed", "curl: rejected tok-abc", nil)
+
+	path := filepath.Join(dir, "bundle.tar.gz")
+	_, cmd := m.handleSupportBundleCommand(path)
+	m.Update(cmd())
+	files := readBundle(t, path)
+	for name, want := range map[string]string{"argonaut.log": "fmt: bad flag <redacted>", "errors.txt": "curl: rejected <redacted>"} {
+		if !strings.Contains(files[name], want) || strings.Contains(files[name], "tok-abc") {
+			t.Errorf("%s should have the secret values redacted whole:\n%s", name, files[name])
+		}
+	}
+}
+
+func TestSupportBundle_NotesWhatIsMissing(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARGONAUT_LOG_FILE", "")
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+
+	m := buildDeleteTestModel(120, 30)
+	path := filepath.Join(dir, "bundle.tar.gz")
+	_, cmd := m.handleSupportBundleCommand(path)
+	m.Update(cmd())
+
+	files := readBundle(t, path)
+	if _, ok := files["argonaut.log"]; ok {
+		t.Error("no log file, so no log in the bundle")
+	}
+	if summary := files["summary.md"]; !strings.Contains(summary, "argonaut.log: no log file") || !strings.Contains(summary, "config.toml: no config file") {
+		t.Errorf("summary should say what was left out:\n%s", summary)
+	}
+}
+
+func TestTailFile_StartsAtWholeLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	os.WriteFile(path, []byte("first line\nsecond line\nthird\n"), 0644)
+	data, err := tailFile(path, 14)
+	if err != nil || string(data) != "third\n" {
+		t.Errorf("tail = %q, %v", data, err)
+	}
+}
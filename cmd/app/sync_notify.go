@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	appcontext "github.com/darksworm/argonaut/pkg/context"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/notify"
+)
+
+// syncNotificationsEnabled reports whether a webhook is configured
+func (m *Model) syncNotificationsEnabled() bool {
+	return m.config.GetNotificationWebhookURL() != ""
+}
+
+// syncNotificationEvent describes the app's finished operation
+func (m *Model) syncNotificationEvent(app model.App) notify.Event {
+	ev := notify.Event{
+		App:       app.Name,
+		Namespace: derefOr(app.AppNamespace),
+		Project:   derefOr(app.Project),
+		Context:   m.currentContextName,
+		Result:    app.OperationPhase,
+		Message:   app.OperationMessage,
+	}
+	for _, src := range app.Sources {
+		if src.Revision != "" {
+			ev.Revision = src.Revision
+			break
+		}
+	}
+	if app.LastSyncAt != nil && app.OperationStartedAt != nil {
+		ev.Duration = notify.FormatDuration(app.LastSyncAt.Sub(*app.OperationStartedAt))
+	}
+	return ev
+}
+
+// sendSyncNotification posts ev to the configured webhook
+func (m *Model) sendSyncNotification(ev notify.Event) tea.Cmd {
+	hook := notify.Webhook{
+		URL:      m.config.GetNotificationWebhookURL(),
+		Format:   m.config.Notifications.Format,
+		Template: m.config.Notifications.Template,
+	}
+	return func() tea.Msg {
+		ctx, cancel := appcontext.WithAPITimeout(context.Background())
+		defer cancel()
+		if err := hook.Send(ctx, ev); err != nil {
+			cblog.With("component", "notify").Warn("Sync notification failed", "app", ev.App, "err", err)
+			return model.StatusChangeMsg{Status: "Notification for " + ev.App + " failed: " + err.Error()}
+		}
+		cblog.With("component", "notify").Info("Sync notification sent", "app", ev.App, "result", ev.Result)
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	apperrors "github.com/darksworm/argonaut/pkg/errors"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestSyncNotification_PostedWhenWatchedSyncFinishes(t *testing.T) {
+	posted := make(chan map[string]any, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		posted <- body
+	}))
+	defer srv.Close()
+
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Notifications: config.NotificationsConfig{WebhookURL: srv.URL}}
+	prev := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	m.state.Apps[0].OperationStartedAt = &prev
+	m.state.Apps[0].OperationPhase = "Succeeded"
+
+	m.handleSyncModal()
+	m.handleConfirmSyncKeys(testKeyMsg("y"))
+	if len(m.watchedOps) != 1 {
+		t.Fatalf("watched sync should be registered, got %v", m.watchedOps)
+	}
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Success: true})
+	if !m.watchedOps[appKey("test-app", m.state.Apps[0].AppNamespace)].notify {
+		t.Fatal("a watched sync should ask for a notification when a webhook is set")
+	}
+	if m.checkWatchedOperations() != nil {
+		t.Fatal("the operation from before the sync must not trigger a notification")
+	}
+
+	started := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	finished := started.Add(95 * time.Second)
+	app := &m.state.Apps[0]
+	app.OperationStartedAt, app.OperationPhase = &started, "Running"
+	if m.checkWatchedOperations() != nil {
+		t.Fatal("running operation must not trigger a notification")
+	}
+
+	app.OperationPhase, app.OperationMessage, app.LastSyncAt = "Failed", "one or more objects failed to apply", &finished
+	app.Sources = []model.AppSource{{Revision: "0123456789abcdef"}}
+	cmd := m.checkWatchedOperations()
+	if cmd == nil {
+		t.Fatal("finished operation should trigger a notification")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("unexpected message: %#v", msg)
+	}
+	body := <-posted
+	if body["app"] != "test-app" || body["result"] != "Failed" || body["duration"] != "1m35s" || body["revision"] != "0123456789abcdef" {
+		t.Errorf("unexpected payload: %v", body)
+	}
+	if len(m.watchedOps) != 0 {
+		t.Error("notified sync should be forgotten")
+	}
+}
+
+func TestSyncNotification_OnlyForWatchedSyncsWithWebhook(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleSyncModal()
+	m.handleConfirmSyncKeys(testKeyMsg("y"))
+	if op, ok := m.watchedOps[appKey("test-app", m.state.Apps[0].AppNamespace)]; !ok || op.notify {
+		t.Fatal("a watched sync should be followed, but not notify without a webhook")
+	}
+
+	m = buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Notifications: config.NotificationsConfig{WebhookURL: "http://127.0.0.1:1"}}
+	m.handleSyncModal()
+	m.handleConfirmSyncKeys(testKeyMsg("w")) // watch off
+	m.handleConfirmSyncKeys(testKeyMsg("y"))
+	if len(m.watchedOps) != 0 {
+		t.Fatal("syncs without Watch should not notify")
+	}
+
+	m.trackWatchedSync("test-app", m.state.Apps[0].AppNamespace)
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", AppNamespace: m.state.Apps[0].AppNamespace, Success: false})
+	if len(m.watchedOps) != 0 {
+		t.Error("cancelled sync should be forgotten")
+	}
+}
+
+func TestSyncNotification_NotForSyncsTheServerRefused(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{Notifications: config.NotificationsConfig{WebhookURL: "http://127.0.0.1:1"}}
+	ns := m.state.Apps[0].AppNamespace
+	started := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	m.trackWatchedSync("test-app", ns)
+	app := &m.state.Apps[0]
+	app.OperationStartedAt, app.OperationPhase = &started, "Succeeded"
+	if m.checkWatchedOperations() != nil {
+		t.Fatal("a sync the server has not accepted must not report the app's next operation")
+	}
+
+	m.Update(model.StructuredErrorMsg{
+		Error:   apperrors.New(apperrors.ErrorAPI, "SYNC_FAILED", "Failed to sync test-app"),
+		Context: map[string]interface{}{"operation": "sync", "appName": "test-app", "appNamespace": ns},
+	})
+	if len(m.watchedOps) != 0 {
+		t.Error("a sync that failed to start should be forgotten")
+	}
+
+	m.trackWatchedSync("test-app", ns)
+	m.Update(model.OperationConflictMsg{AppName: "test-app", AppNamespace: ns, Operation: "sync", Message: "another operation is already in progress"})
+	if len(m.watchedOps) != 0 {
+		t.Error("a sync refused for a running operation should be forgotten")
+	}
+}
+
+func TestWatchedOperations_DisambiguateAppsSharingAName(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	ns, other := m.state.Apps[0].AppNamespace, strp("other-namespace")
+	m.state.Apps[1].Name, m.state.Apps[1].AppNamespace = "test-app", other
+
+	m.trackWatchedSync("test-app", ns)
+	m.trackWatchedSync("test-app", other)
+	m.Update(model.SyncCompletedMsg{AppName: "test-app", AppNamespace: other, Success: true})
+	if m.watchedOps[appKey("test-app", ns)].confirmed || !m.watchedOps[appKey("test-app", other)].confirmed {
+		t.Fatalf("only the synced app's operation should be confirmed, got %v", m.watchedOps)
+	}
+
+	m.trackWatchedSync("test-app", other)
+	m.Update(model.StructuredErrorMsg{
+		Error:   apperrors.New(apperrors.ErrorAPI, "SYNC_FAILED", "Failed to sync test-app"),
+		Context: map[string]interface{}{"operation": "sync", "appName": "test-app", "appNamespace": ns},
+	})
+	if _, ok := m.watchedOps[appKey("test-app", ns)]; ok {
+		t.Error("the refused sync should be forgotten")
+	}
+	if _, ok := m.watchedOps[appKey("test-app", other)]; !ok {
+		t.Error("the other app's sync should still be watched")
+	}
+}
+
+func TestWatchedOperations_MultiSyncKeysByListedApp(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Selections.SelectedApps = map[string]bool{"test-app": true}
+	m.state.Modals.ConfirmTarget = strp("__MULTI__")
+	m.state.Modals.ConfirmSyncWatch = true
+	m.handleConfirmSyncKeys(testKeyMsg("y"))
+	if _, ok := m.watchedOps[appKey("test-app", m.state.Apps[0].AppNamespace)]; !ok {
+		t.Fatalf("multi-select sync should be keyed like the cursor sync, got %v", m.watchedOps)
+	}
+}
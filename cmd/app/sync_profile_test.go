@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestConfirmSync_SyncProfilePrepopulates(t *testing.T) {
+	on, off := true, false
+	m := buildDeleteTestModel(120, 30)
+	m.config = &config.ArgonautConfig{SyncProfiles: []config.SyncProfile{
+		{Project: "test-*", Prune: &on, Watch: &off, ServerSideApply: &on},
+	}}
+
+	m.handleSyncModal()
+	modals := m.state.Modals
+	if !modals.ConfirmSyncPrune || modals.ConfirmSyncWatch || !modals.ConfirmSyncServerSideApply {
+		t.Fatalf("profile should pre-populate the modal, got %+v", modals)
+	}
+	if opts := m.confirmSyncOptions(); !opts.Prune || !opts.ServerSideApply {
+		t.Errorf("sync options should carry the profile values: %+v", opts)
+	}
+	if out := stripANSI(m.renderConfirmSyncModal()); !strings.Contains(out, "sync profile: project test-*") {
+		t.Errorf("modal should name the applied profile:\n%s", out)
+	}
+
+	// The next app has no matching profile and must not inherit prune
+	m.state.Mode = model.ModeNormal
+	m.state.Navigation.SelectedIdx = 1
+	m.handleSyncModal()
+	modals = m.state.Modals
+	if modals.ConfirmSyncPrune || !modals.ConfirmSyncWatch || modals.ConfirmSyncServerSideApply || modals.ConfirmSyncProfile != "" {
+		t.Fatalf("options should reset for an app without a profile, got %+v", modals)
+	}
+}
+
+func TestConfirmSync_ToggleServerSideApply(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleSyncModal()
+	m.handleConfirmSyncKeys(testKeyMsg("a"))
+	if !m.confirmSyncOptions().ServerSideApply {
+		t.Fatal("a should toggle server-side apply on")
+	}
+	if out := stripANSI(m.renderConfirmSyncModal()); !strings.Contains(out, "a: Server-side On") {
+		t.Errorf("modal should show server-side apply state:\n%s", out)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfirmSync_PruneOptions(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Modals.ConfirmSyncPrunePolicy = "orphan" // stale value from a previous sync
+	m.handleSyncModal()
+	if m.state.Modals.ConfirmSyncPrunePolicy != "" || m.state.Modals.ConfirmSyncPruneLast {
+		t.Fatal("opening the modal should reset the prune options")
+	}
+
+	want := []string{"foreground", "background", "orphan", ""}
+	for _, policy := range want {
+		m.handleConfirmSyncKeys(testKeyMsg("o"))
+		if got := m.state.Modals.ConfirmSyncPrunePolicy; got != policy {
+			t.Fatalf("expected policy %q, got %q", policy, got)
+		}
+	}
+
+	m.handleConfirmSyncKeys(testKeyMsg("o"))
+	m.handleConfirmSyncKeys(testKeyMsg("L"))
+	opts := m.confirmSyncOptions()
+	if opts.PrunePropagationPolicy != "foreground" || !opts.PruneLast {
+		t.Fatalf("unexpected sync options: %+v", opts)
+	}
+
+	out := stripANSI(m.renderConfirmSyncModal())
+	if !strings.Contains(out, "o: Policy foreground") || !strings.Contains(out, "L: Prune last On") {
+		t.Errorf("modal should show the prune options:\n%s", out)
+	}
+}
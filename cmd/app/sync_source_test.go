@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestConfirmSync_SourcePicker(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].MultiSource = true
+	m.state.Apps[0].Sources = []model.AppSource{
+		{RepoURL: "https://charts.example.com", Chart: "web", TargetRevision: "1.4.0"},
+		{RepoURL: "https://git.example.com/values.git", TargetRevision: "main", Ref: "values"},
+	}
+
+	m.handleSyncModal()
+	if len(m.confirmSyncSources()) != 2 {
+		t.Fatal("expected source picker for multi-source app")
+	}
+	m.handleConfirmSyncKeys(testKeyMsg("j"))
+	m.handleConfirmSyncKeys(testKeyMsg("j"))
+	m.handleConfirmSyncKeys(testKeyMsg("j"))
+	if got := m.state.Modals.ConfirmSyncSource; got != 2 {
+		t.Fatalf("expected source 2 selected (clamped), got %d", got)
+	}
+
+	out := stripANSI(m.renderConfirmSyncModal())
+	if !strings.Contains(out, "► 2. https://git.example.com/values.git") {
+		t.Errorf("picker should mark the selected source:\n%s", out)
+	}
+
+	m.handleConfirmSyncKeys(testKeyMsg("k"))
+	m.handleConfirmSyncKeys(testKeyMsg("k"))
+	if got := m.state.Modals.ConfirmSyncSource; got != 0 {
+		t.Fatalf("expected all sources after moving up, got %d", got)
+	}
+}
+
+func TestConfirmSync_NoSourcePickerForSingleSource(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.handleSyncModal()
+	if m.confirmSyncSources() != nil {
+		t.Fatal("single-source app should not offer a source picker")
+	}
+	if strings.Contains(stripANSI(m.renderConfirmSyncModal()), "All sources") {
+		t.Error("single-source sync modal should not render the picker")
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// termCheckProbeTimeout is how long :termcheck waits for the terminal to
+// answer its queries; mosh and older terminals never do
+const termCheckProbeTimeout = 2 * time.Second
+
+// termCheckWidthSample mixes a wide character with the symbols argonaut
+// draws in its tables; the terminal should advance the cursor by as many
+// cells as lipgloss measures
+const termCheckWidthSample = "日✓⚑"
+
+// termCheckModes are the DEC modes :termcheck asks the terminal about
+var termCheckModes = []ansi.DECMode{ansi.ModeMouseAnyEvent, ansi.ModeMouseExtSgr, ansi.ModeAltScreenSaveCursor}
+
+// Results of a terminal check
+const (
+	termPass    = "pass"
+	termWarn    = "warn"
+	termFail    = "fail"
+	termUnknown = "unknown"
+)
+
+// termCheckTimeoutMsg ends the wait for replies to a :termcheck
+type termCheckTimeoutMsg struct{ id int }
+
+// termCheck is one row of the :termcheck report
+type termCheck struct {
+	name   string
+	result string // empty while its probe is unanswered
+	detail string
+	hint   string
+}
+
+// startTermCheck opens the terminal check and sends its probes: the width
+// sample is drawn in the top left corner between a cursor save and
+// restore, and the screen is redrawn once the cursor position is in
+func (m *Model) startTermCheck() tea.Cmd {
+	m.termCheckSeq++
+	id := m.termCheckSeq
+	m.state.Modals.TermCheck = &model.TermCheckState{Probing: true, Modes: map[int]string{}, ID: id}
+	m.state.Mode = model.ModeTermCheck
+
+	modes := ""
+	for _, mode := range termCheckModes {
+		modes += ansi.RequestMode(mode)
+	}
+	return tea.Batch(
+		tea.Raw(ansi.SaveCursor+ansi.CursorHomePosition+termCheckWidthSample+ansi.RequestCursorPositionReport+ansi.RestoreCursor),
+		tea.Raw(modes),
+		tea.RequestTerminalVersion,
+		tea.Tick(termCheckProbeTimeout, func(time.Time) tea.Msg { return termCheckTimeoutMsg{id: id} }),
+	)
+}
+
+// handleTermCheckReply records the terminal's answer to a probe; replies
+// arriving while no check is running are someone else's and ignored
+func (m *Model) handleTermCheckReply(msg tea.Msg) tea.Cmd {
+	st := m.state.Modals.TermCheck
+	if st == nil || !st.Probing {
+		return nil
+	}
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.CursorPositionMsg:
+		if st.CursorX != nil {
+			return nil
+		}
+		x := msg.X
+		st.CursorX = &x
+		cmd = tea.ClearScreen // repaint over the width sample
+	case tea.ModeReportMsg:
+		if _, asked := st.Modes[msg.Mode.Mode()]; asked || !termCheckAsked(msg.Mode) {
+			return nil
+		}
+		st.Modes[msg.Mode.Mode()] = modeSettingName(msg.Value)
+	case tea.TerminalVersionMsg:
+		st.Version = msg.Name
+	case termCheckTimeoutMsg:
+		if msg.id != st.ID {
+			return nil
+		}
+		st.Probing = false
+		return tea.ClearScreen
+	}
+	if st.CursorX != nil && len(st.Modes) == len(termCheckModes) {
+		st.Probing = false
+	}
+	return cmd
+}
+
+// termCheckAsked reports whether :termcheck queries a mode
+func termCheckAsked(mode ansi.Mode) bool {
+	for _, asked := range termCheckModes {
+		if asked.Mode() == mode.Mode() {
+			return true
+		}
+	}
+	return false
+}
+
+func modeSettingName(v ansi.ModeSetting) string {
+	switch {
+	case v.IsSet():
+		return "set"
+	case v.IsReset():
+		return "reset"
+	case v.IsPermanentlySet():
+		return "permanently set"
+	case v.IsPermanentlyReset():
+		return "permanently reset"
+	}
+	return "not recognized"
+}
+
+// termCheckColorProfile is the color profile Bubble Tea detected, or the
+// one the environment suggests before it was reported
+func (m *Model) termCheckColorProfile() colorprofile.Profile {
+	if m.colorProfile != colorprofile.Unknown {
+		return m.colorProfile
+	}
+	return colorprofile.Env(os.Environ())
+}
+
+// termChecks turns the probe replies and the environment into the report,
+// with a suggestion for each check that did not pass
+func termChecks(st *model.TermCheckState, profile colorprofile.Profile, lowBandwidth bool, getenv func(string) string) []termCheck {
+	tmux, screen := getenv("TMUX") != "", getenv("STY") != ""
+	noReply := "no reply; mosh and some older terminals do not answer queries"
+
+	term := termCheck{name: "Terminal", result: termPass}
+	parts := []string{"TERM=" + orUnknown(getenv("TERM"))}
+	if p := getenv("TERM_PROGRAM"); p != "" {
+		parts = append(parts, p)
+	}
+	if st.Version != "" {
+		parts = append(parts, st.Version)
+	}
+	switch {
+	case tmux:
+		parts = append(parts, "in tmux")
+	case screen:
+		parts = append(parts, "in GNU screen")
+	}
+	term.detail = strings.Join(parts, " · ")
+	if t := getenv("TERM"); t == "" || t == "dumb" {
+		term.result, term.hint = termFail, "set TERM to what your terminal emulates, e.g. TERM=xterm-256color"
+	} else if (tmux || screen) && !strings.HasPrefix(t, "tmux") && !strings.HasPrefix(t, "screen") {
+		term.result, term.hint = termWarn, "inside a multiplexer TERM should start with tmux or screen; check default-terminal in .tmux.conf"
+	}
+
+	colors := termCheck{name: "Colors"}
+	switch profile {
+	case colorprofile.TrueColor:
+		colors.result, colors.detail = termPass, "24-bit true color"
+	case colorprofile.ANSI256:
+		colors.result, colors.detail = termWarn, "256 colors; theme colors are approximated"
+		colors.hint = "if the terminal has true color, set COLORTERM=truecolor"
+		if tmux {
+			colors.hint += " and add `set -as terminal-features ',*:RGB'` to .tmux.conf"
+		}
+	case colorprofile.ANSI:
+		colors.result, colors.detail = termWarn, "16 colors"
+		colors.hint = "set TERM=xterm-256color, or COLORTERM=truecolor if the terminal has true color"
+		if lowBandwidth {
+			colors.result, colors.detail, colors.hint = termPass, "16 colors, as low bandwidth mode asks for", ""
+		}
+	default:
+		colors.result, colors.detail = termFail, "no colors"
+		colors.hint = "check TERM and that NO_COLOR is not set"
+	}
+
+	unicode := termCheck{name: "Unicode width"}
+	locale := getenv("LC_ALL")
+	if locale == "" {
+		locale = getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = getenv("LANG")
+	}
+	utf8 := strings.Contains(strings.ToLower(strings.ReplaceAll(locale, "-", "")), "utf8")
+	want := lipgloss.Width(termCheckWidthSample)
+	switch {
+	case st.CursorX != nil && *st.CursorX != want:
+		unicode.result = termFail
+		unicode.detail = fmt.Sprintf("%q took %d cells, argonaut expects %d; columns will not line up", termCheckWidthSample, *st.CursorX, want)
+		unicode.hint = "use a UTF-8 locale (e.g. LANG=en_US.UTF-8) on both ends of ssh or mosh"
+		if tmux {
+			unicode.hint += ", and start tmux with -u"
+		}
+	case st.CursorX != nil && !utf8:
+		unicode.result, unicode.detail = termWarn, "widths match, but the locale is "+orUnknown(locale)
+		unicode.hint = "set a UTF-8 locale, e.g. LANG=en_US.UTF-8"
+	case st.CursorX != nil:
+		unicode.result, unicode.detail = termPass, "wide characters and symbols take the cells argonaut expects"
+	case st.Probing:
+	default:
+		unicode.result, unicode.detail = termUnknown, noReply
+		if !utf8 {
+			unicode.result, unicode.detail = termWarn, "no reply, and the locale is "+orUnknown(locale)
+			unicode.hint = "set a UTF-8 locale, e.g. LANG=en_US.UTF-8"
+		}
+	}
+
+	mouse := termCheck{name: "Mouse"}
+	anyEvent, sgr := st.Modes[ansi.ModeMouseAnyEvent.Mode()], st.Modes[ansi.ModeMouseExtSgr.Mode()]
+	switch {
+	case anyEvent == "not recognized" || sgr == "not recognized":
+		mouse.result, mouse.detail = termFail, "mouse tracking not supported; clicks and scrolling will not reach argonaut"
+		if tmux {
+			mouse.hint = "add `set -g mouse on` to .tmux.conf"
+		} else {
+			mouse.hint = "use a terminal with SGR mouse reporting (xterm, iTerm2, WezTerm, kitty, Windows Terminal)"
+		}
+	case anyEvent != "" && sgr != "":
+		mouse.result, mouse.detail = termPass, "motion and click tracking with SGR coordinates"
+	case st.Probing:
+	default:
+		mouse.result, mouse.detail = termUnknown, noReply
+	}
+
+	alt := termCheck{name: "Alternate screen"}
+	switch st.Modes[ansi.ModeAltScreenSaveCursor.Mode()] {
+	case "":
+		if !st.Probing {
+			alt.result, alt.detail = termUnknown, noReply
+		}
+	case "not recognized":
+		alt.result, alt.detail = termFail, "not supported; argonaut draws over your scrollback"
+		if screen {
+			alt.hint = "add `altscreen on` to .screenrc"
+		} else {
+			alt.hint = "check that TERM matches the terminal"
+		}
+	default:
+		alt.result, alt.detail = termPass, "the shell's screen comes back on quit"
+	}
+
+	clip := termCheck{name: "Clipboard (OSC 52)"}
+	native := clipboard.NativeCommand()
+	if runtime.GOOS == "linux" && getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == "" &&
+		(strings.HasPrefix(native, "xclip") || strings.HasPrefix(native, "xsel")) {
+		native = "" // no X display to copy to, e.g. over ssh
+	}
+	switch {
+	case native != "":
+		clip.result, clip.detail = termPass, "copies go through "+native+", OSC 52 is not needed"
+	case screen:
+		clip.result, clip.detail = termFail, "copies use OSC 52, which GNU screen does not pass on"
+		clip.hint = "set [clipboard] copy_command in the config, or run argonaut outside screen"
+	case tmux:
+		clip.result, clip.detail = termWarn, "copies use OSC 52, which tmux passes on only when allowed"
+		clip.hint = "add `set -g set-clipboard on` to .tmux.conf"
+	default:
+		clip.result, clip.detail = termUnknown, "copies use OSC 52, which terminals do not confirm"
+		clip.hint = "if y copies nothing, allow clipboard access in the terminal's settings or set [clipboard] copy_command"
+	}
+
+	return []termCheck{term, colors, unicode, mouse, alt, clip}
+}
+
+// termCheckReport is the report as plain text, for pasting into an issue
+func termCheckReport(checks []termCheck) string {
+	var b strings.Builder
+	for _, c := range checks {
+		fmt.Fprintf(&b, "%-7s %s: %s\n", strings.ToUpper(orUnknown(c.result)), c.name, c.detail)
+		if c.hint != "" {
+			fmt.Fprintf(&b, "        → %s\n", c.hint)
+		}
+	}
+	return b.String()
+}
+
+func (m *Model) currentTermChecks() []termCheck {
+	lowBandwidth := m.config != nil && m.config.Appearance.LowBandwidth
+	return termChecks(m.state.Modals.TermCheck, m.termCheckColorProfile(), lowBandwidth, os.Getenv)
+}
+
+// handleTermCheckKeys handles input in the terminal check: r probes
+// again and y copies the report
+func (m *Model) handleTermCheckKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	st := m.state.Modals.TermCheck
+	if st == nil {
+		m.state.Mode = model.ModeNormal
+		return m, nil
+	}
+	switch msg.String() {
+	case "q", "esc":
+		m.state.Modals.TermCheck = nil
+		m.state.Mode = model.ModeNormal
+	case "r":
+		if st.Probing {
+			return m, nil
+		}
+		return m, m.startTermCheck()
+	case "y":
+		return m, tea.Batch(clipboard.CopyCmd(termCheckReport(m.currentTermChecks())), m.showStatusNote("Copied the terminal report"))
+	}
+	return m, nil
+}
+
+// renderTermCheckModal renders the terminal check: a row per capability
+// with its result and, below the ones that did not pass, what to try
+func (m *Model) renderTermCheckModal() string {
+	st := m.state.Modals.TermCheck
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(60, 3, 4)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	marks := map[string]string{
+		termPass:    lipgloss.NewStyle().Foreground(syncedColor).Render("✓"),
+		termWarn:    lipgloss.NewStyle().Foreground(yellowBright).Render("!"),
+		termFail:    lipgloss.NewStyle().Foreground(outOfSyncColor).Render("✗"),
+		termUnknown: dim.Render("?"),
+		"":          m.spinner.View(),
+	}
+
+	title := lipgloss.NewStyle().Foreground(yellowBright).Bold(true).Render("Terminal check")
+	if st.Probing {
+		title += " " + dim.Render(m.spinner.View()+" probing")
+	}
+	lines := []string{title, ""}
+
+	checks := m.currentTermChecks()
+	nameWidth := 0
+	for _, c := range checks {
+		nameWidth = max(nameWidth, len(c.name))
+	}
+	for _, c := range checks {
+		detail := c.detail
+		if c.result == "" {
+			detail = "waiting for the terminal…"
+		}
+		text := truncateWithEllipsis(fmt.Sprintf("%-*s  %s", nameWidth, c.name, detail), innerWidth-2)
+		lines = append(lines, marks[c.result]+" "+text)
+		if c.hint != "" {
+			lines = append(lines, dim.Render(truncateWithEllipsis(strings.Repeat(" ", nameWidth+4)+"→ "+c.hint, innerWidth)))
+		}
+	}
+
+	lines = append(lines, "", dim.Render(truncateWithEllipsis("r to probe again • y to copy the report • Esc to close", innerWidth)))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(cyanBright).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
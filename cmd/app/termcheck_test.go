@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/clipboard"
+)
+
+func termCheckEnv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func termCheckByName(t *testing.T, checks []termCheck, name string) termCheck {
+	t.Helper()
+	for _, c := range checks {
+		if c.name == name {
+			return c
+		}
+	}
+	t.Fatalf("no %s check in %+v", name, checks)
+	return termCheck{}
+}
+
+func TestTermCheck_RecordsTheTerminalsReplies(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	if cmd := m.startTermCheck(); cmd == nil || m.state.Mode != model.ModeTermCheck {
+		t.Fatalf("expected the probes to be sent, mode %s", m.state.Mode)
+	}
+	st := m.state.Modals.TermCheck
+
+	if cmd := m.handleTermCheckReply(tea.CursorPositionMsg{X: 4, Y: 0}); cmd == nil {
+		t.Error("the screen should be redrawn over the width sample")
+	}
+	m.handleTermCheckReply(tea.TerminalVersionMsg{Name: "WezTerm 20240203"})
+	m.handleTermCheckReply(tea.ModeReportMsg{Mode: ansi.ModeMouseAnyEvent, Value: ansi.ModeSet})
+	m.handleTermCheckReply(tea.ModeReportMsg{Mode: ansi.ModeFocusEvent, Value: ansi.ModeSet}) // not asked for
+	m.handleTermCheckReply(tea.ModeReportMsg{Mode: ansi.ModeMouseExtSgr, Value: ansi.ModeSet})
+	if !st.Probing {
+		t.Fatal("the check should wait for the alternate screen reply")
+	}
+	m.handleTermCheckReply(tea.ModeReportMsg{Mode: ansi.ModeAltScreenSaveCursor, Value: ansi.ModeSet})
+	if st.Probing || len(st.Modes) != 3 || st.Version != "WezTerm 20240203" {
+		t.Fatalf("every probe was answered, got %+v", st)
+	}
+
+	clipboard.SetCopyCommand("wl-copy")
+	t.Cleanup(func() { clipboard.SetCopyCommand("") })
+	env := termCheckEnv(map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm", "LANG": "en_US.UTF-8"})
+	checks := termChecks(st, colorprofile.TrueColor, false, env)
+	for _, c := range checks {
+		if c.result != termPass || c.hint != "" {
+			t.Errorf("%s: expected a pass without hints, got %+v", c.name, c)
+		}
+	}
+	if got := termCheckByName(t, checks, "Terminal").detail; got != "TERM=xterm-256color · WezTerm · WezTerm 20240203" {
+		t.Errorf("unexpected terminal detail %q", got)
+	}
+	report := termCheckReport(checks)
+	if !strings.Contains(report, "PASS    Clipboard (OSC 52): copies go through wl-copy") {
+		t.Errorf("the report should list each check:\n%s", report)
+	}
+}
+
+func TestTermChecks_SuggestFixesInTmux(t *testing.T) {
+	x := 6
+	st := &model.TermCheckState{CursorX: &x, Modes: map[int]string{
+		ansi.ModeMouseAnyEvent.Mode():       "not recognized",
+		ansi.ModeMouseExtSgr.Mode():         "reset",
+		ansi.ModeAltScreenSaveCursor.Mode(): "set",
+	}}
+	env := termCheckEnv(map[string]string{"TERM": "xterm-256color", "TMUX": "/tmp/tmux-1000/default,1,0", "LANG": "C"})
+	checks := termChecks(st, colorprofile.ANSI256, false, env)
+
+	for name, want := range map[string]struct{ result, hint string }{
+		"Terminal":      {termWarn, "default-terminal"},
+		"Colors":        {termWarn, "terminal-features ',*:RGB'"},
+		"Unicode width": {termFail, "start tmux with -u"},
+		"Mouse":         {termFail, "set -g mouse on"},
+	} {
+		c := termCheckByName(t, checks, name)
+		if c.result != want.result || !strings.Contains(c.hint, want.hint) {
+			t.Errorf("%s: expected %s with a hint about %q, got %+v", name, want.result, want.hint, c)
+		}
+	}
+	if c := termCheckByName(t, checks, "Unicode width"); !strings.Contains(c.detail, "took 6 cells, argonaut expects 4") {
+		t.Errorf("the width mismatch should be spelled out, got %q", c.detail)
+	}
+}
+
+func TestTermCheck_UnansweredProbesTimeOut(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.startTermCheck()
+	st := m.state.Modals.TermCheck
+
+	m.handleTermCheckReply(termCheckTimeoutMsg{id: st.ID - 1})
+	if !st.Probing {
+		t.Fatal("the timeout of an earlier check should be ignored")
+	}
+	out := stripANSI(m.renderTermCheckModal())
+	if !strings.Contains(out, "waiting for the terminal…") || !strings.Contains(out, "Colors") {
+		t.Errorf("unanswered probes should show as pending:\n%s", out)
+	}
+
+	m.handleTermCheckReply(termCheckTimeoutMsg{id: st.ID})
+	checks := termChecks(st, colorprofile.TrueColor, false, termCheckEnv(map[string]string{"TERM": "xterm", "LANG": "en_US.utf8"}))
+	for _, name := range []string{"Unicode width", "Mouse", "Alternate screen"} {
+		if c := termCheckByName(t, checks, name); c.result != termUnknown || !strings.Contains(c.detail, "mosh") {
+			t.Errorf("%s: expected unknown after the timeout, got %+v", name, c)
+		}
+	}
+
+	// A late reply does not change a finished check
+	m.handleTermCheckReply(tea.CursorPositionMsg{X: 4})
+	if st.CursorX != nil {
+		t.Error("replies after the timeout should be ignored")
+	}
+}
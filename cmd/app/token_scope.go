@@ -0,0 +1,32 @@
+package main
+
+import (
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// tokenScope returns the project the current server's token is limited to,
+// and false for user and account tokens
+func (m *Model) tokenScope() (api.TokenScope, bool) {
+	if m.state.Server == nil {
+		return api.TokenScope{}, false
+	}
+	return api.ProjectTokenScope(m.state.Server.Token)
+}
+
+// constrainScopeToToken scopes the views to the token's project when a
+// project role token is in use, replacing a project scope it cannot see
+// (e.g. one carried over from another context)
+func (m *Model) constrainScopeToToken() {
+	scope, ok := m.tokenScope()
+	if !ok {
+		return
+	}
+	projects := m.state.Selections.ScopeProjects
+	if len(projects) == 1 && projects[scope.Project] {
+		return
+	}
+	cblog.With("component", "auth").Info("Project role token, scoping to its project", "project", scope.Project, "role", scope.Role)
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{scope.Project})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// projectRoleToken builds an unsigned project role token for proj:<project>:<role>
+func projectRoleToken(project, role string) string {
+	enc := base64.RawURLEncoding
+	claims := `{"sub":"proj:` + project + `:` + role + `","iss":"argocd"}`
+	return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestConstrainScopeToToken(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "plain-token"}
+	m.constrainScopeToToken()
+	if len(m.state.Selections.ScopeProjects) != 0 {
+		t.Fatalf("user tokens leave the scope alone, got %v", m.state.Selections.ScopeProjects)
+	}
+
+	m.state.Server.Token = projectRoleToken("payments", "ci")
+	m.state.Selections.ScopeProjects = model.StringSetFromSlice([]string{"infra"})
+	m.Update(model.AppsLoadedMsg{Apps: m.state.Apps, SwitchEpoch: m.switchEpoch})
+	if got := m.state.Selections.ScopeProjects; len(got) != 1 || !got["payments"] {
+		t.Errorf("scope should be the token's project, got %v", got)
+	}
+}
+
+func TestRenderContextBlock_ShowsProjectToken(t *testing.T) {
+	m := NewModel(nil)
+	m.ready = true
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: projectRoleToken("payments", "ci")}
+
+	out := stripANSI(m.renderContextBlock(false))
+	if !strings.Contains(out, "Token: proj:payments:ci") {
+		t.Fatalf("expected the token scope in the header, got:\n%s", out)
+	}
+
+	m.state.Server.Token = "plain-token"
+	if out := stripANSI(m.renderContextBlock(false)); strings.Contains(out, "Token:") {
+		t.Fatalf("user tokens are not annotated, got:\n%s", out)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// handleToggleTreeFollow turns follow mode on or off. While it is on, every
+// tree update moves the cursor to a Progressing resource, so a rollout can
+// be watched without touching the keys; moving the cursor turns it off.
+func (m *Model) handleToggleTreeFollow() (tea.Model, tea.Cmd) {
+	if m.treeView == nil {
+		return m, nil
+	}
+	m.treeFollow = !m.treeFollow
+	if !m.treeFollow {
+		return m, m.showStatusNote("Follow off")
+	}
+	if !m.followProgressing() {
+		return m, m.showStatusNote("Following: nothing is progressing yet")
+	}
+	return m, tea.Batch(m.showStatusNote("Following progressing resources"), m.checkSelectedRollout())
+}
+
+// followProgressing moves the tree cursor to a Progressing resource when
+// follow mode is on, and reports whether there was one
+func (m *Model) followProgressing() bool {
+	if !m.treeFollow || m.treeView == nil || !m.treeView.FollowProgressing() {
+		return false
+	}
+	m.treeNav.SetItemCount(m.treeView.VisibleCount())
+	m.treeNav.SetViewportHeight(m.treeViewportHeight())
+	m.treeNav.SetCursor(m.treeView.SelectedIndex())
+	return true
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// rolloutTreeJSON is test-app's tree with a Deployment rolling out the
+// given pods, each "name=health"
+func rolloutTreeJSON(t *testing.T, pods ...string) []byte {
+	t.Helper()
+	progressing, healthy := "Progressing", "Healthy"
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "d1", Group: "apps", Kind: "Deployment", Name: "web", Health: &api.ResourceHealth{Status: &progressing}},
+		{UID: "s1", Kind: "Service", Name: "web", Health: &api.ResourceHealth{Status: &healthy}},
+	}}
+	for _, p := range pods {
+		name, health, _ := strings.Cut(p, "=")
+		tree.Nodes = append(tree.Nodes, api.ResourceNode{UID: name, Kind: "Pod", Name: name,
+			Health: &api.ResourceHealth{Status: &health}, ParentRefs: []api.ResourceRef{{UID: "d1"}}})
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func selectedName(m *Model) string {
+	_, _, _, name, _ := m.treeView.SelectedResource()
+	return name
+}
+
+func TestTreeFollow_TracksProgressingPods(t *testing.T) {
+	m, seq := buildTreeStreamModel(t)
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, TreeJSON: rolloutTreeJSON(t, "web-a=Healthy", "web-b=Progressing")})
+
+	m.Update(keyPress("f"))
+	if !m.treeFollow || selectedName(m) != "web-b" {
+		t.Fatalf("f should follow the progressing pod, on %q (follow %v)", selectedName(m), m.treeFollow)
+	}
+	if line := stripANSI(m.renderStatusLine()); !strings.Contains(line, "[follow]") {
+		t.Errorf("status line should show follow mode, got %q", line)
+	}
+
+	// The pod is ready and a new one starts
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, TreeJSON: rolloutTreeJSON(t, "web-a=Healthy", "web-b=Healthy", "web-c=Progressing")})
+	if selectedName(m) != "web-c" {
+		t.Errorf("cursor should move to the new progressing pod, on %q", selectedName(m))
+	}
+	if m.treeNav.Cursor() != m.treeView.SelectedIndex() {
+		t.Errorf("navigator cursor %d should match the tree's %d", m.treeNav.Cursor(), m.treeView.SelectedIndex())
+	}
+
+	// Moving the cursor by hand stops following
+	m.Update(keyPress("up"))
+	if m.treeFollow {
+		t.Fatal("manual navigation should turn follow off")
+	}
+	moved := selectedName(m)
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, TreeJSON: rolloutTreeJSON(t, "web-a=Healthy", "web-b=Healthy", "web-c=Healthy", "web-d=Progressing")})
+	if selectedName(m) != moved {
+		t.Errorf("cursor should stay where it was moved, on %q", selectedName(m))
+	}
+}
+
+func TestTreeFollow_ToggleOffAndLeavingTree(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	m := buildJobTreeModel(t, srv)
+
+	_, cmd := m.Update(keyPress("f"))
+	if !m.treeFollow || cmd == nil || !strings.Contains(m.state.UI.StatusNote, "nothing is progressing") {
+		t.Fatalf("f should turn follow on and say nothing progresses, note %q", m.state.UI.StatusNote)
+	}
+	m.Update(keyPress("f"))
+	if m.treeFollow {
+		t.Fatal("a second f should turn follow off")
+	}
+
+	m.Update(keyPress("f"))
+	m.safeChangeView(model.ViewApps)
+	if m.treeFollow {
+		t.Error("leaving the tree should turn follow off")
+	}
+}
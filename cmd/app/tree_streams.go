@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+const (
+	// treeStreamMaxAttempts is how many times in a row a tree stream is
+	// reopened without an update before it is given up on
+	treeStreamMaxAttempts = 5
+	// treeStreamMaxDelay caps the pause between reconnects
+	treeStreamMaxDelay = 30 * time.Second
+)
+
+// treeStream is the watch stream that keeps one app's tree live
+type treeStream struct {
+	seq      int
+	app      model.App
+	state    treeview.StreamState
+	attempts int // reconnects since the last update
+	err      string
+	cleanup  func()
+}
+
+// treeResubscribeMsg reopens an app's tree stream after a pause
+type treeResubscribeMsg struct {
+	appName string
+	seq     int
+	epoch   int
+}
+
+// beginTreeStream registers a new stream for the app, replacing the one
+// before it, and returns its sequence number
+func (m *Model) beginTreeStream(app model.App) int {
+	m.treeStreamSeq++
+	if m.treeStreams == nil {
+		m.treeStreams = make(map[string]*treeStream)
+	}
+	st := &treeStream{seq: m.treeStreamSeq, app: app, state: treeview.StreamReconnecting}
+	if prev := m.treeStreams[app.Name]; prev != nil {
+		st.attempts = prev.attempts
+	}
+	m.treeStreams[app.Name] = st
+	m.showTreeStreamState(st)
+	return st.seq
+}
+
+// currentTreeStream returns the app's stream if seq is still the current one
+func (m *Model) currentTreeStream(appName string, seq int) *treeStream {
+	st := m.treeStreams[appName]
+	if st == nil || st.seq != seq || m.state.Navigation.View != model.ViewTree {
+		return nil
+	}
+	return st
+}
+
+// showTreeStreamState puts the stream's state after the app's root row
+func (m *Model) showTreeStreamState(st *treeStream) {
+	if m.treeView == nil {
+		return
+	}
+	var text string
+	switch {
+	case st.state == treeview.StreamLive:
+		text = "● live"
+	case st.state == treeview.StreamDead:
+		text = "✖ not live, W to resubscribe"
+	case st.attempts == 0:
+		text = "connecting…"
+	default:
+		text = fmt.Sprintf("⟳ reconnecting (%d/%d)", st.attempts, treeStreamMaxAttempts)
+	}
+	m.treeView.SetStreamState(st.app.Name, st.state, text)
+}
+
+// handleTreeStreamStarted marks the stream live
+func (m *Model) handleTreeStreamStarted(msg treeWatchStartedMsg) {
+	st := m.currentTreeStream(msg.appName, msg.seq)
+	if st == nil {
+		return
+	}
+	st.state = treeview.StreamLive
+	st.err = ""
+	st.cleanup = msg.cleanup
+	m.showTreeStreamState(st)
+}
+
+// handleTreeStreamUpdate notes that the stream delivers. Only an update
+// resets the reconnect count: a server that accepts the stream and closes it
+// right away would otherwise be reconnected to forever.
+func (m *Model) handleTreeStreamUpdate(msg model.ResourceTreeStreamMsg) {
+	if st := m.currentTreeStream(msg.AppName, msg.Seq); st != nil {
+		st.attempts = 0
+		if st.state != treeview.StreamLive {
+			st.state = treeview.StreamLive
+			m.showTreeStreamState(st)
+		}
+	}
+}
+
+// handleTreeStreamEnded reconnects a stream that closed or could not be
+// opened, waiting longer after each failure, and gives up after
+// treeStreamMaxAttempts; W then starts over
+func (m *Model) handleTreeStreamEnded(appName string, seq int, reason string) tea.Cmd {
+	st := m.currentTreeStream(appName, seq)
+	if st == nil {
+		return nil
+	}
+	st.attempts++
+	st.err = reason
+	if st.attempts > treeStreamMaxAttempts {
+		st.state = treeview.StreamDead
+		m.showTreeStreamState(st)
+		text := fmt.Sprintf("Live updates of %s stopped", appName)
+		cblog.With("component", "tree").Warn(text, "reason", reason)
+		m.statusService.Error(text + ", press W to resubscribe")
+		m.recordError("tree", text, fmt.Sprintf("The resource tree stream failed %d times in a row: %s. The tree shows the last state received.", treeStreamMaxAttempts, reason), nil)
+		return nil
+	}
+	st.state = treeview.StreamReconnecting
+	m.showTreeStreamState(st)
+	delay := watchReconnectDelay << (st.attempts - 1)
+	if delay > treeStreamMaxDelay {
+		delay = treeStreamMaxDelay
+	}
+	cblog.With("component", "tree").Info("Tree stream ended, reconnecting", "app", appName, "attempt", st.attempts, "delay", delay, "reason", reason)
+	epoch := m.switchEpoch
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return treeResubscribeMsg{appName: appName, seq: seq, epoch: epoch}
+	})
+}
+
+// handleTreeResubscribe reopens a stream after the reconnect pause. The
+// tree is loaded again too, for the changes made while it was closed.
+func (m *Model) handleTreeResubscribe(msg treeResubscribeMsg) tea.Cmd {
+	st := m.currentTreeStream(msg.appName, msg.seq)
+	if st == nil || st.state != treeview.StreamReconnecting {
+		return nil
+	}
+	return tea.Batch(m.startLoadingResourceTree(st.app), m.startWatchingResourceTree(st.app))
+}
+
+// handleTreeResubscribeKey reopens the stream of the app under the cursor
+// right away, also when it was given up on or looks live but has gone quiet
+func (m *Model) handleTreeResubscribeKey() (tea.Model, tea.Cmd) {
+	if m.treeView == nil {
+		return m, nil
+	}
+	name := m.treeView.SelectedNodeApp()
+	st := m.treeStreams[name]
+	if st == nil || m.state.Server == nil || !m.config.IsStreamEnabled() {
+		return m, m.showStatusNote("No live stream for this tree; ctrl+r reloads it")
+	}
+	if st.cleanup != nil {
+		st.cleanup()
+	}
+	st.attempts = 0
+	cblog.With("component", "tree").Info("Resubscribing tree stream", "app", name)
+	m.statusService.Set("Resubscribing to " + name)
+	return m, tea.Batch(m.startLoadingResourceTree(st.app), m.startWatchingResourceTree(st.app))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/treeview"
+)
+
+// buildTreeStreamModel shows test-app's tree with its stream just opened
+func buildTreeStreamModel(t *testing.T) (*Model, int) {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	m := buildJobTreeModel(t, srv)
+	seq := m.beginTreeStream(m.state.Apps[0])
+	m.Update(treeWatchStartedMsg{appName: "test-app", seq: seq, cleanup: func() {}})
+	return m, seq
+}
+
+func TestTreeStream_ShowsLiveState(t *testing.T) {
+	m, _ := buildTreeStreamModel(t)
+	if m.treeView.StreamState("test-app") != treeview.StreamLive {
+		t.Fatalf("an opened stream should be live, got %v", m.treeView.StreamState("test-app"))
+	}
+	if out := stripANSI(m.treeView.Render()); !strings.Contains(out, "Application [test-app]") || !strings.Contains(out, "● live") {
+		t.Errorf("the app row should say the tree is live:\n%s", out)
+	}
+}
+
+func TestTreeStream_ReconnectsWhenItEnds(t *testing.T) {
+	m, seq := buildTreeStreamModel(t)
+
+	_, cmd := m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, Ended: true})
+	if cmd == nil || m.treeView.StreamState("test-app") != treeview.StreamReconnecting {
+		t.Fatal("an ended stream should be reconnected")
+	}
+	if out := stripANSI(m.treeView.Render()); !strings.Contains(out, "reconnecting (1/5)") {
+		t.Errorf("the app row should say it is reconnecting:\n%s", out)
+	}
+
+	// An update from the stream resets the count
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, TreeJSON: []byte(`{"nodes":[]}`)})
+	if st := m.treeStreams["test-app"]; st.attempts != 0 || st.state != treeview.StreamLive {
+		t.Errorf("an update should mark the stream live again, got %+v", st)
+	}
+
+	// Ends of a replaced stream are ignored
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq - 1, Ended: true})
+	if m.treeStreams["test-app"].attempts != 0 {
+		t.Error("the end of an old stream should not count")
+	}
+}
+
+func TestTreeStream_GivesUpAndResubscribesOnKey(t *testing.T) {
+	m, seq := buildTreeStreamModel(t)
+	for i := 0; i <= treeStreamMaxAttempts; i++ {
+		m.Update(treeWatchFailedMsg{appName: "test-app", seq: seq, err: errors.New("connection refused")})
+	}
+	if m.treeView.StreamState("test-app") != treeview.StreamDead {
+		t.Fatal("the stream should be given up on after too many failures")
+	}
+	if out := stripANSI(m.treeView.Render()); !strings.Contains(out, "not live, W to resubscribe") {
+		t.Errorf("the app row should say the tree is not live:\n%s", out)
+	}
+	if len(m.state.RecentErrors) != 1 || !strings.Contains(m.state.RecentErrors[0].Message, "Live updates of test-app stopped") {
+		t.Errorf("giving up should be recorded, got %+v", m.state.RecentErrors)
+	}
+
+	_, cmd := m.Update(keyPress("W"))
+	st := m.treeStreams["test-app"]
+	if cmd == nil || st.seq == seq || st.attempts != 0 || st.state != treeview.StreamReconnecting {
+		t.Errorf("W should open a new stream, got %+v", st)
+	}
+	if out := stripANSI(m.treeView.Render()); !strings.Contains(out, "connecting…") {
+		t.Errorf("the app row should say it is connecting:\n%s", out)
+	}
+}
+
+func TestTreeStream_StaleResubscribeIgnored(t *testing.T) {
+	m, seq := buildTreeStreamModel(t)
+	m.Update(model.ResourceTreeStreamMsg{AppName: "test-app", Seq: seq, Ended: true})
+	if _, cmd := m.Update(treeResubscribeMsg{appName: "test-app", seq: seq, epoch: m.switchEpoch + 1}); cmd != nil {
+		t.Error("a reconnect for another context should be dropped")
+	}
+	m.cleanupTreeWatchers()
+	if _, cmd := m.Update(treeResubscribeMsg{appName: "test-app", seq: seq, epoch: m.switchEpoch}); cmd != nil {
+		t.Error("a reconnect after the tree was closed should be dropped")
+	}
+}
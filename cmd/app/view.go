@@ -12,6 +12,8 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/darksworm/argonaut/pkg/model"
 	"github.com/darksworm/argonaut/pkg/sort"
+	"github.com/darksworm/argonaut/pkg/timefmt"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 )
 
 // Color mappings from TypeScript colorFor() function
@@ -268,6 +270,9 @@ const (
 // View implements tea.Model.View - 1:1 mapping from React App.tsx
 func (m *Model) View() tea.View {
 	m.renderCount++
+	if p := m.renderProfile; p != nil && p.recording {
+		defer p.measure(m.profileViewName())()
+	}
 
 	var content string
 	// Don't show plain "Starting..." - let renderMainLayout handle the loading modal
@@ -281,6 +286,8 @@ func (m *Model) View() tea.View {
 			content = m.renderMainLayout()
 		case model.ModeAuthRequired:
 			content = m.renderAuthRequiredView()
+		case model.ModeDeviceLogin:
+			content = m.renderDeviceLoginView()
 		case model.ModeHelp:
 			content = m.renderHelpModal()
 		case model.ModeRollback:
@@ -343,13 +350,14 @@ func (m *Model) renderFullScreenViewWithOptions(header, content, status string,
 	if opts.ContentBordered {
 		// Calculate available space for bordered content
 		// lipgloss Height() sets total visual height including borders
-		headerLines := countLines(header)
-		statusLines := countLines(status)
-		overhead := headerLines + statusLines
-		availableRows := max(1, m.state.Terminal.Rows-overhead)
+		availableRows := layout.VStack(m.state.Terminal.Rows,
+			layout.Fixed(regionHeader, countLines(header)),
+			layout.Fill(regionBody, 1),
+			layout.Fixed(regionStatus, countLines(status)),
+		).Height(regionBody)
 
 		// Apply bordered styling with custom color if specified
-		contentWidth := max(0, m.state.Terminal.Cols-2) // Adjusted to fill space properly
+		contentWidth := m.screenWidth()
 		borderStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(opts.BorderColor).
@@ -586,9 +594,10 @@ func (m *Model) getVisibleItems() []interface{} {
 		} else {
 			apps = m.state.Apps
 		}
+		apps = m.filterAppsByStatus(apps)
 		appsCopy := make([]model.App, len(apps))
 		copy(appsCopy, apps)
-		sort.Sort(appsCopy, m.state.UI.Sort)
+		sort.SortAt(appsCopy, m.state.UI.Sort, clockNow())
 		for _, app := range appsCopy {
 			base = append(base, app)
 		}
@@ -612,19 +621,10 @@ func (m *Model) getVisibleItems() []interface{} {
 
 	filtered := make([]interface{}, 0, len(base))
 	if m.state.Navigation.View == model.ViewApps {
+		// Terms with errors are skipped here; the search bar reports them
+		search, _ := parseAppSearch(filter)
 		for _, it := range base {
-			app := it.(model.App)
-			name := strings.ToLower(app.Name)
-			sync := strings.ToLower(app.Sync)
-			health := strings.ToLower(app.Health)
-			var ns, prj string
-			if app.Namespace != nil {
-				ns = strings.ToLower(*app.Namespace)
-			}
-			if app.Project != nil {
-				prj = strings.ToLower(*app.Project)
-			}
-			if strings.Contains(name, f) || strings.Contains(sync, f) || strings.Contains(health, f) || strings.Contains(ns, f) || strings.Contains(prj, f) {
+			if search.matches(it.(model.App)) {
 				filtered = append(filtered, it)
 			}
 		}
@@ -695,6 +695,8 @@ func (m *Model) renderAuthRequiredView() string {
 		contentSections = append(contentSections, statusStyle.Render("- "+instruction))
 	}
 	contentSections = append(contentSections, "")
+	contentSections = append(contentSections, statusStyle.Render("Or press d to sign in with SSO using a device code (works over SSH)."))
+	contentSections = append(contentSections, "")
 	if serverText != "—" {
 		contentSections = append(contentSections, statusStyle.Render("Current context: "+serverText))
 	}
@@ -703,7 +705,7 @@ func (m *Model) renderAuthRequiredView() string {
 	content := strings.Join(contentSections, "\n")
 
 	// Status
-	status := statusStyle.Render("Press l to view logs, q to quit.")
+	status := statusStyle.Render("Press d to sign in with SSO, l to view logs, q to quit.")
 
 	// Use the new layout helper with red border (matches AuthRequiredView borderColor="red")
 	return m.renderFullScreenViewWithOptions(header, content, status, FullScreenViewOptions{
@@ -724,8 +726,7 @@ func (m *Model) renderConfirmSyncModal() string {
 	isMulti := target == "__MULTI__"
 
 	// Modal width: compact and centered
-	half := m.state.Terminal.Cols / 2
-	modalWidth := min(max(36, half), m.state.Terminal.Cols-6)
+	modalWidth := m.modalWidth(36, 1, 2)
 	innerWidth := max(0, modalWidth-4) // border(2)+padding(2)
 
 	// Message: de-emphasize the "Sync" verb and highlight the subject
@@ -750,10 +751,10 @@ func (m *Model) renderConfirmSyncModal() string {
 	inactive := lipgloss.NewStyle().Background(inactiveBG).Foreground(inactiveFG).Padding(0, 2)
 	yesBtn := inactive.Render("Yes")
 	cancelBtn := inactive.Render("Cancel")
-	if m.state.Modals.ConfirmSyncSelected == 0 {
+	if m.state.Modals.ConfirmSyncSelected == 0 && m.buttonFocused() {
 		yesBtn = active.Render("Yes")
 	}
-	if m.state.Modals.ConfirmSyncSelected == 1 {
+	if m.state.Modals.ConfirmSyncSelected == 1 && m.buttonFocused() {
 		cancelBtn = active.Render("Cancel")
 	}
 
@@ -778,14 +779,14 @@ func (m *Model) renderConfirmSyncModal() string {
 	dim := lipgloss.NewStyle().Foreground(dimColor)
 	on := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
 	var optsLine strings.Builder
-	optsLine.WriteString(dim.Render("p: Prune "))
+	optsLine.WriteString(m.focusMarker(focusPrune) + dim.Render("p: Prune "))
 	if m.state.Modals.ConfirmSyncPrune {
 		optsLine.WriteString(on.Render("On"))
 	} else {
 		optsLine.WriteString(dim.Render("Off"))
 	}
 	// Always show watch toggle (single and multi)
-	optsLine.WriteString(dim.Render(" • w: Watch "))
+	optsLine.WriteString(dim.Render(" • ") + m.focusMarker(focusWatch) + dim.Render("w: Watch "))
 	if m.state.Modals.ConfirmSyncWatch {
 		optsLine.WriteString(on.Render("On"))
 	} else {
@@ -793,15 +794,89 @@ func (m *Model) renderConfirmSyncModal() string {
 	}
 	aux := center.Render(optsLine.String())
 
+	// Advanced prune options: propagation policy and prune ordering
+	policyOpt := m.focusMarker(focusPolicy) + dim.Render("o: Policy ") + dim.Render("default")
+	if policy := m.state.Modals.ConfirmSyncPrunePolicy; policy != "" {
+		policyOpt = m.focusMarker(focusPolicy) + dim.Render("o: Policy ") + on.Render(policy)
+	}
+	pruneLastOpt := m.focusMarker(focusPruneLast) + dim.Render("L: Prune last ") + dim.Render("Off")
+	if m.state.Modals.ConfirmSyncPruneLast {
+		pruneLastOpt = m.focusMarker(focusPruneLast) + dim.Render("L: Prune last ") + on.Render("On")
+	}
+	ssaOpt := m.focusMarker(focusServerSide) + dim.Render("a: Server-side ") + dim.Render("Off")
+	if m.state.Modals.ConfirmSyncServerSideApply {
+		ssaOpt = m.focusMarker(focusServerSide) + dim.Render("a: Server-side ") + on.Render("On")
+	}
+
 	// Lines are already centered to innerWidth; avoid re-normalizing which can
 	// introduce asymmetric trailing padding.
-	body := strings.Join([]string{title, "", buttons, "", aux}, "\n")
+	bodyLines := []string{title, "", buttons, "", aux}
+	// Pack the advanced options into as few lines as fit instead of letting them wrap mid-word
+	for _, line := range packOptionSegments([]string{policyOpt, pruneLastOpt, ssaOpt}, dim.Render(" • "), innerWidth) {
+		bodyLines = append(bodyLines, center.Render(line))
+	}
+	if preview := m.renderPrunePreview(innerWidth); len(preview) > 0 {
+		// Left-aligned block so resource names line up, centered as a whole
+		block := lipgloss.NewStyle().Align(lipgloss.Left).Render(strings.Join(preview, "\n"))
+		bodyLines = append(bodyLines, "", center.Render(block))
+	}
+	if profile := m.state.Modals.ConfirmSyncProfile; profile != "" {
+		bodyLines = append(bodyLines, center.Render(dim.Render(truncateWithEllipsis("Defaults from sync profile: "+profile, innerWidth))))
+	}
+	if sources := m.confirmSyncSources(); len(sources) > 0 {
+		bodyLines = append(bodyLines, "", m.renderSyncSourcePicker(sources, innerWidth))
+	}
+	body := strings.Join(bodyLines, "\n")
 
 	// Add outer whitespace so the modal doesn't sit directly on top of content
 	outer := lipgloss.NewStyle().Padding(1, 1) // 1 blank line top/bottom, 1 space left/right
 	return outer.Render(wrapper.Render(body))
 }
 
+// packOptionSegments joins styled option segments with sep, starting a new
+// line whenever the next segment would not fit in width
+func packOptionSegments(segments []string, sep string, width int) []string {
+	var lines []string
+	current := ""
+	for _, seg := range segments {
+		if current == "" {
+			current = seg
+			continue
+		}
+		if lipgloss.Width(current)+lipgloss.Width(sep)+lipgloss.Width(seg) > width {
+			lines = append(lines, current)
+			current = seg
+			continue
+		}
+		current += sep + seg
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// renderSyncSourcePicker lists "all sources" plus each source of a multi-source
+// app, marking the one the sync will target
+func (m *Model) renderSyncSourcePicker(sources []model.AppSource, width int) string {
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	selected := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
+	lines := []string{dim.Render("j/k: Source")}
+	for i := 0; i <= len(sources); i++ {
+		label := "All sources"
+		if i > 0 {
+			label = fmt.Sprintf("%d. %s", i, appSourceLabel(sources[i-1]))
+		}
+		label = truncateWithEllipsis(label, max(1, width-2))
+		if i == m.state.Modals.ConfirmSyncSource {
+			lines = append(lines, selected.Render("► "+label))
+		} else {
+			lines = append(lines, "  "+label)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderDiffView - simple pager for diff content
 func (m *Model) renderDiffView() string {
 	if m.state.Diff == nil {
@@ -819,17 +894,7 @@ func (m *Model) renderDiffView() string {
 		lines = filtered
 	}
 
-	// Compute viewport height: account for all UI elements like main layout does
-	// The diff view structure: title + bordered_content + status
-	// contentBorderStyle adds 2 lines (top+bottom border), no vertical padding
-	const (
-		TITLE_LINES            = 1 // diff title line
-		STATUS_LINES           = 1 // diff status line
-		BORDER_LINES           = 2 // contentBorderStyle border top+bottom
-		MAIN_CONTAINER_PADDING = 1 // main container has some margin
-	)
-	overhead := TITLE_LINES + STATUS_LINES + BORDER_LINES + MAIN_CONTAINER_PADDING
-	contentHeight := max(3, m.state.Terminal.Rows-overhead)
+	contentHeight := m.diffViewportHeight()
 
 	// Clamp offset - the content area height should be used for pagination
 	if m.state.Diff.Offset < 0 {
@@ -842,14 +907,12 @@ func (m *Model) renderDiffView() string {
 	end := min(len(lines), start+contentHeight)
 	body := strings.Join(lines[start:end], "\n")
 
-	title := headerStyle.Render(m.state.Diff.Title)
-	status := statusStyle.Render(fmt.Sprintf("%d-%d/%d  j/k, g/G, / search, esc/q back", start+1, end, len(lines)))
-
-	// Width should account for main container padding (2) and content border padding (2)
-	contentWidth := max(0, m.state.Terminal.Cols-4)
+	// Long lines would wrap and push the pager past the bottom of the screen
+	title := headerStyle.Render(truncateWithEllipsis(m.state.Diff.Title, m.screenWidth()))
+	status := statusStyle.Render(truncateWithEllipsis(fmt.Sprintf("%d-%d/%d  j/k, g/G, / search, esc/q back", start+1, end, len(lines)), m.screenWidth()))
 
 	// Don't set a fixed height on the content border - let it size naturally
-	content := contentBorderStyle.Width(contentWidth).Render(body)
+	content := contentBorderStyle.Width(m.screenWidth()).Render(body)
 
 	// Build sections ensuring header and status are always visible
 	// Don't use fixed height container which can clip the header
@@ -1024,7 +1087,7 @@ func (m *Model) renderErrorView() string {
 
 		// Timestamp
 		timeStyle := lipgloss.NewStyle().Foreground(unknownColor)
-		errorContent += fmt.Sprintf("\nTime: %s\n", timeStyle.Render(err.Timestamp.Format("2006-01-02 15:04:05")))
+		errorContent += fmt.Sprintf("\nTime: %s\n", timeStyle.Render(timefmt.StampSeconds(err.Timestamp)))
 
 	} else if m.state.CurrentError != nil {
 		// Fallback to legacy error structure
@@ -1058,7 +1121,7 @@ func (m *Model) renderErrorView() string {
 
 		// Timestamp
 		timeStyle := lipgloss.NewStyle().Foreground(unknownColor)
-		timeStr := time.Unix(err.Timestamp, 0).Format("2006-01-02 15:04:05")
+		timeStr := timefmt.StampSeconds(time.Unix(err.Timestamp, 0))
 		errorContent += fmt.Sprintf("\nTime: %s\n", timeStyle.Render(timeStr))
 	} else {
 		// Fallback error message
@@ -1088,6 +1151,13 @@ func (m *Model) renderConnectionErrorView() string {
 	// Header
 	header := m.renderBanner()
 
+	if m.tlsFailure != "" {
+		return m.renderFullScreenViewWithOptions(header, m.renderCertificateErrorContent(), "", FullScreenViewOptions{
+			ContentBordered: true,
+			BorderColor:     outOfSyncColor,
+		})
+	}
+
 	// Build connection error content
 	errorContent := ""
 
@@ -1188,44 +1258,42 @@ func (m *Model) renderRollbackHistory(rollback *model.RollbackState) string {
 		content += currentStyle.Render(fmt.Sprintf("Current: %s", rollback.CurrentRevision[:min(8, len(rollback.CurrentRevision))])) + "\n\n"
 	}
 
-	// Show deployment history table
-	content += "Deployment History:\n\n"
+	// Compute how many rows we can show to avoid overflowing the modal
+	rowsViewport := m.rollbackHistoryRows(rollback)
 
-	// Compute how many rows we can show to avoid overflowing the modal.
-	// This mirrors the height math used in renderRollbackModal.
-	header := m.renderBanner()
-	headerLines := countLines(header)
-	const BORDER_LINES = 2
-	const STATUS_LINES = 1
-	const MARGIN_TOP_LINES = 1 // blank line between header and box
-	availableRows := max(0, m.state.Terminal.Rows-(BORDER_LINES+headerLines+STATUS_LINES+MARGIN_TOP_LINES))
-
-	// Inside the modal we render the following fixed lines when in list mode:
-	// 2 (title + blank) + optional 2 for current revision + 2 (section header + blank)
-	// + 2 (blank + options) added below in this function
-	// + 3 (two blanks + instructions) appended by renderRollbackModal.
-	fixedTop := 2
-	if rollback.CurrentRevision != "" {
-		fixedTop += 2
-	}
-	fixedBottom := 2 + 3
-	rowsViewport := max(1, availableRows-fixedTop-fixedBottom)
+	// Window the rows the filter keeps around the selection
+	visible, start, end := rollbackWindow(rollback, rowsViewport)
+	total := len(visible)
 
-	// Window the rows around the selection
-	total := len(rollback.Rows)
-	start := max(0, min(rollback.SelectedIdx-rowsViewport/2, total-rowsViewport))
-	end := min(start+rowsViewport, total)
+	// Show deployment history table, with where the window is in it
+	dimStyle := lipgloss.NewStyle().Foreground(dimColor)
+	heading := "Deployment History:"
+	switch {
+	case total == 0:
+		heading += dimStyle.Render(fmt.Sprintf(" no matches among %d", len(rollback.Rows)))
+	case rollback.Filter != "":
+		heading += dimStyle.Render(fmt.Sprintf(" %d–%d of %d matches (%d total)", start+1, end, total, len(rollback.Rows)))
+	default:
+		heading += dimStyle.Render(fmt.Sprintf(" %d–%d of %d", start+1, end, total))
+	}
+	if rollback.Filtering || rollback.Filter != "" {
+		filter := "/" + rollback.Filter
+		if rollback.Filtering {
+			filter += "█"
+		}
+		heading += "  " + lipgloss.NewStyle().Foreground(yellowBright).Render(filter)
+	}
+	content += heading + "\n\n"
 
 	// Indicators for clipped content
 	if start > 0 {
-		content += lipgloss.NewStyle().Foreground(dimColor).Render("… older entries above …") + "\n"
+		content += dimStyle.Render(fmt.Sprintf("… %d older entries above …", start)) + "\n"
 	}
 
 	// Calculate the maximum line width inside the modal so rows never wrap
-	containerWidth := max(0, m.state.Terminal.Cols-2)
-	rowMaxWidth := max(0, containerWidth-4) // inner width (2 border + 2 padding)
+	rowMaxWidth := layout.Card.InnerWidth(m.screenWidth())
 
-	for i := start; i < end; i++ {
+	for _, i := range visible[start:end] {
 		row := rollback.Rows[i]
 		var line string
 
@@ -1235,10 +1303,16 @@ func (m *Model) renderRollbackHistory(rollback *model.RollbackState) string {
 		line += fmt.Sprintf("%s %s",
 			idStyle.Render(fmt.Sprintf("#%d", row.ID)),
 			revisionStyle.Render(row.Revision[:min(8, len(row.Revision))]))
+		if len(row.Revisions) > 1 {
+			line += lipgloss.NewStyle().Foreground(dimColor).Render(fmt.Sprintf(" +%d sources", len(row.Revisions)-1))
+		}
 
 		if row.DeployedAt != nil {
 			dateStyle := lipgloss.NewStyle().Foreground(unknownColor)
-			line += " " + dateStyle.Render(row.DeployedAt.Format("2006-01-02 15:04"))
+			line += " " + dateStyle.Render(timefmt.Stamp(*row.DeployedAt))
+		}
+		if row.InitiatedBy != nil {
+			line += lipgloss.NewStyle().Foreground(dimColor).Render(" by " + row.InitiatedBy.String())
 		}
 
 		if row.Author != nil && row.Message != nil {
@@ -1272,7 +1346,7 @@ func (m *Model) renderRollbackHistory(rollback *model.RollbackState) string {
 	}
 
 	if end < total {
-		content += lipgloss.NewStyle().Foreground(dimColor).Render("… newer entries below …") + "\n"
+		content += dimStyle.Render(fmt.Sprintf("… %d newer entries below …", total-end)) + "\n"
 	}
 
 	// No options in list view; options are configured in confirmation view
@@ -1301,13 +1375,23 @@ func (m *Model) renderRollbackConfirmation(rollback *model.RollbackState, innerH
 	// Target revision
 	targetStyle := lipgloss.NewStyle().Foreground(yellowBright)
 	content += fmt.Sprintf("Rollback to: %s\n", targetStyle.Render(selectedRow.Revision[:min(8, len(selectedRow.Revision))]))
+	if len(selectedRow.Revisions) > 1 {
+		short := make([]string, 0, len(selectedRow.Revisions))
+		for _, rev := range selectedRow.Revisions {
+			short = append(short, rev[:min(8, len(rev))])
+		}
+		content += fmt.Sprintf("Source revisions: %s\n", strings.Join(short, ", "))
+	}
+	if selectedRow.InitiatedBy != nil {
+		content += fmt.Sprintf("Deployed by: %s\n", selectedRow.InitiatedBy.String())
+	}
 
 	// Git metadata if available
 	if selectedRow.Author != nil && selectedRow.Message != nil {
 		content += fmt.Sprintf("Author: %s\n", *selectedRow.Author)
 		content += fmt.Sprintf("Message: %s\n", *selectedRow.Message)
 		if selectedRow.Date != nil {
-			content += fmt.Sprintf("Date: %s\n", selectedRow.Date.Format("2006-01-02 15:04:05"))
+			content += fmt.Sprintf("Date: %s\n", timefmt.StampSeconds(*selectedRow.Date))
 		}
 	}
 
@@ -1319,13 +1403,13 @@ func (m *Model) renderRollbackConfirmation(rollback *model.RollbackState, innerH
 	dim := lipgloss.NewStyle().Foreground(dimColor)
 	on := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
 	var opts strings.Builder
-	opts.WriteString(dim.Render("[p] Prune: "))
+	opts.WriteString(m.focusMarker(focusPrune) + dim.Render("[p] Prune: "))
 	if rollback.Prune {
 		opts.WriteString(on.Render("Yes"))
 	} else {
 		opts.WriteString(dim.Render("No"))
 	}
-	opts.WriteString(dim.Render("   [w] Watch: "))
+	opts.WriteString(dim.Render("   ") + m.focusMarker(focusWatch) + dim.Render("[w] Watch: "))
 	if rollback.Watch {
 		opts.WriteString(on.Render("Yes"))
 	} else {
@@ -1337,10 +1421,10 @@ func (m *Model) renderRollbackConfirmation(rollback *model.RollbackState, innerH
 	inactive := lipgloss.NewStyle().Background(inactiveBG).Foreground(inactiveFG).Padding(0, 2)
 	yesBtn := inactive.Render("Yes")
 	noBtn := inactive.Render("No")
-	if rollback.ConfirmSelected == 0 {
+	if rollback.ConfirmSelected == 0 && m.buttonFocused() {
 		yesBtn = active.Render("Yes")
 	}
-	if rollback.ConfirmSelected == 1 {
+	if rollback.ConfirmSelected == 1 && m.buttonFocused() {
 		noBtn = active.Render("No")
 	}
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, yesBtn, strings.Repeat(" ", 4), noBtn)
@@ -6,10 +6,28 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 )
 
+// renderBanner renders the header, preceded by any maintenance notices
 func (m *Model) renderBanner() string {
+	header := m.renderHeader()
+	if notice := m.renderMaintenanceBanner(); notice != "" {
+		return notice + "\n" + header
+	}
+	return header
+}
+
+// renderHeader renders the context block and logo, sized to the terminal
+func (m *Model) renderHeader() string {
+	switch m.config.GetBannerMode() {
+	case config.BannerHidden:
+		return ""
+	case config.BannerCompact:
+		return m.renderCompactBanner()
+	}
+
 	// If the terminal is short, collapse the header into 1–2 lines
 	if m.state.Terminal.Rows <= 22 {
 		return m.renderCompactBanner()
@@ -28,7 +46,7 @@ func (m *Model) renderBanner() string {
 				rest = strings.Join(ctxLines[1:], "\n")
 			}
 		}
-		total := max(0, m.state.Terminal.Cols-2)
+		total := m.screenWidth()
 		// Width-based decision to show app version in badge
 		withVersion := m.state.Terminal.Cols >= 72
 		top := joinWithRightAlignment(first, m.renderSmallBadge(false, withVersion)+" ", total)
@@ -48,7 +66,7 @@ func (m *Model) renderBanner() string {
 	if rightLines < leftLines {
 		right = strings.Repeat("\n", leftLines-rightLines) + right
 	}
-	total := max(0, m.state.Terminal.Cols-2)
+	total := m.screenWidth()
 	return joinWithRightAlignment(left, right, total)
 }
 
@@ -77,7 +95,7 @@ func (m *Model) renderSmallBadge(grayscale bool, withVersion bool) string {
 // hidden; then the breadcrumb drops context, then cluster, then namespace
 // until it fits.
 func (m *Model) renderCompactBanner() string {
-	total := max(0, m.state.Terminal.Cols-2)
+	total := m.screenWidth()
 
 	host := "—"
 	if m.currentContextName != "" {
@@ -88,6 +106,9 @@ func (m *Model) renderCompactBanner() string {
 	cls := scopeToText(m.state.Selections.ScopeClusters)
 	ns, pr := m.effectiveNamespaceProjectScope()
 
+	if m.insecureOverride {
+		host += " " + lipgloss.NewStyle().Foreground(outOfSyncColor).Render("⚠ TLS not verified")
+	}
 	parts := []string{host, cls, ns, pr}
 	// Build breadcrumb tokens with dim separators
 	sep := " " + lipgloss.NewStyle().Foreground(dimColor).Render(">") + " "
@@ -199,8 +220,24 @@ func (m *Model) renderContextBlock(isNarrow bool) string {
 	if projectScope != "—" {
 		lines = append(lines, fmt.Sprintf("%s %s", label.Render("Project:"), projectScope))
 	}
+	if scope, ok := m.tokenScope(); ok {
+		lines = append(lines, fmt.Sprintf("%s %s", label.Render("Token:"), cyan.Render(scope.String())))
+	}
+	if m.insecureOverride {
+		lines = append(lines, fmt.Sprintf("%s %s", label.Render("TLS:"), lipgloss.NewStyle().Foreground(outOfSyncColor).Bold(true).Render("not verified ⚠")))
+	}
+	if config.SafeMode() {
+		lines = append(lines, fmt.Sprintf("%s %s", label.Render("Config:"), lipgloss.NewStyle().Foreground(yellowBright).Render("safe mode, defaults")))
+	}
 	if !isNarrow && m.state.APIVersion != "" {
-		lines = append(lines, fmt.Sprintf("%s %s", label.Render("ArgoCD:"), green.Render(m.state.APIVersion)))
+		version := green.Render(m.state.APIVersion)
+		if m.serverCompat != nil && m.serverCompat.Problems() {
+			version = lipgloss.NewStyle().Foreground(yellowBright).Render(m.state.APIVersion + " ⚠")
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", label.Render("ArgoCD:"), version))
+	}
+	if !isNarrow && m.profileName != "" {
+		lines = append(lines, fmt.Sprintf("%s %s", label.Render("Profile:"), cyan.Render(m.profileName)))
 	}
 	block := strings.Join(lines, "\n")
 	return lipgloss.NewStyle().PaddingRight(2).Render(block)
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 )
 
@@ -58,3 +59,33 @@ func TestRenderContextBlock_ScopeOverridesTreeAppFallback(t *testing.T) {
 		t.Fatalf("did not expect app project fallback when explicit scope exists, got:\n%s", out)
 	}
 }
+
+func TestRenderHeader_BannerModes(t *testing.T) {
+	newModel := func(banner string) *Model {
+		m := NewModel(nil)
+		m.ready = true
+		m.state.Server = &model.Server{BaseURL: "https://argo.example.com"}
+		m.state.Terminal.Cols = 160
+		m.state.Terminal.Rows = 50
+		m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{Banner: banner}}
+		return m
+	}
+
+	full := newModel("").renderHeader()
+	compact := newModel(config.BannerCompact).renderHeader()
+	if countLines(compact) > 2 || countLines(compact) >= countLines(full) {
+		t.Fatalf("compact banner should be 1-2 lines and shorter than full (%d), got %d:\n%s",
+			countLines(full), countLines(compact), stripANSI(compact))
+	}
+	if !strings.Contains(stripANSI(compact), "argo.example.com") {
+		t.Fatalf("compact banner should still show the server, got:\n%s", stripANSI(compact))
+	}
+
+	hidden := newModel(config.BannerHidden)
+	if got := hidden.renderHeader(); got != "" {
+		t.Fatalf("hidden banner should render nothing, got:\n%s", stripANSI(got))
+	}
+	if got := countLines(hidden.renderBanner()); got != 0 {
+		t.Fatalf("hidden banner should take no lines, got %d", got)
+	}
+}
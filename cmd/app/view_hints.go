@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/compat"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// keyHint is one key and what it does, as shown in the hint bar
+type keyHint struct {
+	key   string
+	label string
+}
+
+// keyHintsEnabled reports whether the hint bar is switched on in config
+func (m *Model) keyHintsEnabled() bool {
+	return m.config != nil && m.config.Appearance.KeyHints
+}
+
+// keyHintLines is the number of rows the hint bar takes in the main layout
+func (m *Model) keyHintLines() int {
+	if m.keyHintsEnabled() {
+		return 1
+	}
+	return 0
+}
+
+// currentKeyHints returns the most relevant keys for the current mode and view,
+// most important first so the bar can drop trailing hints when narrow
+func (m *Model) currentKeyHints() []keyHint {
+	switch m.state.Mode {
+	case model.ModeSearch:
+		return []keyHint{{"enter", "apply"}, {"esc", "cancel"}, {"↑/↓", "move"}}
+	case model.ModeCommand:
+		return []keyHint{{"tab", "complete"}, {"enter", "run"}, {"esc", "cancel"}, {"↑/↓", "pick match"}, {":help views", "views"}}
+	}
+
+	general := []keyHint{{"/", "search"}, {":", "command"}, {"?", "help"}}
+	switch m.state.Navigation.View {
+	case model.ViewApps:
+		return append([]keyHint{
+			{"s", "sync"}, {"d", "diff"}, {"r", "resources"}, {"R", "rollback"},
+			{"i", "details"}, {"space", "select"}, {"K", "k9s"},
+		}, general...)
+	case model.ViewTree:
+		hints := []keyHint{{"/", "filter"}, {"n/N", "next/prev"}, {"d", "diff"}, {"s", "sync"}}
+		if m.unavailable(compat.ResourceActions) == "" {
+			hints = append(hints, keyHint{"a", "actions"})
+		}
+		hints = append(hints, keyHint{"K", "k9s"}, keyHint{"esc", "back"})
+		return append(hints, general[1:]...)
+	default:
+		return append([]keyHint{
+			{"enter", "drill down"}, {"space", "select"}, {"esc", "up"},
+		}, general...)
+	}
+}
+
+// renderKeyHintBar renders the hint bar as a single line fitted to the
+// main container width, dropping hints that do not fit
+func (m *Model) renderKeyHintBar() string {
+	available := m.screenWidth()
+	keyStyle := lipgloss.NewStyle().Foreground(cyanBright)
+	sep := statusStyle.Render(" • ")
+
+	var parts []string
+	width := 0
+	for _, h := range m.currentKeyHints() {
+		part := keyStyle.Render(h.key) + " " + statusStyle.Render(h.label)
+		partWidth := lipgloss.Width(part)
+		if len(parts) > 0 {
+			partWidth += lipgloss.Width(sep)
+		}
+		if width+partWidth > available {
+			break
+		}
+		parts = append(parts, part)
+		width += partWidth
+	}
+	return padRight(strings.Join(parts, sep), available)
+}
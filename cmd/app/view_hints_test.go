@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestKeyHintBar_DisabledByDefault(t *testing.T) {
+	m := buildTestModelWithApps(100, 24)
+	m.config = config.GetDefaultConfig()
+
+	if m.keyHintsEnabled() || m.keyHintLines() != 0 {
+		t.Fatal("hint bar should be off unless enabled in config")
+	}
+	if strings.Contains(stripANSI(m.renderMainLayout()), "rollback") {
+		t.Error("main layout should not show key hints when disabled")
+	}
+}
+
+func TestKeyHintBar_FollowsViewAndMode(t *testing.T) {
+	m := buildTestModelWithApps(120, 24)
+	m.config = config.GetDefaultConfig()
+	m.config.Appearance.KeyHints = true
+
+	layout := stripANSI(m.renderMainLayout())
+	if !strings.Contains(layout, "s sync") || !strings.Contains(layout, "R rollback") {
+		t.Errorf("apps view should hint at app actions, got:\n%s", layout)
+	}
+	if got := strings.Count(layout, "\n") + 1; got != m.state.Terminal.Rows {
+		t.Errorf("layout should still fill %d rows, got %d", m.state.Terminal.Rows, got)
+	}
+
+	m.state.Navigation.View = model.ViewClusters
+	if bar := stripANSI(m.renderKeyHintBar()); !strings.Contains(bar, "enter drill down") {
+		t.Errorf("list views should hint at drilling down, got %q", bar)
+	}
+
+	m.state.Mode = model.ModeSearch
+	if bar := stripANSI(m.renderKeyHintBar()); !strings.Contains(bar, "esc cancel") {
+		t.Errorf("search mode should hint at cancelling, got %q", bar)
+	}
+}
+
+func TestKeyHintBar_DropsHintsThatDoNotFit(t *testing.T) {
+	m := buildTestModelWithApps(30, 24)
+	m.config = config.GetDefaultConfig()
+	m.config.Appearance.KeyHints = true
+
+	bar := stripANSI(m.renderKeyHintBar())
+	if w := len([]rune(bar)); w != 28 {
+		t.Errorf("bar should fill the container width exactly, got %d", w)
+	}
+	if strings.Contains(bar, "help") || strings.HasSuffix(strings.TrimSpace(bar), "•") {
+		t.Errorf("trailing hints should be dropped whole, got %q", bar)
+	}
+}
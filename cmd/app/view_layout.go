@@ -6,6 +6,7 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 )
 
 // moved: full-screen helpers remain in view.go
@@ -65,6 +66,51 @@ func (m *Model) activeOverlay() *overlaySpec {
 		}
 		return &overlaySpec{modal: modal, desaturate: true}
 	}
+	if m.state.Mode == model.ModeDiffOutline {
+		return &overlaySpec{modal: m.renderDiffOutlineModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeAppDetails {
+		return &overlaySpec{modal: m.renderAppDetailsModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeHooks {
+		return &overlaySpec{modal: m.renderHooksModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeErrors {
+		return &overlaySpec{modal: m.renderErrorsModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeStream {
+		return &overlaySpec{modal: m.renderStreamModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeProfile {
+		return &overlaySpec{modal: m.renderProfileModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeDiffSummary {
+		return &overlaySpec{modal: m.renderDiffSummaryModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeHistory {
+		return &overlaySpec{modal: m.renderHistoryModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeWait {
+		return &overlaySpec{modal: m.renderWaitModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeManifest {
+		return &overlaySpec{modal: m.renderManifestModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeMap {
+		return &overlaySpec{modal: m.renderMapModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeBulkRefresh {
+		return &overlaySpec{modal: m.renderBulkRefreshModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeStaging {
+		return &overlaySpec{modal: m.renderStagingModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeTermCheck {
+		return &overlaySpec{modal: m.renderTermCheckModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeOperationConflict {
+		return &overlaySpec{modal: m.renderOperationConflictModal(), desaturate: true}
+	}
 	if m.state.Mode == model.ModeNoDiff {
 		return &overlaySpec{modal: m.renderNoDiffModal(), desaturate: true}
 	}
@@ -74,6 +120,15 @@ func (m *Model) activeOverlay() *overlaySpec {
 	if m.state.Mode == model.ModeDefaultViewWarning {
 		return &overlaySpec{modal: m.renderDefaultViewWarningModal(), desaturate: true}
 	}
+	if m.state.Mode == model.ModeConfirmQuit {
+		return &overlaySpec{modal: m.renderConfirmQuitModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeCompatWarning {
+		return &overlaySpec{modal: m.renderCompatWarningModal(), desaturate: true}
+	}
+	if m.state.Mode == model.ModeDeviceLogin {
+		return &overlaySpec{modal: m.renderDeviceLoginModal(), desaturate: true}
+	}
 	if m.state.Mode == model.ModeConfirmAppDelete {
 		modal := m.renderAppDeleteConfirmModal()
 		if m.state.Modals.DeleteLoading {
@@ -110,7 +165,7 @@ func (m *Model) activeOverlay() *overlaySpec {
 		}
 		return &overlaySpec{modal: modal, desaturate: true}
 	}
-	if m.state.Mode == model.ModeLoading && m.state.Navigation.View != model.ViewContexts {
+	if m.state.Mode == model.ModeLoading && m.state.Navigation.View != model.ViewContexts && !m.appsFromCache {
 		spec := &overlaySpec{modal: m.renderInitialLoadingModal(), desaturate: true}
 		// Diff loading badge in the top-left corner, layered below the
 		// loading modal but above the desaturated base.
@@ -139,11 +194,11 @@ func (m *Model) activeOverlay() *overlaySpec {
 // composition.
 func (m *Model) willDesaturateBase() bool {
 	ov := m.activeOverlay()
-	return ov != nil && ov.desaturate
+	return ov != nil && ov.desaturate && !m.reducedMotion()
 }
 
 // renderTreePanel renders the resource tree view inside a bordered container with scrolling
-func (m *Model) renderTreePanel(availableRows int) string {
+func (m *Model) renderTreePanel(height int) string {
 	contentWidth := max(0, m.contentInnerWidth())
 	treeContent := "(no data)"
 	if m.treeView != nil {
@@ -155,7 +210,7 @@ func (m *Model) renderTreePanel(availableRows int) string {
 	totalLines := len(lines)
 
 	// Calculate viewport
-	viewportHeight := availableRows
+	viewportHeight := max(1, layout.Card.InnerHeight(height))
 	cursorIdx := 0
 	if m.treeView != nil {
 		// Account for blank separator lines inserted between app roots
@@ -216,51 +271,31 @@ func (m *Model) renderTreePanel(availableRows int) string {
 		_ = scrollInfo
 	}
 
-	adjustedWidth := max(0, m.state.Terminal.Cols-2)
-	return contentBorderStyle.Width(adjustedWidth).Height(availableRows + 1).AlignVertical(lipgloss.Top).Render(visibleContent)
+	return contentBorderStyle.Width(m.screenWidth()).Height(height).AlignVertical(lipgloss.Top).Render(visibleContent)
 }
 
 // contentInnerWidth computes inner content width inside the bordered box
 func (m *Model) contentInnerWidth() int {
-	return max(0, m.state.Terminal.Cols-6)
+	return layout.Card.InnerWidth(m.primaryPaneWidth())
 }
 
 // Main layout
 func (m *Model) renderMainLayout() string {
-	const (
-		BORDER_LINES       = 2
-		TABLE_HEADER_LINES = 0
-		TAG_LINE           = 0
-		STATUS_LINES       = 1
-	)
-	header := m.renderBanner()
-	searchBar := ""
-	if m.state.Mode == model.ModeSearch {
-		searchBar = m.renderEnhancedSearchBar()
-	}
-	commandBar := ""
-	if m.state.Mode == model.ModeCommand {
-		commandBar = m.renderEnhancedCommandBar()
-	}
-	headerLines := countLines(header)
-	searchLines := countLines(searchBar)
-	commandLines := countLines(commandBar)
-	overhead := BORDER_LINES + headerLines + searchLines + commandLines + TABLE_HEADER_LINES + TAG_LINE + STATUS_LINES
-	availableRows := max(0, m.state.Terminal.Rows-overhead)
-	listRows := max(0, availableRows)
+	screen := m.layoutMainScreen()
+	bodyRows := screen.stack.Height(regionBody)
 
 	var sections []string
-	sections = append(sections, header)
-	// Add a subtle vertical gap only in wide layout. The narrow banner
-	// already includes spacing, so avoid doubling it.
-	if m.state.Terminal.Cols > 100 {
+	if screen.header != "" {
+		sections = append(sections, screen.header)
+	}
+	if screen.gap {
 		sections = append(sections, "")
 	}
-	if searchBar != "" {
-		sections = append(sections, searchBar)
+	if screen.searchBar != "" {
+		sections = append(sections, screen.searchBar)
 	}
-	if commandBar != "" {
-		sections = append(sections, commandBar)
+	if screen.commandBar != "" {
+		sections = append(sections, screen.commandBar)
 	}
 
 	// Set desaturate mode on tree view if a modal with desaturation will be shown
@@ -270,29 +305,41 @@ func (m *Model) renderMainLayout() string {
 	}
 
 	if m.state.Navigation.View == model.ViewTree {
-		sections = append(sections, m.renderTreePanel(listRows))
+		sections = append(sections, m.renderTreePanel(bodyRows))
+	} else if m.splitPaneVisible() {
+		list := m.renderListView(bodyRows)
+		pane := m.renderDetailsPane(m.screenWidth()-m.primaryPaneWidth(), bodyRows)
+		sections = append(sections, lipgloss.JoinHorizontal(lipgloss.Top, list, pane))
 	} else {
-		sections = append(sections, m.renderListView(listRows))
+		sections = append(sections, m.renderListView(bodyRows))
+	}
+	if m.keyHintsEnabled() {
+		sections = append(sections, m.renderKeyHintBar())
 	}
 	sections = append(sections, m.renderStatusLine())
 
-	content := strings.Join(sections, "\n")
-	baseView := mainContainerStyle.Render(content)
+	// Too small a terminal overflows the stack; clip rather than scroll
+	content := clipAnsiToLines(strings.Join(sections, "\n"), m.state.Terminal.Rows)
+	return m.renderWithOverlay(mainContainerStyle.Render(content))
+}
 
+// renderWithOverlay draws the active overlay, if any, centered over
+// baseView
+func (m *Model) renderWithOverlay(baseView string) string {
 	ov := m.activeOverlay()
 	if ov == nil {
 		return baseView
 	}
 
 	base := baseView
-	if ov.desaturate {
+	if ov.desaturate && !m.reducedMotion() {
 		base = desaturateANSI(baseView)
 	}
 	layers := []*lipgloss.Layer{lipgloss.NewLayer(base)}
 	layers = append(layers, ov.extraLayers...)
 
-	modalX := (m.state.Terminal.Cols - lipgloss.Width(ov.modal)) / 2
-	modalY := (m.state.Terminal.Rows - lipgloss.Height(ov.modal)) / 2
+	modalX := max(0, (m.state.Terminal.Cols-lipgloss.Width(ov.modal))/2)
+	modalY := max(0, (m.state.Terminal.Rows-lipgloss.Height(ov.modal))/2)
 	// Modal sits above any extra layers (badges, etc.) the spec carries.
 	modalZ := 1
 	if len(ov.extraLayers) > 0 {
@@ -5,16 +5,18 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/kubeapi"
 	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 )
 
 // renderListView - custom list/table rendering with fixed inner width
-func (m *Model) renderListView(availableRows int) string {
+func (m *Model) renderListView(height int) string {
 	visibleItems := m.getVisibleItems()
 
 	contentWidth := max(0, m.contentInnerWidth())
 	// Leave room for the table header row inside the bordered area
-	tableHeight := max(3, availableRows-1)
+	tableHeight := max(2, layout.Card.InnerHeight(height))
 
 	// Prepare data and update the appropriate table directly
 	var tableView string
@@ -119,8 +121,8 @@ func (m *Model) renderListView(availableRows int) string {
 	if tableView == "" {
 		// Empty state: use fixed height to fill available space like other views
 		// Adjust width to properly fill horizontal space
-		adjustedWidth := max(0, m.state.Terminal.Cols-2) // Expand width to fill space
-		return contentBorderStyle.Width(adjustedWidth).Height(availableRows + 1).AlignVertical(lipgloss.Center).Render(content.String())
+		adjustedWidth := m.primaryPaneWidth() // Expand width to fill space
+		return contentBorderStyle.Width(adjustedWidth).Height(height).AlignVertical(lipgloss.Center).Render(content.String())
 	}
 
 	// Non-empty content: let height auto-size to content to avoid tmux line-wrapping issues
@@ -132,7 +134,7 @@ func (m *Model) renderListHeader() string {
 	if m.state.Navigation.View == model.ViewApps {
 		// Responsive widths matching row rendering
 		contentWidth := m.contentInnerWidth()
-		nameWidth, syncWidth, healthWidth := calculateColumnWidths(contentWidth)
+		nameWidth, syncWidth, healthWidth := m.appColumnWidths(contentWidth)
 
 		// Get sort indicator for the active column
 		sortIndicator := m.state.UI.Sort.Direction.Indicator()
@@ -182,14 +184,22 @@ func (m *Model) renderListHeader() string {
 			}
 		}
 
-		nameCell := padRight(clipAnsiToWidth(nameHeader, nameWidth), nameWidth)
+		rest, opWidth := m.splitOperationColumns(nameWidth)
+		nameOnly, commitWidth := m.splitCommitColumn(rest)
+		nameCell := padRight(clipAnsiToWidth(nameHeader, nameOnly), nameOnly)
+		if commitWidth > 0 {
+			nameCell += " " + padRight(headerStyle.Render("COMMIT"), commitWidth)
+		}
+		if opWidth > 0 {
+			nameCell += " " + m.renderOperationHeader(headerStyle)
+		}
 		// Align headers with content: sync and health cells use padLeft (right-aligned)
 		syncCell := padLeft(clipAnsiToWidth(syncHeader, syncWidth), syncWidth)
 		healthCell := padLeft(clipAnsiToWidth(healthHeader, healthWidth), healthWidth)
 
-		header := fmt.Sprintf("%s %s %s", nameCell, syncCell, healthCell)
+		header := joinAppColumns(nameCell, syncCell, healthCell, syncWidth, healthWidth)
 		// Use same width calculation as rows to ensure perfect alignment
-		fullRowWidth := nameWidth + syncWidth + healthWidth + 2 // +2 for separators
+		fullRowWidth := appRowWidth(nameWidth, syncWidth, healthWidth)
 		headerWidth := lipgloss.Width(header)
 		if headerWidth < fullRowWidth {
 			header = padRight(header, fullRowWidth)
@@ -210,6 +220,16 @@ func (m *Model) renderListHeader() string {
 	return hdr
 }
 
+// splitCommitColumn carves the COMMIT column out of the name column when it
+// is enabled and the name column is wide enough to share; commitWidth is 0 otherwise
+func (m *Model) splitCommitColumn(nameWidth int) (nameOnly, commitWidth int) {
+	if !m.commitColumnEnabled() || m.state.UI.WideNames || nameWidth < 40 {
+		return nameWidth, 0
+	}
+	commitWidth = min(50, nameWidth/2)
+	return nameWidth - commitWidth - 1, commitWidth
+}
+
 // renderAppRow - matches ListView app row rendering
 func (m *Model) renderAppRow(app model.App, isCursor bool) string {
 	// Selection checking (matches ListView isChecked logic)
@@ -229,19 +249,64 @@ func (m *Model) renderAppRow(app model.App, isCursor bool) string {
 	healthIcon := m.getHealthIcon(app.Health)
 
 	contentWidth := m.contentInnerWidth() // Match header/content inner width
-	nameWidth, syncWidth, healthWidth := calculateColumnWidths(contentWidth)
+	nameWidth, syncWidth, healthWidth := m.appColumnWidths(contentWidth)
 
 	// Generate text based on available width (either full text or icons only)
 	// Colored status strings with icons (as before)
 	syncText := fmt.Sprintf("%s %s", syncIcon, app.Sync)
 	healthText := fmt.Sprintf("%s %s", healthIcon, app.Health)
 
-	// Truncate app name with ellipsis if it's too long
-	truncatedName := truncateWithEllipsis(app.Name, nameWidth)
+	// The optional COMMIT and operation columns share the name column's width
+	rest, opWidth := m.splitOperationColumns(nameWidth)
+	nameOnly, commitWidth := m.splitCommitColumn(rest)
+
+	// Shorten the app name if it's too long, keeping room for the markers
+	nameRoom := m.appNameRoom(app, nameOnly)
+	truncatedName := m.truncateName(app.Name, nameRoom)
 
 	var nameCell, syncCell, healthCell string
 	// Build cells with clipping to assigned widths to prevent wrapping
-	nameCell = padRight(truncateWithEllipsis(truncatedName, nameWidth), nameWidth)
+	nameCell = padRight(truncatedName, nameOnly)
+	// Markers at the end of the name: unreachable cluster, drift, then
+	// deprecated API versions
+	var markers []string
+	if _, failed := m.clusterConnectionError(app); failed {
+		marker := clusterConnectionMarker
+		if !active {
+			marker = lipgloss.NewStyle().Foreground(outOfSyncColor).Render(marker)
+		}
+		markers = append(markers, marker)
+	}
+	if m.isBehindBranchTip(app) {
+		marker := revisionDriftMarker
+		if !active {
+			marker = lipgloss.NewStyle().Foreground(yellowBright).Render(marker)
+		}
+		markers = append(markers, marker)
+	}
+	if status := m.deprecatedAPIStatus(app); status != kubeapi.Supported {
+		marker := deprecatedAPIMarker
+		if !active {
+			marker = deprecatedAPIColor(status).Render(marker)
+		}
+		markers = append(markers, marker)
+	}
+	if nameRoom < nameOnly {
+		nameCell = padRight(truncatedName+" "+strings.Join(markers, " "), nameOnly)
+	}
+	if commitWidth > 0 {
+		commitText := ""
+		if c := m.syncedCommit(app); c != nil {
+			commitText = truncateWithEllipsis(commitSummary(c), commitWidth)
+		}
+		if !active {
+			commitText = lipgloss.NewStyle().Foreground(dimColor).Render(commitText)
+		}
+		nameCell += " " + padRight(commitText, commitWidth)
+	}
+	if opWidth > 0 {
+		nameCell += " " + m.renderOperationCells(app, active)
+	}
 
 	if isCursor || isSelected {
 		// Active row: avoid inner color styles so background highlight spans the whole row
@@ -267,10 +332,10 @@ func (m *Model) renderAppRow(app model.App, isCursor bool) string {
 		healthCell = padLeft(healthStyled, healthWidth)
 	}
 
-	row := fmt.Sprintf("%s %s %s", nameCell, syncCell, healthCell)
+	row := joinAppColumns(nameCell, syncCell, healthCell, syncWidth, healthWidth)
 
 	// Ensure row is exactly the content width to avoid wrapping
-	fullRowWidth := nameWidth + syncWidth + healthWidth + 2 // +2 for separators
+	fullRowWidth := appRowWidth(nameWidth, syncWidth, healthWidth)
 	if lipgloss.Width(row) < fullRowWidth {
 		row = padRight(row, fullRowWidth)
 	} else if lipgloss.Width(row) > fullRowWidth {
@@ -321,7 +386,7 @@ func (m *Model) renderSimpleRow(label string, isCursor bool) string {
 	contentWidth := m.contentInnerWidth()
 
 	// Truncate and pad label to full width
-	truncatedLabel := truncateWithEllipsis(label, contentWidth)
+	truncatedLabel := m.truncateName(label, contentWidth)
 	row := padRight(truncatedLabel, contentWidth)
 
 	// Apply selection highlight if active
@@ -5,9 +5,18 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/compat"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
 )
 
 func (m *Model) renderHelpModal() string {
+	switch m.state.Modals.HelpTopic {
+	case "views":
+		return m.renderViewsHelp()
+	case "keys":
+		return m.renderKeysHelp()
+	}
 
 	// Layout toggle (match earlier TS threshold)
 	isWide := m.state.Terminal.Cols >= 60
@@ -22,7 +31,7 @@ func (m *Model) renderHelpModal() string {
 
 	// GENERAL
 	general := strings.Join([]string{
-		mono(":"), " command ", bullet(), " ", mono("/"), " search ", bullet(), " ", mono("?"), " help",
+		mono(":"), " command ", bullet(), " ", mono("/"), " search ", bullet(), " ", mono("?"), " help ", bullet(), " ", keycap("Ctrl+R"), " reload view",
 	}, "")
 
 	// NAVIGATION
@@ -33,37 +42,61 @@ func (m *Model) renderHelpModal() string {
 	}, "")
 
 	// VIEWS
+	appsetsHelp := " "
+	if m.unavailable(compat.ApplicationSets) != "" {
+		appsetsHelp = " (off: not on this server) "
+	}
 	views := strings.Join([]string{
 		mono(":cls"), "|", mono(":clusters"), " ", bullet(), " ", mono(":ns"), "|", mono(":namespaces"), " ", bullet(), " ", mono(":proj"), "|", mono(":projects"), " ", bullet(), " ", mono(":apps"),
 		"\n",
-		mono(":appsets"), "|", mono(":applicationsets"), " ", bullet(), " ", mono(":theme"), " ", bullet(), " ", mono(":logs"),
+		mono(":appsets"), "|", mono(":applicationsets"), appsetsHelp, bullet(), " ", mono(":theme"), " ", bullet(), " ", mono(":logs"),
 		"\n",
 		mono(":context"), "|", mono(":contexts"), "|", mono(":ctx"), "|", mono(":argocd"), " [name] ",
+		"\n",
+		keycap("M"), " ", mono(":map"), " apps by cluster and namespace, colored by health",
 	}, "")
 
 	// COMMANDS
 	commands := strings.Join([]string{
-		mono(":q"), " (to exit, google how to exit vim)",
+		mono(":help views"), " views and default_view ", bullet(), " ", mono(":q"), " (to exit, google how to exit vim)",
+		"\n",
+		mono(":keys"), " every key binding by view and modal ", bullet(), " ", mono(":errors"), " recent errors",
+		"\n",
+		mono(":stream"), " watch events and what they changed ", bullet(), " ", mono(":refresh"), " on a project: all its apps",
+		"\n",
+		mono(":history"), " step back through app statuses of the last hour",
 	}, "")
 
 	// APPS VIEW - hotkeys and commands specific to apps view
 	appsView := strings.Join([]string{
-		keycap("s"), " sync ", bullet(), " ", keycap("R"), " rollback ", bullet(), " ", keycap("r"), " resources ", bullet(), " ", keycap("d"), " diff ", bullet(), " ", keycap("K"), " open in k9s ", bullet(), " ", keycap("Ctrl+D"), " delete",
+		keycap("s"), " sync ", bullet(), " ", keycap("R"), " rollback ", bullet(), " ", keycap("r"), " resources ", bullet(), " ", keycap("d"), " diff ", bullet(), " ", keycap("K"), " open in k9s ", bullet(), " ", keycap("i"), " details ", bullet(), " ", keycap("Ctrl+D"), " delete",
 		"\n",
-		mono(":diff"), " [app] ", bullet(), " ", mono(":sync"), " [app] ", bullet(), " ", mono(":rollback"), " [app] ", bullet(), " ", mono(":delete"), " [app]",
+		mono(":diff"), " [app] ", bullet(), " ", mono(":sync"), " [app] ", bullet(), " ", mono(":rollback"), " [app] ", bullet(), " ", mono(":details"), " [app] ", bullet(), " ", mono(":delete"), " [app]",
 		"\n",
 		mono(":refresh"), " [app] ", bullet(), " ", mono(":refresh!"), " [app] (hard) ", bullet(), " ", mono(":sort"), " health|sync asc|desc",
 		"\n",
-		mono(":resources"), " [app] ", bullet(), " ", mono(":up"), " ", bullet(), " ", mono(":all"),
+		mono(":resources"), " [app] ", bullet(), " ", keycap("H"), " ", mono(":hooks"), " [app] sync hooks ", bullet(), " ", mono(":up"), " ", bullet(), " ", mono(":all"), " ", bullet(), " ", mono(":wide"), " names",
+		"\n",
+		keycap("P"), " app's project ", bullet(), " ", keycap("C"), " app's cluster ", bullet(), " ", mono(":wait"), " [app] until synced/healthy",
+		"\n",
+		keycap("1"), " all ", bullet(), " ", keycap("2"), " OutOfSync ", bullet(), " ", keycap("3"), " Degraded ", bullet(), " ", keycap("4"), " Progressing (press again for all)",
+		"\n",
+		keycap("|"), " details pane ", bullet(), " ", keycap("Ctrl+←/→"), " ", keycap("<"), keycap(">"), " resize panes",
 	}, "")
 
 	// TREE VIEW - hotkeys specific to tree/resources view
+	actionsHelp := " actions (Rollouts) "
+	if m.unavailable(compat.ResourceActions) != "" {
+		actionsHelp = " actions (off: not on this server) "
+	}
 	treeView := strings.Join([]string{
-		mono("/"), " filter ", bullet(), " ", mono("n"), "/", mono("N"), " next/prev match ", bullet(), " ", keycap("d"), " diff ", bullet(), " ", mono("K"), " open in k9s",
+		mono("/"), " filter ", bullet(), " ", mono("n"), "/", mono("N"), " next/prev match ", bullet(), " ", keycap("d"), " diff ", bullet(), " ", mono("K"), " open in k9s ", bullet(), " ", mono("L"), " pod logs",
+		"\n",
+		keycap("Space"), " select ", bullet(), " ", keycap("s"), " sync ", bullet(), " ", keycap("a"), actionsHelp, bullet(), " ", keycap("Ctrl+D"), " delete",
 		"\n",
-		keycap("Space"), " select ", bullet(), " ", keycap("s"), " sync ", bullet(), " ", keycap("a"), " actions (Rollouts) ", bullet(), " ", keycap("Ctrl+D"), " delete",
+		keycap("y"), " copy web UI link ", bullet(), " ", keycap("w"), " ", mono(":save"), " [file] save YAML ", bullet(), " ", keycap("v"), " ", mono(":manifest"), " view",
 		"\n",
-		mono(":refresh"), "|", mono(":refresh!"), " ", bullet(), " ", mono(":up"),
+		mono(":refresh"), "|", mono(":refresh!"), " ", bullet(), " ", mono(":up"), " ", bullet(), " ", keycap("W"), " resubscribe live updates ", bullet(), " ", keycap("f"), " follow rollout",
 	}, "")
 
 	var helpSections []string
@@ -87,6 +120,53 @@ func (m *Model) renderHelpModal() string {
 	return m.renderFullScreenViewWithOptions("", body, m.renderStatusLine(), FullScreenViewOptions{ContentBordered: true, BorderColor: magentaBright})
 }
 
+// renderViewsHelp renders the `:help views` page: every view, how to reach it,
+// and the values default_view accepts to start in it
+func (m *Model) renderViewsHelp() string {
+	isWide := m.state.Terminal.Cols >= 60
+	mono := func(s string) string { return lipgloss.NewStyle().Foreground(cyanBright).Render(s) }
+	appsetsOff := ""
+	if m.unavailable(compat.ApplicationSets) != "" {
+		appsetsOff = " (off: not on this server)"
+	}
+
+	views := strings.Join([]string{
+		mono(":clusters"), "  Clusters",
+		"\n",
+		mono(":ns"), "        Namespaces",
+		"\n",
+		mono(":proj"), "      Projects",
+		"\n",
+		mono(":apps"), "      Applications",
+		"\n",
+		mono(":appsets"), "   ApplicationSets" + appsetsOff,
+		"\n",
+		mono(":resources"), " [app] resource tree",
+		"\n",
+		mono(":ctx"), "       Argo CD contexts",
+	}, "")
+
+	width := 0
+	for _, opt := range config.DefaultViewOptions {
+		width = max(width, len(opt.Value))
+	}
+	defaults := []string{statusStyle.Render("Set default_view in config.toml to start in a view:")}
+	for _, opt := range config.DefaultViewOptions {
+		defaults = append(defaults, mono(fmt.Sprintf("%-*s", width, opt.Value))+"  "+opt.Description)
+	}
+
+	sections := []string{
+		m.renderHelpSection("VIEWS", views, isWide),
+		"",
+		m.renderHelpSection("DEFAULT_VIEW", strings.Join(defaults, "\n"), isWide),
+		"",
+		statusStyle.Render("Press ?, q or Esc to close"),
+	}
+
+	body := "\n" + strings.Join(sections, "\n") + "\n"
+	return m.renderFullScreenViewWithOptions("", body, m.renderStatusLine(), FullScreenViewOptions{ContentBordered: true, BorderColor: magentaBright})
+}
+
 func (m *Model) renderDiffLoadingSpinner() string {
 	spinnerContent := fmt.Sprintf("%s Loading diff...", m.spinner.View())
 	spinnerStyle := lipgloss.NewStyle().
@@ -192,19 +272,11 @@ func (m *Model) renderNoServerModal() string {
 
 func (m *Model) renderRollbackModal() string {
 	header := m.renderBanner()
-	headerLines := countLines(header)
-	const BORDER_LINES = 2
-	const STATUS_LINES = 1
-	const MARGIN_TOP_LINES = 1 // blank line between header and box
-	overhead := BORDER_LINES + headerLines + STATUS_LINES + MARGIN_TOP_LINES
-	availableRows := max(0, m.state.Terminal.Rows-overhead)
-
-	containerWidth := max(0, m.state.Terminal.Cols-2)
-	// Expand modal height to fully occupy available space (align with other views)
-	// Use +2 here and adjust overall container height below to avoid clipping the status line.
-	contentHeight := max(3, availableRows+2)
-	innerWidth := max(0, containerWidth-4)
-	innerHeight := max(0, contentHeight-2)
+	// The modal box fills the space between the header and the status line
+	containerWidth := m.screenWidth()
+	contentHeight := m.rollbackBoxHeight(header)
+	innerWidth := layout.Card.InnerWidth(containerWidth)
+	innerHeight := layout.Card.InnerHeight(contentHeight)
 
 	if m.state.Rollback == nil || m.state.Modals.RollbackAppName == nil {
 		var content string
@@ -235,7 +307,12 @@ func (m *Model) renderRollbackModal() string {
 
 	if rollback.Mode != "confirm" {
 		instructionStyle := lipgloss.NewStyle().Foreground(cyanBright)
-		instructions := "j/k: Navigate • Enter: Select • Esc: Cancel"
+		instructions := "j/k: Navigate • /: Filter • Enter: Select • Esc: Cancel"
+		if rollback.Filtering {
+			instructions = "Type to filter by revision, author, message or date • ↑/↓: Navigate • Enter: Done • Esc: Clear"
+		} else if rollback.Filter != "" {
+			instructions = "j/k: Navigate • /: Edit filter • Enter: Select • Esc: Clear filter"
+		}
 		modalContent += "\n\n" + instructionStyle.Render(instructions)
 	}
 
@@ -262,8 +339,6 @@ func (m *Model) renderRollbackModal() string {
 	sections = append(sections, status)
 
 	content := strings.Join(sections, "\n")
-	// Use full terminal height here to accommodate the taller rollback modal while
-	// keeping the status line visible.
 	totalHeight := m.state.Terminal.Rows
 	content = clipAnsiToLines(content, totalHeight)
 	return mainContainerStyle.Height(totalHeight).Render(content)
@@ -271,15 +346,8 @@ func (m *Model) renderRollbackModal() string {
 
 func (m *Model) renderSimpleModal(title, content string) string {
 	header := m.renderBanner()
-	headerLines := countLines(header)
-	const BORDER_LINES = 2
-	const STATUS_LINES = 1
-	overhead := BORDER_LINES + headerLines + STATUS_LINES
-	availableRows := max(0, m.state.Terminal.Rows-overhead)
-
-	containerWidth := max(0, m.state.Terminal.Cols-2)
-	contentWidth := max(0, containerWidth-4)
-	contentHeight := max(3, availableRows)
+	// Same box as renderRollbackModal, so it does not jump once loaded
+	contentHeight := m.rollbackBoxHeight(header)
 
 	titleStyle := lipgloss.NewStyle().Foreground(cyanBright).Bold(true)
 	modalContent := titleStyle.Render(title) + "\n\n" + content
@@ -287,22 +355,24 @@ func (m *Model) renderSimpleModal(title, content string) string {
 	modalStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(cyanBright).
-		Width(contentWidth).
+		Width(m.screenWidth()).
 		Height(contentHeight).
 		AlignVertical(lipgloss.Top).
 		PaddingLeft(1).
 		PaddingRight(1)
 
+	modalContent = clipAnsiToLines(modalContent, layout.Card.InnerHeight(contentHeight))
 	styledContent := modalStyle.Render(modalContent)
 	var sections []string
 	sections = append(sections, header)
+	sections = append(sections, "")
 	sections = append(sections, styledContent)
 	// Add status line for consistent height
 	sections = append(sections, m.renderStatusLine())
 
 	content = strings.Join(sections, "\n")
-	totalHeight := m.state.Terminal.Rows - 1
-	return mainContainerStyle.Height(totalHeight).Render(content)
+	totalHeight := m.state.Terminal.Rows
+	return mainContainerStyle.Height(totalHeight).Render(clipAnsiToLines(content, totalHeight))
 }
 
 // renderUpgradeConfirmModal renders the upgrade confirmation modal
@@ -518,9 +588,8 @@ func (m *Model) renderAppDeleteConfirmModal() string {
 	isMulti := appName == "__MULTI__"
 
 	// Modal width: compact and centered (like sync modal)
-	half := m.state.Terminal.Cols / 2
-	modalWidth := min(max(36, half), m.state.Terminal.Cols-6)
-	innerWidth := max(0, modalWidth-6) // border(2) + padding(2*2)
+	modalWidth := m.modalWidth(36, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
 
 	// Message: make all title text bright and readable
 	var titleLine string
@@ -570,7 +639,7 @@ func (m *Model) renderAppDeleteConfirmModal() string {
 
 	// Cascade option
 	var cascadeLine strings.Builder
-	cascadeLine.WriteString(dim.Render("c: Cascade "))
+	cascadeLine.WriteString(m.focusMarker(focusCascade) + dim.Render("c: Cascade "))
 	if m.state.Modals.DeleteCascade {
 		cascadeLine.WriteString(on.Render("On"))
 		cascadeLine.WriteString(dim.Render(" (all resources deleted)"))
@@ -581,7 +650,7 @@ func (m *Model) renderAppDeleteConfirmModal() string {
 
 	// Propagation policy option
 	var policyLine strings.Builder
-	policyLine.WriteString(dim.Render("p: Policy "))
+	policyLine.WriteString(m.focusMarker(focusPolicy) + dim.Render("p: Policy "))
 	policyLine.WriteString(on.Render(m.state.Modals.DeletePropagationPolicy))
 	switch m.state.Modals.DeletePropagationPolicy {
 	case "foreground":
@@ -637,9 +706,8 @@ func (m *Model) renderResourceDeleteConfirmModal() string {
 	count := len(targets)
 
 	// Modal width: compact and centered (like sync modal)
-	half := m.state.Terminal.Cols / 2
-	modalWidth := min(max(36, half), m.state.Terminal.Cols-6)
-	innerWidth := max(0, modalWidth-6) // border(2) + padding(2*2)
+	modalWidth := m.modalWidth(36, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
 
 	// Message: make all title text bright and readable
 	var titleLine string
@@ -693,7 +761,7 @@ func (m *Model) renderResourceDeleteConfirmModal() string {
 
 	// Cascade option
 	var cascadeLine strings.Builder
-	cascadeLine.WriteString(dim.Render("c: Cascade "))
+	cascadeLine.WriteString(m.focusMarker(focusCascade) + dim.Render("c: Cascade "))
 	if m.state.Modals.ResourceDeleteCascade {
 		cascadeLine.WriteString(on.Render("On"))
 		cascadeLine.WriteString(dim.Render(" (all resources deleted)"))
@@ -704,7 +772,7 @@ func (m *Model) renderResourceDeleteConfirmModal() string {
 
 	// Propagation policy option
 	var policyLine strings.Builder
-	policyLine.WriteString(dim.Render("p: Policy "))
+	policyLine.WriteString(m.focusMarker(focusPolicy) + dim.Render("p: Policy "))
 	policyLine.WriteString(on.Render(m.state.Modals.ResourceDeletePropagationPolicy))
 	switch m.state.Modals.ResourceDeletePropagationPolicy {
 	case "foreground":
@@ -717,7 +785,7 @@ func (m *Model) renderResourceDeleteConfirmModal() string {
 
 	// Force option
 	var forceLine strings.Builder
-	forceLine.WriteString(dim.Render("f: Force "))
+	forceLine.WriteString(m.focusMarker(focusForce) + dim.Render("f: Force "))
 	if m.state.Modals.ResourceDeleteForce {
 		forceLine.WriteString(on.Render("On"))
 		forceLine.WriteString(dim.Render(" (ignore finalizers)"))
@@ -771,9 +839,8 @@ func (m *Model) renderResourceSyncConfirmModal() string {
 	count := len(targets)
 
 	// Modal width: compact and centered (like sync modal)
-	half := m.state.Terminal.Cols / 2
-	modalWidth := min(max(36, half), m.state.Terminal.Cols-6)
-	innerWidth := max(0, modalWidth-6) // border(2) + padding(2*2)
+	modalWidth := m.modalWidth(36, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
 
 	// Message: make all title text bright and readable
 	var titleLine string
@@ -801,10 +868,14 @@ func (m *Model) renderResourceSyncConfirmModal() string {
 	inactive := lipgloss.NewStyle().Background(inactiveBG).Foreground(inactiveFG).Padding(0, 2)
 
 	var syncBtn, cancelBtn string
-	if m.state.Modals.ResourceSyncConfirmSelected == 0 {
+	switch {
+	case !m.buttonFocused():
+		syncBtn = inactive.Render("Sync")
+		cancelBtn = inactive.Render("Cancel")
+	case m.state.Modals.ResourceSyncConfirmSelected == 0:
 		syncBtn = active.Render("Sync")
 		cancelBtn = inactive.Render("Cancel")
-	} else {
+	default:
 		syncBtn = inactive.Render("Sync")
 		cancelBtn = active.Render("Cancel")
 	}
@@ -829,7 +900,7 @@ func (m *Model) renderResourceSyncConfirmModal() string {
 
 	// Prune option
 	var pruneLine strings.Builder
-	pruneLine.WriteString(dim.Render("p: Prune "))
+	pruneLine.WriteString(m.focusMarker(focusPrune) + dim.Render("p: Prune "))
 	if m.state.Modals.ResourceSyncPrune {
 		pruneLine.WriteString(on.Render("On"))
 		pruneLine.WriteString(dim.Render(" (remove extra resources)"))
@@ -839,7 +910,7 @@ func (m *Model) renderResourceSyncConfirmModal() string {
 
 	// Force option
 	var forceLine strings.Builder
-	forceLine.WriteString(dim.Render("f: Force "))
+	forceLine.WriteString(m.focusMarker(focusForce) + dim.Render("f: Force "))
 	if m.state.Modals.ResourceSyncForce {
 		forceLine.WriteString(on.Render("On"))
 		forceLine.WriteString(dim.Render(" (delete & recreate)"))
@@ -890,9 +961,8 @@ func (m *Model) renderResourceActionModal() string {
 	}
 
 	// Modal width: compact and centered (like sync modal)
-	half := m.state.Terminal.Cols / 2
-	modalWidth := min(max(36, half), m.state.Terminal.Cols-6)
-	innerWidth := max(0, modalWidth-6) // border(2) + padding(2*2)
+	modalWidth := m.modalWidth(36, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
 
 	center := lipgloss.NewStyle().Width(innerWidth).Align(lipgloss.Center)
 	dim := lipgloss.NewStyle().Foreground(dimColor)
@@ -1316,15 +1386,7 @@ func (m *Model) renderThemeSelectionModal() string {
 	m.ensureThemeOptionsLoaded()
 	options := m.themeOptions
 
-	// Calculate available height for themes (subtract header, footer, borders, etc.)
-	headerLines := 2 // title + blank line
-	borderLines := 4 // top + bottom border + padding
-	footerLines := 2 // potential warning message + blank line
-	statusLine := 1  // status line at bottom
-	maxAvailableHeight := m.state.Terminal.Rows - headerLines - borderLines - footerLines - statusLine
-
-	// Ensure we have a reasonable minimum height
-	maxThemeLines := max(5, maxAvailableHeight)
+	maxThemeLines := m.themeListLines()
 
 	// Build theme list with selection highlight and scrolling
 	var themeLines []string
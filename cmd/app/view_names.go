@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/kubeapi"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// truncateName shortens a name to maxWidth the way appearance.truncate asks
+func (m *Model) truncateName(name string, maxWidth int) string {
+	mode, keep := m.config.GetTruncation()
+	if mode != config.TruncateMiddle {
+		return truncateWithEllipsis(name, maxWidth)
+	}
+	return truncateMiddle(name, maxWidth, keep == config.KeepSuffix)
+}
+
+// truncateMiddle cuts the middle out of a plain text so it fits maxWidth,
+// e.g. payments-a...eu-west-1. The favored end gets two thirds of the room.
+func truncateMiddle(text string, maxWidth int, keepSuffix bool) string {
+	if lipgloss.Width(text) <= maxWidth {
+		return text
+	}
+	if maxWidth <= 5 {
+		// Too narrow to show both ends
+		return truncateWithEllipsis(text, maxWidth)
+	}
+	room := maxWidth - 3
+	favored := (2*room + 2) / 3
+	head, tail := favored, room-favored
+	if keepSuffix {
+		head, tail = tail, favored
+	}
+	runes := []rune(text)
+	return string(runes[:head]) + "..." + string(runes[len(runes)-tail:])
+}
+
+// appColumnWidths returns the apps table's column widths. With :wide the
+// name column takes the whole row and the status columns are hidden.
+func (m *Model) appColumnWidths(contentWidth int) (nameWidth, syncWidth, healthWidth int) {
+	if m.state.UI.WideNames {
+		return contentWidth, 0, 0
+	}
+	return calculateColumnWidths(contentWidth)
+}
+
+// appRowWidth is the width of an apps table row: its columns and the
+// separators between them
+func appRowWidth(nameWidth, syncWidth, healthWidth int) int {
+	if syncWidth == 0 && healthWidth == 0 {
+		return nameWidth
+	}
+	return nameWidth + syncWidth + healthWidth + 2
+}
+
+// joinAppColumns lays out the cells of an apps table row
+func joinAppColumns(nameCell, syncCell, healthCell string, syncWidth, healthWidth int) string {
+	if syncWidth == 0 && healthWidth == 0 {
+		return nameCell
+	}
+	return fmt.Sprintf("%s %s %s", nameCell, syncCell, healthCell)
+}
+
+// appNameRoom is how wide an app's name may be in a name cell of cellWidth:
+// the cell minus room for the markers that follow the name, when they fit
+func (m *Model) appNameRoom(app model.App, cellWidth int) int {
+	markers := 0
+	if _, failed := m.clusterConnectionError(app); failed {
+		markers++
+	}
+	if m.isBehindBranchTip(app) {
+		markers++
+	}
+	if m.deprecatedAPIStatus(app) != kubeapi.Supported {
+		markers++
+	}
+	if reserve := 2 * markers; reserve > 0 && cellWidth > reserve+1 {
+		return cellWidth - reserve
+	}
+	return cellWidth
+}
+
+// selectedFullName returns the name of the selected list row when the list
+// shows it shortened, so the status line can show it whole; "" otherwise
+func (m *Model) selectedFullName() string {
+	if m.state.Navigation.View == model.ViewTree {
+		return ""
+	}
+	items := m.getVisibleItems()
+	idx := m.state.Navigation.SelectedIdx
+	if idx < 0 || idx >= len(items) {
+		return ""
+	}
+	contentWidth := m.contentInnerWidth()
+	if app, ok := items[idx].(model.App); ok {
+		nameWidth, _, _ := m.appColumnWidths(contentWidth)
+		rest, _ := m.splitOperationColumns(nameWidth)
+		nameOnly, _ := m.splitCommitColumn(rest)
+		if lipgloss.Width(app.Name) > m.appNameRoom(app, nameOnly) {
+			return app.Name
+		}
+		return ""
+	}
+	if label := fmt.Sprintf("%v", items[idx]); lipgloss.Width(label) > contentWidth {
+		return label
+	}
+	return ""
+}
+
+// handleToggleWide runs ":wide": the apps table's name column takes the
+// whole width, or shares it with the status columns again
+func (m *Model) handleToggleWide() (tea.Model, tea.Cmd) {
+	m.state.UI.WideNames = !m.state.UI.WideNames
+	note := "Wide names off"
+	if m.state.UI.WideNames {
+		note = "Wide names on"
+	}
+	return m, m.showStatusNote(note)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/config"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestTruncateMiddle(t *testing.T) {
+	name := "payments-api-canary-eu-west-1"
+	tests := []struct {
+		width      int
+		keepSuffix bool
+		want       string
+	}{
+		{width: 40, want: name},
+		{width: 15, want: "payments...st-1"},
+		{width: 15, keepSuffix: true, want: "paym...u-west-1"},
+		{width: 5, want: "pa..."},
+	}
+	for _, tt := range tests {
+		if got := truncateMiddle(name, tt.width, tt.keepSuffix); got != tt.want {
+			t.Errorf("truncateMiddle(%d, keepSuffix=%v) = %q, want %q", tt.width, tt.keepSuffix, got, tt.want)
+		}
+		if got := truncateMiddle(name, tt.width, tt.keepSuffix); len(got) > tt.width {
+			t.Errorf("truncateMiddle(%d) = %q is wider than the room", tt.width, got)
+		}
+	}
+}
+
+func TestRenderAppRow_MiddleTruncation(t *testing.T) {
+	m := buildDeleteTestModel(60, 30)
+	m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{Truncate: "middle", TruncateKeep: "suffix"}}
+	app := model.App{Name: "a-very-long-application-name-for-production-eu-west-1", Sync: "Synced", Health: "Healthy"}
+
+	row := stripANSI(m.renderAppRow(app, false))
+	if !strings.Contains(row, "...") || !strings.Contains(row, "eu-west-1") {
+		t.Errorf("expected the suffix to survive truncation, got %q", row)
+	}
+}
+
+func TestStatusLine_ShowsFullNameOfTruncatedRow(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	long := "a-very-long-application-name-for-the-payments-platform-running-in-production-eu-west-1"
+	m.state.Apps[0].Name = long
+	m.state.Index = model.BuildAppIndex(m.state.Apps)
+
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, long) {
+		t.Errorf("status line should show the whole selected name, got %q", status)
+	}
+
+	m.state.Navigation.SelectedIdx = 1
+	if status := stripANSI(m.renderStatusLine()); strings.Contains(status, long) {
+		t.Errorf("names that fit are not repeated, got %q", status)
+	}
+}
+
+func TestWideCommand_NameTakesWholeRow(t *testing.T) {
+	m := buildDeleteTestModel(60, 30)
+	app := model.App{Name: "a-very-long-application-name-for-prod", Sync: "Synced", Health: "Healthy"}
+
+	if row := stripANSI(m.renderAppRow(app, false)); strings.Contains(row, app.Name) {
+		t.Fatalf("name should not fit beside the status columns, got %q", row)
+	}
+
+	m.handleToggleWide()
+	if !m.state.UI.WideNames {
+		t.Fatal(":wide should turn wide names on")
+	}
+	row := stripANSI(m.renderAppRow(app, false))
+	if !strings.Contains(row, app.Name) || strings.Contains(row, "Synced") {
+		t.Errorf("wide row should be just the whole name, got %q", row)
+	}
+	if header := stripANSI(m.renderListHeader()); strings.Contains(header, "SYNC") {
+		t.Errorf("wide header hides the status columns, got %q", header)
+	}
+
+	m.handleToggleWide()
+	if m.state.UI.WideNames {
+		t.Error(":wide again should turn wide names off")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// handleReloadView reloads only the data behind the current view (ctrl+r):
+// the app list, the resource tree, the rollback history or the diff outline.
+// Cursor and selections are kept; the status line shows a spinner until the
+// data is back.
+func (m *Model) handleReloadView() (tea.Model, tea.Cmd) {
+	if m.state.Server == nil {
+		return m, nil
+	}
+
+	var what string
+	var cmd tea.Cmd
+	switch {
+	case m.state.Mode == model.ModeRollback:
+		rb := m.state.Rollback
+		if rb == nil || rb.Loading || rb.Mode != "list" {
+			return m, nil
+		}
+		m.prefetch.invalidate(appKey(rb.AppName, rb.AppNamespace))
+		what, cmd = "history", m.startRollbackSession(rb.AppName, rb.AppNamespace)
+	case m.state.Mode == model.ModeDiffOutline:
+		outline := m.state.Modals.DiffOutline
+		if outline == nil || outline.Notice != "" {
+			// Deferred outlines load each resource on open; nothing to reload
+			return m, nil
+		}
+		m.prefetch.invalidate(appKey(outline.AppName, outline.AppNamespace))
+		m.diffCache.invalidate(appKey(outline.AppName, outline.AppNamespace))
+		what, cmd = "diff", m.startDiffSession(outline.AppName, outline.AppNamespace)
+	case m.state.Mode != model.ModeNormal:
+		return m, nil
+	case m.state.Navigation.View == model.ViewTree:
+		if m.treeView == nil {
+			return m, nil
+		}
+		what, cmd = "resources", m.reloadTree()
+	case m.state.Navigation.View == model.ViewContexts:
+		return m, nil
+	default:
+		what, cmd = "apps", m.startLoadingApplications()
+	}
+
+	cblog.With("component", "reload").Info("Reloading view", "what", what)
+	m.viewReload = what
+	return m, cmd
+}
+
+// reloadTree loads the trees shown in the tree view again and restarts their
+// watches. The tree view keeps the cursor and selection on the same nodes.
+func (m *Model) reloadTree() tea.Cmd {
+	m.cleanupTreeWatchers()
+	var cmds []tea.Cmd
+	for _, name := range m.treeView.AppNames() {
+		app := model.App{Name: name, AppNamespace: m.treeAppNamespace(name)}
+		if found := m.findAppByNameAndNamespace(name, derefOr(app.AppNamespace)); found != nil {
+			app = *found
+		}
+		cmds = append(cmds, m.startLoadingResourceTree(app), m.startWatchingResourceTree(app))
+	}
+	return tea.Batch(append(cmds, m.consumeTreeEvent())...)
+}
+
+// reloadFinished reports whether msg ends a reload started with ctrl+r,
+// either with the reloaded data or with an error
+func reloadFinished(msg tea.Msg) bool {
+	switch msg.(type) {
+	case model.AppsLoadedMsg, model.ResourceTreeLoadedMsg, model.RollbackHistoryLoadedMsg,
+		model.DiffOutlineLoadedMsg, model.ApiErrorMsg, model.StructuredErrorMsg,
+		model.AuthErrorMsg, pagerDoneMsg:
+		return true
+	case model.SetModeMsg:
+		// A diff that became empty lands in the no-diff mode
+		return msg.(model.SetModeMsg).Mode == model.ModeNoDiff
+	}
+	return false
+}
+
+// clampListCursor keeps the list cursor on a row after the list shrank
+func (m *Model) clampListCursor() {
+	if m.state.Navigation.View == model.ViewTree {
+		return
+	}
+	if n := len(m.getVisibleItems()); m.state.Navigation.SelectedIdx >= n {
+		m.state.Navigation.SelectedIdx = max(0, n-1)
+	}
+}
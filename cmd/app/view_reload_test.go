@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestReloadView_AppsKeepsCursorAndShowsSpinner(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "t"}
+	m.state.Navigation.SelectedIdx = 1
+
+	_, cmd := m.handleKeyMsg(keyPress("ctrl+r"))
+	if cmd == nil {
+		t.Fatal("expected a reload command")
+	}
+	if m.viewReload != "apps" {
+		t.Fatalf("viewReload = %q, want apps", m.viewReload)
+	}
+	if status := stripANSI(m.renderStatusLine()); !strings.Contains(status, "Reloading apps") {
+		t.Errorf("status line should show the reload, got %q", status)
+	}
+
+	// The reloaded list lost an app; the cursor moves up onto the last row
+	m.Update(model.AppsLoadedMsg{Apps: m.state.Apps[:1]})
+	if m.viewReload != "" {
+		t.Errorf("reload should be done, viewReload = %q", m.viewReload)
+	}
+	if m.state.Navigation.SelectedIdx != 0 {
+		t.Errorf("SelectedIdx = %d, want 0", m.state.Navigation.SelectedIdx)
+	}
+}
+
+func TestReloadView_RollbackKeepsSelection(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "t"}
+	rows := []model.RollbackRow{{ID: 3, Revision: "c"}, {ID: 2, Revision: "b"}, {ID: 1, Revision: "a"}}
+	m.Update(model.RollbackHistoryLoadedMsg{AppName: "test-app", Rows: rows, CurrentRevision: "c"})
+	m.state.Mode = model.ModeRollback
+	m.state.Rollback.SelectedIdx = 2
+	m.state.Rollback.Prune = true
+
+	m.handleKeyMsg(keyPress("ctrl+r"))
+	if m.viewReload != "history" {
+		t.Fatalf("viewReload = %q, want history", m.viewReload)
+	}
+
+	m.Update(model.RollbackHistoryLoadedMsg{AppName: "test-app", Rows: rows, CurrentRevision: "c"})
+	if m.viewReload != "" {
+		t.Errorf("reload should be done, viewReload = %q", m.viewReload)
+	}
+	if m.state.Rollback.SelectedIdx != 2 || !m.state.Rollback.Prune {
+		t.Errorf("selection and options should survive the reload, got idx %d prune %v",
+			m.state.Rollback.SelectedIdx, m.state.Rollback.Prune)
+	}
+}
+
+func TestReloadView_IgnoredInModals(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Server = &model.Server{BaseURL: "https://argo.example.com", Token: "t"}
+	m.state.Mode = model.ModeHelp
+
+	if _, cmd := m.handleReloadView(); cmd != nil || m.viewReload != "" {
+		t.Errorf("nothing to reload behind the help modal, got %q", m.viewReload)
+	}
+}
+
+func TestReloadFinished(t *testing.T) {
+	cases := []struct {
+		msg  any
+		want bool
+	}{
+		{model.AppsLoadedMsg{}, true},
+		{model.ApiErrorMsg{}, true},
+		{model.SetModeMsg{Mode: model.ModeNoDiff}, true},
+		{model.SetModeMsg{Mode: model.ModeNormal}, false},
+		{model.StatusChangeMsg{}, false},
+	}
+	for _, c := range cases {
+		if got := reloadFinished(c.msg); got != c.want {
+			t.Errorf("reloadFinished(%T) = %v, want %v", c.msg, got, c.want)
+		}
+	}
+}
@@ -26,12 +26,31 @@ func (m *Model) renderStatusLine() string {
 		sort.Strings(appsetNames)
 		appsetName := appsetNames[0] // Use first name alphabetically
 		if m.state.UI.ActiveFilter != "" {
-			leftText = fmt.Sprintf("<apps in %s:%s>", appsetName, m.state.UI.ActiveFilter)
+			leftText = fmt.Sprintf("<apps in %s%s:%s>", appsetName, m.statusFilterTag(), m.state.UI.ActiveFilter)
 		} else {
-			leftText = fmt.Sprintf("<apps in %s>", appsetName)
+			leftText = fmt.Sprintf("<apps in %s%s>", appsetName, m.statusFilterTag())
+		}
+	} else if m.state.Navigation.View == model.ViewApps {
+		if m.state.UI.ActiveFilter != "" {
+			leftText = fmt.Sprintf("<%s%s:%s>", m.state.Navigation.View, m.statusFilterTag(), m.state.UI.ActiveFilter)
+		} else {
+			leftText = fmt.Sprintf("<%s%s>", m.state.Navigation.View, m.statusFilterTag())
 		}
-	} else if m.state.UI.ActiveFilter != "" && m.state.Navigation.View == model.ViewApps {
-		leftText = fmt.Sprintf("<%s:%s>", m.state.Navigation.View, m.state.UI.ActiveFilter)
+	}
+	// In the tree view, describe the selected Rollout's progress or Job's
+	// last run, or else the selected resource's health and its source
+	if m.state.Navigation.View == model.ViewTree {
+		if detail := m.rolloutDetail(); detail != "" {
+			leftText = fmt.Sprintf("<%s> %s", m.state.Navigation.View, detail)
+		} else if detail := m.jobDetail(); detail != "" {
+			leftText = fmt.Sprintf("<%s> %s", m.state.Navigation.View, detail)
+		} else if detail := m.treeHealthDetail(); detail != "" {
+			leftText = fmt.Sprintf("<%s> %s", m.state.Navigation.View, detail)
+		}
+	}
+	// Show the selected row's whole name when the list had to shorten it
+	if full := m.selectedFullName(); full != "" {
+		leftText = fmt.Sprintf("%s %s", leftText, full)
 	}
 	// Show tree filter info if active
 	if m.state.Navigation.View == model.ViewTree && m.treeView != nil && m.treeView.GetFilter() != "" {
@@ -44,6 +63,13 @@ func (m *Model) renderStatusLine() string {
 		}
 	}
 
+	if m.state.Navigation.View == model.ViewTree && m.treeFollow {
+		leftText += " [follow]"
+	}
+	if m.renderProfile != nil && m.renderProfile.recording {
+		leftText += " [profiling]"
+	}
+
 	// Right side: status and position (matches MainLayout right Box)
 	// For tree view, use treeView counts; otherwise use list counts.
 	position := ""
@@ -66,7 +92,7 @@ func (m *Model) renderStatusLine() string {
 
 	// Get upgrade notification text based on screen width
 	if m.state.UI.IsVersionOutdated && m.shouldShowUpgradeNotification() {
-		available := max(0, m.state.Terminal.Cols-2)
+		available := m.screenWidth()
 
 		// Progressive text shortening based on available space
 		upgradeFG := ensureContrastingForeground(mutedBG, whiteBright)
@@ -95,7 +121,7 @@ func (m *Model) renderStatusLine() string {
 		// If even that doesn't fit, rightText stays empty (removes notification entirely)
 	} else if m.state.UI.ShowWhatsNew && m.shouldShowWhatsNewNotification() {
 		// Show what's new notification (only if upgrade notification isn't showing)
-		available := max(0, m.state.Terminal.Cols-2)
+		available := m.screenWidth()
 
 		// Progressive text shortening based on available space
 		changelogFG := ensureContrastingForeground(mutedBG, whiteBright)
@@ -124,6 +150,24 @@ func (m *Model) renderStatusLine() string {
 
 	// Always show Ready, ignore status messages
 	statusText := "Ready"
+	if !m.config.IsStreamEnabled() {
+		if interval := m.config.GetStreamRefreshInterval(); interval > 0 {
+			statusText = "Ready • reloads every " + refreshIntervalText(interval)
+		} else {
+			statusText = "Ready • not live, ctrl+r reloads"
+		}
+	}
+	if m.appsFromCache {
+		statusText = "Cached • refreshing…"
+	}
+	if m.viewReload != "" {
+		statusText = m.spinner.View() + " Reloading " + m.viewReload + "…"
+	}
+
+	if m.state.UI.StatusNote != "" {
+		noteStyle := lipgloss.NewStyle().Foreground(syncedColor)
+		statusText = noteStyle.Render(m.state.UI.StatusNote) + " • " + statusText
+	}
 
 	// Show "Copied!" briefly after text selection copy
 	if m.state.UI.SelectionCopied {
@@ -135,6 +179,10 @@ func (m *Model) renderStatusLine() string {
 		statusText += fmt.Sprintf(" • %s", position)
 	}
 
+	if clock := m.renderStatusClock(); clock != "" {
+		statusText = clock + " • " + statusText
+	}
+
 	// Combine the full right side text
 	fullRightText := rightText + statusText
 
@@ -143,7 +191,7 @@ func (m *Model) renderStatusLine() string {
 	rightStyled := statusStyle.Render(fullRightText)
 
 	// Available width inside main container (accounts for its padding)
-	available := max(0, m.state.Terminal.Cols-2)
+	available := m.screenWidth()
 	// Use lipgloss.Width for accurate spacing
 	gap := max(0, available-lipgloss.Width(leftText)-lipgloss.Width(fullRightText))
 	line := lipgloss.JoinHorizontal(
@@ -162,6 +210,30 @@ func (m *Model) renderStatusLine() string {
 	return line
 }
 
+// treeHealthDetail describes the health of the selected tree resource and
+// whether it comes from a custom Lua check, a built-in check, or none at all.
+func (m *Model) treeHealthDetail() string {
+	if m.treeView == nil || m.treeView.IsSelectedSyntheticRoot() {
+		return ""
+	}
+	group, kind, _, _, ok := m.treeView.SelectedResource()
+	if !ok || kind == "" {
+		return ""
+	}
+	health, _ := m.treeView.SelectedStatus()
+	switch m.state.HealthCustomizations.Source(group, kind, health) {
+	case model.HealthSourceCustom:
+		if health == "" {
+			health = "Unknown"
+		}
+		return fmt.Sprintf("%s health: %s (custom check)", kind, health)
+	case model.HealthSourceNone:
+		return fmt.Sprintf("%s health: not assessed", kind)
+	default:
+		return fmt.Sprintf("%s health: %s (built-in)", kind, health)
+	}
+}
+
 // Helper functions matching TypeScript utilities
 
 func (m *Model) getSyncIcon(sync string) string {
@@ -219,7 +291,7 @@ func (m *Model) shouldShowUpgradeNotification() bool {
 		return true // Show if we haven't started timing yet
 	}
 
-	elapsed := time.Since(*m.state.UI.UpdateInfo.NotificationShownAt)
+	elapsed := clockNow().Sub(*m.state.UI.UpdateInfo.NotificationShownAt)
 	return elapsed < upgradeNotificationTimeout
 }
 
@@ -230,6 +302,6 @@ func (m *Model) shouldShowWhatsNewNotification() bool {
 		return true // Show if we haven't started timing yet
 	}
 
-	elapsed := time.Since(*m.state.UI.WhatsNewShownAt)
+	elapsed := clockNow().Sub(*m.state.UI.WhatsNewShownAt)
 	return elapsed < whatsNewNotificationTimeout
 }
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/config"
 	"github.com/darksworm/argonaut/pkg/model"
 )
 
@@ -74,3 +77,67 @@ func TestShouldShowWhatsNewNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestTreeHealthDetail_HealthSources(t *testing.T) {
+	m := buildBaseModel(100, 24)
+	m.state.Navigation.View = model.ViewTree
+	m.state.HealthCustomizations = model.HealthCustomizations{"argoproj.io/Rollout"}
+
+	ns := "prod"
+	healthy := "Healthy"
+	tree := api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "r1", Group: "argoproj.io", Kind: "Rollout", Name: "web", Namespace: &ns, Health: &api.ResourceHealth{Status: &healthy}},
+		{UID: "d1", Group: "apps", Kind: "Deployment", Name: "api", Namespace: &ns, Health: &api.ResourceHealth{Status: &healthy}},
+		{UID: "e1", Group: "discovery.k8s.io", Kind: "EndpointSlice", Name: "api-x", Namespace: &ns},
+	}}
+	m.treeView.SetAppMeta("demo", "Healthy", "Synced")
+	m.treeView.SetData(&tree)
+
+	if got := m.treeHealthDetail(); got != "" {
+		t.Fatalf("synthetic app root should have no detail, got %q", got)
+	}
+
+	want := map[string]string{
+		"Rollout":       "Rollout health: Healthy (custom check)",
+		"Deployment":    "Deployment health: Healthy (built-in)",
+		"EndpointSlice": "EndpointSlice health: not assessed",
+	}
+	for i := 1; i < m.treeView.VisibleCount(); i++ {
+		m.treeView.SetSelectedIndex(i)
+		_, kind, _, _, _ := m.treeView.SelectedResource()
+		if got := m.treeHealthDetail(); got != want[kind] {
+			t.Errorf("%s: got %q, want %q", kind, got, want[kind])
+		}
+	}
+}
+
+func TestRenderStatusLine_Clock(t *testing.T) {
+	withDeterministicMode(t)
+	api.ResetLatency()
+	m := buildDeleteTestModel(120, 30)
+
+	if line := stripANSI(m.renderStatusLine()); strings.Contains(line, "api ") {
+		t.Fatalf("clock is off by default, got %q", line)
+	}
+
+	m.config = &config.ArgonautConfig{Appearance: config.AppearanceConfig{StatusClock: true}}
+	line := stripANSI(m.renderStatusLine())
+	if want := "12:00 api – • Ready"; !strings.Contains(line, want) {
+		t.Fatalf("expected %q in the status line, got %q", want, line)
+	}
+	if m.statusClockTick() != nil {
+		t.Error("the frozen clock should not tick")
+	}
+}
+
+func TestFormatLatency(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		85 * time.Millisecond:   "85ms",
+		999 * time.Millisecond:  "999ms",
+		1500 * time.Millisecond: "1.5s",
+	} {
+		if got := formatLatency(d); got != want {
+			t.Errorf("formatLatency(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/darksworm/argonaut/pkg/tui/layout"
+)
+
+// waitDefaultTimeout bounds a :wait without --timeout
+const waitDefaultTimeout = 5 * time.Minute
+
+// waitConditions lists the :wait conditions in the order they are shown;
+// all of them are waited for when --for is not given, like argocd app wait
+var waitConditions = []string{model.WaitSynced, model.WaitHealthy, model.WaitOperation}
+
+// waitTickMsg re-checks a running :wait so the elapsed time moves and the
+// timeout fires even while no app updates arrive
+type waitTickMsg struct {
+	id          int
+	switchEpoch int
+}
+
+// waitNow returns the time on the :wait clock. It defaults to the wall
+// clock rather than clockNow, so timeouts still fire in deterministic mode.
+func (m *Model) waitNow() time.Time {
+	if m.waitClock != nil {
+		return m.waitClock()
+	}
+	return time.Now()
+}
+
+// parseWaitArgs parses the arguments of ":wait [[ns/]app] [--for cond[,cond]]
+// [--timeout duration]". --for may be repeated; a bare number of seconds is
+// accepted as the timeout like argocd app wait does.
+func parseWaitArgs(args []string) (app string, conditions []string, timeout time.Duration, err error) {
+	timeout = waitDefaultTimeout
+	wanted := map[string]bool{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			if app != "" {
+				return "", nil, 0, fmt.Errorf("unexpected argument %q", arg)
+			}
+			app = arg
+			continue
+		}
+		flag, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", nil, 0, fmt.Errorf("--%s needs a value", flag)
+			}
+			i++
+			value = args[i]
+		}
+		switch flag {
+		case "for":
+			for _, c := range strings.Split(strings.ToLower(value), ",") {
+				if !slices.Contains(waitConditions, c) {
+					return "", nil, 0, fmt.Errorf("unknown condition %q (use %s)", c, strings.Join(waitConditions, ", "))
+				}
+				wanted[c] = true
+			}
+		case "timeout":
+			if secs, convErr := strconv.Atoi(value); convErr == nil {
+				timeout = time.Duration(secs) * time.Second
+			} else if timeout, err = time.ParseDuration(value); err != nil {
+				return "", nil, 0, fmt.Errorf("invalid timeout %q", value)
+			}
+			if timeout <= 0 {
+				return "", nil, 0, fmt.Errorf("timeout must be positive")
+			}
+		default:
+			return "", nil, 0, fmt.Errorf("unknown flag --%s", flag)
+		}
+	}
+	for _, c := range waitConditions {
+		if len(wanted) == 0 || wanted[c] {
+			conditions = append(conditions, c)
+		}
+	}
+	return app, conditions, timeout, nil
+}
+
+// handleWaitCommand runs ":wait" for the named app, or the app under the
+// cursor when none is named. A name shared by apps in several namespaces
+// picks the one under the cursor unless given as ns/name.
+func (m *Model) handleWaitCommand(args []string) (tea.Model, tea.Cmd) {
+	name, conditions, timeout, err := parseWaitArgs(args)
+	if err != nil {
+		return m, func() tea.Msg { return model.StatusChangeMsg{Status: "wait: " + err.Error()} }
+	}
+
+	var appNamespace *string
+	switch {
+	case name != "":
+		ns, appName, qualified := strings.Cut(name, "/")
+		if !qualified {
+			appName, ns = name, ""
+			if app, ok := m.cursorApp(); ok && app.Name == name {
+				ns = derefOr(app.AppNamespace)
+			}
+		}
+		found := m.findAppByNameAndNamespace(appName, ns)
+		if found != nil && qualified && derefOr(found.AppNamespace) != ns {
+			found = nil // no app of that name in the namespace given
+		}
+		if found == nil {
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: "App not found: " + name} }
+		}
+		name, appNamespace = found.Name, found.AppNamespace
+	case m.state.Navigation.View == model.ViewTree && m.state.UI.TreeApp != nil:
+		name, appNamespace = m.state.UI.TreeApp.Name, m.state.UI.TreeApp.AppNamespace
+	default:
+		app, ok := m.cursorApp()
+		if !ok {
+			return m, func() tea.Msg {
+				return model.StatusChangeMsg{Status: "Navigate to apps view first to select an app to wait for"}
+			}
+		}
+		name, appNamespace = app.Name, app.AppNamespace
+	}
+	return m, m.startWait(name, appNamespace, conditions, timeout)
+}
+
+// startWait opens the wait modal and checks the app right away, since it
+// may already be where it is waited for
+func (m *Model) startWait(appName string, appNamespace *string, conditions []string, timeout time.Duration) tea.Cmd {
+	m.waitSeq++
+	m.state.Modals.Wait = &model.WaitState{
+		AppName:      appName,
+		AppNamespace: appNamespace,
+		Conditions:   conditions,
+		StartedAt:    m.waitNow(),
+		Timeout:      timeout,
+		ID:           m.waitSeq,
+	}
+	m.state.Mode = model.ModeWait
+	if cmd := m.checkWait(); cmd != nil {
+		return cmd
+	}
+	return m.waitTick(m.waitSeq)
+}
+
+// waitTick schedules the next check of the wait with the given id
+func (m *Model) waitTick(id int) tea.Cmd {
+	epoch := m.switchEpoch
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return waitTickMsg{id: id, switchEpoch: epoch} })
+}
+
+// handleWaitTick checks the running wait and keeps ticking until it is
+// over. A context switch ends the wait, so ticks from before it are dropped.
+func (m *Model) handleWaitTick(msg waitTickMsg) tea.Cmd {
+	st := m.state.Modals.Wait
+	if msg.switchEpoch != m.switchEpoch || st == nil || st.ID != msg.id || st.Result != "" {
+		return nil
+	}
+	if cmd := m.checkWait(); cmd != nil {
+		return cmd
+	}
+	return m.waitTick(msg.id)
+}
+
+// waitConditionMet reports whether the app has reached a wait condition
+func waitConditionMet(app model.App, condition string) bool {
+	switch condition {
+	case model.WaitSynced:
+		return app.Sync == "Synced"
+	case model.WaitHealthy:
+		return app.Health == "Healthy"
+	case model.WaitOperation:
+		return app.OperationPhase == "" || isFinishedOperation(app.OperationPhase)
+	}
+	return false
+}
+
+// checkWait ends the running wait when the app reached its conditions, its
+// operation failed or the timeout passed. Returns nil while still waiting.
+func (m *Model) checkWait() tea.Cmd {
+	st := m.state.Modals.Wait
+	if st == nil || st.Result != "" {
+		return nil
+	}
+	now := m.waitNow()
+	finish := func(result, message string) tea.Cmd {
+		st.Result, st.Message, st.FinishedAt = result, message, now
+		return func() tea.Msg { return model.StatusChangeMsg{Status: message} }
+	}
+
+	app := m.findAppByNameAndNamespace(st.AppName, derefOr(st.AppNamespace))
+	if app == nil {
+		return finish("failed", st.AppName+" no longer exists")
+	}
+	met := true
+	for _, c := range st.Conditions {
+		met = met && waitConditionMet(*app, c)
+	}
+	elapsed := now.Sub(st.StartedAt).Round(time.Second)
+	switch {
+	case slices.Contains(st.Conditions, model.WaitOperation) && (app.OperationPhase == "Failed" || app.OperationPhase == "Error"):
+		message := fmt.Sprintf("%s: last operation %s", st.AppName, app.OperationPhase)
+		if app.OperationMessage != "" {
+			message += ": " + app.OperationMessage
+		}
+		return finish("failed", message)
+	case met:
+		return finish("met", fmt.Sprintf("%s is %s after %s", st.AppName, strings.Join(st.Conditions, ", "), elapsed))
+	case elapsed >= st.Timeout:
+		return finish("timeout", fmt.Sprintf("Timed out after %s waiting for %s", st.Timeout, st.AppName))
+	}
+	return nil
+}
+
+// handleWaitKeys handles input in the wait modal; closing it stops waiting
+func (m *Model) handleWaitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "enter":
+		st := m.state.Modals.Wait
+		m.state.Modals.Wait = nil
+		m.state.Mode = model.ModeNormal
+		if st != nil && st.Result == "" {
+			return m, func() tea.Msg { return model.StatusChangeMsg{Status: "Stopped waiting for " + st.AppName} }
+		}
+	}
+	return m, nil
+}
+
+// renderWaitModal renders the progress of a :wait: each condition with the
+// app's current value, and the elapsed time against the timeout
+func (m *Model) renderWaitModal() string {
+	st := m.state.Modals.Wait
+	if st == nil {
+		return ""
+	}
+
+	modalWidth := m.modalWidth(50, 1, 2)
+	innerWidth := layout.Dialog.InnerWidth(modalWidth)
+	dim := lipgloss.NewStyle().Foreground(dimColor)
+	ok := lipgloss.NewStyle().Foreground(syncedColor)
+	bad := lipgloss.NewStyle().Foreground(outOfSyncColor)
+
+	titleStyle := lipgloss.NewStyle().Foreground(yellowBright).Bold(true)
+	border := cyanBright
+	title := "Waiting for " + st.AppName
+	switch st.Result {
+	case "met":
+		title, border = st.AppName+" is ready", syncedColor
+	case "failed", "timeout":
+		title, border = "Stopped waiting for "+st.AppName, outOfSyncColor
+	}
+	lines := []string{titleStyle.Render(truncateWithEllipsis(title, innerWidth)), ""}
+
+	var app model.App
+	if found := m.findAppByNameAndNamespace(st.AppName, derefOr(st.AppNamespace)); found != nil {
+		app = *found
+	}
+	for _, c := range st.Conditions {
+		current := map[string]string{
+			model.WaitSynced:    app.Sync,
+			model.WaitHealthy:   app.Health,
+			model.WaitOperation: app.OperationPhase,
+		}[c]
+		if current == "" {
+			current = "–"
+		}
+		mark := dim.Render("…")
+		if waitConditionMet(app, c) {
+			mark = ok.Render("✓")
+		}
+		lines = append(lines, fmt.Sprintf("%s %-9s %s", mark, c, current))
+	}
+
+	end := m.waitNow()
+	if st.Result != "" {
+		end = st.FinishedAt
+	}
+	elapsed := fmt.Sprintf("%s of %s", end.Sub(st.StartedAt).Round(time.Second), st.Timeout)
+	if st.Result == "" && m.reducedMotion() {
+		// A counter ticking every second is motion; show only the limit
+		elapsed = "timeout " + st.Timeout.String()
+	}
+	lines = append(lines, "", dim.Render(elapsed))
+	help := "Esc to stop waiting"
+	if st.Result != "" {
+		style := ok
+		if st.Result != "met" {
+			style = bad
+		}
+		lines = append(lines, style.Width(innerWidth).Render(st.Message))
+		help = "Esc to close"
+	}
+	lines = append(lines, "", dim.Render(help))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(modalWidth).
+		AlignHorizontal(lipgloss.Left)
+	return modalStyle.Render(strings.Join(lines, "\n"))
+}
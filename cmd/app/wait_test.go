@@ -0,0 +1,159 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestParseWaitArgs(t *testing.T) {
+	tests := []struct {
+		args       []string
+		app        string
+		conditions []string
+		timeout    time.Duration
+		err        string
+	}{
+		{args: nil, conditions: []string{"synced", "healthy", "operation"}, timeout: waitDefaultTimeout},
+		{args: []string{"api", "--for", "healthy"}, app: "api", conditions: []string{"healthy"}, timeout: waitDefaultTimeout},
+		{args: []string{"--for=operation,synced", "--timeout", "90"}, conditions: []string{"synced", "operation"}, timeout: 90 * time.Second},
+		{args: []string{"--for", "healthy", "--for", "synced", "--timeout=2m"}, conditions: []string{"synced", "healthy"}, timeout: 2 * time.Minute},
+		{args: []string{"--for", "degraded"}, err: `unknown condition "degraded"`},
+		{args: []string{"--timeout"}, err: "--timeout needs a value"},
+		{args: []string{"--timeout", "soon"}, err: `invalid timeout "soon"`},
+		{args: []string{"a", "b"}, err: `unexpected argument "b"`},
+	}
+	for _, tt := range tests {
+		app, conditions, timeout, err := parseWaitArgs(tt.args)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%v: expected error %q, got %v", tt.args, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || app != tt.app || !reflect.DeepEqual(conditions, tt.conditions) || timeout != tt.timeout {
+			t.Errorf("%v: got %q %v %s %v", tt.args, app, conditions, timeout, err)
+		}
+	}
+}
+
+func TestWait_EndsWhenWatchReportsHealthy(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].Health = "Progressing"
+	m.state.Apps[0].OperationPhase = "Running"
+
+	_, cmd := m.handleWaitCommand([]string{"test-app", "--for", "healthy,operation"})
+	if m.state.Mode != model.ModeWait || cmd == nil {
+		t.Fatal(":wait should open the wait modal and start ticking")
+	}
+	out := stripANSI(m.renderWaitModal())
+	for _, want := range []string{"Waiting for test-app", "healthy   Progressing", "operation Running", "Esc to stop waiting"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("modal should contain %q:\n%s", want, out)
+		}
+	}
+
+	app := m.state.Apps[0]
+	app.Health, app.OperationPhase = "Healthy", "Succeeded"
+	m.Update(model.AppsBatchUpdateMsg{Updates: []model.AppUpdatedMsg{{App: app}}, Generation: m.watchGeneration, SwitchEpoch: m.switchEpoch})
+
+	st := m.state.Modals.Wait
+	if st == nil || st.Result != "met" {
+		t.Fatalf("the wait should end once the app is healthy, got %+v", st)
+	}
+	if out := stripANSI(m.renderWaitModal()); !strings.Contains(out, "test-app is ready") || !strings.Contains(out, "test-app is healthy, operation after") {
+		t.Errorf("modal should report the result:\n%s", out)
+	}
+	if m.handleWaitTick(waitTickMsg{id: st.ID}) != nil {
+		t.Error("a finished wait should stop ticking")
+	}
+}
+
+func TestWait_FailsAndTimesOut(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].OperationPhase = "Failed"
+	m.state.Apps[0].OperationMessage = "one or more objects failed to apply"
+	m.handleWaitCommand([]string{"test-app"})
+	if st := m.state.Modals.Wait; st.Result != "failed" || !strings.Contains(st.Message, "last operation Failed: one or more objects failed to apply") {
+		t.Errorf("a failed operation should end the wait, got %+v", st)
+	}
+
+	m.state.Apps[0].OperationPhase = ""
+	m.state.Apps[0].Sync = "OutOfSync"
+	m.handleWaitKeys(testKeyMsg("esc"))
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	m.waitClock = func() time.Time { return now }
+	m.handleWaitCommand([]string{"--for", "synced", "--timeout", "30s"})
+	st := m.state.Modals.Wait
+	now = now.Add(10 * time.Second)
+	if cmd := m.handleWaitTick(waitTickMsg{id: st.ID}); cmd == nil || st.Result != "" {
+		t.Fatal("the wait should keep ticking before the timeout")
+	}
+	if out := stripANSI(m.renderWaitModal()); !strings.Contains(out, "10s of 30s") {
+		t.Errorf("elapsed time should come from the wait clock:\n%s", out)
+	}
+	now = now.Add(21 * time.Second)
+	m.handleWaitTick(waitTickMsg{id: st.ID})
+	if st.Result != "timeout" || !strings.Contains(st.Message, "Timed out after 30s") {
+		t.Errorf("the wait should time out, got %+v", st)
+	}
+
+	m.handleWaitKeys(testKeyMsg("q"))
+	if m.state.Mode != model.ModeNormal || m.state.Modals.Wait != nil {
+		t.Error("q should close the wait modal")
+	}
+}
+
+func TestWait_RunsOnTheWallClockInDeterministicMode(t *testing.T) {
+	withDeterministicMode(t)
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].Sync = "OutOfSync"
+	m.handleWaitCommand([]string{"--for", "synced", "--timeout", "30s"})
+	st := m.state.Modals.Wait
+	if time.Since(st.StartedAt) > time.Minute {
+		t.Errorf("the wait should run on the wall clock so it can time out, started %s", st.StartedAt)
+	}
+}
+
+func TestWait_DisambiguatesAppsSharingAName(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	other := strp("other-namespace")
+	m.state.Apps[1].Name, m.state.Apps[1].AppNamespace = "test-app", other
+
+	m.handleWaitCommand([]string{"other-namespace/test-app"})
+	if st := m.state.Modals.Wait; st == nil || derefOr(st.AppNamespace) != *other {
+		t.Fatalf("ns/name should wait for the app in that namespace, got %+v", st)
+	}
+	m.handleWaitKeys(testKeyMsg("esc"))
+
+	for i, item := range m.getVisibleItemsForCurrentView() {
+		if app, ok := item.(model.App); ok && derefOr(app.AppNamespace) == *other {
+			m.state.Navigation.SelectedIdx = i
+		}
+	}
+	m.handleWaitCommand([]string{"test-app"})
+	if st := m.state.Modals.Wait; st == nil || derefOr(st.AppNamespace) != *other {
+		t.Fatalf("a bare name should pick the app under the cursor, got %+v", st)
+	}
+	m.handleWaitKeys(testKeyMsg("esc"))
+
+	m.handleWaitCommand([]string{"missing-namespace/test-app"})
+	if m.state.Modals.Wait != nil {
+		t.Error("ns/name with no app in that namespace should not start a wait")
+	}
+}
+
+func TestWait_TicksFromBeforeAContextSwitchAreDropped(t *testing.T) {
+	m := buildDeleteTestModel(120, 30)
+	m.state.Apps[0].Sync = "OutOfSync"
+	m.handleWaitCommand([]string{"--for", "synced"})
+	st := m.state.Modals.Wait
+	stale := waitTickMsg{id: st.ID, switchEpoch: m.switchEpoch}
+	m.switchEpoch++
+	if m.handleWaitTick(stale) != nil {
+		t.Error("a tick from the previous context should not keep a wait going")
+	}
+}
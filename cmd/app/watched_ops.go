@@ -0,0 +1,138 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// watchedOperationTTL bounds how long a watched operation waits to be seen
+// finishing before it is forgotten. Measured with the wall clock, not
+// clockNow, so entries still expire in deterministic mode.
+const watchedOperationTTL = time.Hour
+
+// watchedOperation is a sync or rollback started in this session that is
+// followed until it finishes: quitting while one runs asks first, and a
+// sync started with Watch posts its result to the webhook
+type watchedOperation struct {
+	kind         string // "sync" or "rollback"
+	appName      string
+	appNamespace string
+	// Operation start seen before the request; any other start is the new operation
+	prevStartedAt *time.Time
+	registeredAt  time.Time
+	// The server accepted the request; until then the app's next operation
+	// may be someone else's and a failed request must not look pending
+	confirmed bool
+	// Post the result to the notification webhook when it finishes
+	notify bool
+}
+
+// trackWatchedOperation remembers a requested sync or rollback, keyed by app
+func (m *Model) trackWatchedOperation(kind, appName string, appNamespace *string) {
+	op := watchedOperation{kind: kind, appName: appName, appNamespace: derefOr(appNamespace), registeredAt: time.Now()}
+	if app := m.findAppByNameAndNamespace(appName, op.appNamespace); app != nil {
+		op.prevStartedAt = app.OperationStartedAt
+	}
+	if m.watchedOps == nil {
+		m.watchedOps = make(map[string]watchedOperation)
+	}
+	m.watchedOps[appKey(appName, appNamespace)] = op
+}
+
+// trackWatchedSync remembers a sync started with Watch; its result is
+// posted when a webhook is configured
+func (m *Model) trackWatchedSync(appName string, appNamespace *string) {
+	m.trackWatchedOperation("sync", appName, appNamespace)
+	key := appKey(appName, appNamespace)
+	op := m.watchedOps[key]
+	op.notify = m.syncNotificationsEnabled()
+	m.watchedOps[key] = op
+}
+
+// confirmWatchedOperations marks the requested operations of a kind as
+// accepted by the server; keys are appKey values, with none given, all of
+// that kind
+func (m *Model) confirmWatchedOperations(kind string, keys ...string) {
+	for key, op := range m.watchedOps {
+		if op.kind != kind || op.confirmed {
+			continue
+		}
+		if len(keys) > 0 && !slices.Contains(keys, key) {
+			continue
+		}
+		op.confirmed = true
+		m.watchedOps[key] = op
+	}
+}
+
+// forgetWatchedOperation drops an operation whose request failed
+func (m *Model) forgetWatchedOperation(appName string, appNamespace *string) {
+	delete(m.watchedOps, appKey(appName, appNamespace))
+}
+
+// forgetUnconfirmedOperation drops the requested operation of a kind on an
+// app the server has not accepted, after it refused one
+func (m *Model) forgetUnconfirmedOperation(kind, appName string, appNamespace *string) {
+	key := appKey(appName, appNamespace)
+	if op, ok := m.watchedOps[key]; ok && !op.confirmed && op.kind == kind {
+		delete(m.watchedOps, key)
+	}
+}
+
+// expired reports whether op waited longer than watchedOperationTTL
+func (op watchedOperation) expired() bool {
+	return time.Since(op.registeredAt) > watchedOperationTTL
+}
+
+// startedOperation returns the app once it shows the operation started by
+// op, or nil while it still shows the one from before
+func (m *Model) startedOperation(op watchedOperation) *model.App {
+	app := m.findAppByNameAndNamespace(op.appName, op.appNamespace)
+	if app == nil || app.OperationStartedAt == nil {
+		return nil
+	}
+	if op.prevStartedAt != nil && app.OperationStartedAt.Equal(*op.prevStartedAt) {
+		return nil
+	}
+	return app
+}
+
+// checkWatchedOperations forgets watched operations that finished or
+// expired, posting notifications for finished syncs that asked for one.
+// Returns nil when there is nothing to post.
+func (m *Model) checkWatchedOperations() tea.Cmd {
+	var cmds []tea.Cmd
+	for key, op := range m.watchedOps {
+		if op.expired() {
+			delete(m.watchedOps, key)
+			continue
+		}
+		if !op.confirmed {
+			continue
+		}
+		app := m.startedOperation(op)
+		if app == nil || !isFinishedOperation(app.OperationPhase) {
+			continue
+		}
+		delete(m.watchedOps, key)
+		if op.notify {
+			cmds = append(cmds, m.sendSyncNotification(m.syncNotificationEvent(*app)))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+// isFinishedOperation reports whether an operation phase is final
+func isFinishedOperation(phase string) bool {
+	switch phase {
+	case "Succeeded", "Failed", "Error":
+		return true
+	}
+	return false
+}
@@ -266,11 +266,11 @@ func TestAppSetCommand_InvalidAppSet(t *testing.T) {
 	_ = tf.Enter()
 
 	// The validateCommand() function marks unknown arguments as invalid,
-	// so the command bar shows "unknown command" visual indicator.
+	// so the command bar names the argument it does not know.
 	// The command is still sent but validation prevents execution.
 	// We verify the UI rejects invalid input.
-	if !tf.WaitForPlain("unknown command", 3*time.Second) {
+	if !tf.WaitForPlain(`unknown appset "nonexistent"`, 3*time.Second) {
 		t.Log(tf.SnapshotPlain())
-		t.Fatal("expected unknown command indicator for invalid ApplicationSet")
+		t.Fatal("expected unknown appset indicator for invalid ApplicationSet")
 	}
 }
@@ -226,15 +226,14 @@ check_enabled = false`
 		// Force isolated Argonaut config - clear any inherited config paths
 		"ARGONAUT_CONFIG="+configPath,
 		"XDG_CONFIG_HOME=", // Clear XDG_CONFIG_HOME to ensure HOME-based path is used
+		// Keep app list snapshots per test
+		"ARGONAUT_CACHE_DIR="+filepath.Join(tf.workspace, "cache"),
 		// Tests don't need the production retry budget — collapse it so
 		// failure-path tests don't pay seconds of exponential back-off.
 		// Tests that specifically exercise the "Connecting…" spinner can
 		// override these via extraEnv (mergeEnv lets later entries win).
 		"ARGONAUT_RETRY_MAX_ATTEMPTS=2",
 		"ARGONAUT_RETRY_INITIAL_DELAY=10ms",
-		// The 500ms watch-scope debounce is real-user-friendly anti-thrash;
-		// in tests we want the restart to fire as soon as scope changes.
-		"ARGONAUT_WATCH_SCOPE_DEBOUNCE=10ms",
 		// The 500ms watch-event batch drain reduces render churn for busy
 		// streams; tests want time-to-first-render to be short.
 		"ARGONAUT_WATCH_BATCH_DRAIN=20ms",
@@ -321,15 +320,14 @@ check_enabled = false`
 		// Force isolated Argonaut config - clear any inherited config paths
 		"ARGONAUT_CONFIG="+configPath,
 		"XDG_CONFIG_HOME=", // Clear XDG_CONFIG_HOME to ensure HOME-based path is used
+		// Keep app list snapshots per test
+		"ARGONAUT_CACHE_DIR="+filepath.Join(tf.workspace, "cache"),
 		// Tests don't need the production retry budget — collapse it so
 		// failure-path tests don't pay seconds of exponential back-off.
 		// Tests that specifically exercise the "Connecting…" spinner can
 		// override these via extraEnv (mergeEnv lets later entries win).
 		"ARGONAUT_RETRY_MAX_ATTEMPTS=2",
 		"ARGONAUT_RETRY_INITIAL_DELAY=10ms",
-		// The 500ms watch-scope debounce is real-user-friendly anti-thrash;
-		// in tests we want the restart to fire as soon as scope changes.
-		"ARGONAUT_WATCH_SCOPE_DEBOUNCE=10ms",
 		// The 500ms watch-event batch drain reduces render churn for busy
 		// streams; tests want time-to-first-render to be short.
 		"ARGONAUT_WATCH_BATCH_DRAIN=20ms",
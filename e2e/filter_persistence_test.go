@@ -94,30 +94,30 @@ func TestFilterPersistsAfterResourcesAndK9s(t *testing.T) {
 	}
 
 	// Mock k9s exits after ~0.2s. Wait for argonaut to regain input routing
-	// (confirmed by being able to open the command bar) before sending Esc.
-	if err := tf.OpenCommand(); err != nil {
-		t.Fatalf("argonaut input not restored after k9s: %v", err)
+	// (confirmed by the command bar opening on the live screen; keys sent
+	// while k9s still runs go to k9s) before sending Esc.
+	if !waitUntil(t, func() bool {
+		_ = tf.Send(":")
+		return tf.WaitForScreen("│ > ", 500*time.Millisecond)
+	}, 10*time.Second) {
+		t.Fatalf("argonaut input not restored after k9s\n%s", tf.Screen())
 	}
 	_ = tf.Send("\x1b") // close command bar
-	// Global 100ms esc debounce — wait it out before the next esc.
-	time.Sleep(150 * time.Millisecond)
+	// Two escapes back to back read as one Alt+Esc; wait for the first to
+	// close the bar before sending the next.
+	if !waitUntil(t, func() bool { return !strings.Contains(tf.Screen(), "│ > ") }, 3*time.Second) {
+		t.Fatalf("command bar did not close\n%s", tf.Screen())
+	}
 
 	// Esc back to apps view.
 	_ = tf.Send("\x1b")
 
 	// We're back in apps view AND filter should still be applied.
 	// WaitForPlain scans the cumulative output ring, which includes stale
-	// "<apps:dem>" frames from before the tree view. Re-render by toggling
-	// search mode briefly, then assert against the live screen.
-	deadline := time.Now().Add(3 * time.Second)
-	var screen string
-	for time.Now().Before(deadline) {
-		screen = tf.Screen()
-		if strings.Contains(screen, "<apps:dem>") {
-			return // pass
-		}
-		time.Sleep(100 * time.Millisecond)
+	// "<apps:dem>" frames from before the tree view, so assert against the
+	// live screen.
+	if !tf.WaitForScreen("<apps:dem>", 3*time.Second) {
+		t.Log(tf.Screen())
+		t.Fatal("filter <apps:dem> was lost after returning from tree view")
 	}
-	t.Log(screen)
-	t.Fatal("filter <apps:dem> was lost after returning from tree view")
 }
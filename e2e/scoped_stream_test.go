@@ -181,10 +181,15 @@ func TestScopedStreamingFiltersEvents(t *testing.T) {
 
 	// Drill down into the first project by pressing Enter. The cursor's
 	// initial position can take an extra render frame to settle on row 1
-	// under heavy parallel load — give it a brief barrier, and if Enter
-	// didn't drill in (current screen still shows the projects list)
-	// retry once.
-	time.Sleep(100 * time.Millisecond)
+	// under heavy parallel load — wait for the list on the live screen,
+	// and if Enter didn't drill in (current screen still shows the projects
+	// list) retry once.
+	if !waitUntil(t, func() bool {
+		s := tf.Screen()
+		return strings.Contains(s, "backend") && strings.Contains(s, "frontend")
+	}, 3*time.Second) {
+		t.Fatalf("projects not on screen\n%s", tf.Screen())
+	}
 	_ = tf.Enter()
 	if !waitUntil(t, func() bool {
 		s := tf.Screen()
@@ -200,7 +205,7 @@ func TestScopedStreamingFiltersEvents(t *testing.T) {
 		}
 	}
 
-	// Wait for watch restart (500ms debounce + some margin)
+	// Wait for the watch restart; the scope debounce is off in e2e runs
 	if !waitUntil(t, func() bool { return rec.len() > initialRequests }, 3*time.Second) {
 		t.Fatalf("expected additional stream request after scope change, had %d requests before drill-down, still %d",
 			initialRequests, rec.len())
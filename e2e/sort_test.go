@@ -239,7 +239,7 @@ func TestSortRequiresValidDirection(t *testing.T) {
 	}
 
 	// Submit a sort command with an invalid direction. Production behaviour:
-	// the command-bar validation rejects it with the "unknown command"
+	// the command-bar validation rejects it with the "unknown sort direction"
 	// indicator and leaves the active sort unchanged.
 	if err := tf.OpenCommand(); err != nil {
 		t.Fatal(err)
@@ -247,9 +247,9 @@ func TestSortRequiresValidDirection(t *testing.T) {
 	_ = tf.Send("sort name backwards")
 	_ = tf.Enter()
 
-	if !tf.WaitForPlain("unknown command", 2*time.Second) {
+	if !tf.WaitForPlain("unknown sort direction", 2*time.Second) {
 		t.Log(tf.SnapshotPlain())
-		t.Fatal("expected `unknown command` indicator for `:sort name backwards`")
+		t.Fatal("expected `unknown sort direction` indicator for `:sort name backwards`")
 	}
 	if !tf.WaitForScreen("▲", 1*time.Second) {
 		t.Log(tf.Screen())
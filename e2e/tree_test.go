@@ -383,13 +383,13 @@ func TestTreeViewResourceSyncStatus(t *testing.T) {
 	}
 
 	// Verify Deployment shows OutOfSync status from resources array
-	if !tf.WaitForPlain("Deployment [default/nginx-deployment] (Healthy, OutOfSync)", 3*time.Second) {
+	if !tf.WaitForPlain("Deployment [default/nginx-deployment] (Healthy) (OutOfSync)", 3*time.Second) {
 		t.Log(tf.SnapshotPlain())
 		t.Fatal("Deployment sync status not shown correctly")
 	}
 
 	// Verify Service shows Synced status from resources array
-	if !tf.WaitForPlain("Service [default/nginx-service] (Healthy, Synced)", 3*time.Second) {
+	if !tf.WaitForPlain("Service [default/nginx-service] (Healthy) (Synced)", 3*time.Second) {
 		t.Log(tf.SnapshotPlain())
 		t.Fatal("Service sync status not shown correctly")
 	}
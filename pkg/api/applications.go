@@ -1,12 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,28 +24,49 @@ type OwnerReference struct {
 	UID        string `json:"uid,omitempty"`
 }
 
+// ApplicationSource describes one source of an application (git path or Helm chart)
+type ApplicationSource struct {
+	RepoURL        string `json:"repoURL,omitempty"`
+	Path           string `json:"path,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+	Chart          string `json:"chart,omitempty"`
+	Ref            string `json:"ref,omitempty"`
+	Name           string `json:"name,omitempty"`
+}
+
+// SourceHydrator describes an application rendered by the Argo CD source hydrator
+type SourceHydrator struct {
+	DrySource struct {
+		RepoURL        string `json:"repoURL,omitempty"`
+		TargetRevision string `json:"targetRevision,omitempty"`
+		Path           string `json:"path,omitempty"`
+	} `json:"drySource"`
+	SyncSource struct {
+		TargetBranch string `json:"targetBranch,omitempty"`
+		Path         string `json:"path,omitempty"`
+	} `json:"syncSource"`
+	HydrateTo *struct {
+		TargetBranch string `json:"targetBranch,omitempty"`
+	} `json:"hydrateTo,omitempty"`
+}
+
 // ArgoApplication represents an ArgoCD application from the API
 type ArgoApplication struct {
 	Metadata struct {
-		Name            string           `json:"name"`
-		Namespace       string           `json:"namespace,omitempty"`
-		OwnerReferences []OwnerReference `json:"ownerReferences,omitempty"`
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace,omitempty"`
+		Annotations     map[string]string `json:"annotations,omitempty"`
+		OwnerReferences []OwnerReference  `json:"ownerReferences,omitempty"`
 	} `json:"metadata"`
 	Spec struct {
 		Project string `json:"project,omitempty"`
 		// Single source (legacy/traditional)
-		Source *struct {
-			RepoURL        string `json:"repoURL,omitempty"`
-			Path           string `json:"path,omitempty"`
-			TargetRevision string `json:"targetRevision,omitempty"`
-		} `json:"source,omitempty"`
+		Source *ApplicationSource `json:"source,omitempty"`
 		// Multiple sources (newer multi-source support)
-		Sources []struct {
-			RepoURL        string `json:"repoURL,omitempty"`
-			Path           string `json:"path,omitempty"`
-			TargetRevision string `json:"targetRevision,omitempty"`
-		} `json:"sources,omitempty"`
-		Destination struct {
+		Sources []ApplicationSource `json:"sources,omitempty"`
+		// Source hydrator (dry source rendered into a sync branch)
+		SourceHydrator *SourceHydrator `json:"sourceHydrator,omitempty"`
+		Destination    struct {
 			Name      string `json:"name,omitempty"`
 			Server    string `json:"server,omitempty"`
 			Namespace string `json:"namespace,omitempty"`
@@ -72,9 +95,12 @@ type ArgoApplication struct {
 			Message string `json:"message,omitempty"`
 		} `json:"health"`
 		OperationState struct {
-			Phase      string    `json:"phase,omitempty"`
-			StartedAt  time.Time `json:"startedAt,omitempty"`
-			FinishedAt time.Time `json:"finishedAt,omitempty"`
+			Phase      string      `json:"phase,omitempty"`
+			Message    string      `json:"message,omitempty"`
+			StartedAt  time.Time   `json:"startedAt,omitempty"`
+			FinishedAt time.Time   `json:"finishedAt,omitempty"`
+			Operation  Operation   `json:"operation,omitempty"`
+			SyncResult *SyncResult `json:"syncResult,omitempty"`
 		} `json:"operationState,omitempty"`
 		History   []DeploymentHistory `json:"history,omitempty"`
 		Resources []ResourceStatus    `json:"resources,omitempty"`
@@ -85,6 +111,8 @@ type ArgoApplication struct {
 type ApplicationWatchEvent struct {
 	Type        string          `json:"type"`
 	Application ArgoApplication `json:"application"`
+	// StreamSeq is the event's entry in the stream log; 0 when not captured
+	StreamSeq uint64 `json:"-"`
 }
 
 // WatchEventResult wraps the watch event in the expected format
@@ -109,25 +137,44 @@ var AppListFields = []string{
 	"items.metadata.ownerReferences",
 	"items.spec",
 	"items.status.sync.status",
+	"items.status.sync.revision",
+	"items.status.sync.revisions",
 	"items.status.health",
+	"items.status.resources",
+	"items.status.operationState.phase",
+	"items.status.operationState.message",
 	"items.status.operationState.finishedAt",
 	"items.status.operationState.startedAt",
+	"items.status.operationState.operation.initiatedBy",
 }
 
 // AppWatchFields is intentionally empty — the stream endpoint does not support
 // field selection. Only resourceVersion is passed to avoid the initial full dump.
 var AppWatchFields []string
 
+// OperationInitiator records who started an operation: a user, or automation
+// such as auto-sync
+type OperationInitiator struct {
+	Username  string `json:"username,omitempty"`
+	Automated bool   `json:"automated,omitempty"`
+}
+
+// Operation is the subset of an Argo CD operation that Argonaut reads
+type Operation struct {
+	InitiatedBy OperationInitiator `json:"initiatedBy"`
+}
+
 // DeploymentHistory represents a deployment history entry from ArgoCD API
 type DeploymentHistory struct {
-	ID         int       `json:"id"`
-	Revision   string    `json:"revision"`
-	DeployedAt time.Time `json:"deployedAt"`
-	Source     *struct {
-		RepoURL        string `json:"repoURL,omitempty"`
-		Path           string `json:"path,omitempty"`
-		TargetRevision string `json:"targetRevision,omitempty"`
-	} `json:"source,omitempty"`
+	ID         int                `json:"id"`
+	Revision   string             `json:"revision"`
+	DeployedAt time.Time          `json:"deployedAt"`
+	Source     *ApplicationSource `json:"source,omitempty"`
+	// Multi-source deployments record one revision per source
+	Revisions []string            `json:"revisions,omitempty"`
+	Sources   []ApplicationSource `json:"sources,omitempty"`
+	// Who triggered the deployment (missing on history written by old Argo CD versions)
+	InitiatedBy *OperationInitiator `json:"initiatedBy,omitempty"`
 }
 
 // RevisionMetadataResponse represents git metadata response from ArgoCD API
@@ -183,8 +230,16 @@ func (s *ApplicationService) ListApplications(ctx context.Context) ([]model.App,
 func (s *ApplicationService) ListApplicationsWithMeta(ctx context.Context) (*ListApplicationsResult, error) {
 	// Build URL with field selection
 	endpoint := "/api/v1/applications"
+	params := url.Values{}
 	if len(AppListFields) > 0 {
-		endpoint += "?fields=" + url.QueryEscape(strings.Join(AppListFields, ","))
+		params.Set("fields", strings.Join(AppListFields, ","))
+	}
+	// Project role tokens are refused for apps outside their project
+	for _, p := range s.scopedProjects(nil) {
+		params.Add("projects", p)
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
 	}
 
 	data, err := s.client.Get(ctx, endpoint)
@@ -192,70 +247,14 @@ func (s *ApplicationService) ListApplicationsWithMeta(ctx context.Context) (*Lis
 		return nil, fmt.Errorf("failed to list applications: %w", err)
 	}
 
-	// First, try to parse as { metadata: { resourceVersion: "..." }, items: [...] }
-	var withItems struct {
-		Metadata struct {
-			ResourceVersion string `json:"resourceVersion"`
-		} `json:"metadata"`
-		Items []json.RawMessage `json:"items"`
-	}
-	if err := json.Unmarshal(data, &withItems); err != nil {
-		return nil, fmt.Errorf("failed to parse applications response: %w", err)
-	}
-
-	resourceVersion := withItems.Metadata.ResourceVersion
-
-	var rawItems []json.RawMessage
-	if len(withItems.Items) > 0 {
-		rawItems = withItems.Items
-	} else {
-		// Some servers may return a bare array instead of an object with items
-		if err := json.Unmarshal(data, &rawItems); err != nil {
-			return nil, fmt.Errorf("failed to parse applications array: %w", err)
-		}
+	resourceVersion, argoApps, err := decodeApplicationList(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
 	}
 
-	apps := make([]model.App, 0, len(rawItems))
-	for _, raw := range rawItems {
-		// Unmarshal into our typed struct first
-		var argoApp ArgoApplication
-		if err := json.Unmarshal(raw, &argoApp); err != nil {
-			// Skip malformed entry
-			continue
-		}
-
-		app := s.ConvertToApp(argoApp)
-
-		// Fallback: if sync/health are empty, extract directly from raw JSON
-		if app.Sync == "" || app.Health == "" || app.Sync == "Unknown" || app.Health == "Unknown" {
-			var root map[string]interface{}
-			if err := json.Unmarshal(raw, &root); err == nil {
-				if sMap, ok := root["status"].(map[string]interface{}); ok {
-					if app.Sync == "" || app.Sync == "Unknown" {
-						if syncMap, ok := sMap["sync"].(map[string]interface{}); ok {
-							if v, ok := syncMap["status"].(string); ok && v != "" {
-								app.Sync = v
-							}
-						}
-					}
-					if app.Health == "" || app.Health == "Unknown" {
-						if healthMap, ok := sMap["health"].(map[string]interface{}); ok {
-							if v, ok := healthMap["status"].(string); ok && v != "" {
-								app.Health = v
-							}
-						}
-					}
-				}
-			}
-			if app.Sync == "" {
-				app.Sync = "Unknown"
-			}
-			if app.Health == "" {
-				app.Health = "Unknown"
-			}
-		}
-
-		apps = append(apps, app)
+	apps := make([]model.App, 0, len(argoApps))
+	for _, argoApp := range argoApps {
+		apps = append(apps, s.ConvertToApp(argoApp))
 	}
 
 	return &ListApplicationsResult{
@@ -273,7 +272,8 @@ func (s *ApplicationService) GetManagedResourceDiffs(ctx context.Context, appNam
 	if appNamespace != "" {
 		path += "?appNamespace=" + url.QueryEscape(appNamespace)
 	}
-	data, err := s.client.Get(ctx, path)
+	// An app's diff can be huge; over the limit it is loaded per resource
+	data, err := s.client.GetWithSizeLimit(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get managed resources: %w", err)
 	}
@@ -290,6 +290,49 @@ func (s *ApplicationService) GetManagedResourceDiffs(ctx context.Context, appNam
 	return []ManagedResourceDiff{}, nil
 }
 
+// ManagedResourceKey identifies one managed resource of an application
+type ManagedResourceKey struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// GetManagedResourceDiff fetches the diff of a single managed resource,
+// filtered by its key on the server, for showing one resource of a large app
+// or one whose full managed-resources response is too large to load at once.
+// Returns nil when the app does not manage the resource.
+func (s *ApplicationService) GetManagedResourceDiff(ctx context.Context, appName string, appNamespace string, key ManagedResourceKey) (*ManagedResourceDiff, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	q := url.Values{}
+	q.Set("group", key.Group)
+	q.Set("kind", key.Kind)
+	q.Set("namespace", key.Namespace)
+	q.Set("name", key.Name)
+	if appNamespace != "" {
+		q.Set("appNamespace", appNamespace)
+	}
+	path := fmt.Sprintf("/api/v1/applications/%s/managed-resources?%s", url.PathEscape(appName), q.Encode())
+	data, err := s.client.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed resource: %w", err)
+	}
+
+	var withItems ManagedResourcesResponse
+	if err := json.Unmarshal(data, &withItems); err != nil {
+		return nil, fmt.Errorf("failed to decode managed resource: %w", err)
+	}
+	for i := range withItems.Items {
+		d := withItems.Items[i]
+		if d.Group == key.Group && d.Kind == key.Kind && d.Namespace == key.Namespace && d.Name == key.Name {
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
 // SyncApplication triggers a sync for the specified application
 func (s *ApplicationService) SyncApplication(ctx context.Context, appName string, opts *SyncOptions) error {
 	if opts == nil {
@@ -307,6 +350,22 @@ func (s *ApplicationService) SyncApplication(ctx context.Context, appName string
 		reqBody["resources"] = opts.Resources
 	}
 
+	// Restrict multi-source sync to specific sources
+	if len(opts.SourcePositions) > 0 {
+		reqBody["sourcePositions"] = opts.SourcePositions
+		reqBody["revisions"] = opts.Revisions
+	}
+
+	// The request's syncOptions replace the app's own for this operation, so
+	// start from the app's and override only the keys the sync sets
+	if items := opts.syncOptionItems(); len(items) > 0 {
+		appItems, err := s.appSyncOptions(ctx, appName, opts.AppNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to read sync options of %s: %w", appName, err)
+		}
+		reqBody["syncOptions"] = map[string]interface{}{"items": mergeSyncOptions(appItems, items)}
+	}
+
 	// Add force option via strategy if enabled
 	if opts.Force {
 		reqBody["strategy"] = map[string]interface{}{
@@ -347,6 +406,7 @@ func (s *ApplicationService) WatchApplicationsWithOptions(ctx context.Context, e
 	endpoint := "/api/v1/stream/applications"
 	params := url.Values{}
 
+	var projects []string
 	if opts != nil {
 		if opts.ResourceVersion != "" {
 			params.Set("resourceVersion", opts.ResourceVersion)
@@ -354,9 +414,11 @@ func (s *ApplicationService) WatchApplicationsWithOptions(ctx context.Context, e
 		if len(opts.Fields) > 0 {
 			params.Set("fields", strings.Join(opts.Fields, ","))
 		}
-		for _, p := range opts.Projects {
-			params.Add("projects", p)
-		}
+		projects = opts.Projects
+	}
+	// Without a filter, project role tokens watch their own project
+	for _, p := range s.scopedProjects(projects) {
+		params.Add("projects", p)
 	}
 
 	if len(params) > 0 {
@@ -416,27 +478,28 @@ func (s *ApplicationService) WatchApplicationsWithOptions(ctx context.Context, e
 		}
 
 		// Process SSE event lines
-		lines := strings.Split(string(eventData), "\n")
+		// Work on the raw bytes: events can be large and converting each one to
+		// strings costs a full copy per line before decoding.
+		lines := bytes.Split(eventData, []byte("\n"))
 		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" || line == ":" {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 || bytes.Equal(line, []byte(":")) {
 				// Skip empty lines and keep-alive messages
 				continue
 			}
 
-			cblog.With("component", "api").Debug("WatchApplications: processing line from event", "line", line)
+			cblog.With("component", "api").Debug("WatchApplications: processing line from event", "bytes", len(line))
 
 			// Handle Server-Sent Events format (lines starting with "data: ")
-			if strings.HasPrefix(line, "data: ") {
-				dataLine := strings.TrimPrefix(line, "data: ")
-
+			if dataLine, ok := bytes.CutPrefix(line, []byte("data: ")); ok {
 				var eventResult WatchEventResult
-				if err := json.Unmarshal([]byte(dataLine), &eventResult); err != nil {
-					cblog.With("component", "api").Warn("WatchApplications: failed to unmarshal event", "error", err, "line", dataLine)
+				if err := json.Unmarshal(dataLine, &eventResult); err != nil {
+					cblog.With("component", "api").Warn("WatchApplications: failed to unmarshal event", "error", err, "line", string(dataLine))
 					// Skip malformed lines
 					continue
 				}
 				cblog.With("component", "api").Debug("WatchApplications: parsed event", "type", eventResult.Result.Type, "app", eventResult.Result.Application.Metadata.Name)
+				eventResult.Result.StreamSeq = recordStreamEvent(eventResult.Result, dataLine)
 
 				select {
 				case eventChan <- eventResult.Result:
@@ -445,9 +508,9 @@ func (s *ApplicationService) WatchApplicationsWithOptions(ctx context.Context, e
 					cblog.With("component", "api").Debug("WatchApplications: context cancelled during send")
 					return ctx.Err()
 				}
-			} else if !strings.HasPrefix(line, ":") {
+			} else if !bytes.HasPrefix(line, []byte(":")) {
 				// Skip comment lines (starting with ":" ) but log unexpected lines
-				cblog.With("component", "api").Debug("WatchApplications: skipping non-data line", "line", line)
+				cblog.With("component", "api").Debug("WatchApplications: skipping non-data line", "line", string(line))
 			}
 		}
 	}
@@ -468,6 +531,85 @@ type SyncOptions struct {
 	Force        bool                 `json:"force,omitempty"`
 	AppNamespace string               `json:"appNamespace,omitempty"`
 	Resources    []SyncResourceTarget `json:"resources,omitempty"`
+	// SourcePositions limits a multi-source sync to the given 1-based source
+	// positions; Revisions holds the revision for each position.
+	SourcePositions []int64  `json:"sourcePositions,omitempty"`
+	Revisions       []string `json:"revisions,omitempty"`
+	// PruneLast prunes resources only after all other resources are synced
+	// and healthy; PrunePropagationPolicy is foreground, background or orphan;
+	// ServerSideApply applies with server-side apply. They are sent as
+	// syncOptions, merged over the app's own sync options.
+	PruneLast              bool   `json:"pruneLast,omitempty"`
+	PrunePropagationPolicy string `json:"prunePropagationPolicy,omitempty"`
+	ServerSideApply        bool   `json:"serverSideApply,omitempty"`
+}
+
+// syncOptionItems returns the syncOptions entries for opts, e.g.
+// "PrunePropagationPolicy=orphan", or nil when none are set
+func (opts *SyncOptions) syncOptionItems() []string {
+	var items []string
+	if opts.PrunePropagationPolicy != "" {
+		items = append(items, "PrunePropagationPolicy="+opts.PrunePropagationPolicy)
+	}
+	if opts.PruneLast {
+		items = append(items, "PruneLast=true")
+	}
+	if opts.ServerSideApply {
+		items = append(items, "ServerSideApply=true")
+	}
+	return items
+}
+
+// appSyncOptions returns the app's spec.syncPolicy.syncOptions
+func (s *ApplicationService) appSyncOptions(ctx context.Context, appName, appNamespace string) ([]string, error) {
+	var ns *string
+	if appNamespace != "" {
+		ns = &appNamespace
+	}
+	data, err := s.GetApplicationJSON(ctx, appName, ns)
+	if err != nil {
+		return nil, err
+	}
+	var app struct {
+		Spec struct {
+			SyncPolicy struct {
+				SyncOptions []string `json:"syncOptions"`
+			} `json:"syncPolicy"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &app); err != nil {
+		return nil, fmt.Errorf("failed to decode application response: %w", err)
+	}
+	return app.Spec.SyncPolicy.SyncOptions, nil
+}
+
+// mergeSyncOptions returns the app's sync options with the entries of
+// overrides replacing those of the same key, e.g. "PruneLast=true" replaces
+// "PruneLast=false"; keys the app does not declare are appended
+func mergeSyncOptions(app, overrides []string) []string {
+	key := func(item string) string {
+		k, _, _ := strings.Cut(item, "=")
+		return k
+	}
+	merged := make([]string, 0, len(app)+len(overrides))
+	set := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		set[key(o)] = o
+	}
+	for _, a := range app {
+		if o, ok := set[key(a)]; ok {
+			merged = append(merged, o)
+			delete(set, key(a))
+			continue
+		}
+		merged = append(merged, a)
+	}
+	for _, o := range overrides {
+		if _, ok := set[key(o)]; ok {
+			merged = append(merged, o)
+		}
+	}
+	return merged
 }
 
 // ConvertToApp converts an ArgoApplication to our model.App
@@ -499,24 +641,25 @@ func (s *ApplicationService) ConvertToApp(argoApp ArgoApplication) model.App {
 			id = argoApp.Spec.Destination.Name
 			label = id
 		} else {
-			server := argoApp.Spec.Destination.Server
-			if server == "https://kubernetes.default.svc" {
-				id = "in-cluster"
-				label = id
-			} else {
-				if u, err := url.Parse(server); err == nil && u.Host != "" {
-					id = u.Host
-					label = u.Host
-				} else {
-					id = server
-					label = server
-				}
-			}
+			id = ClusterIDForServer(argoApp.Spec.Destination.Server)
+			label = id
 		}
 		app.ClusterID = &id
 		app.ClusterLabel = &label
 	}
 
+	if by := argoApp.Status.OperationState.Operation.InitiatedBy; by.Username != "" || by.Automated {
+		app.LastOperationBy = &model.OperationInitiator{Username: by.Username, Automated: by.Automated}
+	}
+
+	// Last operation's progress, used to follow watched syncs to completion
+	app.OperationPhase = argoApp.Status.OperationState.Phase
+	app.OperationMessage = argoApp.Status.OperationState.Message
+	if !argoApp.Status.OperationState.StartedAt.IsZero() {
+		startedAt := argoApp.Status.OperationState.StartedAt
+		app.OperationStartedAt = &startedAt
+	}
+
 	// Handle sync timestamp
 	if !argoApp.Status.OperationState.FinishedAt.IsZero() {
 		app.LastSyncAt = &argoApp.Status.OperationState.FinishedAt
@@ -532,6 +675,42 @@ func (s *ApplicationService) ConvertToApp(argoApp ArgoApplication) model.App {
 		}
 	}
 
+	// Sources, paired with the synced revision for each one
+	app.MultiSource = argoApp.HasMultipleSources()
+	revisions := argoApp.Status.Sync.Revisions
+	if !app.MultiSource && argoApp.Status.Sync.Revision != "" {
+		revisions = []string{argoApp.Status.Sync.Revision}
+	}
+	for i, src := range argoApp.GetSources() {
+		as := model.AppSource{
+			RepoURL:        src.RepoURL,
+			Path:           src.Path,
+			Chart:          src.Chart,
+			TargetRevision: src.TargetRevision,
+			Ref:            src.Ref,
+			Name:           src.Name,
+		}
+		if i < len(revisions) {
+			as.Revision = revisions[i]
+		}
+		app.Sources = append(app.Sources, as)
+	}
+
+	if h := argoApp.Spec.SourceHydrator; h != nil {
+		app.Hydrator = &model.AppHydrator{
+			DryRepoURL:  h.DrySource.RepoURL,
+			DryRevision: h.DrySource.TargetRevision,
+			DryPath:     h.DrySource.Path,
+			SyncBranch:  h.SyncSource.TargetBranch,
+			SyncPath:    h.SyncSource.Path,
+		}
+		if h.HydrateTo != nil {
+			app.Hydrator.HydrateToBranch = h.HydrateTo.TargetBranch
+		}
+	}
+
+	app.DeprecatedAPIs = argoApp.DeprecatedAPIs()
+
 	// Normalize status values to match TypeScript app
 	if app.Sync == "" {
 		app.Sync = "Unknown"
@@ -548,12 +727,8 @@ func (app *ArgoApplication) HasMultipleSources() bool {
 	return len(app.Spec.Sources) > 0
 }
 
-// GetPrimarySources returns either the single source or the first source from multiple sources
-func (app *ArgoApplication) GetPrimarySource() *struct {
-	RepoURL        string `json:"repoURL,omitempty"`
-	Path           string `json:"path,omitempty"`
-	TargetRevision string `json:"targetRevision,omitempty"`
-} {
+// GetPrimarySource returns either the single source or the first source from multiple sources
+func (app *ArgoApplication) GetPrimarySource() *ApplicationSource {
 	if app.Spec.Source != nil {
 		return app.Spec.Source
 	}
@@ -563,6 +738,28 @@ func (app *ArgoApplication) GetPrimarySource() *struct {
 	return nil
 }
 
+// GetSources returns every source in spec order, for single- and multi-source apps
+func (app *ArgoApplication) GetSources() []ApplicationSource {
+	if app.HasMultipleSources() {
+		return app.Spec.Sources
+	}
+	if app.Spec.Source != nil {
+		return []ApplicationSource{*app.Spec.Source}
+	}
+	return nil
+}
+
+// GitSourceIndex returns the index of the first non-chart source, which is the
+// one whose revisions carry git metadata. Falls back to 0 when every source is a chart.
+func GitSourceIndex(sources []ApplicationSource) int {
+	for i, src := range sources {
+		if src.Chart == "" {
+			return i
+		}
+	}
+	return 0
+}
+
 // ResourceNode represents a Kubernetes resource from ArgoCD API
 type ResourceNode struct {
 	Kind           string          `json:"kind"`
@@ -819,6 +1016,24 @@ func (s *ApplicationService) RefreshApplication(ctx context.Context, name string
 	return nil
 }
 
+// TerminateOperation stops the sync or rollback currently running on the application
+func (s *ApplicationService) TerminateOperation(ctx context.Context, name string, appNamespace *string) error {
+	if name == "" {
+		return fmt.Errorf("application name is required")
+	}
+
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/operation", url.PathEscape(name))
+	if appNamespace != nil && *appNamespace != "" {
+		endpoint += "?appNamespace=" + url.QueryEscape(*appNamespace)
+	}
+
+	if _, err := s.client.Delete(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to terminate operation of %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // GetRevisionMetadata fetches git metadata for a specific revision
 func (s *ApplicationService) GetRevisionMetadata(ctx context.Context, name string, revision string, appNamespace *string) (*model.RevisionMetadata, error) {
 	endpoint := fmt.Sprintf("/api/v1/applications/%s/revisions/%s/metadata", name, revision)
@@ -844,6 +1059,35 @@ func (s *ApplicationService) GetRevisionMetadata(ctx context.Context, name strin
 	}, nil
 }
 
+// GetRevisionMetadataForSource fetches git metadata for a revision of one source
+// of a multi-source app. versionID is the deployment history ID the revision came from.
+func (s *ApplicationService) GetRevisionMetadataForSource(ctx context.Context, name string, revision string, appNamespace *string, sourceIndex int, versionID int) (*model.RevisionMetadata, error) {
+	q := url.Values{}
+	q.Set("sourceIndex", strconv.Itoa(sourceIndex))
+	q.Set("versionId", strconv.Itoa(versionID))
+	if appNamespace != nil && *appNamespace != "" {
+		q.Set("appNamespace", *appNamespace)
+	}
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/revisions/%s/metadata?%s", name, revision, q.Encode())
+
+	resp, err := s.client.Get(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision metadata for %s@%s (source %d): %w", name, revision, sourceIndex, err)
+	}
+
+	var metadata RevisionMetadataResponse
+	if err := json.Unmarshal(resp, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode revision metadata response: %w", err)
+	}
+
+	return &model.RevisionMetadata{
+		Author:  metadata.Author,
+		Date:    metadata.Date,
+		Message: metadata.Message,
+		Tags:    metadata.Tags,
+	}, nil
+}
+
 // RollbackApplication performs a rollback operation
 func (s *ApplicationService) RollbackApplication(ctx context.Context, request model.RollbackRequest) error {
 	endpoint := fmt.Sprintf("/api/v1/applications/%s/rollback", request.Name)
@@ -995,6 +1239,19 @@ func ConvertDeploymentHistoryToRollbackRows(history []DeploymentHistory) []model
 			Message:    nil, // Will be loaded asynchronously
 			MetaError:  nil,
 		}
+		if by := deployment.InitiatedBy; by != nil && (by.Username != "" || by.Automated) {
+			row.InitiatedBy = &model.OperationInitiator{Username: by.Username, Automated: by.Automated}
+		}
+		// Multi-source deployments have no single revision; show the git source's one
+		if len(deployment.Revisions) > 0 {
+			idx := GitSourceIndex(deployment.Sources)
+			if idx >= len(deployment.Revisions) {
+				idx = 0
+			}
+			row.Revision = deployment.Revisions[idx]
+			row.Revisions = deployment.Revisions
+			row.SourceIndex = &idx
+		}
 		rows = append(rows, row)
 	}
 
@@ -234,3 +234,26 @@ func TestDeleteApplication_NoCascade(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 }
+
+func TestTerminateOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/applications/test-app/operation" {
+			t.Errorf("Expected the operation path, got %s", r.URL.Path)
+		}
+		if ns := r.URL.Query().Get("appNamespace"); ns != "team-a" {
+			t.Errorf("Expected appNamespace=team-a, got %s", ns)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	ns := "team-a"
+	if err := svc.TerminateOperation(context.Background(), "test-app", &ns); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
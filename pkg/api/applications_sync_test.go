@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestSyncApplication_PruneSyncOptions(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"metadata": {"name": "web"}, "spec": {"syncPolicy": {"syncOptions": ["CreateNamespace=true", "PruneLast=false"]}}}`))
+			return
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+
+	if err := svc.SyncApplication(context.Background(), "web", &SyncOptions{Prune: true}); err != nil {
+		t.Fatalf("SyncApplication returned error: %v", err)
+	}
+	if _, ok := got["syncOptions"]; ok {
+		t.Errorf("syncOptions should be omitted when no advanced option is set: %v", got["syncOptions"])
+	}
+
+	err := svc.SyncApplication(context.Background(), "web", &SyncOptions{
+		Prune:                  true,
+		PruneLast:              true,
+		PrunePropagationPolicy: "background",
+		ServerSideApply:        true,
+	})
+	if err != nil {
+		t.Fatalf("SyncApplication returned error: %v", err)
+	}
+	syncOptions, _ := got["syncOptions"].(map[string]interface{})
+	items, _ := syncOptions["items"].([]interface{})
+	want := []interface{}{"CreateNamespace=true", "PruneLast=true", "PrunePropagationPolicy=background", "ServerSideApply=true"}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("syncOptions = %v, want the app's own with the modal's overrides %v", items, want)
+	}
+}
+
+func TestMergeSyncOptions(t *testing.T) {
+	got := mergeSyncOptions(
+		[]string{"CreateNamespace=true", "PrunePropagationPolicy=foreground", "Validate=false"},
+		[]string{"PrunePropagationPolicy=orphan", "PruneLast=true"},
+	)
+	want := []string{"CreateNamespace=true", "PrunePropagationPolicy=orphan", "Validate=false", "PruneLast=true"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeSyncOptions() = %v, want %v", got, want)
+	}
+}
+
+func TestSyncApplication_ServerSideApplyKeepsAppSyncOptions(t *testing.T) {
+	var got struct {
+		SyncOptions struct {
+			Items []string `json:"items"`
+		} `json:"syncOptions"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Query().Get("appNamespace") != "team-a" {
+				t.Errorf("the app should be read from its namespace, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"spec": {"syncPolicy": {"syncOptions": ["ServerSideApply=false", "CreateNamespace=true", "RespectIgnoreDifferences=true"]}}}`))
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	if err := svc.SyncApplication(context.Background(), "web", &SyncOptions{AppNamespace: "team-a", ServerSideApply: true}); err != nil {
+		t.Fatalf("SyncApplication returned error: %v", err)
+	}
+	want := []string{"ServerSideApply=true", "CreateNamespace=true", "RespectIgnoreDifferences=true"}
+	if !reflect.DeepEqual(got.SyncOptions.Items, want) {
+		t.Errorf("syncOptions = %v, want %v", got.SyncOptions.Items, want)
+	}
+}
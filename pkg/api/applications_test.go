@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -10,9 +11,10 @@ func TestConvertToApp_WithApplicationSet(t *testing.T) {
 
 	argoApp := ArgoApplication{
 		Metadata: struct {
-			Name            string           `json:"name"`
-			Namespace       string           `json:"namespace,omitempty"`
-			OwnerReferences []OwnerReference `json:"ownerReferences,omitempty"`
+			Name            string            `json:"name"`
+			Namespace       string            `json:"namespace,omitempty"`
+			Annotations     map[string]string `json:"annotations,omitempty"`
+			OwnerReferences []OwnerReference  `json:"ownerReferences,omitempty"`
 		}{
 			Name:      "test-app",
 			Namespace: "argocd",
@@ -48,9 +50,12 @@ func TestConvertToApp_WithApplicationSet(t *testing.T) {
 				Message string `json:"message,omitempty"`
 			} `json:"health"`
 			OperationState struct {
-				Phase      string    `json:"phase,omitempty"`
-				StartedAt  time.Time `json:"startedAt,omitempty"`
-				FinishedAt time.Time `json:"finishedAt,omitempty"`
+				Phase      string      `json:"phase,omitempty"`
+				Message    string      `json:"message,omitempty"`
+				StartedAt  time.Time   `json:"startedAt,omitempty"`
+				FinishedAt time.Time   `json:"finishedAt,omitempty"`
+				Operation  Operation   `json:"operation,omitempty"`
+				SyncResult *SyncResult `json:"syncResult,omitempty"`
 			} `json:"operationState,omitempty"`
 			History   []DeploymentHistory `json:"history,omitempty"`
 			Resources []ResourceStatus    `json:"resources,omitempty"`
@@ -99,9 +104,10 @@ func TestConvertToApp_WithoutApplicationSet(t *testing.T) {
 
 	argoApp := ArgoApplication{
 		Metadata: struct {
-			Name            string           `json:"name"`
-			Namespace       string           `json:"namespace,omitempty"`
-			OwnerReferences []OwnerReference `json:"ownerReferences,omitempty"`
+			Name            string            `json:"name"`
+			Namespace       string            `json:"namespace,omitempty"`
+			Annotations     map[string]string `json:"annotations,omitempty"`
+			OwnerReferences []OwnerReference  `json:"ownerReferences,omitempty"`
 		}{
 			Name:            "standalone-app",
 			Namespace:       "argocd",
@@ -130,9 +136,12 @@ func TestConvertToApp_WithoutApplicationSet(t *testing.T) {
 				Message string `json:"message,omitempty"`
 			} `json:"health"`
 			OperationState struct {
-				Phase      string    `json:"phase,omitempty"`
-				StartedAt  time.Time `json:"startedAt,omitempty"`
-				FinishedAt time.Time `json:"finishedAt,omitempty"`
+				Phase      string      `json:"phase,omitempty"`
+				Message    string      `json:"message,omitempty"`
+				StartedAt  time.Time   `json:"startedAt,omitempty"`
+				FinishedAt time.Time   `json:"finishedAt,omitempty"`
+				Operation  Operation   `json:"operation,omitempty"`
+				SyncResult *SyncResult `json:"syncResult,omitempty"`
 			} `json:"operationState,omitempty"`
 			History   []DeploymentHistory `json:"history,omitempty"`
 			Resources []ResourceStatus    `json:"resources,omitempty"`
@@ -174,9 +183,10 @@ func TestConvertToApp_WithOtherOwnerReference(t *testing.T) {
 	// Test that apps with non-ApplicationSet owner references don't get an ApplicationSet field
 	argoApp := ArgoApplication{
 		Metadata: struct {
-			Name            string           `json:"name"`
-			Namespace       string           `json:"namespace,omitempty"`
-			OwnerReferences []OwnerReference `json:"ownerReferences,omitempty"`
+			Name            string            `json:"name"`
+			Namespace       string            `json:"namespace,omitempty"`
+			Annotations     map[string]string `json:"annotations,omitempty"`
+			OwnerReferences []OwnerReference  `json:"ownerReferences,omitempty"`
 		}{
 			Name:      "app-with-other-owner",
 			Namespace: "argocd",
@@ -212,9 +222,12 @@ func TestConvertToApp_WithOtherOwnerReference(t *testing.T) {
 				Message string `json:"message,omitempty"`
 			} `json:"health"`
 			OperationState struct {
-				Phase      string    `json:"phase,omitempty"`
-				StartedAt  time.Time `json:"startedAt,omitempty"`
-				FinishedAt time.Time `json:"finishedAt,omitempty"`
+				Phase      string      `json:"phase,omitempty"`
+				Message    string      `json:"message,omitempty"`
+				StartedAt  time.Time   `json:"startedAt,omitempty"`
+				FinishedAt time.Time   `json:"finishedAt,omitempty"`
+				Operation  Operation   `json:"operation,omitempty"`
+				SyncResult *SyncResult `json:"syncResult,omitempty"`
 			} `json:"operationState,omitempty"`
 			History   []DeploymentHistory `json:"history,omitempty"`
 			Resources []ResourceStatus    `json:"resources,omitempty"`
@@ -249,3 +262,36 @@ func TestConvertToApp_WithOtherOwnerReference(t *testing.T) {
 		t.Errorf("Expected ApplicationSet to be nil for app with non-ApplicationSet owner, got %v", *app.ApplicationSet)
 	}
 }
+
+func TestConvertToApp_OperationInitiator(t *testing.T) {
+	var argoApp ArgoApplication
+	data := `{"metadata": {"name": "web"}, "status": {"operationState": {"phase": "Succeeded", "operation": {"initiatedBy": {"automated": true}}}}}`
+	if err := json.Unmarshal([]byte(data), &argoApp); err != nil {
+		t.Fatal(err)
+	}
+	app := (&ApplicationService{}).ConvertToApp(argoApp)
+	if app.LastOperationBy == nil || !app.LastOperationBy.Automated {
+		t.Fatalf("expected automated initiator, got %+v", app.LastOperationBy)
+	}
+
+	argoApp = ArgoApplication{}
+	if err := json.Unmarshal([]byte(`{"metadata": {"name": "web"}}`), &argoApp); err != nil {
+		t.Fatal(err)
+	}
+	if app := (&ApplicationService{}).ConvertToApp(argoApp); app.LastOperationBy != nil {
+		t.Errorf("app without an operation should have no initiator, got %+v", app.LastOperationBy)
+	}
+}
+
+func TestConvertDeploymentHistoryToRollbackRows_InitiatedBy(t *testing.T) {
+	rows := ConvertDeploymentHistoryToRollbackRows([]DeploymentHistory{
+		{ID: 1, Revision: "abc", InitiatedBy: &OperationInitiator{Username: "alice"}},
+		{ID: 2, Revision: "def"},
+	})
+	if rows[0].InitiatedBy == nil || rows[0].InitiatedBy.Username != "alice" {
+		t.Errorf("expected alice, got %+v", rows[0].InitiatedBy)
+	}
+	if rows[1].InitiatedBy != nil {
+		t.Errorf("history without initiator should stay nil, got %+v", rows[1].InitiatedBy)
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/darksworm/argonaut/pkg/compat"
+	apperrors "github.com/darksworm/argonaut/pkg/errors"
+)
+
+// probeAppName is an application name no server can have (underscores are
+// not valid in Kubernetes names), so a probe request is always turned away
+// and touches nothing
+const probeAppName = "argonaut_capability_probe"
+
+// endpointProbe is a request that tells whether an optional endpoint exists:
+// a server that has it rejects the request for the unknown app, one that
+// doesn't has no route for it
+type endpointProbe struct {
+	feature compat.Feature
+	method  string
+	path    string
+}
+
+// endpointProbes lists the optional endpoints argonaut probes at startup
+var endpointProbes = []endpointProbe{
+	{feature: compat.ResourceActions, method: "POST", path: "/api/v1/applications/%s/resource/actions/v2"},
+	{feature: compat.AppEvents, method: "GET", path: "/api/v1/applications/%s/events"},
+	{feature: compat.ApplicationSets, method: "GET", path: "/api/v1/applicationsets/%s"},
+}
+
+// fieldProbe is a request field that tells whether a feature exists. The
+// server ignores fields it doesn't know, so asking it can't tell; its API
+// description, served at /swagger.json, lists them.
+type fieldProbe struct {
+	feature    compat.Feature
+	definition string
+	field      string
+}
+
+// fieldProbes lists the optional request fields argonaut probes at startup
+var fieldProbes = []fieldProbe{
+	{feature: compat.SourceSync, definition: "applicationApplicationSyncRequest", field: "sourcePositions"},
+}
+
+// ProbeEndpoints asks the server which of the optional endpoints it serves.
+// Features whose probe was inconclusive (network or auth errors) are left
+// out, so the version check decides for them.
+func (s *ApplicationService) ProbeEndpoints(ctx context.Context) map[compat.Feature]bool {
+	served := make(map[compat.Feature]bool, len(endpointProbes))
+	for _, p := range endpointProbes {
+		path := fmt.Sprintf(p.path, url.PathEscape(probeAppName))
+		var err error
+		switch p.method {
+		case "POST":
+			_, err = s.client.Post(ctx, path, map[string]interface{}{"name": probeAppName})
+		default:
+			_, err = s.client.Get(ctx, path)
+		}
+		switch {
+		case err == nil:
+			served[p.feature] = true
+		case IsEndpointMissing(err):
+			served[p.feature] = false
+		case hasStatus(err, 400, 403, 404, 409, 422):
+			// The route exists; the server turned the unknown app away
+			served[p.feature] = true
+		}
+	}
+	for f, ok := range s.probeFields(ctx) {
+		served[f] = ok
+	}
+	return served
+}
+
+// probeFields reads the server's API description for the optional request
+// fields; it returns nothing when the description can't be read
+func (s *ApplicationService) probeFields(ctx context.Context) map[compat.Feature]bool {
+	data, err := s.client.Get(ctx, "/swagger.json")
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil || len(doc.Definitions) == 0 {
+		return nil
+	}
+	served := make(map[compat.Feature]bool, len(fieldProbes))
+	for _, p := range fieldProbes {
+		def, ok := doc.Definitions[p.definition]
+		if !ok {
+			// Renamed rather than missing; let the version decide
+			continue
+		}
+		_, served[p.feature] = def.Properties[p.field]
+	}
+	return served
+}
+
+// IsEndpointMissing reports whether err says the server has no route for
+// the request, rather than that the request itself was refused: a 404 with
+// the gateway's bare "Not Found" (an unknown app reads "application ... not
+// found"), or a 405/501 for a path it only serves with other methods
+func IsEndpointMissing(err error) bool {
+	if hasStatus(err, 405, 501) {
+		return true
+	}
+	var argErr *apperrors.ArgonautError
+	return hasStatus(err, 404) && errors.As(err, &argErr) &&
+		strings.EqualFold(strings.TrimSpace(argErr.Message), "not found")
+}
+
+// hasStatus reports whether err is an API error with one of the statuses
+func hasStatus(err error, statuses ...int) bool {
+	var argErr *apperrors.ArgonautError
+	if !errors.As(err, &argErr) || argErr.Context == nil {
+		return false
+	}
+	code, ok := argErr.Context["statusCode"].(int)
+	if !ok {
+		return false
+	}
+	for _, s := range statuses {
+		if code == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/compat"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestProbeEndpoints(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		want     bool
+		decisive bool
+	}{
+		{"unknown app", http.StatusNotFound, `{"code":5,"message":"applications.argoproj.io \"argonaut_capability_probe\" not found"}`, true, true},
+		{"permission denied", http.StatusForbidden, `{"code":7,"message":"permission denied"}`, true, true},
+		{"no route", http.StatusNotFound, `{"code":5,"error":"Not Found","message":"Not Found"}`, false, true},
+		{"method not allowed", http.StatusMethodNotAllowed, `{"code":12,"message":"Method Not Allowed"}`, false, true},
+		{"not implemented", http.StatusNotImplemented, `{"code":12,"message":"Method Not Allowed"}`, false, true},
+		{"unauthorized", http.StatusUnauthorized, `{"code":16,"message":"invalid session"}`, false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			probes := map[string]compat.Feature{
+				"POST /api/v1/applications/argonaut_capability_probe/resource/actions/v2": compat.ResourceActions,
+				"GET /api/v1/applications/argonaut_capability_probe/events":               compat.AppEvents,
+				"GET /api/v1/applicationsets/argonaut_capability_probe":                   compat.ApplicationSets,
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := probes[r.Method+" "+r.URL.Path]; !ok && r.URL.Path != "/swagger.json" {
+					t.Errorf("unexpected probe %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(c.status)
+				w.Write([]byte(c.body))
+			}))
+			defer server.Close()
+
+			svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+			served := svc.ProbeEndpoints(context.Background())
+			for _, f := range probes {
+				got, ok := served[f]
+				if ok != c.decisive {
+					t.Fatalf("%s: decisive = %v, want %v (served %v)", f, ok, c.decisive, served)
+				}
+				if ok && got != c.want {
+					t.Errorf("%s: served = %v, want %v", f, got, c.want)
+				}
+			}
+			if _, ok := served[compat.SourceSync]; ok {
+				t.Error("fields should be left to the version without an API description")
+			}
+		})
+	}
+}
+
+func TestProbeEndpoints_RequestFields(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		swagger string
+		want    bool
+	}{
+		{"field listed", `{"definitions": {"applicationApplicationSyncRequest": {"properties": {"name": {}, "sourcePositions": {}}}}}`, true},
+		{"field missing", `{"definitions": {"applicationApplicationSyncRequest": {"properties": {"name": {}}}}}`, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/swagger.json" {
+					w.Write([]byte(c.swagger))
+					return
+				}
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"code":7,"message":"permission denied"}`))
+			}))
+			defer server.Close()
+
+			svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+			got, ok := svc.ProbeEndpoints(context.Background())[compat.SourceSync]
+			if !ok || got != c.want {
+				t.Errorf("source sync served = %v (decisive %v), want %v", got, ok, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"strings"
+)
+
+// CertificateError reports whether err is the server's TLS certificate
+// failing verification (expired, self-signed, unknown authority or issued
+// for another host), and returns what was wrong with it
+func CertificateError(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	var verifyErr *tls.CertificateVerificationError
+	if errors.As(err, &verifyErr) {
+		return verifyErr.Err.Error(), true
+	}
+	var invalid x509.CertificateInvalidError
+	if errors.As(err, &invalid) {
+		return invalid.Error(), true
+	}
+	var unknownAuthority x509.UnknownAuthorityError
+	if errors.As(err, &unknownAuthority) {
+		return unknownAuthority.Error(), true
+	}
+	var hostname x509.HostnameError
+	if errors.As(err, &hostname) {
+		return hostname.Error(), true
+	}
+	// Errors that crossed a message or log boundary only keep their text
+	msg := err.Error()
+	if i := strings.Index(msg, "x509: "); i >= 0 {
+		return msg[i:], true
+	}
+	return "", false
+}
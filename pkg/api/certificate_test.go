@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestCertificateError_SelfSignedServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(&model.Server{BaseURL: server.URL, Token: "t"}).Get(context.Background(), "/api/v1/session/userinfo")
+	problem, ok := CertificateError(fmt.Errorf("failed to get user info: %w", err))
+	if !ok || !strings.Contains(problem, "x509") {
+		t.Fatalf("a self-signed certificate should be reported, got %q, %v (err %v)", problem, ok, err)
+	}
+
+	if _, err := NewClient(&model.Server{BaseURL: server.URL, Token: "t", Insecure: true}).Get(context.Background(), "/api/v1/session/userinfo"); err != nil {
+		t.Errorf("insecure clients should not verify the certificate: %v", err)
+	}
+}
+
+func TestCertificateError_OtherErrors(t *testing.T) {
+	if _, ok := CertificateError(errors.New("dial tcp 10.0.0.1:443: connect: connection refused")); ok {
+		t.Error("a refused connection is not a certificate error")
+	}
+	if _, ok := CertificateError(nil); ok {
+		t.Error("nil is not a certificate error")
+	}
+	problem, ok := CertificateError(errors.New(`Get "https://argocd": tls: failed to verify certificate: x509: certificate has expired or is not yet valid`))
+	if !ok || problem != "x509: certificate has expired or is not yet valid" {
+		t.Errorf("errors known only by their text should be recognized, got %q", problem)
+	}
+}
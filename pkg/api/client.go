@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -32,6 +33,19 @@ type Client struct {
 
 var customHTTPClient *http.Client
 
+// DefaultMaxResponseSize caps how much of a single response body is read into memory
+const DefaultMaxResponseSize int64 = 32 << 20
+
+// maxResponseSize is the limit applied by GetWithSizeLimit; zero or less
+// disables it
+var maxResponseSize = DefaultMaxResponseSize
+
+// SetMaxResponseSize sets the largest response body GetWithSizeLimit reads
+// into memory. Zero or a negative size disables the limit.
+func SetMaxResponseSize(n int64) {
+	maxResponseSize = n
+}
+
 // SetHTTPClient sets a custom HTTP client to be used by all new Client instances
 func SetHTTPClient(client *http.Client) {
 	customHTTPClient = client
@@ -148,14 +162,16 @@ func sanitizeURL(rawURL string) string {
 	return rawURL
 }
 
-// buildURL constructs the full URL including the gRPC-web root path if configured
+// buildURL constructs the full URL including the gRPC-web root path if
+// configured. The base URL may carry a path of its own, like
+// https://example.com/argocd; slashes where the parts meet are not doubled.
 func (c *Client) buildURL(path string) string {
-	if c.grpcWebRootPath != "" {
-		// Trim leading and trailing slashes from root path, similar to ArgoCD implementation
-		rootPath := strings.TrimRight(strings.TrimLeft(c.grpcWebRootPath, "/"), "/")
-		return fmt.Sprintf("%s/%s%s", c.baseURL, rootPath, path)
+	base := strings.TrimRight(c.baseURL, "/")
+	// Trim leading and trailing slashes from root path, similar to ArgoCD implementation
+	if rootPath := strings.Trim(c.grpcWebRootPath, "/"); rootPath != "" {
+		base += "/" + rootPath
 	}
-	return c.baseURL + path
+	return base + "/" + strings.TrimLeft(path, "/")
 }
 
 // Get performs a GET request with retry logic.
@@ -163,10 +179,22 @@ func (c *Client) buildURL(path string) string {
 // or WithMinAPITimeout). Do not add a timeout here — it would undercut WithMinAPITimeout
 // callers that need a longer deadline for slow operations like diffs and rollbacks.
 func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	return c.get(ctx, path, 0)
+}
+
+// GetWithSizeLimit is Get for responses the caller can do without, such as
+// an app's full diff, which can be loaded a resource at a time instead.
+// Bodies larger than the configured limit are not returned; see
+// ResponseTooLargeError.
+func (c *Client) GetWithSizeLimit(ctx context.Context, path string) ([]byte, error) {
+	return c.get(ctx, path, maxResponseSize)
+}
+
+func (c *Client) get(ctx context.Context, path string, limit int64) ([]byte, error) {
 	var result []byte
 	err := retry.RetryNetworkOperation(ctx, fmt.Sprintf("GET %s", path), func(attempt int) error {
 		var opErr error
-		result, opErr = c.request(ctx, "GET", path, nil)
+		result, opErr = c.requestWithLimit(ctx, "GET", path, nil, limit)
 		return opErr
 	})
 
@@ -199,6 +227,19 @@ func (c *Client) Put(ctx context.Context, path string, body interface{}) ([]byte
 	return result, err
 }
 
+// Patch performs a PATCH request with retry logic.
+// See Get for timeout responsibility.
+func (c *Client) Patch(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	var result []byte
+	err := retry.RetryNetworkOperation(ctx, fmt.Sprintf("PATCH %s", path), func(attempt int) error {
+		var opErr error
+		result, opErr = c.request(ctx, "PATCH", path, body)
+		return opErr
+	})
+
+	return result, err
+}
+
 // Delete performs a DELETE request with retry logic.
 // See Get for timeout responsibility.
 func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
@@ -277,6 +318,19 @@ func (c *Client) Stream(ctx context.Context, path string) (*StreamResponse, erro
 
 // request performs the actual HTTP request
 func (c *Client) request(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	return c.requestWithLimit(ctx, method, path, body, 0)
+}
+
+func (c *Client) requestWithLimit(ctx context.Context, method, path string, body interface{}, limit int64) (_ []byte, err error) {
+	trace := RequestTrace{At: time.Now(), Method: method, Path: path}
+	defer func() {
+		trace.Duration = time.Since(trace.At)
+		if err != nil {
+			trace.Err = err.Error()
+		}
+		recordRequest(trace)
+	}()
+
 	// Retrieve the original timeout duration for accurate error messages.
 	// Uses the value stored by WithAPITimeout/WithMinAPITimeout at context
 	// creation time, avoiding time.Until(deadline) which drifts on retries.
@@ -323,6 +377,7 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 		"timeout", timeoutStr,
 	)
 
+	started := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// Check for timeout first - context errors have priority
@@ -372,6 +427,20 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 				WithUserAction("Check network/firewall settings and TLS configuration. Increase request_timeout if needed")
 		}
 
+		// A certificate that fails verification fails the same way on retry
+		if problem, ok := CertificateError(err); ok {
+			cblog.With("component", "api", "op", "http").Warn("Server certificate not verified",
+				"method", method,
+				"url", sanitizeURL(url),
+				"error", problem,
+			)
+			return nil, apperrors.Wrap(err, apperrors.ErrorConfig, "TLS_CERTIFICATE_INVALID",
+				"TLS certificate verification failed: "+problem).
+				WithContext("method", method).
+				WithContext("url", url).
+				WithUserAction("Trust the server's CA with --ca-cert or --ca-path, or connect with --insecure")
+		}
+
 		// Log the actual error at warn level so users can see what went wrong
 		// This will show TLS certificate errors, connection refused, etc.
 		cblog.With("component", "api", "op", "http").Warn("HTTP request failed",
@@ -388,8 +457,24 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 			WithUserAction("Check your network connection and ArgoCD server status")
 	}
 	defer resp.Body.Close()
+	recordLatency(time.Since(started))
+	trace.Status = resp.StatusCode
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readResponseBody(resp.Body, limit)
+	var tooLarge *ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		cblog.With("component", "api", "op", "http").Warn("Response exceeded size limit",
+			"method", method,
+			"url", sanitizeURL(url),
+			"size", tooLarge.Size,
+			"limit", tooLarge.Limit,
+			"saved", tooLarge.Path,
+		)
+		return nil, apperrors.Wrap(err, apperrors.ErrorAPI, "RESPONSE_TOO_LARGE", tooLarge.Error()).
+			WithContext("method", method).
+			WithContext("path", path).
+			WithUserAction("Load resources one at a time, or raise max_response_size under [http_limits] in config")
+	}
 	if err != nil {
 		return nil, apperrors.Wrap(err, apperrors.ErrorNetwork, "RESPONSE_READ_FAILED",
 			"Failed to read response body").
@@ -228,6 +228,12 @@ func TestBuildURL(t *testing.T) {
 			path:            "/api/v1/apps",
 			expectedURL:     "https://example.com/argocd/api/v1/apps",
 		},
+		{
+			name:        "Base path in the server URL",
+			baseURL:     "http://example.com/argocd/",
+			path:        "/api/v1/apps",
+			expectedURL: "http://example.com/argocd/api/v1/apps",
+		},
 	}
 
 	for _, tt := range tests {
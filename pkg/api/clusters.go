@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// inClusterServer is the API server address of the cluster Argo CD runs in
+const inClusterServer = "https://kubernetes.default.svc"
+
+// ConnectionState is Argo CD's last attempt to reach a cluster
+type ConnectionState struct {
+	Status  string `json:"status"` // Successful, Failed or Unknown
+	Message string `json:"message,omitempty"`
+}
+
+// Cluster is the subset of an Argo CD cluster that Argonaut reads
+type Cluster struct {
+	Server string `json:"server"`
+	Name   string `json:"name"`
+	Info   struct {
+		ConnectionState ConnectionState `json:"connectionState"`
+		ServerVersion   string          `json:"serverVersion,omitempty"`
+	} `json:"info"`
+	// Older servers report the state and version at the top level only
+	ConnectionState ConnectionState `json:"connectionState"`
+	ServerVersion   string          `json:"serverVersion,omitempty"`
+}
+
+// Connection returns the cluster's connection state, preferring info
+func (c Cluster) Connection() ConnectionState {
+	if c.Info.ConnectionState.Status != "" {
+		return c.Info.ConnectionState
+	}
+	return c.ConnectionState
+}
+
+// KubernetesVersion returns the cluster's Kubernetes version as Argo CD last
+// saw it, e.g. "1.29", preferring info; "" when unknown
+func (c Cluster) KubernetesVersion() string {
+	if c.Info.ServerVersion != "" {
+		return c.Info.ServerVersion
+	}
+	return c.ServerVersion
+}
+
+// ListClusters fetches the clusters visible to the current user
+func (s *ApplicationService) ListClusters(ctx context.Context) ([]Cluster, error) {
+	resp, err := s.client.Get(ctx, "/api/v1/clusters")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var list struct {
+		Items []Cluster `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode clusters response: %w", err)
+	}
+	return list.Items, nil
+}
+
+// ClusterIDForServer returns the cluster ID apps show for a destination
+// server: "in-cluster" for the local cluster, otherwise the URL's host
+func ClusterIDForServer(server string) string {
+	if server == inClusterServer {
+		return "in-cluster"
+	}
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return server
+}
+
+// FailedClusterConnections maps the cluster IDs of clusters Argo CD cannot
+// reach to the reason it gives. A cluster is listed under both its name and
+// its server's ID since apps may target it by either.
+func FailedClusterConnections(clusters []Cluster) map[string]string {
+	failed := make(map[string]string)
+	for _, c := range clusters {
+		state := c.Connection()
+		if state.Status != "Failed" {
+			continue
+		}
+		msg := state.Message
+		if msg == "" {
+			msg = "connection failed"
+		}
+		if c.Name != "" {
+			failed[c.Name] = msg
+		}
+		if c.Server != "" {
+			failed[ClusterIDForServer(c.Server)] = msg
+		}
+	}
+	return failed
+}
+
+// ClusterVersions maps cluster IDs to the Kubernetes version of the
+// cluster, under both its name and its server's ID like
+// FailedClusterConnections. Clusters of unknown version are left out.
+func ClusterVersions(clusters []Cluster) map[string]string {
+	versions := make(map[string]string)
+	for _, c := range clusters {
+		v := c.KubernetesVersion()
+		if v == "" {
+			continue
+		}
+		if c.Name != "" {
+			versions[c.Name] = v
+		}
+		if c.Server != "" {
+			versions[ClusterIDForServer(c.Server)] = v
+		}
+	}
+	return versions
+}
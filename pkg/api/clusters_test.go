@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestListClusters_FailedConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/clusters" {
+			t.Errorf("Expected path /api/v1/clusters, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": [
+			{"server": "https://kubernetes.default.svc", "name": "in-cluster", "info": {"connectionState": {"status": "Successful"}, "serverVersion": "1.29"}},
+			{"server": "https://prod.example.com:6443", "name": "prod", "info": {"connectionState": {"status": "Failed", "message": "the server has asked for the client to provide credentials"}}},
+			{"server": "https://legacy.example.com", "name": "legacy", "connectionState": {"status": "Failed"}, "serverVersion": "1.21+"},
+			{"server": "https://idle.example.com", "name": "idle", "info": {"connectionState": {"status": "Unknown"}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	clusters, err := svc.ListClusters(context.Background())
+	if err != nil {
+		t.Fatalf("ListClusters returned error: %v", err)
+	}
+	if len(clusters) != 4 {
+		t.Fatalf("expected 4 clusters, got %d", len(clusters))
+	}
+
+	want := map[string]string{
+		"prod":                  "the server has asked for the client to provide credentials",
+		"prod.example.com:6443": "the server has asked for the client to provide credentials",
+		"legacy":                "connection failed",
+		"legacy.example.com":    "connection failed",
+	}
+	if got := FailedClusterConnections(clusters); !reflect.DeepEqual(got, want) {
+		t.Errorf("FailedClusterConnections() = %v, want %v", got, want)
+	}
+
+	wantVersions := map[string]string{
+		"in-cluster":         "1.29",
+		"legacy":             "1.21+",
+		"legacy.example.com": "1.21+",
+	}
+	if got := ClusterVersions(clusters); !reflect.DeepEqual(got, wantVersions) {
+		t.Errorf("ClusterVersions() = %v, want %v", got, wantVersions)
+	}
+}
+
+func TestClusterIDForServer(t *testing.T) {
+	for server, want := range map[string]string{
+		"https://kubernetes.default.svc": "in-cluster",
+		"https://prod.example.com:6443":  "prod.example.com:6443",
+		"not a url":                      "not a url",
+	} {
+		if got := ClusterIDForServer(server); got != want {
+			t.Errorf("ClusterIDForServer(%q) = %q, want %q", server, got, want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/darksworm/argonaut/pkg/kubeapi"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// DeprecatedAPIs returns the kinds the application deploys at an API
+// version Kubernetes deprecated, with how many resources use each, in the
+// order Argo CD lists the resources
+func (app *ArgoApplication) DeprecatedAPIs() []model.ResourceAPI {
+	var apis []model.ResourceAPI
+	index := make(map[model.ResourceAPI]int)
+	for _, r := range app.Status.Resources {
+		if _, ok := kubeapi.Lookup(r.Group, r.Version, r.Kind); !ok {
+			continue
+		}
+		key := model.ResourceAPI{Group: r.Group, Version: r.Version, Kind: r.Kind}
+		if i, seen := index[key]; seen {
+			apis[i].Count++
+			continue
+		}
+		index[key] = len(apis)
+		key.Count = 1
+		apis = append(apis, key)
+	}
+	return apis
+}
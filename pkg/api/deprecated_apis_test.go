@@ -0,0 +1,29 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestDeprecatedAPIs(t *testing.T) {
+	var app ArgoApplication
+	app.Status.Resources = []ResourceStatus{
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob", Name: "nightly"},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Name: "web"},
+		{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", Name: "web"},
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob", Name: "weekly"},
+		{Version: "v1", Kind: "Service", Name: "web"},
+	}
+	want := []model.ResourceAPI{
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob", Count: 2},
+		{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", Count: 1},
+	}
+	if got := app.DeprecatedAPIs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeprecatedAPIs() = %+v, want %+v", got, want)
+	}
+	if got := (&ArgoApplication{}).DeprecatedAPIs(); got != nil {
+		t.Errorf("an app without resources uses no deprecated APIs, got %+v", got)
+	}
+}
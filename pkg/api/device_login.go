@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cblog "github.com/charmbracelet/log"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// maxDeviceLoginResponse caps what is read from the identity provider
+const maxDeviceLoginResponse = 1 << 20
+
+// deviceCodeGrantType is the grant of RFC 8628 that trades a device code
+// for tokens
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Answers of the token endpoint while a device login is not done yet
+var (
+	ErrDeviceLoginPending  = errors.New("waiting for the code to be approved")
+	ErrDeviceLoginSlowDown = errors.New("polling too often")
+	ErrDeviceLoginDenied   = errors.New("the sign-in was denied")
+	ErrDeviceLoginExpired  = errors.New("the code expired")
+)
+
+// DeviceLogin signs in to the server's SSO with the OAuth device
+// authorization grant (RFC 8628): the user approves a short code on any
+// device with a browser while argonaut polls for the tokens. Unlike the
+// localhost callback of argocd login --sso, it works over SSH.
+type DeviceLogin struct {
+	Issuer         string
+	ClientID       string
+	Scopes         []string
+	deviceEndpoint string
+	tokenEndpoint  string
+	httpClient     *http.Client
+}
+
+// DeviceCode is the code the user approves, and where
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// DeviceToken is the session an approved device login returns. The ID
+// token is what Argo CD takes as the auth token.
+type DeviceToken struct {
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// oauthError is the error body of OAuth endpoints
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// NewDeviceLogin finds the server's SSO provider in its public settings and
+// the provider's device endpoint in its OIDC discovery document. Requests
+// verify TLS the way the server's API client does.
+func NewDeviceLogin(ctx context.Context, server *model.Server) (*DeviceLogin, error) {
+	anonymous := *server
+	anonymous.Token = ""
+	settings, err := NewApplicationService(&anonymous).GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issuer, clientID, scopes, err := settings.SSOProvider(server.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DeviceLogin{Issuer: issuer, ClientID: clientID, Scopes: scopes, httpClient: NewClient(server).httpClient}
+	var discovery struct {
+		DeviceEndpoint string `json:"device_authorization_endpoint"`
+		TokenEndpoint  string `json:"token_endpoint"`
+	}
+	wellKnown := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+	if err := d.do(ctx, http.MethodGet, wellKnown, nil, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover the SSO provider at %s: %w", issuer, err)
+	}
+	if discovery.DeviceEndpoint == "" || discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("the SSO provider at %s does not support device login", issuer)
+	}
+	d.deviceEndpoint, d.tokenEndpoint = discovery.DeviceEndpoint, discovery.TokenEndpoint
+	cblog.With("component", "auth").Info("Device login provider found", "issuer", issuer, "client", clientID)
+	return d, nil
+}
+
+// Start asks the provider for a code for the user to approve
+func (d *DeviceLogin) Start(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{"client_id": {d.ClientID}, "scope": {strings.Join(d.Scopes, " ")}}
+	var code DeviceCode
+	if err := d.do(ctx, http.MethodPost, d.deviceEndpoint, form, &code); err != nil {
+		return nil, fmt.Errorf("failed to start device login: %w", err)
+	}
+	if code.DeviceCode == "" || code.UserCode == "" || code.VerificationURI == "" {
+		return nil, errors.New("failed to start device login: the provider returned no code")
+	}
+	return &code, nil
+}
+
+// Poll asks once whether the code was approved. Until it is, the error is
+// ErrDeviceLoginPending, or ErrDeviceLoginSlowDown when asked too often;
+// ErrDeviceLoginDenied and ErrDeviceLoginExpired end the login.
+func (d *DeviceLogin) Poll(ctx context.Context, code *DeviceCode) (*DeviceToken, error) {
+	form := url.Values{"grant_type": {deviceCodeGrantType}, "device_code": {code.DeviceCode}, "client_id": {d.ClientID}}
+	var token DeviceToken
+	err := d.do(ctx, http.MethodPost, d.tokenEndpoint, form, &token)
+	var oerr *oauthError
+	if errors.As(err, &oerr) {
+		switch oerr.Code {
+		case "authorization_pending":
+			return nil, ErrDeviceLoginPending
+		case "slow_down":
+			return nil, ErrDeviceLoginSlowDown
+		case "access_denied":
+			return nil, ErrDeviceLoginDenied
+		case "expired_token":
+			return nil, ErrDeviceLoginExpired
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to finish device login: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("failed to finish device login: the provider returned no ID token")
+	}
+	return &token, nil
+}
+
+// do sends a request, with form as its urlencoded body when given, and
+// decodes the JSON answer into out. OAuth errors come back as *oauthError.
+func (d *DeviceLogin) do(ctx context.Context, method, endpoint string, form url.Values, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDeviceLoginResponse))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		var oerr oauthError
+		if json.Unmarshal(data, &oerr) == nil && oerr.Code != "" {
+			return &oerr
+		}
+		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, sanitizeURL(endpoint))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode the response from %s: %w", sanitizeURL(endpoint), err)
+	}
+	return nil
+}
+
+func (e *oauthError) Error() string {
+	if e.Description != "" {
+		return e.Code + ": " + e.Description
+	}
+	return e.Code
+}
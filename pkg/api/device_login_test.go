@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// newDexServer serves an Argo CD with Dex that approves the device code on
+// the second poll, or answers every poll with finalErr when it is set
+func newDexServer(t *testing.T, finalErr string) *httptest.Server {
+	t.Helper()
+	polls := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/settings":
+			w.Write([]byte(`{"dexConfig": {"connectors": [{"name": "GitHub", "type": "github"}]}}`))
+		case "/api/dex/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"device_authorization_endpoint": srv.URL + "/api/dex/device/code",
+				"token_endpoint":                srv.URL + "/api/dex/token",
+			})
+		case "/api/dex/device/code":
+			if r.FormValue("client_id") != "argo-cd-cli" || r.FormValue("scope") == "" {
+				t.Errorf("unexpected device code request: %v", r.Form)
+			}
+			w.Write([]byte(`{"device_code": "dev-1", "user_code": "ABCD-EFGH", "verification_uri": "https://argo.example.com/api/dex/device", "expires_in": 300, "interval": 5}`))
+		case "/api/dex/token":
+			if r.FormValue("grant_type") != deviceCodeGrantType || r.FormValue("device_code") != "dev-1" {
+				t.Errorf("unexpected token request: %v", r.Form)
+			}
+			polls++
+			switch {
+			case finalErr != "":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "` + finalErr + `"}`))
+			case polls == 1:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "authorization_pending"}`))
+			default:
+				w.Write([]byte(`{"id_token": "id-tok", "refresh_token": "refresh-tok", "access_token": "access-tok"}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDeviceLogin_ApprovedAfterPending(t *testing.T) {
+	srv := newDexServer(t, "")
+	ctx := context.Background()
+
+	d, err := NewDeviceLogin(ctx, &model.Server{BaseURL: srv.URL, Token: "expired"})
+	if err != nil {
+		t.Fatalf("NewDeviceLogin: %v", err)
+	}
+	if d.Issuer != srv.URL+"/api/dex" || d.ClientID != "argo-cd-cli" {
+		t.Errorf("expected the bundled Dex with the CLI client, got %s %s", d.Issuer, d.ClientID)
+	}
+
+	code, err := d.Start(ctx)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if code.UserCode != "ABCD-EFGH" || code.Interval != 5 {
+		t.Errorf("unexpected code %+v", code)
+	}
+
+	if _, err := d.Poll(ctx, code); !errors.Is(err, ErrDeviceLoginPending) {
+		t.Fatalf("first poll should be pending, got %v", err)
+	}
+	token, err := d.Poll(ctx, code)
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if token.IDToken != "id-tok" || token.RefreshToken != "refresh-tok" {
+		t.Errorf("unexpected token %+v", token)
+	}
+}
+
+func TestDeviceLogin_PollErrors(t *testing.T) {
+	for answer, want := range map[string]error{
+		"slow_down":     ErrDeviceLoginSlowDown,
+		"access_denied": ErrDeviceLoginDenied,
+		"expired_token": ErrDeviceLoginExpired,
+	} {
+		srv := newDexServer(t, answer)
+		d, err := NewDeviceLogin(context.Background(), &model.Server{BaseURL: srv.URL})
+		if err != nil {
+			t.Fatalf("NewDeviceLogin: %v", err)
+		}
+		if _, err := d.Poll(context.Background(), &DeviceCode{DeviceCode: "dev-1"}); !errors.Is(err, want) {
+			t.Errorf("%s: got %v, want %v", answer, err, want)
+		}
+	}
+}
+
+func TestDeviceLogin_ProviderWithoutDeviceFlow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/settings":
+			w.Write([]byte(`{"oidcConfig": {"issuer": "` + "http://" + r.Host + `/oidc", "clientID": "argo"}}`))
+		case "/oidc/.well-known/openid-configuration":
+			w.Write([]byte(`{"token_endpoint": "http://` + r.Host + `/oidc/token"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := NewDeviceLogin(context.Background(), &model.Server{BaseURL: srv.URL}); err == nil {
+		t.Fatal("a provider without a device endpoint should fail")
+	}
+}
+
+func TestSettings_SSOProvider(t *testing.T) {
+	oidc := &Settings{OIDCConfig: &OIDCConfig{Issuer: "https://idp.example.com", ClientID: "argo", CLIClientID: "argo-cli", Scopes: []string{"groups"}}}
+	issuer, client, scopes, err := oidc.SSOProvider("https://argo.example.com")
+	if err != nil || issuer != "https://idp.example.com" || client != "argo-cli" {
+		t.Errorf("OIDC: got %s %s %v", issuer, client, err)
+	}
+	if !reflect.DeepEqual(scopes, []string{"openid", "groups"}) {
+		t.Errorf("OIDC scopes should gain openid, got %v", scopes)
+	}
+
+	dex := &Settings{URL: "https://argo.example.com/", DexConfig: &DexConfig{Connectors: []DexConnector{{Name: "GitHub"}}}}
+	if issuer, client, _, _ := dex.SSOProvider("https://ignored"); issuer != "https://argo.example.com/api/dex" || client != "argo-cd-cli" {
+		t.Errorf("Dex: got %s %s", issuer, client)
+	}
+
+	if _, _, _, err := (&Settings{}).SSOProvider("https://argo.example.com"); err == nil {
+		t.Error("a server without SSO should fail")
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// appEndpoint is the path of an application resource, with its namespace
+// for multi-tenant servers
+func appEndpoint(name, sub string, appNamespace *string) string {
+	endpoint := "/api/v1/applications/" + url.PathEscape(name) + sub
+	if appNamespace != nil && *appNamespace != "" {
+		endpoint += "?appNamespace=" + url.QueryEscape(*appNamespace)
+	}
+	return endpoint
+}
+
+// GetApplicationJSON returns an application as the server sends it, with
+// every field of its spec, for exporting it as a manifest
+func (s *ApplicationService) GetApplicationJSON(ctx context.Context, name string, appNamespace *string) ([]byte, error) {
+	resp, err := s.client.Get(ctx, appEndpoint(name, "", appNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application %s: %w", name, err)
+	}
+	return resp, nil
+}
+
+// GetManifests returns the manifests Argo CD renders from an application's
+// sources at their target revisions, each a JSON object
+func (s *ApplicationService) GetManifests(ctx context.Context, name string, appNamespace *string) ([]string, error) {
+	resp, err := s.client.Get(ctx, appEndpoint(name, "/manifests", appNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifests of %s: %w", name, err)
+	}
+	var out struct {
+		Manifests []string `json:"manifests"`
+	}
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode manifests response: %w", err)
+	}
+	return out.Manifests, nil
+}
+
+// ResolveRevision returns the commit SHA a revision of an application's
+// source (a branch, tag or HEAD) points at. The revision metadata endpoint
+// leaves the SHA out, so the manifests endpoint, which reports the revision
+// it rendered, resolves it.
+func (s *ApplicationService) ResolveRevision(ctx context.Context, name, revision string, appNamespace *string) (string, error) {
+	endpoint := appEndpoint(name, "/manifests", appNamespace)
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	resp, err := s.client.Get(ctx, endpoint+sep+"revision="+url.QueryEscape(revision))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s of %s: %w", revision, name, err)
+	}
+	var out struct {
+		Revision string `json:"revision"`
+	}
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return "", fmt.Errorf("failed to decode manifests response: %w", err)
+	}
+	if out.Revision == "" {
+		return "", fmt.Errorf("failed to resolve %s of %s: no revision in response", revision, name)
+	}
+	return out.Revision, nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestGetManifests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/web/manifests" {
+			t.Errorf("Expected path /api/v1/applications/web/manifests, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("appNamespace"); got != "team-a" {
+			t.Errorf("appNamespace = %q, want team-a", got)
+		}
+		w.Write([]byte(`{"manifests": ["{\"kind\":\"Service\"}", "{\"kind\":\"Deployment\"}"], "revision": "abc"}`))
+	}))
+	defer server.Close()
+
+	ns := "team-a"
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	got, err := svc.GetManifests(context.Background(), "web", &ns)
+	if err != nil {
+		t.Fatalf("GetManifests returned error: %v", err)
+	}
+	if want := []string{`{"kind":"Service"}`, `{"kind":"Deployment"}`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetManifests() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v1/applications/web/manifests" || q.Get("revision") != "release/1.x" || q.Get("appNamespace") != "team-a" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"manifests": [], "revision": "0123456789abcdef0123456789abcdef01234567"}`))
+	}))
+	defer server.Close()
+
+	ns := "team-a"
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	got, err := svc.ResolveRevision(context.Background(), "web", "release/1.x", &ns)
+	if err != nil || got != "0123456789abcdef0123456789abcdef01234567" {
+		t.Errorf("ResolveRevision() = %q, %v", got, err)
+	}
+}
+
+func TestGetApplicationJSON(t *testing.T) {
+	body := `{"metadata":{"name":"web"},"spec":{"project":"shop","syncPolicy":{"automated":{}}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/web" || r.URL.RawQuery != "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	got, err := svc.GetApplicationJSON(context.Background(), "web", nil)
+	if err != nil {
+		t.Fatalf("GetApplicationJSON returned error: %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("GetApplicationJSON() = %s, want the response as sent", got)
+	}
+}
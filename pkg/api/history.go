@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// appHistoryFields are the list view's fields plus the deployment history
+var appHistoryFields = append(append([]string(nil), AppListFields...), "items.status.history")
+
+// ListApplicationsWithHistory lists the apps of the given projects, or all
+// apps the token can see when none are given, with their deployment history
+func (s *ApplicationService) ListApplicationsWithHistory(ctx context.Context, projects []string) ([]ArgoApplication, error) {
+	params := url.Values{}
+	params.Set("fields", strings.Join(appHistoryFields, ","))
+	for _, p := range s.scopedProjects(projects) {
+		params.Add("projects", p)
+	}
+	data, err := s.client.Get(ctx, "/api/v1/applications?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	_, apps, err := decodeApplicationList(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// ApplicationEvent is a Kubernetes event recorded for an application, such
+// as Argo CD noting who started a sync or rollback
+type ApplicationEvent struct {
+	Type           string    `json:"type,omitempty"`
+	Reason         string    `json:"reason,omitempty"`
+	Message        string    `json:"message,omitempty"`
+	Count          int       `json:"count,omitempty"`
+	FirstTimestamp time.Time `json:"firstTimestamp,omitempty"`
+	LastTimestamp  time.Time `json:"lastTimestamp,omitempty"`
+}
+
+// At returns when the event last happened
+func (e ApplicationEvent) At() time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp
+	}
+	return e.FirstTimestamp
+}
+
+// RollbackTarget returns the history ID an "initiated rollback to N" event
+// rolled back to
+func (e ApplicationEvent) RollbackTarget() (int, bool) {
+	var id int
+	msg := strings.ToLower(e.Message)
+	if i := strings.Index(msg, "initiated rollback to "); i >= 0 {
+		if _, err := fmt.Sscanf(msg[i+len("initiated rollback to "):], "%d", &id); err == nil {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// ListApplicationEvents fetches the events recorded for an application.
+// Kubernetes keeps events for a limited time (an hour by default), so older
+// operations are missing.
+func (s *ApplicationService) ListApplicationEvents(ctx context.Context, name string, appNamespace *string) ([]ApplicationEvent, error) {
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/events", url.PathEscape(name))
+	if appNamespace != nil && *appNamespace != "" {
+		endpoint += "?appNamespace=" + url.QueryEscape(*appNamespace)
+	}
+	resp, err := s.client.Get(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events of %s: %w", name, err)
+	}
+	var list struct {
+		Items []ApplicationEvent `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode events response: %w", err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestListApplicationsWithHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query()["projects"]; len(got) != 1 || got[0] != "ecommerce" {
+			t.Errorf("projects = %v", got)
+		}
+		if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "items.status.history") {
+			t.Errorf("the history should be selected, fields = %s", fields)
+		}
+		w.Write([]byte(`{"items": [{"metadata": {"name": "checkout"}, "status": {"history": [
+			{"id": 3, "revision": "abc", "deployedAt": "2026-10-16T09:00:00Z", "initiatedBy": {"username": "alice"}}
+		]}}]}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	apps, err := svc.ListApplicationsWithHistory(context.Background(), []string{"ecommerce"})
+	if err != nil {
+		t.Fatalf("ListApplicationsWithHistory returned error: %v", err)
+	}
+	if len(apps) != 1 || len(apps[0].Status.History) != 1 || apps[0].Status.History[0].InitiatedBy.Username != "alice" {
+		t.Errorf("got %+v", apps)
+	}
+}
+
+func TestListApplicationEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/checkout/events" || r.URL.Query().Get("appNamespace") != "shop" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"items": [
+			{"type": "Normal", "reason": "OperationStarted", "message": "admin initiated rollback to 12", "firstTimestamp": "2026-10-16T09:00:00Z", "lastTimestamp": null},
+			{"type": "Normal", "reason": "OperationStarted", "message": "admin initiated sync to HEAD (abc)", "lastTimestamp": "2026-10-16T10:00:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	ns := "shop"
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	events, err := svc.ListApplicationEvents(context.Background(), "checkout", &ns)
+	if err != nil {
+		t.Fatalf("ListApplicationEvents returned error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if id, ok := events[0].RollbackTarget(); !ok || id != 12 {
+		t.Errorf("RollbackTarget() = %d, %v", id, ok)
+	}
+	if events[0].At().IsZero() || events[0].At().Hour() != 9 {
+		t.Errorf("At() should fall back to the first timestamp, got %v", events[0].At())
+	}
+	if _, ok := events[1].RollbackTarget(); ok {
+		t.Error("a sync is not a rollback")
+	}
+}
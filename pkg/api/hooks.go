@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// SyncResult is the outcome of an application's last sync
+type SyncResult struct {
+	Resources []SyncResultResource `json:"resources,omitempty"`
+}
+
+// SyncResultResource is one resource applied by an application's last sync
+type SyncResultResource struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Message   string `json:"message,omitempty"`
+	HookType  string `json:"hookType,omitempty"`
+	HookPhase string `json:"hookPhase,omitempty"`
+	SyncPhase string `json:"syncPhase,omitempty"`
+}
+
+// HookRuns returns the hook resources run by the application's last sync,
+// in the order Argo CD ran them
+func (app *ArgoApplication) HookRuns() []model.HookRun {
+	result := app.Status.OperationState.SyncResult
+	if result == nil {
+		return nil
+	}
+	var hooks []model.HookRun
+	for _, r := range result.Resources {
+		if r.HookType == "" {
+			continue
+		}
+		hooks = append(hooks, model.HookRun{
+			Group:     r.Group,
+			Version:   r.Version,
+			Kind:      r.Kind,
+			Namespace: r.Namespace,
+			Name:      r.Name,
+			HookType:  r.HookType,
+			Phase:     r.HookPhase,
+			Message:   r.Message,
+			SyncPhase: r.SyncPhase,
+		})
+	}
+	return hooks
+}
+
+// GetResourceLogs fetches the last tailLines log lines of the pods a
+// resource runs, such as a hook Job
+func (s *ApplicationService) GetResourceLogs(ctx context.Context, params LiveResourceParams, tailLines int) (string, error) {
+	if params.AppName == "" {
+		return "", fmt.Errorf("application name is required")
+	}
+	if params.ResourceName == "" {
+		return "", fmt.Errorf("resource name is required")
+	}
+	if params.Kind == "" {
+		return "", fmt.Errorf("resource kind is required")
+	}
+
+	queryParams := url.Values{}
+	queryParams.Set("resourceName", params.ResourceName)
+	queryParams.Set("kind", params.Kind)
+	queryParams.Set("group", params.Group)
+	queryParams.Set("follow", "false")
+	queryParams.Set("tailLines", strconv.Itoa(tailLines))
+	if params.Namespace != "" {
+		queryParams.Set("namespace", params.Namespace)
+	}
+	if params.AppNamespace != nil && *params.AppNamespace != "" {
+		queryParams.Set("appNamespace", *params.AppNamespace)
+	}
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/logs?%s", url.PathEscape(params.AppName), queryParams.Encode())
+
+	resp, err := s.client.Get(ctx, endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs for %s/%s: %w", params.Kind, params.ResourceName, err)
+	}
+	return parseLogEntries(resp)
+}
+
+// parseLogEntries joins the log stream Argo CD returns, one JSON object per
+// log line, into plain text. Lines from several pods are prefixed with the
+// pod name.
+func parseLogEntries(resp []byte) (string, error) {
+	type entry struct {
+		Result *struct {
+			Content string `json:"content"`
+			PodName string `json:"podName"`
+			Last    bool   `json:"last"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	var entries []entry
+	pods := make(map[string]bool)
+	dec := json.NewDecoder(bytes.NewReader(resp))
+	for {
+		var e entry
+		if err := dec.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", fmt.Errorf("failed to parse logs response: %w", err)
+		}
+		if e.Error != nil {
+			return "", errors.New(e.Error.Message)
+		}
+		if e.Result == nil || e.Result.Last {
+			continue
+		}
+		pods[e.Result.PodName] = true
+		entries = append(entries, e)
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		if len(pods) > 1 {
+			b.WriteString(e.Result.PodName + " ")
+		}
+		b.WriteString(e.Result.Content)
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
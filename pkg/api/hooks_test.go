@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestHookRuns_ListsOnlyHooks(t *testing.T) {
+	var app ArgoApplication
+	err := json.Unmarshal([]byte(`{"status": {"operationState": {"phase": "Failed", "syncResult": {"resources": [
+		{"group": "batch", "version": "v1", "kind": "Job", "namespace": "shop", "name": "migrate", "hookType": "PreSync", "hookPhase": "Failed", "syncPhase": "PreSync", "message": "Job has reached the specified backoff limit"},
+		{"group": "apps", "version": "v1", "kind": "Deployment", "namespace": "shop", "name": "web", "status": "Synced", "syncPhase": "Sync"},
+		{"kind": "Pod", "namespace": "shop", "name": "notify", "hookType": "SyncFail", "hookPhase": "Succeeded", "syncPhase": "SyncFail"}
+	]}}}}`), &app)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []model.HookRun{
+		{Group: "batch", Version: "v1", Kind: "Job", Namespace: "shop", Name: "migrate", HookType: "PreSync", Phase: "Failed", SyncPhase: "PreSync", Message: "Job has reached the specified backoff limit"},
+		{Kind: "Pod", Namespace: "shop", Name: "notify", HookType: "SyncFail", Phase: "Succeeded", SyncPhase: "SyncFail"},
+	}
+	if got := app.HookRuns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("HookRuns() = %+v, want %+v", got, want)
+	}
+
+	var noSync ArgoApplication
+	if got := noSync.HookRuns(); got != nil {
+		t.Errorf("app that never synced should have no hooks, got %+v", got)
+	}
+}
+
+func TestGetResourceLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/web/logs" {
+			t.Errorf("Expected path /api/v1/applications/web/logs, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		for key, want := range map[string]string{
+			"resourceName": "migrate",
+			"kind":         "Job",
+			"group":        "batch",
+			"namespace":    "shop",
+			"follow":       "false",
+			"tailLines":    "100",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("query %s = %q, want %q", key, got, want)
+			}
+		}
+		w.Write([]byte(`{"result":{"content":"running migrations","podName":"migrate-abc"}}
+{"result":{"content":"error: relation exists","podName":"migrate-abc"}}
+{"result":{"content":"","podName":"","last":true}}
+`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	logs, err := svc.GetResourceLogs(context.Background(), LiveResourceParams{
+		AppName:      "web",
+		ResourceName: "migrate",
+		Namespace:    "shop",
+		Kind:         "Job",
+		Group:        "batch",
+	}, 100)
+	if err != nil {
+		t.Fatalf("GetResourceLogs returned error: %v", err)
+	}
+	if want := "running migrations\nerror: relation exists\n"; logs != want {
+		t.Errorf("GetResourceLogs() = %q, want %q", logs, want)
+	}
+}
+
+func TestParseLogEntries(t *testing.T) {
+	logs, err := parseLogEntries([]byte(`{"result":{"content":"a","podName":"job-1"}}
+{"result":{"content":"b","podName":"job-2"}}`))
+	if err != nil {
+		t.Fatalf("parseLogEntries returned error: %v", err)
+	}
+	if want := "job-1 a\njob-2 b\n"; logs != want {
+		t.Errorf("lines from several pods should be prefixed, got %q", logs)
+	}
+
+	if _, err := parseLogEntries([]byte(`{"error":{"message":"pods \"migrate\" not found"}}`)); err == nil || err.Error() != `pods "migrate" not found` {
+		t.Errorf("stream errors should be returned, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// latencySamples is how many recent requests the average latency covers
+const latencySamples = 20
+
+// latency keeps the time to response headers of the most recent API
+// requests. Streams are not included since they stay open.
+var latency struct {
+	mu      sync.Mutex
+	samples [latencySamples]time.Duration
+	next    int
+	count   int
+}
+
+// recordLatency adds a request's time to response headers to the average
+func recordLatency(d time.Duration) {
+	latency.mu.Lock()
+	defer latency.mu.Unlock()
+	latency.samples[latency.next] = d
+	latency.next = (latency.next + 1) % latencySamples
+	latency.count = min(latency.count+1, latencySamples)
+}
+
+// AverageLatency returns the average response time of the recent API
+// requests, and false when none have completed yet
+func AverageLatency() (time.Duration, bool) {
+	latency.mu.Lock()
+	defer latency.mu.Unlock()
+	if latency.count == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, d := range latency.samples[:latency.count] {
+		total += d
+	}
+	return total / time.Duration(latency.count), true
+}
+
+// ResetLatency forgets the recorded requests, e.g. after switching to
+// another server
+func ResetLatency() {
+	latency.mu.Lock()
+	defer latency.mu.Unlock()
+	latency.next, latency.count = 0, 0
+}
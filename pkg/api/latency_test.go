@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestAverageLatency_RollsOverOldSamples(t *testing.T) {
+	ResetLatency()
+	defer ResetLatency()
+
+	if _, ok := AverageLatency(); ok {
+		t.Fatal("no requests yet, expected no average")
+	}
+	recordLatency(100 * time.Millisecond)
+	recordLatency(300 * time.Millisecond)
+	if avg, ok := AverageLatency(); !ok || avg != 200*time.Millisecond {
+		t.Fatalf("average = %v, %v; want 200ms", avg, ok)
+	}
+
+	// A full window of fast requests pushes the slow ones out
+	for range latencySamples {
+		recordLatency(10 * time.Millisecond)
+	}
+	if avg, _ := AverageLatency(); avg != 10*time.Millisecond {
+		t.Fatalf("average = %v; want 10ms once the old samples rolled over", avg)
+	}
+}
+
+func TestClientRequest_RecordsLatency(t *testing.T) {
+	ResetLatency()
+	defer ResetLatency()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&model.Server{BaseURL: server.URL, Token: "t"})
+	if _, err := client.Get(context.Background(), "/api/version"); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	avg, ok := AverageLatency()
+	if !ok || avg < 20*time.Millisecond {
+		t.Fatalf("average = %v, %v; want at least the 20ms the server took", avg, ok)
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	cblog "github.com/charmbracelet/log"
+)
+
+// decodeApplicationList decodes an application list response in a single pass.
+// It accepts both { metadata: { resourceVersion }, items: [...] } and a bare
+// array, decoding each item straight into ArgoApplication so large payloads
+// are never held as intermediate raw messages or generic maps. Items with
+// type mismatches are skipped; syntax errors abort the decode.
+func decodeApplicationList(r io.Reader) (resourceVersion string, apps []ArgoApplication, err error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse applications response: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('['):
+		apps, err = decodeApplicationItems(dec)
+		return "", apps, err
+	case json.Delim('{'):
+	default:
+		return "", nil, fmt.Errorf("failed to parse applications response: unexpected token %v", tok)
+	}
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse applications response: %w", err)
+		}
+		key, _ := keyTok.(string)
+
+		switch key {
+		case "metadata":
+			var meta struct {
+				ResourceVersion string `json:"resourceVersion"`
+			}
+			if err := dec.Decode(&meta); err != nil {
+				return "", nil, fmt.Errorf("failed to parse list metadata: %w", err)
+			}
+			resourceVersion = meta.ResourceVersion
+		case "items":
+			itemsTok, err := dec.Token()
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to parse applications array: %w", err)
+			}
+			if itemsTok == nil {
+				continue // "items": null on an empty list
+			}
+			if itemsTok != json.Delim('[') {
+				return "", nil, fmt.Errorf("failed to parse applications array: unexpected token %v", itemsTok)
+			}
+			if apps, err = decodeApplicationItems(dec); err != nil {
+				return "", nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return "", nil, fmt.Errorf("failed to parse applications response: %w", err)
+			}
+		}
+	}
+
+	return resourceVersion, apps, nil
+}
+
+// decodeApplicationItems decodes array elements after the opening '[' up to
+// and including the closing ']'.
+func decodeApplicationItems(dec *json.Decoder) ([]ArgoApplication, error) {
+	apps := make([]ArgoApplication, 0, 64)
+	for dec.More() {
+		var app ArgoApplication
+		if err := dec.Decode(&app); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				// The decoder has consumed the whole element; skip the malformed entry
+				cblog.With("component", "api").Debug("Skipping malformed application", "field", typeErr.Field, "err", err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to parse applications array: %w", err)
+		}
+		apps = append(apps, app)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to parse applications array: %w", err)
+	}
+	return apps, nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeApplicationList_ObjectWithItems(t *testing.T) {
+	data := `{
+		"metadata": {"resourceVersion": "12345"},
+		"items": [
+			{"metadata": {"name": "a"}, "status": {"sync": {"status": "Synced"}, "health": {"status": "Healthy"}}},
+			{"metadata": {"name": 42}},
+			{"metadata": {"name": "c"}, "spec": {"project": "infra"}}
+		],
+		"extra": {"ignored": [1, 2, 3]}
+	}`
+
+	rv, apps, err := decodeApplicationList(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rv != "12345" {
+		t.Errorf("resourceVersion = %q, want 12345", rv)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("expected malformed item to be skipped, got %d apps", len(apps))
+	}
+	if apps[0].Status.Health.Status != "Healthy" || apps[1].Spec.Project != "infra" {
+		t.Errorf("unexpected decoded apps: %+v", apps)
+	}
+}
+
+func TestDecodeApplicationList_BareArrayAndEmpty(t *testing.T) {
+	_, apps, err := decodeApplicationList(strings.NewReader(`[{"metadata": {"name": "a"}}]`))
+	if err != nil || len(apps) != 1 || apps[0].Metadata.Name != "a" {
+		t.Fatalf("bare array: apps=%+v err=%v", apps, err)
+	}
+
+	rv, apps, err := decodeApplicationList(strings.NewReader(`{"metadata": {"resourceVersion": "7"}, "items": null}`))
+	if err != nil || len(apps) != 0 || rv != "7" {
+		t.Fatalf("empty list: rv=%q apps=%+v err=%v", rv, apps, err)
+	}
+}
+
+func TestDecodeApplicationList_SyntaxError(t *testing.T) {
+	if _, _, err := decodeApplicationList(strings.NewReader(`{"items": [{"metadata": `)); err == nil {
+		t.Fatal("expected error for truncated payload")
+	}
+}
+
+func BenchmarkDecodeApplicationList(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"metadata":{"resourceVersion":"1"},"items":[`)
+	for i := 0; i < 2000; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"metadata":{"name":"app-%d","namespace":"argocd"},"spec":{"project":"default","source":{"repoURL":"https://git.example.com/repo.git","path":"apps/%d","targetRevision":"HEAD"},"destination":{"server":"https://kubernetes.default.svc","namespace":"ns-%d"}},"status":{"sync":{"status":"Synced","revision":"0123456789abcdef0123456789abcdef01234567"},"health":{"status":"Healthy"}}}`, i, i, i)
+	}
+	buf.WriteString(`]}`)
+	data := buf.Bytes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := decodeApplicationList(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// NotificationSubscribePrefix starts the annotations that subscribe
+// recipients to an app's notifications, such as
+// notifications.argoproj.io/subscribe.on-sync-failed.slack: "alerts;ops"
+const NotificationSubscribePrefix = "notifications.argoproj.io/subscribe."
+
+// NotificationAnnotationKey returns the annotation holding the recipients
+// of a trigger on a service; an empty trigger means the default triggers
+func NotificationAnnotationKey(trigger, service string) string {
+	if trigger == "" {
+		return NotificationSubscribePrefix + service
+	}
+	return NotificationSubscribePrefix + trigger + "." + service
+}
+
+// NotificationSubscriptions returns a subscription per recipient of the
+// application's subscribe annotations, sorted by annotation
+func (app *ArgoApplication) NotificationSubscriptions() []model.NotificationSubscription {
+	keys := make([]string, 0, len(app.Metadata.Annotations))
+	for key := range app.Metadata.Annotations {
+		if strings.HasPrefix(key, NotificationSubscribePrefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var subs []model.NotificationSubscription
+	for _, key := range keys {
+		trigger, service, found := strings.Cut(strings.TrimPrefix(key, NotificationSubscribePrefix), ".")
+		if !found {
+			trigger, service = "", trigger
+		}
+		if service == "" {
+			continue
+		}
+		for _, recipient := range strings.Split(app.Metadata.Annotations[key], ";") {
+			if recipient = strings.TrimSpace(recipient); recipient != "" {
+				subs = append(subs, model.NotificationSubscription{Trigger: trigger, Service: service, Recipient: recipient})
+			}
+		}
+	}
+	return subs
+}
+
+// NotificationAnnotationPatch returns the annotation change that leaves the
+// subscriptions of a trigger on a service as they are in subs: their
+// recipients joined by ";", or nil to remove the annotation
+func NotificationAnnotationPatch(subs []model.NotificationSubscription, trigger, service string) map[string]*string {
+	var recipients []string
+	for _, sub := range subs {
+		if sub.Trigger == trigger && sub.Service == service && !slices.Contains(recipients, sub.Recipient) {
+			recipients = append(recipients, sub.Recipient)
+		}
+	}
+	key := NotificationAnnotationKey(trigger, service)
+	if len(recipients) == 0 {
+		return map[string]*string{key: nil}
+	}
+	value := strings.Join(recipients, ";")
+	return map[string]*string{key: &value}
+}
+
+// PatchApplicationAnnotations sets the given annotations of an application
+// with a merge patch; nil values remove their annotation. It returns the
+// application as patched.
+func (s *ApplicationService) PatchApplicationAnnotations(ctx context.Context, name string, appNamespace *string, annotations map[string]*string) (*ArgoApplication, error) {
+	if name == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": annotations}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode annotations patch: %w", err)
+	}
+	body := map[string]any{
+		"name":      name,
+		"patch":     string(patch),
+		"patchType": "merge",
+	}
+	if appNamespace != nil && *appNamespace != "" {
+		body["appNamespace"] = *appNamespace
+	}
+
+	resp, err := s.client.Patch(ctx, fmt.Sprintf("/api/v1/applications/%s", url.PathEscape(name)), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch application %s: %w", name, err)
+	}
+
+	var app ArgoApplication
+	if err := json.Unmarshal(resp, &app); err != nil {
+		return nil, fmt.Errorf("failed to decode application response: %w", err)
+	}
+	return &app, nil
+}
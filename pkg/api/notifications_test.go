@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestNotificationSubscriptions_ParsesAnnotations(t *testing.T) {
+	var app ArgoApplication
+	app.Metadata.Annotations = map[string]string{
+		"notifications.argoproj.io/subscribe.on-sync-failed.slack": "alerts; ops;",
+		"notifications.argoproj.io/subscribe.email":                "team@example.com",
+		"notifications.argoproj.io/subscribe.":                     "nobody",
+		"argocd.argoproj.io/refresh":                               "hard",
+	}
+
+	want := []model.NotificationSubscription{
+		{Service: "email", Recipient: "team@example.com"},
+		{Trigger: "on-sync-failed", Service: "slack", Recipient: "alerts"},
+		{Trigger: "on-sync-failed", Service: "slack", Recipient: "ops"},
+	}
+	if got := app.NotificationSubscriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NotificationSubscriptions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNotificationAnnotationPatch(t *testing.T) {
+	subs := []model.NotificationSubscription{
+		{Trigger: "on-sync-failed", Service: "slack", Recipient: "alerts"},
+		{Service: "slack", Recipient: "general"},
+		{Trigger: "on-sync-failed", Service: "slack", Recipient: "ops"},
+	}
+	patch := NotificationAnnotationPatch(subs, "on-sync-failed", "slack")
+	if v := patch["notifications.argoproj.io/subscribe.on-sync-failed.slack"]; len(patch) != 1 || v == nil || *v != "alerts;ops" {
+		t.Errorf("recipients should be joined, got %v", patch)
+	}
+
+	patch = NotificationAnnotationPatch(subs, "", "email")
+	if v, ok := patch["notifications.argoproj.io/subscribe.email"]; !ok || v != nil {
+		t.Errorf("an annotation without recipients should be removed, got %v", patch)
+	}
+}
+
+func TestPatchApplicationAnnotations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/applications/web" {
+			t.Errorf("Expected PATCH /api/v1/applications/web, got %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]string
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		want := map[string]string{
+			"name":         "web",
+			"appNamespace": "team",
+			"patchType":    "merge",
+			"patch":        `{"metadata":{"annotations":{"notifications.argoproj.io/subscribe.email":null}}}`,
+		}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+		w.Write([]byte(`{"metadata":{"name":"web","annotations":{"notifications.argoproj.io/subscribe.slack":"general"}}}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	ns := "team"
+	app, err := svc.PatchApplicationAnnotations(context.Background(), "web", &ns, map[string]*string{
+		"notifications.argoproj.io/subscribe.email": nil,
+	})
+	if err != nil {
+		t.Fatalf("PatchApplicationAnnotations returned error: %v", err)
+	}
+	if subs := app.NotificationSubscriptions(); len(subs) != 1 || subs[0].Recipient != "general" {
+		t.Errorf("the patched application should be returned, got %+v", subs)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// HelmParameter is a Helm parameter override of an application source, as
+// argocd app set --helm-set sets it
+type HelmParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SetHelmParameters overrides Helm parameters of one source of an
+// application. position is the 1-based source position of a multi-source
+// app, or 0 for the source of a single-source app. Parameters already set
+// keep their place and get the new value; others are appended.
+func (s *ApplicationService) SetHelmParameters(ctx context.Context, name string, appNamespace *string, position int, params []HelmParameter) error {
+	if name == "" {
+		return fmt.Errorf("application name is required")
+	}
+	data, err := s.GetApplicationJSON(ctx, name, appNamespace)
+	if err != nil {
+		return err
+	}
+	var app struct {
+		Spec struct {
+			Source  map[string]any   `json:"source"`
+			Sources []map[string]any `json:"sources"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &app); err != nil {
+		return fmt.Errorf("failed to decode application response: %w", err)
+	}
+
+	// Sources are patched whole, so fields argonaut does not know are kept
+	var src map[string]any
+	spec := map[string]any{}
+	switch {
+	case position == 0 && app.Spec.Source != nil:
+		src = app.Spec.Source
+		spec["source"] = src
+	case position > 0 && position <= len(app.Spec.Sources):
+		src = app.Spec.Sources[position-1]
+		spec["sources"] = app.Spec.Sources
+	default:
+		return fmt.Errorf("application %s has no source %d", name, position)
+	}
+	helm, _ := src["helm"].(map[string]any)
+	if helm == nil {
+		helm = map[string]any{}
+		src["helm"] = helm
+	}
+	existing, _ := helm["parameters"].([]any)
+	for _, p := range params {
+		replaced := false
+		for _, e := range existing {
+			if m, ok := e.(map[string]any); ok && m["name"] == p.Name {
+				m["value"] = p.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			existing = append(existing, map[string]any{"name": p.Name, "value": p.Value})
+		}
+	}
+	helm["parameters"] = existing
+
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return fmt.Errorf("failed to encode parameters patch: %w", err)
+	}
+	body := map[string]any{
+		"name":      name,
+		"patch":     string(patch),
+		"patchType": "merge",
+	}
+	if appNamespace != nil && *appNamespace != "" {
+		body["appNamespace"] = *appNamespace
+	}
+	if _, err := s.client.Patch(ctx, fmt.Sprintf("/api/v1/applications/%s", url.PathEscape(name)), body); err != nil {
+		return fmt.Errorf("failed to patch application %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestSetHelmParameters_TargetsOneSource(t *testing.T) {
+	var patch map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"metadata": {"name": "web"}, "spec": {"sources": [
+				{"repoURL": "https://charts.example.com", "chart": "web", "targetRevision": "1.4.0",
+				 "helm": {"valueFiles": ["$values/web.yaml"], "parameters": [{"name": "replicas", "value": "2"}]}},
+				{"repoURL": "https://git.example.com/values.git", "targetRevision": "main", "ref": "values"}
+			]}}`))
+		case http.MethodPatch:
+			var body struct {
+				Patch     string `json:"patch"`
+				PatchType string `json:"patchType"`
+			}
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &body); err != nil || body.PatchType != "merge" {
+				t.Errorf("unexpected patch request %s", data)
+			}
+			if err := json.Unmarshal([]byte(body.Patch), &patch); err != nil {
+				t.Errorf("patch is not JSON: %v", err)
+			}
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "t"})
+	err := svc.SetHelmParameters(context.Background(), "web", nil, 1, []HelmParameter{
+		{Name: "replicas", Value: "3"},
+		{Name: "image.tag", Value: "v2"},
+	})
+	if err != nil {
+		t.Fatalf("SetHelmParameters: %v", err)
+	}
+
+	sources := patch["spec"].(map[string]any)["sources"].([]any)
+	if len(sources) != 2 {
+		t.Fatalf("every source should be sent back, got %v", sources)
+	}
+	helm := sources[0].(map[string]any)["helm"].(map[string]any)
+	want := []any{
+		map[string]any{"name": "replicas", "value": "3"},
+		map[string]any{"name": "image.tag", "value": "v2"},
+	}
+	if !reflect.DeepEqual(helm["parameters"], want) {
+		t.Errorf("parameters = %v, want %v", helm["parameters"], want)
+	}
+	if helm["valueFiles"] == nil {
+		t.Error("other helm settings of the source should be kept")
+	}
+	if _, ok := sources[1].(map[string]any)["helm"]; ok {
+		t.Error("the other source should be left alone")
+	}
+
+	if err := svc.SetHelmParameters(context.Background(), "web", nil, 3, nil); err == nil {
+		t.Error("a source the app does not have should fail")
+	}
+}
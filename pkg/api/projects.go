@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Project is the subset of an Argo CD AppProject that Argonaut reads
+type Project struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+}
+
+// ListProjects fetches the projects visible to the current user
+func (s *ApplicationService) ListProjects(ctx context.Context) ([]Project, error) {
+	resp, err := s.client.Get(ctx, "/api/v1/projects")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var list struct {
+		Items []Project `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode projects response: %w", err)
+	}
+	return list.Items, nil
+}
+
+// ProjectAnnotationMessages returns the distinct non-empty values of the
+// annotation across projects, in project name order
+func ProjectAnnotationMessages(projects []Project, annotation string) []string {
+	sorted := append([]Project(nil), projects...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Metadata.Name < sorted[j].Metadata.Name })
+
+	seen := make(map[string]bool)
+	var messages []string
+	for _, p := range sorted {
+		msg := strings.TrimSpace(p.Metadata.Annotations[annotation])
+		if msg == "" || seen[msg] {
+			continue
+		}
+		seen[msg] = true
+		messages = append(messages, msg)
+	}
+	return messages
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestListProjects_AnnotationMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects" {
+			t.Errorf("Expected path /api/v1/projects, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"items": [
+			{"metadata": {"name": "payments", "annotations": {"argonaut.io/banner": "Change freeze until Monday"}}},
+			{"metadata": {"name": "default", "annotations": {"argonaut.io/banner": "  Change freeze until Monday "}}},
+			{"metadata": {"name": "infra", "annotations": {"argonaut.io/banner": "Cluster upgrade 14:00 UTC"}}},
+			{"metadata": {"name": "web"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	projects, err := svc.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects returned error: %v", err)
+	}
+	if len(projects) != 4 {
+		t.Fatalf("expected 4 projects, got %d", len(projects))
+	}
+
+	want := []string{"Change freeze until Monday", "Cluster upgrade 14:00 UTC"}
+	if got := ProjectAnnotationMessages(projects, "argonaut.io/banner"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectAnnotationMessages() = %v, want %v", got, want)
+	}
+	if got := ProjectAnnotationMessages(projects, "other"); len(got) != 0 {
+		t.Errorf("expected no messages for an unused annotation, got %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// savedResponses are the temp files oversized bodies were saved to, removed
+// by RemoveSavedResponses
+var (
+	savedResponsesMu sync.Mutex
+	savedResponses   []string
+)
+
+// ResponseTooLargeError reports a response body over the size limit. The
+// body is streamed to Path rather than held in memory so it can still be
+// inspected; Path is empty if it could not be saved.
+type ResponseTooLargeError struct {
+	Size  int64
+	Limit int64
+	Path  string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	msg := fmt.Sprintf("response of %s exceeds the %s limit", FormatByteSize(e.Size), FormatByteSize(e.Limit))
+	if e.Path != "" {
+		msg += " (saved to " + e.Path + ")"
+	}
+	return msg
+}
+
+// readResponseBody reads body into memory up to limit bytes. A larger body
+// is copied to a temp file and reported as *ResponseTooLargeError. A limit
+// of zero or less reads everything.
+func readResponseBody(body io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(body)
+	}
+	head, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil || int64(len(head)) <= limit {
+		return head, err
+	}
+
+	tooLarge := &ResponseTooLargeError{Limit: limit}
+	f, err := os.CreateTemp("", "argonaut-response-*.json")
+	if err != nil {
+		rest, _ := io.Copy(io.Discard, body)
+		tooLarge.Size = int64(len(head)) + rest
+		return nil, tooLarge
+	}
+	_, err = f.Write(head)
+	var rest int64
+	if err == nil {
+		rest, err = io.Copy(f, body)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// A partial copy is no use for inspecting the response
+		os.Remove(f.Name())
+		more, _ := io.Copy(io.Discard, body)
+		tooLarge.Size = int64(len(head)) + rest + more
+		return nil, tooLarge
+	}
+	tooLarge.Size = int64(len(head)) + rest
+	tooLarge.Path = f.Name()
+	savedResponsesMu.Lock()
+	savedResponses = append(savedResponses, f.Name())
+	savedResponsesMu.Unlock()
+	return nil, tooLarge
+}
+
+// RemoveSavedResponses deletes the temp files oversized responses were saved
+// to. Their paths are shown while the app runs, so this is for exit.
+func RemoveSavedResponses() {
+	savedResponsesMu.Lock()
+	defer savedResponsesMu.Unlock()
+	for _, path := range savedResponses {
+		os.Remove(path)
+	}
+	savedResponses = nil
+}
+
+// FormatByteSize renders a byte count like "52.3 MB"
+func FormatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
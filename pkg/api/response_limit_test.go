@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestReadResponseBody_SpillsOversizedBodyToTempFile(t *testing.T) {
+	body, err := readResponseBody(strings.NewReader("0123456789"), 10)
+	if err != nil || string(body) != "0123456789" {
+		t.Fatalf("body at the limit should be returned, got %q, %v", body, err)
+	}
+
+	_, err = readResponseBody(strings.NewReader("0123456789abc"), 10)
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected ResponseTooLargeError, got %v", err)
+	}
+	defer os.Remove(tooLarge.Path)
+	if tooLarge.Size != 13 || tooLarge.Limit != 10 {
+		t.Errorf("size/limit = %d/%d", tooLarge.Size, tooLarge.Limit)
+	}
+	saved, _ := os.ReadFile(tooLarge.Path)
+	if string(saved) != "0123456789abc" {
+		t.Errorf("saved body = %q", saved)
+	}
+	RemoveSavedResponses()
+	if _, err := os.Stat(tooLarge.Path); !os.IsNotExist(err) {
+		t.Errorf("the saved body should be removed on exit, stat err = %v", err)
+	}
+
+	if body, _ := readResponseBody(strings.NewReader("0123456789abc"), 0); len(body) != 13 {
+		t.Error("a zero limit should read everything")
+	}
+}
+
+func TestClientGet_ResponseSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [` + strings.Repeat(`{"kind": "ConfigMap"},`, 100) + `{}]}`))
+	}))
+	defer server.Close()
+	defer SetMaxResponseSize(DefaultMaxResponseSize)
+	SetMaxResponseSize(1024)
+
+	client := NewClient(&model.Server{BaseURL: server.URL, Token: "t"})
+	_, err := client.GetWithSizeLimit(context.Background(), "/api/v1/applications/big/managed-resources")
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected ResponseTooLargeError, got %v", err)
+	}
+	os.Remove(tooLarge.Path)
+	if !strings.Contains(err.Error(), "exceeds the 1.0 KB limit") {
+		t.Errorf("error should mention the limit: %v", err)
+	}
+
+	// Responses without a fallback are read in full
+	if _, err := client.Get(context.Background(), "/api/v1/applications/big/resource-tree"); err != nil {
+		t.Errorf("unlimited get failed: %v", err)
+	}
+}
+
+func TestGetManagedResourceDiff_FiltersByResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("kind") != "Deployment" {
+			w.Write([]byte(`{"items": []}`))
+			return
+		}
+		if q.Get("name") != "web" || q.Get("namespace") != "prod" || q.Get("group") != "apps" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"items": [{"group": "apps", "kind": "Deployment", "namespace": "prod", "name": "web", "diff": "x"}]}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "t"})
+	d, err := svc.GetManagedResourceDiff(context.Background(), "app", "", ManagedResourceKey{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "web"})
+	if err != nil || d == nil || d.Diff != "x" {
+		t.Fatalf("GetManagedResourceDiff() = %+v, %v", d, err)
+	}
+	d, _ = svc.GetManagedResourceDiff(context.Background(), "app", "", ManagedResourceKey{Kind: "Service", Name: "web"})
+	if d != nil {
+		t.Error("a resource the response does not contain should return nil")
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	for n, want := range map[int64]string{512: "512 B", 1536: "1.5 KB", 52 << 20: "52.0 MB"} {
+		if got := FormatByteSize(n); got != want {
+			t.Errorf("FormatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// RolloutGroup and RolloutKind identify Argo Rollouts Rollout resources
+const (
+	RolloutGroup = "argoproj.io"
+	RolloutKind  = "Rollout"
+)
+
+// IsRollout reports whether a resource is an Argo Rollouts Rollout
+func IsRollout(group, kind string) bool {
+	return group == RolloutGroup && kind == RolloutKind
+}
+
+// LiveResourceParams identifies a resource managed by an application
+type LiveResourceParams struct {
+	AppName      string
+	AppNamespace *string
+	ResourceName string
+	Namespace    string
+	Kind         string
+	Group        string
+	Version      string
+}
+
+// GetResourceManifest fetches the live manifest of a resource as JSON
+func (s *ApplicationService) GetResourceManifest(ctx context.Context, params LiveResourceParams) ([]byte, error) {
+	if params.AppName == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	if params.ResourceName == "" {
+		return nil, fmt.Errorf("resource name is required")
+	}
+	if params.Kind == "" {
+		return nil, fmt.Errorf("resource kind is required")
+	}
+
+	queryParams := url.Values{}
+	queryParams.Set("resourceName", params.ResourceName)
+	queryParams.Set("kind", params.Kind)
+	if params.Namespace != "" {
+		queryParams.Set("namespace", params.Namespace)
+	}
+	if params.Group != "" {
+		queryParams.Set("group", params.Group)
+	}
+	if params.Version != "" {
+		queryParams.Set("version", params.Version)
+	}
+	if params.AppNamespace != nil && *params.AppNamespace != "" {
+		queryParams.Set("appNamespace", *params.AppNamespace)
+	}
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/resource?%s", url.PathEscape(params.AppName), queryParams.Encode())
+
+	resp, err := s.client.Get(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %w", params.Kind, params.ResourceName, err)
+	}
+
+	// Argo CD returns the manifest as a JSON-encoded string
+	var result struct {
+		Manifest string `json:"manifest"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse resource response: %w", err)
+	}
+	if result.Manifest == "" {
+		return nil, fmt.Errorf("%s/%s has no live manifest", params.Kind, params.ResourceName)
+	}
+	return []byte(result.Manifest), nil
+}
+
+// GetRolloutStatus fetches a Rollout's live manifest and reads its progress
+func (s *ApplicationService) GetRolloutStatus(ctx context.Context, params LiveResourceParams) (*model.RolloutStatus, error) {
+	manifest, err := s.GetResourceManifest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRolloutStatus(manifest)
+}
+
+// rolloutManifest is the subset of a Rollout that Argonaut reads
+type rolloutManifest struct {
+	Spec struct {
+		Paused   bool `json:"paused"`
+		Strategy struct {
+			Canary *struct {
+				Steps []struct {
+					SetWeight *int `json:"setWeight,omitempty"`
+				} `json:"steps"`
+			} `json:"canary,omitempty"`
+			BlueGreen *struct{} `json:"blueGreen,omitempty"`
+		} `json:"strategy"`
+	} `json:"spec"`
+	Status struct {
+		Phase            string `json:"phase"`
+		Message          string `json:"message"`
+		CurrentStepIndex *int   `json:"currentStepIndex,omitempty"`
+		Abort            bool   `json:"abort"`
+		ControllerPause  bool   `json:"controllerPause"`
+		PauseConditions  []struct {
+			Reason string `json:"reason"`
+		} `json:"pauseConditions"`
+		Canary struct {
+			Weights *struct {
+				Canary struct {
+					Weight int `json:"weight"`
+				} `json:"canary"`
+			} `json:"weights,omitempty"`
+		} `json:"canary"`
+	} `json:"status"`
+}
+
+// ParseRolloutStatus reads the strategy, step, canary weight and pause state
+// from a Rollout manifest
+func ParseRolloutStatus(manifest []byte) (*model.RolloutStatus, error) {
+	var r rolloutManifest
+	if err := json.Unmarshal(manifest, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse rollout: %w", err)
+	}
+
+	st := &model.RolloutStatus{
+		Phase:   r.Status.Phase,
+		Message: r.Status.Message,
+		Aborted: r.Status.Abort,
+		Paused:  r.Spec.Paused || r.Status.ControllerPause || len(r.Status.PauseConditions) > 0,
+	}
+	for _, c := range r.Status.PauseConditions {
+		st.PauseReasons = append(st.PauseReasons, c.Reason)
+	}
+
+	switch {
+	case r.Spec.Strategy.Canary != nil:
+		st.Strategy = "canary"
+		steps := r.Spec.Strategy.Canary.Steps
+		st.Steps = len(steps)
+		if idx := r.Status.CurrentStepIndex; idx != nil && st.Steps > 0 {
+			st.Step = *idx + 1
+		}
+		switch {
+		case r.Status.Canary.Weights != nil:
+			st.Weight = r.Status.Canary.Weights.Canary.Weight
+		case st.Aborted:
+			st.Weight = 0
+		case st.Steps == 0 || st.Step > st.Steps:
+			st.Weight = 100
+		case st.Step > 0:
+			// The controller holds the weight of the last setWeight reached,
+			// the same way Argo Rollouts computes it without a traffic router
+			for i := st.Step - 1; i >= 0; i-- {
+				if w := steps[i].SetWeight; w != nil {
+					st.Weight = *w
+					break
+				}
+			}
+		}
+	case r.Spec.Strategy.BlueGreen != nil:
+		st.Strategy = "blueGreen"
+	}
+	return st, nil
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestGetRolloutStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/web/resource" {
+			t.Errorf("Expected path /api/v1/applications/web/resource, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		for key, want := range map[string]string{
+			"resourceName": "web",
+			"namespace":    "shop",
+			"kind":         "Rollout",
+			"group":        "argoproj.io",
+			"version":      "v1alpha1",
+			"appNamespace": "argocd",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("query %s = %q, want %q", key, got, want)
+			}
+		}
+		w.Write([]byte(`{"manifest": "{\"spec\":{\"strategy\":{\"canary\":{\"steps\":[{\"setWeight\":20},{\"pause\":{}},{\"setWeight\":50},{\"pause\":{\"duration\":\"10m\"}}]}}},\"status\":{\"phase\":\"Paused\",\"message\":\"CanaryPauseStep\",\"currentStepIndex\":1,\"pauseConditions\":[{\"reason\":\"CanaryPauseStep\"}]}}"}`))
+	}))
+	defer server.Close()
+
+	appNamespace := "argocd"
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	st, err := svc.GetRolloutStatus(context.Background(), LiveResourceParams{
+		AppName:      "web",
+		AppNamespace: &appNamespace,
+		ResourceName: "web",
+		Namespace:    "shop",
+		Kind:         RolloutKind,
+		Group:        RolloutGroup,
+		Version:      "v1alpha1",
+	})
+	if err != nil {
+		t.Fatalf("GetRolloutStatus returned error: %v", err)
+	}
+	want := &model.RolloutStatus{
+		Strategy:     "canary",
+		Phase:        "Paused",
+		Message:      "CanaryPauseStep",
+		Step:         2,
+		Steps:        4,
+		Weight:       20,
+		Paused:       true,
+		PauseReasons: []string{"CanaryPauseStep"},
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("GetRolloutStatus() = %+v, want %+v", st, want)
+	}
+}
+
+func TestParseRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     model.RolloutStatus
+	}{
+		{
+			name:     "weight from traffic router",
+			manifest: `{"spec":{"strategy":{"canary":{"steps":[{"setWeight":10},{"setWeight":40}]}}},"status":{"currentStepIndex":1,"canary":{"weights":{"canary":{"weight":35}}}}}`,
+			want:     model.RolloutStatus{Strategy: "canary", Step: 2, Steps: 2, Weight: 35},
+		},
+		{
+			name:     "all steps done",
+			manifest: `{"spec":{"strategy":{"canary":{"steps":[{"setWeight":10}]}}},"status":{"phase":"Healthy","currentStepIndex":1}}`,
+			want:     model.RolloutStatus{Strategy: "canary", Phase: "Healthy", Step: 2, Steps: 1, Weight: 100},
+		},
+		{
+			name:     "aborted",
+			manifest: `{"spec":{"strategy":{"canary":{"steps":[{"setWeight":10},{"pause":{}}]}}},"status":{"phase":"Degraded","abort":true,"currentStepIndex":0}}`,
+			want:     model.RolloutStatus{Strategy: "canary", Phase: "Degraded", Step: 1, Steps: 2, Aborted: true},
+		},
+		{
+			name:     "blue-green paused by user",
+			manifest: `{"spec":{"paused":true,"strategy":{"blueGreen":{"activeService":"web"}}},"status":{"phase":"Paused"}}`,
+			want:     model.RolloutStatus{Strategy: "blueGreen", Phase: "Paused", Paused: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRolloutStatus([]byte(tt.manifest))
+			if err != nil {
+				t.Fatalf("ParseRolloutStatus returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("ParseRolloutStatus() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
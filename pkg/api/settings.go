@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ResourceOverride mirrors the argocd-cm resource customization for a group/kind
+type ResourceOverride struct {
+	HealthLua   string `json:"healthLua,omitempty"`
+	UseOpenLibs bool   `json:"useOpenLibs,omitempty"`
+	Actions     string `json:"actions,omitempty"`
+}
+
+// Settings is the subset of /api/v1/settings that argonaut reads
+type Settings struct {
+	URL               string                      `json:"url,omitempty"`
+	ResourceOverrides map[string]ResourceOverride `json:"resourceOverrides,omitempty"`
+	OIDCConfig        *OIDCConfig                 `json:"oidcConfig,omitempty"`
+	DexConfig         *DexConfig                  `json:"dexConfig,omitempty"`
+}
+
+// OIDCConfig is an external OIDC provider Argo CD signs users in with
+type OIDCConfig struct {
+	Name        string   `json:"name,omitempty"`
+	Issuer      string   `json:"issuer,omitempty"`
+	ClientID    string   `json:"clientID,omitempty"`
+	CLIClientID string   `json:"cliClientID,omitempty"`
+	Scopes      []string `json:"scopes,omitempty"`
+}
+
+// DexConfig is the bundled Dex, set when it has connectors to sign in with
+type DexConfig struct {
+	Connectors []DexConnector `json:"connectors,omitempty"`
+}
+
+// DexConnector is an identity provider Dex signs users in with
+type DexConnector struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// dexCLIClientID is the client Argo CD registers in its Dex for the CLI
+const dexCLIClientID = "argo-cd-cli"
+
+// defaultSSOScopes are requested when the OIDC config names none, the same
+// ones the argocd CLI asks for, with offline_access for a refresh token
+var defaultSSOScopes = []string{"openid", "profile", "email", "groups", "offline_access"}
+
+// SSOProvider returns where the CLI signs in: the issuer, the client ID and
+// the scopes to request. An external OIDC provider wins over Dex, as in Argo
+// CD; Dex is served under /api/dex of the server. Fails when neither is set
+// up.
+func (s *Settings) SSOProvider(serverURL string) (issuer, clientID string, scopes []string, err error) {
+	if s != nil && s.OIDCConfig != nil && s.OIDCConfig.Issuer != "" {
+		clientID = s.OIDCConfig.CLIClientID
+		if clientID == "" {
+			clientID = s.OIDCConfig.ClientID
+		}
+		scopes = defaultSSOScopes
+		if len(s.OIDCConfig.Scopes) > 0 {
+			scopes = s.OIDCConfig.Scopes
+			if !slices.Contains(scopes, "openid") {
+				scopes = append([]string{"openid"}, scopes...)
+			}
+		}
+		return s.OIDCConfig.Issuer, clientID, scopes, nil
+	}
+	if s != nil && s.DexConfig != nil && len(s.DexConfig.Connectors) > 0 {
+		base := s.URL
+		if base == "" {
+			base = serverURL
+		}
+		return strings.TrimRight(base, "/") + "/api/dex", dexCLIClientID, defaultSSOScopes, nil
+	}
+	return "", "", nil, errors.New("the server has no SSO configured")
+}
+
+// GetSettings fetches the public Argo CD server settings
+func (s *ApplicationService) GetSettings(ctx context.Context) (*Settings, error) {
+	resp, err := s.client.Get(ctx, "/api/v1/settings")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(resp, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode settings response: %w", err)
+	}
+	return &settings, nil
+}
+
+// CustomHealthKeys returns the resource override keys that define a Lua
+// health check, sorted for stable output. Keys use Argo CD's "group/Kind"
+// form ("Kind" for the core group) and may contain glob wildcards.
+func (s *Settings) CustomHealthKeys() []string {
+	if s == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(s.ResourceOverrides))
+	for key, ov := range s.ResourceOverrides {
+		if ov.HealthLua != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
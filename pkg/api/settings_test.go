@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestGetSettings_CustomHealthKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/settings" {
+			t.Errorf("Expected path /api/v1/settings, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"url": "https://argo.example.com",
+			"resourceOverrides": {
+				"argoproj.io/Rollout": {"healthLua": "hs = {}\nreturn hs"},
+				"Secret": {"healthLua": "return {status = 'Healthy'}"},
+				"apps/Deployment": {"actions": "discovery.lua: ..."}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	settings, err := svc.GetSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetSettings returned error: %v", err)
+	}
+
+	want := []string{"Secret", "argoproj.io/Rollout"}
+	if got := settings.CustomHealthKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CustomHealthKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomHealthKeys_NilSettings(t *testing.T) {
+	var s *Settings
+	if keys := s.CustomHealthKeys(); keys != nil {
+		t.Errorf("expected nil keys, got %v", keys)
+	}
+}
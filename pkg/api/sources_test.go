@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestConvertToApp_MultiSource(t *testing.T) {
+	var argoApp ArgoApplication
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"name": "web", "namespace": "argocd"},
+		"spec": {
+			"sources": [
+				{"repoURL": "https://charts.example.com", "chart": "web", "targetRevision": "1.4.0"},
+				{"repoURL": "https://git.example.com/values.git", "targetRevision": "main", "ref": "values"}
+			]
+		},
+		"status": {"sync": {"status": "Synced", "revisions": ["1.4.0", "0123456789abcdef"]}}
+	}`), &argoApp)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	app := (&ApplicationService{}).ConvertToApp(argoApp)
+	if !app.MultiSource {
+		t.Fatal("expected MultiSource to be set")
+	}
+	if len(app.Sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(app.Sources))
+	}
+	if app.Sources[0].Chart != "web" || app.Sources[0].Revision != "1.4.0" {
+		t.Errorf("unexpected chart source: %+v", app.Sources[0])
+	}
+	if app.Sources[1].Ref != "values" || app.Sources[1].Revision != "0123456789abcdef" {
+		t.Errorf("unexpected git source: %+v", app.Sources[1])
+	}
+}
+
+func TestConvertToApp_SingleSourceAndHydrator(t *testing.T) {
+	var argoApp ArgoApplication
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"name": "api"},
+		"spec": {
+			"source": {"repoURL": "https://git.example.com/app.git", "path": "deploy", "targetRevision": "HEAD"},
+			"sourceHydrator": {
+				"drySource": {"repoURL": "https://git.example.com/dry.git", "targetRevision": "main", "path": "apps/api"},
+				"syncSource": {"targetBranch": "env/prod", "path": "api"},
+				"hydrateTo": {"targetBranch": "env/prod-next"}
+			}
+		},
+		"status": {"sync": {"status": "Synced", "revision": "abc123"}}
+	}`), &argoApp)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	app := (&ApplicationService{}).ConvertToApp(argoApp)
+	if app.MultiSource {
+		t.Error("single-source app should not be marked multi-source")
+	}
+	if len(app.Sources) != 1 || app.Sources[0].Path != "deploy" || app.Sources[0].Revision != "abc123" {
+		t.Errorf("unexpected sources: %+v", app.Sources)
+	}
+	if app.Hydrator == nil {
+		t.Fatal("expected hydrator to be set")
+	}
+	if app.Hydrator.DryPath != "apps/api" || app.Hydrator.SyncBranch != "env/prod" || app.Hydrator.HydrateToBranch != "env/prod-next" {
+		t.Errorf("unexpected hydrator: %+v", app.Hydrator)
+	}
+}
+
+func TestConvertDeploymentHistoryToRollbackRows_MultiSource(t *testing.T) {
+	history := []DeploymentHistory{
+		{ID: 1, Revision: "aaa111"},
+		{
+			ID:        2,
+			Revisions: []string{"1.4.0", "bbb222"},
+			Sources: []ApplicationSource{
+				{RepoURL: "https://charts.example.com", Chart: "web"},
+				{RepoURL: "https://git.example.com/values.git"},
+			},
+		},
+	}
+
+	rows := ConvertDeploymentHistoryToRollbackRows(history)
+	if rows[0].SourceIndex != nil || rows[0].Revision != "aaa111" {
+		t.Errorf("single-source row changed: %+v", rows[0])
+	}
+	if rows[1].SourceIndex == nil || *rows[1].SourceIndex != 1 {
+		t.Fatalf("expected git source index 1, got %+v", rows[1].SourceIndex)
+	}
+	if rows[1].Revision != "bbb222" || len(rows[1].Revisions) != 2 {
+		t.Errorf("unexpected multi-source row: %+v", rows[1])
+	}
+}
+
+func TestGetRevisionMetadataForSource_Query(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/web/revisions/bbb222/metadata" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("sourceIndex") != "1" || q.Get("versionId") != "2" || q.Get("appNamespace") != "team-a" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"author": "dev", "message": "bump values"}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	ns := "team-a"
+	meta, err := svc.GetRevisionMetadataForSource(context.Background(), "web", "bbb222", &ns, 1, 2)
+	if err != nil {
+		t.Fatalf("GetRevisionMetadataForSource returned error: %v", err)
+	}
+	if meta.Author != "dev" || meta.Message != "bump values" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+}
+
+func TestSyncApplication_SourcePositions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		positions, _ := body["sourcePositions"].([]interface{})
+		revisions, _ := body["revisions"].([]interface{})
+		if len(positions) != 1 || positions[0] != float64(2) {
+			t.Errorf("unexpected sourcePositions: %v", body["sourcePositions"])
+		}
+		if len(revisions) != 1 || revisions[0] != "main" {
+			t.Errorf("unexpected revisions: %v", body["revisions"])
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	err := svc.SyncApplication(context.Background(), "web", &SyncOptions{SourcePositions: []int64{2}, Revisions: []string{"main"}})
+	if err != nil {
+		t.Fatalf("SyncApplication returned error: %v", err)
+	}
+}
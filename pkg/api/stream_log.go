@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StreamLogSize is how many watch stream events the stream log keeps
+const StreamLogSize = 200
+
+// redactedValue replaces the values the stream log must not keep
+const redactedValue = "<redacted>"
+
+// StreamEvent is one application watch stream event as it was received,
+// with anything that may hold a secret redacted
+type StreamEvent struct {
+	Seq  uint64
+	At   time.Time
+	Type string // ADDED, MODIFIED or DELETED
+	App  string // namespace/name
+	Raw  []byte // redacted event JSON
+}
+
+// streamLog keeps the most recent watch stream events while capturing is
+// on. Capturing is off until something asks for the log, since redacting
+// every event of a large fleet is not free.
+var streamLog struct {
+	capture atomic.Bool
+	mu      sync.Mutex
+	events  []StreamEvent
+	seq     uint64
+}
+
+// SetStreamCapture turns recording of the watch stream events on or off
+func SetStreamCapture(on bool) {
+	streamLog.capture.Store(on)
+}
+
+// StreamCapturing reports whether watch stream events are being recorded
+func StreamCapturing() bool {
+	return streamLog.capture.Load()
+}
+
+// StreamEvents returns the recorded watch stream events, oldest first
+func StreamEvents() []StreamEvent {
+	streamLog.mu.Lock()
+	defer streamLog.mu.Unlock()
+	return append([]StreamEvent(nil), streamLog.events...)
+}
+
+// ClearStreamEvents forgets the recorded watch stream events
+func ClearStreamEvents() {
+	streamLog.mu.Lock()
+	defer streamLog.mu.Unlock()
+	streamLog.events = nil
+}
+
+// recordStreamEvent keeps a redacted copy of a watch stream event and
+// returns its sequence number, or 0 when capturing is off
+func recordStreamEvent(event ApplicationWatchEvent, data []byte) uint64 {
+	if !streamLog.capture.Load() {
+		return 0
+	}
+	app := event.Application.Metadata.Name
+	if ns := event.Application.Metadata.Namespace; ns != "" {
+		app = ns + "/" + app
+	}
+	raw := redactStreamEvent(data)
+
+	streamLog.mu.Lock()
+	defer streamLog.mu.Unlock()
+	streamLog.seq++
+	streamLog.events = append(streamLog.events, StreamEvent{
+		Seq:  streamLog.seq,
+		At:   time.Now(),
+		Type: event.Type,
+		App:  app,
+		Raw:  raw,
+	})
+	if over := len(streamLog.events) - StreamLogSize; over > 0 {
+		streamLog.events = append([]StreamEvent(nil), streamLog.events[over:]...)
+	}
+	return streamLog.seq
+}
+
+// redactedKeys are the fields whose whole value is dropped from the stream
+// log: Helm values and parameters, plugin environment, and the last applied
+// configuration, which repeats the spec
+var redactedKeys = map[string]bool{
+	"values":         true,
+	"valuesObject":   true,
+	"parameters":     true,
+	"fileParameters": true,
+	"env":            true,
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+}
+
+// redactStreamEvent drops the fields of an event that may hold secrets:
+// those in redactedKeys and any whose name mentions a password, token or
+// secret. Events that do not decode are not kept at all.
+func redactStreamEvent(data []byte) []byte {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return []byte(`"` + redactedValue + `"`)
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redactValue(v)); err != nil {
+		return []byte(`"` + redactedValue + `"`)
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if isSecretKey(k) {
+				t[k] = redactedValue
+				continue
+			}
+			t[k] = redactValue(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = redactValue(child)
+		}
+	}
+	return v
+}
+
+func isSecretKey(key string) bool {
+	if redactedKeys[key] {
+		return true
+	}
+	lower := strings.ToLower(key)
+	return strings.Contains(lower, "password") || strings.Contains(lower, "token") || strings.Contains(lower, "secret")
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// watchEvents serves the given watch events as an application stream and
+// reads them all back through WatchApplications
+func watchEvents(t *testing.T, events ...string) []ApplicationWatchEvent {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprintf(w, "data: %s\n\n", e)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: "test-token"})
+	ch := make(chan ApplicationWatchEvent, len(events))
+	if err := svc.WatchApplications(context.Background(), ch); err != nil {
+		t.Fatalf("WatchApplications: %v", err)
+	}
+	close(ch)
+	var got []ApplicationWatchEvent
+	for e := range ch {
+		got = append(got, e)
+	}
+	return got
+}
+
+func resetStreamLog(t *testing.T) {
+	t.Cleanup(func() {
+		SetStreamCapture(false)
+		ClearStreamEvents()
+	})
+	ClearStreamEvents()
+}
+
+func TestStreamLog_OffUntilCaptureIsTurnedOn(t *testing.T) {
+	resetStreamLog(t)
+	got := watchEvents(t, `{"result":{"type":"MODIFIED","application":{"metadata":{"name":"guestbook"}}}}`)
+	if len(got) != 1 || got[0].StreamSeq != 0 {
+		t.Fatalf("expected the event without a stream log entry, got %+v", got)
+	}
+	if n := len(StreamEvents()); n != 0 {
+		t.Errorf("nothing should be recorded while capturing is off, got %d events", n)
+	}
+}
+
+func TestStreamLog_RecordsRedactedEvents(t *testing.T) {
+	resetStreamLog(t)
+	SetStreamCapture(true)
+	got := watchEvents(t,
+		`{"result":{"type":"ADDED","application":{"metadata":{"name":"guestbook","namespace":"argocd"},`+
+			`"spec":{"source":{"repoURL":"https://git.example.com/app","helm":{"values":"db: hunter2","parameters":[{"name":"a","value":"b"}]}}},`+
+			`"status":{"sync":{"status":"Synced"}}}}}`,
+		`{"result":{"type":"MODIFIED","application":{"metadata":{"name":"guestbook","namespace":"argocd"},`+
+			`"spec":{"source":{"plugin":{"env":[{"name":"X","value":"y"}]}}},"status":{"extra":{"dbPassword":"s3cret","apiToken":"t0k"}}}}}`,
+	)
+
+	events := StreamEvents()
+	if len(events) != 2 || len(got) != 2 {
+		t.Fatalf("expected 2 recorded events, got %d (%d delivered)", len(events), len(got))
+	}
+	for i, e := range events {
+		if e.Seq == 0 || e.Seq != got[i].StreamSeq {
+			t.Errorf("event %d: delivered seq %d does not match the log entry %d", i, got[i].StreamSeq, e.Seq)
+		}
+	}
+	if events[0].Type != "ADDED" || events[0].App != "argocd/guestbook" {
+		t.Errorf("unexpected event summary %+v", events[0])
+	}
+
+	raw := string(events[0].Raw) + string(events[1].Raw)
+	for _, secret := range []string{"hunter2", `"value":"b"`, `"value":"y"`, "s3cret", "t0k"} {
+		if strings.Contains(raw, secret) {
+			t.Errorf("the stream log kept %q:\n%s", secret, raw)
+		}
+	}
+	for _, kept := range []string{"https://git.example.com/app", `"status":"Synced"`, redactedValue} {
+		if !strings.Contains(raw, kept) {
+			t.Errorf("expected %q in the stream log:\n%s", kept, raw)
+		}
+	}
+}
+
+func TestStreamLog_KeepsOnlyTheLastEvents(t *testing.T) {
+	resetStreamLog(t)
+	SetStreamCapture(true)
+	event := ApplicationWatchEvent{Type: "MODIFIED"}
+	var last uint64
+	for range StreamLogSize + 5 {
+		last = recordStreamEvent(event, []byte(`{}`))
+	}
+	events := StreamEvents()
+	if len(events) != StreamLogSize {
+		t.Fatalf("expected %d events, got %d", StreamLogSize, len(events))
+	}
+	if events[len(events)-1].Seq != last || events[0].Seq != last-StreamLogSize+1 {
+		t.Errorf("expected the newest events to be kept, got seqs %d..%d", events[0].Seq, events[len(events)-1].Seq)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// TokenScope is the project and role a project role token is limited to
+type TokenScope struct {
+	Project string
+	Role    string
+}
+
+// String renders the scope the way Argo CD names the token's subject
+func (s TokenScope) String() string {
+	return "proj:" + s.Project + ":" + s.Role
+}
+
+// ProjectTokenScope reports the project a token is limited to. Tokens made
+// with `argocd proj role create-token` carry the subject proj:<project>:<role>;
+// user and account tokens, and anything that is not a JWT, return false.
+// The signature is not checked: the server does that, this only decides
+// which projects to ask for.
+func ProjectTokenScope(token string) (TokenScope, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenScope{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return TokenScope{}, false
+	}
+	var claims struct {
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return TokenScope{}, false
+	}
+	rest, ok := strings.CutPrefix(claims.Sub, "proj:")
+	if !ok {
+		return TokenScope{}, false
+	}
+	project, role, ok := strings.Cut(rest, ":")
+	if !ok || project == "" {
+		return TokenScope{}, false
+	}
+	return TokenScope{Project: project, Role: role}, true
+}
+
+// scopedProjects returns the projects to filter list and watch calls by: the
+// requested ones, or the token's project when none were requested and the
+// token cannot see the others anyway
+func (s *ApplicationService) scopedProjects(projects []string) []string {
+	if len(projects) > 0 {
+		return projects
+	}
+	if scope, ok := ProjectTokenScope(s.client.token); ok {
+		return []string{scope.Project}
+	}
+	return nil
+}
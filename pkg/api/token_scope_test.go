@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// testJWT builds an unsigned token with the given claims
+func testJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestProjectTokenScope(t *testing.T) {
+	scope, ok := ProjectTokenScope(testJWT(`{"sub":"proj:payments:ci","iss":"argocd"}`))
+	if !ok || scope != (TokenScope{Project: "payments", Role: "ci"}) {
+		t.Fatalf("ProjectTokenScope() = %+v, %v", scope, ok)
+	}
+	if got := scope.String(); got != "proj:payments:ci" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, token := range []string{
+		testJWT(`{"sub":"admin","iss":"argocd"}`),
+		testJWT(`{"sub":"ci-bot:apiKey"}`),
+		testJWT(`{"sub":"proj::ci"}`),
+		"not-a-jwt",
+		"a.!!!.c",
+	} {
+		if scope, ok := ProjectTokenScope(token); ok {
+			t.Errorf("ProjectTokenScope(%q) = %+v, want no scope", token, scope)
+		}
+	}
+}
+
+func TestListApplications_ProjectTokenFiltersByProject(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()["projects"]
+		w.Write([]byte(`{"metadata": {"resourceVersion": "1"}, "items": []}`))
+	}))
+	defer server.Close()
+
+	svc := NewApplicationService(&model.Server{BaseURL: server.URL, Token: testJWT(`{"sub":"proj:payments:ci"}`)})
+	if _, err := svc.ListApplicationsWithMeta(context.Background()); err != nil {
+		t.Fatalf("ListApplicationsWithMeta returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"payments"}) {
+		t.Errorf("projects = %v, want [payments]", got)
+	}
+
+	svc = NewApplicationService(&model.Server{BaseURL: server.URL, Token: testJWT(`{"sub":"admin"}`)})
+	if _, err := svc.ListApplicationsWithMeta(context.Background()); err != nil {
+		t.Fatalf("ListApplicationsWithMeta returned error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("user tokens list every project, got filter %v", got)
+	}
+}
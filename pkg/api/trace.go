@@ -0,0 +1,51 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// RequestTraceSize is how many API requests the request trace keeps
+const RequestTraceSize = 100
+
+// RequestTrace is one completed API request, without its body or headers
+type RequestTrace struct {
+	At       time.Time
+	Method   string
+	Path     string
+	Status   int // 0 when no response was received
+	Duration time.Duration
+	Err      string
+}
+
+// requestTrace keeps the most recent API requests for support bundles.
+// Streams are not included since they stay open.
+var requestTrace struct {
+	mu       sync.Mutex
+	requests []RequestTrace
+}
+
+// recordRequest adds a completed request to the trace, dropping the oldest
+// once RequestTraceSize are kept
+func recordRequest(r RequestTrace) {
+	requestTrace.mu.Lock()
+	defer requestTrace.mu.Unlock()
+	requestTrace.requests = append(requestTrace.requests, r)
+	if over := len(requestTrace.requests) - RequestTraceSize; over > 0 {
+		requestTrace.requests = append([]RequestTrace(nil), requestTrace.requests[over:]...)
+	}
+}
+
+// RecentRequests returns the traced API requests, oldest first
+func RecentRequests() []RequestTrace {
+	requestTrace.mu.Lock()
+	defer requestTrace.mu.Unlock()
+	return append([]RequestTrace(nil), requestTrace.requests...)
+}
+
+// ResetRequestTrace forgets the traced requests
+func ResetRequestTrace() {
+	requestTrace.mu.Lock()
+	defer requestTrace.mu.Unlock()
+	requestTrace.requests = nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestClientRequest_RecordsTrace(t *testing.T) {
+	ResetRequestTrace()
+	defer ResetRequestTrace()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/applications/missing" {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&model.Server{BaseURL: server.URL, Token: "t"})
+	_, _ = client.Get(context.Background(), "/api/version")
+	_, _ = client.Get(context.Background(), "/api/v1/applications/missing")
+
+	trace := RecentRequests()
+	if len(trace) != 2 {
+		t.Fatalf("traced %d requests, want 2", len(trace))
+	}
+	if trace[0].Method != "GET" || trace[0].Path != "/api/version" || trace[0].Status != 200 || trace[0].Err != "" {
+		t.Errorf("first request = %+v", trace[0])
+	}
+	if trace[1].Status != 404 || trace[1].Err == "" {
+		t.Errorf("failed request should keep its status and error: %+v", trace[1])
+	}
+}
+
+func TestRecordRequest_KeepsTheMostRecent(t *testing.T) {
+	ResetRequestTrace()
+	defer ResetRequestTrace()
+
+	for i := range RequestTraceSize + 5 {
+		recordRequest(RequestTrace{Status: i})
+	}
+	trace := RecentRequests()
+	if len(trace) != RequestTraceSize || trace[0].Status != 5 {
+		t.Errorf("kept %d requests starting at %d, want %d starting at 5", len(trace), trace[0].Status, RequestTraceSize)
+	}
+}
@@ -0,0 +1,107 @@
+// Package appcache persists the last-known application list per Argo CD
+// server so the UI can render it instantly on startup while the live list
+// loads in the background.
+package appcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// formatVersion is bumped whenever the snapshot layout changes; snapshots
+// written with another version are ignored rather than misread.
+const formatVersion = 1
+
+// Snapshot is the on-disk app list for one server
+type Snapshot struct {
+	Version int         `json:"version"`
+	Server  string      `json:"server"`
+	SavedAt time.Time   `json:"savedAt"`
+	Apps    []model.App `json:"apps"`
+}
+
+// DefaultDir returns the directory snapshots are stored in.
+// ARGONAUT_CACHE_DIR overrides the platform user cache directory.
+func DefaultDir() string {
+	if dir := os.Getenv("ARGONAUT_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "argonaut")
+}
+
+// snapshotPath names the snapshot file after a hash of the server URL so
+// several contexts can keep their own list side by side
+func snapshotPath(dir, serverURL string) string {
+	sum := sha256.Sum256([]byte(serverURL))
+	return filepath.Join(dir, "apps-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// Load reads the snapshot for serverURL. It returns (nil, nil) when no
+// usable snapshot exists.
+func Load(dir, serverURL string) (*Snapshot, error) {
+	data, err := os.ReadFile(snapshotPath(dir, serverURL))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app cache: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse app cache: %w", err)
+	}
+	if snap.Version != formatVersion || snap.Server != serverURL {
+		return nil, nil
+	}
+	return &snap, nil
+}
+
+// Save writes the snapshot for serverURL atomically (temp file + rename)
+func Save(dir, serverURL string, apps []model.App) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	data, err := json.Marshal(Snapshot{
+		Version: formatVersion,
+		Server:  serverURL,
+		SavedAt: time.Now(),
+		Apps:    apps,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode app cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "apps-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write app cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write app cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write app cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), snapshotPath(dir, serverURL)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write app cache: %w", err)
+	}
+	return nil
+}
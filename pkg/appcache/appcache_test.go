@@ -0,0 +1,72 @@
+package appcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	project := "infra"
+	apps := []model.App{
+		{Name: "api", Sync: "Synced", Health: "Healthy", Project: &project},
+		{Name: "web", Sync: "OutOfSync", Health: "Degraded"},
+	}
+
+	if err := Save(dir, "https://argo.example.com", apps); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	snap, err := Load(dir, "https://argo.example.com")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if snap == nil || len(snap.Apps) != 2 {
+		t.Fatalf("expected 2 cached apps, got %+v", snap)
+	}
+	if snap.Apps[0].Project == nil || *snap.Apps[0].Project != "infra" {
+		t.Errorf("project not preserved: %+v", snap.Apps[0])
+	}
+	if snap.SavedAt.IsZero() {
+		t.Error("SavedAt should be set")
+	}
+}
+
+func TestLoad_MissingOrOtherServer(t *testing.T) {
+	dir := t.TempDir()
+	if snap, err := Load(dir, "https://argo.example.com"); snap != nil || err != nil {
+		t.Fatalf("missing cache: snap=%+v err=%v", snap, err)
+	}
+
+	if err := Save(dir, "https://a.example.com", []model.App{{Name: "a"}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if snap, _ := Load(dir, "https://b.example.com"); snap != nil {
+		t.Fatalf("snapshot leaked across servers: %+v", snap)
+	}
+}
+
+func TestLoad_IgnoresOtherFormatVersion(t *testing.T) {
+	dir := t.TempDir()
+	server := "https://argo.example.com"
+	data := []byte(`{"version": 999, "server": "https://argo.example.com", "apps": [{"name": "x"}]}`)
+	if err := os.WriteFile(snapshotPath(dir, server), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if snap, err := Load(dir, server); snap != nil || err != nil {
+		t.Fatalf("expected unknown version to be ignored: snap=%+v err=%v", snap, err)
+	}
+}
+
+func TestSave_LeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := Save(dir, "https://argo.example.com", nil); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if len(tmps) != 0 {
+		t.Fatalf("temp files left behind: %v", tmps)
+	}
+}
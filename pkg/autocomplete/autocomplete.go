@@ -85,6 +85,47 @@ func NewAutocompleteEngine() *AutocompleteEngine {
 			TakesArg:    true,
 			ArgType:     "app",
 		},
+		{
+			Command:     "details",
+			Aliases:     []string{"details", "info"},
+			Description: "Show sources, revisions and hydrator for application",
+			TakesArg:    true,
+			ArgType:     "app",
+		},
+		{
+			Command:     "hooks",
+			Aliases:     []string{"hooks", "hook"},
+			Description: "Show hooks run by the last sync and their logs",
+			TakesArg:    true,
+			ArgType:     "app",
+		},
+		{
+			Command:     "wait",
+			Aliases:     []string{"wait"},
+			Description: "Wait for application to be synced, healthy and done (--for, --timeout)",
+			TakesArg:    true,
+			ArgType:     "app",
+		},
+		{
+			Command:     "param",
+			Aliases:     []string{"param", "set-param"},
+			Description: "Override Helm parameters of the selected app ([source] name=value...)",
+			TakesArg:    true,
+			ArgType:     "param",
+		},
+		{
+			Command:     "manifest",
+			Aliases:     []string{"manifest", "yaml"},
+			Description: "View the selected resource's live manifest with folding",
+			TakesArg:    false,
+		},
+		{
+			Command:     "save",
+			Aliases:     []string{"save", "w"},
+			Description: "Save the selected resource's live manifest as YAML",
+			TakesArg:    true,
+			ArgType:     "file",
+		},
 		{
 			Command:     "delete",
 			Aliases:     []string{"delete", "del", "rm"},
@@ -137,9 +178,79 @@ func NewAutocompleteEngine() *AutocompleteEngine {
 		{
 			Command:     "help",
 			Aliases:     []string{"help", "h", "?"},
-			Description: "Show help modal",
+			Description: "Show help modal (:help views for views and default_view)",
+			TakesArg:    true,
+			ArgType:     "help-topic",
+		},
+		{
+			Command:     "errors",
+			Aliases:     []string{"errors", "errs"},
+			Description: "Show recent errors and retry failed operations",
 			TakesArg:    false,
-			ArgType:     "",
+		},
+		{
+			Command:     "map",
+			Aliases:     []string{"map", "topology"},
+			Description: "Show apps as cells by cluster and namespace, colored by health",
+			TakesArg:    false,
+		},
+		{
+			Command:     "stream",
+			Aliases:     []string{"stream"},
+			Description: "Show received watch events and what they changed",
+			TakesArg:    false,
+		},
+		{
+			Command:     "profile",
+			Aliases:     []string{"profile"},
+			Description: "Record render times per view; run again to see them (:profile render)",
+			TakesArg:    true,
+			ArgType:     "profile",
+		},
+		{
+			Command:     "history",
+			Aliases:     []string{"history", "timeline"},
+			Description: "Step back through app statuses seen in the last hour",
+			TakesArg:    false,
+		},
+		{
+			Command:     "wide",
+			Aliases:     []string{"wide"},
+			Description: "Toggle the NAME column taking the whole width",
+			TakesArg:    false,
+		},
+		{
+			Command:     "keys",
+			Aliases:     []string{"keys", "keymap", "bindings"},
+			Description: "Show every key binding by view and modal (export [file] writes a cheat sheet)",
+			TakesArg:    true,
+			ArgType:     "keys-action",
+		},
+		{
+			Command:     "stage",
+			Aliases:     []string{"stage", "train"},
+			Description: "Stage a sync, refresh, rollback or skip per selected app and run them in order",
+			TakesArg:    false,
+		},
+		{
+			Command:     "export",
+			Aliases:     []string{"export"},
+			Description: "Export rendered manifests (or specs) of the apps in scope to a folder per app (.zip to zip)",
+			TakesArg:    true,
+			ArgType:     "export-kind",
+		},
+		{
+			Command:     "termcheck",
+			Aliases:     []string{"termcheck"},
+			Description: "Check colors, Unicode width, mouse, alternate screen and clipboard support of the terminal",
+			TakesArg:    false,
+		},
+		{
+			Command:     "support-bundle",
+			Aliases:     []string{"support-bundle", "support"},
+			Description: "Write logs, redacted config and recent errors to a tar.gz for bug reports",
+			TakesArg:    true,
+			ArgType:     "file",
 		},
 		{
 			Command:     "upgrade",
@@ -336,6 +447,26 @@ func (e *AutocompleteEngine) getArgumentSuggestions(command, argPrefix string, s
 		suggestions = e.getSortSuggestions(argPrefix)
 	case "argocd-context":
 		suggestions = e.getArgocdContextSuggestions(argPrefix, state)
+	case "keys-action":
+		if strings.HasPrefix("export", argPrefix) {
+			suggestions = append(suggestions, "export")
+		}
+	case "export-kind":
+		for _, kind := range []string{"manifests", "specs"} {
+			if strings.HasPrefix(kind, argPrefix) {
+				suggestions = append(suggestions, kind)
+			}
+		}
+	case "profile":
+		if strings.HasPrefix("render", argPrefix) {
+			suggestions = append(suggestions, "render")
+		}
+	case "help-topic":
+		for _, topic := range []string{"keys", "views"} {
+			if strings.HasPrefix(topic, argPrefix) {
+				suggestions = append(suggestions, topic)
+			}
+		}
 	}
 
 	// Add command prefix to suggestions
@@ -520,7 +651,7 @@ func (e *AutocompleteEngine) getArgocdContextSuggestions(prefix string, state *m
 func (e *AutocompleteEngine) getSortSuggestions(prefix string) []string {
 	// Sort suggestions are just field names - direction is a second argument
 	options := []string{
-		"name", "sync", "health",
+		"name", "sync", "health", "operation", "duration",
 	}
 
 	var suggestions []string
@@ -584,3 +715,50 @@ func (e *AutocompleteEngine) getSecondArgumentSuggestions(command, firstArg, pre
 func (e *AutocompleteEngine) GetAllCommands() []CommandAlias {
 	return e.commands
 }
+
+// GetFuzzyArgumentMatches returns the argument values of a command that
+// contain the typed characters in order. Prefix matches come first, then
+// substring matches, then scattered ones; each group stays alphabetical.
+func (e *AutocompleteEngine) GetFuzzyArgumentMatches(command, arg string, state *model.AppState) []string {
+	all := e.getArgumentSuggestions(command, "", state)
+	arg = strings.ToLower(arg)
+
+	var groups [3][]string
+	seen := make(map[string]bool)
+	for _, s := range all {
+		cand := strings.TrimPrefix(s, ":"+command+" ")
+		if seen[cand] {
+			continue
+		}
+		seen[cand] = true
+		lower := strings.ToLower(cand)
+		switch {
+		case strings.HasPrefix(lower, arg):
+			groups[0] = append(groups[0], cand)
+		case strings.Contains(lower, arg):
+			groups[1] = append(groups[1], cand)
+		case isSubsequence(arg, lower):
+			groups[2] = append(groups[2], cand)
+		}
+	}
+
+	var matches []string
+	for _, g := range groups {
+		matches = append(matches, g...)
+	}
+	return matches
+}
+
+// isSubsequence reports whether every rune of needle appears in haystack in order
+func isSubsequence(needle, haystack string) bool {
+	rest := []rune(needle)
+	for _, r := range haystack {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
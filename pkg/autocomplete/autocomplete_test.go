@@ -273,7 +273,7 @@ func TestSortCommandAutocomplete(t *testing.T) {
 
 	// Test sort field suggestions with trailing space
 	suggestions := engine.GetCommandAutocomplete(":sort ", state)
-	expectedFields := []string{":sort health", ":sort name", ":sort sync"}
+	expectedFields := []string{":sort duration", ":sort health", ":sort name", ":sort operation", ":sort sync"}
 	if !reflect.DeepEqual(suggestions, expectedFields) {
 		t.Errorf("Expected %v, got %v", expectedFields, suggestions)
 	}
@@ -465,3 +465,29 @@ func TestAppSetCommandAutocomplete_NoAppSets(t *testing.T) {
 		t.Errorf("Expected 0 suggestions when no apps have ApplicationSet, got %d: %v", len(suggestions), suggestions)
 	}
 }
+
+func TestGetFuzzyArgumentMatches(t *testing.T) {
+	engine := NewAutocompleteEngine()
+	state := createTestState()
+
+	tests := []struct {
+		command  string
+		arg      string
+		expected []string
+	}{
+		{"sync", "", []string{"analytics", "backend", "cache", "database", "frontend"}},
+		{"sync", "a", []string{"analytics", "backend", "cache", "database"}},
+		{"sync", "end", []string{"backend", "frontend"}},
+		{"s", "FE", []string{"frontend"}}, // aliases resolve, case-insensitive, scattered
+		{"sync", "xyz", nil},
+		{"cluster", "sg", []string{"staging"}},
+		{"logs", "a", nil}, // takes no argument
+	}
+
+	for _, test := range tests {
+		got := engine.GetFuzzyArgumentMatches(test.command, test.arg, state)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("GetFuzzyArgumentMatches(%q, %q) = %v, want %v", test.command, test.arg, got, test.expected)
+		}
+	}
+}
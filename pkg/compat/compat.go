@@ -0,0 +1,225 @@
+// Package compat checks an Argo CD server's version (as reported by
+// /api/version) against the versions argonaut supports, and names the
+// features a server is too old for so they can be turned off up front
+// rather than failing with a 404 later. Where a version can't be trusted
+// (forks, backports, builds from source) probing the endpoints themselves
+// decides.
+package compat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed Argo CD release version
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// String renders the version as Argo CD does, e.g. "v2.10.3"
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less reports whether v is an older release than o
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// Parse reads a version such as "v2.10.3+a1b2c3d" or "v3.1.0-rc1"; the
+// pre-release and build suffixes are ignored. Servers built from source can
+// report anything, so ok is false for versions that don't look like a
+// release.
+func Parse(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, false
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, false
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, true
+}
+
+// Oldest is the oldest Argo CD release argonaut supports
+var Oldest = Version{Major: 2, Minor: 8}
+
+// NewestMajor is the newest Argo CD major release argonaut has been tested
+// with; later majors may have moved endpoints
+const NewestMajor = 3
+
+// Feature is a part of argonaut that not every supported server serves
+type Feature string
+
+const (
+	// ResourceActions runs resource actions (e.g. promoting a rollout)
+	// through the v2 actions endpoint
+	ResourceActions Feature = "resource-actions"
+	// SourceSync syncs a single source of a multi-source app
+	SourceSync Feature = "source-sync"
+	// AppEvents reads an app's Kubernetes events, which the standup report
+	// uses to tell rollbacks apart
+	AppEvents Feature = "app-events"
+	// ApplicationSets is the ApplicationSet API, absent when the server
+	// runs without the ApplicationSet controller
+	ApplicationSets Feature = "applicationsets"
+)
+
+// featureInfo describes a Feature for the compatibility warning
+type featureInfo struct {
+	Feature Feature
+	Name    string
+	Since   Version
+}
+
+// features lists each Feature with the first release that serves it; those
+// since Oldest are only turned off when a probe finds them missing
+var features = []featureInfo{
+	{Feature: ResourceActions, Name: "Resource actions", Since: Version{Major: 3, Minor: 1}},
+	{Feature: SourceSync, Name: "Syncs of a single source", Since: Version{Major: 3, Minor: 0}},
+	{Feature: AppEvents, Name: "App events", Since: Oldest},
+	{Feature: ApplicationSets, Name: "ApplicationSets", Since: Oldest},
+}
+
+// Report is the result of checking a server's version, and of probing its
+// endpoints (see Probed)
+type Report struct {
+	Version Version
+	Known   bool // Version was read from the server
+	TooOld  bool // older than Oldest
+	TooNew  bool // a major release newer than NewestMajor
+	missing []featureInfo
+	probed  map[Feature]bool
+}
+
+// Check compares a server version against the supported range; ok is false
+// when the version can't be parsed, in which case nothing is turned off
+// until a probe says otherwise
+func Check(raw string) (Report, bool) {
+	v, ok := Parse(raw)
+	if !ok {
+		return Report{}, false
+	}
+	r := Report{
+		Version: v,
+		Known:   true,
+		TooOld:  v.Less(Oldest),
+		TooNew:  v.Major > NewestMajor,
+	}
+	for _, f := range features {
+		if v.Less(f.Since) {
+			r.missing = append(r.missing, f)
+		}
+	}
+	return r, true
+}
+
+// Probed records whether the server answered a feature's endpoint. A probe
+// outranks the version table: forks and backports don't follow it.
+func (r *Report) Probed(f Feature, served bool) {
+	if r.probed == nil {
+		r.probed = make(map[Feature]bool)
+	}
+	r.probed[f] = served
+}
+
+// off returns the features turned off for this server
+func (r Report) off() []featureInfo {
+	var out []featureInfo
+	for _, f := range features {
+		if !r.Supports(f.Feature) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// versionText is the server version for messages
+func (r Report) versionText() string {
+	if !r.Known {
+		return "an unknown version"
+	}
+	return r.Version.String()
+}
+
+// Supports reports whether the server serves a feature
+func (r Report) Supports(f Feature) bool {
+	if served, ok := r.probed[f]; ok {
+		return served
+	}
+	for _, m := range r.missing {
+		if m.Feature == f {
+			return false
+		}
+	}
+	return true
+}
+
+// Problems reports whether there is anything to warn about
+func (r Report) Problems() bool {
+	return r.TooOld || r.TooNew || len(r.off()) > 0
+}
+
+// Unavailable describes a feature the server lacks, e.g. "Resource actions
+// need Argo CD v3.1.0 or newer (server runs v2.9.3)", or "Resource actions
+// are not served by this server (v3.0.2)" when a probe found the endpoint
+// missing; it is empty when the feature is available
+func (r Report) Unavailable(f Feature) string {
+	for _, m := range r.off() {
+		if m.Feature != f {
+			continue
+		}
+		if served, ok := r.probed[f]; ok && !served {
+			return fmt.Sprintf("%s are not served by this server (%s)", m.Name, r.versionText())
+		}
+		return fmt.Sprintf("%s need Argo CD %s or newer (server runs %s)", m.Name, m.Since, r.Version)
+	}
+	return ""
+}
+
+// Disabled lists the features turned off for this server, one line each,
+// e.g. "Resource actions (needs v3.1.0)"
+func (r Report) Disabled() []string {
+	off := r.off()
+	lines := make([]string, 0, len(off))
+	for _, m := range off {
+		if served, ok := r.probed[m.Feature]; ok && !served {
+			lines = append(lines, fmt.Sprintf("%s (endpoint not found)", m.Name))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s (needs %s)", m.Name, m.Since))
+	}
+	return lines
+}
+
+// Summary is a one-line description of the problem, e.g. for the status
+// line; it is empty when there is nothing to warn about
+func (r Report) Summary() string {
+	switch {
+	case r.TooOld:
+		return fmt.Sprintf("Argo CD %s is older than the oldest supported release (%s)", r.Version, Oldest)
+	case r.TooNew:
+		return fmt.Sprintf("Argo CD %s is newer than argonaut has been tested with (v%d.x)", r.Version, NewestMajor)
+	case len(r.off()) > 0 && !r.Known:
+		return fmt.Sprintf("This Argo CD server lacks %d feature(s), turned off", len(r.off()))
+	case len(r.off()) > 0:
+		return fmt.Sprintf("Argo CD %s: %d feature(s) disabled", r.Version, len(r.off()))
+	}
+	return ""
+}
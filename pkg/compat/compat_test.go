@@ -0,0 +1,105 @@
+package compat
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Version
+		ok   bool
+	}{
+		{"v2.10.3+a1b2c3d", Version{2, 10, 3}, true},
+		{"v3.1.0-rc1+abc", Version{3, 1, 0}, true},
+		{"2.8", Version{2, 8, 0}, true},
+		{" v3.0.12 ", Version{3, 0, 12}, true},
+		{"e2e", Version{}, false},
+		{"v99+unknown", Version{}, false},
+		{"v2.x.1", Version{}, false},
+		{"", Version{}, false},
+	}
+	for _, c := range cases {
+		got, ok := Parse(c.in)
+		if ok != c.ok || got != c.want {
+			t.Errorf("Parse(%q) = %v, %v; want %v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		in          string
+		tooOld      bool
+		tooNew      bool
+		problems    bool
+		withActions bool
+	}{
+		{"v3.1.2+abc", false, false, false, true},
+		{"v3.0.5", false, false, true, false},
+		{"v2.9.3", false, false, true, false},
+		{"v2.7.14", true, false, true, false},
+		{"v4.0.0", false, true, true, true},
+	}
+	for _, c := range cases {
+		r, ok := Check(c.in)
+		if !ok {
+			t.Fatalf("Check(%q) could not parse the version", c.in)
+		}
+		if r.TooOld != c.tooOld || r.TooNew != c.tooNew || r.Problems() != c.problems {
+			t.Errorf("Check(%q) = old %v new %v problems %v; want %v %v %v",
+				c.in, r.TooOld, r.TooNew, r.Problems(), c.tooOld, c.tooNew, c.problems)
+		}
+		if got := r.Supports(ResourceActions); got != c.withActions {
+			t.Errorf("Check(%q).Supports(ResourceActions) = %v, want %v", c.in, got, c.withActions)
+		}
+	}
+
+	if _, ok := Check("e2e"); ok {
+		t.Error("Check(\"e2e\") should not parse")
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	r, _ := Check("v2.9.3")
+	want := "Resource actions need Argo CD v3.1.0 or newer (server runs v2.9.3)"
+	if got := r.Unavailable(ResourceActions); got != want {
+		t.Errorf("Unavailable = %q, want %q", got, want)
+	}
+	if got := r.Disabled(); len(got) != 2 || got[0] != "Resource actions (needs v3.1.0)" || got[1] != "Syncs of a single source (needs v3.0.0)" {
+		t.Errorf("Disabled = %q", got)
+	}
+
+	r, _ = Check("v3.2.0")
+	if got := r.Unavailable(ResourceActions); got != "" {
+		t.Errorf("Unavailable on a new server = %q, want empty", got)
+	}
+	if r.Summary() != "" {
+		t.Errorf("Summary on a supported server = %q, want empty", r.Summary())
+	}
+}
+
+func TestProbedOutranksVersion(t *testing.T) {
+	r, _ := Check("v3.0.2")
+	r.Probed(ResourceActions, true)
+	if !r.Supports(ResourceActions) || r.Problems() {
+		t.Error("a served endpoint should turn the feature back on despite the version")
+	}
+
+	r, _ = Check("v3.2.0")
+	r.Probed(ResourceActions, false)
+	if r.Supports(ResourceActions) {
+		t.Error("a missing endpoint should turn the feature off despite the version")
+	}
+	want := "Resource actions are not served by this server (v3.2.0)"
+	if got := r.Unavailable(ResourceActions); got != want {
+		t.Errorf("Unavailable = %q, want %q", got, want)
+	}
+	if got := r.Disabled(); len(got) != 1 || got[0] != "Resource actions (endpoint not found)" {
+		t.Errorf("Disabled = %q", got)
+	}
+
+	var unknown Report
+	unknown.Probed(ResourceActions, false)
+	if got := unknown.Summary(); got != "This Argo CD server lacks 1 feature(s), turned off" {
+		t.Errorf("Summary = %q", got)
+	}
+}
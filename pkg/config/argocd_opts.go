@@ -0,0 +1,238 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ArgocdOpts holds the argocd CLI's global flags, taken from ARGOCD_OPTS and
+// the CLI's environment variables, so a shell set up for the CLI works for
+// argonaut as it is
+type ArgocdOpts struct {
+	Server               string
+	AuthToken            string
+	Context              string
+	Config               string
+	GrpcWebRootPath      string
+	PortForwardNamespace string
+	ClientCert           string
+	ClientCertKey        string
+	ServerCert           string
+	Insecure             bool
+	PlainText            bool
+	Core                 bool
+	PortForward          bool
+	// Ignored lists the flags argonaut has no use for, e.g. --loglevel
+	Ignored []string
+}
+
+// argocdValueFlags are the CLI flags argonaut honors that take a value
+var argocdValueFlags = map[string]func(o *ArgocdOpts) *string{
+	"server":                 func(o *ArgocdOpts) *string { return &o.Server },
+	"auth-token":             func(o *ArgocdOpts) *string { return &o.AuthToken },
+	"argocd-context":         func(o *ArgocdOpts) *string { return &o.Context },
+	"config":                 func(o *ArgocdOpts) *string { return &o.Config },
+	"grpc-web-root-path":     func(o *ArgocdOpts) *string { return &o.GrpcWebRootPath },
+	"port-forward-namespace": func(o *ArgocdOpts) *string { return &o.PortForwardNamespace },
+	"client-crt":             func(o *ArgocdOpts) *string { return &o.ClientCert },
+	"client-crt-key":         func(o *ArgocdOpts) *string { return &o.ClientCertKey },
+	"server-crt":             func(o *ArgocdOpts) *string { return &o.ServerCert },
+}
+
+// argocdBoolFlags are the CLI flags argonaut honors that are switches.
+// --grpc-web has nothing to switch: argonaut always uses the REST API,
+// which works wherever grpc-web does.
+var argocdBoolFlags = map[string]func(o *ArgocdOpts) *bool{
+	"insecure":     func(o *ArgocdOpts) *bool { return &o.Insecure },
+	"plaintext":    func(o *ArgocdOpts) *bool { return &o.PlainText },
+	"core":         func(o *ArgocdOpts) *bool { return &o.Core },
+	"port-forward": func(o *ArgocdOpts) *bool { return &o.PortForward },
+	"grpc-web":     func(o *ArgocdOpts) *bool { return new(bool) },
+}
+
+// ArgocdOptsFromEnv reads ARGOCD_SERVER and ARGOCD_AUTH_TOKEN, then the
+// flags in ARGOCD_OPTS, which take precedence like they do for the CLI
+func ArgocdOptsFromEnv() (ArgocdOpts, error) {
+	o := ArgocdOpts{
+		Server:    os.Getenv("ARGOCD_SERVER"),
+		AuthToken: os.Getenv("ARGOCD_AUTH_TOKEN"),
+	}
+	if err := o.parse(os.Getenv("ARGOCD_OPTS")); err != nil {
+		return ArgocdOpts{}, fmt.Errorf("invalid ARGOCD_OPTS: %w", err)
+	}
+	return o, nil
+}
+
+// ParseArgocdOpts parses argocd CLI flags written like ARGOCD_OPTS
+func ParseArgocdOpts(opts string) (ArgocdOpts, error) {
+	var o ArgocdOpts
+	err := o.parse(opts)
+	return o, err
+}
+
+func (o *ArgocdOpts) parse(opts string) error {
+	args, err := splitArgs(opts)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unexpected argument %q", arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		nextIsValue := i+1 < len(args) && !strings.HasPrefix(args[i+1], "-")
+
+		if field, ok := argocdValueFlags[name]; ok {
+			if !hasValue {
+				if !nextIsValue {
+					return fmt.Errorf("flag --%s needs a value", name)
+				}
+				i++
+				value = args[i]
+			}
+			*field(o) = value
+			continue
+		}
+		if field, ok := argocdBoolFlags[name]; ok {
+			on := true
+			if hasValue {
+				if on, err = strconv.ParseBool(value); err != nil {
+					return fmt.Errorf("flag --%s: invalid value %q", name, value)
+				}
+			}
+			*field(o) = on
+			continue
+		}
+		// Not knowing whether an unknown flag takes a value, a following
+		// non-flag argument is taken to be its value
+		if !hasValue && nextIsValue {
+			i++
+		}
+		o.Ignored = append(o.Ignored, "--"+name)
+	}
+	return nil
+}
+
+// splitArgs splits a command line like a POSIX shell would: at whitespace
+// outside quotes, with backslash escapes outside single quotes
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// Apply points the CLI config at the server, context and credentials the
+// flags select, the way the argocd CLI combines its flags with its config.
+// Only the copy in memory changes.
+func (c *ArgoCLIConfig) Apply(o ArgocdOpts) {
+	if o.Context != "" {
+		c.CurrentContext = o.Context
+	}
+	server := o.Server
+	if o.PortForward {
+		server = "port-forward"
+	}
+	if server != "" {
+		c.useServer(server)
+	}
+	if o.AuthToken != "" {
+		c.setCurrentToken(o.AuthToken)
+	}
+
+	current, err := c.GetCurrentServer()
+	if err != nil {
+		return
+	}
+	for i := range c.Servers {
+		s := &c.Servers[i]
+		if s.Server != current {
+			continue
+		}
+		s.Insecure = s.Insecure || o.Insecure
+		s.PlainText = s.PlainText || o.PlainText
+		s.Core = s.Core || o.Core
+		if o.GrpcWebRootPath != "" {
+			s.GrpcWebRootPath = o.GrpcWebRootPath
+		}
+	}
+}
+
+// useServer makes the context of the given server current, adding one for a
+// server the config does not know yet
+func (c *ArgoCLIConfig) useServer(server string) {
+	if srv, err := c.GetCurrentServer(); err == nil && srv == server {
+		return
+	}
+	for _, ctx := range c.Contexts {
+		if ctx.Server == server {
+			c.CurrentContext = ctx.Name
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, ArgoContext{Name: server, Server: server, User: server})
+	c.CurrentContext = server
+	if _, err := c.GetCurrentServerConfig(); err != nil {
+		c.Servers = append(c.Servers, ArgoServer{Server: server})
+	}
+}
+
+// setCurrentToken sets the auth token of the current context's user
+func (c *ArgoCLIConfig) setCurrentToken(token string) {
+	for i := range c.Contexts {
+		ctx := &c.Contexts[i]
+		if ctx.Name != c.CurrentContext {
+			continue
+		}
+		if ctx.User == "" {
+			ctx.User = ctx.Name
+		}
+		for j := range c.Users {
+			if c.Users[j].Name == ctx.User {
+				c.Users[j].AuthToken = token
+				return
+			}
+		}
+		c.Users = append(c.Users, ArgoUser{Name: ctx.User, AuthToken: token})
+		return
+	}
+}
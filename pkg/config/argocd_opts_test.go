@@ -0,0 +1,103 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgocdOpts(t *testing.T) {
+	o, err := ParseArgocdOpts(`--grpc-web --insecure --server argocd.example.com:443 --loglevel debug ` +
+		`--header "X-Team: platform" --grpc-web-root-path=/argo --plaintext=false --client-crt '/certs/my cert.pem'`)
+	if err != nil {
+		t.Fatalf("ParseArgocdOpts: %v", err)
+	}
+	want := ArgocdOpts{
+		Server:          "argocd.example.com:443",
+		GrpcWebRootPath: "/argo",
+		ClientCert:      "/certs/my cert.pem",
+		Insecure:        true,
+		Ignored:         []string{"--loglevel", "--header"},
+	}
+	if !reflect.DeepEqual(o, want) {
+		t.Errorf("got %+v\nwant %+v", o, want)
+	}
+
+	for _, bad := range []string{`--server`, `--insecure=maybe`, `--config "unterminated`, `stray`} {
+		if _, err := ParseArgocdOpts(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestArgocdOptsFromEnv_FlagsOverrideEnvironment(t *testing.T) {
+	t.Setenv("ARGOCD_SERVER", "env.example.com")
+	t.Setenv("ARGOCD_AUTH_TOKEN", "env-token")
+	t.Setenv("ARGOCD_OPTS", "--server opts.example.com")
+	o, err := ArgocdOptsFromEnv()
+	if err != nil {
+		t.Fatalf("ArgocdOptsFromEnv: %v", err)
+	}
+	if o.Server != "opts.example.com" || o.AuthToken != "env-token" {
+		t.Errorf("unexpected options %+v", o)
+	}
+}
+
+func TestArgoCLIConfig_Apply(t *testing.T) {
+	newConfig := func() *ArgoCLIConfig {
+		return &ArgoCLIConfig{
+			CurrentContext: "prod",
+			Contexts: []ArgoContext{
+				{Name: "prod", Server: "prod.example.com", User: "prod"},
+				{Name: "staging", Server: "staging.example.com", User: "staging"},
+			},
+			Servers: []ArgoServer{{Server: "prod.example.com"}, {Server: "staging.example.com"}},
+			Users:   []ArgoUser{{Name: "prod", AuthToken: "prod-token"}, {Name: "staging", AuthToken: "staging-token"}},
+		}
+	}
+
+	// A known server uses its context's credentials
+	cfg := newConfig()
+	cfg.Apply(ArgocdOpts{Server: "staging.example.com", Insecure: true, GrpcWebRootPath: "/argo"})
+	server, err := cfg.ToServerConfig()
+	if err != nil {
+		t.Fatalf("ToServerConfig: %v", err)
+	}
+	if server.BaseURL != "https://staging.example.com" || server.Token != "staging-token" || !server.Insecure || server.GrpcWebRootPath != "/argo" {
+		t.Errorf("unexpected server %+v", server)
+	}
+
+	// An unknown one needs a token and honors --plaintext
+	cfg = newConfig()
+	cfg.Apply(ArgocdOpts{Server: "localhost:8080", AuthToken: "tok", PlainText: true})
+	server, err = cfg.ToServerConfig()
+	if err != nil {
+		t.Fatalf("ToServerConfig: %v", err)
+	}
+	if server.BaseURL != "http://localhost:8080" || server.Token != "tok" {
+		t.Errorf("unexpected server %+v", server)
+	}
+
+	// Without a config file at all
+	cfg = &ArgoCLIConfig{}
+	cfg.Apply(ArgocdOpts{Server: "argocd.example.com", AuthToken: "tok"})
+	if server, err = cfg.ToServerConfig(); err != nil || server.Token != "tok" {
+		t.Errorf("expected the server from the flags alone, got %+v, %v", server, err)
+	}
+
+	// --argocd-context and --core
+	cfg = newConfig()
+	cfg.Apply(ArgocdOpts{Context: "staging", Core: true})
+	if core, err := cfg.IsCurrentServerCore(); err != nil || !core {
+		t.Errorf("expected core mode on the staging context, got %v, %v", core, err)
+	}
+	if cfg.Servers[0].Core {
+		t.Error("--core should only apply to the selected server")
+	}
+
+	// --port-forward
+	cfg = newConfig()
+	cfg.Apply(ArgocdOpts{PortForward: true})
+	if pf, err := cfg.IsPortForwardMode(); err != nil || !pf {
+		t.Errorf("expected port-forward mode, got %v, %v", pf, err)
+	}
+}
@@ -1,13 +1,19 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	cblog "github.com/charmbracelet/log"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -23,15 +29,155 @@ type ArgonautConfig struct {
 	PortForward     PortForwardConfig `toml:"port_forward,omitempty"`
 	Clipboard       ClipboardConfig   `toml:"clipboard,omitempty"`
 	HTTPTimeouts    HTTPTimeoutConfig `toml:"http_timeouts,omitempty"`
+	HTTPLimits      HTTPLimitsConfig  `toml:"http_limits,omitempty"`
 	Updates         UpdatesConfig     `toml:"updates,omitempty"`
 	DefaultView     string            `toml:"default_view,omitempty"`
 	LastSeenVersion string            `toml:"last_seen_version,omitempty"`
+	SyncProfiles    []SyncProfile     `toml:"sync_profiles,omitempty"`
+	Revisions       RevisionsConfig   `toml:"revisions,omitempty"`
+	Time            TimeConfig        `toml:"time,omitempty"`
+	Memory          MemoryConfig      `toml:"memory,omitempty"`
+	// ArgocdConfig points at the Argo CD CLI config to read servers from,
+	// so each profile can talk to its own set of servers
+	ArgocdConfig string `toml:"argocd_config,omitempty"`
+	// Secrets is an encrypted file whose values string settings can reference
+	Secrets       SecretsConfig       `toml:"secrets,omitempty"`
+	Notifications NotificationsConfig `toml:"notifications,omitempty"`
+	// MaintenanceBanner shows org-wide notices published on AppProjects
+	MaintenanceBanner MaintenanceBannerConfig `toml:"maintenance_banner,omitempty"`
+	Prefetch          PrefetchConfig          `toml:"prefetch,omitempty"`
+	Stream            StreamConfig            `toml:"stream,omitempty"`
+	// NoConfigWrites keeps argonaut from ever writing the config file, for
+	// configs mounted read-only; the last seen version and the theme and
+	// sorting picked at runtime are kept in the state directory instead
+	NoConfigWrites bool `toml:"no_config_writes,omitempty"`
+	// Servers holds per-server connection settings, keyed by the server's
+	// address as in the Argo CD CLI config
+	Servers map[string]ServerSettings `toml:"servers,omitempty"`
+	Layout  LayoutConfig              `toml:"layout,omitempty"`
+	// Hooks run local commands on app events, e.g. a smoke test after a sync
+	Hooks []LocalHook `toml:"hooks,omitempty"`
+
+	// Decrypted secrets, keyed by dotted name. Never written back to disk.
+	secrets map[string]string
 }
 
 // AppearanceConfig holds theme and visual settings
 type AppearanceConfig struct {
 	Theme     string            `toml:"theme"`
 	Overrides map[string]string `toml:"overrides,omitempty"`
+	// KeyHints shows a one-line bar of the most relevant keys for the
+	// current view above the status line
+	KeyHints bool `toml:"key_hints,omitempty"`
+	// Banner is the header layout: "full" (default) picks the logo and
+	// context block by terminal size, "compact" always uses the one or two
+	// line breadcrumb and "hidden" drops the header altogether
+	Banner string `toml:"banner,omitempty"`
+	// StatusClock adds the local time and the average ArgoCD API latency
+	// to the status line
+	StatusClock bool `toml:"status_clock,omitempty"`
+	// OperationColumns adds the phase and duration of each app's last
+	// operation to the apps list
+	OperationColumns bool `toml:"operation_columns,omitempty"`
+	// Truncate is how names too long for their column are shortened: "end"
+	// (default) cuts the tail, "middle" keeps both ends
+	Truncate string `toml:"truncate,omitempty"`
+	// TruncateKeep is the end of a middle-truncated name that gets more of
+	// the room: "prefix" (default) or "suffix", e.g. for names ending in
+	// an environment or region
+	TruncateKeep string `toml:"truncate_keep,omitempty"`
+	// ReducedMotion stops the spinner animation, the refresh flash, the
+	// dimming behind modals and the live elapsed time of :wait, so the
+	// screen only changes when the data does
+	ReducedMotion bool `toml:"reduced_motion,omitempty"`
+	// LowBandwidth keeps redraw output small for slow SSH sessions: fewer
+	// frames per second, 16 colors and reduced motion
+	LowBandwidth bool `toml:"low_bandwidth,omitempty"`
+}
+
+// Header layouts for appearance.banner
+const (
+	BannerFull    = "full"
+	BannerCompact = "compact"
+	BannerHidden  = "hidden"
+)
+
+// GetBannerMode returns the configured header layout, "full" when unset or
+// not recognized
+func (c *ArgonautConfig) GetBannerMode() string {
+	if c == nil {
+		return BannerFull
+	}
+	switch mode := strings.ToLower(strings.TrimSpace(c.Appearance.Banner)); mode {
+	case BannerCompact, BannerHidden:
+		return mode
+	}
+	return BannerFull
+}
+
+// Name truncation for appearance.truncate and appearance.truncate_keep
+const (
+	TruncateEnd    = "end"
+	TruncateMiddle = "middle"
+	KeepPrefix     = "prefix"
+	KeepSuffix     = "suffix"
+)
+
+// GetTruncation returns how long names are shortened and which end of a
+// middle-truncated name is favored; "end" and "prefix" when unset or not
+// recognized
+func (c *ArgonautConfig) GetTruncation() (mode, keep string) {
+	mode, keep = TruncateEnd, KeepPrefix
+	if c == nil {
+		return mode, keep
+	}
+	if strings.EqualFold(strings.TrimSpace(c.Appearance.Truncate), TruncateMiddle) {
+		mode = TruncateMiddle
+	}
+	if strings.EqualFold(strings.TrimSpace(c.Appearance.TruncateKeep), KeepSuffix) {
+		keep = KeepSuffix
+	}
+	return mode, keep
+}
+
+// TimeConfig holds how timestamps are displayed
+type TimeConfig struct {
+	// Format is "absolute" (default) for the date and time or "relative"
+	// for "5m ago"
+	Format string `toml:"format,omitempty"`
+	// Clock is "24h" (default) or "12h"
+	Clock string `toml:"clock,omitempty"`
+	// Zone is "local" (default) or "utc"
+	Zone string `toml:"zone,omitempty"`
+}
+
+// Timestamp display for time.format, time.clock and time.zone
+const (
+	TimeAbsolute = "absolute"
+	TimeRelative = "relative"
+	Clock24h     = "24h"
+	Clock12h     = "12h"
+	ZoneLocal    = "local"
+	ZoneUTC      = "utc"
+)
+
+// GetTimeDisplay returns how timestamps are displayed; "absolute", "24h"
+// and "local" when unset or not recognized
+func (c *ArgonautConfig) GetTimeDisplay() (format, clock, zone string) {
+	format, clock, zone = TimeAbsolute, Clock24h, ZoneLocal
+	if c == nil {
+		return format, clock, zone
+	}
+	if strings.EqualFold(strings.TrimSpace(c.Time.Format), TimeRelative) {
+		format = TimeRelative
+	}
+	if strings.EqualFold(strings.TrimSpace(c.Time.Clock), Clock12h) {
+		clock = Clock12h
+	}
+	if strings.EqualFold(strings.TrimSpace(c.Time.Zone), ZoneUTC) {
+		zone = ZoneUTC
+	}
+	return format, clock, zone
 }
 
 // SortConfig holds sort preferences
@@ -67,6 +213,169 @@ type ClipboardConfig struct {
 	PasteCommand string `toml:"paste_command,omitempty"`
 }
 
+// RevisionsConfig holds settings for the git revision lookups done for visible apps
+type RevisionsConfig struct {
+	// Drift compares each visible app's synced revision with the current tip
+	// of its target branch and marks apps that are behind. Costs two revision
+	// metadata requests per app, so it is off by default.
+	Drift bool `toml:"drift,omitempty"`
+	// CommitInfo looks up the synced commit of visible apps and shows its
+	// author and message in the details panel
+	CommitInfo bool `toml:"commit_info,omitempty"`
+	// CommitColumn adds a COMMIT column to the apps list (implies CommitInfo)
+	CommitColumn bool `toml:"commit_column,omitempty"`
+}
+
+// NotificationsConfig holds the outbound webhook for syncs started with Watch
+type NotificationsConfig struct {
+	// WebhookURL receives a POST when a watched sync finishes; may reference
+	// a secret, e.g. "${secret:slack.webhook}"
+	WebhookURL string `toml:"webhook_url,omitempty"`
+	// Format is the payload shape: "slack", "teams" or "generic" (default)
+	Format string `toml:"format,omitempty"`
+	// Template is a Go text/template for the message text
+	Template string `toml:"template,omitempty"`
+}
+
+// GetNotificationWebhookURL returns the webhook URL with secrets expanded,
+// or "" when notifications are off
+func (c *ArgonautConfig) GetNotificationWebhookURL() string {
+	if c == nil {
+		return ""
+	}
+	return c.ExpandSecrets(c.Notifications.WebhookURL)
+}
+
+// DefaultBannerAnnotation is the AppProject annotation read for the
+// maintenance banner when none is configured
+const DefaultBannerAnnotation = "argonaut.io/banner"
+
+// MaintenanceBannerConfig holds settings for the org-wide notice shown above
+// the header (e.g. "change freeze until Monday"). The text is read from an
+// annotation on the AppProjects visible to the user.
+type MaintenanceBannerConfig struct {
+	// Enabled turns on polling the projects for the banner; off by default
+	Enabled bool `toml:"enabled,omitempty"`
+	// Annotation is the AppProject annotation holding the banner text
+	Annotation string `toml:"annotation,omitempty"`
+}
+
+// IsMaintenanceBannerEnabled returns true when project annotations should be
+// checked for a banner. Defaults to false when the config key is omitted.
+func (c *ArgonautConfig) IsMaintenanceBannerEnabled() bool {
+	return c != nil && c.MaintenanceBanner.Enabled
+}
+
+// GetBannerAnnotation returns the annotation holding the banner text
+func (c *ArgonautConfig) GetBannerAnnotation() string {
+	if c == nil || c.MaintenanceBanner.Annotation == "" {
+		return DefaultBannerAnnotation
+	}
+	return c.MaintenanceBanner.Annotation
+}
+
+// LayoutConfig holds the split layouts: which secondary panes are shown and
+// how the width is shared, as last set with the resize keys
+type LayoutConfig struct {
+	// DetailsPane shows the selected app's details beside the apps list
+	DetailsPane bool `toml:"details_pane,omitempty"`
+	// SplitRatios is the share of the width in percent the list keeps in
+	// each split view, keyed by view, e.g. apps = 60
+	SplitRatios map[string]int `toml:"split_ratios,omitempty"`
+	// CollapseBelow is the terminal width under which secondary panes are
+	// hidden and the list takes the whole width
+	CollapseBelow int `toml:"collapse_below,omitempty"`
+}
+
+// Split ratio bounds and defaults for layout.split_ratios and
+// layout.collapse_below
+const (
+	DefaultSplitRatio    = 60
+	MinSplitRatio        = 30
+	MaxSplitRatio        = 80
+	DefaultCollapseBelow = 110
+)
+
+// GetSplitRatio returns the share of the width in percent the list keeps in
+// the view's split layout, within MinSplitRatio and MaxSplitRatio
+func (c *ArgonautConfig) GetSplitRatio(view string) int {
+	if c == nil {
+		return DefaultSplitRatio
+	}
+	ratio, ok := c.Layout.SplitRatios[view]
+	if !ok {
+		return DefaultSplitRatio
+	}
+	return min(max(ratio, MinSplitRatio), MaxSplitRatio)
+}
+
+// GetCollapseBelow returns the terminal width under which secondary panes
+// are hidden
+func (c *ArgonautConfig) GetCollapseBelow() int {
+	if c == nil || c.Layout.CollapseBelow <= 0 {
+		return DefaultCollapseBelow
+	}
+	return c.Layout.CollapseBelow
+}
+
+// PrefetchConfig holds settings for loading the app under the cursor in the
+// background so diff, resources and rollback open without waiting
+type PrefetchConfig struct {
+	// Enabled defaults to true when unset; set to false on slow links or
+	// very large apps where the extra requests are not worth it
+	Enabled *bool `toml:"enabled,omitempty"`
+}
+
+// IsPrefetchEnabled returns true when the app under the cursor should be
+// prefetched. Defaults to true when the config key is omitted.
+func (c *ArgonautConfig) IsPrefetchEnabled() bool {
+	if c == nil || c.Prefetch.Enabled == nil {
+		return true
+	}
+	return *c.Prefetch.Enabled
+}
+
+// StreamConfig holds settings for the watch streams that keep the app list
+// and resource trees live
+type StreamConfig struct {
+	// Enabled defaults to true when unset; set to false where a proxy or
+	// firewall blocks the streaming endpoint, to poll instead
+	Enabled *bool `toml:"enabled,omitempty"`
+	// RefreshInterval is how often the app list and the open resource tree
+	// are reloaded while streaming is off, in Go duration format; "0"
+	// leaves reloading to ctrl+r
+	RefreshInterval string `toml:"refresh_interval,omitempty"`
+}
+
+// DefaultStreamRefreshInterval is how often data is reloaded while
+// streaming is off
+const DefaultStreamRefreshInterval = 30 * time.Second
+
+// IsStreamEnabled returns true when the app list and resource trees should
+// be watched. Defaults to true when the config key is omitted.
+func (c *ArgonautConfig) IsStreamEnabled() bool {
+	if c == nil || c.Stream.Enabled == nil {
+		return true
+	}
+	return *c.Stream.Enabled
+}
+
+// GetStreamRefreshInterval returns how often data is reloaded while
+// streaming is off, defaulting to 30 seconds. Zero means never.
+func (c *ArgonautConfig) GetStreamRefreshInterval() time.Duration {
+	if c == nil || c.Stream.RefreshInterval == "" {
+		return DefaultStreamRefreshInterval
+	}
+	if c.Stream.RefreshInterval == "0" {
+		return 0
+	}
+	duration, err := time.ParseDuration(c.Stream.RefreshInterval)
+	if err != nil || duration <= 0 {
+		return DefaultStreamRefreshInterval
+	}
+	return duration
+}
+
 // UpdatesConfig holds settings for the GitHub-API update check.
 type UpdatesConfig struct {
 	// CheckEnabled controls whether the periodic GitHub release check runs
@@ -86,6 +395,113 @@ func (c *ArgonautConfig) IsUpdateCheckEnabled() bool {
 	return *c.Updates.CheckEnabled
 }
 
+// SyncProfile pre-populates the sync modal for matching apps. App and Project
+// are glob patterns (path.Match syntax); an empty pattern matches anything.
+// Options left unset keep the modal's defaults.
+type SyncProfile struct {
+	App             string `toml:"app,omitempty"`
+	Project         string `toml:"project,omitempty"`
+	Prune           *bool  `toml:"prune,omitempty"`
+	Watch           *bool  `toml:"watch,omitempty"`
+	ServerSideApply *bool  `toml:"server_side_apply,omitempty"`
+}
+
+// Matches reports whether the profile applies to the given app
+func (p SyncProfile) Matches(appName, project string) bool {
+	return globMatch(p.App, appName) && globMatch(p.Project, project)
+}
+
+// Label describes which apps the profile targets, for display
+func (p SyncProfile) Label() string {
+	var parts []string
+	if p.App != "" {
+		parts = append(parts, "app "+p.App)
+	}
+	if p.Project != "" {
+		parts = append(parts, "project "+p.Project)
+	}
+	if len(parts) == 0 {
+		return "all apps"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func globMatch(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// SyncProfileFor returns the first sync profile matching the app, or nil
+func (c *ArgonautConfig) SyncProfileFor(appName, project string) *SyncProfile {
+	if c == nil {
+		return nil
+	}
+	for i := range c.SyncProfiles {
+		if c.SyncProfiles[i].Matches(appName, project) {
+			return &c.SyncProfiles[i]
+		}
+	}
+	return nil
+}
+
+// Events a local hook can run on
+const (
+	// A sync or rollback finished, whatever its result
+	HookSyncCompleted = "sync-completed"
+	// An app's health turned Degraded
+	HookAppDegraded = "app-degraded"
+	// An app is about to be deleted; a failing command cancels the delete
+	HookBeforeDelete = "before-delete"
+)
+
+// DefaultHookTimeout bounds a local hook without a timeout
+const DefaultHookTimeout = time.Minute
+
+// LocalHook runs a shell command when an app event happens, with the app's
+// details in ARGONAUT_* environment variables. App and Project are glob
+// patterns as in sync profiles; an empty pattern matches anything.
+type LocalHook struct {
+	Event   string `toml:"event"`
+	Command string `toml:"command"`
+	App     string `toml:"app,omitempty"`
+	Project string `toml:"project,omitempty"`
+	// Timeout after which the command is killed, e.g. "5m"
+	Timeout string `toml:"timeout,omitempty"`
+}
+
+// Matches reports whether the hook runs on the event for the given app
+func (h LocalHook) Matches(event, appName, project string) bool {
+	return h.Event == event && h.Command != "" && globMatch(h.App, appName) && globMatch(h.Project, project)
+}
+
+// GetTimeout returns how long the command may run, a minute when unset or
+// invalid
+func (h LocalHook) GetTimeout() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultHookTimeout
+}
+
+// HooksFor returns the hooks that run on the event for the app, in config
+// order. Their commands keep the secret references, to be shown in logs and
+// errors; expand them with ExpandSecretsForShell only to run them.
+func (c *ArgonautConfig) HooksFor(event, appName, project string) []LocalHook {
+	if c == nil {
+		return nil
+	}
+	var hooks []LocalHook
+	for _, h := range c.Hooks {
+		if h.Matches(event, appName, project) {
+			hooks = append(hooks, h)
+		}
+	}
+	return hooks
+}
+
 // HTTPTimeoutConfig holds HTTP request timeout settings.
 // This configuration is essential for large deployments where API operations
 // may take longer due to the volume of data being processed.
@@ -96,8 +512,87 @@ type HTTPTimeoutConfig struct {
 	RequestTimeout string `toml:"request_timeout,omitempty"`
 }
 
-// GetArgonautConfigPath returns the path to the Argonaut configuration file
+// MemoryConfig holds how per-app bookkeeping of long sessions is bounded
+type MemoryConfig struct {
+	// PruneAfter releases the cached revisions, diffs and prefetches of apps
+	// that stayed out of the filtered scope this long (Go duration, e.g.
+	// "10m"; "0" keeps them for the whole session)
+	PruneAfter string `toml:"prune_after,omitempty"`
+}
+
+// HTTPLimitsConfig holds limits on API responses
+type HTTPLimitsConfig struct {
+	// MaxResponseSize is the largest app diff read into memory (e.g.
+	// "32MB", "512KB"). Larger diffs are saved to a temp file instead and
+	// resources are offered one at a time. "0" disables the limit.
+	MaxResponseSize string `toml:"max_response_size,omitempty"`
+}
+
+// defaultMaxResponseSize applies when max_response_size is unset or invalid
+const defaultMaxResponseSize int64 = 32 << 20
+
+// activeProfile is the profile selected with --profile; empty uses config.toml
+var activeProfile string
+
+// profileNamePattern keeps profile names usable as plain file names
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// SetProfile selects the named profile, whose config lives in
+// profiles/<name>.toml next to the default config file. The profile must exist.
+func SetProfile(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+	profilePath := filepath.Join(profilesDir(), name+".toml")
+	if _, err := os.Stat(profilePath); err != nil {
+		hint := "no profiles exist yet"
+		if names := ListProfiles(); len(names) > 0 {
+			hint = "available: " + strings.Join(names, ", ")
+		}
+		return fmt.Errorf("profile %q not found at %s (%s)", name, profilePath, hint)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the selected profile name, or "" for the default config
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// ListProfiles returns the names of the profiles found in the profiles directory
+func ListProfiles() []string {
+	entries, err := os.ReadDir(profilesDir())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".toml")
+		if ok && !e.IsDir() && profileNamePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profilesDir is the directory holding profile configs, next to the default config
+func profilesDir() string {
+	return filepath.Join(filepath.Dir(defaultArgonautConfigPath()), "profiles")
+}
+
+// GetArgonautConfigPath returns the path to the Argonaut configuration file,
+// which is the active profile's file when one is selected
 func GetArgonautConfigPath() string {
+	if activeProfile != "" {
+		return filepath.Join(profilesDir(), activeProfile+".toml")
+	}
+	return defaultArgonautConfigPath()
+}
+
+// defaultArgonautConfigPath returns the path of config.toml, ignoring profiles
+func defaultArgonautConfigPath() string {
 	if configPath := os.Getenv("ARGONAUT_CONFIG"); configPath != "" {
 		return configPath
 	}
@@ -152,9 +647,48 @@ func GetDefaultConfig() *ArgonautConfig {
 	}
 }
 
+// safeMode is set by --safe-mode: the config file and saved state are
+// ignored and nothing is written back
+var safeMode bool
+
+// SetSafeMode makes LoadArgonautConfig return the built-in defaults and
+// SaveArgonautConfig a no-op, to rule out a broken or unusual config
+// without touching it
+func SetSafeMode(on bool) {
+	safeMode = on
+}
+
+// SafeMode reports whether the config is ignored for --safe-mode
+func SafeMode() bool {
+	return safeMode
+}
+
+// unloadablePath is the config file that last failed to load. Callers fall
+// back to the defaults then, and saving those would replace the user's
+// file, so SaveArgonautConfig refuses to write it until it loads again.
+var unloadablePath string
+
+// errConfigNotLoaded is returned by saves while the config fails to load
+var errConfigNotLoaded = errors.New("config not saved: it could not be loaded, and saving would replace it with the defaults")
+
 // LoadArgonautConfig loads the Argonaut configuration with fallback to defaults
 func LoadArgonautConfig() (*ArgonautConfig, error) {
+	if safeMode {
+		return GetDefaultConfig(), nil
+	}
 	configPath := GetArgonautConfigPath()
+	cfg, err := loadArgonautConfig(configPath)
+	if err != nil {
+		unloadablePath = configPath
+		return nil, err
+	}
+	if unloadablePath == configPath {
+		unloadablePath = ""
+	}
+	return cfg, nil
+}
+
+func loadArgonautConfig(configPath string) (*ArgonautConfig, error) {
 
 	// If config file doesn't exist, return defaults
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -172,22 +706,47 @@ func LoadArgonautConfig() (*ArgonautConfig, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Apply defaults for missing fields
-	if config.Appearance.Theme == "" {
-		config.Appearance.Theme = DefaultThemeName
-	}
-	if config.Sort.Field == "" {
-		config.Sort.Field = "name"
+	config.applyDefaults()
+	if config.NoConfigWrites {
+		if err := config.applyState(); err != nil {
+			// State is only remembered settings; losing it must not cost the config
+			cblog.With("component", "config").Warn("Ignoring unreadable state, starting without it", "err", err)
+		}
 	}
-	if config.Sort.Direction == "" {
-		config.Sort.Direction = "asc"
+
+	if err := config.loadSecrets(filepath.Dir(configPath)); err != nil {
+		return nil, err
 	}
 
 	return &config, nil
 }
 
-// SaveArgonautConfig saves the configuration to the config file
+// applyDefaults fills in the settings a config file may leave out
+func (c *ArgonautConfig) applyDefaults() {
+	if c.Appearance.Theme == "" {
+		c.Appearance.Theme = DefaultThemeName
+	}
+	if c.Sort.Field == "" {
+		c.Sort.Field = "name"
+	}
+	if c.Sort.Direction == "" {
+		c.Sort.Direction = "asc"
+	}
+}
+
+// SaveArgonautConfig saves the configuration to the config file, or only
+// its runtime state to the state directory when no_config_writes is set.
+// Saves nothing in safe mode, so the defaults never replace the user's file.
 func SaveArgonautConfig(config *ArgonautConfig) error {
+	if safeMode {
+		return nil
+	}
+	if unloadablePath != "" && unloadablePath == GetArgonautConfigPath() {
+		return errConfigNotLoaded
+	}
+	if config.NoConfigWrites {
+		return saveState(config)
+	}
 	if err := EnsureArgonautConfigDir(); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
@@ -213,6 +772,15 @@ func GetConfigPathForHelp() string {
 	return GetArgonautConfigPath()
 }
 
+// GetArgocdConfigPath returns the configured Argo CD CLI config path with a
+// leading "~/" expanded, or "" when unset
+func (c *ArgonautConfig) GetArgocdConfigPath() string {
+	if c == nil || c.ArgocdConfig == "" {
+		return ""
+	}
+	return expandHome(c.ArgocdConfig)
+}
+
 // GetK9sCommand returns the k9s command path, defaulting to "k9s" if not configured.
 // Priority: ARGONAUT_K9S_COMMAND env var > config file > default "k9s"
 func (c *ArgonautConfig) GetK9sCommand() string {
@@ -220,7 +788,7 @@ func (c *ArgonautConfig) GetK9sCommand() string {
 		return envCmd
 	}
 	if c.K9s.Command != "" {
-		return c.K9s.Command
+		return c.ExpandSecrets(c.K9s.Command)
 	}
 	return "k9s"
 }
@@ -230,14 +798,16 @@ func (c *ArgonautConfig) GetK9sContext() string {
 	return c.K9s.Context
 }
 
-// GetDiffViewer returns the external diff viewer command, or empty string if not configured
+// GetDiffViewer returns the external diff viewer command, or empty string if
+// not configured; secrets are quoted for the shell that runs it
 func (c *ArgonautConfig) GetDiffViewer() string {
-	return c.Diff.Viewer
+	return c.ExpandSecretsForShell(c.Diff.Viewer)
 }
 
-// GetDiffFormatter returns the diff formatter command, or empty string if not configured
+// GetDiffFormatter returns the diff formatter command, or empty string if
+// not configured; secrets are quoted for the shell that runs it
 func (c *ArgonautConfig) GetDiffFormatter() string {
-	return c.Diff.Formatter
+	return c.ExpandSecretsForShell(c.Diff.Formatter)
 }
 
 // GetPortForwardNamespace returns the namespace for kubectl port-forward, defaulting to "argocd"
@@ -248,14 +818,16 @@ func (c *ArgonautConfig) GetPortForwardNamespace() string {
 	return "argocd"
 }
 
-// GetClipboardCopyCommand returns the configured clipboard copy command, or empty for auto-detect
+// GetClipboardCopyCommand returns the configured clipboard copy command, or
+// empty for auto-detect; secrets are quoted for the shell that runs it
 func (c *ArgonautConfig) GetClipboardCopyCommand() string {
-	return c.Clipboard.CopyCommand
+	return c.ExpandSecretsForShell(c.Clipboard.CopyCommand)
 }
 
-// GetClipboardPasteCommand returns the configured clipboard paste command, or empty for auto-detect
+// GetClipboardPasteCommand returns the configured clipboard paste command, or
+// empty for auto-detect; secrets are quoted for the shell that runs it
 func (c *ArgonautConfig) GetClipboardPasteCommand() string {
-	return c.Clipboard.PasteCommand
+	return c.ExpandSecretsForShell(c.Clipboard.PasteCommand)
 }
 
 // GetRequestTimeoutString returns the raw string value of the request timeout configuration.
@@ -268,6 +840,36 @@ func (c *ArgonautConfig) GetRequestTimeoutString() string {
 	return "10s"
 }
 
+// DefaultViewOption documents one accepted form of the default_view setting
+type DefaultViewOption struct {
+	Value       string
+	Description string
+}
+
+// DefaultViewOptions lists the accepted default_view forms. It backs both the
+// warning shown for invalid values and the `:help views` page.
+var DefaultViewOptions = []DefaultViewOption{
+	{Value: "clusters", Description: "Clusters list (default)"},
+	{Value: "ns", Description: "Namespaces list"},
+	{Value: "proj", Description: "Projects list"},
+	{Value: "apps", Description: "Applications list"},
+	{Value: "appsets", Description: "ApplicationSets list"},
+	{Value: "cluster <name>", Description: "Namespaces of one cluster"},
+	{Value: "ns <name>", Description: "Projects in one namespace"},
+	{Value: "project <name>", Description: "Apps in one project"},
+	{Value: "appset <name>", Description: "Apps generated by one ApplicationSet"},
+	{Value: "tree:<app>", Description: "Resource tree of one app (also \"tree <app>\" or \"tree <ns>/<app>\")"},
+}
+
+// defaultViewHint lists the valid default_view values for warning messages
+func defaultViewHint() string {
+	values := make([]string, 0, len(DefaultViewOptions))
+	for _, opt := range DefaultViewOptions {
+		values = append(values, opt.Value)
+	}
+	return "Valid options: " + strings.Join(values, ", ") + "\nRun :help views for details."
+}
+
 // ParseDefaultView parses the default_view config value into a view, scope type, and scope value.
 // Returns zero values if the input is empty. Returns an error message if the input is invalid.
 // The view is returned as a string matching model.View constants (e.g. "apps", "clusters").
@@ -277,12 +879,18 @@ func (c *ArgonautConfig) GetRequestTimeoutString() string {
 //   - project+arg → apps view scoped to project
 //   - appset+arg → apps view scoped to appset
 //   - app+arg → apps view (no scope)
+//   - tree+arg → resource tree of the app (scope type "app"); arg may be namespace/app
 func (c *ArgonautConfig) ParseDefaultView() (view string, scopeType string, scopeValue string, errMsg string) {
 	input := strings.TrimSpace(c.DefaultView)
 	if input == "" {
 		return "", "", "", ""
 	}
 
+	// tree:<app> is accepted alongside the command form "tree <app>"
+	if rest, ok := strings.CutPrefix(input, "tree:"); ok {
+		input = "tree " + rest
+	}
+
 	// Split on whitespace: command + optional arg
 	parts := strings.Fields(input)
 	cmd := parts[0]
@@ -317,11 +925,23 @@ func (c *ArgonautConfig) ParseDefaultView() (view string, scopeType string, scop
 		"applicationset":  {view: "applicationsets", drillView: "apps", scopeType: "appset"},
 		"applicationsets": {view: "applicationsets", drillView: "apps", scopeType: "appset"},
 		"as":              {view: "applicationsets", drillView: "apps", scopeType: "appset"},
+		"tree":            {drillView: "tree", scopeType: "app"},
+		"resources":       {drillView: "tree", scopeType: "app"},
+		"res":             {drillView: "tree", scopeType: "app"},
+	}
+
+	if cmd == "overview" {
+		return "", "", "", fmt.Sprintf("default_view %q is not available: there is no overview screen in this version.\n%s", c.DefaultView, defaultViewHint())
 	}
 
 	def, ok := aliases[cmd]
 	if !ok {
-		return "", "", "", fmt.Sprintf("Malformed default_view in config: %q\nValid options: apps, clusters, ns, proj, appset\nExample: default_view = \"cluster production\"", input)
+		return "", "", "", fmt.Sprintf("Malformed default_view in config: %q\n%s", c.DefaultView, defaultViewHint())
+	}
+
+	// The tree has no list form: it always needs the app to open
+	if def.view == "" && arg == "" {
+		return "", "", "", fmt.Sprintf("default_view %q needs an application name, e.g. \"tree:my-app\".\n%s", c.DefaultView, defaultViewHint())
 	}
 
 	if arg == "" || def.drillView == "" {
@@ -352,3 +972,58 @@ func (c *ArgonautConfig) GetRequestTimeout() time.Duration {
 
 	return duration
 }
+
+// DefaultPruneAfter is how long an app stays out of the filtered scope
+// before its cached data is released
+const DefaultPruneAfter = 10 * time.Minute
+
+// GetPruneAfter returns how long an app must stay out of the filtered scope
+// before its cached data is released, defaulting to 10 minutes. Zero means
+// it is never released.
+func (c *ArgonautConfig) GetPruneAfter() time.Duration {
+	if c == nil || c.Memory.PruneAfter == "" {
+		return DefaultPruneAfter
+	}
+	if c.Memory.PruneAfter == "0" {
+		return 0
+	}
+	duration, err := time.ParseDuration(c.Memory.PruneAfter)
+	if err != nil || duration < 0 {
+		return DefaultPruneAfter
+	}
+	return duration
+}
+
+// GetMaxResponseSize returns the response size limit in bytes, defaulting to
+// 32MB. Zero means no limit.
+func (c *ArgonautConfig) GetMaxResponseSize() int64 {
+	size, ok := parseByteSize(c.HTTPLimits.MaxResponseSize)
+	if !ok {
+		return defaultMaxResponseSize
+	}
+	return size
+}
+
+// parseByteSize parses sizes like "512KB", "32MB", "1GB" or a plain byte count.
+// Units are binary (1KB = 1024 bytes).
+func parseByteSize(s string) (int64, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int64(n * float64(mult)), true
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetArgonautConfigPath(t *testing.T) {
@@ -191,6 +193,25 @@ func TestLoadArgonautConfig_InvalidTOML(t *testing.T) {
 	if err == nil {
 		t.Error("LoadArgonautConfig() should fail with invalid TOML")
 	}
+
+	// The defaults used instead must not be saved over the broken file
+	if err := SaveArgonautConfig(GetDefaultConfig()); err == nil {
+		t.Error("SaveArgonautConfig() should refuse to replace a config that failed to load")
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "invalid toml content [[[" {
+		t.Errorf("config file was overwritten:\n%s", data)
+	}
+
+	// Once it loads again, saving works
+	if err := os.WriteFile(configPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadArgonautConfig(); err != nil {
+		t.Fatalf("LoadArgonautConfig() = %v", err)
+	}
+	if err := SaveArgonautConfig(GetDefaultConfig()); err != nil {
+		t.Errorf("SaveArgonautConfig() after a good load = %v", err)
+	}
 }
 
 func TestEnsureArgonautConfigDir(t *testing.T) {
@@ -434,6 +455,49 @@ func TestPortForwardConfigGetters(t *testing.T) {
 	}
 }
 
+func TestLayoutConfigGetters(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *ArgonautConfig
+		expectRatio    int
+		expectCollapse int
+	}{
+		{
+			name:           "empty config returns defaults",
+			config:         &ArgonautConfig{},
+			expectRatio:    DefaultSplitRatio,
+			expectCollapse: DefaultCollapseBelow,
+		},
+		{
+			name: "custom values",
+			config: &ArgonautConfig{
+				Layout: LayoutConfig{SplitRatios: map[string]int{"apps": 45}, CollapseBelow: 140},
+			},
+			expectRatio:    45,
+			expectCollapse: 140,
+		},
+		{
+			name: "ratio out of range is clamped",
+			config: &ArgonautConfig{
+				Layout: LayoutConfig{SplitRatios: map[string]int{"apps": 95}},
+			},
+			expectRatio:    MaxSplitRatio,
+			expectCollapse: DefaultCollapseBelow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.GetSplitRatio("apps"); got != tt.expectRatio {
+				t.Errorf("GetSplitRatio() = %d, want %d", got, tt.expectRatio)
+			}
+			if got := tt.config.GetCollapseBelow(); got != tt.expectCollapse {
+				t.Errorf("GetCollapseBelow() = %d, want %d", got, tt.expectCollapse)
+			}
+		})
+	}
+}
+
 func TestHTTPTimeoutConfigGetters(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -568,7 +632,15 @@ func TestParseDefaultView(t *testing.T) {
 		{name: "as with arg", input: "as myset", wantView: "apps", wantScope: "appset", wantValue: "myset"},
 		{name: "apps with arg (no scope)", input: "apps myapp", wantView: "apps", wantScope: "", wantValue: ""},
 
+		// Resource tree of a single app
+		{name: "tree with arg", input: "tree my-app", wantView: "tree", wantScope: "app", wantValue: "my-app"},
+		{name: "tree colon form", input: "tree:my-app", wantView: "tree", wantScope: "app", wantValue: "my-app"},
+		{name: "tree with namespace", input: "tree:argocd/my-app", wantView: "tree", wantScope: "app", wantValue: "argocd/my-app"},
+		{name: "resources alias", input: "res my-app", wantView: "tree", wantScope: "app", wantValue: "my-app"},
+
 		// Invalid inputs — should return error
+		{name: "tree colon without app", input: "tree:", wantView: "", wantScope: "", wantValue: "", wantErr: true},
+		{name: "overview not available", input: "overview", wantView: "", wantScope: "", wantValue: "", wantErr: true},
 		{name: "invalid tree", input: "tree", wantView: "", wantScope: "", wantValue: "", wantErr: true},
 		{name: "invalid unknown", input: "unknown", wantView: "", wantScope: "", wantValue: "", wantErr: true},
 		{name: "invalid sync", input: "sync", wantView: "", wantScope: "", wantValue: "", wantErr: true},
@@ -602,6 +674,16 @@ func TestParseDefaultView(t *testing.T) {
 	}
 }
 
+func TestParseDefaultView_WarningListsOptions(t *testing.T) {
+	cfg := &ArgonautConfig{DefaultView: "dashboard"}
+	_, _, _, errMsg := cfg.ParseDefaultView()
+	for _, want := range []string{`"dashboard"`, "tree:<app>", "appset <name>", ":help views"} {
+		if !strings.Contains(errMsg, want) {
+			t.Errorf("warning %q should mention %q", errMsg, want)
+		}
+	}
+}
+
 func TestSaveAndLoadHTTPTimeoutConfig(t *testing.T) {
 	// Create a temporary directory
 	tempDir := t.TempDir()
@@ -672,6 +754,42 @@ func TestIsUpdateCheckEnabled(t *testing.T) {
 	}
 }
 
+func TestGetMaxResponseSize(t *testing.T) {
+	tests := map[string]int64{
+		"":      32 << 20,
+		"64MB":  64 << 20,
+		"512kb": 512 << 10,
+		"1.5GB": 3 << 29,
+		"2048":  2048,
+		"0":     0,
+		"lots":  32 << 20,
+		"-1MB":  32 << 20,
+	}
+	for in, want := range tests {
+		cfg := &ArgonautConfig{HTTPLimits: HTTPLimitsConfig{MaxResponseSize: in}}
+		if got := cfg.GetMaxResponseSize(); got != want {
+			t.Errorf("GetMaxResponseSize(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestMaintenanceBannerDefaults(t *testing.T) {
+	var nilCfg *ArgonautConfig
+	if nilCfg.IsMaintenanceBannerEnabled() || nilCfg.GetBannerAnnotation() != DefaultBannerAnnotation {
+		t.Error("nil config should leave the banner off with the default annotation")
+	}
+	if (&ArgonautConfig{}).IsMaintenanceBannerEnabled() {
+		t.Error("the banner should be opt-in")
+	}
+	cfg := &ArgonautConfig{MaintenanceBanner: MaintenanceBannerConfig{Enabled: true, Annotation: "example.com/notice"}}
+	if !cfg.IsMaintenanceBannerEnabled() {
+		t.Error("enabled = true should turn the banner on")
+	}
+	if got := cfg.GetBannerAnnotation(); got != "example.com/notice" {
+		t.Errorf("GetBannerAnnotation() = %q", got)
+	}
+}
+
 func TestSaveAndLoadUpdatesConfig(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -709,3 +827,270 @@ func TestSaveAndLoadUpdatesConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncProfileFor(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", configPath)
+	data := `
+[[sync_profiles]]
+app = "payments-*"
+prune = true
+server_side_apply = true
+
+[[sync_profiles]]
+project = "platform"
+watch = false
+`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig() failed: %v", err)
+	}
+
+	p := cfg.SyncProfileFor("payments-api", "platform")
+	if p == nil || p.Prune == nil || !*p.Prune || p.Watch != nil {
+		t.Fatalf("expected first matching profile to win, got %+v", p)
+	}
+	if p.Label() != "app payments-*" {
+		t.Errorf("Label() = %q", p.Label())
+	}
+
+	p = cfg.SyncProfileFor("ingress", "platform")
+	if p == nil || p.Watch == nil || *p.Watch {
+		t.Fatalf("expected project profile, got %+v", p)
+	}
+
+	if p := cfg.SyncProfileFor("ingress", "default"); p != nil {
+		t.Errorf("expected no profile, got %+v", p)
+	}
+	if p := (*ArgonautConfig)(nil).SyncProfileFor("x", "y"); p != nil {
+		t.Error("nil config should have no profiles")
+	}
+}
+
+func TestProfiles_SelectAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+	t.Cleanup(func() { activeProfile = "" })
+
+	if err := os.MkdirAll(filepath.Join(dir, "profiles"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	homelab := "argocd_config = \"~/homelab/argocd.yaml\"\n[appearance]\ntheme = \"dracula\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "profiles", "homelab.toml"), []byte(homelab), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "profiles", "work.toml"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ListProfiles(); strings.Join(got, ",") != "homelab,work" {
+		t.Errorf("ListProfiles() = %v", got)
+	}
+
+	err := SetProfile("missing")
+	if err == nil || !strings.Contains(err.Error(), "available: homelab, work") {
+		t.Fatalf("expected not-found error listing profiles, got %v", err)
+	}
+	if err := SetProfile("../config"); err == nil {
+		t.Fatal("expected path-like profile names to be rejected")
+	}
+	if ActiveProfile() != "" {
+		t.Fatal("failed selections must not change the active profile")
+	}
+
+	if err := SetProfile("homelab"); err != nil {
+		t.Fatalf("SetProfile: %v", err)
+	}
+	if got := GetArgonautConfigPath(); got != filepath.Join(dir, "profiles", "homelab.toml") {
+		t.Errorf("GetArgonautConfigPath() = %q", got)
+	}
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+	if cfg.Appearance.Theme != "dracula" {
+		t.Errorf("theme = %q, want the profile's", cfg.Appearance.Theme)
+	}
+	home, _ := os.UserHomeDir()
+	if got := cfg.GetArgocdConfigPath(); got != filepath.Join(home, "homelab", "argocd.yaml") {
+		t.Errorf("GetArgocdConfigPath() = %q", got)
+	}
+}
+
+func TestGetBannerMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *ArgonautConfig
+		want   string
+	}{
+		{name: "nil config", config: nil, want: BannerFull},
+		{name: "unset", config: &ArgonautConfig{}, want: BannerFull},
+		{name: "compact", config: &ArgonautConfig{Appearance: AppearanceConfig{Banner: "compact"}}, want: BannerCompact},
+		{name: "hidden mixed case", config: &ArgonautConfig{Appearance: AppearanceConfig{Banner: " Hidden "}}, want: BannerHidden},
+		{name: "unknown falls back", config: &ArgonautConfig{Appearance: AppearanceConfig{Banner: "tiny"}}, want: BannerFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.GetBannerMode(); got != tt.want {
+				t.Errorf("GetBannerMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTruncation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *ArgonautConfig
+		wantMode string
+		wantKeep string
+	}{
+		{name: "nil config", config: nil, wantMode: TruncateEnd, wantKeep: KeepPrefix},
+		{name: "unset", config: &ArgonautConfig{}, wantMode: TruncateEnd, wantKeep: KeepPrefix},
+		{name: "middle keeping suffix", config: &ArgonautConfig{Appearance: AppearanceConfig{Truncate: " Middle", TruncateKeep: "suffix"}}, wantMode: TruncateMiddle, wantKeep: KeepSuffix},
+		{name: "unknown falls back", config: &ArgonautConfig{Appearance: AppearanceConfig{Truncate: "start", TruncateKeep: "both"}}, wantMode: TruncateEnd, wantKeep: KeepPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, keep := tt.config.GetTruncation()
+			if mode != tt.wantMode || keep != tt.wantKeep {
+				t.Errorf("GetTruncation() = %q, %q, want %q, %q", mode, keep, tt.wantMode, tt.wantKeep)
+			}
+		})
+	}
+}
+
+func TestGetTimeDisplay(t *testing.T) {
+	tests := []struct {
+		name                            string
+		config                          *ArgonautConfig
+		wantFormat, wantClock, wantZone string
+	}{
+		{name: "nil config", config: nil, wantFormat: TimeAbsolute, wantClock: Clock24h, wantZone: ZoneLocal},
+		{name: "unset", config: &ArgonautConfig{}, wantFormat: TimeAbsolute, wantClock: Clock24h, wantZone: ZoneLocal},
+		{name: "all set", config: &ArgonautConfig{Time: TimeConfig{Format: "Relative", Clock: "12h", Zone: " UTC"}}, wantFormat: TimeRelative, wantClock: Clock12h, wantZone: ZoneUTC},
+		{name: "unknown falls back", config: &ArgonautConfig{Time: TimeConfig{Format: "fuzzy", Clock: "36h", Zone: "Europe/Riga"}}, wantFormat: TimeAbsolute, wantClock: Clock24h, wantZone: ZoneLocal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, clock, zone := tt.config.GetTimeDisplay()
+			if format != tt.wantFormat || clock != tt.wantClock || zone != tt.wantZone {
+				t.Errorf("GetTimeDisplay() = %q, %q, %q, want %q, %q, %q", format, clock, zone, tt.wantFormat, tt.wantClock, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestGetPruneAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: DefaultPruneAfter},
+		{value: "30m", want: 30 * time.Minute},
+		{value: "0", want: 0},
+		{value: "-5m", want: DefaultPruneAfter},
+		{value: "soon", want: DefaultPruneAfter},
+	}
+	for _, tt := range tests {
+		c := &ArgonautConfig{Memory: MemoryConfig{PruneAfter: tt.value}}
+		if got := c.GetPruneAfter(); got != tt.want {
+			t.Errorf("GetPruneAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGetStreamRefreshInterval(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: DefaultStreamRefreshInterval},
+		{value: "2m", want: 2 * time.Minute},
+		{value: "0", want: 0},
+		{value: "-5s", want: DefaultStreamRefreshInterval},
+		{value: "often", want: DefaultStreamRefreshInterval},
+	}
+	for _, tt := range tests {
+		c := &ArgonautConfig{Stream: StreamConfig{RefreshInterval: tt.value}}
+		if got := c.GetStreamRefreshInterval(); got != tt.want {
+			t.Errorf("GetStreamRefreshInterval(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsStreamEnabled(t *testing.T) {
+	off := false
+	if !(&ArgonautConfig{}).IsStreamEnabled() {
+		t.Error("streaming should default to on")
+	}
+	if (&ArgonautConfig{Stream: StreamConfig{Enabled: &off}}).IsStreamEnabled() {
+		t.Error("enabled = false should turn streaming off")
+	}
+}
+
+func TestHooksFor(t *testing.T) {
+	cfg := &ArgonautConfig{Hooks: []LocalHook{
+		{Event: HookSyncCompleted, App: "payments-*", Command: "./smoke.sh", Timeout: "5m"},
+		{Event: HookSyncCompleted, Project: "platform", Command: "notify ${secret:token}"},
+		{Event: HookAppDegraded, Command: "page"},
+		{Event: HookSyncCompleted, Command: ""},
+	}}
+
+	hooks := cfg.HooksFor(HookSyncCompleted, "payments-api", "platform")
+	if len(hooks) != 2 || hooks[0].Command != "./smoke.sh" {
+		t.Fatalf("expected every matching hook in config order, got %+v", hooks)
+	}
+	if hooks[1].Command != "notify ${secret:token}" {
+		t.Errorf("hook commands should keep their secret references, got %q", hooks[1].Command)
+	}
+	if hooks[0].GetTimeout() != 5*time.Minute || hooks[1].GetTimeout() != DefaultHookTimeout {
+		t.Errorf("unexpected timeouts %v, %v", hooks[0].GetTimeout(), hooks[1].GetTimeout())
+	}
+	if hooks := cfg.HooksFor(HookBeforeDelete, "payments-api", "platform"); len(hooks) != 0 {
+		t.Errorf("expected no before-delete hooks, got %+v", hooks)
+	}
+	if hooks := (*ArgonautConfig)(nil).HooksFor(HookAppDegraded, "x", "y"); hooks != nil {
+		t.Error("nil config should have no hooks")
+	}
+}
+
+func TestSafeMode_IgnoresAndKeepsTheConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", configPath)
+	data := "[appearance]\ntheme = \"dracula\"\n\n[[hooks]]\nevent = \"sync-completed\"\ncommand = \"./smoke.sh\"\n"
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	SetSafeMode(true)
+	t.Cleanup(func() { SetSafeMode(false) })
+
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig() failed: %v", err)
+	}
+	if cfg.Appearance.Theme != DefaultThemeName || len(cfg.Hooks) != 0 {
+		t.Errorf("expected the built-in defaults, got %+v", cfg)
+	}
+
+	cfg.Appearance.Theme = "nord"
+	if err := SaveArgonautConfig(cfg); err != nil {
+		t.Fatalf("SaveArgonautConfig() failed: %v", err)
+	}
+	if got, _ := os.ReadFile(configPath); string(got) != data {
+		t.Errorf("safe mode should not write the config file, got:\n%s", got)
+	}
+
+	if _, err := ImportSettings([]byte("[appearance]\ntheme = \"nord\"\n")); err == nil {
+		t.Error("safe mode should refuse to import settings it cannot save")
+	}
+	if _, err := os.Stat(configPath + ".bak"); err == nil {
+		t.Error("safe mode should not write a backup")
+	}
+}
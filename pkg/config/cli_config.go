@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/darksworm/argonaut/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -29,8 +30,9 @@ type ArgoServer struct {
 
 // ArgoUser represents an ArgoCD user configuration
 type ArgoUser struct {
-	Name      string `yaml:"name"`
-	AuthToken string `yaml:"auth-token,omitempty"`
+	Name         string `yaml:"name"`
+	AuthToken    string `yaml:"auth-token,omitempty"`
+	RefreshToken string `yaml:"refresh-token,omitempty"`
 }
 
 // ArgoCLIConfig represents the complete ArgoCD CLI configuration
@@ -95,38 +97,128 @@ func ReadCLIConfigFromPath(configPath string) (*ArgoCLIConfig, error) {
 	return &config, nil
 }
 
-// GetCurrentServer returns the server URL for the current context
-func (c *ArgoCLIConfig) GetCurrentServer() (string, error) {
-	if c.CurrentContext == "" {
-		return "", fmt.Errorf("no current context set in ArgoCD config")
+// ResolvedContext is a context of the CLI config with the server and user
+// entries it references
+type ResolvedContext struct {
+	Context ArgoContext
+	Server  *ArgoServer // nil when the servers list has no entry for it
+	User    *ArgoUser   // nil when the users list has no entry for it
+}
+
+// ResolveContext looks up a context by name, or the current context when
+// name is empty, with the server and user it references. Both are found
+// only through the context's server and user fields, like the argocd CLI
+// does, so a config with several users for one server, or a context whose
+// user is not named after its server, gets the user the context names.
+func (c *ArgoCLIConfig) ResolveContext(name string) (*ResolvedContext, error) {
+	if name == "" {
+		if c.CurrentContext == "" {
+			return nil, fmt.Errorf("no current context set in ArgoCD config")
+		}
+		name = c.CurrentContext
 	}
 
+	var r *ResolvedContext
 	for _, ctx := range c.Contexts {
-		if ctx.Name == c.CurrentContext {
-			if ctx.Server == "" {
-				return "", fmt.Errorf("no server specified for context %s", c.CurrentContext)
+		if ctx.Name == name {
+			r = &ResolvedContext{Context: ctx}
+			break
+		}
+	}
+	if r == nil {
+		return nil, fmt.Errorf("context %q not found in ArgoCD config", name)
+	}
+	if r.Context.Server != "" {
+		for i := range c.Servers {
+			if c.Servers[i].Server == r.Context.Server {
+				r.Server = &c.Servers[i]
+				break
 			}
-			return ctx.Server, nil
 		}
 	}
+	if r.Context.User != "" {
+		for i := range c.Users {
+			if c.Users[i].Name == r.Context.User {
+				r.User = &c.Users[i]
+				break
+			}
+		}
+	}
+	return r, nil
+}
+
+// ServerConfig returns the server entry the context references
+func (r *ResolvedContext) ServerConfig() (*ArgoServer, error) {
+	if r.Context.Server == "" {
+		return nil, fmt.Errorf("no server specified for context %q", r.Context.Name)
+	}
+	if r.Server == nil {
+		return nil, fmt.Errorf("server configuration not found for %s", r.Context.Server)
+	}
+	return r.Server, nil
+}
 
-	return "", fmt.Errorf("context %s not found in ArgoCD config", c.CurrentContext)
+// Token returns the auth token of the user the context references
+func (r *ResolvedContext) Token() (string, error) {
+	if r.Context.User == "" {
+		return "", fmt.Errorf("no user specified for context %q", r.Context.Name)
+	}
+	if r.User == nil {
+		return "", fmt.Errorf("user %s of context %q not found in ArgoCD config", r.Context.User, r.Context.Name)
+	}
+	if r.User.AuthToken == "" {
+		return "", fmt.Errorf("no auth token found for user %s in context %q. Please run 'argocd login' to authenticate", r.Context.User, r.Context.Name)
+	}
+	return r.User.AuthToken, nil
 }
 
-// GetCurrentServerConfig returns the server configuration for the current context
-func (c *ArgoCLIConfig) GetCurrentServerConfig() (*ArgoServer, error) {
-	serverURL, err := c.GetCurrentServer()
+// ToServer converts the context to our internal Server model
+func (r *ResolvedContext) ToServer() (*model.Server, error) {
+	server, err := r.ServerWithoutToken()
+	if err != nil {
+		return nil, err
+	}
+	token, err := r.Token()
 	if err != nil {
 		return nil, err
 	}
+	server.Token = token
+	return server, nil
+}
 
-	for _, server := range c.Servers {
-		if server.Server == serverURL {
-			return &server, nil
-		}
+// ServerWithoutToken converts the context to a Server that has no token
+// yet, for signing in to it
+func (r *ResolvedContext) ServerWithoutToken() (*model.Server, error) {
+	serverConfig, err := r.ServerConfig()
+	if err != nil {
+		return nil, err
 	}
+	return &model.Server{
+		BaseURL:         ensureHTTPS(serverConfig.Server, serverConfig.PlainText),
+		Insecure:        serverConfig.Insecure,
+		GrpcWebRootPath: serverConfig.GrpcWebRootPath,
+	}, nil
+}
 
-	return nil, fmt.Errorf("server configuration not found for %s", serverURL)
+// GetCurrentServer returns the server URL for the current context
+func (c *ArgoCLIConfig) GetCurrentServer() (string, error) {
+	r, err := c.ResolveContext("")
+	if err != nil {
+		return "", err
+	}
+	if r.Context.Server == "" {
+		return "", fmt.Errorf("no server specified for context %s", r.Context.Name)
+	}
+	return r.Context.Server, nil
+}
+
+// GetCurrentServerConfig returns the server configuration for the current context
+func (c *ArgoCLIConfig) GetCurrentServerConfig() (*ArgoServer, error) {
+	r, err := c.ResolveContext("")
+	if err != nil {
+		return nil, err
+	}
+	return r.ServerConfig()
 }
 
 // IsCurrentServerCore returns true if the current server is running in core mode
@@ -150,56 +242,20 @@ func (c *ArgoCLIConfig) IsPortForwardMode() (bool, error) {
 
 // GetCurrentToken returns the auth token for the current context
 func (c *ArgoCLIConfig) GetCurrentToken() (string, error) {
-	if c.CurrentContext == "" {
-		return "", fmt.Errorf("no current context set in ArgoCD config")
-	}
-
-	// Find the current context
-	var currentUser string
-	for _, ctx := range c.Contexts {
-		if ctx.Name == c.CurrentContext {
-			currentUser = ctx.User
-			break
-		}
-	}
-
-	if currentUser == "" {
-		return "", fmt.Errorf("no user specified for context %s", c.CurrentContext)
-	}
-
-	// Find the user and their token
-	for _, user := range c.Users {
-		if user.Name == currentUser {
-			if user.AuthToken == "" {
-				return "", fmt.Errorf("no auth token found for user %s. Please run 'argocd login' to authenticate", currentUser)
-			}
-			return user.AuthToken, nil
-		}
+	r, err := c.ResolveContext("")
+	if err != nil {
+		return "", err
 	}
-
-	return "", fmt.Errorf("user %s not found in ArgoCD config", currentUser)
+	return r.Token()
 }
 
 // ToServerConfig converts the ArgoCD CLI config to our internal Server model
 func (c *ArgoCLIConfig) ToServerConfig() (*model.Server, error) {
-	serverConfig, err := c.GetCurrentServerConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	token, err := c.GetCurrentToken()
+	r, err := c.ResolveContext("")
 	if err != nil {
 		return nil, err
 	}
-
-	baseURL := ensureHTTPS(serverConfig.Server, serverConfig.PlainText)
-
-	return &model.Server{
-		BaseURL:         baseURL,
-		Token:           token,
-		Insecure:        serverConfig.Insecure,
-		GrpcWebRootPath: serverConfig.GrpcWebRootPath,
-	}, nil
+	return r.ToServer()
 }
 
 // GetContextNames returns a sorted list of all context names
@@ -214,95 +270,36 @@ func (c *ArgoCLIConfig) GetContextNames() []string {
 
 // ToServerConfigForContext converts a named context to a Server model
 func (c *ArgoCLIConfig) ToServerConfigForContext(contextName string) (*model.Server, error) {
-	// Find context
-	var ctx *ArgoContext
-	for i := range c.Contexts {
-		if c.Contexts[i].Name == contextName {
-			ctx = &c.Contexts[i]
-			break
-		}
-	}
-	if ctx == nil {
-		return nil, fmt.Errorf("context %q not found in ArgoCD config", contextName)
-	}
-
-	if ctx.Server == "" {
-		return nil, fmt.Errorf("no server specified for context %q", contextName)
-	}
-
-	// Find server config
-	var serverConfig *ArgoServer
-	for i := range c.Servers {
-		if c.Servers[i].Server == ctx.Server {
-			serverConfig = &c.Servers[i]
-			break
-		}
-	}
-	if serverConfig == nil {
-		return nil, fmt.Errorf("server configuration not found for %s", ctx.Server)
-	}
-
-	// Find user token
-	if ctx.User == "" {
-		return nil, fmt.Errorf("no user specified for context %q", contextName)
-	}
-	var token string
-	for _, user := range c.Users {
-		if user.Name == ctx.User {
-			token = user.AuthToken
-			break
-		}
-	}
-	if token == "" {
-		return nil, fmt.Errorf("no auth token found for user %s in context %q", ctx.User, contextName)
+	r, err := c.ResolveContext(contextName)
+	if err != nil {
+		return nil, err
 	}
-
-	baseURL := ensureHTTPS(serverConfig.Server, serverConfig.PlainText)
-
-	return &model.Server{
-		BaseURL:         baseURL,
-		Token:           token,
-		Insecure:        serverConfig.Insecure,
-		GrpcWebRootPath: serverConfig.GrpcWebRootPath,
-	}, nil
+	return r.ToServer()
 }
 
 // IsContextPortForward returns true if the named context uses port-forward mode
 func (c *ArgoCLIConfig) IsContextPortForward(contextName string) (bool, error) {
-	for _, ctx := range c.Contexts {
-		if ctx.Name == contextName {
-			return ctx.Server == "port-forward", nil
-		}
+	r, err := c.ResolveContext(contextName)
+	if err != nil {
+		return false, err
 	}
-	return false, fmt.Errorf("context %q not found in ArgoCD config", contextName)
+	return r.Context.Server == "port-forward", nil
 }
 
 // IsContextCore returns true if the named context's server is running in core mode
 func (c *ArgoCLIConfig) IsContextCore(contextName string) (bool, error) {
-	// Find context
-	var serverURL string
-	found := false
-	for _, ctx := range c.Contexts {
-		if ctx.Name == contextName {
-			serverURL = ctx.Server
-			found = true
-			break
-		}
-	}
-	if !found {
-		return false, fmt.Errorf("context %q not found in ArgoCD config", contextName)
+	r, err := c.ResolveContext(contextName)
+	if err != nil {
+		return false, err
 	}
-	if serverURL == "" {
+	if r.Context.Server == "" {
 		return false, nil
 	}
-
-	// Find server config
-	for _, server := range c.Servers {
-		if server.Server == serverURL {
-			return server.Core, nil
-		}
+	serverConfig, err := r.ServerConfig()
+	if err != nil {
+		return false, err
 	}
-	return false, fmt.Errorf("server configuration not found for %s", serverURL)
+	return serverConfig.Core, nil
 }
 
 // ensureHTTPS ensures the URL has the correct protocol
@@ -312,7 +309,7 @@ func ensureHTTPS(baseURL string, plainText bool) string {
 	}
 
 	// If already has protocol, return as-is
-	if len(baseURL) >= 7 && (baseURL[:7] == "http://" || baseURL[:8] == "https://") {
+	if strings.HasPrefix(baseURL, "http://") || strings.HasPrefix(baseURL, "https://") {
 		return baseURL
 	}
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -586,3 +587,70 @@ func TestIsContextCore(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveContext_UsesTheContextsUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	os.WriteFile(path, []byte(`contexts:
+- name: argocd.example.com
+  server: argocd.example.com
+  user: argocd.example.com
+- name: example-sso
+  server: argocd.example.com
+  user: alice-sso
+- name: broken
+  server: argocd.example.com
+  user: bob
+current-context: example-sso
+servers:
+- server: argocd.example.com
+  grpc-web-root-path: argocd
+users:
+- name: argocd.example.com
+  auth-token: admin-token
+- name: alice-sso
+  auth-token: alice-token
+`), 0644)
+	cfg, err := ReadCLIConfigFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := cfg.ToServerConfig()
+	if err != nil {
+		t.Fatalf("current context should resolve: %v", err)
+	}
+	if server.Token != "alice-token" || server.BaseURL != "https://argocd.example.com" || server.GrpcWebRootPath != "argocd" {
+		t.Errorf("server = %+v, want the current context's user on its server", server)
+	}
+
+	if server, err := cfg.ToServerConfigForContext("argocd.example.com"); err != nil || server.Token != "admin-token" {
+		t.Errorf("another context of the same server should use its own user: %+v, %v", server, err)
+	}
+
+	_, err = cfg.ToServerConfigForContext("broken")
+	if err == nil || !strings.Contains(err.Error(), `user bob of context "broken" not found`) {
+		t.Errorf("a context naming a missing user should say so, got %v", err)
+	}
+
+	if _, err := cfg.ResolveContext("missing"); err == nil || !strings.Contains(err.Error(), `context "missing" not found`) {
+		t.Errorf("unknown context: %v", err)
+	}
+}
+
+func TestResolveContext_ContextFlagPicksNonCurrentContext(t *testing.T) {
+	cfg := &ArgoCLIConfig{
+		CurrentContext: "prod",
+		Contexts: []ArgoContext{
+			{Name: "prod", Server: "prod.example.com", User: "prod"},
+			{Name: "staging", Server: "staging.example.com", User: "staging"},
+		},
+		Servers: []ArgoServer{{Server: "prod.example.com"}, {Server: "staging.example.com", PlainText: true}},
+		Users:   []ArgoUser{{Name: "prod", AuthToken: "p"}, {Name: "staging", AuthToken: "s"}},
+	}
+	cfg.Apply(ArgocdOpts{Context: "staging"})
+
+	server, err := cfg.ToServerConfig()
+	if err != nil || server.BaseURL != "http://staging.example.com" || server.Token != "s" {
+		t.Errorf("server = %+v, %v; want the staging context", server, err)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SaveContextToken stores a session in the ArgoCD CLI config at path, as
+// argocd login does: the auth token and refresh token of the user the
+// context names, or of the current context when name is empty. The file is
+// edited in place, so fields argonaut does not know about are kept, and
+// replaced in one rename, so a failed write never leaves it half written. A
+// context without a user gets one named after its server.
+func SaveContextToken(path, name, authToken, refreshToken string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ArgoCD config from %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse ArgoCD config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse ArgoCD config: not a mapping")
+	}
+	root := doc.Content[0]
+
+	if name == "" {
+		if current := mappingValue(root, "current-context"); current != nil {
+			name = current.Value
+		}
+		if name == "" {
+			return fmt.Errorf("no current context set in ArgoCD config")
+		}
+	}
+	ctx := findNamed(mappingValue(root, "contexts"), name)
+	if ctx == nil {
+		return fmt.Errorf("context %q not found in ArgoCD config", name)
+	}
+	userName := ""
+	if u := mappingValue(ctx, "user"); u != nil {
+		userName = u.Value
+	}
+	if userName == "" {
+		server := mappingValue(ctx, "server")
+		if server == nil || server.Value == "" {
+			return fmt.Errorf("no server specified for context %q", name)
+		}
+		userName = server.Value
+		setMappingValue(ctx, "user", userName)
+	}
+
+	users := mappingValue(root, "users")
+	if users == nil || users.Kind != yaml.SequenceNode {
+		users = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingNode(root, "users", users)
+	}
+	user := findNamed(users, userName)
+	if user == nil {
+		user = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(user, "name", userName)
+		users.Content = append(users.Content, user)
+	}
+	setMappingValue(user, "auth-token", authToken)
+	if refreshToken != "" {
+		setMappingValue(user, "refresh-token", refreshToken)
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode ArgoCD config: %w", err)
+	}
+	if err := replaceFile(path, out); err != nil {
+		return fmt.Errorf("failed to write ArgoCD config to %s: %w", path, err)
+	}
+	return nil
+}
+
+// replaceFile writes data to a temporary file next to path and renames it
+// over path. The file holds credentials; it is kept private like the argocd
+// CLI does.
+func replaceFile(path string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingNode sets key in a YAML mapping, adding it when missing
+func setMappingNode(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setMappingValue sets key in a YAML mapping to a string
+func setMappingValue(m *yaml.Node, key, value string) {
+	setMappingNode(m, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+// findNamed returns the mapping with the given name in a YAML sequence
+func findNamed(seq *yaml.Node, name string) *yaml.Node {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, item := range seq.Content {
+		if n := mappingValue(item, "name"); n != nil && n.Value == name {
+			return item
+		}
+	}
+	return nil
+}
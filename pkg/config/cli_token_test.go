@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveContextToken_UpdatesTheContextsUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	original := `contexts:
+- name: prod
+  server: argo.example.com
+  user: argo.example.com
+- name: dev
+  server: dev.example.com
+  user: dev.example.com
+current-context: dev
+prompts-enabled: false
+servers:
+- grpc-web-root-path: ""
+  server: argo.example.com
+users:
+- auth-token: old
+  name: argo.example.com
+- auth-token: dev-token
+  name: dev.example.com
+`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveContextToken(path, "prod", "new-token", "new-refresh"); err != nil {
+		t.Fatalf("SaveContextToken: %v", err)
+	}
+
+	cfg, err := ReadCLIConfigFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := cfg.ResolveContext("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.User.AuthToken != "new-token" || r.User.RefreshToken != "new-refresh" {
+		t.Errorf("prod user should have the new session, got %+v", r.User)
+	}
+	if tok, _ := mustResolve(t, cfg, "dev").Token(); tok != "dev-token" {
+		t.Errorf("other users should be untouched, dev has %q", tok)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "grpc-web-root-path") {
+		t.Errorf("fields argonaut does not model should be kept:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("the config holds credentials and should be private, got %v", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("the temporary file should be renamed over the config, found %d files", len(entries))
+	}
+}
+
+func TestSaveContextToken_AddsMissingUser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	original := "contexts:\n- name: prod\n  server: argo.example.com\ncurrent-context: prod\nservers:\n- server: argo.example.com\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveContextToken(path, "", "tok", ""); err != nil {
+		t.Fatalf("SaveContextToken: %v", err)
+	}
+	cfg, err := ReadCLIConfigFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := mustResolve(t, cfg, "").ToServer()
+	if err != nil {
+		t.Fatalf("the current context should now resolve to a server with a token: %v", err)
+	}
+	if server.Token != "tok" || server.BaseURL != "https://argo.example.com" {
+		t.Errorf("unexpected server %+v", server)
+	}
+}
+
+func TestSaveContextToken_UnknownContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("contexts: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveContextToken(path, "missing", "tok", ""); err == nil {
+		t.Error("an unknown context should fail")
+	}
+}
+
+func mustResolve(t *testing.T, cfg *ArgoCLIConfig, name string) *ResolvedContext {
+	t.Helper()
+	r, err := cfg.ResolveContext(name)
+	if err != nil {
+		t.Fatalf("ResolveContext(%q): %v", name, err)
+	}
+	return r
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// keepLocalSettings copies the settings that only make sense on the machine
+// they were written on: paths, local tools, credentials and bookkeeping. Every
+// command argonaut runs and every connection setting is local too, so an
+// imported file can neither run commands nor turn off TLS verification.
+// Everything else is portable and travels with export and import.
+func (c *ArgonautConfig) keepLocalSettings(from *ArgonautConfig) {
+	c.ArgocdConfig = from.ArgocdConfig
+	c.Secrets = from.Secrets
+	c.LastSeenVersion = from.LastSeenVersion
+	c.NoConfigWrites = from.NoConfigWrites
+	c.K9s = from.K9s
+	c.Diff = from.Diff
+	c.Clipboard = from.Clipboard
+	c.Hooks = from.Hooks
+	c.Servers = from.Servers
+	c.PortForward = from.PortForward
+	c.Notifications.WebhookURL = from.Notifications.WebhookURL
+}
+
+// readConfigFile parses a config file as written, without defaults or secrets
+func readConfigFile(path string) (*ArgonautConfig, error) {
+	var cfg ArgonautConfig
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", path, err)
+	}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ExportSettings returns the portable part of the active config (theme,
+// sorting, default view, revision columns, sync profiles and the like) as a
+// TOML file another machine can import. Local paths, tools and credentials are
+// left out.
+func ExportSettings() ([]byte, error) {
+	cfg, err := readConfigFile(GetArgonautConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	cfg.keepLocalSettings(&ArgonautConfig{})
+
+	data, err := toml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	header := fmt.Sprintf("# argonaut settings exported %s\n# Import with: argonaut config import <file>\n\n",
+		time.Now().Format("2006-01-02"))
+	return append([]byte(header), data...), nil
+}
+
+// ImportSettings replaces the portable settings of the active config with
+// those in data, keeping this machine's local settings. The previous config
+// is kept next to it with a .bak suffix, whose path is returned when one
+// was written. Refused in safe mode, which saves nothing.
+func ImportSettings(data []byte) (string, error) {
+	if safeMode {
+		return "", fmt.Errorf("not importing: safe mode saves nothing")
+	}
+	var imported ArgonautConfig
+	dec := toml.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&imported); err != nil {
+		return "", fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	configPath := GetArgonautConfigPath()
+	current, err := readConfigFile(configPath)
+	if err != nil {
+		return "", err
+	}
+	if current.NoConfigWrites {
+		return "", fmt.Errorf("not importing: %s sets no_config_writes", configPath)
+	}
+	imported.keepLocalSettings(current)
+
+	backupPath := ""
+	if old, err := os.ReadFile(configPath); err == nil {
+		backupPath = configPath + ".bak"
+		if err := os.WriteFile(backupPath, old, 0644); err != nil {
+			return "", fmt.Errorf("failed to back up config to %s: %w", backupPath, err)
+		}
+	}
+	if err := SaveArgonautConfig(&imported); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// redactedSetting replaces sensitive values in RedactedConfig
+const redactedSetting = "<redacted>"
+
+// isSensitiveSetting reports whether a setting may hold a credential: the
+// notification webhook, whose URL usually is one, and anything named like a
+// password, token or secret
+func isSensitiveSetting(key string) bool {
+	lower := strings.ToLower(key)
+	return lower == "webhook_url" || strings.Contains(lower, "password") ||
+		strings.Contains(lower, "token") || strings.Contains(lower, "secret")
+}
+
+// redactSettings replaces the sensitive string values of a decoded config.
+// ${secret:name} references are kept since they hold no secret themselves.
+func redactSettings(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if s, ok := child.(string); ok && isSensitiveSetting(k) {
+				if secretRefPattern.ReplaceAllString(s, "") != "" {
+					t[k] = redactedSetting
+				}
+				continue
+			}
+			t[k] = redactSettings(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = redactSettings(child)
+		}
+	}
+	return v
+}
+
+// RedactedConfig returns the active config file as written, with webhook
+// URLs and anything named like a password, token or secret replaced, for
+// attaching to bug reports. It is empty when there is no config file.
+func RedactedConfig() ([]byte, error) {
+	path := GetArgonautConfigPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", path, err)
+	}
+	var settings map[string]any
+	if err := toml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	out, err := toml.Marshal(redactSettings(settings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return out, nil
+}
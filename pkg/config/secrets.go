@@ -0,0 +1,207 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// SecretsConfig points at an encrypted file holding sensitive settings.
+// String settings reference its values as ${secret:name}; nested keys are
+// joined with dots (${secret:slack.webhook}).
+type SecretsConfig struct {
+	// File is the encrypted secrets file, relative to the config directory
+	// unless absolute. "*.age" files are decrypted with age, anything else
+	// with sops. The format (toml, yaml or json) follows the extension once
+	// a trailing .age/.enc is dropped.
+	File string `toml:"file,omitempty"`
+	// DecryptCommand overrides the decryption tool; {file} is replaced with
+	// the quoted path and the plaintext is read from stdout
+	DecryptCommand string `toml:"decrypt_command,omitempty"`
+}
+
+// secretRefPattern matches ${secret:name} references in string settings
+var secretRefPattern = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.-]+)\}`)
+
+// loadSecrets decrypts the configured secrets file into c.secrets
+func (c *ArgonautConfig) loadSecrets(configDir string) error {
+	if c.Secrets.File == "" {
+		return nil
+	}
+	path := expandHome(c.Secrets.File)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir, path)
+	}
+
+	plain, err := decryptSecretsFile(path, c.Secrets.DecryptCommand)
+	if err != nil {
+		return err
+	}
+	secrets, err := parseSecrets(plain, secretsFormat(path))
+	if err != nil {
+		return fmt.Errorf("failed to parse secrets file %s: %w", path, err)
+	}
+	c.secrets = secrets
+	return nil
+}
+
+// decryptSecretsFile runs the decryption tool for path and returns the plaintext
+func decryptSecretsFile(path, command string) ([]byte, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("secrets file not found: %w", err)
+	}
+
+	var cmd *exec.Cmd
+	switch {
+	case command != "":
+		cmd = exec.Command("sh", "-c", strings.ReplaceAll(command, "{file}", shellQuote(path)))
+	case strings.HasSuffix(path, ".age"):
+		cmd = exec.Command("age", "--decrypt", "--identity", ageIdentityFile(), path)
+	default:
+		cmd = exec.Command("sops", "--decrypt", path)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to decrypt secrets file %s: %w: %s", path, err, msg)
+		}
+		return nil, fmt.Errorf("failed to decrypt secrets file %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// ageIdentityFile returns the age key file, following sops' lookup so one
+// key serves both tools
+func ageIdentityFile() string {
+	if keyFile := os.Getenv("SOPS_AGE_KEY_FILE"); keyFile != "" {
+		return keyFile
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "sops", "age", "keys.txt")
+}
+
+// secretsFormat guesses the plaintext format from the file name,
+// e.g. secrets.enc.yaml → yaml, secrets.toml.age → toml
+func secretsFormat(path string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".age"), ".enc")
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	default:
+		return "toml"
+	}
+}
+
+// parseSecrets decodes the plaintext and flattens it into dotted keys
+func parseSecrets(data []byte, format string) (map[string]string, error) {
+	var raw map[string]any
+	var err error
+	switch format {
+	case "yaml":
+		err = yaml.Unmarshal(data, &raw)
+	case "json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		err = toml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string]string)
+	flattenSecrets("", raw, secrets)
+	delete(secrets, "sops") // sops metadata, present when decrypting yaml/json in place
+	for key := range secrets {
+		if strings.HasPrefix(key, "sops.") {
+			delete(secrets, key)
+		}
+	}
+	return secrets, nil
+}
+
+func flattenSecrets(prefix string, value any, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenSecrets(key, child, out)
+		}
+	case nil:
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// ExpandSecrets replaces ${secret:name} references with values from the
+// secrets file. Unknown references are left as-is so typos stay visible.
+func (c *ArgonautConfig) ExpandSecrets(s string) string {
+	return c.expandSecrets(s, func(v string) string { return v })
+}
+
+// ExpandSecretsForShell is ExpandSecrets for commands run with sh -c: each
+// value is single-quoted, so spaces, quotes, ";" or "$(...)" in a secret stay
+// one argument instead of being run. References therefore belong outside
+// quotes in the command.
+func (c *ArgonautConfig) ExpandSecretsForShell(s string) string {
+	return c.expandSecrets(s, shellQuote)
+}
+
+func (c *ArgonautConfig) expandSecrets(s string, quote func(string) string) string {
+	if c == nil || !strings.Contains(s, "${secret:") {
+		return s
+	}
+	return secretRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := secretRefPattern.FindStringSubmatch(ref)[1]
+		if v, ok := c.secrets[name]; ok {
+			return quote(v)
+		}
+		return ref
+	})
+}
+
+// SecretNames lists the keys available from the secrets file
+func (c *ArgonautConfig) SecretNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.secrets))
+	for name := range c.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// shellQuote wraps s in single quotes for sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// expandHome expands a leading "~/" to the user's home directory
+func expandHome(p string) string {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return p
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSecretsConfig(t *testing.T, secretsName, secrets, extra string) string {
+	t.Helper()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	t.Setenv("ARGONAUT_CONFIG", configPath)
+	if err := os.WriteFile(filepath.Join(dir, secretsName), []byte(secrets), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := "[secrets]\nfile = \"" + secretsName + "\"\ndecrypt_command = \"cat {file}\"\n" + extra
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func TestSecrets_ExpandedInSettings(t *testing.T) {
+	configPath := writeSecretsConfig(t, "secrets.enc.yaml", `
+diff:
+  token: s3cr3t
+sops:
+  version: 3.9.0
+`, "[diff]\nformatter = \"fmt --token ${secret:diff.token} ${secret:missing}\"\n")
+
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+	if got := cfg.GetDiffFormatter(); got != "fmt --token 's3cr3t' ${secret:missing}" {
+		t.Errorf("GetDiffFormatter() = %q", got)
+	}
+	if names := cfg.SecretNames(); strings.Join(names, ",") != "diff.token" {
+		t.Errorf("SecretNames() = %v, sops metadata should be dropped", names)
+	}
+
+	// Saving must keep the reference, never the decrypted value
+	if err := SaveArgonautConfig(cfg); err != nil {
+		t.Fatalf("SaveArgonautConfig: %v", err)
+	}
+	saved, _ := os.ReadFile(configPath)
+	if strings.Contains(string(saved), "s3cr3t") || !strings.Contains(string(saved), "${secret:diff.token}") {
+		t.Errorf("secret leaked into saved config:\n%s", saved)
+	}
+}
+
+func TestSecrets_QuotedForTheShell(t *testing.T) {
+	hostile := `a b'; touch pwned; echo "$(id)" \`
+	dir := t.TempDir()
+	cfg := &ArgonautConfig{secrets: map[string]string{"token": hostile}}
+
+	cmd := exec.Command("sh", "-c", cfg.ExpandSecretsForShell("printf %s ${secret:token}"))
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if string(out) != hostile {
+		t.Errorf("the secret should reach the command as one argument, got %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+		t.Error("the secret was run as shell code")
+	}
+	if got := cfg.ExpandSecrets("${secret:token}"); got != hostile {
+		t.Errorf("ExpandSecrets should not quote, got %q", got)
+	}
+}
+
+func TestSecrets_FormatsAndFailures(t *testing.T) {
+	for name, content := range map[string]string{
+		"secrets.toml.age": "[slack]\nwebhook = \"https://hooks.example.com/x\"\n",
+		"secrets.json":     `{"slack": {"webhook": "https://hooks.example.com/x"}}`,
+	} {
+		writeSecretsConfig(t, name, content, "")
+		cfg, err := LoadArgonautConfig()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := cfg.ExpandSecrets("${secret:slack.webhook}"); got != "https://hooks.example.com/x" {
+			t.Errorf("%s: got %q", name, got)
+		}
+	}
+
+	configPath := writeSecretsConfig(t, "secrets.yaml", "a: b\n", "")
+	data, _ := os.ReadFile(configPath)
+	data = []byte(strings.Replace(string(data), "cat {file}", "false", 1))
+	if err := os.WriteFile(configPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadArgonautConfig(); err == nil || !strings.Contains(err.Error(), "failed to decrypt") {
+		t.Errorf("expected decrypt error, got %v", err)
+	}
+}
+
+func TestSecretsFormat(t *testing.T) {
+	tests := map[string]string{
+		"secrets.enc.yaml": "yaml",
+		"secrets.yml":      "yaml",
+		"secrets.json.age": "json",
+		"secrets.toml":     "toml",
+		"secrets.age":      "toml",
+	}
+	for path, want := range tests {
+		if got := secretsFormat(path); got != want {
+			t.Errorf("secretsFormat(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+// ServerSettings holds connection settings for one Argo CD server that its
+// Argo CD CLI config entry does not carry
+type ServerSettings struct {
+	// PlainText talks plain HTTP to the server instead of HTTPS
+	PlainText bool `toml:"plaintext,omitempty"`
+	// BasePath is the path Argo CD is served under, e.g. "/argocd" for a
+	// server at https://example.com/argocd (Argo CD's server.rootpath)
+	BasePath string `toml:"base_path,omitempty"`
+	// Insecure skips TLS certificate verification, like the CLI's
+	// --insecure; set when continuing past a certificate error is remembered
+	Insecure bool `toml:"insecure,omitempty"`
+}
+
+// serverKey normalizes a server address for matching: no scheme, no
+// trailing slash, lowercase
+func serverKey(server string) string {
+	server = strings.ToLower(strings.TrimSpace(server))
+	for _, scheme := range []string{"https://", "http://"} {
+		server = strings.TrimPrefix(server, scheme)
+	}
+	return strings.TrimRight(server, "/")
+}
+
+// ServerSettingsFor returns the [servers] entry for a server, which may be
+// keyed by its address as in the Argo CD CLI config or by its URL
+func (c *ArgonautConfig) ServerSettingsFor(baseURL string) (ServerSettings, bool) {
+	if c == nil {
+		return ServerSettings{}, false
+	}
+	key := serverKey(baseURL)
+	for name, s := range c.Servers {
+		if serverKey(name) == key {
+			return s, true
+		}
+	}
+	return ServerSettings{}, false
+}
+
+// ApplyServerSettings applies the [servers] entry of the server, if any:
+// plain HTTP, skipping certificate verification and the base path all API
+// and stream URLs are prefixed with
+func (c *ArgonautConfig) ApplyServerSettings(server *model.Server) {
+	if server == nil {
+		return
+	}
+	s, ok := c.ServerSettingsFor(server.BaseURL)
+	if !ok {
+		return
+	}
+	if s.PlainText {
+		server.BaseURL = "http://" + strings.TrimPrefix(server.BaseURL, "https://")
+	}
+	if s.Insecure {
+		server.Insecure = true
+	}
+	if s.BasePath != "" {
+		server.GrpcWebRootPath = s.BasePath
+	}
+}
+
+// SetServerInsecure turns off certificate verification for a server in its
+// [servers] entry, adding one keyed by the server's address if needed
+func (c *ArgonautConfig) SetServerInsecure(baseURL string) {
+	key := serverKey(baseURL)
+	for name, s := range c.Servers {
+		if serverKey(name) == key {
+			s.Insecure = true
+			c.Servers[name] = s
+			return
+		}
+	}
+	if c.Servers == nil {
+		c.Servers = make(map[string]ServerSettings)
+	}
+	c.Servers[key] = ServerSettings{Insecure: true}
+}
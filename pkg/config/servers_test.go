@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/model"
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestApplyServerSettings(t *testing.T) {
+	var cfg ArgonautConfig
+	if err := toml.Unmarshal([]byte(`
+[servers."argocd.internal:8080"]
+plaintext = true
+base_path = "/argocd"
+
+[servers."https://other.example.com/"]
+base_path = "/cd"
+`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	server := &model.Server{BaseURL: "https://argocd.internal:8080"}
+	cfg.ApplyServerSettings(server)
+	if server.BaseURL != "http://argocd.internal:8080" || server.GrpcWebRootPath != "/argocd" {
+		t.Errorf("unexpected server %+v", server)
+	}
+
+	server = &model.Server{BaseURL: "https://Other.example.com"}
+	cfg.ApplyServerSettings(server)
+	if server.BaseURL != "https://Other.example.com" || server.GrpcWebRootPath != "/cd" {
+		t.Errorf("expected a URL key to match, got %+v", server)
+	}
+
+	server = &model.Server{BaseURL: "https://unknown.example.com", GrpcWebRootPath: "/keep"}
+	cfg.ApplyServerSettings(server)
+	if server.GrpcWebRootPath != "/keep" {
+		t.Errorf("servers without settings should be left alone, got %+v", server)
+	}
+}
+
+func TestSetServerInsecure(t *testing.T) {
+	cfg := ArgonautConfig{Servers: map[string]ServerSettings{"argocd.internal:8080": {BasePath: "/argocd"}}}
+	cfg.SetServerInsecure("https://argocd.internal:8080")
+	cfg.SetServerInsecure("https://Other.example.com/")
+
+	if s := cfg.Servers["argocd.internal:8080"]; !s.Insecure || s.BasePath != "/argocd" {
+		t.Errorf("the existing entry should gain insecure and keep its settings: %+v", s)
+	}
+	if s, ok := cfg.Servers["other.example.com"]; !ok || !s.Insecure {
+		t.Errorf("a new entry should be keyed by the address: %+v", cfg.Servers)
+	}
+
+	server := &model.Server{BaseURL: "https://other.example.com"}
+	cfg.ApplyServerSettings(server)
+	if !server.Insecure {
+		t.Error("the setting should turn off verification for the server")
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// localState is what argonaut remembers between runs when no_config_writes
+// keeps it from saving to the config file: the last version seen and the
+// theme, sorting and pane layout picked at runtime
+type localState struct {
+	LastSeenVersion string       `toml:"last_seen_version,omitempty"`
+	Theme           string       `toml:"theme,omitempty"`
+	Sort            SortConfig   `toml:"sort,omitempty"`
+	Layout          LayoutConfig `toml:"layout,omitempty"`
+}
+
+// StateDir returns the directory state is kept in when the config is not
+// written to. ARGONAUT_STATE_DIR overrides the platform state directory.
+func StateDir() string {
+	if dir := os.Getenv("ARGONAUT_STATE_DIR"); dir != "" {
+		return dir
+	}
+	if runtime.GOOS == "windows" {
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			return filepath.Join(local, "argonaut", "state")
+		}
+	}
+	if xdgState := os.Getenv("XDG_STATE_HOME"); xdgState != "" {
+		return filepath.Join(xdgState, "argonaut")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "state", "argonaut")
+}
+
+// stateFilePath returns the state file of the active profile
+func stateFilePath() string {
+	if activeProfile != "" {
+		return filepath.Join(StateDir(), "profiles", activeProfile+".toml")
+	}
+	return filepath.Join(StateDir(), "state.toml")
+}
+
+// applyState overlays the saved state on a config loaded from disk
+func (c *ArgonautConfig) applyState() error {
+	data, err := os.ReadFile(stateFilePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state from %s: %w", stateFilePath(), err)
+	}
+	var st localState
+	if err := toml.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("failed to parse state %s: %w", stateFilePath(), err)
+	}
+	if st.LastSeenVersion != "" {
+		c.LastSeenVersion = st.LastSeenVersion
+	}
+	if st.Theme != "" {
+		c.Appearance.Theme = st.Theme
+	}
+	if st.Sort.Field != "" {
+		c.Sort = st.Sort
+	}
+	if !reflect.DeepEqual(st.Layout, LayoutConfig{}) {
+		c.Layout = st.Layout
+	}
+	return nil
+}
+
+// saveState writes what argonaut would have saved to the config file to the
+// state file instead. The theme, sorting and layout are only kept while they
+// differ from the config file, so changing the file still takes effect.
+func saveState(c *ArgonautConfig) error {
+	file, err := readConfigFile(GetArgonautConfigPath())
+	if err != nil {
+		return err
+	}
+	file.applyDefaults()
+
+	st := localState{LastSeenVersion: c.LastSeenVersion}
+	if c.Appearance.Theme != file.Appearance.Theme {
+		st.Theme = c.Appearance.Theme
+	}
+	if c.Sort != file.Sort {
+		st.Sort = c.Sort
+	}
+	if !reflect.DeepEqual(c.Layout, file.Layout) {
+		st.Layout = c.Layout
+	}
+	data, err := toml.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	path := stateFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNoConfigWrites_KeepsStateOutOfTheConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+	t.Setenv("ARGONAUT_STATE_DIR", filepath.Join(dir, "state"))
+	original := "no_config_writes = true\n\n[appearance]\ntheme = \"nord\"\n"
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte(original), 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+	cfg.LastSeenVersion = "v2.1.0"
+	cfg.Appearance.Theme = "dracula"
+	if err := SaveArgonautConfig(cfg); err != nil {
+		t.Fatalf("SaveArgonautConfig: %v", err)
+	}
+	if data, _ := os.ReadFile(GetArgonautConfigPath()); string(data) != original {
+		t.Errorf("the config file was changed:\n%s", data)
+	}
+
+	cfg, err = LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("LoadArgonautConfig: %v", err)
+	}
+	if cfg.LastSeenVersion != "v2.1.0" || cfg.Appearance.Theme != "dracula" {
+		t.Errorf("expected the saved state back, got version %q theme %q", cfg.LastSeenVersion, cfg.Appearance.Theme)
+	}
+
+	// Settings equal to the config file's are not pinned in the state
+	cfg.Appearance.Theme = "nord"
+	if err := SaveArgonautConfig(cfg); err != nil {
+		t.Fatalf("SaveArgonautConfig: %v", err)
+	}
+	state, _ := os.ReadFile(filepath.Join(dir, "state", "state.toml"))
+	if strings.Contains(string(state), "theme") || strings.Contains(string(state), "sort") {
+		t.Errorf("state should only hold what differs from the config:\n%s", state)
+	}
+
+	if _, err := ImportSettings([]byte("default_view = \"apps\"\n")); err == nil || !strings.Contains(err.Error(), "no_config_writes") {
+		t.Errorf("import should refuse to write the config, got %v", err)
+	}
+}
+
+func TestNoConfigWrites_UnreadableStateIsIgnored(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARGONAUT_CONFIG", filepath.Join(dir, "config.toml"))
+	t.Setenv("ARGONAUT_STATE_DIR", filepath.Join(dir, "state"))
+	if err := os.WriteFile(GetArgonautConfigPath(), []byte("no_config_writes = true\n\n[appearance]\ntheme = \"nord\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "state"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "state", "state.toml"), []byte("theme = [broken\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadArgonautConfig()
+	if err != nil {
+		t.Fatalf("a broken state file should not fail the config load: %v", err)
+	}
+	if cfg.Appearance.Theme != "nord" {
+		t.Errorf("expected the config file's theme, got %q", cfg.Appearance.Theme)
+	}
+}
@@ -0,0 +1,110 @@
+// Package fixture serves applications and resource trees read from local
+// JSON files through a read-only imitation of the Argo CD API, so argonaut
+// can run offline for demos, UI work and reproducing rendering bugs from
+// exported data.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture is a snapshot of an Argo CD instance: its applications and the
+// resource trees of any of them
+type Fixture struct {
+	Applications []Application
+	Trees        map[string]json.RawMessage // by application name
+}
+
+// Application is one application of a fixture. Raw is served as read so
+// fields argonaut does not parse yet still reach it
+type Application struct {
+	Name      string
+	Namespace string
+	Raw       json.RawMessage
+}
+
+// file is the object form of a fixture file: a Kubernetes or Argo CD list
+// of applications with optional resource trees by application name
+type file struct {
+	Items []json.RawMessage          `json:"items"`
+	Trees map[string]json.RawMessage `json:"trees"`
+}
+
+// Load reads a fixture file. The file holds either an array of applications
+// (argocd app list -o json) or a list object with "items" (kubectl get
+// applications -o json, or the /api/v1/applications response) and optional
+// "trees" keyed by application name. Trees are also read from a trees
+// directory next to the file, one <app>.json per application, as returned
+// by /api/v1/applications/<app>/resource-tree.
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var f file
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		err = json.Unmarshal(data, &f.Items)
+	} else {
+		err = json.Unmarshal(data, &f)
+		if err == nil && f.Items == nil {
+			err = errors.New(`expected an array of applications or an object with "items"`)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	fx := &Fixture{Trees: map[string]json.RawMessage{}}
+	for i, raw := range f.Items {
+		var meta struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(raw, &meta); err != nil || meta.Metadata.Name == "" {
+			return nil, fmt.Errorf("failed to parse fixture %s: application %d has no metadata.name", path, i)
+		}
+		fx.Applications = append(fx.Applications, Application{
+			Name:      meta.Metadata.Name,
+			Namespace: meta.Metadata.Namespace,
+			Raw:       raw,
+		})
+	}
+
+	treeFiles, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "trees", "*.json"))
+	for _, tf := range treeFiles {
+		tree, err := os.ReadFile(tf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture tree: %w", err)
+		}
+		if !json.Valid(tree) {
+			return nil, fmt.Errorf("failed to parse fixture tree %s: invalid JSON", tf)
+		}
+		fx.Trees[strings.TrimSuffix(filepath.Base(tf), ".json")] = tree
+	}
+	// Trees in the fixture file win over tree files
+	for name, tree := range f.Trees {
+		fx.Trees[name] = tree
+	}
+	return fx, nil
+}
+
+// find returns the application with the given name, in appNamespace when
+// one is given
+func (f *Fixture) find(name, appNamespace string) (Application, bool) {
+	for _, app := range f.Applications {
+		if app.Name == name && (appNamespace == "" || app.Namespace == appNamespace) {
+			return app, true
+		}
+	}
+	return Application{}, false
+}
@@ -0,0 +1,113 @@
+package fixture
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darksworm/argonaut/pkg/api"
+	"github.com/darksworm/argonaut/pkg/model"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_Formats(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "list.json"), `[{"metadata": {"name": "api"}}, {"metadata": {"name": "web", "namespace": "team"}}]`)
+	writeFile(t, filepath.Join(dir, "items.json"), `{"kind": "ApplicationList", "items": [{"metadata": {"name": "api"}}],
+		"trees": {"api": {"nodes": [{"kind": "Deployment", "name": "inline"}]}}}`)
+	writeFile(t, filepath.Join(dir, "trees", "api.json"), `{"nodes": [{"kind": "Deployment", "name": "from-file"}]}`)
+
+	fx, err := Load(filepath.Join(dir, "list.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(fx.Applications) != 2 || fx.Applications[1].Namespace != "team" {
+		t.Fatalf("unexpected applications %+v", fx.Applications)
+	}
+	if string(fx.Trees["api"]) != `{"nodes": [{"kind": "Deployment", "name": "from-file"}]}` {
+		t.Errorf("tree should come from trees/api.json, got %s", fx.Trees["api"])
+	}
+
+	fx, err = Load(filepath.Join(dir, "items.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(fx.Applications) != 1 || string(fx.Trees["api"]) != `{"nodes": [{"kind": "Deployment", "name": "inline"}]}` {
+		t.Errorf("inline trees should win over tree files, got %s", fx.Trees["api"])
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"broken.json":   `[{"metadata":`,
+		"object.json":   `{"apps": []}`,
+		"unnamed.json":  `[{"spec": {}}]`,
+		"not-json.json": `apps: []`,
+	} {
+		writeFile(t, filepath.Join(dir, name), data)
+		if _, err := Load(filepath.Join(dir, name)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file: expected an error")
+	}
+}
+
+// The API client argonaut uses reads the fixture like a real server
+func TestHandler_ServesClient(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "apps.json"), `[{"metadata": {"name": "api", "namespace": "argocd"},
+		"spec": {"project": "default"}, "status": {"sync": {"status": "OutOfSync"}, "health": {"status": "Degraded"}}}]`)
+	writeFile(t, filepath.Join(dir, "trees", "api.json"), `{"nodes": [{"kind": "Deployment", "name": "api", "namespace": "prod"}]}`)
+	fx, err := Load(filepath.Join(dir, "apps.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	srv := httptest.NewServer(NewHandler(fx))
+	defer srv.Close()
+
+	svc := api.NewApplicationService(&model.Server{BaseURL: srv.URL, Token: "fixture"})
+	ctx := context.Background()
+	if err := svc.GetUserInfo(ctx); err != nil {
+		t.Fatalf("GetUserInfo: %v", err)
+	}
+	apps, err := svc.ListApplications(ctx)
+	if err != nil {
+		t.Fatalf("ListApplications: %v", err)
+	}
+	if len(apps) != 1 || apps[0].Name != "api" || apps[0].Sync != "OutOfSync" || apps[0].Health != "Degraded" {
+		t.Fatalf("unexpected apps %+v", apps)
+	}
+
+	tree, err := svc.GetResourceTree(ctx, "api", "argocd")
+	if err != nil {
+		t.Fatalf("GetResourceTree: %v", err)
+	}
+	if len(tree.Nodes) != 1 || tree.Nodes[0].Kind != "Deployment" {
+		t.Errorf("unexpected tree %+v", tree)
+	}
+	if _, err := svc.GetApplication(ctx, "missing", nil); err == nil {
+		t.Error("unknown apps should not be found")
+	}
+
+	ns := "argocd"
+	if err := svc.RefreshApplication(ctx, "api", &api.RefreshOptions{AppNamespace: &ns}); err != nil {
+		t.Errorf("refresh is a GET and should succeed, got %v", err)
+	}
+	if err := svc.SyncApplication(ctx, "api", &api.SyncOptions{}); err == nil {
+		t.Error("syncing a fixture should fail")
+	}
+}
@@ -0,0 +1,101 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// NewHandler serves a fixture through the Argo CD API endpoints argonaut
+// reads. Watch streams stay open without events, and anything that would
+// change the instance fails with PermissionDenied.
+func NewHandler(f *Fixture) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"Version": "fixture"})
+	})
+	mux.HandleFunc("GET /api/v1/session/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"loggedIn": true, "username": "fixture"})
+	})
+	mux.HandleFunc("GET /api/v1/settings", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{})
+	})
+	for _, path := range []string{"GET /api/v1/clusters", "GET /api/v1/projects"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, map[string]any{"items": []any{}})
+		})
+	}
+
+	mux.HandleFunc("GET /api/v1/applications", func(w http.ResponseWriter, r *http.Request) {
+		items := make([]json.RawMessage, 0, len(f.Applications))
+		for _, app := range f.Applications {
+			items = append(items, app.Raw)
+		}
+		writeJSON(w, map[string]any{
+			"metadata": map[string]string{"resourceVersion": "1"},
+			"items":    items,
+		})
+	})
+	mux.HandleFunc("GET /api/v1/applications/{name}", func(w http.ResponseWriter, r *http.Request) {
+		app, ok := f.find(r.PathValue("name"), r.URL.Query().Get("appNamespace"))
+		if !ok {
+			writeError(w, http.StatusNotFound, 5, fmt.Sprintf("applications.argoproj.io %q not found", r.PathValue("name")))
+			return
+		}
+		writeJSON(w, app.Raw)
+	})
+	mux.HandleFunc("GET /api/v1/applications/{name}/resource-tree", func(w http.ResponseWriter, r *http.Request) {
+		tree, ok := f.Trees[r.PathValue("name")]
+		if !ok {
+			// Apps without a tree in the fixture show an empty tree
+			tree = json.RawMessage(`{"nodes": []}`)
+		}
+		writeJSON(w, tree)
+	})
+
+	// Nothing changes in a fixture, so streams only have to stay open
+	hold := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		if fl, ok := w.(http.Flusher); ok {
+			fl.Flush()
+		}
+		<-r.Context().Done()
+	}
+	mux.HandleFunc("GET /api/v1/stream/applications", hold)
+	mux.HandleFunc("GET /api/v1/stream/applications/{name}/resource-tree", hold)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusForbidden, 7, "argonaut is running against a fixture; nothing can be changed")
+			return
+		}
+		writeError(w, http.StatusNotFound, 5, "not available in fixture mode")
+	})
+	return mux
+}
+
+// Serve serves a fixture on a loopback port until the process exits and
+// returns its base URL
+func Serve(f *Fixture) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to listen for fixture server: %w", err)
+	}
+	go func() { _ = http.Serve(ln, NewHandler(f)) }()
+	return "http://" + ln.Addr().String(), nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error the way Argo CD's gRPC gateway does
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": message, "code": code, "message": message})
+}
@@ -0,0 +1,136 @@
+// Package gitweb builds links to files in the web UI of a git host
+// (GitHub, GitLab, Bitbucket, Azure DevOps, and hosts that follow GitHub's
+// URL layout such as Gitea) from the repo URL an Argo CD source uses.
+package gitweb
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// RepoURL returns the https address of a repository's web UI for an
+// https, ssh or scp-like (git@host:org/repo.git) clone URL
+func RepoURL(repoURL string) (string, bool) {
+	s := strings.TrimSpace(repoURL)
+	if s == "" {
+		return "", false
+	}
+	if !strings.Contains(s, "://") {
+		// scp-like syntax: [user@]host:org/repo
+		host, repoPath, ok := strings.Cut(s, ":")
+		if !ok {
+			return "", false
+		}
+		if _, h, found := strings.Cut(host, "@"); found {
+			host = h
+		}
+		s = "https://" + host + "/" + repoPath
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh", "git":
+	default:
+		return "", false
+	}
+	host := u.Hostname()
+	repoPath := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if repoPath == "" {
+		return "", false
+	}
+	if host == "ssh.dev.azure.com" {
+		// ssh://git@ssh.dev.azure.com/v3/org/project/repo
+		parts := strings.Split(strings.TrimPrefix(repoPath, "v3/"), "/")
+		if len(parts) != 3 {
+			return "", false
+		}
+		return "https://dev.azure.com/" + parts[0] + "/" + parts[1] + "/_git/" + parts[2], true
+	}
+	base := "https://" + host
+	if u.Scheme == "http" || u.Scheme == "https" {
+		// Keep a non-default port of a self-hosted web UI; ssh ports are not it
+		if port := u.Port(); port != "" {
+			base = u.Scheme + "://" + host + ":" + port
+		}
+	}
+	return base + "/" + repoPath, true
+}
+
+// FileURL links to a file of the repository at a revision
+func FileURL(repoURL, revision, file string) (string, bool) {
+	return link(repoURL, revision, file, false)
+}
+
+// DirURL links to a directory of the repository at a revision; an empty
+// dir is the repository root
+func DirURL(repoURL, revision, dir string) (string, bool) {
+	return link(repoURL, revision, dir, true)
+}
+
+func link(repoURL, revision, p string, dir bool) (string, bool) {
+	base, ok := RepoURL(repoURL)
+	if !ok {
+		return "", false
+	}
+	if revision == "" {
+		revision = "HEAD"
+	}
+	p = strings.Trim(path.Clean("/"+p), "/")
+	u, _ := url.Parse(base)
+	host := u.Hostname()
+
+	switch {
+	case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+		q := url.Values{"path": {"/" + p}, "version": {azureVersion(revision)}}
+		return base + "?" + q.Encode(), true
+	case host == "bitbucket.org":
+		return base + "/src/" + revision + "/" + escapePath(p), true
+	}
+
+	kind := "blob"
+	if dir {
+		kind = "tree"
+	}
+	if strings.Contains(host, "gitlab") {
+		kind = "-/" + kind
+	}
+	link := base + "/" + kind + "/" + revision
+	if p != "" {
+		link += "/" + escapePath(p)
+	}
+	return link, true
+}
+
+// azureVersion names a revision the way Azure DevOps links expect: GC for
+// a commit, GB for a branch
+func azureVersion(revision string) string {
+	if isCommitSHA(revision) {
+		return "GC" + revision
+	}
+	return "GB" + revision
+}
+
+func isCommitSHA(s string) bool {
+	if len(s) < 7 || len(s) > 40 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// escapePath escapes each segment of a slash-separated path
+func escapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
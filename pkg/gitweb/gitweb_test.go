@@ -0,0 +1,53 @@
+package gitweb
+
+import "testing"
+
+func TestRepoURL(t *testing.T) {
+	cases := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"https://github.com/org/repo.git", "https://github.com/org/repo", true},
+		{"https://user@github.com/org/repo", "https://github.com/org/repo", true},
+		{"git@github.com:org/repo.git", "https://github.com/org/repo", true},
+		{"ssh://git@gitlab.example.com:2222/group/sub/repo.git", "https://gitlab.example.com/group/sub/repo", true},
+		{"https://git.example.com:8443/org/repo", "https://git.example.com:8443/org/repo", true},
+		{"git@ssh.dev.azure.com:v3/org/project/repo", "https://dev.azure.com/org/project/_git/repo", true},
+		{"oci://registry.example.com/charts", "", false},
+		{"charts", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		got, ok := RepoURL(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("RepoURL(%q) = %q, %v; want %q, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestFileAndDirURL(t *testing.T) {
+	const sha = "1a2b3c4d5e6f7a8b9c0d1a2b3c4d5e6f7a8b9c0d"
+	cases := []struct {
+		name, got, want string
+	}{
+		{"github file", must(FileURL("git@github.com:org/repo.git", sha, "apps/web/deployment.yaml")), "https://github.com/org/repo/blob/" + sha + "/apps/web/deployment.yaml"},
+		{"github dir", must(DirURL("https://github.com/org/repo", "main", "apps/web/")), "https://github.com/org/repo/tree/main/apps/web"},
+		{"github root", must(DirURL("https://github.com/org/repo", "main", "")), "https://github.com/org/repo/tree/main"},
+		{"gitlab", must(FileURL("https://gitlab.com/g/repo.git", "v1", "base/../app/cm.yaml")), "https://gitlab.com/g/repo/-/blob/v1/app/cm.yaml"},
+		{"bitbucket", must(DirURL("git@bitbucket.org:team/repo.git", "main", "k8s")), "https://bitbucket.org/team/repo/src/main/k8s"},
+		{"azure", must(FileURL("https://dev.azure.com/org/proj/_git/repo", sha, "a b.yaml")), "https://dev.azure.com/org/proj/_git/repo?path=%2Fa+b.yaml&version=GC" + sha},
+		{"escaped", must(FileURL("https://github.com/org/repo", "", "a b/c#d.yaml")), "https://github.com/org/repo/blob/HEAD/a%20b/c%23d.yaml"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+func must(s string, ok bool) string {
+	if !ok {
+		return "<not ok>"
+	}
+	return s
+}
@@ -0,0 +1,160 @@
+// Package kubeapi knows which Kubernetes API versions were deprecated and
+// removed in which release, so apps still deploying resources at those
+// versions can be flagged before a cluster upgrade breaks their syncs. The
+// table follows the Kubernetes deprecated API migration guide and only
+// lists the kinds apps deploy, not review or admission objects.
+package kubeapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a Kubernetes minor release, e.g. 1.25
+type Version struct {
+	Major, Minor int
+}
+
+// String renders the version as Kubernetes release notes do, e.g. "1.25"
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast reports whether v is o or a later release
+func (v Version) AtLeast(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major > o.Major
+	}
+	return v.Minor >= o.Minor
+}
+
+// ParseVersion reads the version a cluster reports, such as "1.29",
+// "v1.27.8-eks-8cb36c9" or GKE's "1.28+". ok is false when it does not
+// start with a major and minor number.
+func ParseVersion(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	major, rest, found := strings.Cut(s, ".")
+	if !found {
+		return Version{}, false
+	}
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	maj, err := strconv.Atoi(major)
+	if err != nil || end == 0 {
+		return Version{}, false
+	}
+	minor, _ := strconv.Atoi(rest[:end])
+	return Version{Major: maj, Minor: minor}, true
+}
+
+// Deprecation is an API version of a kind that Kubernetes deprecated and
+// later removed, with the version to move to
+type Deprecation struct {
+	Group        string
+	Version      string
+	Kind         string
+	DeprecatedIn Version
+	RemovedIn    Version
+	// Replacement is the apiVersion to use instead, or a note when the
+	// kind is gone altogether
+	Replacement string
+}
+
+// APIVersion renders the deprecated apiVersion, e.g. "batch/v1beta1"
+func (d Deprecation) APIVersion() string {
+	if d.Group == "" {
+		return d.Version
+	}
+	return d.Group + "/" + d.Version
+}
+
+// Status is how a deprecated API version stands on a cluster
+type Status int
+
+const (
+	// Supported means the cluster serves the version without a warning
+	Supported Status = iota
+	// Deprecated means the cluster still serves it, but a later release
+	// will not
+	Deprecated
+	// Removed means the cluster no longer serves it, so syncs fail
+	Removed
+)
+
+// StatusOn returns how the API version stands on a cluster of version v
+func (d Deprecation) StatusOn(v Version) Status {
+	switch {
+	case v.AtLeast(d.RemovedIn):
+		return Removed
+	case v.AtLeast(d.DeprecatedIn):
+		return Deprecated
+	}
+	return Supported
+}
+
+func v1(minor int) Version { return Version{Major: 1, Minor: minor} }
+
+// deprecations lists the deprecated API versions, oldest removal first
+var deprecations = []Deprecation{
+	{"extensions", "v1beta1", "Deployment", v1(9), v1(16), "apps/v1"},
+	{"extensions", "v1beta1", "DaemonSet", v1(9), v1(16), "apps/v1"},
+	{"extensions", "v1beta1", "ReplicaSet", v1(9), v1(16), "apps/v1"},
+	{"extensions", "v1beta1", "NetworkPolicy", v1(9), v1(16), "networking.k8s.io/v1"},
+	{"extensions", "v1beta1", "PodSecurityPolicy", v1(10), v1(16), "policy/v1beta1"},
+	{"apps", "v1beta1", "Deployment", v1(9), v1(16), "apps/v1"},
+	{"apps", "v1beta1", "StatefulSet", v1(9), v1(16), "apps/v1"},
+	{"apps", "v1beta2", "Deployment", v1(9), v1(16), "apps/v1"},
+	{"apps", "v1beta2", "StatefulSet", v1(9), v1(16), "apps/v1"},
+	{"apps", "v1beta2", "DaemonSet", v1(9), v1(16), "apps/v1"},
+	{"apps", "v1beta2", "ReplicaSet", v1(9), v1(16), "apps/v1"},
+
+	{"extensions", "v1beta1", "Ingress", v1(14), v1(22), "networking.k8s.io/v1"},
+	{"networking.k8s.io", "v1beta1", "Ingress", v1(19), v1(22), "networking.k8s.io/v1"},
+	{"networking.k8s.io", "v1beta1", "IngressClass", v1(19), v1(22), "networking.k8s.io/v1"},
+	{"apiextensions.k8s.io", "v1beta1", "CustomResourceDefinition", v1(16), v1(22), "apiextensions.k8s.io/v1"},
+	{"admissionregistration.k8s.io", "v1beta1", "MutatingWebhookConfiguration", v1(16), v1(22), "admissionregistration.k8s.io/v1"},
+	{"admissionregistration.k8s.io", "v1beta1", "ValidatingWebhookConfiguration", v1(16), v1(22), "admissionregistration.k8s.io/v1"},
+	{"apiregistration.k8s.io", "v1beta1", "APIService", v1(19), v1(22), "apiregistration.k8s.io/v1"},
+	{"certificates.k8s.io", "v1beta1", "CertificateSigningRequest", v1(19), v1(22), "certificates.k8s.io/v1"},
+	{"coordination.k8s.io", "v1beta1", "Lease", v1(19), v1(22), "coordination.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "ClusterRole", v1(17), v1(22), "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding", v1(17), v1(22), "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "Role", v1(17), v1(22), "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io", "v1beta1", "RoleBinding", v1(17), v1(22), "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io", "v1beta1", "PriorityClass", v1(14), v1(22), "scheduling.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSIDriver", v1(19), v1(22), "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSINode", v1(17), v1(22), "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "StorageClass", v1(19), v1(22), "storage.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "VolumeAttachment", v1(19), v1(22), "storage.k8s.io/v1"},
+
+	{"batch", "v1beta1", "CronJob", v1(21), v1(25), "batch/v1"},
+	{"discovery.k8s.io", "v1beta1", "EndpointSlice", v1(21), v1(25), "discovery.k8s.io/v1"},
+	{"events.k8s.io", "v1beta1", "Event", v1(22), v1(25), "events.k8s.io/v1"},
+	{"autoscaling", "v2beta1", "HorizontalPodAutoscaler", v1(22), v1(25), "autoscaling/v2"},
+	{"policy", "v1beta1", "PodDisruptionBudget", v1(21), v1(25), "policy/v1"},
+	{"policy", "v1beta1", "PodSecurityPolicy", v1(21), v1(25), "none, use Pod Security Admission"},
+	{"node.k8s.io", "v1beta1", "RuntimeClass", v1(20), v1(25), "node.k8s.io/v1"},
+
+	{"autoscaling", "v2beta2", "HorizontalPodAutoscaler", v1(23), v1(26), "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "FlowSchema", v1(23), v1(26), "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "PriorityLevelConfiguration", v1(23), v1(26), "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io", "v1beta1", "CSIStorageCapacity", v1(24), v1(27), "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta2", "FlowSchema", v1(26), v1(29), "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta2", "PriorityLevelConfiguration", v1(26), v1(29), "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta3", "FlowSchema", v1(29), v1(32), "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta3", "PriorityLevelConfiguration", v1(29), v1(32), "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+// Lookup returns the deprecation of a kind at an API version, or false
+// when the version was never deprecated
+func Lookup(group, version, kind string) (Deprecation, bool) {
+	for _, d := range deprecations {
+		if d.Group == group && d.Version == version && d.Kind == kind {
+			return d, true
+		}
+	}
+	return Deprecation{}, false
+}
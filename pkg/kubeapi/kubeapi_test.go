@@ -0,0 +1,50 @@
+package kubeapi
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	for in, want := range map[string]Version{
+		"1.29":                {1, 29},
+		"v1.27.8-eks-8cb36c9": {1, 27},
+		"1.28+":               {1, 28},
+		"1.30.2-gke.1587003":  {1, 30},
+	} {
+		if got, ok := ParseVersion(in); !ok || got != want {
+			t.Errorf("ParseVersion(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "unknown", "1", "v.2"} {
+		if _, ok := ParseVersion(in); ok {
+			t.Errorf("ParseVersion(%q) should fail", in)
+		}
+	}
+}
+
+func TestDeprecation_StatusOn(t *testing.T) {
+	d, ok := Lookup("batch", "v1beta1", "CronJob")
+	if !ok {
+		t.Fatal("batch/v1beta1 CronJob is deprecated")
+	}
+	if d.APIVersion() != "batch/v1beta1" || d.Replacement != "batch/v1" {
+		t.Errorf("unexpected deprecation %+v", d)
+	}
+	for cluster, want := range map[Version]Status{
+		{1, 20}: Supported,
+		{1, 21}: Deprecated,
+		{1, 24}: Deprecated,
+		{1, 25}: Removed,
+		{2, 0}:  Removed,
+	} {
+		if got := d.StatusOn(cluster); got != want {
+			t.Errorf("on %s: got %v, want %v", cluster, got, want)
+		}
+	}
+}
+
+func TestLookup_CurrentVersions(t *testing.T) {
+	for _, gvk := range [][3]string{{"batch", "v1", "CronJob"}, {"apps", "v1", "Deployment"}, {"", "v1", "ConfigMap"}} {
+		if _, ok := Lookup(gvk[0], gvk[1], gvk[2]); ok {
+			t.Errorf("%v was never deprecated", gvk)
+		}
+	}
+}
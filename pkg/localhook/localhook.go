@@ -0,0 +1,75 @@
+// Package localhook runs the shell commands configured for app events,
+// passing the app's details in ARGONAUT_* environment variables.
+package localhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// outputLimit is how much of a command's output is kept for error reports
+const outputLimit = 2000
+
+// Event describes the app event a hook runs on
+type Event struct {
+	Name         string // sync-completed, app-degraded or before-delete
+	App          string
+	AppNamespace string
+	Project      string
+	Cluster      string
+	Namespace    string // destination namespace
+	Context      string
+	Server       string
+	Sync         string
+	Health       string
+	// Result and Message of the operation, for sync-completed
+	Result   string
+	Message  string
+	Revision string
+}
+
+// Env returns the event as environment variables
+func (e Event) Env() []string {
+	return []string{
+		"ARGONAUT_EVENT=" + e.Name,
+		"ARGONAUT_APP=" + e.App,
+		"ARGONAUT_APP_NAMESPACE=" + e.AppNamespace,
+		"ARGONAUT_PROJECT=" + e.Project,
+		"ARGONAUT_CLUSTER=" + e.Cluster,
+		"ARGONAUT_NAMESPACE=" + e.Namespace,
+		"ARGONAUT_CONTEXT=" + e.Context,
+		"ARGONAUT_SERVER=" + e.Server,
+		"ARGONAUT_SYNC=" + e.Sync,
+		"ARGONAUT_HEALTH=" + e.Health,
+		"ARGONAUT_RESULT=" + e.Result,
+		"ARGONAUT_MESSAGE=" + e.Message,
+		"ARGONAUT_REVISION=" + e.Revision,
+	}
+}
+
+// Run runs command with sh -c and the event added to argonaut's own
+// environment, and returns its output. The command gets no input and is
+// killed after timeout; a non-zero exit is an error.
+func Run(ctx context.Context, command string, timeout time.Duration, ev Event) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), ev.Env()...)
+	// Don't wait forever on children that keep the output open
+	cmd.WaitDelay = time.Second
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if len(output) > outputLimit {
+		output = "…" + output[len(output)-outputLimit:]
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("timed out after %s", timeout)
+	}
+	return output, err
+}
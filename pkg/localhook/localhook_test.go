@@ -0,0 +1,31 @@
+package localhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_PassesTheEventInTheEnvironment(t *testing.T) {
+	ev := Event{Name: "sync-completed", App: "payment-api", Project: "shop", Result: "Succeeded"}
+	out, err := Run(context.Background(), `echo "$ARGONAUT_EVENT $ARGONAUT_APP $ARGONAUT_PROJECT $ARGONAUT_RESULT"`, time.Minute, ev)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if out != "sync-completed payment-api shop Succeeded" {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestRun_ReportsFailures(t *testing.T) {
+	out, err := Run(context.Background(), "echo smoke test failed >&2; exit 3", time.Minute, Event{})
+	if err == nil || out != "smoke test failed" {
+		t.Errorf("expected the exit status with the output, got %q, %v", out, err)
+	}
+
+	_, err = Run(context.Background(), "sleep 5", 50*time.Millisecond, Event{})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// StatusHistoryWindow is how far back the :history view can go
+const StatusHistoryWindow = time.Hour
+
+// MaxStatusChanges caps the changes kept in the window; older ones are
+// folded into the starting point early
+const MaxStatusChanges = 5000
+
+// AppStatus is an app's sync, health and operation state at one moment
+type AppStatus struct {
+	Name         string `json:"name"`
+	AppNamespace string `json:"appNamespace,omitempty"`
+	Sync         string `json:"sync"`
+	Health       string `json:"health"`
+	Operation    string `json:"operation,omitempty"`
+}
+
+func (s AppStatus) key() string {
+	return s.AppNamespace + "/" + s.Name
+}
+
+// StatusChange is an app's status from At on, or its removal
+type StatusChange struct {
+	At      time.Time `json:"at"`
+	Status  AppStatus `json:"status"`
+	Prev    AppStatus `json:"prev"`
+	Added   bool      `json:"added,omitempty"`
+	Removed bool      `json:"removed,omitempty"`
+}
+
+// AppStatusAt is an app's status at a moment of the history and when it
+// last changed; Since is zero when it has not changed since recording began
+type AppStatusAt struct {
+	AppStatus
+	Since time.Time
+}
+
+// StatusHistory records how the status of every app changed during the
+// session, so any moment of the last StatusHistoryWindow can be shown again.
+// It keeps the statuses at Since and the changes after it.
+type StatusHistory struct {
+	since   time.Time
+	base    map[string]AppStatus
+	changes []StatusChange
+	current map[string]AppStatus
+}
+
+// Record compares the app list with the last one recorded and keeps the
+// status changes as happening at the given time
+func (h *StatusHistory) Record(at time.Time, apps []App) {
+	next := make(map[string]AppStatus, len(apps))
+	for _, app := range apps {
+		s := AppStatus{
+			Name:      app.Name,
+			Sync:      app.Sync,
+			Health:    app.Health,
+			Operation: app.OperationPhase,
+		}
+		if app.AppNamespace != nil {
+			s.AppNamespace = *app.AppNamespace
+		}
+		next[s.key()] = s
+	}
+
+	if h.current == nil {
+		h.since = at
+		h.base = next
+		h.current = next
+		return
+	}
+
+	var changes []StatusChange
+	for k, s := range next {
+		prev, ok := h.current[k]
+		if !ok {
+			changes = append(changes, StatusChange{At: at, Status: s, Added: true})
+		} else if prev != s {
+			changes = append(changes, StatusChange{At: at, Status: s, Prev: prev})
+		}
+	}
+	for k, prev := range h.current {
+		if _, ok := next[k]; !ok {
+			changes = append(changes, StatusChange{At: at, Status: prev, Prev: prev, Removed: true})
+		}
+	}
+	// Map order is random; keep a batch's changes stable for the view
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Status.key() < changes[j].Status.key() })
+	h.changes = append(h.changes, changes...)
+	h.current = next
+	h.prune(at)
+}
+
+// prune folds changes older than the window, or beyond MaxStatusChanges,
+// into the starting point
+func (h *StatusHistory) prune(now time.Time) {
+	cut := 0
+	for cut < len(h.changes) && h.changes[cut].At.Before(now.Add(-StatusHistoryWindow)) {
+		cut++
+	}
+	cut = max(cut, len(h.changes)-MaxStatusChanges)
+	if cut == 0 {
+		return
+	}
+	base := make(map[string]AppStatus, len(h.base))
+	for k, s := range h.base {
+		base[k] = s
+	}
+	for _, c := range h.changes[:cut] {
+		if c.Removed {
+			delete(base, c.Status.key())
+		} else {
+			base[c.Status.key()] = c.Status
+		}
+	}
+	h.base = base
+	h.since = h.changes[cut-1].At
+	if since := now.Add(-StatusHistoryWindow); since.After(h.since) {
+		h.since = since
+	}
+	h.changes = append([]StatusChange(nil), h.changes[cut:]...)
+}
+
+// Since is the earliest moment the history can show; zero before the
+// first recording
+func (h *StatusHistory) Since() time.Time {
+	return h.since
+}
+
+// Changes returns the recorded changes, oldest first
+func (h *StatusHistory) Changes() []StatusChange {
+	return h.changes
+}
+
+// At returns the status of every app at the given moment, sorted by name
+func (h *StatusHistory) At(t time.Time) []AppStatusAt {
+	statuses := make(map[string]AppStatusAt, len(h.base))
+	for k, s := range h.base {
+		statuses[k] = AppStatusAt{AppStatus: s}
+	}
+	for _, c := range h.changes {
+		if c.At.After(t) {
+			break
+		}
+		if c.Removed {
+			delete(statuses, c.Status.key())
+			continue
+		}
+		statuses[c.Status.key()] = AppStatusAt{AppStatus: c.Status, Since: c.At}
+	}
+
+	out := make([]AppStatusAt, 0, len(statuses))
+	for _, s := range statuses {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].AppNamespace < out[j].AppNamespace
+	})
+	return out
+}
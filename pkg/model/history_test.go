@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatusHistory_ReplaysChanges(t *testing.T) {
+	start := time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC)
+	var h StatusHistory
+	h.Record(start, []App{{Name: "api", Sync: "Synced", Health: "Healthy"}, {Name: "web", Sync: "Synced", Health: "Healthy"}})
+	h.Record(start.Add(time.Minute), []App{{Name: "api", Sync: "OutOfSync", Health: "Healthy"}, {Name: "web", Sync: "Synced", Health: "Healthy"}})
+	h.Record(start.Add(2*time.Minute), []App{{Name: "api", Sync: "Synced", Health: "Healthy"}, {Name: "db", Sync: "Synced", Health: "Missing"}})
+
+	if len(h.Changes()) != 4 {
+		t.Fatalf("expected api twice, db added and web removed, got %+v", h.Changes())
+	}
+
+	at := h.At(start.Add(90 * time.Second))
+	if len(at) != 2 || at[0].Name != "api" || at[0].Sync != "OutOfSync" || !at[0].Since.Equal(start.Add(time.Minute)) {
+		t.Fatalf("api should be OutOfSync since 14:01, got %+v", at)
+	}
+	if at[1].Name != "web" || !at[1].Since.IsZero() {
+		t.Fatalf("web has not changed since recording began, got %+v", at[1])
+	}
+
+	at = h.At(start.Add(2 * time.Minute))
+	if len(at) != 2 || at[0].Name != "api" || at[0].Sync != "Synced" || at[1].Name != "db" {
+		t.Fatalf("web should be gone and db added, got %+v", at)
+	}
+}
+
+func TestStatusHistory_ForgetsOutsideWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC)
+	var h StatusHistory
+	h.Record(start, []App{{Name: "api", Sync: "Synced"}})
+	h.Record(start.Add(time.Minute), []App{{Name: "api", Sync: "OutOfSync"}})
+	h.Record(start.Add(StatusHistoryWindow+2*time.Minute), []App{{Name: "api", Sync: "Synced"}})
+
+	if len(h.Changes()) != 1 {
+		t.Fatalf("the change before the window should be folded away, got %+v", h.Changes())
+	}
+	if want := start.Add(2 * time.Minute); !h.Since().Equal(want) {
+		t.Fatalf("history should start an hour back, at %s, got %s", want, h.Since())
+	}
+	if at := h.At(h.Since()); len(at) != 1 || at[0].Sync != "OutOfSync" {
+		t.Fatalf("the folded change should be the starting point, got %+v", at)
+	}
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	tea "charm.land/bubbletea/v2"
 	apperrors "github.com/darksworm/argonaut/pkg/errors"
 )
@@ -150,7 +152,8 @@ type SetTerminalSizeMsg struct {
 
 // SetAPIVersionMsg sets the API version
 type SetAPIVersionMsg struct {
-	Version string
+	Version     string
+	SwitchEpoch int
 }
 
 // API Event Messages - correspond to ArgoApiService events
@@ -162,10 +165,19 @@ type AppsLoadedMsg struct {
 	SwitchEpoch     int    // Context switch epoch for stale message gating
 }
 
+// CachedAppsLoadedMsg carries the last-known app list read from disk at
+// startup; it is shown until the live AppsLoadedMsg replaces it
+type CachedAppsLoadedMsg struct {
+	Apps        []App
+	SavedAt     time.Time
+	SwitchEpoch int
+}
+
 // AppUpdatedMsg is sent when an app is updated
 type AppUpdatedMsg struct {
 	App           App
 	ResourcesJSON []byte // JSON encoded []api.ResourceStatus for sync status updates
+	StreamSeq     uint64 // stream log entry of the watch event, 0 when not captured
 }
 
 // AppDeletedMsg is sent when an app is deleted (from watch stream)
@@ -198,6 +210,8 @@ type AppBatchOperation struct {
 	Type   AppBatchOperationType
 	Update *AppUpdatedMsg
 	Delete string
+	// DeleteStreamSeq is the stream log entry of a delete, 0 when not captured
+	DeleteStreamSeq uint64
 }
 
 // AppDeleteRequestMsg represents a request to delete an application
@@ -288,9 +302,10 @@ type ResourceActionExecutedMsg struct {
 
 // ResourceActionExecuteErrorMsg is sent when running a resource action fails
 type ResourceActionExecuteErrorMsg struct {
-	Target      ResourceActionTarget
-	Error       string
-	SwitchEpoch int
+	Target          ResourceActionTarget
+	Error           string
+	EndpointMissing bool // the server has no route for the v2 actions endpoint
+	SwitchEpoch     int
 }
 
 // AuthErrorMsg is sent when authentication is required
@@ -361,8 +376,11 @@ type WindowSizeMsg tea.WindowSizeMsg
 // KeyMsg wraps Bubbletea's KeyMsg
 type KeyMsg tea.KeyMsg
 
-// QuitMsg is sent to quit the application
-type QuitMsg struct{}
+// QuitMsg is sent to quit the application. Unless Force is set, quitting
+// while watched syncs or rollbacks are running asks first.
+type QuitMsg struct {
+	Force bool
+}
 
 // SetInitialLoadingMsg controls the initial loading modal display
 type SetInitialLoadingMsg struct {
@@ -416,6 +434,24 @@ type SyncCompletedMsg struct {
 	SwitchEpoch  int // Context switch epoch for stale message gating
 }
 
+// OperationConflictMsg is sent when a sync or rollback is refused because
+// another operation is already running on the app
+type OperationConflictMsg struct {
+	AppName      string
+	AppNamespace *string
+	Operation    string // what was refused: "sync" or "rollback"
+	Message      string
+	SwitchEpoch  int // Context switch epoch for stale message gating
+}
+
+// OperationTerminatedMsg is sent when terminating an app's running operation finished
+type OperationTerminatedMsg struct {
+	AppName      string
+	AppNamespace *string
+	Error        string // empty on success
+	SwitchEpoch  int    // Context switch epoch for stale message gating
+}
+
 // MultiSyncCompletedMsg indicates multiple app sync has completed
 type MultiSyncCompletedMsg struct {
 	AppCount    int
@@ -459,12 +495,14 @@ type RollbackHistoryLoadedMsg struct {
 
 // RollbackMetadataLoadedMsg is sent when git metadata is loaded for a revision
 type RollbackMetadataLoadedMsg struct {
+	AppName  string
 	RowIndex int
 	Metadata RevisionMetadata
 }
 
 // RollbackMetadataErrorMsg is sent when metadata loading fails
 type RollbackMetadataErrorMsg struct {
+	AppName  string
 	RowIndex int
 	Error    string
 }
@@ -512,6 +550,10 @@ type ResourceTreeLoadedMsg struct {
 type ResourceTreeStreamMsg struct {
 	AppName  string
 	TreeJSON []byte
+	// Seq identifies the stream the update came from
+	Seq int
+	// Ended is set instead of TreeJSON when the stream closed
+	Ended bool
 }
 
 // Update Messages - for version checking and updates
@@ -562,4 +604,125 @@ type ContextSwitchResultMsg struct {
 type AuthValidationResultMsg struct {
 	Mode        Mode
 	SwitchEpoch int
+	// CertError says what was wrong with the server's TLS certificate when
+	// verifying it is why the connection failed
+	CertError string
+}
+
+// DiffOutlineLoadedMsg is sent when an app diff spans several resources and
+// the outline picker should be shown instead of opening the pager directly
+type DiffOutlineLoadedMsg struct {
+	AppName      string
+	AppNamespace *string
+	Sections     []DiffSection
+	Notice       string // set when sections are deferred, see DiffOutlineState
+	SwitchEpoch  int
+}
+
+// HealthCustomizationsLoadedMsg carries the custom health check keys read
+// from the server's resource overrides
+type HealthCustomizationsLoadedMsg struct {
+	Keys        []string
+	SwitchEpoch int
+}
+
+// MaintenanceBannerLoadedMsg carries the org-wide notices read from
+// AppProject annotations; empty when none are set. On Err the current
+// banner is kept.
+type MaintenanceBannerLoadedMsg struct {
+	Messages    []string
+	Err         error
+	SwitchEpoch int
+}
+
+// MaintenanceBannerRefreshMsg triggers a periodic re-read of the banner
+type MaintenanceBannerRefreshMsg struct {
+	SwitchEpoch int
+}
+
+// ClusterConnectionsLoadedMsg carries the clusters Argo CD cannot reach,
+// keyed by cluster ID, with the reason it gives, and the Kubernetes version
+// of each cluster. On Err the previous result is kept.
+type ClusterConnectionsLoadedMsg struct {
+	Failed      map[string]string
+	Versions    map[string]string
+	Err         error
+	SwitchEpoch int
+}
+
+// ClusterConnectionsRefreshMsg triggers a periodic re-read of cluster
+// connection states
+type ClusterConnectionsRefreshMsg struct {
+	SwitchEpoch int
+}
+
+// PrunePreviewLoadedMsg carries what a sync with prune would delete
+type PrunePreviewLoadedMsg struct {
+	Target       string
+	AppNamespace *string
+	Resources    []PruneCandidate
+	Kept         int
+	Applied      []ResourceSyncTarget
+	Err          error
+	SwitchEpoch  int
+}
+
+// HooksLoadedMsg carries the hook resources of an app's last sync
+type HooksLoadedMsg struct {
+	AppName      string
+	AppNamespace *string
+	Hooks        []HookRun
+	Err          error
+	SwitchEpoch  int
+}
+
+// NotificationSubscriptionsMsg carries an app's notification
+// subscriptions, as loaded or, when Saved, as left by a change to them
+type NotificationSubscriptionsMsg struct {
+	AppName       string
+	AppNamespace  *string
+	Subscriptions []NotificationSubscription
+	Saved         bool
+	Err           error
+	SwitchEpoch   int
+}
+
+// HookLogsLoadedMsg carries the pod logs of a sync hook of an app
+type HookLogsLoadedMsg struct {
+	AppName      string
+	AppNamespace *string
+	Hook         HookRun
+	Logs         string
+	Err          error
+	SwitchEpoch  int
+}
+
+// PodLogsLoadedMsg carries the logs of a pod picked in the tree
+type PodLogsLoadedMsg struct {
+	Pod         string // namespace/name
+	Logs        string
+	Err         error
+	SwitchEpoch int
+}
+
+// RolloutStatusLoadedMsg carries the live status of an Argo Rollouts
+// Rollout selected in the tree. On Err the previous status is kept.
+type RolloutStatusLoadedMsg struct {
+	Key         string // rolloutKey of the resource
+	Health      string // tree health the status was read at
+	Status      *RolloutStatus
+	Err         error
+	SwitchEpoch int
+}
+
+// RevisionInfoLoadedMsg carries the git lookup for an app's synced revision:
+// its commit metadata and whether it is behind the target branch tip.
+// Commit is nil when the lookup failed.
+type RevisionInfoLoadedMsg struct {
+	AppName      string
+	AppNamespace *string
+	Revision     string // synced revision the lookup was for
+	Commit       *RevisionMetadata
+	Behind       bool
+	SwitchEpoch  int
 }
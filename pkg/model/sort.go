@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // SortField represents the field to sort applications by
 type SortField string
 
@@ -7,6 +9,9 @@ const (
 	SortFieldName   SortField = "name"
 	SortFieldSync   SortField = "sync"
 	SortFieldHealth SortField = "health"
+	// Phase and duration of the last operation, for the operation columns
+	SortFieldOperation SortField = "operation"
+	SortFieldDuration  SortField = "duration"
 )
 
 // SortDirection represents the sort direction
@@ -33,7 +38,7 @@ func DefaultSortConfig() SortConfig {
 
 // ValidSortFields returns all valid sort field values
 func ValidSortFields() []SortField {
-	return []SortField{SortFieldName, SortFieldSync, SortFieldHealth}
+	return []SortField{SortFieldName, SortFieldSync, SortFieldHealth, SortFieldOperation, SortFieldDuration}
 }
 
 // ValidSortDirections returns all valid sort direction values
@@ -75,4 +80,9 @@ type SortKey struct {
 	Sync   string
 	Kind   string
 	Name   string
+	// Phase and duration of an app's last operation; HasOperation is false
+	// for apps that never had one
+	Operation    string
+	Duration     time.Duration
+	HasOperation bool
 }
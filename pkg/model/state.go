@@ -126,12 +126,18 @@ type UIState struct {
 	ThemeScrollOffset  int             `json:"themeScrollOffset"`
 	ThemeOriginalName  string          `json:"themeOriginalName,omitempty"`
 	CommandInvalid     bool            `json:"commandInvalid"`
+	CommandMenuIdx     int             `json:"commandMenuIdx,omitempty"` // highlighted fuzzy match below the command bar
 	Sort               SortConfig      `json:"sort"`
+	StatusFilter       StatusFilter    `json:"statusFilter,omitempty"` // apps view quick filter, 1-4 keys
 	ShowWhatsNew       bool            `json:"showWhatsNew"`
 	WhatsNewShownAt    *time.Time      `json:"whatsNewShownAt,omitempty"`
-	RefreshFlashApps   map[string]bool `json:"-"` // Apps to highlight after refresh (transient)
-	RefreshFlashTree   bool            `json:"-"` // Flash tree view after refresh (transient)
-	SelectionCopied    bool            `json:"-"` // Show "Copied!" message briefly (transient)
+	WideNames          bool            `json:"wideNames"`             // :wide, the apps table's name column takes the whole width
+	DetailsPane        bool            `json:"detailsPane"`           // the selected app's details beside the apps list
+	SplitRatios        map[View]int    `json:"splitRatios,omitempty"` // percent of the width the list keeps, per split view
+	RefreshFlashApps   map[string]bool `json:"-"`                     // Apps to highlight after refresh (transient)
+	RefreshFlashTree   bool            `json:"-"`                     // Flash tree view after refresh (transient)
+	SelectionCopied    bool            `json:"-"`                     // Show "Copied!" message briefly (transient)
+	StatusNote         string          `json:"-"`                     // Short note shown briefly in the status line, e.g. "Saved x.yaml" (transient)
 }
 
 // ModalState holds modal-related state
@@ -142,6 +148,21 @@ type ModalState struct {
 	ConfirmSyncWatch       bool    `json:"confirmSyncWatch"`
 	// Which button is selected in confirm modal: 0 = Yes, 1 = Cancel
 	ConfirmSyncSelected int `json:"confirmSyncSelected"`
+	// Focus is the toggle of the open modal that Tab moved focus to; ""
+	// while the modal's selected button has focus
+	Focus string `json:"focus,omitempty"`
+	// Advanced prune options: propagation policy ("" = app default, foreground,
+	// background, orphan) and whether to prune after everything else is synced
+	ConfirmSyncPrunePolicy string `json:"confirmSyncPrunePolicy,omitempty"`
+	ConfirmSyncPruneLast   bool   `json:"confirmSyncPruneLast"`
+	// Apply with server-side apply
+	ConfirmSyncServerSideApply bool `json:"confirmSyncServerSideApply"`
+	// Label of the config sync profile that pre-populated the options, if any
+	ConfirmSyncProfile string `json:"confirmSyncProfile,omitempty"`
+	// What prune would delete, loaded while prune is on
+	ConfirmSyncPrunePreview *PrunePreview `json:"confirmSyncPrunePreview,omitempty"`
+	// Source targeted by a multi-source sync: 0 = all sources, i > 0 = 1-based source position
+	ConfirmSyncSource int `json:"confirmSyncSource"`
 	// When true, show a small syncing overlay instead of the confirm UI
 	ConfirmSyncLoading bool `json:"confirmSyncLoading"`
 	// When true, show initial loading modal overlay during app startup
@@ -180,6 +201,39 @@ type ModalState struct {
 	ResourceSyncForce           bool                 `json:"resourceSyncForce"` // Force option
 	// Resource action modal state (Rollouts promote/abort/etc. and other custom actions)
 	ResourceAction *ResourceActionState `json:"resourceAction,omitempty"`
+	// Diff outline picker state (jump to a single resource within an app diff)
+	DiffOutline *DiffOutlineState `json:"diffOutline,omitempty"`
+	// Diff summary of several selected apps
+	DiffSummary *DiffSummaryState `json:"diffSummary,omitempty"`
+	// Help page shown in help mode: "" = key bindings, "views" = views and default_view,
+	// "keys" = every key binding from the registry
+	HelpTopic string `json:"helpTopic,omitempty"`
+	// App details modal state (sources, revisions, hydrator)
+	AppDetails *AppDetailsState `json:"appDetails,omitempty"`
+	// Sync hooks modal state (hook resources from the last sync and their phases)
+	Hooks *HooksState `json:"hooks,omitempty"`
+	// Recent errors drawer state
+	Errors *ErrorsState `json:"errors,omitempty"`
+	// :stream watch event view state
+	Stream *StreamLogState `json:"stream,omitempty"`
+	// :history status timeline state
+	History *HistoryState `json:"history,omitempty"`
+	// :wait progress modal state
+	Wait *WaitState `json:"wait,omitempty"`
+	// Live manifest viewer state
+	Manifest *ManifestState `json:"manifest,omitempty"`
+	// App map state (apps grouped by cluster and namespace)
+	Map *MapState `json:"map,omitempty"`
+	// Project or ApplicationSet refresh progress modal state
+	BulkRefresh *BulkRefreshState `json:"bulkRefresh,omitempty"`
+	// Release train of staged per-app actions
+	Staging *StagingState `json:"staging,omitempty"`
+	// Terminal capability check state
+	TermCheck *TermCheckState `json:"termCheck,omitempty"`
+	// Dialog shown when a sync or rollback hits an operation already in progress
+	OperationConflict *OperationConflictState `json:"operationConflict,omitempty"`
+	// Dialog shown when quitting while watched operations are running
+	QuitConfirm *QuitConfirmState `json:"quitConfirm,omitempty"`
 	// Changelog loading modal state
 	ChangelogLoading bool `json:"changelogLoading"`
 	// K9s error modal state
@@ -201,6 +255,16 @@ type AppState struct {
 	Index        *AppIndex       `json:"-"` // Pre-computed index, rebuilt on mutation
 	APIVersion   string          `json:"apiVersion"`
 	ContextNames []string        `json:"contextNames,omitempty"`
+	// Resource kinds with custom Lua health checks (from /api/v1/settings)
+	HealthCustomizations HealthCustomizations `json:"healthCustomizations,omitempty"`
+	// Org-wide notices from AppProject annotations, shown above the header
+	MaintenanceBanner []string `json:"maintenanceBanner,omitempty"`
+	// Clusters Argo CD cannot reach, by cluster ID, with the reason; apps
+	// deployed to them stop reconciling
+	FailedClusters map[string]string `json:"failedClusters,omitempty"`
+	// Kubernetes version of each cluster by cluster ID, e.g. "1.29", for
+	// flagging apps that deploy deprecated API versions
+	ClusterVersions map[string]string `json:"clusterVersions,omitempty"`
 	// Note: AbortController equivalent will use context.Context in Go services
 	Diff     *DiffState     `json:"diff,omitempty"`
 	Rollback *RollbackState `json:"rollback,omitempty"`
@@ -210,6 +274,22 @@ type AppState struct {
 	// Store current error information for error screen display
 	CurrentError *ApiError   `json:"currentError,omitempty"` // DEPRECATED: Use ErrorState
 	ErrorState   *ErrorState `json:"errorState,omitempty"`
+	// Errors shown in the :errors drawer, oldest first
+	RecentErrors []RecentError `json:"recentErrors,omitempty"`
+	// App status changes seen this session, for the :history view
+	History StatusHistory `json:"-"`
+}
+
+// MaxRecentErrors is how many errors the :errors drawer keeps
+const MaxRecentErrors = 50
+
+// RecordError adds an error to the :errors drawer, dropping the oldest
+// once MaxRecentErrors are kept
+func (s *AppState) RecordError(e RecentError) {
+	s.RecentErrors = append(s.RecentErrors, e)
+	if over := len(s.RecentErrors) - MaxRecentErrors; over > 0 {
+		s.RecentErrors = append([]RecentError(nil), s.RecentErrors[over:]...)
+	}
 }
 
 // ApiError holds structured error information for display - DEPRECATED: Use ErrorState
@@ -0,0 +1,25 @@
+package model
+
+import "strings"
+
+// StatusFilter narrows the apps view to apps in one sync or health status
+type StatusFilter string
+
+const (
+	StatusFilterAll         StatusFilter = ""
+	StatusFilterOutOfSync   StatusFilter = "OutOfSync"
+	StatusFilterDegraded    StatusFilter = "Degraded"
+	StatusFilterProgressing StatusFilter = "Progressing"
+)
+
+// Matches reports whether the app passes the filter
+func (f StatusFilter) Matches(app App) bool {
+	switch f {
+	case StatusFilterAll:
+		return true
+	case StatusFilterOutOfSync:
+		return strings.EqualFold(app.Sync, string(f))
+	default:
+		return strings.EqualFold(app.Health, string(f))
+	}
+}
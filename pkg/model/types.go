@@ -1,6 +1,8 @@
 package model
 
 import (
+	"path"
+	"strings"
 	"time"
 )
 
@@ -46,7 +48,25 @@ const (
 	ModeK9sError              Mode = "k9s-error"
 	ModeConfirmResourceSync   Mode = "confirm-resource-sync"
 	ModeDefaultViewWarning    Mode = "default-view-warning"
+	ModeCompatWarning         Mode = "compat-warning"
+	ModeConfirmQuit           Mode = "confirm-quit"
 	ModeResourceAction        Mode = "resource-action"
+	ModeDiffOutline           Mode = "diff-outline"
+	ModeAppDetails            Mode = "app-details"
+	ModeHooks                 Mode = "hooks"
+	ModeErrors                Mode = "errors"
+	ModeWait                  Mode = "wait"
+	ModeOperationConflict     Mode = "operation-conflict"
+	ModeStream                Mode = "stream"
+	ModeBulkRefresh           Mode = "bulk-refresh"
+	ModeManifest              Mode = "manifest"
+	ModeMap                   Mode = "map"
+	ModeHistory               Mode = "history"
+	ModeDiffSummary           Mode = "diff-summary"
+	ModeProfile               Mode = "profile"
+	ModeDeviceLogin           Mode = "device-login"
+	ModeStaging               Mode = "staging"
+	ModeTermCheck             Mode = "termcheck"
 )
 
 // App represents an ArgoCD application
@@ -61,11 +81,101 @@ type App struct {
 	Namespace      *string    `json:"namespace,omitempty"`
 	AppNamespace   *string    `json:"appNamespace,omitempty"`
 	ApplicationSet *string    `json:"applicationSet,omitempty"`
+	// Sources lists every application source in spec order; MultiSource is
+	// set when the app uses spec.sources rather than spec.source
+	Sources     []AppSource  `json:"sources,omitempty"`
+	MultiSource bool         `json:"multiSource,omitempty"`
+	Hydrator    *AppHydrator `json:"hydrator,omitempty"`
+	// Who started the last operation (sync, rollback) on the app
+	LastOperationBy *OperationInitiator `json:"lastOperationBy,omitempty"`
+	// Phase (Running, Succeeded, Failed, Error) and message of the last
+	// operation, and when it started
+	OperationPhase     string     `json:"operationPhase,omitempty"`
+	OperationMessage   string     `json:"operationMessage,omitempty"`
+	OperationStartedAt *time.Time `json:"operationStartedAt,omitempty"`
+	// Kinds the app deploys at API versions Kubernetes deprecated in some
+	// release (see pkg/kubeapi), whether or not its cluster is there yet
+	DeprecatedAPIs []ResourceAPI `json:"deprecatedAPIs,omitempty"`
 }
 
-// SortKey returns the values used for semantic ordering of apps.
-func (a App) SortKey() SortKey {
-	return SortKey{Health: a.Health, Sync: a.Sync, Name: a.Name}
+// ResourceAPI is a kind at one API version, and how many of the app's
+// resources use it
+type ResourceAPI struct {
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Count   int    `json:"count"`
+}
+
+// OperationInitiator records who started an operation
+type OperationInitiator struct {
+	Username  string `json:"username,omitempty"`
+	Automated bool   `json:"automated,omitempty"`
+}
+
+// String describes the initiator, e.g. "alice" or "automation"
+func (i OperationInitiator) String() string {
+	switch {
+	case i.Automated && i.Username != "":
+		return i.Username + " (automated)"
+	case i.Automated:
+		return "automation"
+	case i.Username != "":
+		return i.Username
+	default:
+		return "unknown"
+	}
+}
+
+// AppSource is one application source with the revision it is synced to
+type AppSource struct {
+	RepoURL        string `json:"repoURL,omitempty"`
+	Path           string `json:"path,omitempty"`
+	Chart          string `json:"chart,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+	Ref            string `json:"ref,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Revision       string `json:"revision,omitempty"` // synced revision, when known
+}
+
+// AppHydrator holds the source hydrator settings of an app
+type AppHydrator struct {
+	DryRepoURL      string `json:"dryRepoURL,omitempty"`
+	DryRevision     string `json:"dryRevision,omitempty"`
+	DryPath         string `json:"dryPath,omitempty"`
+	SyncBranch      string `json:"syncBranch,omitempty"`
+	SyncPath        string `json:"syncPath,omitempty"`
+	HydrateToBranch string `json:"hydrateToBranch,omitempty"`
+}
+
+// SortKey returns the values used for semantic ordering of apps; running
+// operations are timed up to now.
+func (a App) SortKey(now time.Time) SortKey {
+	took, hasOp := a.OperationDuration(now)
+	return SortKey{Health: a.Health, Sync: a.Sync, Name: a.Name, Operation: a.OperationPhase, Duration: took, HasOperation: hasOp}
+}
+
+// OperationRunning reports whether the app's last operation is still in
+// progress
+func (a App) OperationRunning() bool {
+	return a.OperationPhase == "Running" || a.OperationPhase == "Terminating"
+}
+
+// OperationDuration returns how long the app's last operation took, or has
+// taken so far while it runs; false when the app has no operation
+func (a App) OperationDuration(now time.Time) (time.Duration, bool) {
+	if a.OperationStartedAt == nil {
+		return 0, false
+	}
+	end := now
+	if !a.OperationRunning() {
+		// LastSyncAt is the operation's finishedAt once it has one
+		if a.LastSyncAt == nil {
+			return 0, true
+		}
+		end = *a.LastSyncAt
+	}
+	return max(0, end.Sub(*a.OperationStartedAt)), true
 }
 
 // Server represents an ArgoCD server configuration
@@ -184,6 +294,12 @@ type RollbackRow struct {
 	Date       *time.Time `json:"date"`       // Git commit date
 	Message    *string    `json:"message"`    // Git commit message
 	MetaError  *string    `json:"metaError"`  // Error loading metadata
+	// Multi-source deployments: all source revisions, and the index of the
+	// source Revision was taken from (nil for single-source apps)
+	Revisions   []string `json:"revisions,omitempty"`
+	SourceIndex *int     `json:"sourceIndex,omitempty"`
+	// Who triggered the deployment, when the history records it
+	InitiatedBy *OperationInitiator `json:"initiatedBy,omitempty"`
 }
 
 // RollbackState holds the state for rollback operations
@@ -200,6 +316,12 @@ type RollbackState struct {
 	Watch           bool          `json:"watch"`           // Watch option after rollback
 	DryRun          bool          `json:"dryRun"`          // Dry run option (not shown in confirm view)
 	ConfirmSelected int           `json:"confirmSelected"` // 0 = Yes, 1 = No/Cancel
+	Filter          string        `json:"filter"`          // Narrows the history list, "/" to edit
+	Filtering       bool          `json:"filtering"`       // Keys go to the filter rather than the list
+	// Rows whose metadata was requested: the rows on screen, and the rest
+	// of the history while a filter is set
+	MetaRequested map[int]bool `json:"-"`
+	MetaPending   int          `json:"-"` // Metadata requests in flight
 }
 
 // RevisionMetadata represents git commit metadata for a revision
@@ -288,3 +410,393 @@ type ResourceActionState struct {
 	Executing bool   `json:"executing"`
 	Error     string `json:"error"`
 }
+
+// DiffSection is a single changed resource within an application diff
+type DiffSection struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Live and Desired hold the cleaned YAML for each side. An empty Live
+	// means the resource will be created; an empty Desired means it will be pruned.
+	Live    string `json:"live,omitempty"`
+	Desired string `json:"desired,omitempty"`
+	// Deferred sections carry no YAML yet; it is loaded when the section is
+	// opened because the app's full diff was too large to load at once
+	Deferred bool `json:"deferred,omitempty"`
+}
+
+// DiffOutlineState holds the state for the diff resource outline picker
+type DiffOutlineState struct {
+	AppName      string        `json:"appName"`
+	AppNamespace *string       `json:"appNamespace,omitempty"`
+	Sections     []DiffSection `json:"sections"`
+	// SelectedIdx indexes the picker rows: 0 is "all resources", i>0 is Sections[i-1]
+	SelectedIdx int `json:"selectedIdx"`
+	// Notice explains why sections are deferred; empty for a normal outline
+	Notice string `json:"notice,omitempty"`
+}
+
+// AppDetailsState identifies the app shown in the details modal. The app
+// itself is resolved from AppState on render so live updates show through.
+type AppDetailsState struct {
+	AppName      string  `json:"appName"`
+	AppNamespace *string `json:"appNamespace,omitempty"`
+
+	// Notification subscriptions are read from the app's annotations when
+	// the modal opens, since the apps list leaves annotations out
+	Subscriptions        []NotificationSubscription `json:"subscriptions,omitempty"`
+	SubscriptionsLoading bool                       `json:"subscriptionsLoading,omitempty"`
+	SubscriptionsError   string                     `json:"subscriptionsError,omitempty"`
+	SubscriptionIdx      int                        `json:"subscriptionIdx,omitempty"`
+	// SubscriptionInput holds a new subscription being typed, nil otherwise
+	SubscriptionInput *string `json:"subscriptionInput,omitempty"`
+	ConfirmRemove     bool    `json:"confirmRemove,omitempty"`
+	Saving            bool    `json:"saving,omitempty"`
+}
+
+// NotificationSubscription is one recipient of an app's Argo CD
+// notifications, read from its notifications.argoproj.io/subscribe.*
+// annotations. An empty Trigger means the service's default triggers.
+type NotificationSubscription struct {
+	Trigger   string `json:"trigger,omitempty"`
+	Service   string `json:"service"`
+	Recipient string `json:"recipient"`
+}
+
+// HookRun is a sync hook resource from an app's last sync operation
+type HookRun struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	HookType  string `json:"hookType"`            // PreSync, Sync, PostSync, SyncFail, PostDelete
+	Phase     string `json:"phase"`               // Running, Succeeded, Failed, Error, Terminating
+	Message   string `json:"message,omitempty"`   // why the hook failed, if it did
+	SyncPhase string `json:"syncPhase,omitempty"` // sync phase the hook ran in
+}
+
+// HasLogs reports whether the hook runs pods whose logs can be read
+func (h HookRun) HasLogs() bool {
+	return (h.Group == "batch" && h.Kind == "Job") || (h.Group == "" && h.Kind == "Pod")
+}
+
+// HooksState holds the state for the sync hooks modal
+type HooksState struct {
+	AppName      string    `json:"appName"`
+	AppNamespace *string   `json:"appNamespace,omitempty"`
+	Hooks        []HookRun `json:"hooks"`
+	SelectedIdx  int       `json:"selectedIdx"`
+	Loading      bool      `json:"loading"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// PruneCandidate is a live resource no longer in git, which a sync with
+// prune would delete
+type PruneCandidate struct {
+	AppName   string `json:"appName"`
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Dependents are the live resources Kubernetes garbage-collects with it
+	Dependents []PruneDependent `json:"dependents,omitempty"`
+	// ClaimsDeleted is set for a StatefulSet whose volume claims are deleted
+	// with it (persistentVolumeClaimRetentionPolicy whenDeleted: Delete)
+	ClaimsDeleted bool `json:"claimsDeleted,omitempty"`
+	// Excluded resources are left out of the sync so they are not pruned
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+// PruneDependent is a live resource owned by a prune candidate, directly
+// (Depth 1) or through the resources between them
+type PruneDependent struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Depth     int    `json:"depth"`
+}
+
+// PrunePreview holds what a sync with prune would delete, for the sync modal
+type PrunePreview struct {
+	Target       string           `json:"target"` // ConfirmTarget the preview was computed for
+	AppNamespace *string          `json:"appNamespace,omitempty"`
+	Resources    []PruneCandidate `json:"resources"`
+	// Kept counts resources missing from git that Prune=false protects
+	Kept    int    `json:"kept"`
+	Loading bool   `json:"loading"`
+	Error   string `json:"error,omitempty"`
+	// Applied lists the app's resources in git. A sync that excludes
+	// resources from prune names these and the candidates left in.
+	Applied []ResourceSyncTarget `json:"applied,omitempty"`
+	// Picking is true while resources are being excluded, with the cursor
+	// on SelectedIdx
+	Picking     bool `json:"picking,omitempty"`
+	SelectedIdx int  `json:"selectedIdx,omitempty"`
+	// SelectiveWarned is set by a confirm with resources excluded, which
+	// only warns that the sync is selective; the next confirm syncs
+	SelectiveWarned bool `json:"selectiveWarned,omitempty"`
+}
+
+// Conditions a :wait can wait for
+const (
+	WaitSynced    = "synced"
+	WaitHealthy   = "healthy"
+	WaitOperation = "operation" // no sync or rollback in progress
+)
+
+// WaitState holds a :wait for an app to reach its conditions
+type WaitState struct {
+	AppName      string        `json:"appName"`
+	AppNamespace *string       `json:"appNamespace,omitempty"`
+	Conditions   []string      `json:"conditions"`
+	StartedAt    time.Time     `json:"startedAt"`
+	Timeout      time.Duration `json:"timeout"`
+	// ID tells this wait's ticks apart from those of an earlier wait
+	ID int `json:"id"`
+	// Set once the wait is over: "met", "failed" or "timeout"
+	Result     string    `json:"result,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// ManifestState holds the state for the live manifest viewer; the
+// document and its folds live in the viewer itself
+type ManifestState struct {
+	Title string `json:"title"`
+	// Resource is the key of the resource shown, to match its load to it
+	Resource string `json:"resource"`
+	Loading  bool   `json:"loading"`
+	// Copied is the path last copied to the clipboard, shown until the next key
+	Copied string `json:"copied,omitempty"`
+}
+
+// BulkRefreshState holds the progress of refreshing every app of a project
+// or ApplicationSet
+type BulkRefreshState struct {
+	Scope string `json:"scope"` // "project" or "ApplicationSet"
+	Name  string `json:"name"`
+	Hard  bool   `json:"hard"`
+	Apps  []App  `json:"-"` // refreshed in order
+	Done  int    `json:"done"`
+	// Failed lists the apps whose refresh request failed
+	Failed []string `json:"failed,omitempty"`
+	// ID tells this run's steps apart from those of an earlier one
+	ID       int  `json:"id"`
+	Finished bool `json:"finished"`
+}
+
+// Actions a staged step can take on its app
+const (
+	StageSync     = "sync"
+	StageRefresh  = "refresh"
+	StageRollback = "rollback" // to the deployment before the current one
+	StageSkip     = "skip"
+)
+
+// Progress of a staged step
+const (
+	StepPending = ""
+	StepRunning = "running"
+	StepDone    = "done"
+	StepFailed  = "failed"
+	StepSkipped = "skipped"
+)
+
+// StagingState holds a release train: an action per app, edited like an
+// interactive rebase todo list and then run one step after the other
+type StagingState struct {
+	Steps       []StagedStep `json:"steps"`
+	SelectedIdx int          `json:"selectedIdx"`
+	// Next is the step to run next; the steps before it have run
+	Next    int  `json:"next"`
+	Running bool `json:"running"`
+	// ID tells this run's steps apart from those of an earlier one
+	ID int `json:"id"`
+}
+
+// StagedStep is one app of a release train and what to do with it
+type StagedStep struct {
+	Name         string  `json:"name"`
+	AppNamespace *string `json:"appNamespace,omitempty"`
+	Action       string  `json:"action"`
+	Status       string  `json:"status,omitempty"`
+	// Message is the rollback target once known, or why the step failed
+	Message   string    `json:"message,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	// Deadline is when a sync or rollback that has not finished fails the
+	// step, on the wall clock
+	Deadline time.Time `json:"deadline"`
+	// Operation start seen before the step; a sync or rollback is done once
+	// an operation started at another time has finished
+	PrevOperationAt *time.Time `json:"prevOperationAt,omitempty"`
+}
+
+// TermCheckState holds the terminal's replies to the :termcheck probes
+type TermCheckState struct {
+	// Probing is set until every probe was answered or the wait timed out
+	Probing bool `json:"probing"`
+	// Version is the terminal's name and version, if it reports one
+	Version string `json:"version,omitempty"`
+	// CursorX is the column the cursor ended up on after the width sample
+	CursorX *int `json:"cursorX,omitempty"`
+	// Modes holds the reported setting of each queried DEC mode, such as
+	// "set" or "not recognized"
+	Modes map[int]string `json:"modes"`
+	// ID tells this check's replies apart from those of an earlier one
+	ID int `json:"id"`
+}
+
+// DiffSummaryState holds the diff summary of several selected apps, whose
+// diffs load a few at a time
+type DiffSummaryState struct {
+	Apps        []DiffSummaryApp `json:"apps"`    // sorted by name
+	Started     int              `json:"started"` // apps whose diff was requested, in order
+	SelectedIdx int              `json:"selectedIdx"`
+	// ID tells this summary's results apart from those of an earlier one
+	ID int `json:"id"`
+}
+
+// DiffSummaryApp is one app of the diff summary and how many of its
+// resources the sync would change, create and prune
+type DiffSummaryApp struct {
+	Name         string  `json:"name"`
+	AppNamespace *string `json:"appNamespace,omitempty"`
+	Loaded       bool    `json:"loaded"`
+	Changed      int     `json:"changed"`
+	Created      int     `json:"created"`
+	Pruned       int     `json:"pruned"`
+	// TooLarge is set when the full diff exceeded the response size limit;
+	// its resources can still be opened one at a time
+	TooLarge bool   `json:"tooLarge,omitempty"`
+	Err      string `json:"err,omitempty"`
+	// Sections is the loaded diff, opened without another request
+	Sections []DiffSection `json:"-"`
+}
+
+// OperationConflictState holds the dialog shown when a sync or rollback
+// was refused because another operation is running on the app
+type OperationConflictState struct {
+	AppName      string  `json:"appName"`
+	AppNamespace *string `json:"appNamespace,omitempty"`
+	Operation    string  `json:"operation"` // what was refused: "sync" or "rollback"
+	Message      string  `json:"message"`
+	Terminating  bool    `json:"terminating"`
+	Error        string  `json:"error,omitempty"` // terminate failure
+	// Terminating asks first; ConfirmSelected is 0 = Terminate, 1 = Cancel
+	ConfirmTerminate bool `json:"confirmTerminate,omitempty"`
+	ConfirmSelected  int  `json:"confirmSelected"`
+}
+
+// QuitConfirmState holds the dialog shown when quitting while syncs or
+// rollbacks started with Watch are still running
+type QuitConfirmState struct {
+	Operations []QuitOperation `json:"operations"`
+	PrevMode   Mode            `json:"prevMode"` // restored on keep watching
+}
+
+// QuitOperation is a watched operation still running at quit
+type QuitOperation struct {
+	Kind         string  `json:"kind"` // "sync" or "rollback"
+	AppName      string  `json:"appName"`
+	AppNamespace *string `json:"appNamespace,omitempty"`
+	Phase        string  `json:"phase"` // operation phase, or Pending before the watch shows it
+}
+
+// RecentError is one entry of the recent errors drawer (:errors)
+type RecentError struct {
+	At      time.Time `json:"at"`
+	Source  string    `json:"source"` // what failed: "sync", "refresh", "watch", ...
+	Message string    `json:"message"`
+	Details string    `json:"details,omitempty"`
+	// Retry re-runs the failed operation; nil when it cannot be retried
+	Retry *RetryOperationMsg `json:"retry,omitempty"`
+}
+
+// MapState holds the state of the app map. The cursor is kept by app, so
+// it stays on the same cell when live updates move the others.
+type MapState struct {
+	App          string `json:"app"`
+	AppNamespace string `json:"appNamespace,omitempty"`
+	Offset       int    `json:"offset"` // first map row shown
+}
+
+// ErrorsState holds the state for the recent errors drawer
+type ErrorsState struct {
+	SelectedIdx int `json:"selectedIdx"` // index into the newest-first list
+}
+
+// StreamLogState holds the state for the :stream watch event view
+type StreamLogState struct {
+	SelectedIdx int `json:"selectedIdx"` // index into the newest-first list
+}
+
+// HistoryState holds the state for the :history status timeline
+type HistoryState struct {
+	At          *time.Time `json:"at,omitempty"` // moment shown; nil follows the live state
+	ShowAll     bool       `json:"showAll"`      // list every app, not only those out of sync or unhealthy
+	SelectedIdx int        `json:"selectedIdx"`  // index into the listed apps
+	Copied      bool       `json:"copied"`       // the listed apps were just copied
+}
+
+// HealthSource describes where a resource's health assessment comes from
+type HealthSource string
+
+const (
+	// HealthSourceCustom is a Lua health check from argocd-cm resource customizations
+	HealthSourceCustom HealthSource = "custom"
+	// HealthSourceBuiltIn is one of Argo CD's bundled health checks
+	HealthSourceBuiltIn HealthSource = "built-in"
+	// HealthSourceNone means Argo CD does not assess health for the resource kind
+	HealthSourceNone HealthSource = "none"
+)
+
+// HealthCustomizations lists argocd-cm resource override keys that define a
+// custom Lua health check. Keys are "group/Kind" ("Kind" for the core group)
+// and may contain glob wildcards such as "*.crossplane.io/*".
+type HealthCustomizations []string
+
+// Matches reports whether a custom health check applies to the given group/kind
+func (h HealthCustomizations) Matches(group, kind string) bool {
+	key := kind
+	if group != "" {
+		key = group + "/" + kind
+	}
+	for _, pattern := range h {
+		if pattern == key {
+			return true
+		}
+		if ok, err := path.Match(pattern, key); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Source resolves the health source for a resource given its reported health
+func (h HealthCustomizations) Source(group, kind, health string) HealthSource {
+	if h.Matches(group, kind) {
+		return HealthSourceCustom
+	}
+	if strings.TrimSpace(health) == "" {
+		return HealthSourceNone
+	}
+	return HealthSourceBuiltIn
+}
+
+// RolloutStatus is the progress of an Argo Rollouts Rollout
+type RolloutStatus struct {
+	Strategy string `json:"strategy"` // canary or blueGreen
+	Phase    string `json:"phase,omitempty"`
+	Message  string `json:"message,omitempty"`
+	// Step is the 1-based canary step being run; Steps is 0 when the
+	// strategy has no steps. Step is Steps+1 once every step has run.
+	Step  int `json:"step,omitempty"`
+	Steps int `json:"steps,omitempty"`
+	// Weight is the percentage of traffic sent to the canary
+	Weight       int      `json:"weight"`
+	Paused       bool     `json:"paused"`
+	PauseReasons []string `json:"pauseReasons,omitempty"`
+	Aborted      bool     `json:"aborted"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestHealthCustomizations_Matches(t *testing.T) {
+	h := HealthCustomizations{"argoproj.io/Rollout", "Secret", "*.crossplane.io/*"}
+	cases := []struct {
+		group, kind string
+		want        bool
+	}{
+		{"argoproj.io", "Rollout", true},
+		{"argoproj.io", "Workflow", false},
+		{"", "Secret", true},
+		{"", "ConfigMap", false},
+		{"s3.aws.crossplane.io", "Bucket", true},
+		{"apps", "Deployment", false},
+	}
+	for _, c := range cases {
+		if got := h.Matches(c.group, c.kind); got != c.want {
+			t.Errorf("Matches(%q, %q) = %v, want %v", c.group, c.kind, got, c.want)
+		}
+	}
+}
+
+func TestHealthCustomizations_Source(t *testing.T) {
+	h := HealthCustomizations{"argoproj.io/Rollout"}
+	if got := h.Source("argoproj.io", "Rollout", ""); got != HealthSourceCustom {
+		t.Errorf("custom check without a reported health should still be custom, got %q", got)
+	}
+	if got := h.Source("apps", "Deployment", "Healthy"); got != HealthSourceBuiltIn {
+		t.Errorf("expected built-in, got %q", got)
+	}
+	if got := h.Source("", "ConfigMap", ""); got != HealthSourceNone {
+		t.Errorf("expected none, got %q", got)
+	}
+}
+
+func TestOperationInitiator_String(t *testing.T) {
+	cases := []struct {
+		in   OperationInitiator
+		want string
+	}{
+		{OperationInitiator{Username: "alice"}, "alice"},
+		{OperationInitiator{Automated: true}, "automation"},
+		{OperationInitiator{Username: "ci-bot", Automated: true}, "ci-bot (automated)"},
+		{OperationInitiator{}, "unknown"},
+	}
+	for _, c := range cases {
+		if got := c.in.String(); got != c.want {
+			t.Errorf("%+v.String() = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package notify posts outbound messages about finished syncs to a
+// webhook (Slack, Microsoft Teams or a generic JSON receiver).
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplate is used when no template is configured
+const DefaultTemplate = `{{.Emoji}} Sync of {{.App}}{{if .Revision}} to {{.ShortRevision}}{{end}} {{.Verb}}{{if .Duration}} after {{.Duration}}{{end}}{{if .Message}}: {{.Message}}{{end}}`
+
+// Event describes a finished sync
+type Event struct {
+	App       string `json:"app"`
+	Namespace string `json:"namespace,omitempty"`
+	Project   string `json:"project,omitempty"`
+	Context   string `json:"context,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Result    string `json:"result"` // operation phase: Succeeded, Failed or Error
+	Message   string `json:"message,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+}
+
+// Succeeded reports whether the sync finished successfully
+func (e Event) Succeeded() bool { return e.Result == "Succeeded" }
+
+// Verb is "succeeded" or "failed", for templates
+func (e Event) Verb() string {
+	if e.Succeeded() {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// Emoji is a result marker for chat templates
+func (e Event) Emoji() string {
+	if e.Succeeded() {
+		return "✅"
+	}
+	return "❌"
+}
+
+// ShortRevision is the first 8 characters of the revision
+func (e Event) ShortRevision() string {
+	return e.Revision[:min(8, len(e.Revision))]
+}
+
+// FormatDuration renders a sync duration the way templates show it
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return d.Round(time.Second).String()
+}
+
+// Webhook is a configured notification target
+type Webhook struct {
+	URL      string
+	Format   string // "slack", "teams" or "generic" (default)
+	Template string // text/template over Event; DefaultTemplate when empty
+	Client   *http.Client
+}
+
+// Render expands the webhook template for ev
+func (w Webhook) Render(ev Event) (string, error) {
+	text := w.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid notification template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ev); err != nil {
+		return "", fmt.Errorf("failed to render notification: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// payload builds the request body for the webhook format
+func (w Webhook) payload(ev Event, text string) any {
+	switch strings.ToLower(w.Format) {
+	case "slack":
+		return map[string]string{"text": text}
+	case "teams":
+		return map[string]string{"@type": "MessageCard", "@context": "https://schema.org/extensions", "text": text}
+	default:
+		return struct {
+			Event
+			Text string `json:"text"`
+		}{ev, text}
+	}
+}
+
+// Send posts the rendered notification for ev
+func (w Webhook) Send(ctx context.Context, ev Event) error {
+	text, err := w.Render(ev)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(w.payload(ev, text))
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", withoutURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", withoutURL(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// withoutURL drops the webhook URL from a request error. The URL carries the
+// webhook's token (Slack and Teams put it in the path), and the error ends
+// up in the log and the status bar.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
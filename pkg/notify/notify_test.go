@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender_DefaultTemplate(t *testing.T) {
+	ev := Event{App: "web", Revision: "0123456789abcdef", Result: "Succeeded", Duration: FormatDuration(42 * time.Second)}
+	got, err := Webhook{}.Render(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "✅ Sync of web to 01234567 succeeded after 42s" {
+		t.Errorf("Render() = %q", got)
+	}
+
+	ev = Event{App: "web", Result: "Failed", Message: "one or more objects failed to apply"}
+	got, _ = Webhook{}.Render(ev)
+	if got != "❌ Sync of web failed: one or more objects failed to apply" {
+		t.Errorf("Render() = %q", got)
+	}
+
+	if _, err := (Webhook{Template: "{{.Nope"}).Render(ev); err == nil {
+		t.Error("expected template parse error")
+	}
+}
+
+func TestSend_Formats(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		got = nil
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+	ev := Event{App: "web", Result: "Succeeded"}
+
+	for format, key := range map[string]string{"slack": "text", "teams": "@type", "": "app"} {
+		w := Webhook{URL: srv.URL, Format: format, Template: "{{.App}} {{.Verb}}"}
+		if err := w.Send(context.Background(), ev); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if _, ok := got[key]; !ok {
+			t.Errorf("%s payload missing %q: %v", format, key, got)
+		}
+		if got["text"] != "web succeeded" {
+			t.Errorf("%s payload text = %v", format, got["text"])
+		}
+	}
+}
+
+func TestSend_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	err := Webhook{URL: srv.URL}.Send(context.Background(), Event{App: "web", Result: "Failed"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Fatalf("expected webhook error, got %v", err)
+	}
+}
+
+func TestSend_ErrorHidesURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+	for _, hookURL := range []string{srv.URL + "/services/T000/B000/s3cr3t", "http://hooks.example.com/s3cr3t\x7f"} {
+		err := Webhook{URL: hookURL}.Send(context.Background(), Event{App: "web", Result: "Failed"})
+		if err == nil {
+			t.Fatalf("expected an error for %q", hookURL)
+		}
+		if strings.Contains(err.Error(), "s3cr3t") {
+			t.Errorf("the error must not show the webhook URL: %v", err)
+		}
+	}
+}
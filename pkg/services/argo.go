@@ -30,11 +30,15 @@ type ArgoApiService interface {
 	WatchApplicationsWithOptions(ctx context.Context, server *model.Server, opts *api.WatchOptions) (<-chan ArgoApiEvent, func(), error)
 
 	// SyncApplication syncs a specific application
-	SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, prune bool) error
+	SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, opts SyncOptions) error
 
 	// GetResourceDiffs gets resource diffs for an application
 	GetResourceDiffs(ctx context.Context, server *model.Server, appName string, appNamespace *string) ([]ResourceDiff, error)
 
+	// GetResourceDiff gets the diff of a single resource of an application;
+	// nil when the app does not manage it
+	GetResourceDiff(ctx context.Context, server *model.Server, appName string, appNamespace *string, key api.ManagedResourceKey) (*ResourceDiff, error)
+
 	// GetAPIVersion fetches the ArgoCD API server version string
 	GetAPIVersion(ctx context.Context, server *model.Server) (string, error)
 
@@ -44,6 +48,12 @@ type ArgoApiService interface {
 	// GetRevisionMetadata fetches git metadata for a specific revision
 	GetRevisionMetadata(ctx context.Context, server *model.Server, appName string, revision string, appNamespace *string) (*model.RevisionMetadata, error)
 
+	// ResolveRevision returns the commit SHA a branch, tag or HEAD of an app's source points at
+	ResolveRevision(ctx context.Context, server *model.Server, appName string, revision string, appNamespace *string) (string, error)
+
+	// GetRevisionMetadataForSource fetches git metadata for one source of a multi-source app
+	GetRevisionMetadataForSource(ctx context.Context, server *model.Server, appName string, revision string, appNamespace *string, sourceIndex int, versionID int) (*model.RevisionMetadata, error)
+
 	// RollbackApplication performs a rollback operation
 	RollbackApplication(ctx context.Context, server *model.Server, request model.RollbackRequest) error
 
@@ -57,6 +67,18 @@ type ArgoApiService interface {
 	Cleanup()
 }
 
+// SyncOptions holds the user-selected options for an application sync
+type SyncOptions struct {
+	Prune bool
+	// PruneLast prunes only after all other resources are synced and healthy
+	PruneLast bool
+	// PrunePropagationPolicy is foreground, background or orphan; empty keeps the app's setting
+	PrunePropagationPolicy string
+	ServerSideApply        bool
+	// Resources limits the sync to the given resources; nil syncs them all
+	Resources []api.SyncResourceTarget
+}
+
 // ArgoApiEvent represents events from the ArgoCD API
 type ArgoApiEvent struct {
 	Type      string               `json:"type"`
@@ -66,6 +88,7 @@ type ArgoApiEvent struct {
 	Error     error                `json:"error,omitempty"`
 	Status    string               `json:"status,omitempty"`
 	Resources []api.ResourceStatus `json:"resources,omitempty"` // Resource sync statuses for tree view
+	StreamSeq uint64               `json:"-"`                   // stream log entry of the event, 0 when not captured
 }
 
 // ResourceDiff represents a resource difference
@@ -192,7 +215,7 @@ func (s *ArgoApiServiceImpl) WatchApplicationsWithOptions(ctx context.Context, s
 }
 
 // SyncApplication implements ArgoApiService.SyncApplication
-func (s *ArgoApiServiceImpl) SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, prune bool) error {
+func (s *ArgoApiServiceImpl) SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, syncOpts SyncOptions) error {
 	if server == nil {
 		return apperrors.ConfigError("SERVER_MISSING",
 			"Server configuration is required").
@@ -217,8 +240,12 @@ func (s *ArgoApiServiceImpl) SyncApplication(ctx context.Context, server *model.
 		ns = *appNamespace
 	}
 	opts := &api.SyncOptions{
-		Prune:        prune,
-		AppNamespace: ns,
+		Prune:                  syncOpts.Prune,
+		AppNamespace:           ns,
+		PruneLast:              syncOpts.PruneLast,
+		PrunePropagationPolicy: syncOpts.PrunePropagationPolicy,
+		ServerSideApply:        syncOpts.ServerSideApply,
+		Resources:              syncOpts.Resources,
 	}
 
 	// Use retry mechanism for sync operations
@@ -231,14 +258,14 @@ func (s *ArgoApiServiceImpl) SyncApplication(ctx context.Context, server *model.
 		if argErr, ok := err.(*apperrors.ArgonautError); ok {
 			return argErr.WithContext("operation", "SyncApplication").
 				WithContext("appName", appName).
-				WithContext("prune", prune)
+				WithContext("prune", syncOpts.Prune)
 		}
 
 		return apperrors.Wrap(err, apperrors.ErrorAPI, "SYNC_FAILED",
 			"Failed to sync application").
 			WithContext("server", server.BaseURL).
 			WithContext("appName", appName).
-			WithContext("prune", prune).
+			WithContext("prune", syncOpts.Prune).
 			AsRecoverable().
 			WithUserAction("Check the application status and try syncing again")
 	}
@@ -290,22 +317,55 @@ func (s *ArgoApiServiceImpl) GetResourceDiffs(ctx context.Context, server *model
 	// Map to service layer struct
 	out := make([]ResourceDiff, len(diffs))
 	for i, d := range diffs {
-		out[i] = ResourceDiff{
-			Group:               d.Group,
-			Kind:                d.Kind,
-			Name:                d.Name,
-			Namespace:           d.Namespace,
-			LiveState:           d.LiveState,
-			TargetState:         d.TargetState,
-			Diff:                d.Diff,
-			Hook:                d.Hook,
-			NormalizedLiveState: d.NormalizedLiveState,
-			PredictedLiveState:  d.PredictedLiveState,
-		}
+		out[i] = toResourceDiff(d)
 	}
 	return out, nil
 }
 
+// GetResourceDiff implements ArgoApiService.GetResourceDiff
+func (s *ArgoApiServiceImpl) GetResourceDiff(ctx context.Context, server *model.Server, appName string, appNamespace *string, key api.ManagedResourceKey) (*ResourceDiff, error) {
+	if server == nil {
+		return nil, apperrors.ConfigError("SERVER_MISSING",
+			"Server configuration is required").
+			WithUserAction("Please run 'argocd login' to configure the server")
+	}
+	if s.appService == nil {
+		s.appService = api.NewApplicationService(server)
+	}
+	ns := ""
+	if appNamespace != nil {
+		ns = *appNamespace
+	}
+	d, err := s.appService.GetManagedResourceDiff(ctx, appName, ns, key)
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrorAPI, "GET_DIFF_FAILED",
+			"Failed to get resource diff").
+			WithContext("appName", appName).
+			WithContext("resource", key.Kind+"/"+key.Name)
+	}
+	if d == nil {
+		return nil, nil
+	}
+	out := toResourceDiff(*d)
+	return &out, nil
+}
+
+// toResourceDiff maps an API diff to the service layer struct
+func toResourceDiff(d api.ManagedResourceDiff) ResourceDiff {
+	return ResourceDiff{
+		Group:               d.Group,
+		Kind:                d.Kind,
+		Name:                d.Name,
+		Namespace:           d.Namespace,
+		LiveState:           d.LiveState,
+		TargetState:         d.TargetState,
+		Diff:                d.Diff,
+		Hook:                d.Hook,
+		NormalizedLiveState: d.NormalizedLiveState,
+		PredictedLiveState:  d.PredictedLiveState,
+	}
+}
+
 // GetAPIVersion fetches /api/version and returns a version string
 func (s *ArgoApiServiceImpl) GetAPIVersion(ctx context.Context, server *model.Server) (string, error) {
 	if server == nil {
@@ -408,8 +468,9 @@ func (s *ArgoApiServiceImpl) handleWatchEvent(event api.ApplicationWatchEvent, e
 	switch event.Type {
 	case "DELETED":
 		eventChan <- ArgoApiEvent{
-			Type:    "app-deleted",
-			AppName: appName,
+			Type:      "app-deleted",
+			AppName:   appName,
+			StreamSeq: event.StreamSeq,
 		}
 	default:
 		// Convert to our model
@@ -418,6 +479,7 @@ func (s *ArgoApiServiceImpl) handleWatchEvent(event api.ApplicationWatchEvent, e
 			Type:      "app-updated",
 			App:       &app,
 			Resources: event.Application.Status.Resources,
+			StreamSeq: event.StreamSeq,
 		}
 	}
 }
@@ -432,6 +494,16 @@ func (s *ArgoApiServiceImpl) GetRevisionMetadata(ctx context.Context, server *mo
 	return s.appService.GetRevisionMetadata(ctx, appName, revision, appNamespace)
 }
 
+// ResolveRevision returns the commit SHA a branch, tag or HEAD of an app's source points at
+func (s *ArgoApiServiceImpl) ResolveRevision(ctx context.Context, server *model.Server, appName string, revision string, appNamespace *string) (string, error) {
+	return s.appService.ResolveRevision(ctx, appName, revision, appNamespace)
+}
+
+// GetRevisionMetadataForSource fetches git metadata for one source of a multi-source app
+func (s *ArgoApiServiceImpl) GetRevisionMetadataForSource(ctx context.Context, server *model.Server, appName string, revision string, appNamespace *string, sourceIndex int, versionID int) (*model.RevisionMetadata, error) {
+	return s.appService.GetRevisionMetadataForSource(ctx, appName, revision, appNamespace, sourceIndex, versionID)
+}
+
 // RollbackApplication performs a rollback operation
 func (s *ArgoApiServiceImpl) RollbackApplication(ctx context.Context, server *model.Server, request model.RollbackRequest) error {
 	return s.appService.RollbackApplication(ctx, request)
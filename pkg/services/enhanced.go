@@ -37,7 +37,7 @@ func NewEnhancedArgoApiService(server *model.Server) *EnhancedArgoApiService {
 }
 
 // SyncApplication implements ArgoApiService.SyncApplication with degradation check
-func (s *EnhancedArgoApiService) SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, prune bool) error {
+func (s *EnhancedArgoApiService) SyncApplication(ctx context.Context, server *model.Server, appName string, appNamespace *string, syncOpts SyncOptions) error {
 	if server == nil {
 		return apperrors.ConfigError("SERVER_MISSING",
 			"Server configuration is required").
@@ -67,8 +67,11 @@ func (s *EnhancedArgoApiService) SyncApplication(ctx context.Context, server *mo
 		ns = *appNamespace
 	}
 	opts := &api.SyncOptions{
-		Prune:        prune,
-		AppNamespace: ns,
+		Prune:                  syncOpts.Prune,
+		AppNamespace:           ns,
+		PruneLast:              syncOpts.PruneLast,
+		PrunePropagationPolicy: syncOpts.PrunePropagationPolicy,
+		ServerSideApply:        syncOpts.ServerSideApply,
 	}
 
 	// Use retry mechanism for sync operations
@@ -84,14 +87,14 @@ func (s *EnhancedArgoApiService) SyncApplication(ctx context.Context, server *mo
 		if argErr, ok := err.(*apperrors.ArgonautError); ok {
 			return argErr.WithContext("operation", "SyncApplication").
 				WithContext("appName", appName).
-				WithContext("prune", prune)
+				WithContext("prune", syncOpts.Prune)
 		}
 
 		return apperrors.Wrap(err, apperrors.ErrorAPI, "SYNC_FAILED",
 			"Failed to sync application").
 			WithContext("server", server.BaseURL).
 			WithContext("appName", appName).
-			WithContext("prune", prune).
+			WithContext("prune", syncOpts.Prune).
 			AsRecoverable().
 			WithUserAction("Check the application status and try syncing again")
 	}
@@ -2,6 +2,7 @@ package sort
 
 import (
 	"strings"
+	"time"
 
 	"github.com/darksworm/argonaut/pkg/model"
 )
@@ -23,25 +24,47 @@ var healthStatusOrder = map[string]int{
 	"Healthy":     5,
 }
 
+// Semantic ordering for operation phases (running operations first when
+// ascending, then failures)
+var operationPhaseOrder = map[string]int{
+	"Running":     0,
+	"Terminating": 1,
+	"Error":       2,
+	"Failed":      3,
+	"Succeeded":   4,
+}
+
 // Sortable is satisfied by types that expose a SortKey for semantic ordering.
+// now is the same for every key of a sort, so running operations are timed
+// alike.
 type Sortable interface {
-	SortKey() model.SortKey
+	SortKey(now time.Time) model.SortKey
 }
 
 // comparatorGeneric provides a less function for any type implementing Sortable.
 // For health/sync fields: primary ordering is semantic, first tiebreak is name,
 // final tiebreak (when names are equal) is kind then name.
 // For name field: primary ordering is name, tiebreak is kind.
-func comparatorGeneric[T Sortable](config model.SortConfig) func(a, b T) bool {
+// Apps without an operation come last when sorting by operation or duration,
+// in either direction.
+func comparatorGeneric[T Sortable](config model.SortConfig, now time.Time) func(a, b T) bool {
 	return func(a, b T) bool {
-		ak := a.SortKey()
-		bk := b.SortKey()
+		ak := a.SortKey(now)
+		bk := b.SortKey(now)
+		byOperation := config.Field == model.SortFieldOperation || config.Field == model.SortFieldDuration
+		if byOperation && ak.HasOperation != bk.HasOperation {
+			return ak.HasOperation
+		}
 		var cmp int
 		switch config.Field {
 		case model.SortFieldHealth:
 			cmp = compareHealthStatus(ak.Health, bk.Health)
 		case model.SortFieldSync:
 			cmp = compareSyncStatus(ak.Sync, bk.Sync)
+		case model.SortFieldOperation:
+			cmp = compareOperationPhase(ak.Operation, bk.Operation)
+		case model.SortFieldDuration:
+			cmp = cmpDuration(ak.Duration, bk.Duration)
 		default:
 			cmp = strings.Compare(strings.ToLower(ak.Name), strings.ToLower(bk.Name))
 		}
@@ -85,6 +108,24 @@ func compareHealthStatus(a, b string) int {
 	return orderA - orderB
 }
 
+// compareOperationPhase compares operation phases using semantic ordering
+func compareOperationPhase(a, b string) int {
+	orderA := getStatusOrder(operationPhaseOrder, a, 5)
+	orderB := getStatusOrder(operationPhaseOrder, b, 5)
+	return orderA - orderB
+}
+
+// cmpDuration compares durations, shortest first
+func cmpDuration(a, b time.Duration) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
 // getStatusOrder returns the order value for a status, using defaultVal for unknown statuses
 func getStatusOrder(orderMap map[string]int, status string, defaultVal int) int {
 	s := strings.TrimSpace(status)
@@ -108,10 +149,15 @@ func getStatusOrder(orderMap map[string]int, status string, defaultVal int) int
 // health/sync ordering. For status fields, tiebreaks by name then kind;
 // for name sort, tiebreaks by kind. Uses insertion sort; efficient for small lists.
 func Sort[T Sortable](items []T, config model.SortConfig) {
+	SortAt(items, config, time.Now())
+}
+
+// SortAt is Sort with running operations timed up to now
+func SortAt[T Sortable](items []T, config model.SortConfig, now time.Time) {
 	if len(items) <= 1 {
 		return
 	}
-	less := comparatorGeneric[T](config)
+	less := comparatorGeneric[T](config, now)
 
 	for i := 1; i < len(items); i++ {
 		j := i
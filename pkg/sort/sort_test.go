@@ -1,7 +1,9 @@
 package sort_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/darksworm/argonaut/pkg/model"
 	pkgsort "github.com/darksworm/argonaut/pkg/sort"
@@ -15,7 +17,7 @@ type testItem struct {
 	name   string
 }
 
-func (t testItem) SortKey() model.SortKey {
+func (t testItem) SortKey(time.Time) model.SortKey {
 	return model.SortKey{Health: t.health, Sync: t.sync, Kind: t.kind, Name: t.name}
 }
 
@@ -281,3 +283,62 @@ func TestSort_AllHealthStatuses(t *testing.T) {
 		}
 	}
 }
+
+func TestSort_OperationAndDuration(t *testing.T) {
+	start := time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)
+	finished := func(d time.Duration) *time.Time { at := start.Add(d); return &at }
+	apps := []model.App{
+		{Name: "a", OperationPhase: "Succeeded", OperationStartedAt: &start, LastSyncAt: finished(time.Minute)},
+		{Name: "b"},
+		{Name: "c", OperationPhase: "Failed", OperationStartedAt: &start, LastSyncAt: finished(10 * time.Minute)},
+		{Name: "d", OperationPhase: "Running", OperationStartedAt: &start},
+	}
+
+	pkgsort.Sort(apps, model.SortConfig{Field: model.SortFieldOperation, Direction: model.SortAsc})
+	var got []string
+	for _, a := range apps {
+		got = append(got, a.Name)
+	}
+	if want := "d c a b"; strings.Join(got, " ") != want {
+		t.Errorf("OperationAsc: got %v want %s", got, want)
+	}
+
+	pkgsort.Sort(apps, model.SortConfig{Field: model.SortFieldDuration, Direction: model.SortDesc})
+	got = got[:0]
+	for _, a := range apps {
+		got = append(got, a.Name)
+	}
+	// d is still running, for longer than any finished operation took
+	if want := "d c a b"; strings.Join(got, " ") != want {
+		t.Errorf("DurationDesc: got %v want %s", got, want)
+	}
+}
+
+func TestSortAt_NoOperationLastAndOneClock(t *testing.T) {
+	start := time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)
+	finished := func(d time.Duration) *time.Time { at := start.Add(d); return &at }
+	apps := []model.App{
+		{Name: "a", OperationPhase: "Succeeded", OperationStartedAt: &start, LastSyncAt: finished(time.Minute)},
+		{Name: "b"},
+		{Name: "c", OperationPhase: "Failed", OperationStartedAt: &start, LastSyncAt: finished(10 * time.Minute)},
+		{Name: "d", OperationPhase: "Running", OperationStartedAt: &start},
+	}
+	order := func() string {
+		var got []string
+		for _, a := range apps {
+			got = append(got, a.Name)
+		}
+		return strings.Join(got, " ")
+	}
+
+	// d has run for five minutes at the time of the sort
+	now := start.Add(5 * time.Minute)
+	pkgsort.SortAt(apps, model.SortConfig{Field: model.SortFieldDuration, Direction: model.SortAsc}, now)
+	if want := "a d c b"; order() != want {
+		t.Errorf("DurationAsc: got %s want %s", order(), want)
+	}
+	pkgsort.SortAt(apps, model.SortConfig{Field: model.SortFieldOperation, Direction: model.SortDesc}, now)
+	if want := "a c d b"; order() != want {
+		t.Errorf("OperationDesc: got %s want %s", order(), want)
+	}
+}
@@ -0,0 +1,67 @@
+package theme
+
+import (
+	"image/color"
+	"math"
+
+	"charm.land/lipgloss/v2"
+)
+
+// wcagAAContrast is the contrast ratio WCAG AA asks of normal text
+const wcagAAContrast = 4.5
+
+var (
+	lightFallback = lipgloss.Color("#ffffff")
+	darkFallback  = lipgloss.Color("#000000")
+)
+
+// ContrastingForeground returns desired when it is readable on bg, and
+// otherwise white or black, whichever contrasts more. A nil desired counts
+// as white.
+func ContrastingForeground(bg color.Color, desired color.Color) color.Color {
+	if desired == nil {
+		desired = lightFallback
+	}
+	if bg == nil {
+		return desired
+	}
+
+	if contrastRatio(bg, desired) >= wcagAAContrast {
+		return desired
+	}
+
+	lightRatio := contrastRatio(bg, lightFallback)
+	darkRatio := contrastRatio(bg, darkFallback)
+	if lightRatio >= darkRatio {
+		return lightFallback
+	}
+	return darkFallback
+}
+
+func contrastRatio(a, b color.Color) float64 {
+	la := relativeLuminance(a)
+	lb := relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+func relativeLuminance(c color.Color) float64 {
+	if c == nil {
+		return 0
+	}
+
+	r, g, b, _ := c.RGBA()
+	rf := srgbToLinear(float64(r) / 65535.0)
+	gf := srgbToLinear(float64(g) / 65535.0)
+	bf := srgbToLinear(float64(b) / 65535.0)
+	return 0.2126*rf + 0.7152*gf + 0.0722*bf
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
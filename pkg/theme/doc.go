@@ -0,0 +1,37 @@
+// Package theme holds Argonaut's color palettes and the styles derived from
+// them.
+//
+// A Palette is the set of colors every view draws with. Palettes come from a
+// preset (FromName), from the user's configuration (FromConfig), or from a
+// program that embeds Argonaut's views and brings its own colors:
+//
+//	type Brand struct {
+//		Primary string `theme:"accent"`
+//		Alert   string `theme:"danger"`
+//		Text    color.Color
+//		Logo    []byte // not a color, skipped
+//	}
+//
+//	palette, err := theme.FromStruct(theme.FromName("tokyo-night"), Brand{
+//		Primary: "#7d56f4",
+//		Alert:   "#ff5f87",
+//		Text:    lipgloss.Color("#fafafa"),
+//	})
+//
+// Normalize fills the colors a palette may leave out, and NewStyles derives
+// the styles of the components shared by the views, with overrides applied
+// on top:
+//
+//	styles := theme.NewStyles(palette,
+//		theme.Override(theme.Header, func(s lipgloss.Style) lipgloss.Style {
+//			return s.Italic(true)
+//		}),
+//	)
+//
+// The views in pkg/tui (treeview and yamlview) draw with these styles:
+// ApplyStyles hands them Styles with overrides applied, and ApplyTheme
+// derives the default Styles from a palette.
+//
+//	tree := treeview.NewTreeView(80, 24)
+//	tree.ApplyStyles(styles)
+package theme
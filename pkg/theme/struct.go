@@ -0,0 +1,97 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+)
+
+// FromStruct reads palette colors from any struct, such as a program's own
+// brand colors. A field names a color with a `theme` tag, or else by its
+// name in snake case (SelectedBG is selected_bg); the names are the keys of
+// [appearance.overrides], and `theme:"-"` skips a field. Fields hold a
+// color.Color or a string lipgloss.Color reads, like "#7d56f4" or "13". Nil
+// and empty fields, and untagged fields that name no color, are skipped, and
+// colors not given keep the base palette's.
+func FromStruct(base Palette, v any) (Palette, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return base, errors.New("theme: nil palette struct")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return base, fmt.Errorf("theme: palette must be a struct, got %T", v)
+	}
+
+	out := base
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, tagged := sf.Tag.Lookup("theme")
+		if key == "-" {
+			continue
+		}
+		if !tagged {
+			key = snakeCase(sf.Name)
+		}
+		field := out.field(key)
+		if field == nil {
+			if tagged {
+				return base, fmt.Errorf("theme: field %s names unknown color %q", sf.Name, key)
+			}
+			continue
+		}
+		c, err := colorOf(rv.Field(i))
+		if err != nil {
+			return base, fmt.Errorf("theme: field %s: %w", sf.Name, err)
+		}
+		if c != nil {
+			*field = c
+		}
+	}
+	return out, nil
+}
+
+// colorOf reads a color from a struct field; nil means none is set
+func colorOf(v reflect.Value) (color.Color, error) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() == "" {
+			return nil, nil
+		}
+		return lipgloss.Color(v.String()), nil
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+	if c, ok := v.Interface().(color.Color); ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("%s is neither a color.Color nor a string", v.Type())
+}
+
+// snakeCase turns a Go field name into a color key: CursorSelectedBG
+// becomes cursor_selected_bg
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
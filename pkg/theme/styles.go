@@ -0,0 +1,149 @@
+package theme
+
+import (
+	"charm.land/lipgloss/v2"
+)
+
+// Component names a piece of the UI shared by many views, whose style can be
+// overridden
+type Component string
+
+const (
+	ContentBorder    Component = "content-border"     // the rounded box around lists and trees
+	Header           Component = "header"             // column headings and modal titles
+	Selected         Component = "selected"           // selected rows
+	CursorOnSelected Component = "cursor-on-selected" // the cursor on a selected row
+	CursorOnMatch    Component = "cursor-on-match"    // the cursor on a search match in the tree
+	SearchMatch      Component = "search-match"       // search matches in the tree
+	Cursor           Component = "cursor"             // the cursor line of the YAML viewer
+	Status           Component = "status"             // the status line and other muted text
+	RefreshFlash     Component = "refresh-flash"      // rows flashed after a refresh
+	Text             Component = "text"               // plain text, like resource kinds
+	OnHighlight      Component = "on-highlight"       // names and statuses on highlighted rows
+	Key              Component = "key"                // YAML keys
+	Success          Component = "success"            // Healthy and Synced
+	Progress         Component = "progress"           // Progressing
+	Danger           Component = "danger"             // Degraded and OutOfSync
+	Warning          Component = "warning"            // Missing and Suspended
+	Unknown          Component = "unknown"            // statuses without a color of their own
+)
+
+// Styles are the styles of the shared components. Rows highlighted in the
+// tree (Selected, CursorOnSelected, CursorOnMatch, SearchMatch and
+// RefreshFlash) take their background from these styles and draw their text
+// with Text and OnHighlight, so it stays readable on any background.
+type Styles struct {
+	ContentBorder    lipgloss.Style
+	Header           lipgloss.Style
+	Selected         lipgloss.Style
+	CursorOnSelected lipgloss.Style
+	CursorOnMatch    lipgloss.Style
+	SearchMatch      lipgloss.Style
+	Cursor           lipgloss.Style
+	Status           lipgloss.Style
+	RefreshFlash     lipgloss.Style
+	Text             lipgloss.Style
+	OnHighlight      lipgloss.Style
+	Key              lipgloss.Style
+	Success          lipgloss.Style
+	Progress         lipgloss.Style
+	Danger           lipgloss.Style
+	Warning          lipgloss.Style
+	Unknown          lipgloss.Style
+}
+
+// StyleOverride changes one component's style after it was derived from the
+// palette, e.g. to make headings italic or drop the content border
+type StyleOverride struct {
+	Component Component
+	Apply     func(lipgloss.Style) lipgloss.Style
+}
+
+// Override returns an override of the component's style
+func Override(c Component, apply func(lipgloss.Style) lipgloss.Style) StyleOverride {
+	return StyleOverride{Component: c, Apply: apply}
+}
+
+// NewStyles derives the component styles from a palette, then applies the
+// overrides in order. Overrides of components that don't exist are ignored.
+func NewStyles(p Palette, overrides ...StyleOverride) Styles {
+	p = Normalize(p)
+	textOnSelected := ContrastingForeground(p.SelectedBG, p.Text)
+	s := Styles{
+		ContentBorder: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(p.Border).
+			PaddingLeft(1).
+			PaddingRight(1),
+		Header: lipgloss.NewStyle().Bold(true).Foreground(p.Warning),
+		Selected: lipgloss.NewStyle().
+			Background(p.SelectedBG).
+			Foreground(textOnSelected),
+		CursorOnSelected: lipgloss.NewStyle().
+			Background(p.CursorSelectedBG).
+			Foreground(ContrastingForeground(p.CursorSelectedBG, textOnSelected)),
+		CursorOnMatch: lipgloss.NewStyle().Background(p.Info),
+		SearchMatch:   lipgloss.NewStyle().Background(p.Warning).Foreground(p.DarkBG),
+		Cursor:        lipgloss.NewStyle().Background(p.CursorBG),
+		Status:        lipgloss.NewStyle().Foreground(p.Dim),
+		// Refresh flash uses a success/green-ish highlight
+		RefreshFlash: lipgloss.NewStyle().
+			Background(p.Success).
+			Foreground(textOnSelected),
+		Text:        lipgloss.NewStyle().Foreground(p.Text),
+		OnHighlight: lipgloss.NewStyle().Foreground(p.DarkBG),
+		Key:         lipgloss.NewStyle().Foreground(p.Info),
+		Success:     lipgloss.NewStyle().Foreground(p.Success),
+		Progress:    lipgloss.NewStyle().Foreground(p.Progress),
+		Danger:      lipgloss.NewStyle().Foreground(p.Danger),
+		Warning:     lipgloss.NewStyle().Foreground(p.Warning),
+		Unknown:     lipgloss.NewStyle().Foreground(p.Unknown),
+	}
+	for _, o := range overrides {
+		if style := s.style(o.Component); style != nil && o.Apply != nil {
+			*style = o.Apply(*style)
+		}
+	}
+	return s
+}
+
+// style returns the style of a component, or nil
+func (s *Styles) style(c Component) *lipgloss.Style {
+	switch c {
+	case ContentBorder:
+		return &s.ContentBorder
+	case Header:
+		return &s.Header
+	case Selected:
+		return &s.Selected
+	case CursorOnSelected:
+		return &s.CursorOnSelected
+	case CursorOnMatch:
+		return &s.CursorOnMatch
+	case SearchMatch:
+		return &s.SearchMatch
+	case Cursor:
+		return &s.Cursor
+	case Status:
+		return &s.Status
+	case RefreshFlash:
+		return &s.RefreshFlash
+	case Text:
+		return &s.Text
+	case OnHighlight:
+		return &s.OnHighlight
+	case Key:
+		return &s.Key
+	case Success:
+		return &s.Success
+	case Progress:
+		return &s.Progress
+	case Danger:
+		return &s.Danger
+	case Warning:
+		return &s.Warning
+	case Unknown:
+		return &s.Unknown
+	}
+	return nil
+}
@@ -100,48 +100,82 @@ func FromConfig(cfg *config.ArgonautConfig) Palette {
 // applyOverrides applies color overrides to a palette
 func applyOverrides(base Palette, overrides map[string]string) Palette {
 	for key, value := range overrides {
-		color := lipgloss.Color(value)
-		switch key {
-		case "accent":
-			base.Accent = color
-			base.SelectedBG = color // Keep them in sync by default
-		case "warning":
-			base.Warning = color
-		case "dim":
-			base.Dim = color
-		case "success":
-			base.Success = color
-		case "danger":
-			base.Danger = color
-		case "progress":
-			base.Progress = color
-		case "unknown":
-			base.Unknown = color
-		case "info":
-			base.Info = color
-		case "text":
-			base.Text = color
-		case "gray":
-			base.Gray = color
-		case "selected_bg":
-			base.SelectedBG = color
-		case "cursor_selected_bg":
-			base.CursorSelectedBG = color
-		case "cursor_bg":
-			base.CursorBG = color
-		case "border":
-			base.Border = color
-		case "muted_bg":
-			base.MutedBG = color
-		case "shade_bg":
-			base.ShadeBG = color
-		case "dark_bg":
-			base.DarkBG = color
+		field := base.field(key)
+		if field == nil {
+			continue
+		}
+		*field = lipgloss.Color(value)
+		if key == "accent" {
+			base.SelectedBG = *field // Keep them in sync by default
 		}
 	}
 	return base
 }
 
+// field returns the color a key of [appearance.overrides] names, or nil
+func (p *Palette) field(key string) *color.Color {
+	switch key {
+	case "accent":
+		return &p.Accent
+	case "warning":
+		return &p.Warning
+	case "dim":
+		return &p.Dim
+	case "success":
+		return &p.Success
+	case "danger":
+		return &p.Danger
+	case "progress":
+		return &p.Progress
+	case "unknown":
+		return &p.Unknown
+	case "info":
+		return &p.Info
+	case "text":
+		return &p.Text
+	case "gray":
+		return &p.Gray
+	case "selected_bg":
+		return &p.SelectedBG
+	case "cursor_selected_bg":
+		return &p.CursorSelectedBG
+	case "cursor_bg":
+		return &p.CursorBG
+	case "border":
+		return &p.Border
+	case "muted_bg":
+		return &p.MutedBG
+	case "shade_bg":
+		return &p.ShadeBG
+	case "dark_bg":
+		return &p.DarkBG
+	}
+	return nil
+}
+
+// Normalize fills the optional colors a palette left nil: the selection and
+// the border follow the accent, and the cursor follows the cursor on a
+// selected row, or the info color.
+func Normalize(p Palette) Palette {
+	if p.CursorSelectedBG == nil {
+		p.CursorSelectedBG = p.Accent
+	}
+	if p.CursorBG == nil {
+		if p.CursorSelectedBG != nil {
+			p.CursorBG = p.CursorSelectedBG
+		} else {
+			p.CursorBG = p.Info
+		}
+	}
+	if p.Border == nil {
+		p.Border = p.Accent
+	}
+	if p.SelectedBG == nil {
+		p.SelectedBG = p.Accent
+	}
+	return p
+}
+
 // GetAvailableThemes returns all available preset theme names
 func GetAvailableThemes() []string {
 	return Names()
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"fmt"
+	"image/color"
 	"strings"
 	"testing"
 
@@ -617,3 +618,124 @@ func TestNewPalette_UsageExample(t *testing.T) {
 		t.Error("All colors in NewPalette result should be non-nil")
 	}
 }
+
+func TestFromStruct_ReadsTaggedAndNamedFields(t *testing.T) {
+	type brand struct {
+		Primary    string      `theme:"accent"`
+		Alert      string      `theme:"danger"`
+		Text       color.Color // named like the palette color
+		SelectedBG string
+		Skipped    string `theme:"-"`
+		Logo       []byte // not a color
+		Warning    string // empty, keeps the base
+	}
+	base := FromName("dracula")
+	got, err := FromStruct(base, &brand{
+		Primary:    "#7d56f4",
+		Alert:      "#ff5f87",
+		Text:       lipgloss.Color("#fafafa"),
+		SelectedBG: "#333333",
+		Skipped:    "#000000",
+		Logo:       []byte("png"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]color.Color{
+		"accent":      lipgloss.Color("#7d56f4"),
+		"danger":      lipgloss.Color("#ff5f87"),
+		"text":        lipgloss.Color("#fafafa"),
+		"selected_bg": lipgloss.Color("#333333"),
+		"warning":     base.Warning,
+		"success":     base.Success,
+	}
+	for key, c := range want {
+		if field := got.field(key); fmt.Sprint(*field) != fmt.Sprint(c) {
+			t.Errorf("%s = %v, want %v", key, *field, c)
+		}
+	}
+}
+
+func TestFromStruct_RejectsBadFields(t *testing.T) {
+	base := Default()
+	tests := []struct {
+		name string
+		v    any
+		err  string
+	}{
+		{"not a struct", "#ffffff", "palette must be a struct"},
+		{"nil pointer", (*struct{ Accent string })(nil), "nil palette struct"},
+		{"unknown tag", struct {
+			Primary string `theme:"acent"`
+		}{"#ffffff"}, `unknown color "acent"`},
+		{"wrong type", struct{ Accent int }{13}, "neither a color.Color nor a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromStruct(base, tt.v)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(base) {
+				t.Error("a failed read should return the base palette")
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"Accent":           "accent",
+		"SelectedBG":       "selected_bg",
+		"CursorSelectedBG": "cursor_selected_bg",
+		"DarkBG":           "dark_bg",
+		"HTTPAccent":       "http_accent",
+	} {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNormalize_FillsOptionalColors(t *testing.T) {
+	accent, info := lipgloss.Color("#7d56f4"), lipgloss.Color("#00ffff")
+	p := Normalize(Palette{Accent: accent, Info: info})
+	for name, c := range map[string]color.Color{"selected": p.SelectedBG, "border": p.Border, "cursorSelected": p.CursorSelectedBG, "cursor": p.CursorBG} {
+		if fmt.Sprint(c) != fmt.Sprint(accent) {
+			t.Errorf("%s = %v, want the accent", name, c)
+		}
+	}
+}
+
+func TestNewStyles_AppliesOverrides(t *testing.T) {
+	p := FromName("dracula")
+	plain := NewStyles(p)
+	if fmt.Sprint(plain.Header.GetForeground()) != fmt.Sprint(p.Warning) || !plain.Header.GetBold() {
+		t.Errorf("header should be bold in the warning color")
+	}
+	if fmt.Sprint(plain.Selected.GetBackground()) != fmt.Sprint(p.SelectedBG) {
+		t.Errorf("selected rows should use the selection color")
+	}
+
+	styled := NewStyles(p,
+		Override(Header, func(s lipgloss.Style) lipgloss.Style { return s.Italic(true) }),
+		Override(Component("missing"), func(s lipgloss.Style) lipgloss.Style { return s.Bold(false) }),
+		Override(Header, nil),
+	)
+	if !styled.Header.GetItalic() || !styled.Header.GetBold() {
+		t.Error("override should build on the derived header style")
+	}
+	if styled.Status.GetItalic() {
+		t.Error("an override should change only its component")
+	}
+}
+
+func TestContrastingForeground(t *testing.T) {
+	white, black := lipgloss.Color("#ffffff"), lipgloss.Color("#000000")
+	if got := ContrastingForeground(black, white); fmt.Sprint(got) != fmt.Sprint(white) {
+		t.Errorf("white on black is readable, got %v", got)
+	}
+	if got := ContrastingForeground(lipgloss.Color("#fafafa"), lipgloss.Color("#eeeeee")); fmt.Sprint(got) != fmt.Sprint(black) {
+		t.Errorf("light text on a light background should turn black, got %v", got)
+	}
+}
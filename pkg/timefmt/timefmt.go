@@ -0,0 +1,121 @@
+// Package timefmt formats the timestamps shown in the UI (rollback history,
+// app details, job runs, errors) by the [time] settings: absolute or
+// relative, 12 or 24 hour clock, local time or UTC.
+package timefmt
+
+import (
+	"fmt"
+	"time"
+)
+
+// Options controls how timestamps are rendered
+type Options struct {
+	// Relative renders "5m ago" instead of the date and time
+	Relative bool
+	// Hour12 uses a 12 hour clock with AM/PM
+	Hour12 bool
+	// UTC renders times in UTC instead of the local time zone
+	UTC bool
+	// Now is the time relative timestamps are measured from; time.Now when nil
+	Now func() time.Time
+}
+
+var opts Options
+
+// Set sets how timestamps are rendered from now on
+func Set(o Options) {
+	opts = o
+}
+
+func now() time.Time {
+	if opts.Now != nil {
+		return opts.Now()
+	}
+	return time.Now()
+}
+
+func zoned(t time.Time) time.Time {
+	if opts.UTC {
+		return t.UTC()
+	}
+	return t.Local()
+}
+
+func clockLayout(seconds bool) string {
+	switch {
+	case opts.Hour12 && seconds:
+		return "3:04:05 PM"
+	case opts.Hour12:
+		return "3:04 PM"
+	case seconds:
+		return "15:04:05"
+	}
+	return "15:04"
+}
+
+// Stamp renders a moment to the minute, e.g. "2025-01-01 15:04" or "5m ago"
+func Stamp(t time.Time) string {
+	if opts.Relative {
+		return Ago(t)
+	}
+	return zoned(t).Format("2006-01-02 " + clockLayout(false))
+}
+
+// StampSeconds is Stamp to the second, for details views
+func StampSeconds(t time.Time) string {
+	if opts.Relative {
+		return Ago(t)
+	}
+	return zoned(t).Format("2006-01-02 " + clockLayout(true))
+}
+
+// At renders a moment after a verb, e.g. "Succeeded at 2025-01-01 15:04"
+// or "Succeeded 5m ago"
+func At(t time.Time) string {
+	if opts.Relative {
+		return Ago(t)
+	}
+	return "at " + Stamp(t)
+}
+
+// TimeOfDay renders a recent moment without its date, e.g. "15:04:05" or
+// "5m ago"
+func TimeOfDay(t time.Time) string {
+	if opts.Relative {
+		return Ago(t)
+	}
+	return zoned(t).Format(clockLayout(true))
+}
+
+// Clock renders the time of a wall clock, e.g. "15:04" or "3:04 PM"; it is
+// never relative
+func Clock(t time.Time) string {
+	return zoned(t).Format(clockLayout(false))
+}
+
+// Ago renders how long ago a moment was in its largest unit, e.g. "45s ago",
+// "5m ago", "3h ago" or "2d ago"; moments ahead render as "in 5m"
+func Ago(t time.Time) string {
+	d := now().Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var s string
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		s = fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		s = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
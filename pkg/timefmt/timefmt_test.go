@@ -0,0 +1,56 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormats(t *testing.T) {
+	defer Set(Options{})
+	at := time.Date(2025, time.January, 1, 15, 4, 5, 0, time.UTC)
+	now := func() time.Time { return at.Add(5 * time.Minute) }
+
+	tests := []struct {
+		name string
+		opts Options
+		fn   func(time.Time) string
+		want string
+	}{
+		{"24h stamp", Options{UTC: true}, Stamp, "2025-01-01 15:04"},
+		{"12h stamp", Options{UTC: true, Hour12: true}, Stamp, "2025-01-01 3:04 PM"},
+		{"seconds", Options{UTC: true}, StampSeconds, "2025-01-01 15:04:05"},
+		{"at", Options{UTC: true}, At, "at 2025-01-01 15:04"},
+		{"time of day", Options{UTC: true, Hour12: true}, TimeOfDay, "3:04:05 PM"},
+		{"relative stamp", Options{Relative: true, Now: now}, Stamp, "5m ago"},
+		{"relative at", Options{Relative: true, Now: now}, At, "5m ago"},
+		{"clock is never relative", Options{Relative: true, UTC: true, Now: now}, Clock, "15:04"},
+	}
+	for _, tt := range tests {
+		Set(tt.opts)
+		if got := tt.fn(at); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAgo(t *testing.T) {
+	defer Set(Options{})
+	base := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	Set(Options{Now: func() time.Time { return base }})
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "just now"},
+		{45 * time.Second, "45s ago"},
+		{90 * time.Minute, "1h ago"},
+		{50 * time.Hour, "2d ago"},
+		{-5 * time.Minute, "in 5m"},
+	}
+	for _, tt := range tests {
+		if got := Ago(base.Add(-tt.ago)); got != tt.want {
+			t.Errorf("Ago(%s) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package browser opens links in the user's web browser.
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	cblog "github.com/charmbracelet/log"
+)
+
+// OpenMsg is sent after trying to open a link
+type OpenMsg struct {
+	URL string
+	Err error
+}
+
+// OpenCmd returns a tea.Cmd that opens url in the browser named by
+// $BROWSER, or else the system's default one. The browser is started
+// without waiting for it to exit.
+func OpenCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := command(url)
+		if err := cmd.Start(); err != nil {
+			cblog.With("component", "browser").Warn("Could not open browser", "cmd", cmd.Path, "err", err)
+			return OpenMsg{URL: url, Err: err}
+		}
+		go func() { _ = cmd.Wait() }()
+		return OpenMsg{URL: url}
+	}
+}
+
+// command builds the command that opens url
+func command(url string) *exec.Cmd {
+	if custom := strings.Fields(os.Getenv("BROWSER")); len(custom) > 0 {
+		return exec.Command(custom[0], append(custom[1:], url)...)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
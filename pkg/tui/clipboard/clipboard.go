@@ -18,7 +18,7 @@ var (
 	customCopyMu  sync.RWMutex
 )
 
-// SetCopyCommand configures a custom clipboard copy command.
+// SetCopyCommand configures a custom clipboard copy command, run with sh -c.
 // The command receives text via stdin. Examples: "pbcopy", "xclip -selection clipboard"
 // Pass an empty string to use auto-detection.
 func SetCopyCommand(cmd string) {
@@ -99,39 +99,46 @@ func CopyWithOSC52(text string) tea.Cmd {
 	return tea.Printf("%s", sequence)
 }
 
-// copyNative uses the system clipboard directly, or a custom command if configured.
-func copyNative(text string) error {
+// NativeCommand returns the command copies go through, or an empty string
+// when there is none and copies are sent with OSC 52.
+func NativeCommand() string {
+	if customCmd := GetCopyCommand(); customCmd != "" {
+		return customCmd
+	}
+	return strings.Join(nativeArgs(), " ")
+}
+
+// nativeArgs returns the custom copy command if configured, else the
+// system clipboard command for this OS
+func nativeArgs() []string {
 	// Check for custom copy command (from config)
 	if customCmd := GetCopyCommand(); customCmd != "" {
-		// Parse the command (supports arguments like "xclip -selection clipboard")
-		parts := strings.Fields(customCmd)
-		if len(parts) == 0 {
-			return exec.ErrNotFound
-		}
-		cmd := exec.Command(parts[0], parts[1:]...)
-		cmd.Stdin = strings.NewReader(text)
-		return cmd.Run()
+		// Through the shell, so quoted arguments and secrets stay whole
+		return []string{"sh", "-c", customCmd}
 	}
 
 	// Auto-detect clipboard command based on OS
-	var cmd *exec.Cmd
-
 	switch runtime.GOOS {
 	case "darwin":
-		cmd = exec.Command("pbcopy")
+		return []string{"pbcopy"}
 	case "linux":
 		// Try xclip first, then xsel
 		if _, err := exec.LookPath("xclip"); err == nil {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
+			return []string{"xclip", "-selection", "clipboard"}
 		} else if _, err := exec.LookPath("xsel"); err == nil {
-			cmd = exec.Command("xsel", "--clipboard", "--input")
-		} else {
-			return exec.ErrNotFound
+			return []string{"xsel", "--clipboard", "--input"}
 		}
-	default:
-		return exec.ErrNotFound
 	}
+	return nil
+}
 
+// copyNative uses the system clipboard directly, or a custom command if configured.
+func copyNative(text string) error {
+	args := nativeArgs()
+	if len(args) == 0 {
+		return exec.ErrNotFound
+	}
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdin = strings.NewReader(text)
 	return cmd.Run()
 }
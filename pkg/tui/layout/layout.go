@@ -0,0 +1,178 @@
+// Package layout splits the terminal into the regions a view draws. Views
+// describe their bands (header, bars, body, status) and the frames they
+// draw content in, and read the sizes back, instead of each subtracting its
+// own overhead constants from the terminal size.
+package layout
+
+// Region is one band of a vertical stack. A fixed region takes exactly its
+// height; a fill region shares what the fixed ones leave, but never gets
+// less than its minimum.
+type Region struct {
+	Name   string
+	Height int // the fixed height, or the minimum of a fill region
+	fill   bool
+}
+
+// Fixed is a region of exactly height rows; a negative height counts as 0.
+func Fixed(name string, height int) Region {
+	return Region{Name: name, Height: max(0, height)}
+}
+
+// Fill is a region that takes the rows the fixed regions leave, at least
+// minHeight of them.
+func Fill(name string, minHeight int) Region {
+	return Region{Name: name, Height: max(0, minHeight), fill: true}
+}
+
+// Stack is a vertical split of a height into regions, top to bottom.
+type Stack struct {
+	total   int
+	names   []string
+	heights []int
+}
+
+// VStack splits total rows into the regions. Fill regions share the spare
+// rows evenly, the first ones taking any remainder. When the fixed heights
+// and minimums do not fit, every region still gets them and the stack
+// overflows; Overflow tells by how much.
+func VStack(total int, regions ...Region) Stack {
+	s := Stack{total: max(0, total), names: make([]string, len(regions)), heights: make([]int, len(regions))}
+	used, fills := 0, 0
+	for i, r := range regions {
+		s.names[i] = r.Name
+		s.heights[i] = r.Height
+		used += r.Height
+		if r.fill {
+			fills++
+		}
+	}
+	spare := s.total - used
+	if spare <= 0 || fills == 0 {
+		return s
+	}
+	share, extra := spare/fills, spare%fills
+	for i, r := range regions {
+		if !r.fill {
+			continue
+		}
+		s.heights[i] += share
+		if extra > 0 {
+			s.heights[i]++
+			extra--
+		}
+	}
+	return s
+}
+
+// Height returns the rows of the named region, or 0 when there is none.
+func (s Stack) Height(name string) int {
+	for i, n := range s.names {
+		if n == name {
+			return s.heights[i]
+		}
+	}
+	return 0
+}
+
+// Top returns the row the named region starts at, or -1 when there is none.
+func (s Stack) Top(name string) int {
+	top := 0
+	for i, n := range s.names {
+		if n == name {
+			return top
+		}
+		top += s.heights[i]
+	}
+	return -1
+}
+
+// Used returns the rows the regions take together.
+func (s Stack) Used() int {
+	used := 0
+	for _, h := range s.heights {
+		used += h
+	}
+	return used
+}
+
+// Overflow returns how many rows the regions need past the total.
+func (s Stack) Overflow() int {
+	return max(0, s.Used()-s.total)
+}
+
+// Box is the frame content is drawn in: a border and padding on each side.
+// Its sizes are outer sizes, which is what lipgloss Width and Height set.
+type Box struct {
+	Border int // border cells on each side
+	PadX   int // padding columns on the left and on the right
+	PadY   int // padding rows above and below
+}
+
+var (
+	// Plain is content drawn without a frame.
+	Plain = Box{}
+	// Bordered is a border hugging its content.
+	Bordered = Box{Border: 1}
+	// Card is a border with a column of padding on each side, like the
+	// box around the lists and the tree.
+	Card = Box{Border: 1, PadX: 1}
+	// Dialog is a border with two columns and a row of padding, like most
+	// modals.
+	Dialog = Box{Border: 1, PadX: 2, PadY: 1}
+)
+
+// InnerWidth returns the columns left for content in a box width wide.
+func (b Box) InnerWidth(width int) int {
+	return max(0, width-2*(b.Border+b.PadX))
+}
+
+// InnerHeight returns the rows left for content in a box height tall.
+func (b Box) InnerHeight(height int) int {
+	return max(0, height-2*(b.Border+b.PadY))
+}
+
+// OuterWidth returns how wide a box must be to fit content width columns.
+func (b Box) OuterWidth(width int) int {
+	return max(0, width) + 2*(b.Border+b.PadX)
+}
+
+// OuterHeight returns how tall a box must be to fit content height rows.
+func (b Box) OuterHeight(height int) int {
+	return max(0, height) + 2*(b.Border+b.PadY)
+}
+
+// ModalMargin is the columns a modal leaves free on each side when the
+// terminal is wide enough.
+const ModalMargin = 3
+
+// OverlayMargin is the columns an overlay covering nearly the whole screen
+// leaves free on each side.
+const OverlayMargin = 2
+
+// minModalWidth is the width a modal keeps before it gives up its margin.
+const minModalWidth = 20
+
+// fit clamps width to the terminal less margin columns on each side. The
+// margin gives way before the width drops under minModalWidth, and the
+// width never gets past the terminal.
+func fit(cols, width, margin int) int {
+	width = min(width, max(minModalWidth, cols-2*margin))
+	return max(0, min(width, cols))
+}
+
+// FitModal clamps the width a modal wants to the terminal, keeping
+// ModalMargin on each side while the terminal is wide enough.
+func FitModal(cols, width int) int {
+	return fit(cols, width, ModalMargin)
+}
+
+// ModalWidth sizes a centered modal as num/den of the terminal width, at
+// least minWidth, fitted with FitModal.
+func ModalWidth(cols, minWidth, num, den int) int {
+	return FitModal(cols, max(minWidth, cols*num/den))
+}
+
+// OverlayWidth sizes an overlay that covers nearly the whole screen.
+func OverlayWidth(cols int) int {
+	return fit(cols, cols, OverlayMargin)
+}
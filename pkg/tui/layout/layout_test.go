@@ -0,0 +1,104 @@
+package layout
+
+import "testing"
+
+func TestVStack_FillTakesSpareRows(t *testing.T) {
+	s := VStack(24,
+		Fixed("header", 3),
+		Fill("body", 3),
+		Fixed("hints", 1),
+		Fixed("status", 1),
+	)
+	if got := s.Height("body"); got != 19 {
+		t.Errorf("body = %d, want 19", got)
+	}
+	if got := s.Top("hints"); got != 22 {
+		t.Errorf("hints top = %d, want 22", got)
+	}
+	if s.Used() != 24 || s.Overflow() != 0 {
+		t.Errorf("used %d overflow %d, want 24 and 0", s.Used(), s.Overflow())
+	}
+}
+
+func TestVStack_FillsShareEvenly(t *testing.T) {
+	s := VStack(11, Fixed("title", 2), Fill("top", 1), Fill("bottom", 1))
+	if s.Height("top") != 5 || s.Height("bottom") != 4 {
+		t.Errorf("top %d bottom %d, want 5 and 4", s.Height("top"), s.Height("bottom"))
+	}
+}
+
+func TestVStack_KeepsMinimumsWhenTooSmall(t *testing.T) {
+	s := VStack(4, Fixed("header", 3), Fill("body", 3), Fixed("status", 1))
+	if got := s.Height("body"); got != 3 {
+		t.Errorf("body = %d, want its minimum 3", got)
+	}
+	if got := s.Overflow(); got != 3 {
+		t.Errorf("overflow = %d, want 3", got)
+	}
+}
+
+func TestVStack_UnknownRegion(t *testing.T) {
+	s := VStack(10, Fixed("status", 1), Fixed("empty", -2))
+	if s.Height("missing") != 0 || s.Top("missing") != -1 {
+		t.Error("expected 0 height and -1 top for a missing region")
+	}
+	if s.Height("empty") != 0 {
+		t.Error("expected a negative height to count as 0")
+	}
+}
+
+func TestBox_InnerAndOuter(t *testing.T) {
+	cases := []struct {
+		box         Box
+		w, h        int
+		innerW      int
+		innerH      int
+		description string
+	}{
+		{Plain, 10, 5, 10, 5, "plain"},
+		{Bordered, 10, 5, 8, 3, "bordered"},
+		{Card, 10, 5, 6, 3, "card"},
+		{Dialog, 10, 5, 4, 1, "dialog"},
+		{Dialog, 3, 2, 0, 0, "too small"},
+	}
+	for _, c := range cases {
+		if got := c.box.InnerWidth(c.w); got != c.innerW {
+			t.Errorf("%s: inner width %d, want %d", c.description, got, c.innerW)
+		}
+		if got := c.box.InnerHeight(c.h); got != c.innerH {
+			t.Errorf("%s: inner height %d, want %d", c.description, got, c.innerH)
+		}
+		if c.innerW > 0 && c.box.OuterWidth(c.innerW) != c.w {
+			t.Errorf("%s: outer width %d, want %d", c.description, c.box.OuterWidth(c.innerW), c.w)
+		}
+		if c.innerH > 0 && c.box.OuterHeight(c.innerH) != c.h {
+			t.Errorf("%s: outer height %d, want %d", c.description, c.box.OuterHeight(c.innerH), c.h)
+		}
+	}
+}
+
+func TestModalWidth(t *testing.T) {
+	cases := []struct {
+		cols, minWidth, num, den, want int
+	}{
+		{120, 60, 2, 3, 80}, // a share of a wide terminal
+		{80, 60, 2, 3, 60},  // the minimum wins
+		{64, 60, 2, 3, 58},  // the margin wins over the minimum
+		{24, 60, 2, 3, 20},  // the margin gives way first
+		{12, 60, 2, 3, 12},  // never wider than the terminal
+		{0, 60, 2, 3, 0},
+	}
+	for _, c := range cases {
+		if got := ModalWidth(c.cols, c.minWidth, c.num, c.den); got != c.want {
+			t.Errorf("ModalWidth(%d, %d, %d/%d) = %d, want %d", c.cols, c.minWidth, c.num, c.den, got, c.want)
+		}
+	}
+	if got := FitModal(100, 64); got != 64 {
+		t.Errorf("FitModal(100, 64) = %d, want 64", got)
+	}
+	for cols, want := range map[int]int{100: 96, 22: 20, 16: 16} {
+		if got := OverlayWidth(cols); got != want {
+			t.Errorf("OverlayWidth(%d) = %d, want %d", cols, got, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package treeview
+
+// followTargets returns the Progressing resources the cursor can follow, in
+// tree order. Only the lowest Progressing node of a branch counts, so the
+// new pods of a rollout are picked over the Deployment and ReplicaSet above
+// them; the app roots are left out.
+func (v *TreeView) followTargets() []*treeNode {
+	var targets []*treeNode
+	var walk func(n *treeNode) bool
+	walk = func(n *treeNode) bool {
+		progressingBelow := false
+		for _, c := range n.children {
+			if walk(c) {
+				progressingBelow = true
+			}
+		}
+		// n is added after its children only when none of them was, so the
+		// list stays in tree order
+		progressing := n.health == "Progressing"
+		if progressing && !progressingBelow && n.parent != nil {
+			targets = append(targets, n)
+		}
+		return progressing || progressingBelow
+	}
+	for _, r := range v.roots {
+		walk(r)
+	}
+	return targets
+}
+
+// FollowProgressing moves the cursor to a Progressing resource, expanding
+// the nodes above it. The cursor stays put while its resource is still one
+// to follow, and otherwise moves to the first one. Returns false when
+// nothing is progressing.
+func (v *TreeView) FollowProgressing() bool {
+	targets := v.followTargets()
+	if len(targets) == 0 {
+		return false
+	}
+	target := targets[0]
+	for _, t := range targets {
+		if t.uid == v.SelectedUID {
+			target = t
+			break
+		}
+	}
+
+	rebuild := false
+	for p := target.parent; p != nil; p = p.parent {
+		if !v.expanded[p.uid] {
+			v.expanded[p.uid] = true
+			rebuild = true
+		}
+	}
+	if rebuild {
+		v.rebuildOrderPreservingState()
+	}
+	if idx := v.indexOf(target); idx >= 0 {
+		v.selIdx = idx
+		v.SelectedUID = target.uid
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package treeview
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/darksworm/argonaut/pkg/timefmt"
+)
+
+// JobRuns summarises the last run of a Job or CronJob node, read from the
+// Job's health and the Jobs and Pods below it in the tree
+type JobRuns struct {
+	Kind       string     // Job or CronJob
+	Phase      string     // last run: Running, Succeeded, Failed or Suspended; "" before the first run
+	Message    string     // health message of the last run
+	LastRun    *time.Time // when the last run's Job was created
+	FailedPods int        // failed pods of the last run
+	LatestPod  *ResourceSelection
+}
+
+// jobPhase maps the health Argo CD gives a Job to the phase of its run
+func jobPhase(health string) string {
+	switch health {
+	case "Healthy":
+		return "Succeeded"
+	case "Degraded":
+		return "Failed"
+	case "Progressing":
+		return "Running"
+	default:
+		return health
+	}
+}
+
+// isJobNode reports whether a node is a batch Job or CronJob
+func isJobNode(n *treeNode) bool {
+	return n != nil && n.group == "batch" && (n.kind == "Job" || n.kind == "CronJob")
+}
+
+// newerNode reports whether a was created after b; nodes without a
+// creation time count as oldest
+func newerNode(a, b *treeNode) bool {
+	if b == nil {
+		return true
+	}
+	if a.createdAt == nil {
+		return false
+	}
+	return b.createdAt == nil || a.createdAt.After(*b.createdAt)
+}
+
+// jobRuns reads the last run of a Job or CronJob node
+func (v *TreeView) jobRuns(n *treeNode) (JobRuns, bool) {
+	if !isJobNode(n) {
+		return JobRuns{}, false
+	}
+	runs := JobRuns{Kind: n.kind}
+
+	job := n
+	if n.kind == "CronJob" {
+		// The newest child Job is the last scheduled run
+		job = nil
+		for _, c := range n.children {
+			if c.kind == "Job" && newerNode(c, job) {
+				job = c
+			}
+		}
+		if job == nil {
+			return runs, true
+		}
+	}
+	runs.Phase = jobPhase(job.health)
+	runs.Message = job.healthMessage
+	runs.LastRun = job.createdAt
+
+	var latest *treeNode
+	for _, c := range job.children {
+		if c.kind != "Pod" {
+			continue
+		}
+		if c.health == "Degraded" {
+			runs.FailedPods++
+		}
+		if newerNode(c, latest) {
+			latest = c
+		}
+	}
+	if latest != nil {
+		runs.LatestPod = &ResourceSelection{
+			AppName:   v.nodeAppName(latest),
+			Group:     latest.group,
+			Version:   latest.version,
+			Kind:      latest.kind,
+			Namespace: latest.namespace,
+			Name:      latest.name,
+			Status:    latest.status,
+			Health:    latest.health,
+		}
+	}
+	return runs, true
+}
+
+// nodeAppName returns the app that owns a node, encoded as the UID prefix
+func (v *TreeView) nodeAppName(n *treeNode) string {
+	if idx := strings.Index(n.uid, "::"); idx > 0 {
+		return n.uid[:idx]
+	}
+	return v.appName
+}
+
+// SelectedJobRuns returns the last run of the Job or CronJob under the
+// cursor. Returns ok=false for other kinds.
+func (v *TreeView) SelectedJobRuns() (JobRuns, bool) {
+	if v.selIdx < 0 || v.selIdx >= len(v.order) {
+		return JobRuns{}, false
+	}
+	return v.jobRuns(v.order[v.selIdx])
+}
+
+// jobNote describes a Job or CronJob's last run after its status, e.g.
+// "last run Failed 2025-01-01 03:00, 2 failed pods". Empty for other kinds.
+func (v *TreeView) jobNote(n *treeNode) string {
+	runs, ok := v.jobRuns(n)
+	if !ok {
+		return ""
+	}
+	var parts []string
+	if n.kind == "CronJob" {
+		if runs.Phase == "" {
+			return "never run"
+		}
+		last := "last run " + runs.Phase
+		if runs.LastRun != nil {
+			last += " " + timefmt.Stamp(*runs.LastRun)
+		}
+		parts = append(parts, last)
+	}
+	switch runs.FailedPods {
+	case 0:
+	case 1:
+		parts = append(parts, "1 failed pod")
+	default:
+		parts = append(parts, fmt.Sprintf("%d failed pods", runs.FailedPods))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderJobNote renders the job note after a row's status, over bg when
+// the row is highlighted. Empty when the node has nothing to add.
+func (v *TreeView) renderJobNote(n *treeNode, bg color.Color) string {
+	note := v.jobNote(n)
+	if note == "" {
+		return ""
+	}
+	if bg != nil {
+		return v.styles.OnHighlight.Background(bg).Render(" " + note)
+	}
+	return v.styles.Status.Render(" " + note)
+}
@@ -0,0 +1,56 @@
+package treeview
+
+import "image/color"
+
+// StreamState is the state of the watch stream that keeps an app's tree live
+type StreamState int
+
+const (
+	StreamUnknown StreamState = iota
+	StreamLive
+	StreamReconnecting
+	StreamDead
+)
+
+// streamNote is what the app's root row says about its watch stream
+type streamNote struct {
+	state StreamState
+	text  string
+}
+
+// SetStreamState sets the note shown after the app's root row about the
+// stream that keeps its tree live, e.g. "● live" or "stream stopped"
+func (v *TreeView) SetStreamState(appName string, state StreamState, text string) {
+	if v.streamNotes == nil {
+		v.streamNotes = make(map[string]streamNote)
+	}
+	v.streamNotes[appName] = streamNote{state: state, text: text}
+}
+
+// StreamState returns the stream state last set for the app
+func (v *TreeView) StreamState(appName string) StreamState {
+	return v.streamNotes[appName].state
+}
+
+// renderStreamNote renders the stream note after an app root's status, over
+// bg when the row is highlighted. Empty for other rows.
+func (v *TreeView) renderStreamNote(n *treeNode, bg color.Color) string {
+	if n.parent != nil || n.kind != "Application" {
+		return ""
+	}
+	note, ok := v.streamNotes[n.name]
+	if !ok || note.text == "" {
+		return ""
+	}
+	if bg != nil {
+		return v.styles.OnHighlight.Background(bg).Render(" " + note.text)
+	}
+	style := v.styles.Status
+	switch note.state {
+	case StreamReconnecting:
+		style = v.styles.Warning
+	case StreamDead:
+		style = v.styles.Danger
+	}
+	return style.Render(" " + note.text)
+}
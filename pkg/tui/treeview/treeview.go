@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"sort"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -39,8 +40,8 @@ type TreeView struct {
 	// Multi-app metadata for synthetic roots
 	appMeta map[string]struct{ health, sync string }
 
-	// Theme colors
-	palette theme.Palette
+	// Styles the rows are drawn with
+	styles theme.Styles
 
 	// Filter/search state
 	filterQuery  string // Current search query (empty = no filter)
@@ -59,6 +60,12 @@ type TreeView struct {
 
 	// Sort configuration for ordering siblings in the tree
 	sortConfig *model.SortConfig
+
+	// Incremented on each UpsertAppTree to mark the nodes it touched
+	generation int
+
+	// Watch stream state per app, shown after the app's root row
+	streamNotes map[string]streamNote
 }
 
 // ResourceSelection represents a selected resource for deletion
@@ -89,12 +96,17 @@ type treeNode struct {
 	namespace string
 	status    string
 	health    string
-	parent    *treeNode
-	children  []*treeNode
+	// Health message and creation time, read for Job and CronJob runs
+	healthMessage string
+	createdAt     *time.Time
+	parent        *treeNode
+	children      []*treeNode
+	// UpsertAppTree pass that last saw this node; stale nodes are dropped
+	generation int
 }
 
 // SortKey satisfies pkgsort.Sortable.
-func (n *treeNode) SortKey() model.SortKey {
+func (n *treeNode) SortKey(time.Time) model.SortKey {
 	return model.SortKey{Health: n.health, Sync: n.status, Kind: n.kind, Name: n.name}
 }
 
@@ -215,17 +227,31 @@ func (v *TreeView) SetSort(config model.SortConfig) {
 	v.rebuildOrderPreservingState()
 }
 
-// statusStyle returns a lipgloss style for the given status using theme colors
-func (v *TreeView) statusStyle(s string) lipgloss.Style {
+// healthStyle returns a lipgloss style for a health status using theme colors
+func (v *TreeView) healthStyle(s string) lipgloss.Style {
 	switch strings.ToLower(s) {
-	case "healthy", "running", "synced":
-		return lipgloss.NewStyle().Foreground(v.palette.Success)
+	case "healthy", "running":
+		return v.styles.Success
 	case "progressing", "pending":
-		return lipgloss.NewStyle().Foreground(v.palette.Progress)
+		return v.styles.Progress
 	case "degraded", "error", "crashloop":
-		return lipgloss.NewStyle().Foreground(v.palette.Danger)
+		return v.styles.Danger
+	case "missing", "suspended":
+		return v.styles.Warning
+	default:
+		return v.styles.Unknown
+	}
+}
+
+// syncStyle returns a lipgloss style for a sync status using theme colors
+func (v *TreeView) syncStyle(s string) lipgloss.Style {
+	switch strings.ToLower(s) {
+	case "synced":
+		return v.styles.Success
+	case "outofsync":
+		return v.styles.Danger
 	default:
-		return lipgloss.NewStyle().Foreground(v.palette.Unknown)
+		return v.styles.Unknown
 	}
 }
 
@@ -240,7 +266,7 @@ func NewTreeView(width, height int) *TreeView {
 		expanded:     make(map[string]bool),
 		selIdx:       0,
 		appMeta:      make(map[string]struct{ health, sync string }),
-		palette:      theme.Default(), // Start with default theme
+		styles:       theme.NewStyles(theme.Default()), // Start with default theme
 		selectedUIDs: make(map[string]bool),
 	}
 	tv.Model = tv // self
@@ -250,9 +276,15 @@ func NewTreeView(width, height int) *TreeView {
 // Init implements tea.Model; no async startup required
 func (v *TreeView) Init() tea.Cmd { return nil }
 
-// ApplyTheme updates the tree view's color palette
+// ApplyTheme draws the tree with the styles derived from a palette
 func (v *TreeView) ApplyTheme(palette theme.Palette) {
-	v.palette = palette
+	v.ApplyStyles(theme.NewStyles(palette))
+}
+
+// ApplyStyles draws the tree with the given styles, e.g. ones with
+// theme.Override applied
+func (v *TreeView) ApplyStyles(styles theme.Styles) {
+	v.styles = styles
 }
 
 // SetData converts api.ResourceTree to internal nodes and builds adjacency
@@ -269,48 +301,50 @@ func (v *TreeView) SetData(tree *api.ResourceTree) {
 	v.UpsertAppTree(v.appName, tree)
 }
 
-// UpsertAppTree replaces/adds a single application's tree under a synthetic root
+// UpsertAppTree replaces/adds a single application's tree under a synthetic root.
+// Incoming nodes are matched to the app's existing nodes by UID and updated in
+// place, so expansion, selection and search state survive stream updates and
+// large trees are not reallocated on every event.
 func (v *TreeView) UpsertAppTree(appName string, tree *api.ResourceTree) {
-	// Remove existing app entries
-	if keys, ok := v.nodesByApp[appName]; ok {
-		for _, k := range keys {
-			delete(v.nodesByUID, k)
-			delete(v.expanded, k)
-		}
-		delete(v.nodesByApp, appName)
-	}
-	if oldRoot, ok := v.rootByApp[appName]; ok {
-		idx := -1
-		for i, r := range v.roots {
-			if r == oldRoot {
-				idx = i
-				break
-			}
-		}
-		if idx >= 0 {
-			v.roots = append(v.roots[:idx], v.roots[idx+1:]...)
-		}
-		delete(v.rootByApp, appName)
-	}
+	snapshot := v.snapshotOrderState()
+	v.generation++
+	gen := v.generation
 
 	// Key scoping to avoid collisions across apps
 	makeKey := func(uid string) string { return appName + "::" + uid }
 
-	// First pass: build nodes for this app
-	nodesLocal := make(map[string]*treeNode)
+	// First pass: update or create nodes for this app
+	nodesLocal := make(map[string]*treeNode, len(tree.Nodes))
 	appKeys := make([]string, 0, len(tree.Nodes)+1)
 	for _, n := range tree.Nodes {
+		key := makeKey(n.UID)
+		tn, existing := v.nodesByUID[key]
+		if !existing {
+			tn = &treeNode{uid: key}
+			v.nodesByUID[key] = tn
+			v.expanded[key] = true // expand newly added nodes
+		}
 		ns := ""
 		if n.Namespace != nil {
 			ns = *n.Namespace
 		}
-		health := ""
+		health, healthMessage := "", ""
 		if n.Health != nil && n.Health.Status != nil {
 			health = *n.Health.Status
 		}
-		key := makeKey(n.UID)
-		tn := &treeNode{uid: key, group: n.Group, version: n.Version, kind: n.Kind, name: n.Name, status: n.Status, health: health, namespace: ns}
-		v.nodesByUID[key] = tn
+		if n.Health != nil && n.Health.Message != nil {
+			healthMessage = *n.Health.Message
+		}
+		tn.group, tn.version, tn.kind, tn.name, tn.namespace, tn.health = n.Group, n.Version, n.Kind, n.Name, ns, health
+		tn.healthMessage, tn.createdAt = healthMessage, n.CreatedAt
+		// Tree nodes rarely carry a sync status; keep the one applied by
+		// SetResourceStatuses rather than blanking it on every event
+		if n.Status != "" || !existing {
+			tn.status = n.Status
+		}
+		tn.parent = nil
+		tn.children = tn.children[:0]
+		tn.generation = gen
 		nodesLocal[key] = tn
 		appKeys = append(appKeys, key)
 	}
@@ -350,6 +384,7 @@ func (v *TreeView) UpsertAppTree(appName string, tree *api.ResourceTree) {
 				filtered = append(filtered, child)
 			}
 			delete(v.nodesByUID, node.uid)
+			delete(v.expanded, node.uid)
 			continue
 		}
 		filtered = append(filtered, node)
@@ -364,28 +399,57 @@ func (v *TreeView) UpsertAppTree(appName string, tree *api.ResourceTree) {
 		}
 	}
 
-	// Synthetic application root for this app
+	// Synthetic application root for this app, reused across updates
 	meta := v.appMeta[appName]
 	rootKey := makeKey("__app_root__")
-	root := &treeNode{uid: rootKey, kind: "Application", name: appName, status: meta.sync, health: meta.health}
+	root, existing := v.rootByApp[appName]
+	if !existing {
+		root = &treeNode{uid: rootKey, kind: "Application", name: appName}
+		v.nodesByUID[rootKey] = root
+		v.rootByApp[appName] = root
+		v.roots = append(v.roots, root)
+		v.expanded[rootKey] = true
+	}
+	root.status, root.health = meta.sync, meta.health
+	root.children = root.children[:0]
+	root.generation = gen
 	for _, r := range tempRoots {
 		r.parent = root
 		root.children = append(root.children, r)
 	}
-	v.nodesByUID[rootKey] = root
-	v.rootByApp[appName] = root
-	v.roots = append(v.roots, root)
-	appKeys = append(appKeys, rootKey)
-	v.nodesByApp[appName] = appKeys
 
-	// Expand newly added nodes
+	// Drop nodes that are no longer part of the app's tree
+	for _, k := range v.nodesByApp[appName] {
+		if node, ok := v.nodesByUID[k]; ok && node.generation != gen {
+			delete(v.nodesByUID, k)
+			delete(v.expanded, k)
+			delete(v.selectedUIDs, k)
+		}
+	}
+	live := appKeys[:0]
 	for _, k := range appKeys {
-		v.expanded[k] = true
+		if _, ok := v.nodesByUID[k]; ok {
+			live = append(live, k)
+		}
 	}
+	v.nodesByApp[appName] = append(live, rootKey)
 
 	// Stable root ordering by app name
-	sort.SliceStable(v.roots, func(i, j int) bool { return v.roots[i].name < v.roots[j].name })
+	if !existing {
+		sort.SliceStable(v.roots, func(i, j int) bool { return v.roots[i].name < v.roots[j].name })
+	}
 	v.rebuildOrder()
+	v.restoreOrderState(snapshot)
+	if v.filterQuery != "" {
+		// New nodes may match the active search; keep the current match if it survived
+		v.rebuildMatches()
+		for i, idx := range v.matchIndices {
+			if v.order[idx].uid == snapshot.currentUID {
+				v.currentMatch = i
+				break
+			}
+		}
+	}
 }
 
 // SetResourceStatuses updates sync and health status for nodes matching the given resources.
@@ -558,13 +622,13 @@ func (v *TreeView) Render() string {
 			disc = "▸ "
 		}
 
-		prefixStyled := lipgloss.NewStyle().Foreground(v.palette.Text).Render(prefix + disc)
+		prefixStyled := v.styles.Text.Render(prefix + disc)
 		label := v.renderLabel(n)
 		line := prefixStyled + label
 		if len(n.children) > 0 && !v.expanded[n.uid] {
 			hidden := countDescendants(n)
 			if hidden > 0 {
-				hint := lipgloss.NewStyle().Foreground(v.palette.Dim).Render(fmt.Sprintf(" (+%d)", hidden))
+				hint := v.styles.Status.Render(fmt.Sprintf(" (+%d)", hidden))
 				line += hint
 			}
 		}
@@ -578,14 +642,14 @@ func (v *TreeView) Render() string {
 			if n.namespace != "" {
 				name = fmt.Sprintf("%s/%s", n.namespace, n.name)
 			}
-			flashBG := v.palette.Success
+			flashBG := v.styles.RefreshFlash.GetBackground()
 			bgStyle := lipgloss.NewStyle().Background(flashBG)
-			ps := lipgloss.NewStyle().Foreground(v.palette.Text).Background(flashBG).Render(prefix + disc)
-			ks := lipgloss.NewStyle().Foreground(v.palette.Text).Background(flashBG).Render(n.kind)
-			ns := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(flashBG).Render("[" + name + "]")
+			ps := v.styles.Text.Background(flashBG).Render(prefix + disc)
+			ks := v.styles.Text.Background(flashBG).Render(n.kind)
+			ns := v.styles.OnHighlight.Background(flashBG).Render("[" + name + "]")
 			st := v.renderStatusPartNeutralBG(n, flashBG)
 			sp := bgStyle.Render(" ")
-			line = ps + ks + sp + ns + sp + st
+			line = ps + ks + sp + ns + sp + st + v.renderJobNote(n, flashBG) + v.renderStreamNote(n, flashBG)
 			line = padRightWithBG(line, v.innerWidth(), flashBG)
 		} else if v.desaturateMode {
 			// In desaturate mode: only highlight selected items, with scoped highlighting
@@ -596,10 +660,10 @@ func (v *TreeView) Render() string {
 				if n.namespace != "" {
 					name = fmt.Sprintf("%s/%s", n.namespace, n.name)
 				}
-				rowBG := v.palette.SelectedBG
+				rowBG := v.styles.Selected.GetBackground()
 				bgStyle := lipgloss.NewStyle().Background(rowBG)
 				// Prefix rendered WITHOUT background (will be dimmed by desaturateANSI)
-				ps := lipgloss.NewStyle().Foreground(v.palette.Text).Render(prefix + disc)
+				ps := v.styles.Text.Render(prefix + disc)
 				// Only resource text (kind, name, status) gets background.
 				// In desaturate mode the row's status uses the neutral
 				// text color rather than status hues — otherwise the
@@ -607,11 +671,11 @@ func (v *TreeView) Render() string {
 				// logic (the bg keeps the segment, but the saturated fg
 				// rides along) and "(Healthy)" / "(OutOfSync)" stay
 				// brightly colored under a popup.
-				ks := lipgloss.NewStyle().Foreground(v.palette.Text).Background(rowBG).Render(n.kind)
-				ns := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(rowBG).Render("[" + name + "]")
+				ks := v.styles.Text.Background(rowBG).Render(n.kind)
+				ns := v.styles.OnHighlight.Background(rowBG).Render("[" + name + "]")
 				st := v.renderStatusPartNeutralBG(n, rowBG)
 				sp := bgStyle.Render(" ")
-				line = ps + ks + sp + ns + sp + st
+				line = ps + ks + sp + ns + sp + st + v.renderJobNote(n, rowBG) + v.renderStreamNote(n, rowBG)
 				// NO padRightWithBG - don't extend highlight to full width
 			}
 			// else: cursor-only or regular line - keep default rendering (no special background)
@@ -626,25 +690,25 @@ func (v *TreeView) Render() string {
 				var rowBG color.Color
 				if isCursor && isSelected {
 					// Cursor on selected: distinct color to show both states
-					rowBG = v.palette.CursorSelectedBG
+					rowBG = v.styles.CursorOnSelected.GetBackground()
 				} else if isMatch {
 					// Search match (cursor or selected): use info color
-					rowBG = v.palette.Info
+					rowBG = v.styles.CursorOnMatch.GetBackground()
 				} else {
 					// Plain cursor or plain selected: use standard selection background
-					rowBG = v.palette.SelectedBG
+					rowBG = v.styles.Selected.GetBackground()
 				}
 				bgStyle := lipgloss.NewStyle().Background(rowBG)
-				ps := lipgloss.NewStyle().Foreground(v.palette.Text).Background(rowBG).Render(prefix + disc)
-				ks := lipgloss.NewStyle().Foreground(v.palette.Text).Background(rowBG).Render(n.kind)
-				ns := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(rowBG).Render("[" + name + "]")
+				ps := v.styles.Text.Background(rowBG).Render(prefix + disc)
+				ks := v.styles.Text.Background(rowBG).Render(n.kind)
+				ns := v.styles.OnHighlight.Background(rowBG).Render("[" + name + "]")
 				// Use the inverted/neutral fg for status too. The
 				// natural status hue (e.g. yellow for Suspended) can
 				// blend into rowBG and make the text disappear when
 				// the row is hovered/selected.
 				st := v.renderStatusPartNeutralBG(n, rowBG)
 				sp := bgStyle.Render(" ")
-				line = ps + ks + sp + ns + sp + st
+				line = ps + ks + sp + ns + sp + st + v.renderJobNote(n, rowBG) + v.renderStreamNote(n, rowBG)
 				line = padRightWithBG(line, v.innerWidth(), rowBG)
 			} else if isMatch {
 				// Non-selected, non-cursor match: highlight with warning background
@@ -652,14 +716,14 @@ func (v *TreeView) Render() string {
 				if n.namespace != "" {
 					name = fmt.Sprintf("%s/%s", n.namespace, n.name)
 				}
-				matchBG := v.palette.Warning
+				matchBG := v.styles.SearchMatch.GetBackground()
 				bgStyle := lipgloss.NewStyle().Background(matchBG)
-				ps := lipgloss.NewStyle().Foreground(v.palette.Text).Background(matchBG).Render(prefix + disc)
-				ks := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(matchBG).Render(n.kind)
-				ns := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(matchBG).Render("[" + name + "]")
+				ps := v.styles.Text.Background(matchBG).Render(prefix + disc)
+				ks := v.styles.OnHighlight.Background(matchBG).Render(n.kind)
+				ns := v.styles.OnHighlight.Background(matchBG).Render("[" + name + "]")
 				st := v.renderStatusPartNeutralBG(n, matchBG)
 				sp := bgStyle.Render(" ")
-				line = ps + ks + sp + ns + sp + st
+				line = ps + ks + sp + ns + sp + st + v.renderJobNote(n, matchBG) + v.renderStreamNote(n, matchBG)
 				line = padRightWithBG(line, v.innerWidth(), matchBG)
 			}
 		}
@@ -682,34 +746,36 @@ func (v *TreeView) renderLabel(n *treeNode) string {
 	}
 	st := v.renderStatusPart(n)
 	// Only the bracketed name should be gray/dim
-	nameStyled := lipgloss.NewStyle().Foreground(v.palette.Dim).Render("[" + name + "]")
-	kindStyled := lipgloss.NewStyle().Foreground(v.palette.Text).Render(n.kind)
-	return fmt.Sprintf("%s %s %s", kindStyled, nameStyled, st)
+	nameStyled := v.styles.Status.Render("[" + name + "]")
+	kindStyled := v.styles.Text.Render(n.kind)
+	return fmt.Sprintf("%s %s %s", kindStyled, nameStyled, st) + v.renderJobNote(n, nil) + v.renderStreamNote(n, nil)
 }
 
-// renderStatusPart returns styled status string showing health and/or sync status
-func (v *TreeView) renderStatusPart(n *treeNode) string {
-	health := n.health
-	sync := n.status
+// noHealthLabel is shown for resources that report neither health nor sync
+// status, so rows never end in a blank where the status normally sits.
+const noHealthLabel = "(no health)"
 
-	// Both present and different: show both
-	if health != "" && sync != "" && !strings.EqualFold(health, sync) {
-		healthStyled := v.statusStyle(health).Render(health)
-		syncStyled := v.statusStyle(sync).Render(sync)
-		return fmt.Sprintf("(%s, %s)", healthStyled, syncStyled)
+// renderStatusPart returns the node's health and sync status as separate
+// badges, each colored on its own like in the Argo CD UI, e.g.
+// "(Healthy) (OutOfSync)". Resources Argo CD does not manage have no sync
+// status and kinds without a health check have no health, so either badge
+// may be missing.
+func (v *TreeView) renderStatusPart(n *treeNode) string {
+	var badges []string
+	if n.health != "" {
+		badges = append(badges, v.healthStyle(n.health).Render("("+n.health+")"))
 	}
-	// Only health present (or both same)
-	if health != "" {
-		return v.statusStyle(health).Render(fmt.Sprintf("(%s)", health))
+	if n.status != "" {
+		badges = append(badges, v.syncStyle(n.status).Render("("+n.status+")"))
 	}
-	// Only sync present
-	if sync != "" {
-		return v.statusStyle(sync).Render(fmt.Sprintf("(%s)", sync))
+	if len(badges) == 0 {
+		// Neither: Argo CD does not assess health for this kind
+		return v.styles.Status.Render(noHealthLabel)
 	}
-	return ""
+	return strings.Join(badges, " ")
 }
 
-// renderStatusPartNeutralBG renders the status with a contrasting,
+// renderStatusPartNeutralBG renders the status badges with a contrasting,
 // non-status-hue foreground over the given background. Used when the
 // row already conveys highlight via bg — keeping the status fg in its
 // natural green/red/yellow either makes the text unreadable against
@@ -717,21 +783,19 @@ func (v *TreeView) renderStatusPart(n *treeNode) string {
 // saturated patch that survives outer dimming. Mirrors the contrast
 // treatment used for the "[name]" portion of selected rows.
 func (v *TreeView) renderStatusPartNeutralBG(n *treeNode, bg color.Color) string {
-	health := n.health
-	sync := n.status
+	textStyle := v.styles.OnHighlight.Background(bg)
 
-	textStyle := lipgloss.NewStyle().Foreground(v.palette.DarkBG).Background(bg)
-
-	if health != "" && sync != "" && !strings.EqualFold(health, sync) {
-		return textStyle.Render(fmt.Sprintf("(%s, %s)", health, sync))
+	var badges []string
+	if n.health != "" {
+		badges = append(badges, "("+n.health+")")
 	}
-	if health != "" {
-		return textStyle.Render(fmt.Sprintf("(%s)", health))
+	if n.status != "" {
+		badges = append(badges, "("+n.status+")")
 	}
-	if sync != "" {
-		return textStyle.Render(fmt.Sprintf("(%s)", sync))
+	if len(badges) == 0 {
+		return textStyle.Render(noHealthLabel)
 	}
-	return ""
+	return textStyle.Render(strings.Join(badges, " "))
 }
 
 func (v *TreeView) innerWidth() int {
@@ -978,11 +1042,33 @@ func (v *TreeView) SelectedResource() (group, kind, namespace, name string, ok b
 	return node.group, node.kind, node.namespace, node.name, true
 }
 
+// SelectedStatus returns the health and sync status of the currently selected node.
+func (v *TreeView) SelectedStatus() (health, sync string) {
+	if v.selIdx < 0 || v.selIdx >= len(v.order) {
+		return "", ""
+	}
+	node := v.order[v.selIdx]
+	if node == nil {
+		return "", ""
+	}
+	return node.health, node.status
+}
+
 // GetAppName returns the name of the application being displayed.
 func (v *TreeView) GetAppName() string {
 	return v.appName
 }
 
+// AppNames returns the applications whose trees are shown, sorted by name.
+func (v *TreeView) AppNames() []string {
+	names := make([]string, 0, len(v.rootByApp))
+	for name := range v.rootByApp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // IsSelectedSyntheticRoot returns true if the currently selected node is a synthetic
 // Application root node (i.e., represents the app being viewed, not a child Application CR).
 func (v *TreeView) IsSelectedSyntheticRoot() bool {
@@ -1106,6 +1192,28 @@ func (v *TreeView) GetSelectedResources() []ResourceSelection {
 	return result
 }
 
+// CurrentResource returns the resource under the cursor, ignoring any
+// explicit selections. Returns ok=false on Application nodes.
+func (v *TreeView) CurrentResource() (ResourceSelection, bool) {
+	if v.selIdx < 0 || v.selIdx >= len(v.order) {
+		return ResourceSelection{}, false
+	}
+	node := v.order[v.selIdx]
+	if node == nil || node.kind == "Application" {
+		return ResourceSelection{}, false
+	}
+	return ResourceSelection{
+		AppName:   v.nodeAppName(node),
+		Group:     node.group,
+		Version:   node.version,
+		Kind:      node.kind,
+		Namespace: node.namespace,
+		Name:      node.name,
+		Status:    node.status,
+		Health:    node.health,
+	}, true
+}
+
 // ClearSelection clears all resource selections.
 func (v *TreeView) ClearSelection() {
 	v.selectedUIDs = make(map[string]bool)
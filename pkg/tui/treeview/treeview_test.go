@@ -3,6 +3,9 @@ package treeview
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"charm.land/lipgloss/v2"
 
 	"github.com/darksworm/argonaut/pkg/api"
 	model "github.com/darksworm/argonaut/pkg/model"
@@ -12,110 +15,53 @@ import (
 // TestRenderStatusPart verifies the status display logic:
 // - Health only → "(Healthy)"
 // - Sync only → "(Synced)"
-// - Both different → "(Healthy, OutOfSync)"
-// - Both same → just one "(Healthy)"
-// - Neither → empty string
+// - Both → separate badges, health first: "(Healthy) (OutOfSync)"
+// - Neither → "(no health)" placeholder
 func TestRenderStatusPart(t *testing.T) {
 	tests := []struct {
-		name       string
-		health     string
-		sync       string
-		wantHealth bool // expect health value in output
-		wantSync   bool // expect sync value in output
-		wantBoth   bool // expect comma-separated format
-		wantEmpty  bool // expect empty string
+		name   string
+		health string
+		sync   string
+		want   string
 	}{
-		{
-			name:       "health only",
-			health:     "Healthy",
-			sync:       "",
-			wantHealth: true,
-		},
-		{
-			name:     "sync only",
-			health:   "",
-			sync:     "OutOfSync",
-			wantSync: true,
-		},
-		{
-			name:       "both different",
-			health:     "Healthy",
-			sync:       "OutOfSync",
-			wantHealth: true,
-			wantSync:   true,
-			wantBoth:   true,
-		},
-		{
-			name:       "both same (Healthy/Healthy)",
-			health:     "Healthy",
-			sync:       "Healthy",
-			wantHealth: true,
-			wantSync:   false, // should not duplicate
-		},
-		{
-			name:       "both same case-insensitive",
-			health:     "Synced",
-			sync:       "synced",
-			wantHealth: true,
-			wantSync:   false, // should not duplicate due to EqualFold
-		},
-		{
-			name:      "neither present",
-			health:    "",
-			sync:      "",
-			wantEmpty: true,
-		},
-		{
-			name:       "degraded health with OutOfSync",
-			health:     "Degraded",
-			sync:       "OutOfSync",
-			wantHealth: true,
-			wantSync:   true,
-			wantBoth:   true,
-		},
+		{name: "health only", health: "Healthy", want: "(Healthy)"},
+		{name: "sync only", sync: "OutOfSync", want: "(OutOfSync)"},
+		{name: "both", health: "Healthy", sync: "OutOfSync", want: "(Healthy) (OutOfSync)"},
+		{name: "both good", health: "Healthy", sync: "Synced", want: "(Healthy) (Synced)"},
+		{name: "degraded health with OutOfSync", health: "Degraded", sync: "OutOfSync", want: "(Degraded) (OutOfSync)"},
+		{name: "neither present shows placeholder", want: noHealthLabel},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := NewTreeView(100, 20)
 			v.ApplyTheme(theme.Default())
+			node := &treeNode{health: tt.health, status: tt.sync}
 
-			node := &treeNode{
-				health: tt.health,
-				status: tt.sync,
+			if got := stripANSI(v.renderStatusPart(node)); got != tt.want {
+				t.Errorf("renderStatusPart = %q, want %q", got, tt.want)
 			}
-
-			result := v.renderStatusPart(node)
-
-			// Strip ANSI codes for easier testing
-			plain := stripANSI(result)
-
-			if tt.wantEmpty {
-				if result != "" {
-					t.Errorf("expected empty string, got %q", plain)
-				}
-				return
-			}
-
-			if tt.wantHealth && !strings.Contains(plain, tt.health) {
-				t.Errorf("expected health %q in output %q", tt.health, plain)
-			}
-
-			if tt.wantSync && !strings.Contains(plain, tt.sync) {
-				t.Errorf("expected sync %q in output %q", tt.sync, plain)
+			if got := stripANSI(v.renderStatusPartNeutralBG(node, v.styles.Selected.GetBackground())); got != tt.want {
+				t.Errorf("renderStatusPartNeutralBG = %q, want %q", got, tt.want)
 			}
+		})
+	}
+}
 
-			if tt.wantBoth {
-				if !strings.Contains(plain, ",") {
-					t.Errorf("expected comma separator for both statuses, got %q", plain)
-				}
-			}
+// TestRenderStatusPart_ColorsBadgesIndependently checks that a healthy but
+// out-of-sync resource shows a healthy badge next to an out-of-sync one
+func TestRenderStatusPart_ColorsBadgesIndependently(t *testing.T) {
+	v := NewTreeView(100, 20)
+	v.ApplyTheme(theme.Default())
 
-			// Verify parentheses format
-			if !strings.HasPrefix(plain, "(") || !strings.HasSuffix(plain, ")") {
-				t.Errorf("expected parentheses wrapping, got %q", plain)
-			}
-		})
+	out := v.renderStatusPart(&treeNode{health: "Healthy", status: "OutOfSync"})
+	health := v.healthStyle("Healthy").Render("(Healthy)")
+	sync := v.syncStyle("OutOfSync").Render("(OutOfSync)")
+	if out != health+" "+sync {
+		t.Fatalf("badges should carry their own colors:\n got %q\nwant %q", out, health+" "+sync)
+	}
+	if v.healthStyle("Healthy").Render("x") == v.syncStyle("OutOfSync").Render("x") {
+		t.Fatal("healthy and out of sync should not share a color")
 	}
 }
 
@@ -198,6 +144,35 @@ func TestDiscriminatorArrow(t *testing.T) {
 	}
 }
 
+// TestApplyStyles_DrawsWithOverrides verifies an embedder's style overrides
+// reach the rows: status colors and the selection background
+func TestApplyStyles_DrawsWithOverrides(t *testing.T) {
+	brand := lipgloss.Color("#123456")
+	v := NewTreeView(100, 20)
+	v.ApplyStyles(theme.NewStyles(theme.Default(),
+		theme.Override(theme.Success, func(s lipgloss.Style) lipgloss.Style { return s.Foreground(brand) }),
+		theme.Override(theme.Selected, func(s lipgloss.Style) lipgloss.Style { return s.Background(brand) }),
+	))
+	root := &treeNode{uid: "root", kind: "Application", name: "app", health: "Healthy"}
+	pod := &treeNode{uid: "pod", kind: "Pod", name: "web-0", health: "Healthy", parent: root}
+	root.children = []*treeNode{pod}
+	v.nodesByUID = map[string]*treeNode{"root": root, "pod": pod}
+	v.roots = []*treeNode{root}
+	v.expanded = map[string]bool{"root": true}
+	v.rebuildOrder()
+
+	lines := strings.Split(v.Render(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], "48;2;18;52;86") {
+		t.Errorf("the cursor row should use the overridden selection background: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "38;2;18;52;86") {
+		t.Errorf("Healthy should use the overridden success style: %q", lines[1])
+	}
+}
+
 // TestCollapsedNodeShowsCount verifies that collapsed nodes show "(+N)" count
 func TestCollapsedNodeShowsCount(t *testing.T) {
 	v := NewTreeView(100, 20)
@@ -462,6 +437,86 @@ func TestUpsertAppTree_DifferentApplicationNodePreserved(t *testing.T) {
 	}
 }
 
+// TestUpsertAppTree_PreservesStateAcrossUpdates verifies that a repeated
+// upsert (as sent by the tree stream) reuses nodes by UID and keeps
+// expansion, the selected node and multi-selection.
+func TestUpsertAppTree_PreservesStateAcrossUpdates(t *testing.T) {
+	v := NewTreeView(100, 20)
+	v.ApplyTheme(theme.Default())
+	healthy := "Healthy"
+
+	tree := func(extra ...api.ResourceNode) *api.ResourceTree {
+		nodes := []api.ResourceNode{
+			{UID: "deploy", Group: "apps", Kind: "Deployment", Name: "web"},
+			{UID: "rs", Group: "apps", Kind: "ReplicaSet", Name: "web-1", ParentRefs: []api.ResourceRef{{UID: "deploy"}}},
+			{UID: "svc", Kind: "Service", Name: "web"},
+		}
+		return &api.ResourceTree{Nodes: append(nodes, extra...)}
+	}
+	v.UpsertAppTree("app", tree(api.ResourceNode{UID: "cm", Kind: "ConfigMap", Name: "old"}))
+	deploy := v.nodesByUID["app::deploy"]
+	v.SetResourceStatuses("app", []api.ResourceStatus{{Group: "apps", Kind: "Deployment", Name: "web", Status: "OutOfSync"}})
+
+	// Collapse the Deployment, select the Service, mark the ConfigMap
+	v.expanded["app::deploy"] = false
+	v.rebuildOrder()
+	v.SetSelectedIndex(v.indexOf(v.nodesByUID["app::svc"]))
+	v.selectedUIDs["app::cm"] = true
+	v.selectedUIDs["app::svc"] = true
+
+	// The stream drops the ConfigMap, adds a Secret that sorts above the Service
+	// and reports the Deployment healthy
+	next := tree(api.ResourceNode{UID: "secret", Kind: "Secret", Name: "a"})
+	next.Nodes[0].Health = &api.ResourceHealth{Status: &healthy}
+	v.UpsertAppTree("app", next)
+
+	if v.nodesByUID["app::deploy"] != deploy {
+		t.Error("existing node should be updated in place")
+	}
+	if deploy.health != "Healthy" || deploy.status != "OutOfSync" {
+		t.Errorf("deployment health/status = %q/%q", deploy.health, deploy.status)
+	}
+	if v.expanded["app::deploy"] {
+		t.Error("collapsed node should stay collapsed")
+	}
+	if !v.expanded["app::secret"] {
+		t.Error("new nodes should be expanded")
+	}
+	if v.SelectedUID != "app::svc" || v.order[v.selIdx].uid != "app::svc" {
+		t.Errorf("selection should follow the Service, got %q", v.SelectedUID)
+	}
+	if _, ok := v.nodesByUID["app::cm"]; ok {
+		t.Error("removed node should be dropped")
+	}
+	if v.selectedUIDs["app::cm"] || !v.selectedUIDs["app::svc"] {
+		t.Errorf("selection marks = %v", v.selectedUIDs)
+	}
+	if got := len(v.nodesByApp["app"]); got != 5 {
+		t.Errorf("expected 4 nodes plus the root for app, got %d", got)
+	}
+	if len(v.roots) != 1 {
+		t.Errorf("synthetic root should be reused, got %d roots", len(v.roots))
+	}
+}
+
+// TestUpsertAppTree_FilterPicksUpNewNodes verifies that an active search
+// includes matching nodes added by a stream update.
+func TestUpsertAppTree_FilterPicksUpNewNodes(t *testing.T) {
+	v := NewTreeView(100, 20)
+	v.UpsertAppTree("app", &api.ResourceTree{Nodes: []api.ResourceNode{{UID: "a", Kind: "Pod", Name: "web-a"}}})
+	v.SetFilter("web")
+	if v.MatchCount() != 1 {
+		t.Fatalf("expected 1 match, got %d", v.MatchCount())
+	}
+	v.UpsertAppTree("app", &api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "a", Kind: "Pod", Name: "web-a"},
+		{UID: "b", Kind: "Pod", Name: "web-b"},
+	}})
+	if v.MatchCount() != 2 {
+		t.Errorf("expected 2 matches after update, got %d", v.MatchCount())
+	}
+}
+
 // TestSetSortHealth verifies that SetSort with SortFieldHealth re-orders siblings
 // so that Degraded nodes appear before Healthy ones when ascending.
 func TestSetSortHealth(t *testing.T) {
@@ -663,3 +718,118 @@ func stripANSI(s string) string {
 	}
 	return result.String()
 }
+
+func TestJobRuns_CronJobLastRun(t *testing.T) {
+	v := NewTreeView(120, 20)
+	v.ApplyTheme(theme.Default())
+	ns := "ops"
+	str := func(s string) *string { return &s }
+	at := func(h int) *time.Time { t := time.Date(2025, 1, 1, h, 0, 0, 0, time.UTC); return &t }
+	ref := func(kind, uid string) []api.ResourceRef {
+		return []api.ResourceRef{{Group: "batch", Kind: kind, UID: uid}}
+	}
+	tree := &api.ResourceTree{Nodes: []api.ResourceNode{
+		{UID: "cj", Group: "batch", Kind: "CronJob", Name: "backup", Namespace: &ns},
+		{UID: "j1", Group: "batch", Kind: "Job", Name: "backup-1", Namespace: &ns, ParentRefs: ref("CronJob", "cj"), CreatedAt: at(1),
+			Health: &api.ResourceHealth{Status: str("Healthy")}},
+		{UID: "j2", Group: "batch", Kind: "Job", Name: "backup-2", Namespace: &ns, ParentRefs: ref("CronJob", "cj"), CreatedAt: at(2),
+			Health: &api.ResourceHealth{Status: str("Degraded"), Message: str("Job has reached the specified backoff limit")}},
+		{UID: "p1", Kind: "Pod", Name: "backup-2-a", Namespace: &ns, ParentRefs: ref("Job", "j2"), CreatedAt: at(2),
+			Health: &api.ResourceHealth{Status: str("Degraded")}},
+		{UID: "p2", Kind: "Pod", Name: "backup-2-b", Namespace: &ns, ParentRefs: ref("Job", "j2"), CreatedAt: at(3),
+			Health: &api.ResourceHealth{Status: str("Degraded")}},
+	}}
+	v.UpsertAppTree("ops", tree)
+
+	v.SetSelectedIndex(1) // CronJob (0 is the synthetic Application root)
+	runs, ok := v.SelectedJobRuns()
+	if !ok {
+		t.Fatal("a CronJob should report its runs")
+	}
+	if runs.Phase != "Failed" || runs.FailedPods != 2 || !runs.LastRun.Equal(*at(2)) {
+		t.Errorf("last run should be the newest Job, got %+v", runs)
+	}
+	if runs.Message != "Job has reached the specified backoff limit" {
+		t.Errorf("message = %q", runs.Message)
+	}
+	if runs.LatestPod == nil || runs.LatestPod.Name != "backup-2-b" || runs.LatestPod.AppName != "ops" {
+		t.Errorf("latest pod = %+v, want backup-2-b", runs.LatestPod)
+	}
+
+	if out := v.Render(); !strings.Contains(out, "2 failed pods") || !strings.Contains(out, "last run Failed") {
+		t.Errorf("CronJob row should note its last run, got:\n%s", out)
+	}
+
+	v.SetSelectedIndex(4) // a Pod of backup-2
+	if _, ok := v.SelectedJobRuns(); ok {
+		t.Error("Pods have no job runs")
+	}
+}
+
+// TestFollowProgressing checks that follow picks the lowest Progressing
+// resource, opens the nodes above it, stays on it while it progresses and
+// moves on once it is done
+func TestFollowProgressing(t *testing.T) {
+	v := NewTreeView(100, 20)
+	v.ApplyTheme(theme.Default())
+	progressing, healthy := "Progressing", "Healthy"
+	status := func(s string) *api.ResourceHealth { return &api.ResourceHealth{Status: &s} }
+
+	tree := func(pods ...api.ResourceNode) *api.ResourceTree {
+		nodes := []api.ResourceNode{
+			{UID: "deploy", Group: "apps", Kind: "Deployment", Name: "web", Health: status(progressing)},
+			{UID: "rs", Group: "apps", Kind: "ReplicaSet", Name: "web-2", Health: status(progressing), ParentRefs: []api.ResourceRef{{UID: "deploy"}}},
+			{UID: "svc", Kind: "Service", Name: "web", Health: status(healthy)},
+		}
+		return &api.ResourceTree{Nodes: append(nodes, pods...)}
+	}
+	pod := func(name, health string) api.ResourceNode {
+		return api.ResourceNode{UID: name, Kind: "Pod", Name: name, Health: status(health), ParentRefs: []api.ResourceRef{{UID: "rs"}}}
+	}
+
+	v.UpsertAppTree("app", tree(pod("web-2-a", healthy), pod("web-2-b", progressing)))
+	v.expanded["app::deploy"] = false
+	v.rebuildOrder()
+	v.SetSelectedIndex(v.indexOf(v.nodesByUID["app::svc"]))
+
+	if !v.FollowProgressing() {
+		t.Fatal("expected something to follow")
+	}
+	if v.SelectedUID != "app::web-2-b" || v.order[v.selIdx].uid != "app::web-2-b" {
+		t.Fatalf("cursor should be on the progressing pod, got %q", v.SelectedUID)
+	}
+	if !v.expanded["app::deploy"] {
+		t.Error("nodes above the followed pod should be expanded")
+	}
+
+	// A newer pod starts; the cursor stays on the one still progressing
+	v.UpsertAppTree("app", tree(pod("web-2-a", healthy), pod("web-2-b", progressing), pod("web-2-0", progressing)))
+	v.FollowProgressing()
+	if v.SelectedUID != "app::web-2-b" {
+		t.Errorf("cursor should stay on its progressing pod, got %q", v.SelectedUID)
+	}
+
+	// The pod is ready; the cursor moves to the one still starting
+	v.UpsertAppTree("app", tree(pod("web-2-a", healthy), pod("web-2-b", healthy), pod("web-2-0", progressing)))
+	v.FollowProgressing()
+	if v.SelectedUID != "app::web-2-0" {
+		t.Errorf("cursor should move to the next progressing pod, got %q", v.SelectedUID)
+	}
+
+	// Only the ReplicaSet progresses now, with no pods below it
+	v.UpsertAppTree("app", tree())
+	v.FollowProgressing()
+	if v.SelectedUID != "app::rs" {
+		t.Errorf("cursor should fall back to the ReplicaSet, got %q", v.SelectedUID)
+	}
+
+	done := tree()
+	done.Nodes[0].Health, done.Nodes[1].Health = status(healthy), status(healthy)
+	v.UpsertAppTree("app", done)
+	if v.FollowProgressing() {
+		t.Error("nothing progresses, follow should report so")
+	}
+	if v.SelectedUID != "app::rs" {
+		t.Errorf("cursor should stay when nothing progresses, got %q", v.SelectedUID)
+	}
+}
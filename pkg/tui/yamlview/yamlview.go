@@ -0,0 +1,453 @@
+// Package yamlview shows a YAML document as a list of lines whose maps and
+// sequences can be folded, and tells the path of the line under the cursor.
+package yamlview
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/darksworm/argonaut/pkg/theme"
+	"gopkg.in/yaml.v3"
+)
+
+// indentWidth is how many spaces each level of nesting is indented by
+const indentWidth = 2
+
+// line is one rendered line of the document
+type line struct {
+	depth  int    // nesting level, for the indentation
+	prefix string // "- " when the line starts a sequence item
+	key    string // map key; empty for sequence scalars and block text
+	value  string // scalar, "{}" or "[]"; empty when the children follow
+	text   bool   // a line of a multi-line string
+	path   string // path of the value on this line
+	parent int    // index of the enclosing line, -1 at the top level
+	end    int    // index of the last line folded with this one
+	folded string // shown in place of the children when folded: "{…}", "[…]" or "…"
+}
+
+func (l line) foldable(i int) bool { return l.end > i }
+
+// Viewer shows a YAML document with folding. Lines are addressed by their
+// index in the whole document; folded lines hide the lines up to their end.
+type Viewer struct {
+	lines   []line
+	folded  map[int]bool
+	visible []int // indexes of the lines not hidden by a fold
+	cursor  int   // index into visible
+	offset  int   // first visible row shown
+
+	width  int
+	height int
+
+	styles theme.Styles
+}
+
+// New builds a viewer for a parsed YAML document or node
+func New(doc *yaml.Node) *Viewer {
+	v := &Viewer{folded: make(map[int]bool), styles: theme.NewStyles(theme.Default())}
+	if doc != nil && doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc != nil {
+		v.walk(doc, 0, "", "", -1)
+	}
+	v.rebuild()
+	return v
+}
+
+// ApplyTheme draws the viewer with the styles derived from a palette
+func (v *Viewer) ApplyTheme(palette theme.Palette) {
+	v.ApplyStyles(theme.NewStyles(palette))
+}
+
+// ApplyStyles draws the viewer with the given styles: Key for keys, Status
+// for fold markers and Cursor for the cursor line
+func (v *Viewer) ApplyStyles(styles theme.Styles) {
+	v.styles = styles
+}
+
+// SetSize sets the width and number of rows the viewer renders
+func (v *Viewer) SetSize(width, height int) {
+	v.width, v.height = width, max(1, height)
+	v.scrollToCursor()
+}
+
+// walk appends the lines of node. prefix is put before the first line,
+// for map items of a sequence.
+func (v *Viewer) walk(node *yaml.Node, depth int, path, prefix string, parent int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			v.lines = append(v.lines, line{depth: depth, prefix: prefix, value: "{}", path: path, parent: parent})
+			v.lines[len(v.lines)-1].end = len(v.lines) - 1
+			return
+		}
+		keyDepth := depth
+		if prefix != "" {
+			keyDepth++ // keys after the first align with it, past the "- "
+		}
+		item := -1 // the first line of a sequence item stands for the whole item
+		if prefix != "" {
+			item = len(v.lines)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			d, p, par := keyDepth, "", parent
+			if i == 0 {
+				d, p = depth, prefix
+			} else if item >= 0 {
+				par = item
+			}
+			v.pair(node.Content[i], node.Content[i+1], d, keyDepth+1, path, p, par)
+		}
+		if item >= 0 {
+			v.lines[item].end = len(v.lines) - 1
+			if v.lines[item].folded == "" {
+				v.lines[item].folded = "{…}"
+			}
+		}
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			v.lines = append(v.lines, line{depth: depth, prefix: prefix, value: "[]", path: path, parent: parent})
+			v.lines[len(v.lines)-1].end = len(v.lines) - 1
+			return
+		}
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch item.Kind {
+			case yaml.MappingNode:
+				v.walk(item, depth, itemPath, "- ", parent)
+			case yaml.SequenceNode:
+				idx := len(v.lines)
+				v.lines = append(v.lines, line{depth: depth, prefix: "-", path: itemPath, parent: parent, folded: "[…]"})
+				v.walk(item, depth+1, itemPath, "", idx)
+				v.lines[idx].end = len(v.lines) - 1
+			default:
+				v.scalar(item, depth, "- ", "", itemPath, parent)
+			}
+		}
+	default:
+		v.scalar(node, depth, prefix, "", path, parent)
+	}
+}
+
+// pair appends a key and its value. The key's line sits at depth, nested
+// values at childDepth.
+func (v *Viewer) pair(k, val *yaml.Node, depth, childDepth int, path, prefix string, parent int) {
+	key := scalarText(k)
+	keyPath := joinPath(path, k.Value)
+	switch {
+	case val.Kind == yaml.MappingNode && len(val.Content) > 0:
+		idx := len(v.lines)
+		v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, path: keyPath, parent: parent, folded: "{…}"})
+		v.walk(val, childDepth, keyPath, "", idx)
+		v.lines[idx].end = len(v.lines) - 1
+	case val.Kind == yaml.SequenceNode && len(val.Content) > 0:
+		idx := len(v.lines)
+		v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, path: keyPath, parent: parent, folded: "[…]"})
+		v.walk(val, childDepth, keyPath, "", idx)
+		v.lines[idx].end = len(v.lines) - 1
+	case val.Kind == yaml.MappingNode:
+		v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, value: "{}", path: keyPath, parent: parent})
+		v.lines[len(v.lines)-1].end = len(v.lines) - 1
+	case val.Kind == yaml.SequenceNode:
+		v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, value: "[]", path: keyPath, parent: parent})
+		v.lines[len(v.lines)-1].end = len(v.lines) - 1
+	default:
+		v.scalar(val, depth, prefix, key, keyPath, parent)
+	}
+}
+
+// scalar appends a scalar value; multi-line strings become a "|" line
+// followed by their text, which can be folded
+func (v *Viewer) scalar(n *yaml.Node, depth int, prefix, key, path string, parent int) {
+	idx := len(v.lines)
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" || !strings.Contains(strings.TrimSuffix(n.Value, "\n"), "\n") {
+		v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, value: scalarText(n), path: path, parent: parent, end: idx})
+		return
+	}
+	block := "|-"
+	if strings.HasSuffix(n.Value, "\n") {
+		block = "|"
+	}
+	v.lines = append(v.lines, line{depth: depth, prefix: prefix, key: key, value: block, path: path, parent: parent, folded: "…"})
+	textDepth := depth + 1
+	if prefix != "" && key != "" {
+		textDepth++
+	}
+	for _, t := range strings.Split(strings.TrimSuffix(n.Value, "\n"), "\n") {
+		v.lines = append(v.lines, line{depth: textDepth, value: t, text: true, path: path, parent: idx, end: len(v.lines)})
+	}
+	v.lines[idx].end = len(v.lines) - 1
+}
+
+// scalarText renders a scalar the way it would appear in YAML, quoted only
+// when it has to be
+func scalarText(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return "*" + n.Value
+	case yaml.ScalarNode:
+	default:
+		return ""
+	}
+	plain := &yaml.Node{Kind: yaml.ScalarNode, Tag: n.Tag, Value: n.Value}
+	out, err := yaml.Marshal(plain)
+	if err != nil {
+		return n.Value
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// plainKey matches the keys a path can name without quoting
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// joinPath appends a map key to a path: spec.replicas, or
+// metadata.labels["app.kubernetes.io/name"] for keys with dots or slashes
+func joinPath(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// rebuild recomputes the visible lines after folding changed, keeping the
+// cursor on the same line, or on the fold that now hides it
+func (v *Viewer) rebuild() {
+	current := -1
+	if v.cursor < len(v.visible) {
+		current = v.visible[v.cursor]
+	}
+	v.visible = v.visible[:0]
+	for i := 0; i < len(v.lines); i++ {
+		v.visible = append(v.visible, i)
+		if v.folded[i] {
+			i = v.lines[i].end
+		}
+	}
+	v.cursor = 0
+	for row, i := range v.visible {
+		if i <= current {
+			v.cursor = row
+		}
+	}
+	v.scrollToCursor()
+}
+
+func (v *Viewer) scrollToCursor() {
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.height > 0 && v.cursor >= v.offset+v.height {
+		v.offset = v.cursor - v.height + 1
+	}
+	v.offset = max(0, min(v.offset, len(v.visible)-v.height))
+}
+
+// LineCount returns the number of lines of the unfolded document
+func (v *Viewer) LineCount() int { return len(v.lines) }
+
+// Move moves the cursor by delta visible lines
+func (v *Viewer) Move(delta int) {
+	v.cursor = max(0, min(len(v.visible)-1, v.cursor+delta))
+	v.scrollToCursor()
+}
+
+// Top moves the cursor to the first line
+func (v *Viewer) Top() { v.Move(-len(v.visible)) }
+
+// Bottom moves the cursor to the last visible line
+func (v *Viewer) Bottom() { v.Move(len(v.visible)) }
+
+// PageSize is how many lines a page up or down moves
+func (v *Viewer) PageSize() int { return max(1, v.height-1) }
+
+func (v *Viewer) current() int {
+	if v.cursor < len(v.visible) {
+		return v.visible[v.cursor]
+	}
+	return -1
+}
+
+// Fold folds the map, sequence or text under the cursor. On a line that
+// cannot be folded, or one already folded, it moves to the enclosing line.
+func (v *Viewer) Fold() {
+	i := v.current()
+	if i < 0 {
+		return
+	}
+	if v.lines[i].foldable(i) && !v.folded[i] {
+		v.folded[i] = true
+		v.rebuild()
+		return
+	}
+	if p := v.lines[i].parent; p >= 0 {
+		for row, idx := range v.visible {
+			if idx == p {
+				v.cursor = row
+			}
+		}
+		v.scrollToCursor()
+	}
+}
+
+// Unfold shows the children of the folded line under the cursor
+func (v *Viewer) Unfold() {
+	if i := v.current(); i >= 0 && v.folded[i] {
+		delete(v.folded, i)
+		v.rebuild()
+	}
+}
+
+// Toggle folds or unfolds the line under the cursor
+func (v *Viewer) Toggle() {
+	i := v.current()
+	if i < 0 || !v.lines[i].foldable(i) {
+		return
+	}
+	if v.folded[i] {
+		v.Unfold()
+	} else {
+		v.Fold()
+	}
+}
+
+// FoldAll folds every top-level value, leaving an outline of the document
+func (v *Viewer) FoldAll() {
+	for i, l := range v.lines {
+		if l.parent < 0 && l.foldable(i) {
+			v.folded[i] = true
+		}
+	}
+	v.rebuild()
+}
+
+// UnfoldAll shows every line
+func (v *Viewer) UnfoldAll() {
+	v.folded = make(map[int]bool)
+	v.rebuild()
+}
+
+// Path returns the path of the value under the cursor, e.g.
+// spec.template.spec.containers[0].image
+func (v *Viewer) Path() string {
+	if i := v.current(); i >= 0 {
+		return v.lines[i].path
+	}
+	return ""
+}
+
+// Breadcrumb returns the path under the cursor split at each level, e.g.
+// [spec template spec containers[0] image]
+func (v *Viewer) Breadcrumb() []string {
+	return splitPath(v.Path())
+}
+
+// splitPath splits a path at the dots that are not inside a quoted key
+func splitPath(path string) []string {
+	var parts []string
+	var cur strings.Builder
+	inQuote, escaped := false, false
+	for _, r := range path {
+		switch {
+		case inQuote:
+			cur.WriteRune(r)
+			if escaped {
+				escaped = false
+			} else if r == '\\' {
+				escaped = true
+			} else if r == '"' {
+				inQuote = false
+			}
+		case r == '"':
+			inQuote = true
+			cur.WriteRune(r)
+		case r == '.':
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
+
+// Render renders the rows in view, the cursor row highlighted
+func (v *Viewer) Render() string {
+	keyStyle := v.styles.Key
+	dim := v.styles.Status
+	cursorStyle := v.styles.Cursor
+
+	end := min(len(v.visible), v.offset+v.height)
+	rows := make([]string, 0, v.height)
+	for row := v.offset; row < end; row++ {
+		i := v.visible[row]
+		l := v.lines[i]
+		indent := strings.Repeat(" ", l.depth*indentWidth)
+		if row == v.cursor {
+			// Plain text on the cursor row so the background runs through
+			rows = append(rows, cursorStyle.Width(v.width).MaxWidth(v.width).Render(indent+v.plain(i)))
+			continue
+		}
+		var b strings.Builder
+		b.WriteString(indent + l.prefix)
+		switch {
+		case l.text:
+			b.WriteString(l.value)
+		case l.key != "":
+			b.WriteString(keyStyle.Render(l.key + ":"))
+			if l.value != "" {
+				b.WriteString(" " + l.value)
+			}
+		default:
+			b.WriteString(l.value)
+		}
+		if v.folded[i] {
+			b.WriteString(" " + dim.Render(v.foldNote(i)))
+		}
+		rows = append(rows, lipgloss.NewStyle().MaxWidth(v.width).Render(b.String()))
+	}
+	for len(rows) < v.height {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// plain renders a line without its indentation or styling
+func (v *Viewer) plain(i int) string {
+	l := v.lines[i]
+	s := l.prefix
+	switch {
+	case l.text:
+		s += l.value
+	case l.key != "":
+		s += l.key + ":"
+		if l.value != "" {
+			s += " " + l.value
+		}
+	default:
+		s += l.value
+	}
+	if v.folded[i] {
+		s += " " + v.foldNote(i)
+	}
+	return s
+}
+
+// foldNote describes what a folded line hides, e.g. "{…} 12 lines"
+func (v *Viewer) foldNote(i int) string {
+	n := v.lines[i].end - i
+	unit := "lines"
+	if n == 1 {
+		unit = "line"
+	}
+	return fmt.Sprintf("%s %d %s", v.lines[i].folded, n, unit)
+}
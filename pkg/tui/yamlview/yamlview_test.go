@@ -0,0 +1,119 @@
+package yamlview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"gopkg.in/yaml.v3"
+)
+
+const deployment = `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"web","labels":{"app.kubernetes.io/name":"web"}},
+"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web","image":"nginx","ports":[{"containerPort":80}]}]}}},
+"data":{"script":"echo a\necho b\n","port":"80"}}`
+
+func newViewer(t *testing.T, src string) *Viewer {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatal(err)
+	}
+	v := New(&doc)
+	v.SetSize(80, 40)
+	return v
+}
+
+// moveTo puts the cursor on the first visible line with the given path
+func moveTo(t *testing.T, v *Viewer, path string) {
+	t.Helper()
+	v.Top()
+	for range v.visible {
+		if v.Path() == path {
+			return
+		}
+		v.Move(1)
+	}
+	t.Fatalf("no visible line with path %s", path)
+}
+
+func TestViewer_RendersYAMLInDocumentOrder(t *testing.T) {
+	v := newViewer(t, deployment)
+	got := ansi.Strip(v.Render())
+	want := strings.Join([]string{
+		"apiVersion: apps/v1",
+		"kind: Deployment",
+		"metadata:",
+		"  name: web",
+		"  labels:",
+		"    app.kubernetes.io/name: web",
+		"spec:",
+		"  replicas: 2",
+		"  template:",
+		"    spec:",
+		"      containers:",
+		"        - name: web",
+		"          image: nginx",
+		"          ports:",
+		"            - containerPort: 80",
+		"data:",
+		"  script: |",
+		"    echo a",
+		"    echo b",
+		`  port: "80"`,
+	}, "\n")
+	if lines := strings.Split(got, "\n"); strings.Join(trimRight(lines[:20]), "\n") != want {
+		t.Errorf("unexpected rendering:\n%s", got)
+	}
+}
+
+func trimRight(lines []string) []string {
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return lines
+}
+
+func TestViewer_PathsAndBreadcrumb(t *testing.T) {
+	v := newViewer(t, deployment)
+	moveTo(t, v, "spec.template.spec.containers[0].image")
+	if got := strings.Join(v.Breadcrumb(), " › "); got != "spec › template › spec › containers[0] › image" {
+		t.Errorf("breadcrumb = %q", got)
+	}
+	moveTo(t, v, `metadata.labels["app.kubernetes.io/name"]`)
+	if got := v.Breadcrumb(); len(got) != 2 || got[1] != `labels["app.kubernetes.io/name"]` {
+		t.Errorf("a quoted key should stay in one piece, got %q", got)
+	}
+}
+
+func TestViewer_Folding(t *testing.T) {
+	v := newViewer(t, deployment)
+	moveTo(t, v, "spec.template.spec.containers")
+	v.Fold()
+	out := ansi.Strip(v.Render())
+	if !strings.Contains(out, "containers: […] 4 lines") || strings.Contains(out, "image: nginx") {
+		t.Errorf("expected the containers folded:\n%s", out)
+	}
+
+	// Folding a folded line moves to the enclosing one
+	v.Fold()
+	if v.Path() != "spec.template.spec" {
+		t.Errorf("expected the cursor on the parent, got %s", v.Path())
+	}
+	v.Move(1)
+	v.Unfold()
+	if !strings.Contains(ansi.Strip(v.Render()), "image: nginx") {
+		t.Error("unfolding should show the containers again")
+	}
+
+	// Folding hides the cursor's line: the cursor stays on the fold
+	moveTo(t, v, "spec.replicas")
+	v.FoldAll()
+	if v.Path() != "spec" || len(v.visible) != 5 {
+		t.Errorf("fold all should leave the top-level keys, got %d lines, cursor on %s", len(v.visible), v.Path())
+	}
+	v.UnfoldAll()
+	if len(v.visible) != v.LineCount() {
+		t.Error("unfold all should show every line")
+	}
+}